@@ -0,0 +1,27 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+)
+
+const defaultAccessRequestMaxHours = 8
+
+// AccessRequestMaxHours JIT 권한 상승 요청으로 부여할 수 있는 최대 시간
+func AccessRequestMaxHours() int {
+	raw := os.Getenv("MC_IAM_MANAGER_ACCESS_REQUEST_MAX_HOURS")
+	if raw == "" {
+		return defaultAccessRequestMaxHours
+	}
+	hours, err := strconv.Atoi(raw)
+	if err != nil || hours <= 0 {
+		log.Printf(
+			"[WARN] invalid MC_IAM_MANAGER_ACCESS_REQUEST_MAX_HOURS=%q, using default %d",
+			raw,
+			defaultAccessRequestMaxHours,
+		)
+		return defaultAccessRequestMaxHours
+	}
+	return hours
+}
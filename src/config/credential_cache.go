@@ -0,0 +1,41 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+)
+
+const defaultTempCredentialMinRemainingSec = 300
+
+// TempCredentialCacheEnabled 임시 자격 증명 캐시 사용 여부 (기본 true)
+func TempCredentialCacheEnabled() bool {
+	raw := os.Getenv("MC_IAM_MANAGER_TEMP_CREDENTIAL_CACHE_ENABLED")
+	if raw == "" {
+		return true
+	}
+	enabled, err := strconv.ParseBool(raw)
+	if err != nil {
+		log.Printf("[WARN] invalid MC_IAM_MANAGER_TEMP_CREDENTIAL_CACHE_ENABLED=%q, cache enabled", raw)
+		return true
+	}
+	return enabled
+}
+
+// TempCredentialMinRemainingSec 캐시된 자격 증명을 재사용하기 위한 최소 잔여 유효 시간(초)
+func TempCredentialMinRemainingSec() int {
+	raw := os.Getenv("MC_IAM_MANAGER_TEMP_CREDENTIAL_MIN_REMAINING")
+	if raw == "" {
+		return defaultTempCredentialMinRemainingSec
+	}
+	seconds, err := strconv.Atoi(raw)
+	if err != nil || seconds < 0 {
+		log.Printf(
+			"[WARN] invalid MC_IAM_MANAGER_TEMP_CREDENTIAL_MIN_REMAINING=%q, using default %d",
+			raw,
+			defaultTempCredentialMinRemainingSec,
+		)
+		return defaultTempCredentialMinRemainingSec
+	}
+	return seconds
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// CspHealthCheckInterval CSP IdP 설정/계정 주기 헬스 체크 간격 (0이면 비활성화, 기본 300초)
+func CspHealthCheckInterval() time.Duration {
+	raw := os.Getenv("MC_IAM_MANAGER_CSP_HEALTH_CHECK_INTERVAL")
+	if raw == "" {
+		return 5 * time.Minute
+	}
+	seconds, err := strconv.Atoi(raw)
+	if err != nil || seconds < 0 {
+		log.Printf("[WARN] invalid MC_IAM_MANAGER_CSP_HEALTH_CHECK_INTERVAL=%q, using 300", raw)
+		return 5 * time.Minute
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// CspHealthRetention 헬스 체크 이력 보관 기간 (MC_IAM_MANAGER_CSP_HEALTH_RETENTION_DAYS, 0이면 삭제하지 않음, 기본 30일)
+func CspHealthRetention() time.Duration {
+	raw := os.Getenv("MC_IAM_MANAGER_CSP_HEALTH_RETENTION_DAYS")
+	if raw == "" {
+		return 30 * 24 * time.Hour
+	}
+	days, err := strconv.Atoi(raw)
+	if err != nil || days < 0 {
+		log.Printf("[WARN] invalid MC_IAM_MANAGER_CSP_HEALTH_RETENTION_DAYS=%q, using 30", raw)
+		return 30 * 24 * time.Hour
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
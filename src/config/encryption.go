@@ -0,0 +1,118 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/m-cmp/mc-iam-manager/pkg/envelope"
+)
+
+const defaultEncryptionKeyringFile = "mciam-keyring.json"
+
+// EncryptionProviderName 저장 시 암호화 키 제공자 (file | none). 기본 file
+func EncryptionProviderName() string {
+	if name := strings.ToLower(os.Getenv("MC_IAM_MANAGER_ENCRYPTION_PROVIDER")); name != "" {
+		return name
+	}
+	return "file"
+}
+
+// EncryptionKeyringCreateAllowed 키 파일이 없을 때 새로 생성해 기동할지 여부 (개발용)
+// (MC_IAM_MANAGER_ENCRYPTION_KEYRING_CREATE, 기본 false)
+func EncryptionKeyringCreateAllowed() bool {
+	raw := os.Getenv("MC_IAM_MANAGER_ENCRYPTION_KEYRING_CREATE")
+	if raw == "" {
+		return false
+	}
+	allowed, err := strconv.ParseBool(raw)
+	if err != nil {
+		log.Printf("[WARN] invalid MC_IAM_MANAGER_ENCRYPTION_KEYRING_CREATE=%q, keyring creation disabled", raw)
+		return false
+	}
+	return allowed
+}
+
+// EncryptionKeyringFile 로컬 키 파일 경로 (MC_IAM_MANAGER_ENCRYPTION_KEYRING_FILE)
+// 미설정 시 키 생성을 허용한 개발 환경에서만 작업 디렉터리의 기본 파일을 사용한다.
+func EncryptionKeyringFile() string {
+	if path := os.Getenv("MC_IAM_MANAGER_ENCRYPTION_KEYRING_FILE"); path != "" {
+		return path
+	}
+	if EncryptionKeyringCreateAllowed() {
+		return defaultEncryptionKeyringFile
+	}
+	return ""
+}
+
+// NewEncryptionKeyProvider 설정에 따른 KeyProvider 생성. none이면 nil(평문 저장)
+// file 제공자는 키 파일이 없으면 기동을 중단한다. 키를 잃으면 암호화된 데이터를 복호화할 수 없으므로
+// 키 파일은 영구 볼륨에 두고 init-keys 명령으로 최초 1회 생성한다 (MC_IAM_MANAGER_ENCRYPTION_KEYRING_CREATE=true면 자동 생성, 개발용).
+func NewEncryptionKeyProvider() (envelope.KeyProvider, error) {
+	switch name := EncryptionProviderName(); name {
+	case "none":
+		log.Printf("[WARN] MC_IAM_MANAGER_ENCRYPTION_PROVIDER=none, secrets are stored in plaintext")
+		return nil, nil
+	case "file":
+		path := EncryptionKeyringFile()
+		if path == "" {
+			return nil, fmt.Errorf("MC_IAM_MANAGER_ENCRYPTION_KEYRING_FILE is required (set MC_IAM_MANAGER_ENCRYPTION_KEYRING_CREATE=true to generate a local keyring in development)")
+		}
+		create := EncryptionKeyringCreateAllowed()
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			if !create {
+				return nil, fmt.Errorf("encryption keyring %s not found (run `mc-iam-manager init-keys` once, or restore the keyring from backup)", path)
+			}
+			log.Printf("[WARN] encryption keyring %s not found, generating a new one (back it up; encrypted data is unreadable without it)", path)
+		}
+		provider, err := envelope.NewFileKeyProvider(path, create)
+		if err != nil {
+			return nil, err
+		}
+		return provider, nil
+	default:
+		return nil, fmt.Errorf("unsupported MC_IAM_MANAGER_ENCRYPTION_PROVIDER: %s", name)
+	}
+}
+
+// InitEncryptionKeyring 키 파일이 없으면 새로 생성 (init-keys 명령). 이미 있으면 그대로 둔다
+func InitEncryptionKeyring() error {
+	if EncryptionProviderName() != "file" {
+		return nil
+	}
+	path := os.Getenv("MC_IAM_MANAGER_ENCRYPTION_KEYRING_FILE")
+	if path == "" {
+		return fmt.Errorf("MC_IAM_MANAGER_ENCRYPTION_KEYRING_FILE is required")
+	}
+	if _, err := os.Stat(path); err == nil {
+		log.Printf("Encryption keyring %s already exists", path)
+		return nil
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create keyring directory: %w", err)
+	}
+	provider, err := envelope.NewFileKeyProvider(path, true)
+	if err != nil {
+		return err
+	}
+	log.Printf("Generated encryption keyring %s (active key: %s). Back it up; encrypted data is unreadable without it", path, provider.ActiveKeyID())
+	return nil
+}
+
+// InitEncryption 전역 KeyProvider 설정 (GORM encrypted serializer 사용)
+func InitEncryption() (envelope.KeyProvider, error) {
+	provider, err := NewEncryptionKeyProvider()
+	if err != nil {
+		return nil, err
+	}
+	envelope.SetDefault(provider)
+	if provider != nil {
+		log.Printf("Encryption at rest enabled (provider: %s, active key: %s)", EncryptionProviderName(), provider.ActiveKeyID())
+	}
+	return provider, nil
+}
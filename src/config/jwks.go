@@ -0,0 +1,145 @@
+package config
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+const (
+	// jwksCacheTTL 알려진 kid라도 이 시간이 지나면 재조회 (폐기된 키 정리)
+	jwksCacheTTL = time.Hour
+	// jwksMinRefreshInterval 모르는 kid로 인한 재조회 최소 간격 (위조 kid 요청으로 Keycloak을 과도하게 호출하지 않도록)
+	jwksMinRefreshInterval = 10 * time.Second
+)
+
+// ErrUnknownSigningKey JWKS에 없는 kid
+var ErrUnknownSigningKey = errors.New("unknown signing key id")
+
+// JWKSCache Keycloak realm 서명 공개키(JWKS) 캐시. kid 단위로 조회하며 모르는 kid가 오면 재조회한다.
+type JWKSCache struct {
+	fetch func(ctx context.Context) ([]gocloak.CertResponseKey, error)
+	now   func() time.Time
+
+	mu          sync.RWMutex
+	keys        map[string]*rsa.PublicKey
+	fetchedAt   time.Time
+	refreshMu   sync.Mutex // 동시 재조회 방지
+	lastAttempt time.Time
+}
+
+// NewJWKSCache fetch로 JWKS를 조회하는 새 JWKSCache 생성
+func NewJWKSCache(fetch func(ctx context.Context) ([]gocloak.CertResponseKey, error)) *JWKSCache {
+	return &JWKSCache{fetch: fetch, now: time.Now, keys: map[string]*rsa.PublicKey{}}
+}
+
+// GetKey kid에 해당하는 RSA 공개키 반환
+// 캐시에 없거나 TTL이 지났으면 재조회하며, 재조회 실패 시 캐시된 키가 있으면 그 키를 사용한다.
+func (c *JWKSCache) GetKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	key, fresh := c.lookup(kid)
+	if key != nil && fresh {
+		return key, nil
+	}
+
+	if err := c.refresh(ctx); err != nil {
+		if key != nil {
+			log.Printf("[WARN] JWKS refresh failed, using cached key kid=%s: %v", kid, err)
+			return key, nil
+		}
+		return nil, err
+	}
+	if key, _ = c.lookup(kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownSigningKey, kid)
+}
+
+func (c *JWKSCache) lookup(kid string) (*rsa.PublicKey, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.keys[kid], c.now().Sub(c.fetchedAt) < jwksCacheTTL
+}
+
+// refresh JWKS 재조회 (jwksMinRefreshInterval 이내 재시도는 건너뜀)
+func (c *JWKSCache) refresh(ctx context.Context) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	now := c.now()
+	if !c.lastAttempt.IsZero() && now.Sub(c.lastAttempt) < jwksMinRefreshInterval {
+		return nil
+	}
+	c.lastAttempt = now
+
+	certs, err := c.fetch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get Keycloak certs: %w", err)
+	}
+	keys := make(map[string]*rsa.PublicKey, len(certs))
+	for _, cert := range certs {
+		kid, key, err := rsaKeyFromCert(cert)
+		if err != nil {
+			log.Printf("[DEBUG] skipping JWKS key: %v", err)
+			continue
+		}
+		keys[kid] = key
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.fetchedAt = now
+	c.mu.Unlock()
+	log.Printf("[DEBUG] JWKS refreshed: %d signing keys", len(keys))
+	return nil
+}
+
+// rsaKeyFromCert 서명용 RSA JWK를 공개키로 변환
+func rsaKeyFromCert(cert gocloak.CertResponseKey) (string, *rsa.PublicKey, error) {
+	if cert.Kid == nil || *cert.Kid == "" {
+		return "", nil, fmt.Errorf("missing kid")
+	}
+	kid := *cert.Kid
+	if cert.Kty == nil || *cert.Kty != "RSA" {
+		return "", nil, fmt.Errorf("kid=%s: not an RSA key", kid)
+	}
+	if cert.Use != nil && *cert.Use != "sig" {
+		return "", nil, fmt.Errorf("kid=%s: not a signing key (use=%s)", kid, *cert.Use)
+	}
+	if cert.N == nil || cert.E == nil {
+		return "", nil, fmt.Errorf("kid=%s: missing modulus or exponent", kid)
+	}
+	n, err := base64.RawURLEncoding.DecodeString(*cert.N)
+	if err != nil {
+		return "", nil, fmt.Errorf("kid=%s: failed to decode modulus: %w", kid, err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(*cert.E)
+	if err != nil {
+		return "", nil, fmt.Errorf("kid=%s: failed to decode exponent: %w", kid, err)
+	}
+	return kid, &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+}
+
+// GetSigningKey kid에 해당하는 realm 서명 공개키 (JWKS 캐시 사용)
+func (kc *KeycloakConfig) GetSigningKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	kc.jwksOnce.Do(func() {
+		kc.jwks = NewJWKSCache(func(ctx context.Context) ([]gocloak.CertResponseKey, error) {
+			certs, err := kc.Client.GetCerts(ctx, kc.Realm)
+			if err != nil {
+				return nil, err
+			}
+			if certs.Keys == nil {
+				return nil, nil
+			}
+			return *certs.Keys, nil
+		})
+	})
+	return kc.jwks.GetKey(ctx, kid)
+}
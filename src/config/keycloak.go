@@ -18,6 +18,7 @@ import (
 
 // KeycloakConfig Keycloak 설정
 type KeycloakConfig struct {
+	CompanyID   uint // 멀티테넌트 모드에서 realm을 소유한 회사 (mcmp_companies.id)
 	Realm       string
 	Host        string
 	ExternalURL string
@@ -25,6 +26,8 @@ type KeycloakConfig struct {
 	adminToken  *gocloak.JWT
 	tokenExpiry time.Time
 	tokenMutex  sync.RWMutex
+	jwks        *JWKSCache
+	jwksOnce    sync.Once
 
 	ClientName       string
 	ClientID         string
@@ -120,7 +123,7 @@ func InitKeycloak() error {
 	keycloakAdmin := os.Getenv("MC_IAM_MANAGER_KEYCLOAK_ADMIN")
 	fmt.Printf("MC_IAM_MANAGER_KEYCLOAK_ADMIN: %s\n", keycloakAdmin)
 
-	client := gocloak.NewClient(host)
+	client := instrumentKeycloakClient(gocloak.NewClient(host))
 
 	KC = &KeycloakConfig{
 		Realm:       realm,
@@ -177,7 +180,7 @@ func (kc *KeycloakConfig) GetUserInfo(ctx context.Context, accessToken string) (
 
 // NewKeycloakClient 함수 정의
 func NewKeycloakClient(config *KeycloakConfig) *gocloak.GoCloak {
-	return gocloak.NewClient(config.Host)
+	return instrumentKeycloakClient(gocloak.NewClient(config.Host))
 }
 
 // LoginUser 사용자 로그인을 수행하고 토큰을 반환합니다.
@@ -221,6 +224,7 @@ func (kc *KeycloakConfig) LoginAdmin(ctx context.Context) (*gocloak.JWT, error)
 }
 
 // GetPublicKey는 Keycloak의 공개키를 가져옵니다.
+// Deprecated: 첫 번째 RS256 키만 사용하므로 키 교체 시 실패합니다. GetSigningKey를 사용하세요.
 func (kc *KeycloakConfig) GetPublicKey() (interface{}, error) {
 	ctx := context.Background()
 
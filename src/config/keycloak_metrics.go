@@ -0,0 +1,40 @@
+package config
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/go-resty/resty/v2"
+	"github.com/m-cmp/mc-iam-manager/pkg/metrics"
+)
+
+// instrumentKeycloakClient gocloak 내부 resty 클라이언트에 Keycloak API 지연 시간 지표 훅 등록
+// keycloakService의 admin API 호출과 토큰 발급이 모두 이 클라이언트를 거친다.
+func instrumentKeycloakClient(client *gocloak.GoCloak) *gocloak.GoCloak {
+	rc := client.RestyClient()
+	rc.OnAfterResponse(func(_ *resty.Client, resp *resty.Response) error {
+		metrics.ObserveKeycloakRequest(resp.Request.Method, keycloakRequestPath(resp.Request), resp.StatusCode(), resp.Time())
+		return nil
+	})
+	rc.OnError(func(req *resty.Request, err error) {
+		// 응답을 받은 경우는 OnAfterResponse에서 이미 기록됨
+		var respErr *resty.ResponseError
+		if errors.As(err, &respErr) {
+			return
+		}
+		metrics.ObserveKeycloakRequest(req.Method, keycloakRequestPath(req), 0, time.Since(req.Time))
+	})
+	return client
+}
+
+func keycloakRequestPath(req *resty.Request) string {
+	if req.RawRequest != nil && req.RawRequest.URL != nil {
+		return req.RawRequest.URL.Path
+	}
+	if u, err := url.Parse(req.URL); err == nil {
+		return u.Path
+	}
+	return ""
+}
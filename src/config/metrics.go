@@ -0,0 +1,11 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// MetricsToken /metrics 조회에 요구할 Bearer 토큰 (MC_IAM_MANAGER_METRICS_TOKEN, 미설정 시 비활성)
+func MetricsToken() string {
+	return strings.TrimSpace(os.Getenv("MC_IAM_MANAGER_METRICS_TOKEN"))
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultOffboardingGraceDays   = 14
+	defaultOffboardingIntervalSec = 300
+)
+
+// OffboardingGraceDays 오프보딩 예약 후 최종 실행까지의 기본 유예 기간(일)
+func OffboardingGraceDays() int {
+	raw := os.Getenv("MC_IAM_MANAGER_OFFBOARDING_GRACE_DAYS")
+	if raw == "" {
+		return defaultOffboardingGraceDays
+	}
+	days, err := strconv.Atoi(raw)
+	if err != nil || days < 0 {
+		log.Printf("[WARN] invalid MC_IAM_MANAGER_OFFBOARDING_GRACE_DAYS=%q, using default %d", raw, defaultOffboardingGraceDays)
+		return defaultOffboardingGraceDays
+	}
+	return days
+}
+
+// OffboardingInterval 유예 기간이 지난 오프보딩 실행 주기 (0이면 예약 실행 비활성화)
+func OffboardingInterval() time.Duration {
+	raw := os.Getenv("MC_IAM_MANAGER_OFFBOARDING_INTERVAL")
+	if raw == "" {
+		return defaultOffboardingIntervalSec * time.Second
+	}
+	seconds, err := strconv.Atoi(raw)
+	if err != nil || seconds < 0 {
+		log.Printf("[WARN] invalid MC_IAM_MANAGER_OFFBOARDING_INTERVAL=%q, using default %d", raw, defaultOffboardingIntervalSec)
+		return defaultOffboardingIntervalSec * time.Second
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 프로젝트 동기화 시 mc-infra-manager에서 사라진 네임스페이스(orphan) 처리 정책
+const (
+	ProjectSyncOrphanKeep     = "keep"     // 보고만 하고 유지
+	ProjectSyncOrphanUnassign = "unassign" // 워크스페이스 할당 해제
+	ProjectSyncOrphanDelete   = "delete"   // 로컬 프로젝트 삭제
+)
+
+// ProjectSyncInterval mc-infra-manager 프로젝트 주기 동기화 간격 (0이면 비활성화, 기본 0)
+func ProjectSyncInterval() time.Duration {
+	raw := os.Getenv("MC_IAM_MANAGER_PROJECT_SYNC_INTERVAL")
+	if raw == "" {
+		return 0
+	}
+	seconds, err := strconv.Atoi(raw)
+	if err != nil || seconds < 0 {
+		log.Printf("[WARN] invalid MC_IAM_MANAGER_PROJECT_SYNC_INTERVAL=%q, project sync disabled", raw)
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// ProjectSyncDryRun true이면 차이만 계산하고 DB는 변경하지 않음 (기본 false)
+func ProjectSyncDryRun() bool {
+	raw := os.Getenv("MC_IAM_MANAGER_PROJECT_SYNC_DRY_RUN")
+	if raw == "" {
+		return false
+	}
+	dryRun, err := strconv.ParseBool(raw)
+	if err != nil {
+		log.Printf("[WARN] invalid MC_IAM_MANAGER_PROJECT_SYNC_DRY_RUN=%q, dry-run enabled", raw)
+		return true
+	}
+	return dryRun
+}
+
+// ProjectSyncWorkspace 새로 발견된/미할당 프로젝트를 자동 할당할 워크스페이스 이름
+// 미설정 시 MC_IAM_MANAGER_DEFAULT_WORKSPACE_NAME (없으면 "default")
+func ProjectSyncWorkspace() string {
+	if name := strings.TrimSpace(os.Getenv("MC_IAM_MANAGER_PROJECT_SYNC_WORKSPACE")); name != "" {
+		return name
+	}
+	if name := strings.TrimSpace(os.Getenv("MC_IAM_MANAGER_DEFAULT_WORKSPACE_NAME")); name != "" {
+		return name
+	}
+	return "default"
+}
+
+// ProjectSyncOrphanPolicy orphan 프로젝트 처리 정책 (keep, unassign, delete; 기본 keep)
+func ProjectSyncOrphanPolicy() string {
+	raw := strings.ToLower(strings.TrimSpace(os.Getenv("MC_IAM_MANAGER_PROJECT_SYNC_ORPHAN_POLICY")))
+	switch raw {
+	case "":
+		return ProjectSyncOrphanKeep
+	case ProjectSyncOrphanKeep, ProjectSyncOrphanUnassign, ProjectSyncOrphanDelete:
+		return raw
+	default:
+		log.Printf("[WARN] invalid MC_IAM_MANAGER_PROJECT_SYNC_ORPHAN_POLICY=%q, using %s", raw, ProjectSyncOrphanKeep)
+		return ProjectSyncOrphanKeep
+	}
+}
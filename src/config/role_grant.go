@@ -0,0 +1,28 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+const defaultRoleGrantReapIntervalSec = 60
+
+// RoleGrantReapInterval 만료된 역할 할당 회수 주기 (0이면 회수 작업 비활성화)
+func RoleGrantReapInterval() time.Duration {
+	raw := os.Getenv("MC_IAM_MANAGER_ROLE_GRANT_REAP_INTERVAL")
+	if raw == "" {
+		return defaultRoleGrantReapIntervalSec * time.Second
+	}
+	seconds, err := strconv.Atoi(raw)
+	if err != nil || seconds < 0 {
+		log.Printf(
+			"[WARN] invalid MC_IAM_MANAGER_ROLE_GRANT_REAP_INTERVAL=%q, using default %d",
+			raw,
+			defaultRoleGrantReapIntervalSec,
+		)
+		return defaultRoleGrantReapIntervalSec * time.Second
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,11 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// ScimToken /scim/v2 프로비저닝 요청에 요구할 Bearer 토큰 (MC_IAM_MANAGER_SCIM_TOKEN, 미설정 시 SCIM 비활성)
+func ScimToken() string {
+	return strings.TrimSpace(os.Getenv("MC_IAM_MANAGER_SCIM_TOKEN"))
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// ApiKeyDefaultTTL 만료 시각 없이 발급한 서비스 계정 API 키의 유효 기간
+// (MC_IAM_MANAGER_API_KEY_DEFAULT_TTL_DAYS, 0이면 만료 없음, 기본 90일)
+func ApiKeyDefaultTTL() time.Duration {
+	raw := os.Getenv("MC_IAM_MANAGER_API_KEY_DEFAULT_TTL_DAYS")
+	if raw == "" {
+		return 90 * 24 * time.Hour
+	}
+	days, err := strconv.Atoi(raw)
+	if err != nil || days < 0 {
+		log.Printf("[WARN] invalid MC_IAM_MANAGER_API_KEY_DEFAULT_TTL_DAYS=%q, using 90", raw)
+		return 90 * 24 * time.Hour
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
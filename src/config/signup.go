@@ -0,0 +1,42 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// SignupEmailVerificationRequired 셀프 가입 시 이메일 인증을 먼저 요구할지 여부
+// (MC_IAM_MANAGER_SIGNUP_REQUIRE_EMAIL_VERIFICATION, 기본 false)
+func SignupEmailVerificationRequired() bool {
+	raw := os.Getenv("MC_IAM_MANAGER_SIGNUP_REQUIRE_EMAIL_VERIFICATION")
+	if raw == "" {
+		return false
+	}
+	required, err := strconv.ParseBool(raw)
+	if err != nil {
+		log.Printf("[WARN] invalid MC_IAM_MANAGER_SIGNUP_REQUIRE_EMAIL_VERIFICATION=%q, verification disabled", raw)
+		return false
+	}
+	return required
+}
+
+// SignupAutoApproveDomains 관리자 승인 없이 가입을 승인할 이메일 도메인 목록
+// (MC_IAM_MANAGER_SIGNUP_AUTO_APPROVE_DOMAINS, 쉼표 구분, 소문자)
+func SignupAutoApproveDomains() []string {
+	var domains []string
+	for _, domain := range strings.Split(os.Getenv("MC_IAM_MANAGER_SIGNUP_AUTO_APPROVE_DOMAINS"), ",") {
+		domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "@"))
+		if domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+	return domains
+}
+
+// SignupDefaultPlatformRole 가입 승인 시 역할을 지정하지 않으면 부여할 플랫폼 역할
+// (MC_IAM_MANAGER_SIGNUP_DEFAULT_PLATFORM_ROLE, 미설정 시 역할 부여 없음)
+func SignupDefaultPlatformRole() string {
+	return strings.TrimSpace(os.Getenv("MC_IAM_MANAGER_SIGNUP_DEFAULT_PLATFORM_ROLE"))
+}
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// TenantHeader 토큰이 없는 요청(로그인 등)에서 대상 회사(realm)를 지정하는 헤더
+const TenantHeader = "X-Tenant"
+
+// MultiTenantEnabled MC_IAM_MANAGER_MULTI_TENANT=true 이면 회사(Company)마다 별도 Keycloak realm/클라이언트를 사용한다.
+// 비활성 시 모든 요청은 MC_IAM_MANAGER_KEYCLOAK_REALM 하나(KC)로 처리한다.
+func MultiTenantEnabled() bool {
+	return strings.EqualFold(os.Getenv("MC_IAM_MANAGER_MULTI_TENANT"), "true")
+}
+
+// tenantRegistry realm 이름 → 회사별 Keycloak 설정
+var tenantRegistry = struct {
+	sync.RWMutex
+	byRealm map[string]*KeycloakConfig
+}{byRealm: map[string]*KeycloakConfig{}}
+
+// RegisterTenant 회사 realm/클라이언트를 등록하고 해당 Keycloak 설정 반환
+// 기본 realm(KC.Realm)은 KC에 회사 ID만 기록한다. 호스트와 gocloak 클라이언트는 KC와 공유한다.
+func RegisterTenant(companyID uint, realm, clientID, clientSecret string) *KeycloakConfig {
+	tenantRegistry.Lock()
+	defer tenantRegistry.Unlock()
+
+	if KC != nil && realm == KC.Realm {
+		KC.CompanyID = companyID
+		tenantRegistry.byRealm[realm] = KC
+		return KC
+	}
+	kc := &KeycloakConfig{
+		CompanyID:        companyID,
+		Realm:            realm,
+		ClientName:       clientID,
+		ClientSecret:     clientSecret,
+		OIDCClientID:     clientID,
+		OIDCClientName:   clientID,
+		OIDCClientSecret: clientSecret,
+	}
+	if KC != nil {
+		kc.Host = KC.Host
+		kc.ExternalURL = KC.ExternalURL
+		kc.Client = KC.Client
+	}
+	tenantRegistry.byRealm[realm] = kc
+	return kc
+}
+
+// UnregisterTenant 비활성화된 회사 realm 등록 해제 (기본 realm은 해제하지 않음)
+func UnregisterTenant(realm string) {
+	if KC != nil && realm == KC.Realm {
+		return
+	}
+	tenantRegistry.Lock()
+	delete(tenantRegistry.byRealm, realm)
+	tenantRegistry.Unlock()
+}
+
+// KeycloakForRealm 등록된 realm의 Keycloak 설정 (없으면 nil, 기본 realm은 항상 KC)
+func KeycloakForRealm(realm string) *KeycloakConfig {
+	tenantRegistry.RLock()
+	defer tenantRegistry.RUnlock()
+	if kc, ok := tenantRegistry.byRealm[realm]; ok {
+		return kc
+	}
+	if KC != nil && realm != "" && realm == KC.Realm {
+		return KC
+	}
+	return nil
+}
+
+// Tenants 사용자를 관리하는 모든 realm의 Keycloak 설정 (realm 이름순). 단일 테넌트 모드에서는 KC 하나
+func Tenants() []*KeycloakConfig {
+	if !MultiTenantEnabled() {
+		if KC == nil {
+			return nil
+		}
+		return []*KeycloakConfig{KC}
+	}
+	tenantRegistry.RLock()
+	defer tenantRegistry.RUnlock()
+	tenants := make([]*KeycloakConfig, 0, len(tenantRegistry.byRealm)+1)
+	if KC != nil {
+		if _, ok := tenantRegistry.byRealm[KC.Realm]; !ok {
+			tenants = append(tenants, KC)
+		}
+	}
+	for _, kc := range tenantRegistry.byRealm {
+		tenants = append(tenants, kc)
+	}
+	sort.Slice(tenants, func(i, j int) bool { return tenants[i].Realm < tenants[j].Realm })
+	return tenants
+}
+
+type tenantContextKey struct{}
+
+// WithKeycloak 요청 컨텍스트에 회사별 Keycloak 설정 저장
+func WithKeycloak(ctx context.Context, kc *KeycloakConfig) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, kc)
+}
+
+// KeycloakFor 요청 컨텍스트의 회사별 Keycloak 설정. 지정되지 않았으면 기본 설정(KC)
+func KeycloakFor(ctx context.Context) *KeycloakConfig {
+	if ctx != nil {
+		if kc, ok := ctx.Value(tenantContextKey{}).(*KeycloakConfig); ok && kc != nil {
+			return kc
+		}
+	}
+	return KC
+}
+
+// RealmFromIssuer iss(.../realms/<realm>)에서 realm 이름 추출
+func RealmFromIssuer(issuer string) string {
+	_, realm, ok := strings.Cut(strings.TrimRight(issuer, "/"), "/realms/")
+	if !ok || strings.Contains(realm, "/") {
+		return ""
+	}
+	return realm
+}
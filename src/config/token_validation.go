@@ -0,0 +1,87 @@
+package config
+
+import (
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TokenValidationAny 검증을 생략하는 값 (MC_IAM_MANAGER_TOKEN_ISSUERS / MC_IAM_MANAGER_TOKEN_AUDIENCES)
+const TokenValidationAny = "*"
+
+// TokenIssuers 허용할 access token 발급자(iss) 목록
+// MC_IAM_MANAGER_TOKEN_ISSUERS(쉼표 구분) 미설정 시 Keycloak 내부/외부 URL 기준 realm issuer
+func TokenIssuers() []string {
+	return TokenIssuersFor(KC)
+}
+
+// TokenIssuersFor 회사별 realm의 허용 발급자 목록. MC_IAM_MANAGER_TOKEN_ISSUERS는 기본 realm(KC)에만 적용한다.
+func TokenIssuersFor(kc *KeycloakConfig) []string {
+	if kc == nil {
+		return nil
+	}
+	if kc == KC {
+		if issuers := splitList(os.Getenv("MC_IAM_MANAGER_TOKEN_ISSUERS")); len(issuers) > 0 {
+			return issuers
+		}
+	}
+	var issuers []string
+	for _, host := range []string{kc.Host, kc.ExternalURL} {
+		if host = strings.TrimRight(host, "/"); host != "" {
+			issuers = append(issuers, host+"/realms/"+kc.Realm)
+		}
+	}
+	return issuers
+}
+
+// TokenAudiences 허용할 aud/azp 값 목록 (aud에 포함되거나 azp가 일치하면 통과)
+// MC_IAM_MANAGER_TOKEN_AUDIENCES(쉼표 구분) 미설정 시 IAM Manager 클라이언트와 OIDC 클라이언트
+func TokenAudiences() []string {
+	return TokenAudiencesFor(KC)
+}
+
+// TokenAudiencesFor 회사별 realm의 허용 aud/azp 목록. MC_IAM_MANAGER_TOKEN_AUDIENCES는 기본 realm(KC)에만 적용한다.
+func TokenAudiencesFor(kc *KeycloakConfig) []string {
+	if kc == nil {
+		return nil
+	}
+	if kc == KC {
+		if audiences := splitList(os.Getenv("MC_IAM_MANAGER_TOKEN_AUDIENCES")); len(audiences) > 0 {
+			return audiences
+		}
+	}
+	var audiences []string
+	for _, client := range []string{kc.ClientName, kc.OIDCClientName} {
+		if client != "" && !slices.Contains(audiences, client) {
+			audiences = append(audiences, client)
+		}
+	}
+	return audiences
+}
+
+// TokenClockSkew exp/nbf/iat 검증 시 허용할 시계 오차 (MC_IAM_MANAGER_TOKEN_CLOCK_SKEW 초, 기본 30)
+func TokenClockSkew() time.Duration {
+	raw := os.Getenv("MC_IAM_MANAGER_TOKEN_CLOCK_SKEW")
+	if raw == "" {
+		return 30 * time.Second
+	}
+	seconds, err := strconv.Atoi(raw)
+	if err != nil || seconds < 0 {
+		log.Printf("[WARN] invalid MC_IAM_MANAGER_TOKEN_CLOCK_SKEW=%q, using 30", raw)
+		return 30 * time.Second
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func splitList(raw string) []string {
+	var values []string
+	for _, v := range strings.Split(raw, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
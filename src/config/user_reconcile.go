@@ -0,0 +1,36 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// UserReconcileInterval Keycloak ↔ DB 사용자 정합성 점검 주기 (0이면 비활성화, 기본 0)
+func UserReconcileInterval() time.Duration {
+	raw := os.Getenv("MC_IAM_MANAGER_USER_RECONCILE_INTERVAL")
+	if raw == "" {
+		return 0
+	}
+	seconds, err := strconv.Atoi(raw)
+	if err != nil || seconds < 0 {
+		log.Printf("[WARN] invalid MC_IAM_MANAGER_USER_RECONCILE_INTERVAL=%q, user reconciliation disabled", raw)
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// UserReconcileFix true이면 주기 점검에서 발견한 불일치를 바로잡음. false이면 보고만 한다 (기본 false)
+func UserReconcileFix() bool {
+	raw := os.Getenv("MC_IAM_MANAGER_USER_RECONCILE_FIX")
+	if raw == "" {
+		return false
+	}
+	fix, err := strconv.ParseBool(raw)
+	if err != nil {
+		log.Printf("[WARN] invalid MC_IAM_MANAGER_USER_RECONCILE_FIX=%q, reporting only", raw)
+		return false
+	}
+	return fix
+}
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+const (
+	defaultWorkspaceTicketLifespanSec = 1800
+	defaultWorkspaceTicketIssuer      = "mc-iam-manager"
+)
+
+// WorkspaceTicketSigner 워크스페이스 티켓 서명 키 정보
+type WorkspaceTicketSigner struct {
+	PrivateKey *rsa.PrivateKey
+	KeyID      string
+}
+
+var (
+	workspaceTicketSigner     *WorkspaceTicketSigner
+	workspaceTicketSignerErr  error
+	workspaceTicketSignerOnce sync.Once
+)
+
+// WorkspaceTicketLifespanSec returns workspace ticket TTL in seconds.
+func WorkspaceTicketLifespanSec() int {
+	raw := os.Getenv("MC_IAM_MANAGER_WORKSPACE_TICKET_LIFESPAN")
+	if raw == "" {
+		return defaultWorkspaceTicketLifespanSec
+	}
+	seconds, err := strconv.Atoi(raw)
+	if err != nil || seconds < 60 {
+		log.Printf(
+			"[WARN] invalid MC_IAM_MANAGER_WORKSPACE_TICKET_LIFESPAN=%q, using default %d",
+			raw,
+			defaultWorkspaceTicketLifespanSec,
+		)
+		return defaultWorkspaceTicketLifespanSec
+	}
+	return seconds
+}
+
+// WorkspaceTicketIssuer 워크스페이스 티켓 iss 클레임 값
+func WorkspaceTicketIssuer() string {
+	if issuer := os.Getenv("MC_IAM_MANAGER_WORKSPACE_TICKET_ISSUER"); issuer != "" {
+		return issuer
+	}
+	return defaultWorkspaceTicketIssuer
+}
+
+// WorkspaceTicketEphemeralKeyAllowed 서명 키 파일 없이 임시 키로 기동 허용 여부 (개발용)
+// (MC_IAM_MANAGER_WORKSPACE_TICKET_EPHEMERAL_KEY, 기본 false)
+func WorkspaceTicketEphemeralKeyAllowed() bool {
+	raw := os.Getenv("MC_IAM_MANAGER_WORKSPACE_TICKET_EPHEMERAL_KEY")
+	if raw == "" {
+		return false
+	}
+	allowed, err := strconv.ParseBool(raw)
+	if err != nil {
+		log.Printf("[WARN] invalid MC_IAM_MANAGER_WORKSPACE_TICKET_EPHEMERAL_KEY=%q, ephemeral key disabled", raw)
+		return false
+	}
+	return allowed
+}
+
+// ValidateWorkspaceTicketSigningKey 기동 시 서명 키 설정 확인
+// 임시 키는 재시작/다중 인스턴스마다 달라 발급한 티켓이 검증되지 않으므로 개발용으로 명시한 경우에만 허용한다.
+func ValidateWorkspaceTicketSigningKey() error {
+	if os.Getenv("MC_IAM_MANAGER_WORKSPACE_TICKET_SIGNING_KEY") == "" && !WorkspaceTicketEphemeralKeyAllowed() {
+		return fmt.Errorf("MC_IAM_MANAGER_WORKSPACE_TICKET_SIGNING_KEY is required (set MC_IAM_MANAGER_WORKSPACE_TICKET_EPHEMERAL_KEY=true to use an ephemeral key in development)")
+	}
+	_, err := GetWorkspaceTicketSigner()
+	return err
+}
+
+// InitWorkspaceTicketSigningKey 서명 키 파일이 없으면 RSA 개인키(PKCS#8 PEM)를 생성 (init-keys 명령). 이미 있으면 그대로 둔다
+func InitWorkspaceTicketSigningKey() error {
+	path := os.Getenv("MC_IAM_MANAGER_WORKSPACE_TICKET_SIGNING_KEY")
+	if path == "" {
+		if WorkspaceTicketEphemeralKeyAllowed() {
+			return nil
+		}
+		return fmt.Errorf("MC_IAM_MANAGER_WORKSPACE_TICKET_SIGNING_KEY is required")
+	}
+	if _, err := os.Stat(path); err == nil {
+		log.Printf("Workspace ticket signing key %s already exists", path)
+		return nil
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return fmt.Errorf("failed to generate workspace ticket signing key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create signing key directory: %w", err)
+	}
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		return fmt.Errorf("failed to write workspace ticket signing key %s: %w", path, err)
+	}
+	log.Printf("Generated workspace ticket signing key %s", path)
+	return nil
+}
+
+// GetWorkspaceTicketSigner 워크스페이스 티켓 서명 키 조회 (최초 1회 로드)
+// MC_IAM_MANAGER_WORKSPACE_TICKET_SIGNING_KEY 에 RSA 개인키(PEM) 파일 경로를 지정한다.
+// 미설정 시 임시 키를 생성하며, 이 경우 서버 재시작 시 기존 티켓은 모두 무효화된다 (기동 시 ValidateWorkspaceTicketSigningKey로 개발용에서만 허용).
+func GetWorkspaceTicketSigner() (*WorkspaceTicketSigner, error) {
+	workspaceTicketSignerOnce.Do(func() {
+		var key *rsa.PrivateKey
+		path := os.Getenv("MC_IAM_MANAGER_WORKSPACE_TICKET_SIGNING_KEY")
+		if path != "" {
+			key, workspaceTicketSignerErr = loadRSAPrivateKey(path)
+			if workspaceTicketSignerErr != nil {
+				return
+			}
+		} else {
+			log.Printf("[WARN] MC_IAM_MANAGER_WORKSPACE_TICKET_SIGNING_KEY not set, generating ephemeral signing key (tickets are invalidated on restart)")
+			key, workspaceTicketSignerErr = rsa.GenerateKey(rand.Reader, 2048)
+			if workspaceTicketSignerErr != nil {
+				workspaceTicketSignerErr = fmt.Errorf("failed to generate workspace ticket signing key: %w", workspaceTicketSignerErr)
+				return
+			}
+		}
+
+		kid, err := rsaKeyID(&key.PublicKey)
+		if err != nil {
+			workspaceTicketSignerErr = err
+			return
+		}
+		workspaceTicketSigner = &WorkspaceTicketSigner{PrivateKey: key, KeyID: kid}
+	})
+	return workspaceTicketSigner, workspaceTicketSignerErr
+}
+
+// loadRSAPrivateKey PEM(PKCS#1 또는 PKCS#8) 파일에서 RSA 개인키 로드
+func loadRSAPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workspace ticket signing key %s: %w", path, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("workspace ticket signing key %s is not PEM encoded", path)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse workspace ticket signing key %s: %w", path, err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("workspace ticket signing key %s is not an RSA key", path)
+	}
+	return key, nil
+}
+
+// rsaKeyID 공개키 DER의 SHA-256 해시로 kid 생성
+func rsaKeyID(pub *rsa.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal workspace ticket public key: %w", err)
+	}
+	sum := sha256.Sum256(der)
+	return base64.RawURLEncoding.EncodeToString(sum[:16]), nil
+}
@@ -32,3 +32,13 @@ const (
 
 	CspRoleNamePrefix = "mciam-" // csp에 role을 추가할 때 접두사를 붙인다.
 )
+
+// 감사 로그(audit) 미들웨어와 핸들러가 공유하는 echo.Context 키
+const (
+	AuditContextAction     = "audit_action"      // 도메인 액션명 (예: role.assign.workspace)
+	AuditContextTargetType = "audit_target_type" // 대상 유형 (예: user, group, role)
+	AuditContextTargetID   = "audit_target_id"   // 대상 식별자
+	AuditContextBefore     = "audit_before"      // 변경 전 스냅샷
+	AuditContextAfter      = "audit_after"       // 변경 후 스냅샷
+	AuditContextSkip       = "audit_skip"        // true이면 기록하지 않음
+)
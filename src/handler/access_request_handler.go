@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"gorm.io/gorm"
+)
+
+// AccessRequestHandler JIT 권한 상승 요청 핸들러
+type AccessRequestHandler struct {
+	accessRequestService *service.AccessRequestService
+	userService          *service.UserService
+}
+
+// NewAccessRequestHandler 새 AccessRequestHandler 인스턴스 생성
+func NewAccessRequestHandler(db *gorm.DB) *AccessRequestHandler {
+	return &AccessRequestHandler{
+		accessRequestService: service.NewAccessRequestService(db),
+		userService:          service.NewUserService(db),
+	}
+}
+
+// getCallerUserID JWT 컨텍스트에서 현재 사용자의 DB ID 조회
+func (h *AccessRequestHandler) getCallerUserID(c echo.Context) (uint, error) {
+	kcUserID, ok := c.Get("kcUserId").(string)
+	if !ok || kcUserID == "" {
+		return 0, errors.New("kcUserId not found in context")
+	}
+	return h.userService.GetUserIDByKcID(c.Request().Context(), kcUserID)
+}
+
+// accessRequestErrorStatus 서비스 오류를 HTTP 상태 코드로 변환
+func accessRequestErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrAccessRequestNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrAccessRequestForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrAccessRequestNotPending):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrAccessRequestInvalid):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// parseAccessRequestID 경로의 requestId 파싱
+func parseAccessRequestID(c echo.Context) (uint, error) {
+	id, err := strconv.ParseUint(c.Param("requestId"), 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid access request ID")
+	}
+	return uint(id), nil
+}
+
+// CreateAccessRequest godoc
+// @Summary Create just-in-time access request
+// @Description Request a higher workspace role (WORKSPACE_ROLE) or a specific CSP role mapping (CSP_ROLE) for a limited number of hours. The grant expires automatically after approval.
+// @Tags access-requests
+// @Accept json
+// @Produce json
+// @Param body body model.CreateAccessRequestRequest true "Access request"
+// @Success 201 {object} model.AccessRequest
+// @Failure 400 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/access-requests [post]
+// @Id createAccessRequest
+func (h *AccessRequestHandler) CreateAccessRequest(c echo.Context) error {
+	callerID, err := h.getCallerUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	var req model.CreateAccessRequestRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request format"})
+	}
+	if req.WorkspaceID == 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "workspaceId is required"})
+	}
+
+	setAuditTarget(c, "access_request.create", "workspace", strconv.FormatUint(uint64(req.WorkspaceID), 10))
+	accessRequest, err := h.accessRequestService.CreateAccessRequest(callerID, &req)
+	if err != nil {
+		return c.JSON(accessRequestErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	setAuditAfter(c, accessRequest)
+	return c.JSON(http.StatusCreated, accessRequest)
+}
+
+// ListAccessRequests godoc
+// @Summary List access requests
+// @Description Platform admins see all requests. Other users see their own requests and requests for workspaces they administer.
+// @Tags access-requests
+// @Accept json
+// @Produce json
+// @Param status query string false "Filter by status (PENDING_APPROVAL/APPROVED/REJECTED/CANCELLED)"
+// @Param workspaceId query int false "Filter by workspace ID"
+// @Success 200 {array} model.AccessRequest
+// @Security BearerAuth
+// @Router /api/access-requests [get]
+// @Id listAccessRequests
+func (h *AccessRequestHandler) ListAccessRequests(c echo.Context) error {
+	callerID, err := h.getCallerUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	var filter model.AccessRequestFilterRequest
+	if err := c.Bind(&filter); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request format"})
+	}
+
+	requests, err := h.accessRequestService.ListAccessRequests(callerID, checkRoleFromContext(c, []string{"platformAdmin"}), &filter)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, requests)
+}
+
+// GetAccessRequest godoc
+// @Summary Get access request
+// @Description Get an access request with its status history
+// @Tags access-requests
+// @Accept json
+// @Produce json
+// @Param requestId path int true "Access request ID"
+// @Success 200 {object} model.AccessRequest
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/access-requests/id/{requestId} [get]
+// @Id getAccessRequest
+func (h *AccessRequestHandler) GetAccessRequest(c echo.Context) error {
+	requestID, err := parseAccessRequestID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	callerID, err := h.getCallerUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	accessRequest, err := h.accessRequestService.GetAccessRequest(requestID, callerID, checkRoleFromContext(c, []string{"platformAdmin"}))
+	if err != nil {
+		return c.JSON(accessRequestErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, accessRequest)
+}
+
+// ApproveAccessRequest godoc
+// @Summary Approve access request
+// @Description Workspace admin or platform admin approves a pending request. The role is granted until now + durationHours and revoked automatically afterwards.
+// @Tags access-requests
+// @Accept json
+// @Produce json
+// @Param requestId path int true "Access request ID"
+// @Param body body model.AccessRequestDecisionRequest false "Decision comment"
+// @Success 200 {object} model.AccessRequest
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/access-requests/id/{requestId}/approve [put]
+// @Id approveAccessRequest
+func (h *AccessRequestHandler) ApproveAccessRequest(c echo.Context) error {
+	return h.decide(c, "access_request.approve", h.accessRequestService.ApproveAccessRequest)
+}
+
+// RejectAccessRequest godoc
+// @Summary Reject access request
+// @Description Workspace admin or platform admin rejects a pending request
+// @Tags access-requests
+// @Accept json
+// @Produce json
+// @Param requestId path int true "Access request ID"
+// @Param body body model.AccessRequestDecisionRequest false "Decision comment"
+// @Success 200 {object} model.AccessRequest
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/access-requests/id/{requestId}/reject [put]
+// @Id rejectAccessRequest
+func (h *AccessRequestHandler) RejectAccessRequest(c echo.Context) error {
+	return h.decide(c, "access_request.reject", h.accessRequestService.RejectAccessRequest)
+}
+
+// decide 승인/거절 공통 처리
+func (h *AccessRequestHandler) decide(c echo.Context, action string,
+	fn func(requestID, approverUserID uint, isPlatformAdmin bool, comment string) (*model.AccessRequest, error)) error {
+	requestID, err := parseAccessRequestID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	callerID, err := h.getCallerUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+	var req model.AccessRequestDecisionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request format"})
+	}
+
+	setAuditTarget(c, action, "access_request", c.Param("requestId"))
+	accessRequest, err := fn(requestID, callerID, checkRoleFromContext(c, []string{"platformAdmin"}), req.Comment)
+	if err != nil {
+		return c.JSON(accessRequestErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	setAuditAfter(c, accessRequest)
+	return c.JSON(http.StatusOK, accessRequest)
+}
+
+// CancelAccessRequest godoc
+// @Summary Cancel access request
+// @Description Requester cancels their own pending request
+// @Tags access-requests
+// @Accept json
+// @Produce json
+// @Param requestId path int true "Access request ID"
+// @Param body body model.AccessRequestDecisionRequest false "Cancel reason"
+// @Success 200 {object} model.AccessRequest
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/access-requests/id/{requestId}/cancel [put]
+// @Id cancelAccessRequest
+func (h *AccessRequestHandler) CancelAccessRequest(c echo.Context) error {
+	requestID, err := parseAccessRequestID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	callerID, err := h.getCallerUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+	var req model.AccessRequestDecisionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request format"})
+	}
+
+	setAuditTarget(c, "access_request.cancel", "access_request", c.Param("requestId"))
+	accessRequest, err := h.accessRequestService.CancelAccessRequest(requestID, callerID, req.Comment)
+	if err != nil {
+		return c.JSON(accessRequestErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	setAuditAfter(c, accessRequest)
+	return c.JSON(http.StatusOK, accessRequest)
+}
@@ -30,6 +30,7 @@ type AdminHandler struct {
 	menuService          service.MenuService
 	organizationService  *service.OrganizationService
 	companyService       *service.CompanyService
+	permissionService    *service.MciamPermissionService
 }
 
 // NewAdminHandler 새 AdminHandler 인스턴스 생성
@@ -42,6 +43,7 @@ func NewAdminHandler(db *gorm.DB) *AdminHandler {
 		menuService:         *service.NewMenuService(db),
 		organizationService: service.NewOrganizationService(db),
 		companyService:      service.NewCompanyService(db),
+		permissionService:   service.NewMciamPermissionService(db),
 	}
 }
 
@@ -190,6 +192,12 @@ func (h *AdminHandler) SetupInitialAdmin(c echo.Context) error {
 		// })
 	}
 
+	// mc-iam-manager 자체 리소스 유형/권한 및 기본 역할 매핑 (permission/mc-iam-manager.yaml)
+	err = h.permissionService.LoadAndRegisterFrameworkPermissionsFromYAML("")
+	if err != nil {
+		log.Printf("[ERROR] Register mc-iam-manager permissions failed: %v", err)
+	}
+
 	// 기본 조직 등록
 	err = h.organizationService.LoadAndRegisterOrganizationsFromYAML("")
 	if err != nil {
@@ -321,6 +329,30 @@ func (h *AdminHandler) InitializeMenuPermissionsFromYAML(c echo.Context) error {
 	})
 }
 
+// InitializeIamPermissions godoc
+// @Summary Initialize mc-iam-manager permissions from YAML
+// @Description asset/permission/mc-iam-manager.yaml의 리소스 유형/권한을 등록하고, mc-iam-manager 권한이 없는 역할에 기본 매핑을 추가합니다. 멱등성 보장.
+// @Tags admin
+// @Produce json
+// @Param filePath query string false "YAML file path (optional, default asset/permission/mc-iam-manager.yaml)"
+// @Success 200 {object} model.Response
+// @Failure 500 {object} model.Response
+// @Security BearerAuth
+// @Router /api/setup/initial-iam-permissions [post]
+// @Id initializeIamPermissions
+func (h *AdminHandler) InitializeIamPermissions(c echo.Context) error {
+	if err := h.permissionService.LoadAndRegisterFrameworkPermissionsFromYAML(c.QueryParam("filePath")); err != nil {
+		log.Printf("[ERROR] Initialize mc-iam-manager permissions failed: %v", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Error:   true,
+			Message: fmt.Sprintf("Failed to initialize mc-iam-manager permissions: %v", err),
+		})
+	}
+	return c.JSON(http.StatusOK, model.Response{
+		Message: "mc-iam-manager permissions initialized successfully",
+	})
+}
+
 // BackupRolePermissions godoc
 // @Summary Backup current role permissions from DB
 // @Description 플랫폼 역할의 현재 메뉴(및 reserved ops/csp) 권한을 role-permission-backup 문서로 내보냅니다
@@ -459,6 +491,7 @@ func (h *AdminHandler) RestoreRolePermissions(c echo.Context) error {
 		}
 	}
 
+	setAuditTarget(c, "role.permissions.restore", "role_permission_backup", mode)
 	result, err := h.menuService.RestoreRolePermissions(backup, mode, sections)
 	if err != nil {
 		log.Printf("[ERROR] RestoreRolePermissions failed: %v", err)
@@ -472,6 +505,7 @@ func (h *AdminHandler) RestoreRolePermissions(c echo.Context) error {
 		"[INFO] Role permissions restored: mode=%s roles=%d added=%d removed=%d",
 		result.Mode, result.RolesProcessed, result.MenusAdded, result.MenusRemoved,
 	)
+	setAuditAfter(c, result)
 	return c.JSON(http.StatusOK, result)
 }
 
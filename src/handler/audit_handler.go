@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"gorm.io/gorm"
+)
+
+// AuditHandler 감사 로그 조회 핸들러
+type AuditHandler struct {
+	auditService *service.AuditService
+}
+
+// NewAuditHandler 새 AuditHandler 인스턴스 생성
+func NewAuditHandler(db *gorm.DB) *AuditHandler {
+	return &AuditHandler{
+		auditService: service.NewAuditService(db),
+	}
+}
+
+// ListAuditEvents godoc
+// @Summary List audit events
+// @Description 감사 이벤트 목록을 조회합니다. 시간 범위, 행위자(kcUserId), 액션(접두사), 대상 유형/ID, 요청 ID로 필터링합니다.
+// @Tags audit
+// @Accept json
+// @Produce json
+// @Param request body model.AuditEventFilterRequest false "Audit Event Filter"
+// @Success 200 {object} model.AuditEventListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/audit-events/list [post]
+// @Id listAuditEvents
+func (h *AuditHandler) ListAuditEvents(c echo.Context) error {
+	var req model.AuditEventFilterRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "잘못된 요청 형식입니다"})
+	}
+
+	result, err := h.auditService.ListAuditEvents(&req)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAuditTimeRange) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// setAuditTarget 감사 미들웨어가 기록할 액션명과 대상을 컨텍스트에 설정
+func setAuditTarget(c echo.Context, action, targetType, targetID string) {
+	c.Set(constants.AuditContextAction, action)
+	c.Set(constants.AuditContextTargetType, targetType)
+	c.Set(constants.AuditContextTargetID, targetID)
+}
+
+// setAuditBefore 변경 전 스냅샷 설정
+func setAuditBefore(c echo.Context, before interface{}) {
+	c.Set(constants.AuditContextBefore, before)
+}
+
+// setAuditAfter 변경 후 스냅샷 설정
+func setAuditAfter(c echo.Context, after interface{}) {
+	c.Set(constants.AuditContextAfter, after)
+}
@@ -19,7 +19,6 @@ import (
 
 	"github.com/Nerzal/gocloak/v13"
 	"github.com/labstack/echo/v4" // Keep this one
-	"github.com/m-cmp/mc-iam-manager/config"
 	"github.com/m-cmp/mc-iam-manager/model" // Keep this one
 	"github.com/m-cmp/mc-iam-manager/model/idp"
 	"github.com/m-cmp/mc-iam-manager/repository" // Needed for error check
@@ -30,9 +29,11 @@ import (
 // Define user authentication related functions.
 
 type AuthHandler struct {
-	userService     *service.UserService
-	keycloakService service.KeycloakService
-	roleService     *service.RoleService
+	userService            *service.UserService
+	keycloakService        service.KeycloakService
+	roleService            *service.RoleService
+	workspaceTicketService *service.WorkspaceTicketService
+	signupService          *service.SignupService
 }
 
 // NewAuthHandler creates a new AuthHandler instance
@@ -41,9 +42,11 @@ func NewAuthHandler(db *gorm.DB) *AuthHandler {
 	keycloakService := service.NewKeycloakService()
 	roleService := service.NewRoleService(db)
 	return &AuthHandler{
-		userService:     userService,
-		keycloakService: keycloakService,
-		roleService:     roleService,
+		userService:            userService,
+		keycloakService:        keycloakService,
+		roleService:            roleService,
+		workspaceTicketService: service.NewWorkspaceTicketService(db),
+		signupService:          service.NewSignupService(db),
 	}
 }
 
@@ -85,6 +88,15 @@ func (h *AuthHandler) Login(c echo.Context) error {
 
 	// Old claims logic removed
 
+	// 가입 신청으로 만든 계정은 승인 후에만 로그인 가능 (이메일 인증 직후라면 여기서 승인 대기로 전환)
+	allowed, err := h.signupService.CheckLoginAllowed(ctx, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to check signup status: %v", err)})
+	}
+	if !allowed {
+		return c.JSON(http.StatusForbidden, map[string]string{"error": "Account is disabled or pending approval"})
+	}
+
 	// 3. Check if user is enabled in Keycloak using a temporary KeycloakService instance
 	kcUser, err := ks.GetUser(ctx, userID) // Use GetUser from KeycloakService
 	if err != nil {
@@ -164,87 +176,215 @@ func (h *AuthHandler) RefreshToken(c echo.Context) error {
 }
 
 // WorkspaceTicket godoc
-// @Summary Set workspace ticket
-// @Description Set workspace ticket
+// @Summary Issue workspace ticket
+// @Description 사용자의 워크스페이스 역할과 매핑된 MC-IAM 권한을 담은 단기 워크스페이스 티켓(mc-iam-manager 서명 JWT)을 발급합니다. 서명 검증용 공개키는 /api/auth/workspace-ticket/certs 에서 제공합니다.
 // @Tags auth
 // @Accept json
 // @Produce json
-// @Success 200 {object} map[string]string "message: Workspace ticket set successfully"
+// @Param request body model.WorkspaceTicketRequest true "Workspace ID"
+// @Success 200 {object} model.WorkspaceTicketResponse
+// @Failure 400 {object} map[string]string "error: Invalid workspace ID"
 // @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: No role in the workspace"
 // @Security BearerAuth
 // @Router /api/workspaces/workspace-ticket [post]
 // @Id mciamWorkspaceTicket
 func (h *AuthHandler) WorkspaceTicket(c echo.Context) error {
-	// 1. Check existing access token
-	authHeader := c.Request().Header.Get("Authorization")
-	if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Authorization header is missing or invalid"})
+	kcUserID, ok := c.Get("kcUserId").(string)
+	if !ok || kcUserID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "User not authenticated"})
 	}
-	accessToken := strings.TrimPrefix(authHeader, "Bearer ")
 
-	// 2. Request binding
 	var req model.WorkspaceTicketRequest
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body: " + err.Error()})
 	}
 
-	// 3. Validate workspace ID
-	if _, err := strconv.ParseUint(req.WorkspaceID, 10, 32); err != nil {
+	workspaceID, err := strconv.ParseUint(req.WorkspaceID, 10, 32)
+	if err != nil {
 		log.Printf("Workspace ID format error: %v", err)
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid workspace ID"})
 	}
 
-	// 4. Check user's workspace role
-	userID, err := h.userService.GetUserIDByKcID(c.Request().Context(), c.Get("kcUserId").(string))
+	ticket, err := h.workspaceTicketService.IssueTicket(kcUserID, uint(workspaceID))
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "User not found"})
+		return workspaceTicketErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, ticket)
+}
+
+// RefreshWorkspaceTicket godoc
+// @Summary Refresh workspace ticket
+// @Description 유효한 워크스페이스 티켓을 현재 역할/권한 기준으로 재발급하고 기존 티켓은 폐기합니다.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body model.WorkspaceTicketTokenRequest true "Workspace ticket"
+// @Success 200 {object} model.WorkspaceTicketResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/workspaces/workspace-ticket/refresh [post]
+// @Id mciamRefreshWorkspaceTicket
+func (h *AuthHandler) RefreshWorkspaceTicket(c echo.Context) error {
+	kcUserID, ok := c.Get("kcUserId").(string)
+	if !ok || kcUserID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "User not authenticated"})
+	}
+
+	var req model.WorkspaceTicketTokenRequest
+	if err := c.Bind(&req); err != nil || req.Ticket == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "ticket is required"})
 	}
 
-	// 5. Get workspace permissions
-	workspaceRoles, err := h.roleService.GetUserWorkspaceRoles(userID, 0)
+	ticket, err := h.workspaceTicketService.RefreshTicket(req.Ticket, kcUserID)
 	if err != nil {
-		log.Printf("Failed to get workspace permissions: %v", err)
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to get workspace permissions"})
+		return workspaceTicketErrorResponse(c, err)
 	}
+	return c.JSON(http.StatusOK, ticket)
+}
 
-	// Convert permissions to scope format
-	scopes := make([]string, 0)
-	for _, role := range workspaceRoles {
-		scopes = append(scopes, fmt.Sprintf("workspace:%s", role.RoleName))
+// RevokeWorkspaceTicket godoc
+// @Summary Revoke workspace ticket
+// @Description 본인에게 발급된 워크스페이스 티켓을 폐기합니다.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body model.WorkspaceTicketTokenRequest true "Workspace ticket"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/workspaces/workspace-ticket/revoke [post]
+// @Id mciamRevokeWorkspaceTicket
+func (h *AuthHandler) RevokeWorkspaceTicket(c echo.Context) error {
+	kcUserID, ok := c.Get("kcUserId").(string)
+	if !ok || kcUserID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "User not authenticated"})
 	}
 
-	// 6. Use KeycloakService to issue RPT
-	ks := service.NewKeycloakService()
+	var req model.WorkspaceTicketTokenRequest
+	if err := c.Bind(&req); err != nil || req.Ticket == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "ticket is required"})
+	}
+	setAuditTarget(c, "workspace_ticket.revoke", "user", kcUserID)
 
-	// Create claim token in authorization.permissions format
-	claimToken := fmt.Sprintf(`{
-		"authorization": {
-			"permissions": [
-				{
-					"rsid": "%s",
-					"rsname": "workspace",
-					"scopes": %v
-				}
-			]
+	if err := h.workspaceTicketService.RevokeTicket(req.Ticket, kcUserID); err != nil {
+		if errors.Is(err, repository.ErrWorkspaceTicketNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Workspace ticket not found or already revoked"})
 		}
-	}`, req.WorkspaceID, scopes)
+		return workspaceTicketErrorResponse(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
 
-	rptOptions := gocloak.RequestingPartyTokenOptions{
-		GrantType: gocloak.StringP("urn:ietf:params:oauth:grant-type:uma-ticket"),
-		Audience:  gocloak.StringP(config.KC.OIDCClientName),
-		//Audience:   gocloak.StringP(config.KC.OIDCClientID),
-		ClaimToken: gocloak.StringP(claimToken),
+// IntrospectWorkspaceTicket godoc
+// @Summary Introspect workspace ticket
+// @Description downstream 프레임워크가 워크스페이스 티켓의 서명, 만료, 폐기 여부를 확인합니다. 유효하지 않으면 active=false 를 반환합니다.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body model.WorkspaceTicketTokenRequest true "Workspace ticket"
+// @Success 200 {object} model.WorkspaceTicketIntrospectResponse
+// @Failure 400 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/workspaces/workspace-ticket/introspect [post]
+// @Id mciamIntrospectWorkspaceTicket
+func (h *AuthHandler) IntrospectWorkspaceTicket(c echo.Context) error {
+	var req model.WorkspaceTicketTokenRequest
+	if err := c.Bind(&req); err != nil || req.Ticket == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "ticket is required"})
+	}
+
+	claims, err := h.workspaceTicketService.ValidateTicket(req.Ticket)
+	if err != nil {
+		if !errors.Is(err, service.ErrWorkspaceTicketInvalid) && !errors.Is(err, service.ErrWorkspaceTicketRevoked) {
+			log.Printf("Failed to introspect workspace ticket: %v", err)
+		}
+		return c.JSON(http.StatusOK, model.WorkspaceTicketIntrospectResponse{Active: false})
 	}
-	rpt, err := ks.GetRequestingPartyToken(c.Request().Context(), accessToken, rptOptions)
+
+	return c.JSON(http.StatusOK, model.WorkspaceTicketIntrospectResponse{
+		Active:      true,
+		Subject:     claims.Subject,
+		WorkspaceID: claims.WorkspaceID,
+		Roles:       claims.Roles,
+		Permissions: claims.Permissions,
+		ExpiresAt:   claims.ExpiresAt.Unix(),
+		TicketID:    claims.ID,
+	})
+}
+
+// WorkspaceTicketCerts godoc
+// @Summary Get workspace ticket signing keys
+// @Description 워크스페이스 티켓 서명 검증용 공개키(JWKS)를 조회합니다.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /api/auth/workspace-ticket/certs [get]
+// @Id mciamWorkspaceTicketCerts
+func (h *AuthHandler) WorkspaceTicketCerts(c echo.Context) error {
+	jwks, err := h.workspaceTicketService.GetJWKS()
 	if err != nil {
-		//log.Printf("Audience: %v", config.KC.OIDCClientID)
-		log.Printf("Audience: %v", config.KC.OIDCClientName)
-		log.Printf("ClaimToken: %v", claimToken)
-		log.Printf("Failed to issue RPT: %v", err)
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to get RPT"})
+		log.Printf("Failed to get workspace ticket JWKS: %v", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to get workspace ticket certs"})
 	}
+	return c.JSON(http.StatusOK, jwks)
+}
 
-	return c.JSON(http.StatusOK, map[string]interface{}{"rpt": rpt})
+// RevokeUserWorkspaceTickets godoc
+// @Summary Revoke user's workspace tickets
+// @Description 관리자가 사용자에게 발급된 워크스페이스 티켓을 일괄 폐기합니다. workspaceId 미지정 시 전체 워크스페이스 대상
+// @Tags auth
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param workspaceId query string false "Workspace ID"
+// @Success 200 {object} map[string]interface{} "revoked: count"
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/users/id/{userId}/workspace-tickets [delete]
+// @Id mciamRevokeUserWorkspaceTickets
+func (h *AuthHandler) RevokeUserWorkspaceTickets(c echo.Context) error {
+	userID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user ID"})
+	}
+	var workspaceID uint64
+	if raw := c.QueryParam("workspaceId"); raw != "" {
+		workspaceID, err = strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid workspace ID"})
+		}
+	}
+	setAuditTarget(c, "workspace_ticket.revoke.admin", "user", c.Param("userId"))
+
+	revoked, err := h.workspaceTicketService.RevokeUserWorkspaceTickets(uint(userID), uint(workspaceID))
+	if err != nil {
+		log.Printf("Failed to revoke workspace tickets for user %d: %v", userID, err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to revoke workspace tickets"})
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"revoked": revoked})
+}
+
+// workspaceTicketErrorResponse 워크스페이스 티켓 서비스 오류를 HTTP 응답으로 변환
+func workspaceTicketErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrWorkspaceTicketNoRole):
+		return c.JSON(http.StatusForbidden, map[string]string{"error": "User has no role in the workspace"})
+	case errors.Is(err, service.ErrWorkspaceTicketNotOwner):
+		return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrWorkspaceTicketInvalid), errors.Is(err, service.ErrWorkspaceTicketRevoked):
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	case errors.Is(err, repository.ErrUserNotFound):
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "User not found"})
+	default:
+		log.Printf("Workspace ticket operation failed: %v", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to process workspace ticket"})
+	}
 }
 
 // AuthCerts godoc
@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/config"
+	"github.com/m-cmp/mc-iam-manager/middleware"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"gorm.io/gorm"
+)
+
+// AuthzHandler downstream 프레임워크용 권한 판단(PDP) 핸들러
+type AuthzHandler struct {
+	authzService *service.AuthzService
+	kcService    service.KeycloakService
+}
+
+// NewAuthzHandler 새 AuthzHandler 인스턴스 생성
+func NewAuthzHandler(db *gorm.DB) *AuthzHandler {
+	return &AuthzHandler{
+		authzService: service.NewAuthzService(db),
+		kcService:    service.NewKeycloakService(),
+	}
+}
+
+// CheckAuthorization godoc
+// @Summary Check authorization
+// @Description 사용자 X가 워크스페이스 Z(프로젝트 P)에서 액션 Y(McmpApiAction 또는 MciamPermission ID)를 수행할 수 있는지 판단하고, 허용 시 권한을 부여한 경로를 반환합니다. kcUserId 미지정 시 요청자 본인을 판단하며, 다른 사용자 판단은 admin 이상만 같은 회사(realm) 사용자에 대해 가능합니다.
+// @Tags authz
+// @Accept json
+// @Produce json
+// @Param request body model.AuthzCheckRequest true "Authorization check"
+// @Success 200 {object} model.AuthzCheckResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/authz/check [post]
+// @Id checkAuthorization
+func (h *AuthzHandler) CheckAuthorization(c echo.Context) error {
+	var req model.AuthzCheckRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "잘못된 요청 형식입니다"})
+	}
+	if allowed, err := h.resolveAuthzSubject(c, &req.KcUserID); !allowed {
+		return denyAuthzSubject(c, err)
+	}
+	setRequesterAuthzContext(c, &req)
+
+	res, err := h.authzService.Check(c.Request().Context(), &req)
+	if err != nil {
+		return authzErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// CheckAuthorizationBatch godoc
+// @Summary Check authorization (batch)
+// @Description 여러 권한 판단을 한 번에 수행합니다. 항목별 kcUserId가 없으면 상위 kcUserId(또는 요청자 본인)를 사용합니다. 최대 100건
+// @Tags authz
+// @Accept json
+// @Produce json
+// @Param request body model.AuthzCheckBatchRequest true "Authorization checks"
+// @Success 200 {object} model.AuthzCheckBatchResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/authz/check-batch [post]
+// @Id checkAuthorizationBatch
+func (h *AuthzHandler) CheckAuthorizationBatch(c echo.Context) error {
+	var req model.AuthzCheckBatchRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "잘못된 요청 형식입니다"})
+	}
+	if len(req.Checks) == 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "checks is required"})
+	}
+	if allowed, err := h.resolveAuthzSubject(c, &req.KcUserID); !allowed {
+		return denyAuthzSubject(c, err)
+	}
+	for i := range req.Checks {
+		if req.Checks[i].KcUserID == "" {
+			continue
+		}
+		if allowed, err := h.resolveAuthzSubject(c, &req.Checks[i].KcUserID); !allowed {
+			return denyAuthzSubject(c, err)
+		}
+	}
+	for i := range req.Checks {
+		if req.Checks[i].KcUserID == "" {
+			req.Checks[i].KcUserID = req.KcUserID
+		}
+		setRequesterAuthzContext(c, &req.Checks[i])
+	}
+
+	res, err := h.authzService.CheckBatch(c.Request().Context(), &req)
+	if err != nil {
+		return authzErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// DryRunAuthorization godoc
+// @Summary Dry-run authorization with policy rules
+// @Description 권한 판단을 실제 요청 없이 평가합니다. context(시각, sourceIp, amr)로 조건을 가정할 수 있고, candidateRules는 저장하지 않고 활성 규칙과 함께 평가합니다. 사용자에게 적용되는 규칙별 일치 여부와 사유를 반환합니다.
+// @Tags authz
+// @Accept json
+// @Produce json
+// @Param request body model.AuthzDryRunRequest true "Authorization check with candidate rules"
+// @Success 200 {object} model.AuthzDryRunResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/authz/dry-run [post]
+// @Id dryRunAuthorization
+func (h *AuthzHandler) DryRunAuthorization(c echo.Context) error {
+	var req model.AuthzDryRunRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "잘못된 요청 형식입니다"})
+	}
+	if req.KcUserID == "" {
+		req.KcUserID, _ = c.Get("kcUserId").(string)
+	}
+	if allowed, err := h.subjectInRequesterTenant(c, req.KcUserID); !allowed {
+		return denyAuthzSubject(c, err)
+	}
+	res, err := h.authzService.DryRun(c.Request().Context(), &req)
+	if err != nil {
+		return authzErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// GetMyPermissions godoc
+// @Summary Get my effective permissions
+// @Description 요청자의 유효 MciamPermission 목록을 반환합니다. workspaceId를 지정하면 해당 워크스페이스의 역할(직접/그룹) 권한을 포함합니다.
+// @Tags authz
+// @Produce json
+// @Param workspaceId query string false "Workspace ID"
+// @Success 200 {object} model.EffectivePermissions
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/authz/permissions [get]
+// @Id getMyPermissions
+func (h *AuthzHandler) GetMyPermissions(c echo.Context) error {
+	var workspaceID uint
+	if value := c.QueryParam("workspaceId"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid workspaceId"})
+		}
+		workspaceID = uint(id)
+	}
+	kcUserID, _ := c.Get("kcUserId").(string)
+	platformRoles, _ := c.Get("platformRoles").([]string)
+
+	res, err := h.authzService.EffectivePermissions(kcUserID, workspaceID, platformRoles, middleware.RequestAuthzContext(c))
+	if err != nil {
+		return authzErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// resolveAuthzSubject 판단 대상 사용자를 결정. 미지정 시 요청자 본인, 타인이면 admin 이상이고 같은 회사 사용자인지 확인
+func (h *AuthzHandler) resolveAuthzSubject(c echo.Context, kcUserID *string) (bool, error) {
+	requester, _ := c.Get("kcUserId").(string)
+	if *kcUserID == "" {
+		*kcUserID = requester
+		return true, nil
+	}
+	if *kcUserID == requester {
+		return true, nil
+	}
+	if !checkRoleFromContext(c, []string{"admin", "platformAdmin"}) {
+		return false, nil
+	}
+	return h.subjectInRequesterTenant(c, *kcUserID)
+}
+
+// subjectInRequesterTenant 멀티테넌트 모드에서 판단 대상이 요청자 회사 realm의 사용자인지 확인 (단일 테넌트 모드는 항상 허용)
+func (h *AuthzHandler) subjectInRequesterTenant(c echo.Context, kcUserID string) (bool, error) {
+	requester, _ := c.Get("kcUserId").(string)
+	if !config.MultiTenantEnabled() || kcUserID == requester {
+		return true, nil
+	}
+	if _, err := h.kcService.GetUser(c.Request().Context(), kcUserID); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// denyAuthzSubject 판단 대상 확인 실패 응답. 다른 회사 사용자는 존재 여부를 드러내지 않도록 권한 부족과 같은 응답
+func denyAuthzSubject(c echo.Context, err error) error {
+	if err != nil {
+		log.Printf("Failed to verify authorization subject: %v", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to verify authorization subject"})
+	}
+	return c.JSON(http.StatusForbidden, map[string]string{"error": "다른 사용자의 권한 판단은 같은 회사의 관리자만 가능합니다"})
+}
+
+// setRequesterAuthzContext 요청자 본인 판단이면 토큰(API 키 scope)의 플랫폼 역할과 현재 요청 정보(IP, amr)로 평가
+// 본인 판단에서 요청자가 보낸 context는 조건부 규칙(IP, MFA)을 우회할 수 있으므로 무시한다.
+// 임의 context는 관리자의 타인 판단과 /authz/dry-run에서만 사용한다.
+func setRequesterAuthzContext(c echo.Context, req *model.AuthzCheckRequest) {
+	requester, _ := c.Get("kcUserId").(string)
+	if req.KcUserID != requester {
+		return
+	}
+	req.PlatformRoles = requesterPlatformRoles(c)
+	req.Context = middleware.RequestAuthzContext(c)
+}
+
+// requesterPlatformRoles 요청 토큰(API 키 scope)의 플랫폼 역할. 역할이 없어도 nil이 아닌 빈 목록을 반환해 DB 역할 전체가 평가되지 않게 한다
+func requesterPlatformRoles(c echo.Context) []string {
+	platformRoles, _ := c.Get("platformRoles").([]string)
+	if platformRoles == nil {
+		platformRoles = []string{}
+	}
+	return platformRoles
+}
+
+// authzErrorResponse 권한 판단 서비스 오류를 HTTP 응답으로 변환
+func authzErrorResponse(c echo.Context, err error) error {
+	if errors.Is(err, service.ErrAuthzInvalidRequest) || errors.Is(err, service.ErrAuthzBatchTooLarge) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	log.Printf("Authorization check failed: %v", err)
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to evaluate authorization"})
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// realmUsersKeycloakService 요청자 realm의 사용자만 조회되는 KeycloakService 스텁
+type realmUsersKeycloakService struct {
+	service.KeycloakService
+	users map[string]bool
+}
+
+func (m *realmUsersKeycloakService) GetUser(ctx context.Context, kcId string) (*gocloak.User, error) {
+	if !m.users[kcId] {
+		return nil, fmt.Errorf("user not found in keycloak (kcId: %s): %w", kcId, repository.ErrUserNotFound)
+	}
+	return &gocloak.User{ID: &kcId}, nil
+}
+
+// 본인 판단은 요청자가 보낸 context 대신 현재 요청 정보로 평가하고, 타인 판단은 전달한 context 유지
+func TestSetRequesterAuthzContext_SelfCheckIgnoresClientContext(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/authz/check", nil)
+	req.RemoteAddr = "203.0.113.7:5000"
+	c := e.NewContext(req, httptest.NewRecorder())
+	c.Set("kcUserId", "kc-self")
+	c.Set("platformRoles", []string{"viewer"})
+
+	spoofed := &model.AuthzRequestContext{SourceIP: "10.0.0.1", AMR: []string{"otp"}}
+	self := &model.AuthzCheckRequest{KcUserID: "kc-self", Context: spoofed}
+	setRequesterAuthzContext(c, self)
+	require.NotNil(t, self.Context)
+	assert.Equal(t, "203.0.113.7", self.Context.SourceIP)
+	assert.Empty(t, self.Context.AMR)
+	assert.Equal(t, []string{"viewer"}, self.PlatformRoles)
+
+	other := &model.AuthzCheckRequest{KcUserID: "kc-other", Context: spoofed}
+	setRequesterAuthzContext(c, other)
+	assert.Same(t, spoofed, other.Context)
+	assert.Nil(t, other.PlatformRoles)
+}
+
+// 멀티테넌트 모드에서 관리자도 다른 회사(realm) 사용자의 권한은 판단할 수 없음
+func TestCheckAuthorization_SubjectRestrictedToRequesterTenant(t *testing.T) {
+	t.Setenv("MC_IAM_MANAGER_MULTI_TENANT", "true")
+	_, db := newTestMcmpApiCallHandler(t)
+	h := &AuthzHandler{
+		authzService: service.NewAuthzService(db),
+		kcService:    &realmUsersKeycloakService{users: map[string]bool{"kc-colleague": true}},
+	}
+	check := func(subject string) int {
+		e := echo.New()
+		body := `{"kcUserId":"` + subject + `","permissionId":"mc-iam-manager:user:read"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/authz/check", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("kcUserId", "kc-admin")
+		c.Set("platformRoles", []string{"admin"})
+		require.NoError(t, h.CheckAuthorization(c))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, check("kc-colleague"))
+	assert.Equal(t, http.StatusForbidden, check("kc-other-tenant"))
+}
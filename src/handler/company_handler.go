@@ -2,6 +2,7 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/labstack/echo/v4"
@@ -10,7 +11,7 @@ import (
 	"gorm.io/gorm"
 )
 
-// CompanyHandler 회사 정보 관리 핸들러 (싱글톤 — URL에 ID 없음)
+// CompanyHandler 회사 정보 관리 핸들러 (/api/company는 요청자의 회사, /api/companies는 멀티테넌트 모드의 전체 회사)
 type CompanyHandler struct {
 	companyService *service.CompanyService
 }
@@ -81,7 +82,7 @@ func (h *CompanyHandler) CreateCompany(c echo.Context) error {
 // @Router /api/company [get]
 // @Id getCompany
 func (h *CompanyHandler) GetCompany(c echo.Context) error {
-	resp, err := h.companyService.GetCompany()
+	resp, err := h.companyService.GetCompanyByID(requestCompanyIDOrDefault(c))
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "Company not found"})
@@ -115,7 +116,7 @@ func (h *CompanyHandler) UpdateCompany(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "name is required"})
 	}
 
-	resp, err := h.companyService.UpdateCompany(&req)
+	resp, err := h.companyService.UpdateCompanyByID(requestCompanyIDOrDefault(c), &req)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "Company not found"})
@@ -137,7 +138,7 @@ func (h *CompanyHandler) UpdateCompany(c echo.Context) error {
 // @Router /api/company [delete]
 // @Id deactivateCompany
 func (h *CompanyHandler) DeactivateCompany(c echo.Context) error {
-	resp, err := h.companyService.DeactivateCompany()
+	resp, err := h.companyService.DeactivateCompanyByID(requestCompanyIDOrDefault(c))
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "Company not found"})
@@ -159,7 +160,53 @@ func (h *CompanyHandler) DeactivateCompany(c echo.Context) error {
 // @Router /api/company/activate [post]
 // @Id activateCompany
 func (h *CompanyHandler) ActivateCompany(c echo.Context) error {
-	resp, err := h.companyService.ActivateCompany()
+	resp, err := h.companyService.ActivateCompanyByID(requestCompanyIDOrDefault(c))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Company not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ListCompanies godoc
+// @Summary List companies
+// @Description 멀티테넌트 모드의 전체 회사 목록을 조회합니다. (기본 realm의 platformAdmin 전용)
+// @Tags company
+// @Produce json
+// @Success 200 {array} model.CompanyResponse
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/companies [get]
+// @Id listCompanies
+func (h *CompanyHandler) ListCompanies(c echo.Context) error {
+	resp, err := h.companyService.ListCompanies()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetCompanyByID godoc
+// @Summary Get company by ID
+// @Description 회사 정보를 ID로 조회합니다. (기본 realm의 platformAdmin 전용)
+// @Tags company
+// @Produce json
+// @Param companyId path string true "Company ID"
+// @Success 200 {object} model.CompanyResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/companies/id/{companyId} [get]
+// @Id getCompanyByID
+func (h *CompanyHandler) GetCompanyByID(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("companyId"), 10, 32)
+	if err != nil || id == 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid company ID"})
+	}
+	resp, err := h.companyService.GetCompanyByID(uint(id))
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "Company not found"})
@@ -61,6 +61,7 @@ func (h *CspAccountHandler) CreateCspAccount(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid CSP type. Must be one of: aws, gcp, azure, alibaba, tencent, ibm, ncp, nhn, kt, openstack"})
 	}
 
+	req.CompanyID = requestCompanyID(c)
 	account, err := h.cspAccountService.CreateCspAccount(&req)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to create CSP account: %v", err)})
@@ -87,6 +88,7 @@ func (h *CspAccountHandler) ListCspAccounts(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request format"})
 	}
 
+	filter.CompanyID = requestCompanyID(c)
 	accounts, err := h.cspAccountService.ListCspAccounts(&filter)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to list CSP accounts: %v", err)})
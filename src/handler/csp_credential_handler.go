@@ -10,6 +10,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/m-cmp/mc-iam-manager/model"
 	"github.com/m-cmp/mc-iam-manager/service"
+	"github.com/m-cmp/mc-iam-manager/util"
 	"gorm.io/gorm"
 )
 
@@ -35,6 +36,7 @@ func NewCspCredentialHandler(db *gorm.DB) *CspCredentialHandler {
 // GetTemporaryCredentials godoc
 // @Summary Get temporary credentials
 // @Description Get temporary credentials for CSP
+// @Description 같은 사용자/워크스페이스 역할/CSP/인증방식/리전으로 발급된 자격 증명이 충분한 잔여 시간을 가지면 캐시에서 반환합니다 (forceRefresh=true 시 재발급).
 // @Tags csp-credentials
 // @Accept json
 // @Produce json
@@ -107,6 +109,64 @@ func (h *CspCredentialHandler) GetTemporaryCredentials(c echo.Context) error {
 	return c.JSON(http.StatusOK, credentials)
 }
 
+// RevokeCachedCredentials godoc
+// @Summary Revoke cached temporary credentials
+// @Description 관리자가 사용자 및/또는 워크스페이스 역할에 대해 캐시된 CSP 임시 자격 증명을 폐기합니다. 이후 요청은 CSP에서 새로 발급받습니다.
+// @Tags csp-credentials
+// @Accept json
+// @Produce json
+// @Param request body model.TempCredentialRevokeRequest true "Revoke filter (userId/kcUserId, roleId)"
+// @Success 200 {object} model.TempCredentialRevokeResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/workspaces/temporary-credentials/revoke [post]
+// @Id mciamRevokeCachedTemporaryCredentials
+func (h *CspCredentialHandler) RevokeCachedCredentials(c echo.Context) error {
+	var req model.TempCredentialRevokeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body: " + err.Error()})
+	}
+	var userID uint
+	if req.UserID != "" {
+		id, err := util.StringToUint(req.UserID)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user ID"})
+		}
+		userID = id
+	}
+	var roleID *uint
+	if req.RoleID != "" {
+		id, err := util.StringToUint(req.RoleID)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid role ID"})
+		}
+		roleID = &id
+	}
+	switch {
+	case req.UserID != "":
+		setAuditTarget(c, "temp_credential.revoke", "user", req.UserID)
+	case req.KcUserID != "":
+		setAuditTarget(c, "temp_credential.revoke", "user", req.KcUserID)
+	default:
+		setAuditTarget(c, "temp_credential.revoke", "role", req.RoleID)
+	}
+
+	revoked, err := h.credService.RevokeCachedCredentials(userID, req.KcUserID, roleID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrTempCredentialRevokeFilterRequired):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case errors.Is(err, service.ErrUserNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "User not found"})
+		}
+		log.Printf("Failed to revoke cached credentials: %v", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to revoke cached credentials"})
+	}
+	return c.JSON(http.StatusOK, model.TempCredentialRevokeResponse{Revoked: revoked})
+}
+
 // Removed placeholder ValidateTokenAndGetClaims function from handler
 
 // ListCredentials godoc
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"github.com/m-cmp/mc-iam-manager/util"
+	"gorm.io/gorm"
+)
+
+// CspHealthHandler CSP IdP 설정/계정 주기 헬스 체크 상태 핸들러
+type CspHealthHandler struct {
+	cspHealthService *service.CspHealthService
+}
+
+// NewCspHealthHandler 새 CspHealthHandler 인스턴스 생성
+func NewCspHealthHandler(db *gorm.DB) *CspHealthHandler {
+	return &CspHealthHandler{
+		cspHealthService: service.NewCspHealthService(db),
+	}
+}
+
+// ListCspIdpHealthStatus godoc
+// @Summary List CSP IDP config health status
+// @Description 주기 헬스 체크로 기록된 CSP IDP 설정별 현재 상태(CONNECTED/FAILED/TIMEOUT, 연속 실패 횟수, 마지막 상태 변경 시각)를 반환합니다.
+// @Tags csp-idp-configs
+// @Produce json
+// @Success 200 {array} model.CspHealthState
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/csp-idp-configs/health-status [get]
+// @Id listCspIdpHealthStatus
+func (h *CspHealthHandler) ListCspIdpHealthStatus(c echo.Context) error {
+	return h.listStatus(c, model.CspHealthTargetIdpConfig)
+}
+
+// GetCspIdpHealthHistory godoc
+// @Summary Get CSP IDP config health history
+// @Description CSP IDP 설정의 현재 헬스 상태와 헬스 체크 이력(최신순)을 반환합니다.
+// @Tags csp-idp-configs
+// @Produce json
+// @Param configId path string true "IDP Config ID"
+// @Param since query string false "조회 시작 시각 (RFC3339)"
+// @Param limit query int false "최대 건수 (기본 100, 최대 1000)"
+// @Param transitionOnly query bool false "상태 전이만 조회"
+// @Success 200 {object} model.CspHealthHistoryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/csp-idp-configs/id/{configId}/health-history [get]
+// @Id getCspIdpHealthHistory
+func (h *CspHealthHandler) GetCspIdpHealthHistory(c echo.Context) error {
+	configID, err := util.StringToUint(c.Param("configId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid config ID"})
+	}
+	return h.getHistory(c, model.CspHealthTargetIdpConfig, configID)
+}
+
+// ListCspAccountHealthStatus godoc
+// @Summary List CSP account health status
+// @Description 주기 헬스 체크로 기록된 CSP 계정별 현재 상태(CONNECTED/FAILED/TIMEOUT, 연속 실패 횟수, 마지막 상태 변경 시각)를 반환합니다.
+// @Tags csp-accounts
+// @Produce json
+// @Success 200 {array} model.CspHealthState
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/csp-accounts/health-status [get]
+// @Id listCspAccountHealthStatus
+func (h *CspHealthHandler) ListCspAccountHealthStatus(c echo.Context) error {
+	return h.listStatus(c, model.CspHealthTargetAccount)
+}
+
+// GetCspAccountHealthHistory godoc
+// @Summary Get CSP account health history
+// @Description CSP 계정의 현재 헬스 상태와 헬스 체크 이력(최신순)을 반환합니다.
+// @Tags csp-accounts
+// @Produce json
+// @Param accountId path string true "CSP Account ID"
+// @Param since query string false "조회 시작 시각 (RFC3339)"
+// @Param limit query int false "최대 건수 (기본 100, 최대 1000)"
+// @Param transitionOnly query bool false "상태 전이만 조회"
+// @Success 200 {object} model.CspHealthHistoryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/csp-accounts/id/{accountId}/health-history [get]
+// @Id getCspAccountHealthHistory
+func (h *CspHealthHandler) GetCspAccountHealthHistory(c echo.Context) error {
+	accountID, err := util.StringToUint(c.Param("accountId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid account ID"})
+	}
+	return h.getHistory(c, model.CspHealthTargetAccount, accountID)
+}
+
+func (h *CspHealthHandler) listStatus(c echo.Context, targetType model.CspHealthTargetType) error {
+	states, err := h.cspHealthService.ListStatus(targetType)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to get health status: %v", err)})
+	}
+	return c.JSON(http.StatusOK, states)
+}
+
+func (h *CspHealthHandler) getHistory(c echo.Context, targetType model.CspHealthTargetType, targetID uint) error {
+	var filter model.CspHealthHistoryFilter
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &filter); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid query parameters"})
+	}
+
+	resp, err := h.cspHealthService.GetHistory(targetType, targetID, &filter)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCspHealthFilter) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to get health history: %v", err)})
+	}
+	return c.JSON(http.StatusOK, resp)
+}
@@ -64,6 +64,8 @@ func (h *GroupRoleHandler) AssignGroupPlatformRole(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
+	setAuditTarget(c, "group.platform-role.assign", "group", c.Param("groupId"))
+	setAuditBefore(c, h.auditSnapshot(h.groupRoleService.GetGroupPlatformRoles(uint(groupID))))
 	if err := h.groupRoleService.AssignGroupPlatformRole(c.Request().Context(), uint(groupID), req.RoleID); err != nil {
 		switch {
 		case errors.Is(err, repository.ErrOrganizationNotFound):
@@ -77,6 +79,7 @@ func (h *GroupRoleHandler) AssignGroupPlatformRole(c echo.Context) error {
 		}
 	}
 
+	setAuditAfter(c, h.auditSnapshot(h.groupRoleService.GetGroupPlatformRoles(uint(groupID))))
 	return c.JSON(http.StatusCreated, map[string]string{"message": "그룹에 플랫폼 역할이 할당되었습니다."})
 }
 
@@ -223,6 +226,8 @@ func (h *GroupRoleHandler) RemoveGroupPlatformRole(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid role ID"})
 	}
 
+	setAuditTarget(c, "group.platform-role.remove", "group", c.Param("groupId"))
+	setAuditBefore(c, h.auditSnapshot(h.groupRoleService.GetGroupPlatformRoles(uint(groupID))))
 	if err := h.groupRoleService.RemoveGroupPlatformRole(c.Request().Context(), uint(groupID), uint(roleID)); err != nil {
 		switch {
 		case errors.Is(err, repository.ErrOrganizationNotFound):
@@ -236,12 +241,13 @@ func (h *GroupRoleHandler) RemoveGroupPlatformRole(c echo.Context) error {
 		}
 	}
 
+	setAuditAfter(c, h.auditSnapshot(h.groupRoleService.GetGroupPlatformRoles(uint(groupID))))
 	return c.JSON(http.StatusOK, map[string]string{"message": "그룹의 플랫폼 역할이 해제되었습니다."})
 }
 
 // AssignGroupWorkspace godoc
 // @Summary 그룹-워크스페이스 매핑
-// @Description 그룹을 워크스페이스에 매핑하고 역할을 지정합니다. DB 전용 관리.
+// @Description 그룹을 워크스페이스에 매핑하고 역할을 지정합니다. DB 전용 관리. starts_at/expires_at으로 유효 기간을 지정할 수 있으며 만료 시 자동 회수됩니다.
 // @Tags groups
 // @Accept json
 // @Produce json
@@ -268,8 +274,13 @@ func (h *GroupRoleHandler) AssignGroupWorkspace(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
-	if err := h.groupRoleService.AssignGroupWorkspace(uint(groupID), req.WorkspaceID, req.RoleID); err != nil {
+	setAuditTarget(c, "group.workspace-role.assign", "group", c.Param("groupId"))
+	setAuditBefore(c, h.auditSnapshot(h.groupRoleService.GetGroupWorkspaces(uint(groupID))))
+	period := model.RoleGrantPeriod{StartsAt: req.StartsAt, ExpiresAt: req.ExpiresAt}
+	if err := h.groupRoleService.AssignGroupWorkspaceWithPeriod(uint(groupID), req.WorkspaceID, req.RoleID, period); err != nil {
 		switch {
+		case errors.Is(err, model.ErrRoleGrantAlreadyExpired), errors.Is(err, model.ErrRoleGrantInvalidPeriod):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 		case errors.Is(err, repository.ErrWorkspaceNotFound):
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "워크스페이스를 찾을 수 없습니다"})
 		case errors.Is(err, repository.ErrRoleMasterNotFound):
@@ -281,6 +292,7 @@ func (h *GroupRoleHandler) AssignGroupWorkspace(c echo.Context) error {
 		}
 	}
 
+	setAuditAfter(c, h.auditSnapshot(h.groupRoleService.GetGroupWorkspaces(uint(groupID))))
 	return c.JSON(http.StatusCreated, map[string]string{"message": "그룹이 워크스페이스에 매핑되었습니다."})
 }
 
@@ -310,7 +322,7 @@ func (h *GroupRoleHandler) GetGroupWorkspaces(c echo.Context) error {
 
 // UpdateGroupWorkspaceRole godoc
 // @Summary 그룹 워크스페이스 역할 변경
-// @Description 그룹-워크스페이스 매핑의 역할을 변경합니다.
+// @Description 그룹-워크스페이스 매핑의 역할과 유효 기간을 변경합니다. starts_at/expires_at을 생략하면 저장된 기간을 유지하며, clear_starts_at/clear_expires_at으로 제한을 해제합니다.
 // @Tags groups
 // @Accept json
 // @Produce json
@@ -341,8 +353,12 @@ func (h *GroupRoleHandler) UpdateGroupWorkspaceRole(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
-	if err := h.groupRoleService.UpdateGroupWorkspaceRole(uint(groupID), uint(workspaceID), req.RoleID); err != nil {
+	setAuditTarget(c, "group.workspace-role.update", "group", c.Param("groupId"))
+	setAuditBefore(c, h.auditSnapshot(h.groupRoleService.GetGroupWorkspaces(uint(groupID))))
+	if err := h.groupRoleService.UpdateGroupWorkspaceRoleWithPeriod(uint(groupID), uint(workspaceID), &req); err != nil {
 		switch {
+		case errors.Is(err, model.ErrRoleGrantAlreadyExpired), errors.Is(err, model.ErrRoleGrantInvalidPeriod):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 		case errors.Is(err, repository.ErrGroupWorkspaceRoleNotFound):
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "매핑을 찾을 수 없습니다"})
 		case errors.Is(err, repository.ErrRoleMasterNotFound):
@@ -352,6 +368,7 @@ func (h *GroupRoleHandler) UpdateGroupWorkspaceRole(c echo.Context) error {
 		}
 	}
 
+	setAuditAfter(c, h.auditSnapshot(h.groupRoleService.GetGroupWorkspaces(uint(groupID))))
 	return c.JSON(http.StatusOK, map[string]string{"message": "그룹 워크스페이스 역할이 변경되었습니다."})
 }
 
@@ -378,6 +395,8 @@ func (h *GroupRoleHandler) RemoveGroupWorkspaceRole(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid workspace ID"})
 	}
 
+	setAuditTarget(c, "group.workspace-role.remove", "group", c.Param("groupId"))
+	setAuditBefore(c, h.auditSnapshot(h.groupRoleService.GetGroupWorkspaces(uint(groupID))))
 	if err := h.groupRoleService.RemoveGroupWorkspaceRole(uint(groupID), uint(workspaceID)); err != nil {
 		if errors.Is(err, repository.ErrGroupWorkspaceRoleNotFound) {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "매핑을 찾을 수 없습니다"})
@@ -385,6 +404,7 @@ func (h *GroupRoleHandler) RemoveGroupWorkspaceRole(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
+	setAuditAfter(c, h.auditSnapshot(h.groupRoleService.GetGroupWorkspaces(uint(groupID))))
 	return c.JSON(http.StatusOK, map[string]string{"message": "그룹-워크스페이스 매핑이 제거되었습니다."})
 }
 
@@ -416,6 +436,7 @@ func (h *GroupRoleHandler) AssignGroupUsers(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
+	setAuditTarget(c, "group.users.assign", "group", c.Param("groupId"))
 	if err := h.groupRoleService.AssignUsersToGroup(c.Request().Context(), uint(groupID), req.UserIDs); err != nil {
 		switch {
 		case errors.Is(err, repository.ErrOrganizationNotFound):
@@ -453,6 +474,7 @@ func (h *GroupRoleHandler) RemoveGroupUser(c echo.Context) error {
 
 	kcUserID := h.getUserKcID(uint(userID))
 
+	setAuditTarget(c, "group.users.remove", "user", c.Param("userId"))
 	if err := h.groupRoleService.RemoveUserFromGroup(c.Request().Context(), uint(userID), uint(groupID), kcUserID); err != nil {
 		switch {
 		case errors.Is(err, repository.ErrOrganizationNotFound):
@@ -495,6 +517,7 @@ func (h *GroupRoleHandler) RemoveGroupUsers(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
+	setAuditTarget(c, "group.users.remove", "group", c.Param("groupId"))
 	if err := h.groupRoleService.RemoveUsersFromGroup(c.Request().Context(), uint(groupID), req.UserIDs); err != nil {
 		switch {
 		case errors.Is(err, repository.ErrOrganizationNotFound):
@@ -536,6 +559,7 @@ func (h *GroupRoleHandler) AssignUserGroups(c echo.Context) error {
 
 	kcUserID := h.getUserKcID(uint(userID))
 
+	setAuditTarget(c, "user.groups.assign", "user", c.Param("userId"))
 	if err := h.groupRoleService.AssignUserToGroups(c.Request().Context(), uint(userID), req.GroupIDs, kcUserID); err != nil {
 		switch {
 		case errors.Is(err, repository.ErrOrganizationNotFound):
@@ -572,6 +596,7 @@ func (h *GroupRoleHandler) RemoveUserFromGroup(c echo.Context) error {
 
 	kcUserID := h.getUserKcID(uint(userID))
 
+	setAuditTarget(c, "group.users.remove", "user", c.Param("userId"))
 	if err := h.groupRoleService.RemoveUserFromGroup(c.Request().Context(), uint(userID), uint(groupID), kcUserID); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
@@ -626,3 +651,11 @@ func (h *GroupRoleHandler) GetUserAccessSummary(c echo.Context) error {
 	}
 	return c.JSON(http.StatusOK, summary)
 }
+
+// auditSnapshot 감사 스냅샷 조회 결과를 반환 (조회 실패 시 nil)
+func (h *GroupRoleHandler) auditSnapshot(v interface{}, err error) interface{} {
+	if err != nil {
+		return nil
+	}
+	return v
+}
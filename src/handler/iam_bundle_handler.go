@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+// IamBundleHandler 선언적 IAM 구성 번들 핸들러
+type IamBundleHandler struct {
+	bundleService *service.IamBundleService
+}
+
+// NewIamBundleHandler 새 IamBundleHandler 인스턴스 생성
+func NewIamBundleHandler(db *gorm.DB) *IamBundleHandler {
+	return &IamBundleHandler{
+		bundleService: service.NewIamBundleService(db),
+	}
+}
+
+// ExportIamBundle godoc
+// @Summary Export IAM configuration bundle
+// @Description 역할, role subs, MC-IAM 권한, 역할-권한/메뉴/CSP 역할 매핑, 그룹 및 그룹 역할 바인딩을 하나의 iam-bundle 문서로 내보냅니다
+// @Tags admin
+// @Produce json
+// @Produce application/yaml
+// @Param format query string false "yaml or json (default yaml)"
+// @Success 200 {object} model.IamBundle
+// @Failure 500 {object} model.Response
+// @Security BearerAuth
+// @Router /api/setup/iam-bundle [get]
+// @Id exportIamBundle
+func (h *IamBundleHandler) ExportIamBundle(c echo.Context) error {
+	bundle, err := h.bundleService.Export()
+	if err != nil {
+		log.Printf("[ERROR] ExportIamBundle failed: %v", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Error:   true,
+			Message: fmt.Sprintf("Failed to export iam bundle: %v", err),
+		})
+	}
+
+	if strings.EqualFold(strings.TrimSpace(c.QueryParam("format")), "json") {
+		return c.JSON(http.StatusOK, bundle)
+	}
+	body, err := yaml.Marshal(bundle)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Error:   true,
+			Message: fmt.Sprintf("Failed to marshal iam bundle yaml: %v", err),
+		})
+	}
+	c.Response().Header().Set("Content-Disposition", `attachment; filename="iam-bundle.yaml"`)
+	return c.Blob(http.StatusOK, "application/yaml", body)
+}
+
+// DiffIamBundle godoc
+// @Summary Diff IAM configuration bundle (dry-run)
+// @Description iam-bundle(YAML/JSON)을 적용했을 때의 변경 사항을 계산합니다. DB는 변경하지 않습니다. mode=merge|replace
+// @Tags admin
+// @Accept json
+// @Accept application/yaml
+// @Produce json
+// @Param mode query string false "merge (default) or replace"
+// @Param body body model.IamBundle true "IAM bundle"
+// @Success 200 {object} model.IamBundlePlan
+// @Failure 400 {object} model.Response
+// @Failure 500 {object} model.Response
+// @Security BearerAuth
+// @Router /api/setup/iam-bundle/diff [post]
+// @Id diffIamBundle
+func (h *IamBundleHandler) DiffIamBundle(c echo.Context) error {
+	bundle, err := readIamBundle(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Error: true, Message: err.Error()})
+	}
+	plan, err := h.bundleService.Diff(bundle, c.QueryParam("mode"))
+	if err != nil {
+		return iamBundleError(c, err)
+	}
+	return c.JSON(http.StatusOK, plan)
+}
+
+// ApplyIamBundle godoc
+// @Summary Apply IAM configuration bundle
+// @Description iam-bundle(YAML/JSON)을 적용합니다. merge는 생성/갱신만, replace는 번들에 포함된 역할/그룹의 매핑을 번들과 일치시킵니다 (역할/그룹 자체는 삭제하지 않음)
+// @Tags admin
+// @Accept json
+// @Accept application/yaml
+// @Produce json
+// @Param mode query string false "merge (default) or replace"
+// @Param body body model.IamBundle true "IAM bundle"
+// @Success 200 {object} model.IamBundlePlan
+// @Failure 400 {object} model.Response
+// @Failure 500 {object} model.Response
+// @Security BearerAuth
+// @Router /api/setup/iam-bundle/apply [post]
+// @Id applyIamBundle
+func (h *IamBundleHandler) ApplyIamBundle(c echo.Context) error {
+	bundle, err := readIamBundle(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, model.Response{Error: true, Message: err.Error()})
+	}
+
+	mode := c.QueryParam("mode")
+	setAuditTarget(c, "iam.bundle.apply", "iam_bundle", mode)
+	plan, err := h.bundleService.Apply(c.Request().Context(), bundle, mode)
+	if err != nil {
+		return iamBundleError(c, err)
+	}
+	log.Printf("[INFO] IAM bundle applied: mode=%s changes=%d warnings=%d", plan.Mode, len(plan.Changes), len(plan.Warnings))
+	setAuditAfter(c, plan)
+	return c.JSON(http.StatusOK, plan)
+}
+
+// readIamBundle 요청 본문(YAML/JSON)에서 번들 읽기
+func readIamBundle(c echo.Context) (*model.IamBundle, error) {
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, errors.New("request body is required")
+	}
+	return service.ParseIamBundle(body)
+}
+
+func iamBundleError(c echo.Context, err error) error {
+	if errors.Is(err, service.ErrInvalidIamBundle) {
+		return c.JSON(http.StatusBadRequest, model.Response{Error: true, Message: err.Error()})
+	}
+	log.Printf("[ERROR] IAM bundle failed: %v", err)
+	return c.JSON(http.StatusInternalServerError, model.Response{Error: true, Message: err.Error()})
+}
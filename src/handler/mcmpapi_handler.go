@@ -5,10 +5,9 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"strings" // Import strings
 
 	"github.com/labstack/echo/v4"
-	"github.com/m-cmp/mc-iam-manager/config" // Import config for Keycloak client
+	"github.com/m-cmp/mc-iam-manager/middleware"
 	"github.com/m-cmp/mc-iam-manager/model"
 	"github.com/m-cmp/mc-iam-manager/model/mcmpapi"
 	"github.com/m-cmp/mc-iam-manager/service"
@@ -17,9 +16,16 @@ import (
 
 const apiYamlEnvVar = "MC_ADMIN_CLI_APIYAML"
 
+// workspaceIDHeader McmpApiCall 권한 평가 워크스페이스 지정 헤더
+const workspaceIDHeader = "X-Workspace-Id"
+
+// targetNamespaceParam 프로젝트(네임스페이스)를 지정하는 대상 API 경로 파라미터
+const targetNamespaceParam = "nsId"
+
 // McmpApiHandler handles requests related to mcmp API definitions. (Renamed)
 type McmpApiHandler struct {
-	service service.McmpApiService // Use renamed service interface
+	service      service.McmpApiService // Use renamed service interface
+	authzService *service.AuthzService
 	// db *gorm.DB // Not needed directly in handler
 }
 
@@ -27,7 +33,7 @@ type McmpApiHandler struct {
 func NewMcmpApiHandler(db *gorm.DB) *McmpApiHandler { // Accept db, remove service param
 	// Initialize service internally
 	mcmpApiService := service.NewMcmpApiService(db)
-	return &McmpApiHandler{service: mcmpApiService} // Renamed struct type
+	return &McmpApiHandler{service: mcmpApiService, authzService: service.NewAuthzService(db)} // Renamed struct type
 }
 
 // SyncMcmpAPIs godoc
@@ -139,12 +145,16 @@ func (h *McmpApiHandler) SetActiveVersion(c echo.Context) error {
 // McmpApiCall godoc
 // @Summary Call an external MCMP API action (Structured Request)
 // @Description Executes a defined MCMP API action with parameters structured in McmpApiCallRequest.
+// @Description 대상 액션에 매핑된 MciamPermission을 요청자의 플랫폼 역할 또는 워크스페이스 역할(workspaceId 필드 또는 X-Workspace-Id 헤더)이 보유해야 합니다.
+// @Description 경로 파라미터 nsId가 있으면 해당 네임스페이스의 프로젝트로 평가하며, 워크스페이스는 그 프로젝트가 속한 워크스페이스여야 합니다.
 // @Tags McmpAPI
 // @Accept json
 // @Produce json
 // @Param callRequest body model.McmpApiCallRequest true "API Call Request"
+// @Param X-Workspace-Id header string false "Workspace ID for permission evaluation"
 // @Success 200 {object} object "External API Response (structure depends on the called API)"
 // @Failure 400 {object} map[string]string "error: Invalid request body or parameters"
+// @Failure 403 {object} model.McmpApiCallDeniedResponse "Caller lacks the permissions mapped to the action"
 // @Failure 404 {object} map[string]string "error: Service or action not found"
 // @Failure 500 {object} map[string]string "error: Internal server error or failed to call external API"
 // @Failure 503 {object} map[string]string "error: External API unavailable"
@@ -157,77 +167,44 @@ func (h *McmpApiHandler) McmpApiCall(c echo.Context) error { // Renamed function
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body: " + err.Error()})
 	}
 
-	// --- RPT Validation and Permission Check START ---
-	authHeader := c.Request().Header.Get("Authorization")
-	if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Authorization 헤더가 없거나 형식이 잘못되었습니다 (RPT 필요)."})
+	// 요청자의 유효 역할로 대상 액션에 매핑된 권한 보유 여부 확인
+	kcUserID, _ := c.Get("kcUserId").(string)
+	if kcUserID == "" {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "인증 정보가 없습니다"})
 	}
-	rptToken := strings.TrimPrefix(authHeader, "Bearer ")
-
-	// Validate RPT token
-	_, claims, err := config.KC.Client.DecodeAccessToken(c.Request().Context(), rptToken, config.KC.Realm)
-	if err != nil {
-		log.Printf("RPT 토큰 검증 실패 (McmpApiCall): %v", err)
-		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "유효하지 않거나 만료된 RPT 토큰입니다."})
+	if req.WorkspaceID == "" {
+		req.WorkspaceID = c.Request().Header.Get(workspaceIDHeader)
 	}
-
-	// Extract permissions from RPT
-	authClaim, ok := (*claims)["authorization"].(map[string]interface{})
-	if !ok {
-		log.Println("RPT 토큰에 'authorization' 클레임이 없습니다 (McmpApiCall).")
-		return c.JSON(http.StatusForbidden, map[string]string{"error": "권한 거부: RPT에 authorization 클레임이 없습니다."})
+	checkReq := &model.AuthzCheckRequest{
+		KcUserID:      kcUserID,
+		ServiceName:   req.ServiceName,
+		ActionName:    req.ActionName,
+		WorkspaceID:   req.WorkspaceID,
+		ProjectID:     req.ProjectID,
+		Context:       middleware.RequestAuthzContext(c),
+		PlatformRoles: requesterPlatformRoles(c),
 	}
-	permissionsClaim, ok := authClaim["permissions"].([]interface{})
-	if !ok {
-		log.Println("RPT 토큰에 'authorization.permissions' 클레임이 없습니다 (McmpApiCall).")
-		return c.JSON(http.StatusForbidden, map[string]string{"error": "권한 거부: RPT에 permissions 클레임이 없습니다."})
+	// 평가 프로젝트는 요청자 지정값이 아닌 실제 호출 대상(nsId)에서 결정
+	if err := h.authzService.BindTargetNamespace(checkReq, req.RequestParams.PathParams[targetNamespaceParam]); err != nil {
+		return authzErrorResponse(c, err)
 	}
-
-	// Check if the required permission is in the RPT claims
-	// Required permission format: "serviceName#actionName" (based on Keycloak UMA resource/scope)
-	requiredPermission := fmt.Sprintf("%s#%s", req.ServiceName, req.ActionName)
-	hasPermission := false
-	requiredParts := strings.SplitN(requiredPermission, "#", 2)
-	requiredResource := requiredParts[0]
-	requiredScope := ""
-	if len(requiredParts) > 1 {
-		requiredScope = requiredParts[1]
-	} else {
-		log.Printf("경고: requiredPermission 형식 오류 (McmpApiCall): %s", requiredPermission)
-		// Decide how to handle - maybe deny access if format is wrong?
-		// return c.JSON(http.StatusInternalServerError, map[string]string{"error": "서버 설정 오류: 잘못된 내부 권한 형식"})
-	}
-
-	for _, p := range permissionsClaim {
-		permMap, ok := p.(map[string]interface{})
-		if !ok {
-			continue
-		}
-		rsname, rsnameOk := permMap["rsname"].(string) // Or rsid
-		scopes, scopesOk := permMap["scopes"].([]interface{})
-		if !rsnameOk || !scopesOk {
-			continue
-		}
-
-		if rsname == requiredResource {
-			for _, scopeInterface := range scopes {
-				scope, ok := scopeInterface.(string)
-				if ok && (requiredScope == "" || scope == requiredScope) {
-					hasPermission = true
-					break
-				}
-			}
-		}
-		if hasPermission {
-			break
-		}
+	req.WorkspaceID, req.ProjectID = checkReq.WorkspaceID, checkReq.ProjectID
+	decision, err := h.authzService.Check(c.Request().Context(), checkReq)
+	if err != nil {
+		return authzErrorResponse(c, err)
 	}
-
-	if !hasPermission {
-		log.Printf("권한 거부 (McmpApiCall): '%s' 필요. RPT 권한: %v", requiredPermission, permissionsClaim)
-		return c.JSON(http.StatusForbidden, fmt.Sprintf("권한 거부: '%s' 권한이 필요합니다.", requiredPermission))
+	if !decision.Allowed {
+		log.Printf("권한 거부 (McmpApiCall): user=%s action=%s/%s workspace=%s reason=%s", kcUserID, req.ServiceName, req.ActionName, req.WorkspaceID, decision.Reason)
+		return c.JSON(http.StatusForbidden, model.McmpApiCallDeniedResponse{
+			Error:               "permission denied",
+			Reason:              decision.Reason,
+			ServiceName:         req.ServiceName,
+			ActionName:          req.ActionName,
+			WorkspaceID:         req.WorkspaceID,
+			ProjectID:           req.ProjectID,
+			RequiredPermissions: decision.RequiredPermissions,
+		})
 	}
-	// --- RPT Validation and Permission Check END ---
 
 	// If permission check passed, proceed to call the service
 	statusCode, respBody, serviceVersion, calledURL, err := h.service.McmpApiCall(c.Request().Context(), &req) // Get new return values
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/model/mcmpapi"
+	"github.com/m-cmp/mc-iam-manager/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const mcmpApiCallTestPermission = "mc-infra-manager:mci:create"
+
+func newTestMcmpApiCallHandler(t *testing.T) (*McmpApiHandler, *gorm.DB) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&model.User{},
+		&model.RoleMaster{},
+		&model.Workspace{},
+		&model.Project{},
+		&model.WorkspaceProject{},
+		&model.UserPlatformRole{},
+		&model.UserWorkspaceRole{},
+		&model.UserOrganization{},
+		&model.GroupPlatformRole{},
+		&model.GroupWorkspaceRole{},
+		&model.MciamRoleMciamPermission{},
+		&mcmpapi.McmpApiAction{},
+		&mcmpapi.McmpApiPermissionActionMapping{},
+		&model.PolicyRule{},
+	))
+	return NewMcmpApiHandler(db), db
+}
+
+func callMcmpApi(h *McmpApiHandler, kcUserID, body, workspaceHeader string) *httptest.ResponseRecorder {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/mcmp-apis/call", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if workspaceHeader != "" {
+		req.Header.Set(workspaceIDHeader, workspaceHeader)
+	}
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("kcUserId", kcUserID)
+	_ = h.McmpApiCall(c)
+	return rec
+}
+
+// 매핑된 권한이 없는 역할이면 구조화된 403 응답
+func TestMcmpApiCall_DeniedWithoutMappedPermission(t *testing.T) {
+	h, db := newTestMcmpApiCallHandler(t)
+	user := &model.User{Username: "caller", KcId: "kc-caller"}
+	require.NoError(t, db.Create(user).Error)
+	ws := &model.Workspace{Name: "ws1"}
+	require.NoError(t, db.Create(ws).Error)
+	role := &model.RoleMaster{Name: "viewer"}
+	require.NoError(t, db.Create(role).Error)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: user.ID, WorkspaceID: ws.ID, RoleID: role.ID}).Error)
+	action := &mcmpapi.McmpApiAction{ServiceName: "mc-infra-manager", ActionName: "PostMciDynamic", Method: "POST"}
+	require.NoError(t, db.Create(action).Error)
+	require.NoError(t, db.Create(&mcmpapi.McmpApiPermissionActionMapping{
+		PermissionID: mcmpApiCallTestPermission, ActionID: action.ID, ActionName: action.ActionName,
+	}).Error)
+
+	rec := callMcmpApi(h, user.KcId, `{"serviceName":"mc-infra-manager","actionName":"PostMciDynamic"}`, util.UintToString(ws.ID))
+	require.Equal(t, http.StatusForbidden, rec.Code)
+
+	var body model.McmpApiCallDeniedResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "mc-infra-manager", body.ServiceName)
+	assert.Equal(t, "PostMciDynamic", body.ActionName)
+	assert.Equal(t, util.UintToString(ws.ID), body.WorkspaceID, "X-Workspace-Id 헤더가 워크스페이스로 사용되어야 한다")
+	assert.Equal(t, []string{mcmpApiCallTestPermission}, body.RequiredPermissions)
+	assert.NotEmpty(t, body.Reason)
+
+	// 역할에 권한을 매핑하면 권한 검사는 통과 (이후 외부 호출 단계에서 실패하므로 403이 아님)
+	require.NoError(t, db.Create(&model.MciamRoleMciamPermission{
+		RoleType: constants.RoleTypeWorkspace, RoleID: role.ID, PermissionID: mcmpApiCallTestPermission,
+	}).Error)
+	rec = callMcmpApi(h, user.KcId, `{"serviceName":"mc-infra-manager","actionName":"PostMciDynamic","workspaceId":"`+util.UintToString(ws.ID)+`"}`, "")
+	assert.NotEqual(t, http.StatusForbidden, rec.Code)
+}
+
+// 요청자가 지정한 워크스페이스가 아닌 대상 nsId의 프로젝트가 속한 워크스페이스로 평가
+func TestMcmpApiCall_WorkspaceBoundToTargetNamespace(t *testing.T) {
+	h, db := newTestMcmpApiCallHandler(t)
+	user := &model.User{Username: "caller", KcId: "kc-caller"}
+	require.NoError(t, db.Create(user).Error)
+	own := &model.Workspace{Name: "own"}
+	require.NoError(t, db.Create(own).Error)
+	other := &model.Workspace{Name: "other"}
+	require.NoError(t, db.Create(other).Error)
+	project := &model.Project{Name: "other-ns", NsId: "other-ns"}
+	require.NoError(t, db.Create(project).Error)
+	require.NoError(t, db.Create(&model.WorkspaceProject{WorkspaceID: other.ID, ProjectID: project.ID}).Error)
+	role := &model.RoleMaster{Name: "operator"}
+	require.NoError(t, db.Create(role).Error)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: user.ID, WorkspaceID: own.ID, RoleID: role.ID}).Error)
+	require.NoError(t, db.Create(&model.MciamRoleMciamPermission{
+		RoleType: constants.RoleTypeWorkspace, RoleID: role.ID, PermissionID: mcmpApiCallTestPermission,
+	}).Error)
+	action := &mcmpapi.McmpApiAction{ServiceName: "mc-infra-manager", ActionName: "PostMciDynamic", Method: "POST"}
+	require.NoError(t, db.Create(action).Error)
+	require.NoError(t, db.Create(&mcmpapi.McmpApiPermissionActionMapping{
+		PermissionID: mcmpApiCallTestPermission, ActionID: action.ID, ActionName: action.ActionName,
+	}).Error)
+
+	body := `{"serviceName":"mc-infra-manager","actionName":"PostMciDynamic","requestParams":{"pathParams":{"nsId":"other-ns"}}}`
+	rec := callMcmpApi(h, user.KcId, body, util.UintToString(own.ID))
+	require.Equal(t, http.StatusForbidden, rec.Code, "다른 워크스페이스의 네임스페이스는 자신의 워크스페이스 역할로 호출할 수 없어야 한다")
+	var denied model.McmpApiCallDeniedResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &denied))
+	assert.Equal(t, util.UintToString(project.ID), denied.ProjectID)
+
+	// 대상 nsId와 다른 projectId 지정은 거부
+	body = `{"serviceName":"mc-infra-manager","actionName":"PostMciDynamic","projectId":"999","requestParams":{"pathParams":{"nsId":"other-ns"}}}`
+	rec = callMcmpApi(h, user.KcId, body, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// 등록되지 않은 네임스페이스에는 워크스페이스 역할이 적용되지 않음
+	body = `{"serviceName":"mc-infra-manager","actionName":"PostMciDynamic","requestParams":{"pathParams":{"nsId":"unknown-ns"}}}`
+	rec = callMcmpApi(h, user.KcId, body, util.UintToString(own.ID))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestMcmpApiCall_RequiresAuthenticatedCaller(t *testing.T) {
+	h, _ := newTestMcmpApiCallHandler(t)
+	rec := callMcmpApi(h, "", `{"serviceName":"mc-infra-manager","actionName":"PostMciDynamic"}`, "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
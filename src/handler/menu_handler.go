@@ -74,6 +74,14 @@ func (h *MenuHandler) ListUserMenuTree(c echo.Context) error {
 		}
 		platformRoleNames = append(platformRoleNames, role.ID)
 	}
+	// JWT 역할이 DB에 없으면(예: platformAdmin) admin 역할로 자동 fallback
+	if len(platformRoleNames) == 0 && len(platformRoles) > 0 {
+		adminRole, err := h.roleService.GetRoleByName("admin", constants.RoleTypePlatform)
+		if err == nil && adminRole != nil {
+			c.Logger().Debug("Falling back to admin role for unrecognized platform roles: %v", platformRoles)
+			platformRoleNames = append(platformRoleNames, adminRole.ID)
+		}
+	}
 
 	// Call the service method with platform role IDs
 	menuTree, err := h.menuService.BuildUserMenuTree(c.Request().Context(), platformRoleNames)
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/pkg/metrics"
+)
+
+// MetricsHandler Prometheus 지표 노출 핸들러
+type MetricsHandler struct {
+	token   string
+	handler http.Handler
+}
+
+// NewMetricsHandler 새 MetricsHandler 인스턴스 생성 (token이 비어 있으면 비활성)
+func NewMetricsHandler(token string) *MetricsHandler {
+	return &MetricsHandler{token: token, handler: metrics.Handler()}
+}
+
+// GetMetrics godoc
+// @Summary Prometheus metrics
+// @Description 라우트별 요청 수/지연 시간, 인증 실패 사유, CSP 임시 자격 증명 발급 결과, Keycloak/MCMP API 호출 지연 시간, DB 커넥션 풀 지표를 Prometheus 형식으로 반환합니다. MC_IAM_MANAGER_METRICS_TOKEN Bearer 토큰이 필요하며, 미설정 시 비활성(404)입니다.
+// @Tags health
+// @Produce plain
+// @Success 200 {string} string "Prometheus text format"
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /metrics [get]
+// @Id getMetrics
+func (h *MetricsHandler) GetMetrics(c echo.Context) error {
+	if h.token == "" {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "Metrics endpoint is disabled"})
+	}
+	given, ok := strings.CutPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(given)), []byte(h.token)) != 1 {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid metrics token"})
+	}
+	h.handler.ServeHTTP(c.Response(), c.Request())
+	return nil
+}
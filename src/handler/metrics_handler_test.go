@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMetrics_RecordsRoutePatternAndRequiresToken(t *testing.T) {
+	e := echo.New()
+	e.Use(middleware.MetricsMiddleware)
+	e.GET("/api/users/id/:userId", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusNotFound, "not found")
+	})
+	e.GET("/metrics", NewMetricsHandler("secret").GetMetrics)
+
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/users/id/42", nil))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `mciam_http_requests_total{method="GET",route="/api/users/id/:userId",status="404"} 1`)
+	assert.NotContains(t, rec.Body.String(), "/api/users/id/42")
+}
+
+// 토큰 미설정 시 /metrics 비활성
+func TestGetMetrics_DisabledWithoutToken(t *testing.T) {
+	e := echo.New()
+	e.GET("/metrics", NewMetricsHandler("").GetMetrics)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"gorm.io/gorm"
+)
+
+// OffboardingHandler 사용자 오프보딩 핸들러
+type OffboardingHandler struct {
+	offboardingService *service.OffboardingService
+}
+
+// NewOffboardingHandler 새 OffboardingHandler 인스턴스 생성
+func NewOffboardingHandler(db *gorm.DB) *OffboardingHandler {
+	return &OffboardingHandler{
+		offboardingService: service.NewOffboardingService(db),
+	}
+}
+
+// offboardingErrorStatus 서비스 오류를 HTTP 상태 코드로 변환
+func offboardingErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrOffboardingNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrOffboardingExists), errors.Is(err, service.ErrOffboardingNotOpen),
+		errors.Is(err, service.ErrOffboardingBlocked):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrOffboardingInvalid):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// parseOffboardingID 경로의 offboardingId 파싱
+func parseOffboardingID(c echo.Context) (uint, error) {
+	id, err := strconv.ParseUint(c.Param("offboardingId"), 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid offboarding ID")
+	}
+	return uint(id), nil
+}
+
+// bindOffboardingRequest 사전 점검/예약 요청 바인딩
+func bindOffboardingRequest(c echo.Context) (*model.OffboardingRequest, error) {
+	var req model.OffboardingRequest
+	if err := c.Bind(&req); err != nil {
+		return nil, errors.New("invalid request format")
+	}
+	if req.UserID == 0 {
+		return nil, errors.New("userId is required")
+	}
+	return &req, nil
+}
+
+// PreviewOffboarding godoc
+// @Summary Preview user offboarding
+// @Description Pre-flight report of what offboarding the user would transfer, revoke or break (e.g. workspaces left without an admin) with the given reassignment targets
+// @Tags offboardings
+// @Accept json
+// @Produce json
+// @Param body body model.OffboardingRequest true "Offboarding target and reassignment targets"
+// @Success 200 {object} model.OffboardingReport
+// @Failure 400 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/offboardings/preview [post]
+// @Id previewOffboarding
+func (h *OffboardingHandler) PreviewOffboarding(c echo.Context) error {
+	req, err := bindOffboardingRequest(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	report, err := h.offboardingService.Preview(req)
+	if err != nil {
+		return c.JSON(offboardingErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, report)
+}
+
+// ScheduleOffboarding godoc
+// @Summary Schedule user offboarding
+// @Description Schedule offboarding after a grace period (default MC_IAM_MANAGER_OFFBOARDING_GRACE_DAYS). On execution, owned resources are transferred to the reassignment targets, cached credentials and sessions are revoked and the user is withdrawn.
+// @Tags offboardings
+// @Accept json
+// @Produce json
+// @Param body body model.OffboardingRequest true "Offboarding target, reassignment targets and grace period"
+// @Success 201 {object} model.Offboarding
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/offboardings [post]
+// @Id scheduleOffboarding
+func (h *OffboardingHandler) ScheduleOffboarding(c echo.Context) error {
+	req, err := bindOffboardingRequest(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	requestedBy, _ := c.Get("kcUserId").(string)
+
+	setAuditTarget(c, "user.offboard.schedule", "user", strconv.FormatUint(uint64(req.UserID), 10))
+	offboarding, err := h.offboardingService.Schedule(req, requestedBy)
+	if err != nil {
+		return c.JSON(offboardingErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	setAuditAfter(c, offboarding)
+	return c.JSON(http.StatusCreated, offboarding)
+}
+
+// ListOffboardings godoc
+// @Summary List user offboardings
+// @Description List scheduled, completed, cancelled and failed offboardings
+// @Tags offboardings
+// @Accept json
+// @Produce json
+// @Param status query string false "Filter by status (SCHEDULED/COMPLETED/CANCELLED/FAILED)"
+// @Param userId query int false "Filter by user DB ID"
+// @Success 200 {array} model.Offboarding
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/offboardings [get]
+// @Id listOffboardings
+func (h *OffboardingHandler) ListOffboardings(c echo.Context) error {
+	var filter model.OffboardingFilterRequest
+	if err := c.Bind(&filter); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request format"})
+	}
+	offboardings, err := h.offboardingService.ListOffboardings(&filter)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, offboardings)
+}
+
+// GetOffboarding godoc
+// @Summary Get user offboarding
+// @Description Get an offboarding with its pre-flight or execution report
+// @Tags offboardings
+// @Accept json
+// @Produce json
+// @Param offboardingId path int true "Offboarding ID"
+// @Success 200 {object} model.Offboarding
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/offboardings/id/{offboardingId} [get]
+// @Id getOffboarding
+func (h *OffboardingHandler) GetOffboarding(c echo.Context) error {
+	offboardingID, err := parseOffboardingID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	offboarding, err := h.offboardingService.GetOffboarding(offboardingID)
+	if err != nil {
+		return c.JSON(offboardingErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, offboarding)
+}
+
+// CancelOffboarding godoc
+// @Summary Cancel user offboarding
+// @Description Cancel a scheduled or failed offboarding during the grace period
+// @Tags offboardings
+// @Accept json
+// @Produce json
+// @Param offboardingId path int true "Offboarding ID"
+// @Success 200 {object} model.Offboarding
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/offboardings/id/{offboardingId}/cancel [put]
+// @Id cancelOffboarding
+func (h *OffboardingHandler) CancelOffboarding(c echo.Context) error {
+	offboardingID, err := parseOffboardingID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	setAuditTarget(c, "user.offboard.cancel", "offboarding", c.Param("offboardingId"))
+	offboarding, err := h.offboardingService.CancelOffboarding(offboardingID)
+	if err != nil {
+		return c.JSON(offboardingErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	setAuditAfter(c, offboarding)
+	return c.JSON(http.StatusOK, offboarding)
+}
+
+// ExecuteOffboarding godoc
+// @Summary Execute user offboarding now
+// @Description Run the final offboarding step immediately without waiting for the grace period. Fails with 409 while pre-flight blockers remain.
+// @Tags offboardings
+// @Accept json
+// @Produce json
+// @Param offboardingId path int true "Offboarding ID"
+// @Success 200 {object} model.Offboarding
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/offboardings/id/{offboardingId}/execute [post]
+// @Id executeOffboarding
+func (h *OffboardingHandler) ExecuteOffboarding(c echo.Context) error {
+	offboardingID, err := parseOffboardingID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	setAuditTarget(c, "user.offboard", "offboarding", c.Param("offboardingId"))
+	offboarding, err := h.offboardingService.ExecuteNow(c.Request().Context(), offboardingID)
+	if err != nil {
+		return c.JSON(offboardingErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	setAuditAfter(c, offboarding)
+	return c.JSON(http.StatusOK, offboarding)
+}
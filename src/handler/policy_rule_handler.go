@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"github.com/m-cmp/mc-iam-manager/util"
+	"gorm.io/gorm"
+)
+
+// PolicyRuleHandler 명시적 허용/거부 정책 규칙 관리 핸들러
+type PolicyRuleHandler struct {
+	policyRuleService *service.PolicyRuleService
+}
+
+// NewPolicyRuleHandler 새 PolicyRuleHandler 인스턴스 생성
+func NewPolicyRuleHandler(db *gorm.DB) *PolicyRuleHandler {
+	return &PolicyRuleHandler{
+		policyRuleService: service.NewPolicyRuleService(db),
+	}
+}
+
+// CreatePolicyRule godoc
+// @Summary Create policy rule
+// @Description 역할 또는 그룹에 붙는 허용/거부 규칙을 생성합니다. 대상은 permissionId(끝의 '*'는 접두어 일치, deny만) 또는 serviceName+actionName이며, 조건(timeWindows, sourceCidrs, requiredAmr, negate)이 모두 만족될 때 적용됩니다. deny가 allow보다 우선하며 platformAdmin에는 적용되지 않습니다. 권한 캐시로 인해 최대 30초 후 반영됩니다.
+// @Tags policy-rules
+// @Accept json
+// @Produce json
+// @Param request body model.PolicyRule true "Policy rule"
+// @Success 201 {object} model.PolicyRule
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/policy-rules [post]
+// @Id createPolicyRule
+func (h *PolicyRuleHandler) CreatePolicyRule(c echo.Context) error {
+	var req model.PolicyRule
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	actor, _ := c.Get("kcUserId").(string)
+	setAuditTarget(c, "policy-rule.create", "policy-rule", req.Name)
+	rule, err := h.policyRuleService.Create(&req, actor)
+	if err != nil {
+		return policyRuleError(c, err)
+	}
+	setAuditAfter(c, rule)
+	return c.JSON(http.StatusCreated, rule)
+}
+
+// ListPolicyRules godoc
+// @Summary List policy rules
+// @Description 정책 규칙 목록을 반환합니다.
+// @Tags policy-rules
+// @Produce json
+// @Param subjectType query string false "role | group"
+// @Param subjectId query string false "Role or group ID"
+// @Param effect query string false "allow | deny"
+// @Success 200 {array} model.PolicyRule
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/policy-rules [get]
+// @Id listPolicyRules
+func (h *PolicyRuleHandler) ListPolicyRules(c echo.Context) error {
+	var filter model.PolicyRuleFilter
+	if err := c.Bind(&filter); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid query parameters"})
+	}
+	rules, err := h.policyRuleService.List(&filter)
+	if err != nil {
+		return policyRuleError(c, err)
+	}
+	return c.JSON(http.StatusOK, rules)
+}
+
+// GetPolicyRule godoc
+// @Summary Get policy rule
+// @Description 정책 규칙을 조회합니다.
+// @Tags policy-rules
+// @Produce json
+// @Param ruleId path string true "Policy rule ID"
+// @Success 200 {object} model.PolicyRule
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/policy-rules/id/{ruleId} [get]
+// @Id getPolicyRule
+func (h *PolicyRuleHandler) GetPolicyRule(c echo.Context) error {
+	id, err := util.StringToUint(c.Param("ruleId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid policy rule ID"})
+	}
+	rule, err := h.policyRuleService.Get(id)
+	if err != nil {
+		return policyRuleError(c, err)
+	}
+	return c.JSON(http.StatusOK, rule)
+}
+
+// UpdatePolicyRule godoc
+// @Summary Update policy rule
+// @Description 정책 규칙 전체를 수정합니다. enabled=false로 규칙을 삭제하지 않고 중지할 수 있습니다.
+// @Tags policy-rules
+// @Accept json
+// @Produce json
+// @Param ruleId path string true "Policy rule ID"
+// @Param request body model.PolicyRule true "Policy rule"
+// @Success 200 {object} model.PolicyRule
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/policy-rules/id/{ruleId} [put]
+// @Id updatePolicyRule
+func (h *PolicyRuleHandler) UpdatePolicyRule(c echo.Context) error {
+	id, err := util.StringToUint(c.Param("ruleId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid policy rule ID"})
+	}
+	var req model.PolicyRule
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	setAuditTarget(c, "policy-rule.update", "policy-rule", c.Param("ruleId"))
+	if before, err := h.policyRuleService.Get(id); err == nil {
+		setAuditBefore(c, before)
+	}
+	rule, err := h.policyRuleService.Update(id, &req)
+	if err != nil {
+		return policyRuleError(c, err)
+	}
+	setAuditAfter(c, rule)
+	return c.JSON(http.StatusOK, rule)
+}
+
+// DeletePolicyRule godoc
+// @Summary Delete policy rule
+// @Description 정책 규칙을 삭제합니다.
+// @Tags policy-rules
+// @Param ruleId path string true "Policy rule ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/policy-rules/id/{ruleId} [delete]
+// @Id deletePolicyRule
+func (h *PolicyRuleHandler) DeletePolicyRule(c echo.Context) error {
+	id, err := util.StringToUint(c.Param("ruleId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid policy rule ID"})
+	}
+	setAuditTarget(c, "policy-rule.delete", "policy-rule", c.Param("ruleId"))
+	if before, err := h.policyRuleService.Get(id); err == nil {
+		setAuditBefore(c, before)
+	}
+	if err := h.policyRuleService.Delete(id); err != nil {
+		return policyRuleError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// policyRuleError 정책 규칙 서비스 오류를 HTTP 응답으로 변환
+func policyRuleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrPolicyRuleNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrPolicyRuleExists):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidPolicyRule):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+}
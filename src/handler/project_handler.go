@@ -71,6 +71,9 @@ func (h *ProjectHandler) CreateProject(c echo.Context) error {
 		if err != nil {
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": "잘못된 워크스페이스 ID 형식입니다"})
 		}
+		if denied, err := denyOtherTenantResource(c, model.TenantResourceWorkspace, workspaceIDInt); denied {
+			return err
+		}
 		workspaceID = workspaceIDInt
 	}
 
@@ -355,6 +358,9 @@ func (h *ProjectHandler) ApplyProjectSync(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "잘못된 workspaceId 형식입니다"})
 	}
+	if denied, err := denyOtherTenantResource(c, model.TenantResourceWorkspace, workspaceIDInt); denied {
+		return err
+	}
 
 	result, err := h.projectService.ApplyProjectSyncToWorkspace(c.Request().Context(), workspaceIDInt, req.NsIds)
 	if err != nil {
@@ -398,6 +404,9 @@ func (h *ProjectHandler) AddWorkspaceToProject(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "잘못된 워크스페이스 ID 형식입니다"})
 	}
+	if denied, err := denyOtherTenantResource(c, model.TenantResourceWorkspace, workspaceIDInt); denied {
+		return err
+	}
 
 	for _, projectID := range req.ProjectIDs {
 		projectIDInt, err := util.StringToUint(projectID)
@@ -447,6 +456,9 @@ func (h *ProjectHandler) RemoveWorkspaceFromProject(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "잘못된 워크스페이스 ID 형식입니다"})
 	}
+	if denied, err := denyOtherTenantResource(c, model.TenantResourceWorkspace, workspaceIDInt); denied {
+		return err
+	}
 
 	for _, projectID := range req.ProjectIDs {
 		projectIDInt, err := util.StringToUint(projectID)
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/service"
+)
+
+// ProjectSyncHandler 프로젝트 주기 동기화 상태 핸들러
+type ProjectSyncHandler struct {
+	worker *service.ProjectSyncWorker
+}
+
+// NewProjectSyncHandler 새 ProjectSyncHandler 인스턴스 생성
+func NewProjectSyncHandler(worker *service.ProjectSyncWorker) *ProjectSyncHandler {
+	return &ProjectSyncHandler{worker: worker}
+}
+
+// GetProjectSyncStatus godoc
+// @Summary 프로젝트 주기 동기화 상태 조회
+// @Description mc-infra-manager 프로젝트 주기 동기화 설정(주기, dry-run, 대상 워크스페이스, orphan 정책)과 마지막 실행 결과(차이, 생성/할당/실패 목록)를 반환합니다.
+// @Tags projects
+// @Produce json
+// @Success 200 {object} model.ProjectSyncStatusResponse
+// @Security BearerAuth
+// @Router /api/setup/projects/sync-status [get]
+// @Id getProjectSyncStatus
+func (h *ProjectSyncHandler) GetProjectSyncStatus(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.worker.Status())
+}
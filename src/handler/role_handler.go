@@ -1,11 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/m-cmp/mc-iam-manager/constants"
@@ -22,6 +24,8 @@ type RoleHandler struct {
 	keycloakService service.KeycloakService
 	menuService     *service.MenuService
 	cspRoleService  *service.CspRoleService
+	// 플랫폼 역할 제거 시 기존 access token 폐기
+	tokenRevocationService *service.TokenRevocationService
 }
 
 // NewRoleHandler create new RoleHandler instance
@@ -37,6 +41,8 @@ func NewRoleHandler(db *gorm.DB) *RoleHandler {
 		keycloakService: keycloakService,
 		menuService:     menuService,
 		cspRoleService:  cspRoleService,
+
+		tokenRevocationService: service.NewTokenRevocationService(db),
 	}
 }
 
@@ -55,6 +61,7 @@ func (h *RoleHandler) ListRoles(c echo.Context) error {
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request format"})
 	}
+	req.CompanyID = requestCompanyID(c)
 	roles, err := h.roleService.ListRoles(&req)
 	if err != nil {
 		log.Printf("Failed to retrieve role list: %v", err)
@@ -97,6 +104,11 @@ func (h *RoleHandler) CreateRole(c echo.Context) error {
 		Name:        req.Name,
 		Description: req.Description,
 		ParentID:    req.ParentID,
+		CompanyID:   requestCompanyID(c),
+	}
+	// 상위 역할 검증은 CSP 역할 생성(외부 호출) 전에 수행
+	if err := h.roleService.ValidateRoleParent(0, role.ParentID, role.CompanyID); err != nil {
+		return roleHierarchyErrorResponse(c, err)
 	}
 
 	roleSubs := make([]model.RoleSub, 0)
@@ -150,6 +162,9 @@ func (h *RoleHandler) CreateRole(c echo.Context) error {
 	// 2. Create role and all dependencies together in transaction
 	createdRole, err := h.roleService.CreateRoleWithAllDependencies(role, roleSubs, req.MenuIDs, createdCspRoles, req.Description)
 	if err != nil {
+		if isRoleHierarchyError(err) {
+			return roleHierarchyErrorResponse(c, err)
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
@@ -196,6 +211,35 @@ func (h *RoleHandler) GetRoleByRoleID(c echo.Context) error {
 	return c.JSON(http.StatusOK, role)
 }
 
+// @Summary Get role effective grants
+// @Description 역할이 가진 메뉴, MciamPermission, CSP 역할 매핑을 상위 역할(parent_id) 상속분과 함께 반환합니다. 같은 항목은 가까운 역할 기준으로 한 번만 표시됩니다.
+// @Tags roles
+// @Produce json
+// @Param roleId path string true "Role ID"
+// @Success 200 {object} model.RoleEffectiveGrantsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/roles/id/{roleId}/effective-grants [get]
+// @Id getRoleEffectiveGrants
+func (h *RoleHandler) GetRoleEffectiveGrants(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("roleId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid roleId ID format"})
+	}
+
+	grants, err := h.roleService.GetRoleEffectiveGrants(uint(id))
+	if err != nil {
+		log.Printf("Failed to retrieve role effective grants - ID: %d, error: %v", id, err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to retrieve role effective grants: %v", err)})
+	}
+	if grants == nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "Role with the specified ID not found"})
+	}
+	return c.JSON(http.StatusOK, grants)
+}
+
 // @Summary Get role by Name
 // @Description Retrieve role details by role name.
 // @Tags roles
@@ -275,6 +319,9 @@ func (h *RoleHandler) UpdateRole(c echo.Context) error {
 
 	updatedRole, err := h.roleService.UpdateRoleWithSubs(role, req.RoleTypes)
 	if err != nil {
+		if isRoleHierarchyError(err) {
+			return roleHierarchyErrorResponse(c, err)
+		}
 		log.Printf("Failed to update role - ID: %d, error: %v", roleIdInt, err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to update role: %v", err)})
 	}
@@ -551,24 +598,33 @@ func (h *RoleHandler) AssignRole(c echo.Context) error {
 			log.Printf("Workspace ID conversion error: %v", err)
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid workspace ID format"})
 		}
+		if denied, err := denyOtherTenantResource(c, model.TenantResourceWorkspace, workspaceID); denied {
+			return err
+		}
 	}
 
 	// Assign role
 	if reqRoleType == constants.RoleTypePlatform {
+		setAuditTarget(c, "role.assign.platform", "user", util.UintToString(userID))
+		setAuditBefore(c, h.auditUserPlatformRoles(userID))
 		if err := h.roleService.AssignPlatformRole(userID, roleID); err != nil {
 			log.Printf("Failed to assign platform role - userID: %d, roleID: %s, error: %v", userID, req.RoleID, err)
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to assign platform role: %v", err)})
 		}
+		setAuditAfter(c, h.auditUserPlatformRoles(userID))
 	} else if reqRoleType == constants.RoleTypeWorkspace {
 		if workspaceID == 0 {
 			log.Printf("Workspace ID missing - userID: %d, roleID: %s", userID, req.RoleID)
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Workspace ID is required"})
 		}
+		setAuditTarget(c, "role.assign.workspace", "user", util.UintToString(userID))
+		setAuditBefore(c, h.auditUserWorkspaceRoles(userID, workspaceID))
 		if err := h.roleService.AssignWorkspaceRole(userID, workspaceID, roleID); err != nil {
 			log.Printf("Failed to assign workspace role - userID: %d, workspaceID: %d, roleID: %s, error: %v",
 				userID, workspaceID, req.RoleID, err)
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to assign workspace role: %v", err)})
 		}
+		setAuditAfter(c, h.auditUserWorkspaceRoles(userID, workspaceID))
 	}
 
 	log.Printf("Successfully assigned role - userID: %d, roleID: %s, roleType: %s", userID, req.RoleID, reqRoleType)
@@ -649,9 +705,15 @@ func (h *RoleHandler) RemoveRole(c echo.Context) error {
 
 	// 역할 제거
 	if reqRoleType == constants.RoleTypePlatform {
+		setAuditTarget(c, "role.unassign.platform", "user", util.UintToString(userID))
+		setAuditBefore(c, h.auditUserPlatformRoles(userID))
 		if err := h.roleService.RemovePlatformRole(userID, roleID); err != nil {
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("플랫폼 역할 제거 실패: %v", err)})
 		}
+		if user, err := h.userService.GetUserByID(c.Request().Context(), userID); err == nil && user != nil {
+			h.revokePlatformRoleTokens(c, user.KcId)
+		}
+		setAuditAfter(c, h.auditUserPlatformRoles(userID))
 	} else if reqRoleType == constants.RoleTypeWorkspace {
 		var workspaceID uint
 		if req.WorkspaceID == "" {
@@ -662,11 +724,17 @@ func (h *RoleHandler) RemoveRole(c echo.Context) error {
 		if err != nil {
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": "잘못된 역할 ID 형식입니다"})
 		}
+		if denied, err := denyOtherTenantResource(c, model.TenantResourceWorkspace, workspaceIDInt); denied {
+			return err
+		}
 		workspaceID = workspaceIDInt
 
+		setAuditTarget(c, "role.unassign.workspace", "user", util.UintToString(userID))
+		setAuditBefore(c, h.auditUserWorkspaceRoles(userID, workspaceID))
 		if err := h.roleService.RemoveWorkspaceRole(userID, workspaceID, roleID); err != nil {
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("워크스페이스 역할 제거 실패: %v", err)})
 		}
+		setAuditAfter(c, h.auditUserWorkspaceRoles(userID, workspaceID))
 	} else {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "지원하지 않는 역할 타입입니다"})
 	}
@@ -695,6 +763,7 @@ func (h *RoleHandler) ListPlatformRoles(c echo.Context) error {
 	if req.RoleTypes == nil {
 		req.RoleTypes = []constants.IAMRoleType{constants.RoleTypePlatform}
 	}
+	req.CompanyID = requestCompanyID(c)
 
 	roles, err := h.roleService.ListRoles(&req)
 	if err != nil {
@@ -727,6 +796,7 @@ func (h *RoleHandler) ListWorkspaceRoles(c echo.Context) error {
 	if req.RoleTypes == nil {
 		req.RoleTypes = []constants.IAMRoleType{constants.RoleTypeWorkspace}
 	}
+	req.CompanyID = requestCompanyID(c)
 	log.Printf("req ListWorkspaceRoles : %v", req)
 	roles, err := h.roleService.ListWorkspaceRoles(&req)
 	if err != nil {
@@ -792,6 +862,7 @@ func (h *RoleHandler) CreatePlatformRole(c echo.Context) error {
 		Name:        req.Name,
 		Description: req.Description,
 		Predefined:  false,
+		CompanyID:   requestCompanyID(c),
 	}
 
 	// Create RoleSubs
@@ -846,6 +917,7 @@ func (h *RoleHandler) CreateWorkspaceRole(c echo.Context) error {
 		Name:        req.Name,
 		Description: req.Description,
 		Predefined:  false,
+		CompanyID:   requestCompanyID(c),
 	}
 
 	// Create RoleSubs
@@ -1202,6 +1274,9 @@ func (h *RoleHandler) UpdateCspRole(c echo.Context) error {
 
 	updatedRole, err := h.roleService.UpdateRoleWithSubs(role, req.RoleTypes)
 	if err != nil {
+		if isRoleHierarchyError(err) {
+			return roleHierarchyErrorResponse(c, err)
+		}
 		log.Printf("csp 역할 수정 실패 - ID: %d, 에러: %v", roleIDInt, err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("역할 수정 실패: %v", err)})
 	}
@@ -1430,7 +1505,7 @@ func (h *RoleHandler) GetUserWorkspaceRoles(c echo.Context) error {
 }
 
 // @Summary Assign platform role
-// @Description Assign a platform role to a user
+// @Description Assign a platform role to a user. Optional expiresAt limits the grant; expired grants are revoked from DB and Keycloak automatically. If the role is already assigned, its expiry is replaced with expiresAt (omitted means no expiry)
 // @Tags roles
 // @Accept json
 // @Produce json
@@ -1451,6 +1526,10 @@ func (h *RoleHandler) AssignPlatformRole(c echo.Context) error {
 	if req.UserID == "" || req.RoleID == "" {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "사용자 ID와 역할 ID가 필요합니다"})
 	}
+	period := model.RoleGrantPeriod{ExpiresAt: req.ExpiresAt}
+	if err := period.Validate(time.Now()); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
 
 	var roleID uint
 	var userID uint
@@ -1461,6 +1540,9 @@ func (h *RoleHandler) AssignPlatformRole(c echo.Context) error {
 		}
 		roleID = uint(rid)
 	}
+	if denied, err := denyOtherTenantResource(c, model.TenantResourceRole, roleID); denied {
+		return err
+	}
 	if req.UserID != "" {
 		uid, err := util.StringToUint(req.UserID)
 		if err != nil {
@@ -1488,13 +1570,19 @@ func (h *RoleHandler) AssignPlatformRole(c echo.Context) error {
 		return c.JSON(http.StatusNotFound, map[string]string{"error": "해당 사용자를 찾을 수 없습니다"})
 	}
 
+	setAuditTarget(c, "role.assign.platform", "user", util.UintToString(userID))
+	setAuditBefore(c, h.auditUserPlatformRoles(userID))
+
 	// 이미 할당 되어있는지 확인.
 	isAssignedPlatformRole, err := h.roleService.IsAssignedPlatformRole(userID, roleID)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("역할 할당 확인 실패: %v", err)})
 	}
 	if isAssignedPlatformRole {
-		// DB에는 이미 있음 — Keycloak 동기화 상태 확인 (idempotent 처리)
+		// DB에는 이미 있음 — 요청한 만료 시각으로 갱신 후 Keycloak 동기화 상태 확인 (idempotent 처리)
+		if err := h.roleService.UpdatePlatformRolePeriod(userID, roleID, period); err != nil {
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("플랫폼 역할 기간 변경 실패: %v", err)})
+		}
 		isKcAssigned, err := h.keycloakService.IsRealmRoleAssignedToUser(c.Request().Context(), user.KcId, req.RoleName)
 		if err != nil {
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("키클로크 역할 확인 실패: %v", err)})
@@ -1518,8 +1606,8 @@ func (h *RoleHandler) AssignPlatformRole(c echo.Context) error {
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("키클로크 역할 할당 실패: %v", err)})
 		}
 	} else {
-		// DB에 역할 할당
-		if err := h.roleService.AssignPlatformRole(userID, roleID); err != nil {
+		// DB에 역할 할당 (만료 시각 지정 시 RoleGrantReaper가 만료 후 DB/Keycloak에서 회수)
+		if err := h.roleService.AssignPlatformRoleWithPeriod(userID, roleID, period); err != nil {
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("플랫폼 역할 할당 실패: %v", err)})
 		}
 
@@ -1557,6 +1645,7 @@ func (h *RoleHandler) AssignPlatformRole(c echo.Context) error {
 		}
 	}
 
+	setAuditAfter(c, h.auditUserPlatformRoles(userID))
 	return c.JSON(http.StatusOK, map[string]string{"message": "플랫폼 역할이 성공적으로 할당되었습니다"})
 }
 
@@ -1643,6 +1732,9 @@ func (h *RoleHandler) RemovePlatformRole(c echo.Context) error {
 		return c.JSON(http.StatusNotFound, map[string]string{"error": "해당 역할을 찾을 수 없습니다"})
 	}
 
+	setAuditTarget(c, "role.unassign.platform", "user", util.UintToString(userID))
+	setAuditBefore(c, h.auditUserPlatformRoles(userID))
+
 	// DB에서 역할 제거
 	if err := h.roleService.RemovePlatformRole(userID, roleID); err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("플랫폼 역할 제거 실패: %v", err)})
@@ -1662,11 +1754,24 @@ func (h *RoleHandler) RemovePlatformRole(c echo.Context) error {
 		}
 	}
 
+	h.revokePlatformRoleTokens(c, user.KcId)
+	setAuditAfter(c, h.auditUserPlatformRoles(userID))
 	return c.JSON(http.StatusOK, map[string]string{"message": "플랫폼 역할이 성공적으로 제거되었습니다"})
 }
 
+// revokePlatformRoleTokens 제거된 역할이 담긴 기존 access token 폐기 (Keycloak 세션은 유지되어 재발급 가능)
+func (h *RoleHandler) revokePlatformRoleTokens(c echo.Context, kcUserID string) {
+	if kcUserID == "" {
+		return
+	}
+	actor, _ := c.Get("kcUserId").(string)
+	if _, err := h.tokenRevocationService.RevokeUserTokens(kcUserID, service.TokenRevokedByRoleRemoval, actor); err != nil {
+		log.Printf("[WARN] failed to revoke tokens of user %s: %v", kcUserID, err)
+	}
+}
+
 // @Summary Assign workspace role
-// @Description Assign a workspace role to a user
+// @Description Assign a workspace role to a user. Optional startsAt/expiresAt limit the grant period; expired grants are revoked automatically
 // @Tags roles
 // @Accept json
 // @Produce json
@@ -1687,6 +1792,11 @@ func (h *RoleHandler) AssignWorkspaceRole(c echo.Context) error {
 
 	log.Printf("AssignWorkspaceRoleReq : %v", req)
 
+	period := model.RoleGrantPeriod{StartsAt: req.StartsAt, ExpiresAt: req.ExpiresAt}
+	if err := period.Validate(time.Now()); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
 	// 사용자 ID 처리
 	var userID uint
 	if req.UserID != "" {
@@ -1732,6 +1842,9 @@ func (h *RoleHandler) AssignWorkspaceRole(c echo.Context) error {
 	} else {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "역할 ID 또는 역할명이 필요합니다"})
 	}
+	if denied, err := denyOtherTenantResource(c, model.TenantResourceRole, roleID); denied {
+		return err
+	}
 
 	// 워크스페이스 ID 처리
 	var workspaceID uint
@@ -1741,17 +1854,24 @@ func (h *RoleHandler) AssignWorkspaceRole(c echo.Context) error {
 		if err != nil {
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": "잘못된 워크스페이스 ID 형식입니다"})
 		}
+		if denied, err := denyOtherTenantResource(c, model.TenantResourceWorkspace, workspaceIDInt); denied {
+			return err
+		}
 		workspaceID = workspaceIDInt
 	} else {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "워크스페이스 ID가 필요합니다"})
 	}
 
+	setAuditTarget(c, "role.assign.workspace", "user", util.UintToString(userID))
+	setAuditBefore(c, h.auditUserWorkspaceRoles(userID, workspaceID))
+
 	// 역할 할당
-	err := h.roleService.AssignWorkspaceRole(userID, workspaceID, roleID)
+	err := h.roleService.AssignWorkspaceRoleWithPeriod(userID, workspaceID, roleID, period)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("역할 할당 실패: %v", err)})
 	}
 
+	setAuditAfter(c, h.auditUserWorkspaceRoles(userID, workspaceID))
 	return c.JSON(http.StatusOK, map[string]string{"message": "역할이 성공적으로 할당되었습니다"})
 }
 
@@ -1822,6 +1942,9 @@ func (h *RoleHandler) RemoveWorkspaceRole(c echo.Context) error {
 	} else {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "역할 ID 또는 역할명이 필요합니다"})
 	}
+	if denied, err := denyOtherTenantResource(c, model.TenantResourceRole, roleID); denied {
+		return err
+	}
 
 	// 워크스페이스 ID 처리
 	var workspaceID uint
@@ -1831,17 +1954,24 @@ func (h *RoleHandler) RemoveWorkspaceRole(c echo.Context) error {
 		if err != nil {
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": "잘못된 워크스페이스 ID 형식입니다"})
 		}
+		if denied, err := denyOtherTenantResource(c, model.TenantResourceWorkspace, workspaceIDInt); denied {
+			return err
+		}
 		workspaceID = workspaceIDInt
 	} else {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "워크스페이스 ID가 필요합니다"})
 	}
 
+	setAuditTarget(c, "role.unassign.workspace", "user", util.UintToString(userID))
+	setAuditBefore(c, h.auditUserWorkspaceRoles(userID, workspaceID))
+
 	// 역할 제거
 	err := h.roleService.RemoveWorkspaceRole(userID, workspaceID, roleID)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("역할 제거 실패: %v", err)})
 	}
 
+	setAuditAfter(c, h.auditUserWorkspaceRoles(userID, workspaceID))
 	return c.JSON(http.StatusOK, map[string]string{"message": "역할이 성공적으로 제거되었습니다"})
 }
 
@@ -1885,6 +2015,9 @@ func (h *RoleHandler) AddCspRoleMappings(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "잘못된 역할 ID 형식입니다"})
 	}
 
+	setAuditTarget(c, "role.csp-mapping.add", "role", roleID)
+	setAuditBefore(c, h.auditRoleCspMappings(roleIDInt))
+
 	// 해당 역할이 CSP 역할에 정의 되어 있는지 조회
 	// . 해당 역할이 존재하지 않는다면 존재하지 않는 역할이라고 알려준다.
 	cspRole, err := h.roleService.GetCspRoleByID(cspRoleIDInt)
@@ -1930,6 +2063,7 @@ func (h *RoleHandler) AddCspRoleMappings(c echo.Context) error {
 	}
 
 	log.Printf("Master 역할-CSP 역할 매핑 생성 성공 - ID: %d", roleIDInt)
+	setAuditAfter(c, h.auditRoleCspMappings(roleIDInt))
 	return c.JSON(http.StatusCreated, map[string]string{"message": "Master 역할-CSP 역할 매핑 생성 성공"})
 }
 
@@ -1968,6 +2102,9 @@ func (h *RoleHandler) RemoveCspRoleMappings(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "잘못된 워크스페이스 ID 형식입니다"})
 	}
 
+	setAuditTarget(c, "role.csp-mapping.remove", "role", req.RoleID)
+	setAuditBefore(c, h.auditRoleCspMappings(roleIDInt))
+
 	// 매핑 삭제
 	err = h.roleService.DeleteRoleCspRoleMapping(roleIDInt, cspRoleIDInt, reqAuthMethod)
 	if err != nil {
@@ -1976,6 +2113,7 @@ func (h *RoleHandler) RemoveCspRoleMappings(c echo.Context) error {
 	}
 
 	log.Printf("Master 역할-CSP 역할 매핑 삭제 성공 - ID: %d", roleIDInt)
+	setAuditAfter(c, h.auditRoleCspMappings(roleIDInt))
 	return c.NoContent(http.StatusNoContent)
 }
 
@@ -2267,3 +2405,59 @@ func (h *RoleHandler) GetRoleMasterMappings(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, mappings)
 }
+
+// auditUserPlatformRoles 감사 스냅샷용 사용자 플랫폼 역할 목록
+func (h *RoleHandler) auditUserPlatformRoles(userID uint) []map[string]interface{} {
+	roles, err := h.roleService.GetUserPlatformRoles(userID)
+	if err != nil {
+		log.Printf("[WARN] audit snapshot: failed to get platform roles for user %d: %v", userID, err)
+		return nil
+	}
+	snapshot := make([]map[string]interface{}, 0, len(roles))
+	for _, role := range roles {
+		snapshot = append(snapshot, map[string]interface{}{"roleId": role.ID, "roleName": role.Name})
+	}
+	return snapshot
+}
+
+// auditUserWorkspaceRoles 감사 스냅샷용 사용자 워크스페이스 역할 목록
+func (h *RoleHandler) auditUserWorkspaceRoles(userID, workspaceID uint) []map[string]interface{} {
+	roles, err := h.roleService.GetUserWorkspaceRoles(userID, workspaceID)
+	if err != nil {
+		log.Printf("[WARN] audit snapshot: failed to get workspace roles for user %d: %v", userID, err)
+		return nil
+	}
+	snapshot := make([]map[string]interface{}, 0, len(roles))
+	for _, role := range roles {
+		snapshot = append(snapshot, map[string]interface{}{
+			"workspaceId": role.WorkspaceID,
+			"roleId":      role.RoleID,
+			"roleName":    role.RoleName,
+		})
+	}
+	return snapshot
+}
+
+// auditRoleCspMappings 감사 스냅샷용 역할-CSP 역할 매핑 목록
+func (h *RoleHandler) auditRoleCspMappings(roleID uint) []model.RoleMasterCspRoleMapping {
+	mappings, err := h.roleService.GetCspRoleMappingsByRoleID(roleID)
+	if err != nil {
+		log.Printf("[WARN] audit snapshot: failed to get csp role mappings for role %d: %v", roleID, err)
+		return nil
+	}
+	return mappings
+}
+
+// isRoleHierarchyError 상위 역할 지정 오류(존재하지 않음, 순환) 여부
+func isRoleHierarchyError(err error) bool {
+	return errors.Is(err, service.ErrRoleParentNotFound) || errors.Is(err, service.ErrRoleHierarchyCycle)
+}
+
+// roleHierarchyErrorResponse 상위 역할 검증 오류를 HTTP 응답으로 변환
+func roleHierarchyErrorResponse(c echo.Context, err error) error {
+	if isRoleHierarchyError(err) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	log.Printf("Failed to validate parent role: %v", err)
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": fmt.Sprintf("Failed to validate parent role: %v", err)})
+}
@@ -0,0 +1,467 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"gorm.io/gorm"
+)
+
+// scimContentType SCIM 응답 미디어 타입 (RFC 7644 3.1)
+const scimContentType = "application/scim+json"
+
+// ScimHandler SCIM 2.0 프로비저닝 핸들러
+type ScimHandler struct {
+	scimService *service.ScimService
+}
+
+// NewScimHandler 새 ScimHandler 인스턴스 생성
+func NewScimHandler(db *gorm.DB) *ScimHandler {
+	return &ScimHandler{
+		scimService: service.NewScimService(db),
+	}
+}
+
+// scimJSON SCIM 미디어 타입으로 응답
+func scimJSON(c echo.Context, status int, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.Blob(status, scimContentType, body)
+}
+
+// scimError 서비스 오류를 SCIM 오류 응답으로 변환
+func scimError(c echo.Context, err error) error {
+	var status int
+	var scimType string
+	switch {
+	case errors.Is(err, service.ErrScimNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrScimConflict):
+		status, scimType = http.StatusConflict, "uniqueness"
+	case errors.Is(err, service.ErrScimInvalidFilter):
+		status, scimType = http.StatusBadRequest, "invalidFilter"
+	case errors.Is(err, service.ErrScimInvalidPath):
+		status, scimType = http.StatusBadRequest, "invalidPath"
+	case errors.Is(err, service.ErrScimInvalidValue):
+		status, scimType = http.StatusBadRequest, "invalidValue"
+	default:
+		log.Printf("[ERROR] SCIM %s %s failed: %v", c.Request().Method, c.Request().URL.Path, err)
+		status = http.StatusInternalServerError
+	}
+	return scimJSON(c, status, model.NewScimError(status, scimType, err.Error()))
+}
+
+// bindScim application/scim+json 본문 디코딩 (echo 기본 바인더는 application/json만 처리)
+func bindScim(c echo.Context, v interface{}) error {
+	if err := json.NewDecoder(c.Request().Body).Decode(v); err != nil {
+		return scimJSON(c, http.StatusBadRequest, model.NewScimError(http.StatusBadRequest, "invalidSyntax", "invalid request body: "+err.Error()))
+	}
+	return nil
+}
+
+// scimPaging startIndex/count 쿼리 파라미터 (count가 없으면 -1 = 기본값, 음수는 0)
+func scimPaging(c echo.Context) (int, int) {
+	startIndex, _ := strconv.Atoi(c.QueryParam("startIndex"))
+	count := -1
+	if raw := c.QueryParam("count"); raw != "" {
+		count, _ = strconv.Atoi(raw)
+		if count < 0 {
+			count = 0
+		}
+	}
+	return startIndex, count
+}
+
+// scimExcludesMembers excludedAttributes에 members가 포함되었는지 여부
+func scimExcludesMembers(c echo.Context) bool {
+	for _, attr := range strings.Split(c.QueryParam("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(attr), "members") {
+			return true
+		}
+	}
+	return false
+}
+
+// GetServiceProviderConfig godoc
+// @Summary SCIM service provider configuration
+// @Description 지원 기능(PATCH, filter)과 인증 방식을 반환합니다. MC_IAM_MANAGER_SCIM_TOKEN Bearer 토큰이 필요합니다.
+// @Tags scim
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} model.ScimError
+// @Security BearerAuth
+// @Router /scim/v2/ServiceProviderConfig [get]
+// @Id getScimServiceProviderConfig
+func (h *ScimHandler) GetServiceProviderConfig(c echo.Context) error {
+	return scimJSON(c, http.StatusOK, service.ScimServiceProviderConfig())
+}
+
+// ListResourceTypes godoc
+// @Summary List SCIM resource types
+// @Description 지원 리소스 유형(User, Group)을 반환합니다.
+// @Tags scim
+// @Produce json
+// @Success 200 {object} model.ScimListResponse
+// @Failure 401 {object} model.ScimError
+// @Security BearerAuth
+// @Router /scim/v2/ResourceTypes [get]
+// @Id listScimResourceTypes
+func (h *ScimHandler) ListResourceTypes(c echo.Context) error {
+	types := service.ScimResourceTypes()
+	resources := make([]interface{}, 0, len(types))
+	for _, t := range types {
+		resources = append(resources, t)
+	}
+	return scimJSON(c, http.StatusOK, &model.ScimListResponse{
+		Schemas:      []string{model.ScimSchemaListResponse},
+		TotalResults: len(resources),
+		StartIndex:   1,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+// ListSchemas godoc
+// @Summary List SCIM schemas
+// @Description User, Group 스키마에서 매핑하는 속성을 반환합니다.
+// @Tags scim
+// @Produce json
+// @Success 200 {object} model.ScimListResponse
+// @Failure 401 {object} model.ScimError
+// @Security BearerAuth
+// @Router /scim/v2/Schemas [get]
+// @Id listScimSchemas
+func (h *ScimHandler) ListSchemas(c echo.Context) error {
+	schemas := service.ScimSchemas()
+	resources := make([]interface{}, 0, len(schemas))
+	for _, schema := range schemas {
+		resources = append(resources, schema)
+	}
+	return scimJSON(c, http.StatusOK, &model.ScimListResponse{
+		Schemas:      []string{model.ScimSchemaListResponse},
+		TotalResults: len(resources),
+		StartIndex:   1,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+// GetSchema godoc
+// @Summary Get SCIM schema
+// @Description 스키마 URN으로 스키마를 조회합니다.
+// @Tags scim
+// @Produce json
+// @Param schemaId path string true "Schema URN"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} model.ScimError
+// @Security BearerAuth
+// @Router /scim/v2/Schemas/{schemaId} [get]
+// @Id getScimSchema
+func (h *ScimHandler) GetSchema(c echo.Context) error {
+	schema, err := service.ScimSchema(c.Param("schemaId"))
+	if err != nil {
+		return scimError(c, err)
+	}
+	return scimJSON(c, http.StatusOK, schema)
+}
+
+// ListScimUsers godoc
+// @Summary List SCIM users
+// @Description 사용자 목록을 조회합니다. filter(예: userName eq "alice"), startIndex, count를 지원합니다. 탈퇴한 사용자는 제외합니다.
+// @Tags scim
+// @Produce json
+// @Param filter query string false "SCIM filter"
+// @Param startIndex query int false "1-based start index"
+// @Param count query int false "Page size (max 200)"
+// @Success 200 {object} model.ScimListResponse
+// @Failure 400 {object} model.ScimError
+// @Failure 401 {object} model.ScimError
+// @Security BearerAuth
+// @Router /scim/v2/Users [get]
+// @Id listScimUsers
+func (h *ScimHandler) ListScimUsers(c echo.Context) error {
+	startIndex, count := scimPaging(c)
+	resp, err := h.scimService.ListUsers(c.Request().Context(), c.QueryParam("filter"), startIndex, count)
+	if err != nil {
+		return scimError(c, err)
+	}
+	return scimJSON(c, http.StatusOK, resp)
+}
+
+// GetScimUser godoc
+// @Summary Get SCIM user
+// @Description Keycloak 사용자 ID로 사용자를 조회합니다.
+// @Tags scim
+// @Produce json
+// @Param id path string true "User ID (Keycloak user ID)"
+// @Success 200 {object} model.ScimUser
+// @Failure 404 {object} model.ScimError
+// @Security BearerAuth
+// @Router /scim/v2/Users/{id} [get]
+// @Id getScimUser
+func (h *ScimHandler) GetScimUser(c echo.Context) error {
+	user, err := h.scimService.GetUser(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return scimError(c, err)
+	}
+	return scimJSON(c, http.StatusOK, user)
+}
+
+// CreateScimUser godoc
+// @Summary Create SCIM user
+// @Description Keycloak 사용자와 DB 사용자를 생성합니다. Keycloak에만 있는 같은 사용자명의 사용자는 연결합니다. active=false이면 비활성 상태로 생성합니다.
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param body body model.ScimUser true "SCIM user"
+// @Success 201 {object} model.ScimUser
+// @Failure 400 {object} model.ScimError
+// @Failure 409 {object} model.ScimError
+// @Security BearerAuth
+// @Router /scim/v2/Users [post]
+// @Id createScimUser
+func (h *ScimHandler) CreateScimUser(c echo.Context) error {
+	var req model.ScimUser
+	if err := bindScim(c, &req); err != nil {
+		return err
+	}
+	user, err := h.scimService.CreateUser(c.Request().Context(), &req)
+	if err != nil {
+		return scimError(c, err)
+	}
+	setAuditTarget(c, "scim.user.create", "user", user.ID)
+	setAuditAfter(c, user)
+	c.Response().Header().Set(echo.HeaderLocation, user.Meta.Location)
+	return scimJSON(c, http.StatusCreated, user)
+}
+
+// ReplaceScimUser godoc
+// @Summary Replace SCIM user
+// @Description 사용자명, 이름, 이메일, 활성 상태를 교체합니다. 요청에 없는 이름/이메일은 비웁니다.
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID (Keycloak user ID)"
+// @Param body body model.ScimUser true "SCIM user"
+// @Success 200 {object} model.ScimUser
+// @Failure 400 {object} model.ScimError
+// @Failure 404 {object} model.ScimError
+// @Failure 409 {object} model.ScimError
+// @Security BearerAuth
+// @Router /scim/v2/Users/{id} [put]
+// @Id replaceScimUser
+func (h *ScimHandler) ReplaceScimUser(c echo.Context) error {
+	var req model.ScimUser
+	if err := bindScim(c, &req); err != nil {
+		return err
+	}
+	setAuditTarget(c, "scim.user.update", "user", c.Param("id"))
+	user, err := h.scimService.ReplaceUser(c.Request().Context(), c.Param("id"), &req)
+	if err != nil {
+		return scimError(c, err)
+	}
+	setAuditAfter(c, user)
+	return scimJSON(c, http.StatusOK, user)
+}
+
+// PatchScimUser godoc
+// @Summary Patch SCIM user
+// @Description add/remove/replace 연산으로 사용자를 수정합니다. active=false이면 Keycloak 비활성화와 세션 폐기를 수행합니다.
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID (Keycloak user ID)"
+// @Param body body model.ScimPatchRequest true "SCIM PatchOp"
+// @Success 200 {object} model.ScimUser
+// @Failure 400 {object} model.ScimError
+// @Failure 404 {object} model.ScimError
+// @Security BearerAuth
+// @Router /scim/v2/Users/{id} [patch]
+// @Id patchScimUser
+func (h *ScimHandler) PatchScimUser(c echo.Context) error {
+	var req model.ScimPatchRequest
+	if err := bindScim(c, &req); err != nil {
+		return err
+	}
+	setAuditTarget(c, "scim.user.update", "user", c.Param("id"))
+	user, err := h.scimService.PatchUser(c.Request().Context(), c.Param("id"), &req)
+	if err != nil {
+		return scimError(c, err)
+	}
+	setAuditAfter(c, user)
+	return scimJSON(c, http.StatusOK, user)
+}
+
+// DeleteScimUser godoc
+// @Summary Delete SCIM user
+// @Description 사용자를 탈퇴 처리합니다 (역할/조직 매핑 삭제, Keycloak 비활성화, 세션 폐기, WITHDRAWN).
+// @Tags scim
+// @Param id path string true "User ID (Keycloak user ID)"
+// @Success 204
+// @Failure 404 {object} model.ScimError
+// @Security BearerAuth
+// @Router /scim/v2/Users/{id} [delete]
+// @Id deleteScimUser
+func (h *ScimHandler) DeleteScimUser(c echo.Context) error {
+	setAuditTarget(c, "scim.user.delete", "user", c.Param("id"))
+	if err := h.scimService.DeleteUser(c.Request().Context(), c.Param("id")); err != nil {
+		return scimError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListScimGroups godoc
+// @Summary List SCIM groups
+// @Description 그룹(조직) 목록을 조회합니다. filter(예: displayName eq "dev"), startIndex, count, excludedAttributes=members를 지원합니다.
+// @Tags scim
+// @Produce json
+// @Param filter query string false "SCIM filter"
+// @Param startIndex query int false "1-based start index"
+// @Param count query int false "Page size (max 200)"
+// @Param excludedAttributes query string false "members"
+// @Success 200 {object} model.ScimListResponse
+// @Failure 400 {object} model.ScimError
+// @Security BearerAuth
+// @Router /scim/v2/Groups [get]
+// @Id listScimGroups
+func (h *ScimHandler) ListScimGroups(c echo.Context) error {
+	startIndex, count := scimPaging(c)
+	resp, err := h.scimService.ListGroups(c.Request().Context(), c.QueryParam("filter"), startIndex, count, scimExcludesMembers(c))
+	if err != nil {
+		return scimError(c, err)
+	}
+	return scimJSON(c, http.StatusOK, resp)
+}
+
+// GetScimGroup godoc
+// @Summary Get SCIM group
+// @Description 조직 ID로 그룹과 구성원을 조회합니다.
+// @Tags scim
+// @Produce json
+// @Param id path string true "Group ID (organization ID)"
+// @Param excludedAttributes query string false "members"
+// @Success 200 {object} model.ScimGroup
+// @Failure 404 {object} model.ScimError
+// @Security BearerAuth
+// @Router /scim/v2/Groups/{id} [get]
+// @Id getScimGroup
+func (h *ScimHandler) GetScimGroup(c echo.Context) error {
+	group, err := h.scimService.GetGroup(c.Request().Context(), c.Param("id"), scimExcludesMembers(c))
+	if err != nil {
+		return scimError(c, err)
+	}
+	return scimJSON(c, http.StatusOK, group)
+}
+
+// CreateScimGroup godoc
+// @Summary Create SCIM group
+// @Description 최상위 조직으로 그룹을 생성하고 구성원을 소속시킵니다 (조직 코드 자동 생성).
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param body body model.ScimGroup true "SCIM group"
+// @Success 201 {object} model.ScimGroup
+// @Failure 400 {object} model.ScimError
+// @Failure 409 {object} model.ScimError
+// @Security BearerAuth
+// @Router /scim/v2/Groups [post]
+// @Id createScimGroup
+func (h *ScimHandler) CreateScimGroup(c echo.Context) error {
+	var req model.ScimGroup
+	if err := bindScim(c, &req); err != nil {
+		return err
+	}
+	group, err := h.scimService.CreateGroup(c.Request().Context(), &req)
+	if err != nil {
+		return scimError(c, err)
+	}
+	setAuditTarget(c, "scim.group.create", "organization", group.ID)
+	setAuditAfter(c, group)
+	c.Response().Header().Set(echo.HeaderLocation, group.Meta.Location)
+	return scimJSON(c, http.StatusCreated, group)
+}
+
+// ReplaceScimGroup godoc
+// @Summary Replace SCIM group
+// @Description 그룹 이름과 구성원 전체를 교체합니다.
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param id path string true "Group ID (organization ID)"
+// @Param body body model.ScimGroup true "SCIM group"
+// @Success 200 {object} model.ScimGroup
+// @Failure 400 {object} model.ScimError
+// @Failure 404 {object} model.ScimError
+// @Failure 409 {object} model.ScimError
+// @Security BearerAuth
+// @Router /scim/v2/Groups/{id} [put]
+// @Id replaceScimGroup
+func (h *ScimHandler) ReplaceScimGroup(c echo.Context) error {
+	var req model.ScimGroup
+	if err := bindScim(c, &req); err != nil {
+		return err
+	}
+	setAuditTarget(c, "scim.group.update", "organization", c.Param("id"))
+	group, err := h.scimService.ReplaceGroup(c.Request().Context(), c.Param("id"), &req)
+	if err != nil {
+		return scimError(c, err)
+	}
+	setAuditAfter(c, group)
+	return scimJSON(c, http.StatusOK, group)
+}
+
+// PatchScimGroup godoc
+// @Summary Patch SCIM group
+// @Description displayName 변경과 members add/remove/replace를 수행합니다. members[value eq "id"] 경로로 구성원을 제거할 수 있습니다.
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param id path string true "Group ID (organization ID)"
+// @Param body body model.ScimPatchRequest true "SCIM PatchOp"
+// @Success 200 {object} model.ScimGroup
+// @Failure 400 {object} model.ScimError
+// @Failure 404 {object} model.ScimError
+// @Security BearerAuth
+// @Router /scim/v2/Groups/{id} [patch]
+// @Id patchScimGroup
+func (h *ScimHandler) PatchScimGroup(c echo.Context) error {
+	var req model.ScimPatchRequest
+	if err := bindScim(c, &req); err != nil {
+		return err
+	}
+	setAuditTarget(c, "scim.group.update", "organization", c.Param("id"))
+	group, err := h.scimService.PatchGroup(c.Request().Context(), c.Param("id"), &req)
+	if err != nil {
+		return scimError(c, err)
+	}
+	setAuditAfter(c, group)
+	return scimJSON(c, http.StatusOK, group)
+}
+
+// DeleteScimGroup godoc
+// @Summary Delete SCIM group
+// @Description 그룹(조직)과 소속을 삭제합니다. 하위 조직이 있으면 409를 반환합니다.
+// @Tags scim
+// @Param id path string true "Group ID (organization ID)"
+// @Success 204
+// @Failure 404 {object} model.ScimError
+// @Failure 409 {object} model.ScimError
+// @Security BearerAuth
+// @Router /scim/v2/Groups/{id} [delete]
+// @Id deleteScimGroup
+func (h *ScimHandler) DeleteScimGroup(c echo.Context) error {
+	setAuditTarget(c, "scim.group.delete", "organization", c.Param("id"))
+	if err := h.scimService.DeleteGroup(c.Request().Context(), c.Param("id")); err != nil {
+		return scimError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,451 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"github.com/m-cmp/mc-iam-manager/util"
+	"gorm.io/gorm"
+)
+
+// ServiceAccountHandler 서비스 계정, API 키, client credentials 관리 핸들러
+type ServiceAccountHandler struct {
+	serviceAccountService *service.ServiceAccountService
+}
+
+// NewServiceAccountHandler 새 ServiceAccountHandler 인스턴스 생성
+func NewServiceAccountHandler(db *gorm.DB) *ServiceAccountHandler {
+	return &ServiceAccountHandler{
+		serviceAccountService: service.NewServiceAccountService(db),
+	}
+}
+
+// CreateServiceAccount godoc
+// @Summary Create service account
+// @Description 기계 클라이언트용 서비스 계정을 생성합니다. authType이 CLIENT_CREDENTIALS이면 Keycloak 클라이언트를 만들고 client secret을 이 응답에서만 반환합니다.
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Param request body model.CreateServiceAccountRequest true "Service account info"
+// @Success 201 {object} model.ServiceAccountResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/service-accounts [post]
+// @Id createServiceAccount
+func (h *ServiceAccountHandler) CreateServiceAccount(c echo.Context) error {
+	var req model.CreateServiceAccountRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	actor, _ := c.Get("kcUserId").(string)
+	setAuditTarget(c, "service-account.create", "service-account", req.Name)
+	resp, err := h.serviceAccountService.Create(c.Request().Context(), &req, actor)
+	if err != nil {
+		return serviceAccountError(c, err)
+	}
+	setAuditAfter(c, resp.ServiceAccount)
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// ListServiceAccounts godoc
+// @Summary List service accounts
+// @Description 서비스 계정 목록을 반환합니다.
+// @Tags service-accounts
+// @Produce json
+// @Success 200 {array} model.ServiceAccount
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/service-accounts [get]
+// @Id listServiceAccounts
+func (h *ServiceAccountHandler) ListServiceAccounts(c echo.Context) error {
+	accounts, err := h.serviceAccountService.List()
+	if err != nil {
+		return serviceAccountError(c, err)
+	}
+	return c.JSON(http.StatusOK, accounts)
+}
+
+// GetServiceAccount godoc
+// @Summary Get service account
+// @Description 서비스 계정을 조회합니다.
+// @Tags service-accounts
+// @Produce json
+// @Param serviceAccountId path string true "Service account ID"
+// @Success 200 {object} model.ServiceAccount
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/service-accounts/id/{serviceAccountId} [get]
+// @Id getServiceAccount
+func (h *ServiceAccountHandler) GetServiceAccount(c echo.Context) error {
+	id, err := util.StringToUint(c.Param("serviceAccountId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid service account ID"})
+	}
+	account, err := h.serviceAccountService.Get(id)
+	if err != nil {
+		return serviceAccountError(c, err)
+	}
+	return c.JSON(http.StatusOK, account)
+}
+
+// UpdateServiceAccount godoc
+// @Summary Update service account
+// @Description 서비스 계정의 설명과 활성 상태를 변경합니다. 비활성 서비스 계정은 API 키와 client credentials 인증이 모두 거부됩니다.
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Param serviceAccountId path string true "Service account ID"
+// @Param request body model.UpdateServiceAccountRequest true "Fields to update"
+// @Success 200 {object} model.ServiceAccount
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/service-accounts/id/{serviceAccountId} [put]
+// @Id updateServiceAccount
+func (h *ServiceAccountHandler) UpdateServiceAccount(c echo.Context) error {
+	id, err := util.StringToUint(c.Param("serviceAccountId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid service account ID"})
+	}
+	var req model.UpdateServiceAccountRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	setAuditTarget(c, "service-account.update", "service-account", c.Param("serviceAccountId"))
+	if before, err := h.serviceAccountService.Get(id); err == nil {
+		setAuditBefore(c, before)
+	}
+	actor, _ := c.Get("kcUserId").(string)
+	account, err := h.serviceAccountService.Update(c.Request().Context(), id, &req, actor)
+	if err != nil {
+		return serviceAccountError(c, err)
+	}
+	setAuditAfter(c, account)
+	return c.JSON(http.StatusOK, account)
+}
+
+// DeleteServiceAccount godoc
+// @Summary Delete service account
+// @Description 서비스 계정과 API 키, 역할 할당, Keycloak 클라이언트를 삭제합니다.
+// @Tags service-accounts
+// @Param serviceAccountId path string true "Service account ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/service-accounts/id/{serviceAccountId} [delete]
+// @Id deleteServiceAccount
+func (h *ServiceAccountHandler) DeleteServiceAccount(c echo.Context) error {
+	id, err := util.StringToUint(c.Param("serviceAccountId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid service account ID"})
+	}
+	setAuditTarget(c, "service-account.delete", "service-account", c.Param("serviceAccountId"))
+	if before, err := h.serviceAccountService.Get(id); err == nil {
+		setAuditBefore(c, before)
+	}
+	actor, _ := c.Get("kcUserId").(string)
+	if err := h.serviceAccountService.Delete(c.Request().Context(), id, actor); err != nil {
+		return serviceAccountError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RegenerateServiceAccountSecret godoc
+// @Summary Regenerate service account client secret
+// @Description CLIENT_CREDENTIALS 서비스 계정의 Keycloak client secret을 재발급합니다. 기존 secret은 즉시 무효화됩니다.
+// @Tags service-accounts
+// @Produce json
+// @Param serviceAccountId path string true "Service account ID"
+// @Success 200 {object} model.ServiceAccountClientCredentials
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/service-accounts/id/{serviceAccountId}/client-secret [post]
+// @Id regenerateServiceAccountSecret
+func (h *ServiceAccountHandler) RegenerateServiceAccountSecret(c echo.Context) error {
+	id, err := util.StringToUint(c.Param("serviceAccountId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid service account ID"})
+	}
+	setAuditTarget(c, "service-account.secret.regenerate", "service-account", c.Param("serviceAccountId"))
+	creds, err := h.serviceAccountService.RegenerateClientSecret(c.Request().Context(), id)
+	if err != nil {
+		return serviceAccountError(c, err)
+	}
+	return c.JSON(http.StatusOK, creds)
+}
+
+// GetServiceAccountRoles godoc
+// @Summary Get service account roles
+// @Description 서비스 계정의 유효 플랫폼/워크스페이스 역할을 반환합니다.
+// @Tags service-accounts
+// @Produce json
+// @Param serviceAccountId path string true "Service account ID"
+// @Success 200 {object} model.ServiceAccountRolesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/service-accounts/id/{serviceAccountId}/roles [get]
+// @Id getServiceAccountRoles
+func (h *ServiceAccountHandler) GetServiceAccountRoles(c echo.Context) error {
+	id, err := util.StringToUint(c.Param("serviceAccountId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid service account ID"})
+	}
+	roles, err := h.serviceAccountService.GetRoles(id)
+	if err != nil {
+		return serviceAccountError(c, err)
+	}
+	return c.JSON(http.StatusOK, roles)
+}
+
+// AssignServiceAccountPlatformRole godoc
+// @Summary Assign platform role to service account
+// @Description 서비스 계정에 플랫폼 역할을 할당합니다.
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Param serviceAccountId path string true "Service account ID"
+// @Param request body model.ServiceAccountRoleRequest true "Role"
+// @Success 200 {object} model.ServiceAccountRolesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/service-accounts/id/{serviceAccountId}/platform-roles [post]
+// @Id assignServiceAccountPlatformRole
+func (h *ServiceAccountHandler) AssignServiceAccountPlatformRole(c echo.Context) error {
+	id, err := util.StringToUint(c.Param("serviceAccountId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid service account ID"})
+	}
+	var req model.ServiceAccountRoleRequest
+	if err := c.Bind(&req); err != nil || req.RoleID == 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "roleId is required"})
+	}
+	setAuditTarget(c, "service-account.role.assign.platform", "service-account", c.Param("serviceAccountId"))
+	if err := h.serviceAccountService.AssignPlatformRole(c.Request().Context(), id, req.RoleID); err != nil {
+		return serviceAccountError(c, err)
+	}
+	return h.respondRoles(c, id)
+}
+
+// RemoveServiceAccountPlatformRole godoc
+// @Summary Remove platform role from service account
+// @Description 서비스 계정의 플랫폼 역할을 제거합니다.
+// @Tags service-accounts
+// @Produce json
+// @Param serviceAccountId path string true "Service account ID"
+// @Param roleId path string true "Role ID"
+// @Success 200 {object} model.ServiceAccountRolesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/service-accounts/id/{serviceAccountId}/platform-roles/{roleId} [delete]
+// @Id removeServiceAccountPlatformRole
+func (h *ServiceAccountHandler) RemoveServiceAccountPlatformRole(c echo.Context) error {
+	id, err := util.StringToUint(c.Param("serviceAccountId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid service account ID"})
+	}
+	roleID, err := util.StringToUint(c.Param("roleId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid role ID"})
+	}
+	setAuditTarget(c, "service-account.role.unassign.platform", "service-account", c.Param("serviceAccountId"))
+	actor, _ := c.Get("kcUserId").(string)
+	if err := h.serviceAccountService.RemovePlatformRole(c.Request().Context(), id, roleID, actor); err != nil {
+		return serviceAccountError(c, err)
+	}
+	return h.respondRoles(c, id)
+}
+
+// AssignServiceAccountWorkspaceRole godoc
+// @Summary Assign workspace role to service account
+// @Description 서비스 계정에 워크스페이스 역할을 할당합니다.
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Param serviceAccountId path string true "Service account ID"
+// @Param request body model.ServiceAccountRoleRequest true "Workspace and role"
+// @Success 200 {object} model.ServiceAccountRolesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/service-accounts/id/{serviceAccountId}/workspace-roles [post]
+// @Id assignServiceAccountWorkspaceRole
+func (h *ServiceAccountHandler) AssignServiceAccountWorkspaceRole(c echo.Context) error {
+	id, err := util.StringToUint(c.Param("serviceAccountId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid service account ID"})
+	}
+	var req model.ServiceAccountRoleRequest
+	if err := c.Bind(&req); err != nil || req.RoleID == 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "roleId and workspaceId are required"})
+	}
+	setAuditTarget(c, "service-account.role.assign.workspace", "service-account", c.Param("serviceAccountId"))
+	if err := h.serviceAccountService.AssignWorkspaceRole(id, req.WorkspaceID, req.RoleID); err != nil {
+		return serviceAccountError(c, err)
+	}
+	return h.respondRoles(c, id)
+}
+
+// RemoveServiceAccountWorkspaceRole godoc
+// @Summary Remove workspace role from service account
+// @Description 서비스 계정의 워크스페이스 역할을 제거합니다.
+// @Tags service-accounts
+// @Produce json
+// @Param serviceAccountId path string true "Service account ID"
+// @Param workspaceId path string true "Workspace ID"
+// @Param roleId path string true "Role ID"
+// @Success 200 {object} model.ServiceAccountRolesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/service-accounts/id/{serviceAccountId}/workspaces/{workspaceId}/roles/{roleId} [delete]
+// @Id removeServiceAccountWorkspaceRole
+func (h *ServiceAccountHandler) RemoveServiceAccountWorkspaceRole(c echo.Context) error {
+	id, err := util.StringToUint(c.Param("serviceAccountId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid service account ID"})
+	}
+	workspaceID, err := util.StringToUint(c.Param("workspaceId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid workspace ID"})
+	}
+	roleID, err := util.StringToUint(c.Param("roleId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid role ID"})
+	}
+	setAuditTarget(c, "service-account.role.unassign.workspace", "service-account", c.Param("serviceAccountId"))
+	if err := h.serviceAccountService.RemoveWorkspaceRole(id, workspaceID, roleID); err != nil {
+		return serviceAccountError(c, err)
+	}
+	return h.respondRoles(c, id)
+}
+
+// CreateServiceAccountApiKey godoc
+// @Summary Create service account API key
+// @Description 서비스 계정 API 키를 발급합니다. 키 원문은 이 응답에서만 확인할 수 있으며 X-API-Key 헤더 또는 Authorization: Bearer 로 전달합니다.
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Param serviceAccountId path string true "Service account ID"
+// @Param request body model.CreateApiKeyRequest true "API key options"
+// @Success 201 {object} model.CreateApiKeyResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/service-accounts/id/{serviceAccountId}/api-keys [post]
+// @Id createServiceAccountApiKey
+func (h *ServiceAccountHandler) CreateServiceAccountApiKey(c echo.Context) error {
+	id, err := util.StringToUint(c.Param("serviceAccountId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid service account ID"})
+	}
+	var req model.CreateApiKeyRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	actor, _ := c.Get("kcUserId").(string)
+	setAuditTarget(c, "service-account.api-key.create", "service-account", c.Param("serviceAccountId"))
+	resp, err := h.serviceAccountService.CreateApiKey(id, &req, actor)
+	if err != nil {
+		return serviceAccountError(c, err)
+	}
+	setAuditAfter(c, resp.ServiceAccountApiKey)
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// ListServiceAccountApiKeys godoc
+// @Summary List service account API keys
+// @Description 서비스 계정의 API 키 목록(접두어, 범위, 만료/마지막 사용 시각)을 반환합니다.
+// @Tags service-accounts
+// @Produce json
+// @Param serviceAccountId path string true "Service account ID"
+// @Success 200 {array} model.ServiceAccountApiKey
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/service-accounts/id/{serviceAccountId}/api-keys [get]
+// @Id listServiceAccountApiKeys
+func (h *ServiceAccountHandler) ListServiceAccountApiKeys(c echo.Context) error {
+	id, err := util.StringToUint(c.Param("serviceAccountId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid service account ID"})
+	}
+	keys, err := h.serviceAccountService.ListApiKeys(id)
+	if err != nil {
+		return serviceAccountError(c, err)
+	}
+	return c.JSON(http.StatusOK, keys)
+}
+
+// RevokeServiceAccountApiKey godoc
+// @Summary Revoke service account API key
+// @Description 서비스 계정 API 키를 폐기합니다.
+// @Tags service-accounts
+// @Param serviceAccountId path string true "Service account ID"
+// @Param keyId path string true "API key ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/service-accounts/id/{serviceAccountId}/api-keys/{keyId} [delete]
+// @Id revokeServiceAccountApiKey
+func (h *ServiceAccountHandler) RevokeServiceAccountApiKey(c echo.Context) error {
+	id, err := util.StringToUint(c.Param("serviceAccountId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid service account ID"})
+	}
+	keyID, err := util.StringToUint(c.Param("keyId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid API key ID"})
+	}
+	setAuditTarget(c, "service-account.api-key.revoke", "service-account", c.Param("serviceAccountId"))
+	setAuditBefore(c, map[string]string{"keyId": c.Param("keyId")})
+	if err := h.serviceAccountService.RevokeApiKey(id, keyID); err != nil {
+		return serviceAccountError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *ServiceAccountHandler) respondRoles(c echo.Context, id uint) error {
+	roles, err := h.serviceAccountService.GetRoles(id)
+	if err != nil {
+		return serviceAccountError(c, err)
+	}
+	setAuditAfter(c, roles)
+	return c.JSON(http.StatusOK, roles)
+}
+
+// serviceAccountError 서비스 계정 오류를 HTTP 상태로 변환
+func serviceAccountError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrServiceAccountNotFound), errors.Is(err, service.ErrApiKeyNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrServiceAccountExists):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidServiceAccount):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+}
@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"gorm.io/gorm"
+)
+
+// SignupHandler 셀프 가입 승인 대기열 핸들러
+type SignupHandler struct {
+	signupService *service.SignupService
+	userService   *service.UserService
+}
+
+// NewSignupHandler 새 SignupHandler 인스턴스 생성
+func NewSignupHandler(db *gorm.DB) *SignupHandler {
+	return &SignupHandler{
+		signupService: service.NewSignupService(db),
+		userService:   service.NewUserService(db),
+	}
+}
+
+// getCallerUserID JWT 컨텍스트에서 현재 사용자의 DB ID 조회
+func (h *SignupHandler) getCallerUserID(c echo.Context) (uint, error) {
+	kcUserID, ok := c.Get("kcUserId").(string)
+	if !ok || kcUserID == "" {
+		return 0, errors.New("kcUserId not found in context")
+	}
+	return h.userService.GetUserIDByKcID(c.Request().Context(), kcUserID)
+}
+
+// signupErrorStatus 서비스 오류를 HTTP 상태 코드로 변환
+func signupErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrSignupNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrSignupNotPending), errors.Is(err, service.ErrSignupNotVerified):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrSignupInvalid):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// parseSignupID 경로의 signupId 파싱
+func parseSignupID(c echo.Context) (uint, error) {
+	id, err := strconv.ParseUint(c.Param("signupId"), 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid signup ID")
+	}
+	return uint(id), nil
+}
+
+// ListSignups godoc
+// @Summary List signups
+// @Description List self-service signups. Signups waiting for email verification are refreshed from Keycloak first.
+// @Tags signups
+// @Accept json
+// @Produce json
+// @Param status query string false "Filter by status (PENDING_VERIFICATION/PENDING_APPROVAL/APPROVED/REJECTED)"
+// @Param email query string false "Filter by email (partial match)"
+// @Param domain query string false "Filter by email domain"
+// @Success 200 {array} model.Signup
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/signups [get]
+// @Id listSignups
+func (h *SignupHandler) ListSignups(c echo.Context) error {
+	var filter model.SignupFilterRequest
+	if err := c.Bind(&filter); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request format"})
+	}
+	filter.CompanyID = requestCompanyID(c)
+
+	signups, err := h.signupService.ListSignups(c.Request().Context(), &filter)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, signups)
+}
+
+// GetSignup godoc
+// @Summary Get signup
+// @Description Get a self-service signup
+// @Tags signups
+// @Accept json
+// @Produce json
+// @Param signupId path int true "Signup ID"
+// @Success 200 {object} model.Signup
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/signups/id/{signupId} [get]
+// @Id getSignup
+func (h *SignupHandler) GetSignup(c echo.Context) error {
+	signupID, err := parseSignupID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	signup, err := h.signupService.GetSignup(c.Request().Context(), signupID)
+	if err != nil {
+		return c.JSON(signupErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, signup)
+}
+
+// ApproveSignup godoc
+// @Summary Approve signup
+// @Description Enable the pending user and assign the initial platform role (default MC_IAM_MANAGER_SIGNUP_DEFAULT_PLATFORM_ROLE) and organizations. Signups waiting for email verification cannot be approved.
+// @Tags signups
+// @Accept json
+// @Produce json
+// @Param signupId path int true "Signup ID"
+// @Param body body model.SignupApproveRequest false "Initial platform role and organizations"
+// @Success 200 {object} model.Signup
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/signups/id/{signupId}/approve [put]
+// @Id approveSignup
+func (h *SignupHandler) ApproveSignup(c echo.Context) error {
+	signupID, err := parseSignupID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	callerID, err := h.getCallerUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+	var req model.SignupApproveRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request format"})
+	}
+
+	setAuditTarget(c, "signup.approve", "signup", c.Param("signupId"))
+	signup, err := h.signupService.ApproveSignup(c.Request().Context(), signupID, callerID, &req)
+	if err != nil {
+		return c.JSON(signupErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	setAuditAfter(c, signup)
+	return c.JSON(http.StatusOK, signup)
+}
+
+// RejectSignup godoc
+// @Summary Reject signup
+// @Description Reject a pending signup with a reason. The Keycloak user stays disabled.
+// @Tags signups
+// @Accept json
+// @Produce json
+// @Param signupId path int true "Signup ID"
+// @Param body body model.SignupRejectRequest true "Reject reason"
+// @Success 200 {object} model.Signup
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/signups/id/{signupId}/reject [put]
+// @Id rejectSignup
+func (h *SignupHandler) RejectSignup(c echo.Context) error {
+	signupID, err := parseSignupID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	callerID, err := h.getCallerUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+	var req model.SignupRejectRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request format"})
+	}
+
+	setAuditTarget(c, "signup.reject", "signup", c.Param("signupId"))
+	signup, err := h.signupService.RejectSignup(c.Request().Context(), signupID, callerID, req.Reason)
+	if err != nil {
+		return c.JSON(signupErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	setAuditAfter(c, signup)
+	return c.JSON(http.StatusOK, signup)
+}
+
+// ResendSignupVerification godoc
+// @Summary Resend signup verification email
+// @Description Trigger the Keycloak VERIFY_EMAIL action email again for a signup waiting for email verification
+// @Tags signups
+// @Accept json
+// @Produce json
+// @Param signupId path int true "Signup ID"
+// @Success 200 {object} model.Signup
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/signups/id/{signupId}/verification-email [post]
+// @Id resendSignupVerification
+func (h *SignupHandler) ResendSignupVerification(c echo.Context) error {
+	signupID, err := parseSignupID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	signup, err := h.signupService.ResendVerification(c.Request().Context(), signupID)
+	if err != nil {
+		return c.JSON(signupErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, signup)
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/middleware"
+)
+
+// requestCompanyID 멀티테넌트 모드에서 요청자의 회사 ID (단일 테넌트 모드이면 nil)
+func requestCompanyID(c echo.Context) *uint {
+	id, ok := c.Get("companyId").(uint)
+	if !ok || id == 0 {
+		return nil
+	}
+	return &id
+}
+
+// requestCompanyIDOrDefault 요청자의 회사 ID (단일 테넌트 모드이면 0 = 기본 회사)
+func requestCompanyIDOrDefault(c echo.Context) uint {
+	if id := requestCompanyID(c); id != nil {
+		return *id
+	}
+	return 0
+}
+
+// denyOtherTenantResource 요청 본문으로 받은 리소스가 다른 회사 소유이면 404(확인 실패 시 500) 응답을 쓰고 denied=true를 반환
+// 사용: if denied, err := denyOtherTenantResource(c, kind, id); denied { return err }
+func denyOtherTenantResource(c echo.Context, kind string, id uint) (bool, error) {
+	allowed, err := middleware.TenantResourceAllowed(c, kind, id)
+	if err != nil {
+		log.Printf("Failed to check tenant ownership of %s %d: %v", kind, id, err)
+		return true, c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to check tenant ownership"})
+	}
+	if !allowed {
+		return true, c.JSON(http.StatusNotFound, map[string]string{"error": fmt.Sprintf("%s not found", kind)})
+	}
+	return false, nil
+}
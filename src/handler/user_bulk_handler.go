@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"gorm.io/gorm"
+)
+
+// UserBulkHandler 사용자 일괄 가져오기/내보내기 핸들러
+type UserBulkHandler struct {
+	bulkService *service.UserBulkService
+}
+
+// NewUserBulkHandler 새 UserBulkHandler 인스턴스 생성
+func NewUserBulkHandler(db *gorm.DB) *UserBulkHandler {
+	return &UserBulkHandler{
+		bulkService: service.NewUserBulkService(db),
+	}
+}
+
+// isCSVRequest format 쿼리 파라미터 또는 Content-Type으로 CSV 여부 판단
+func isCSVRequest(c echo.Context) bool {
+	if format := strings.TrimSpace(c.QueryParam("format")); format != "" {
+		return strings.EqualFold(format, "csv")
+	}
+	return strings.HasPrefix(strings.ToLower(c.Request().Header.Get(echo.HeaderContentType)), "text/csv")
+}
+
+// ImportUsers godoc
+// @Summary Bulk import users
+// @Description CSV 또는 JSON 행으로 사용자를 일괄 생성하고 조직/플랫폼 역할/워크스페이스 역할을 할당합니다.
+// @Description CSV 컬럼: username,email,first_name,last_name,organization_codes,platform_roles,workspace_roles (여러 값은 ';', 워크스페이스 역할은 workspace:role)
+// @Description 행 단위로 처리하여 실패한 행이 있어도 나머지는 반영되며, 사용자명 기준으로 멱등합니다. dryRun=true이면 검증만 수행합니다.
+// @Tags users
+// @Accept json
+// @Accept text/csv
+// @Produce json
+// @Param format query string false "csv or json (default: Content-Type)"
+// @Param dryRun query bool false "Validate only without applying changes"
+// @Param body body model.UserImportRequest true "Users to import"
+// @Success 200 {object} model.UserImportResult
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/users/import [post]
+// @Id importUsers
+func (h *UserBulkHandler) ImportUsers(c echo.Context) error {
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "failed to read request body"})
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "request body is required"})
+	}
+	rows, err := service.ParseUserImport(body, isCSVRequest(c))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	dryRun, _ := strconv.ParseBool(c.QueryParam("dryRun"))
+
+	if !dryRun {
+		setAuditTarget(c, "user.import", "user", "")
+	}
+	result, err := h.bulkService.Import(c.Request().Context(), rows, dryRun, requestCompanyID(c))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidUserImport) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		log.Printf("[ERROR] ImportUsers failed: %v", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if !dryRun {
+		log.Printf("[INFO] Users imported: total=%d created=%d updated=%d unchanged=%d failed=%d",
+			result.Total, result.Created, result.Updated, result.Unchanged, result.Failed)
+		setAuditAfter(c, result)
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// ExportUsers godoc
+// @Summary Bulk export users
+// @Description 사용자와 직접 할당된 조직/플랫폼 역할/워크스페이스 역할을 가져오기와 같은 형식으로 내보냅니다 (탈퇴 사용자, 서비스 계정 제외)
+// @Tags users
+// @Produce json
+// @Produce text/csv
+// @Param format query string false "csv or json (default json)"
+// @Success 200 {array} model.UserImportRow
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/users/export [get]
+// @Id exportUsers
+func (h *UserBulkHandler) ExportUsers(c echo.Context) error {
+	rows, err := h.bulkService.Export(c.Request().Context())
+	if err != nil {
+		log.Printf("[ERROR] ExportUsers failed: %v", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if !strings.EqualFold(strings.TrimSpace(c.QueryParam("format")), "csv") {
+		return c.JSON(http.StatusOK, rows)
+	}
+	body, err := service.WriteUserExportCSV(rows)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	c.Response().Header().Set("Content-Disposition", `attachment; filename="users.csv"`)
+	return c.Blob(http.StatusOK, "text/csv", body)
+}
@@ -49,6 +49,7 @@ type UserHandler struct {
 	userService      *service.UserService
 	roleService      *service.RoleService
 	workspaceService *service.WorkspaceService
+	signupService    *service.SignupService
 	// db *gorm.DB // Not needed directly
 	// keycloakConfig *config.KeycloakConfig // Not needed directly
 	// keycloakClient *gocloak.GoCloak // Not needed directly
@@ -62,6 +63,7 @@ func NewUserHandler(db *gorm.DB) *UserHandler {
 		userService:      userService,
 		roleService:      roleService,
 		workspaceService: workspaceService,
+		signupService:    service.NewSignupService(db),
 	}
 }
 
@@ -232,7 +234,7 @@ func (h *UserHandler) CreateUser(c echo.Context) error {
 
 // SignupUser godoc
 // @Summary User signup
-// @Description Public user signup (no authentication required)
+// @Description Public user signup (no authentication required). Depending on configuration the request waits for email verification and/or admin approval (/api/signups), or is approved automatically for allowlisted email domains.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -260,7 +262,7 @@ func (h *UserHandler) SignupUser(c echo.Context) error {
 	}
 
 	// Create user in pending state
-	_, err := h.userService.SignupUser(c.Request().Context(), &req)
+	signup, err := h.signupService.Signup(c.Request().Context(), &req, requestCompanyID(c))
 	if err != nil {
 		if strings.Contains(err.Error(), "already in use") {
 			return c.JSON(http.StatusConflict, map[string]string{
@@ -273,9 +275,17 @@ func (h *UserHandler) SignupUser(c echo.Context) error {
 		})
 	}
 
+	message := "Signup request completed. You can login after admin approval"
+	switch signup.Status {
+	case model.SignupStatusPendingVerification:
+		message = "Signup request completed. Please verify your email address, then you can login after admin approval"
+	case model.SignupStatusApproved:
+		message = "Signup completed. You can login now"
+	}
 	return c.JSON(http.StatusCreated, map[string]interface{}{
 		"success":     true,
-		"message":     "Signup request completed. You can login after admin approval",
+		"message":     message,
+		"status":      signup.Status,
 		"redirectUrl": "/login",
 	})
 }
@@ -1077,6 +1087,8 @@ func (h *UserHandler) DeactivateUser(c echo.Context) error {
 	}
 	requestorKcIDVal := c.Get("kcUserId")
 	requestorKcID, _ := requestorKcIDVal.(string)
+	setAuditTarget(c, "user.deactivate", "user", c.Param("userId"))
+	setAuditBefore(c, h.auditUserState(userIDInt))
 	if err := h.userService.DeactivateUser(c.Request().Context(), userIDInt, requestorKcID); err != nil {
 		switch err.Error() {
 		case "cannot deactivate yourself":
@@ -1090,6 +1102,7 @@ func (h *UserHandler) DeactivateUser(c echo.Context) error {
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to deactivate user"})
 		}
 	}
+	setAuditAfter(c, h.auditUserState(userIDInt))
 	return c.NoContent(http.StatusNoContent)
 }
 
@@ -1114,6 +1127,8 @@ func (h *UserHandler) ActivateUser(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user ID format"})
 	}
+	setAuditTarget(c, "user.activate", "user", c.Param("userId"))
+	setAuditBefore(c, h.auditUserState(userIDInt))
 	if err := h.userService.ActivateUser(c.Request().Context(), userIDInt); err != nil {
 		switch err.Error() {
 		case "user is not inactive":
@@ -1125,6 +1140,7 @@ func (h *UserHandler) ActivateUser(c echo.Context) error {
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to activate user"})
 		}
 	}
+	setAuditAfter(c, h.auditUserState(userIDInt))
 	return c.NoContent(http.StatusNoContent)
 }
 
@@ -1145,6 +1161,7 @@ func (h *UserHandler) RequestWithdrawal(c echo.Context) error {
 	if !ok || kcUserID == "" {
 		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
 	}
+	setAuditTarget(c, "user.withdrawal.request", "user", kcUserID)
 	if err := h.userService.RequestWithdrawal(c.Request().Context(), kcUserID); err != nil {
 		switch err.Error() {
 		case "only active users can request withdrawal":
@@ -1177,6 +1194,8 @@ func (h *UserHandler) ProcessWithdrawal(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user ID format"})
 	}
+	setAuditTarget(c, "user.withdraw", "user", c.Param("userId"))
+	setAuditBefore(c, h.auditUserState(userIDInt))
 	if err := h.userService.ProcessWithdrawal(c.Request().Context(), userIDInt); err != nil {
 		switch err.Error() {
 		case "user has not requested withdrawal":
@@ -1188,5 +1207,26 @@ func (h *UserHandler) ProcessWithdrawal(c echo.Context) error {
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to process withdrawal"})
 		}
 	}
+	setAuditAfter(c, h.auditUserState(userIDInt))
 	return c.NoContent(http.StatusNoContent)
 }
+
+// auditUserState 감사 스냅샷용 사용자 상태 및 플랫폼 역할 목록
+func (h *UserHandler) auditUserState(userID uint) map[string]interface{} {
+	state := map[string]interface{}{"userId": userID}
+	if status, err := h.userService.GetUserStatus(userID); err == nil {
+		state["status"] = status
+	} else {
+		log.Printf("[WARN] audit snapshot: failed to get status for user %d: %v", userID, err)
+	}
+	if roles, err := h.roleService.GetUserPlatformRoles(userID); err == nil {
+		names := make([]string, 0, len(roles))
+		for _, role := range roles {
+			names = append(names, role.Name)
+		}
+		state["platformRoles"] = names
+	} else {
+		log.Printf("[WARN] audit snapshot: failed to get platform roles for user %d: %v", userID, err)
+	}
+	return state
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/service"
+)
+
+// UserReconcileHandler Keycloak ↔ DB 사용자 정합성 점검 핸들러
+type UserReconcileHandler struct {
+	reconciler *service.UserReconciler
+}
+
+// NewUserReconcileHandler 새 UserReconcileHandler 인스턴스 생성
+func NewUserReconcileHandler(reconciler *service.UserReconciler) *UserReconcileHandler {
+	return &UserReconcileHandler{reconciler: reconciler}
+}
+
+// GetUserReconcileStatus godoc
+// @Summary 사용자 정합성 점검 상태 조회
+// @Description Keycloak ↔ DB 사용자 정합성 점검 설정(주기, 자동 수정 여부)과 마지막 실행 결과(불일치 목록, 수정/실패 건수)를 반환합니다.
+// @Tags users
+// @Produce json
+// @Success 200 {object} model.UserReconcileStatusResponse
+// @Security BearerAuth
+// @Router /api/setup/users/reconcile-status [get]
+// @Id getUserReconcileStatus
+func (h *UserReconcileHandler) GetUserReconcileStatus(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.reconciler.Status())
+}
+
+// RunUserReconcile godoc
+// @Summary 사용자 정합성 점검 실행
+// @Description 모든 realm의 Keycloak 사용자와 mcmp_users를 비교합니다 (양방향 누락, 상태/사용자명 불일치, 플랫폼 역할 불일치).
+// @Description fix=true이면 더 제한적인 쪽으로 불일치를 수정합니다 (Keycloak에서 삭제된 사용자 탈퇴 처리, 비활성 상태 동기화, 한쪽에만 있는 플랫폼 역할 제거). 기본은 보고만 합니다.
+// @Tags users
+// @Produce json
+// @Param fix query bool false "불일치 수정 여부 (기본 false)"
+// @Success 200 {object} model.UserReconcileRun
+// @Failure 400 {object} map[string]string "error: 잘못된 요청"
+// @Failure 409 {object} map[string]string "error: 이미 실행 중"
+// @Security BearerAuth
+// @Router /api/setup/users/reconcile [post]
+// @Id runUserReconcile
+func (h *UserReconcileHandler) RunUserReconcile(c echo.Context) error {
+	fix := false
+	if raw := c.QueryParam("fix"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "fix는 true 또는 false여야 합니다"})
+		}
+		fix = parsed
+	}
+	if fix {
+		setAuditTarget(c, "user.reconcile", "user", "")
+	}
+	run, err := h.reconciler.RunOnce(c.Request().Context(), fix)
+	if err != nil {
+		if errors.Is(err, service.ErrUserReconcileRunning) {
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if fix {
+		setAuditAfter(c, run)
+	}
+	return c.JSON(http.StatusOK, run)
+}
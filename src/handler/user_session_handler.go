@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"github.com/m-cmp/mc-iam-manager/util"
+	"gorm.io/gorm"
+)
+
+// UserSessionHandler 사용자 Keycloak 세션 조회/종료 및 access token 폐기 핸들러
+type UserSessionHandler struct {
+	userService            *service.UserService
+	tokenRevocationService *service.TokenRevocationService
+}
+
+// NewUserSessionHandler 새 UserSessionHandler 인스턴스 생성
+func NewUserSessionHandler(db *gorm.DB) *UserSessionHandler {
+	return &UserSessionHandler{
+		userService:            service.NewUserService(db),
+		tokenRevocationService: service.NewTokenRevocationService(db),
+	}
+}
+
+// ListUserSessions godoc
+// @Summary List user sessions
+// @Description 사용자의 활성 Keycloak 세션 목록(IP, 시작/마지막 접근 시각, 클라이언트)을 반환합니다.
+// @Tags users
+// @Produce json
+// @Param userId path string true "User DB ID"
+// @Success 200 {array} model.UserSession
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/users/id/{userId}/sessions [get]
+// @Id listUserSessions
+func (h *UserSessionHandler) ListUserSessions(c echo.Context) error {
+	user, err := h.findUser(c)
+	if user == nil {
+		return err
+	}
+	sessions, err := h.tokenRevocationService.ListUserSessions(c.Request().Context(), user.KcId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to list user sessions: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, sessions)
+}
+
+// RevokeUserSession godoc
+// @Summary Revoke user session
+// @Description 사용자의 Keycloak 세션 하나를 종료하고, 그 세션으로 발급된 access token을 즉시 거부합니다.
+// @Tags users
+// @Produce json
+// @Param userId path string true "User DB ID"
+// @Param sessionId path string true "Keycloak session ID"
+// @Success 200 {object} model.RevokeUserSessionsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/users/id/{userId}/sessions/{sessionId} [delete]
+// @Id revokeUserSession
+func (h *UserSessionHandler) RevokeUserSession(c echo.Context) error {
+	user, err := h.findUser(c)
+	if user == nil {
+		return err
+	}
+	sessionID := c.Param("sessionId")
+	setAuditTarget(c, "user.session.revoke", "user", c.Param("userId"))
+	setAuditBefore(c, map[string]string{"sessionId": sessionID})
+
+	actor, _ := c.Get("kcUserId").(string)
+	resp, err := h.tokenRevocationService.RevokeSession(c.Request().Context(), user.KcId, sessionID, actor)
+	if err != nil {
+		if errors.Is(err, service.ErrUserSessionNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to revoke user session: " + err.Error()})
+	}
+	setAuditAfter(c, resp)
+	return c.JSON(http.StatusOK, resp)
+}
+
+// RevokeAllUserSessions godoc
+// @Summary Revoke all user sessions
+// @Description 사용자의 모든 Keycloak 세션을 종료하고, 지금까지 발급된 access token을 모두 거부합니다.
+// @Tags users
+// @Produce json
+// @Param userId path string true "User DB ID"
+// @Success 200 {object} model.RevokeUserSessionsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/users/id/{userId}/sessions [delete]
+// @Id revokeAllUserSessions
+func (h *UserSessionHandler) RevokeAllUserSessions(c echo.Context) error {
+	user, err := h.findUser(c)
+	if user == nil {
+		return err
+	}
+	setAuditTarget(c, "user.session.revoke-all", "user", c.Param("userId"))
+
+	actor, _ := c.Get("kcUserId").(string)
+	resp, err := h.tokenRevocationService.RevokeAllSessions(c.Request().Context(), user.KcId, service.TokenRevokedByAdmin, actor)
+	if err != nil {
+		if resp != nil {
+			// 로컬 폐기는 기록됨 — Keycloak 세션 종료만 실패
+			setAuditAfter(c, resp)
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to revoke user sessions: " + err.Error()})
+	}
+	setAuditAfter(c, resp)
+	return c.JSON(http.StatusOK, resp)
+}
+
+// findUser 경로의 userId로 사용자 조회 (실패 시 오류 응답을 쓰고 nil 사용자 반환)
+func (h *UserSessionHandler) findUser(c echo.Context) (*model.User, error) {
+	userID, err := util.StringToUint(c.Param("userId"))
+	if err != nil {
+		return nil, c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user ID format"})
+	}
+	user, err := h.userService.GetUserByID(c.Request().Context(), userID)
+	if err != nil || user == nil {
+		if user == nil || strings.Contains(err.Error(), "not found") {
+			return nil, c.JSON(http.StatusNotFound, map[string]string{"error": "User not found"})
+		}
+		return nil, c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to get user"})
+	}
+	if user.KcId == "" {
+		return nil, c.JSON(http.StatusBadRequest, map[string]string{"error": "User is not linked to Keycloak"})
+	}
+	return user, nil
+}
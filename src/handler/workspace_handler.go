@@ -80,6 +80,8 @@ func (h *WorkspaceHandler) ListWorkspaces(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request format"})
 	}
 
+	req.CompanyID = requestCompanyID(c)
+
 	var workspaces []*model.Workspace
 	// if hasListAllPermission {
 	// User has permission to list all workspaces
@@ -163,6 +165,9 @@ func (h *WorkspaceHandler) CreateWorkspace(c echo.Context) error {
 		})
 	}
 
+	// 소유 회사는 요청자의 회사로 고정
+	workspace.CompanyID = requestCompanyID(c)
+
 	if err := h.workspaceService.CreateWorkspace(&workspace); err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to create workspace",
@@ -388,6 +393,7 @@ func (h *WorkspaceHandler) ListWorkspaceProjects(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request format"})
 	}
 
+	req.CompanyID = requestCompanyID(c)
 	workspaceProjects, err := h.workspaceService.ListWorkspacesProjects(&req)
 	if err != nil {
 		// Handle not found error from service (which checks workspace existence)
@@ -458,6 +464,9 @@ func (h *WorkspaceHandler) AddProjectToWorkspace(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid workspace ID format"})
 	}
+	if denied, err := denyOtherTenantResource(c, model.TenantResourceWorkspace, workspaceIDInt); denied {
+		return err
+	}
 
 	for _, projectID := range req.ProjectIDs {
 		projectIDInt, err := util.StringToUint(projectID)
@@ -500,6 +509,9 @@ func (h *WorkspaceHandler) RemoveProjectFromWorkspace(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid workspace ID format"})
 	}
+	if denied, err := denyOtherTenantResource(c, model.TenantResourceWorkspace, workspaceIDInt); denied {
+		return err
+	}
 
 	// Cannot remove project from default workspace
 	if req.WorkspaceID == "1" {
@@ -573,6 +585,7 @@ func (h *WorkspaceHandler) ListWorkspaceRoles(c echo.Context) error {
 	if req.RoleTypes == nil {
 		req.RoleTypes = []constants.IAMRoleType{constants.RoleTypeWorkspace}
 	}
+	req.CompanyID = requestCompanyID(c)
 
 	roles, err := h.roleService.ListWorkspaceRoles(&req)
 	if err != nil {
@@ -615,6 +628,9 @@ func (h *WorkspaceHandler) AddUserToWorkspace(c echo.Context) error {
 		log.Printf("Workspace ID conversion error: %v", err)
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid workspace ID format"})
 	}
+	if denied, err := denyOtherTenantResource(c, model.TenantResourceWorkspace, workspaceID); denied {
+		return err
+	}
 	if req.UserID != "" {
 		userID, err = util.StringToUint(req.UserID)
 		if err != nil {
@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"io"
 	"log"
 	"net/http"
@@ -18,6 +20,9 @@ import (
 	"github.com/m-cmp/mc-iam-manager/config"
 	"github.com/m-cmp/mc-iam-manager/handler"
 	"github.com/m-cmp/mc-iam-manager/middleware"
+	"github.com/m-cmp/mc-iam-manager/pkg/envelope"
+	"github.com/m-cmp/mc-iam-manager/pkg/metrics"
+	"github.com/m-cmp/mc-iam-manager/service"
 	"github.com/m-cmp/mc-iam-manager/util"
 
 	// "github.com/m-cmp/mc-iam-manager/repository" // Removed unused import
@@ -59,6 +64,17 @@ func main() {
 	// .env 파일 로드 (프로젝트 루트에서 찾도록 수정)
 	util.LoadEnvFiles()
 
+	// 키 초기화 명령: mc-iam-manager init-keys (암호화 키 파일, 워크스페이스 티켓 서명 키가 없을 때만 생성, DB 연결 불필요)
+	if len(os.Args) > 1 && os.Args[1] == "init-keys" {
+		if err := config.InitEncryptionKeyring(); err != nil {
+			log.Fatalf("Failed to initialize keys: %v", err)
+		}
+		if err := config.InitWorkspaceTicketSigningKey(); err != nil {
+			log.Fatalf("Failed to initialize keys: %v", err)
+		}
+		return
+	}
+
 	// 시작 로그 추가
 	log.Printf("=== Application Starting ===")
 
@@ -70,6 +86,18 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
+	// DB 커넥션 풀 지표 (/metrics)
+	if sqlDB, err := db.DB(); err == nil {
+		if err := metrics.RegisterDBStats(sqlDB, dbConfig.DBName); err != nil {
+			log.Printf("[WARN] failed to register DB stats metrics: %v", err)
+		}
+	}
+
+	// 저장 시 암호화 키 초기화 (민감 필드 GORM serializer)
+	keyProvider, err := config.InitEncryption()
+	if err != nil {
+		log.Fatalf("Failed to initialize encryption: %v", err)
+	}
 
 	// 데이터베이스 마이그레이션 실행
 	if err := db.AutoMigrate(
@@ -99,31 +127,87 @@ func main() {
 		&model.GroupPlatformRole{},
 		&model.GroupWorkspaceRole{},
 		&model.WorkspaceInvitation{},
+		&model.AccessRequest{},
+		&model.AccessRequestEvent{},
 		&model.Company{},
+		&model.AuditEvent{},
+		&model.WorkspaceTicket{},
+		&model.CspHealthCheck{},
+		&model.CspHealthState{},
+		&model.TokenRevocation{},
+		&model.ServiceAccount{},
+		&model.ServiceAccountApiKey{},
+		&model.PolicyRule{},
+		&model.Signup{},
+		&model.Offboarding{},
 	); err != nil {
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
 
+	// 키 교체 명령: mc-iam-manager rotate-encryption-keys [--reencrypt-only]
+	if len(os.Args) > 1 && os.Args[1] == "rotate-encryption-keys" {
+		if err := rotateEncryptionKeys(db, keyProvider, os.Args[2:]); err != nil {
+			log.Fatalf("Failed to rotate encryption keys: %v", err)
+		}
+		return
+	}
+
+	// 워크스페이스 티켓 서명 키 확인 (임시 키는 개발용으로 명시한 경우에만 허용)
+	if err := config.ValidateWorkspaceTicketSigningKey(); err != nil {
+		log.Fatalf("Failed to load workspace ticket signing key: %v", err)
+	}
+
 	// Keycloak 초기화
 	if err := config.InitKeycloak(); err != nil {
 		log.Fatalf("Failed to initialize Keycloak: %v", err)
 	}
 
+	// AuthMiddleware에서 폐기된 access token 거부
+	middleware.SetTokenRevocationChecker(service.NewTokenRevocationService(db))
+	// 서비스 계정 API 키 인증 (X-API-Key 또는 Bearer mciam_...)
+	middleware.SetApiKeyAuthenticator(service.NewServiceAccountService(db))
+	// mc-iam-manager 자체 권한 시드 및 RequirePermission 권한 조회기
+	if err := service.NewMciamPermissionService(db).LoadAndRegisterFrameworkPermissionsFromYAML(""); err != nil {
+		log.Printf("[WARN] failed to register mc-iam-manager permissions: %v", err)
+	}
+	middleware.SetPermissionResolver(service.NewAuthzService(db))
+	// 멀티테넌트 모드: 회사별 realm 등록 및 경로 리소스의 회사 소유권 확인
+	companyService := service.NewCompanyService(db)
+	middleware.SetTenantResourceResolver(companyService)
+	if config.MultiTenantEnabled() {
+		if err := companyService.LoadTenants(); err != nil {
+			log.Fatalf("Failed to load tenants: %v", err)
+		}
+	}
+
 	// 핸들러 초기화
 	authHandler := handler.NewAuthHandler(db)
 	adminHandler := handler.NewAdminHandler(db)
 	userHandler := handler.NewUserHandler(db)
+	userSessionHandler := handler.NewUserSessionHandler(db)
 	menuHandler := handler.NewMenuHandler(db)
 	workspaceHandler := handler.NewWorkspaceHandler(db)
 	workspaceInvitationHandler := handler.NewWorkspaceInvitationHandler(db)
+	accessRequestHandler := handler.NewAccessRequestHandler(db)
+	signupHandler := handler.NewSignupHandler(db)
+	offboardingHandler := handler.NewOffboardingHandler(db)
+	userBulkHandler := handler.NewUserBulkHandler(db)
+	iamBundleHandler := handler.NewIamBundleHandler(db)
+
+	cspHealthHandler := handler.NewCspHealthHandler(db)
 
 	projectHandler := handler.NewProjectHandler(db)
+	projectSyncWorker := service.NewProjectSyncWorker(db)
+	projectSyncHandler := handler.NewProjectSyncHandler(projectSyncWorker)
+	userReconciler := service.NewUserReconciler(db)
+	userReconcileHandler := handler.NewUserReconcileHandler(userReconciler)
 
 	resourceTypeHandler := handler.NewResourceTypeHandler(db)
 	cspCredentialHandler := handler.NewCspCredentialHandler(db)
 	mcmpApiHandler := handler.NewMcmpApiHandler(db)
 	mcmpApiPermissionActionMappingHandler := handler.NewMcmpApiPermissionActionMappingHandler(db)
 	healthHandler := handler.NewHealthHandler(db)
+	metricsHandler := handler.NewMetricsHandler(config.MetricsToken())
 	permissionHandler := handler.NewMciamPermissionHandler(db)
 	roleHandler := handler.NewRoleHandler(db)
 
@@ -140,6 +224,16 @@ func main() {
 	groupRoleHandler := handler.NewGroupRoleHandler(db)
 	// 회사 정보 핸들러 초기화
 	companyHandler := handler.NewCompanyHandler(db)
+	// 감사 로그 핸들러 초기화
+	auditHandler := handler.NewAuditHandler(db)
+	// 권한 판단(PDP) 핸들러 초기화
+	authzHandler := handler.NewAuthzHandler(db)
+	// 서비스 계정 핸들러 초기화
+	serviceAccountHandler := handler.NewServiceAccountHandler(db)
+	// 정책 규칙 핸들러 초기화
+	policyRuleHandler := handler.NewPolicyRuleHandler(db)
+	// SCIM 2.0 프로비저닝 핸들러 초기화
+	scimHandler := handler.NewScimHandler(db)
 
 	// Echo 인스턴스 생성
 	e := echo.New()
@@ -157,6 +251,8 @@ func main() {
 	}))
 	e.Use(echomiddleware.Recover())
 	e.Use(echomiddleware.CORS())
+	e.Use(echomiddleware.RequestID())
+	e.Use(middleware.MetricsMiddleware)
 
 	basePath := "/api"
 
@@ -167,17 +263,30 @@ func main() {
 		basePath + "/auth/login",
 		basePath + "/auth/logout",
 		basePath + "/auth/refresh",
-		basePath + "/auth/certs",  // 인증서 조회 경로 추가
-		basePath + "/auth/signup", // 사용자 가입 신청 경로 추가
+		basePath + "/auth/certs",                  // 인증서 조회 경로 추가
+		basePath + "/auth/signup",                 // 사용자 가입 신청 경로 추가
+		basePath + "/auth/workspace-ticket/certs", // 워크스페이스 티켓 서명 공개키
 
 	}
 
+	// 멀티테넌트 모드: X-Tenant 헤더로 회사(realm) 선택 (토큰이 있으면 인증 시 iss와 일치 여부 확인)
+	e.Use(middleware.TenantMiddleware)
+
 	// 인증 미들웨어 설정
 	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			path := c.Request().URL.Path
 			//c.Logger().Debugf("Checking auth skip for path: %s", path)
 
+			// SCIM 프로비저닝은 MC_IAM_MANAGER_SCIM_TOKEN으로 별도 보호
+			if strings.HasPrefix(path, model.ScimBasePath) {
+				return next(c)
+			}
+			// /metrics는 MC_IAM_MANAGER_METRICS_TOKEN으로 별도 보호 (정확한 경로만)
+			if path == "/metrics" {
+				return next(c)
+			}
+
 			for _, skipPath := range skipAuthPaths {
 				// 정확한 경로 일치 또는 path가 skipPath로 끝나는 경우
 				if path == skipPath || strings.HasSuffix(path, skipPath) {
@@ -192,10 +301,37 @@ func main() {
 		}
 	})
 
+	// 감사 로그 미들웨어 (인증 이후 실행되어 행위자 정보를 기록)
+	e.Use(middleware.AuditMiddleware(db))
+
 	// 라우트 설정
 	e.GET("/readyz", healthHandler.CheckHealth)
+	e.GET("/metrics", metricsHandler.GetMetrics)
+
+	// SCIM 2.0 프로비저닝 라우트 (IdP → 사용자/그룹 동기화, 토큰 미설정 시 비활성)
+	scim := e.Group(model.ScimBasePath, middleware.ScimAuthMiddleware(config.ScimToken()))
+	{
+		scim.GET("/ServiceProviderConfig", scimHandler.GetServiceProviderConfig)
+		scim.GET("/ResourceTypes", scimHandler.ListResourceTypes)
+		scim.GET("/Schemas", scimHandler.ListSchemas)
+		scim.GET("/Schemas/:schemaId", scimHandler.GetSchema)
+		scim.GET("/Users", scimHandler.ListScimUsers)
+		scim.POST("/Users", scimHandler.CreateScimUser)
+		scim.GET("/Users/:id", scimHandler.GetScimUser)
+		scim.PUT("/Users/:id", scimHandler.ReplaceScimUser)
+		scim.PATCH("/Users/:id", scimHandler.PatchScimUser)
+		scim.DELETE("/Users/:id", scimHandler.DeleteScimUser)
+		scim.GET("/Groups", scimHandler.ListScimGroups)
+		scim.POST("/Groups", scimHandler.CreateScimGroup)
+		scim.GET("/Groups/:id", scimHandler.GetScimGroup)
+		scim.PUT("/Groups/:id", scimHandler.ReplaceScimGroup)
+		scim.PATCH("/Groups/:id", scimHandler.PatchScimGroup)
+		scim.DELETE("/Groups/:id", scimHandler.DeleteScimGroup)
+	}
 
 	api := e.Group(basePath)
+	// 경로의 워크스페이스/역할/CSP 계정이 요청자 회사 소유인지 확인 (라우팅 이후 실행)
+	api.Use(middleware.TenantScopeMiddleware)
 
 	// 인증 라우트
 	auth := api.Group("/auth")
@@ -204,6 +340,7 @@ func main() {
 		auth.POST("/logout", authHandler.Logout)
 		auth.POST("/refresh", authHandler.RefreshToken)
 		auth.GET("/certs", authHandler.AuthCerts)
+		auth.GET("/workspace-ticket/certs", authHandler.WorkspaceTicketCerts)
 		auth.GET("/temp-credential-csps", authHandler.GetTempCredentialProviders)
 		auth.POST("/validate", authHandler.Validate)
 		auth.POST("/signup", userHandler.SignupUser) // Public signup
@@ -212,23 +349,33 @@ func main() {
 	// platform admin 생성. 권한체크 필요한데...
 	api.POST("/initial-admin", adminHandler.SetupInitialAdmin) // TODO : 초기 설정에서 직접 keycloak 호출하는 것으로 바꿔야 할 듯.
 
-	// 회사 정보 라우트 (싱글톤 — URL에 ID 없음)
+	// 회사 정보 라우트 (요청자의 회사 — URL에 ID 없음)
 	company := api.Group("/company")
 	{
-		company.POST("", companyHandler.CreateCompany, middleware.PlatformAdminMiddleware)
+		company.POST("", companyHandler.CreateCompany, middleware.PlatformAdminMiddleware, middleware.DefaultTenantOnlyMiddleware)
 		company.GET("", companyHandler.GetCompany, middleware.PlatformRoleMiddleware(middleware.Read))
 		company.PUT("", companyHandler.UpdateCompany, middleware.PlatformAdminMiddleware)
 		company.DELETE("", companyHandler.DeactivateCompany, middleware.PlatformAdminMiddleware)
 		company.POST("/activate", companyHandler.ActivateCompany, middleware.PlatformAdminMiddleware)
 	}
 
-	// 관리자 setup 라우트
-	setup := api.Group("/setup", middleware.PlatformAdminMiddleware)
+	// 멀티테넌트 모드 회사 목록 (기본 realm 운영자 전용)
+	companies := api.Group("/companies", middleware.PlatformAdminMiddleware, middleware.DefaultTenantOnlyMiddleware)
+	{
+		companies.GET("", companyHandler.ListCompanies)
+		companies.GET("/id/:companyId", companyHandler.GetCompanyByID)
+	}
+
+	// 관리자 setup 라우트 (전체 테넌트에 영향을 주므로 멀티테넌트 모드에서는 기본 realm만 허용)
+	setup := api.Group("/setup", middleware.PlatformAdminMiddleware, middleware.DefaultTenantOnlyMiddleware)
 	{
 		setup.GET("/check-user-roles", adminHandler.CheckUserRoles)
 		setup.POST("/sync-projects", projectHandler.SyncProjects)
 		setup.GET("/projects/sync-diff", projectHandler.GetProjectSyncDiff)
 		setup.POST("/projects/sync", projectHandler.ApplyProjectSync)
+		setup.GET("/projects/sync-status", projectSyncHandler.GetProjectSyncStatus)
+		setup.GET("/users/reconcile-status", userReconcileHandler.GetUserReconcileStatus)
+		setup.POST("/users/reconcile", userReconcileHandler.RunUserReconcile)
 		setup.POST("/sync-mcmp-apis", mcmpApiHandler.SyncMcmpAPIs)
 		setup.POST("/initial-menus", menuHandler.RegisterMenusFromYAML, middleware.PlatformAdminMiddleware)
 		setup.POST("/initial-menus2", menuHandler.RegisterMenusFromBody, middleware.PlatformAdminMiddleware)
@@ -237,7 +384,11 @@ func main() {
 		setup.GET("/initial-role-menu-permission-yaml", adminHandler.InitializeMenuPermissionsFromYAML, middleware.PlatformAdminMiddleware)
 		setup.GET("/backup-role-permissions", adminHandler.BackupRolePermissions, middleware.PlatformAdminMiddleware)
 		setup.POST("/restore-role-permissions", adminHandler.RestoreRolePermissions, middleware.PlatformAdminMiddleware)
+		setup.GET("/iam-bundle", iamBundleHandler.ExportIamBundle)
+		setup.POST("/iam-bundle/diff", iamBundleHandler.DiffIamBundle)
+		setup.POST("/iam-bundle/apply", iamBundleHandler.ApplyIamBundle)
 		setup.POST("/initial-organizations", organizationHandler.SetupInitialOrganizations, middleware.PlatformAdminMiddleware)
+		setup.POST("/initial-iam-permissions", adminHandler.InitializeIamPermissions)
 	}
 
 	// 워크스페이스 라우트
@@ -247,11 +398,15 @@ func main() {
 		workspaces.POST("", workspaceHandler.CreateWorkspace)
 		workspaces.GET("/id/:workspaceId", workspaceHandler.GetWorkspaceByID)
 		workspaces.GET("/name/:workspaceName", workspaceHandler.GetWorkspaceByName)
-		workspaces.PUT("/id/:workspaceId", workspaceHandler.UpdateWorkspace, middleware.PlatformRoleMiddleware(middleware.Write))
-		workspaces.DELETE("/id/:workspaceId", workspaceHandler.DeleteWorkspace, middleware.PlatformRoleMiddleware(middleware.Write))
+		workspaces.PUT("/id/:workspaceId", workspaceHandler.UpdateWorkspace, middleware.RequirePermission("mc-iam-manager:workspace:update"))
+		workspaces.DELETE("/id/:workspaceId", workspaceHandler.DeleteWorkspace, middleware.RequirePermission("mc-iam-manager:workspace:delete"))
 
 		workspaces.POST("/workspace-ticket", authHandler.WorkspaceTicket) // 1개 워크스페이스에 대한 티켓 설정
+		workspaces.POST("/workspace-ticket/refresh", authHandler.RefreshWorkspaceTicket)
+		workspaces.POST("/workspace-ticket/revoke", authHandler.RevokeWorkspaceTicket)
+		workspaces.POST("/workspace-ticket/introspect", authHandler.IntrospectWorkspaceTicket)
 		workspaces.POST("/temporary-credentials", cspCredentialHandler.GetTemporaryCredentials)
+		workspaces.POST("/temporary-credentials/revoke", cspCredentialHandler.RevokeCachedCredentials, middleware.PlatformAdminMiddleware, middleware.DefaultTenantOnlyMiddleware) // 캐시된 임시 자격 증명 폐기
 		workspaces.POST("/credentials/validate", cspValidationHandler.ValidateCredentials)
 
 		workspaces.POST("/users/list", workspaceHandler.ListWorkspaceUsers, middleware.PlatformRoleMiddleware(middleware.Write))               // workspace의 사용자 목록 조회
@@ -259,9 +414,9 @@ func main() {
 		workspaces.POST("/roles/list", workspaceHandler.ListWorkspaceRoles, middleware.PlatformRoleMiddleware(middleware.Write))               // workspace 역할 목록 조회
 
 		workspaces.POST("/projects/list", workspaceHandler.ListWorkspaceProjects, middleware.PlatformRoleMiddleware(middleware.Write))
-		workspaces.GET("/id/:workspaceId/projects/list", workspaceHandler.GetWorkspaceProjectsByWorkspaceId, middleware.PlatformRoleMiddleware(middleware.Write))
-		workspaces.POST("/id/:workspaceId/users/list", workspaceHandler.ListUsersAndRolesByWorkspaces)                                              // TODO ListAllWorkspaceUsersAndRoles으로 대체 또는 통합 가능하지 않나?
-		workspaces.GET("/id/:workspaceId/users/id/:userId", roleHandler.GetUserWorkspaceRoles, middleware.PlatformRoleMiddleware(middleware.Write)) // 특정 사용자에게 할당된 워크스페이스 역할 조회 ( 관리자가 사용자의 workspace role 조회) --> get을 post로 바꿀까?
+		workspaces.GET("/id/:workspaceId/projects/list", workspaceHandler.GetWorkspaceProjectsByWorkspaceId, middleware.RequirePermission("mc-iam-manager:project:read"))
+		workspaces.POST("/id/:workspaceId/users/list", workspaceHandler.ListUsersAndRolesByWorkspaces)                                                               // TODO ListAllWorkspaceUsersAndRoles으로 대체 또는 통합 가능하지 않나?
+		workspaces.GET("/id/:workspaceId/users/id/:userId", roleHandler.GetUserWorkspaceRoles, middleware.RequirePermission("mc-iam-manager:workspace-member:read")) // 특정 사용자에게 할당된 워크스페이스 역할 조회 ( 관리자가 사용자의 workspace role 조회) --> get을 post로 바꿀까?
 
 		workspaces.POST("/id/:id/users", workspaceHandler.AddUserToWorkspace, middleware.PlatformRoleMiddleware(middleware.Write))                // workspace에 사용자 추가
 		workspaces.DELETE("/id/:id/users/:userId", workspaceHandler.RemoveUserFromWorkspace, middleware.PlatformRoleMiddleware(middleware.Write)) // workspace에서 사용자 제거
@@ -296,6 +451,7 @@ func main() {
 		roles.POST("/list", roleHandler.ListRoles)
 		roles.POST("", roleHandler.CreateRole, middleware.PlatformRoleMiddleware(middleware.Write))
 		roles.GET("/id/:roleId", roleHandler.GetRoleByRoleID)
+		roles.GET("/id/:roleId/effective-grants", roleHandler.GetRoleEffectiveGrants, middleware.RequirePermission("mc-iam-manager:role:read"))
 		roles.GET("/name/:roleName", roleHandler.GetRoleByRoleName)
 		roles.PUT("/id/:roleId", roleHandler.UpdateRole, middleware.PlatformRoleMiddleware(middleware.Write))
 		roles.DELETE("/id/:roleId", roleHandler.DeleteRole, middleware.PlatformRoleMiddleware(middleware.Write))
@@ -309,8 +465,8 @@ func main() {
 		roles.POST("/assign/platform-role", roleHandler.AssignPlatformRole, middleware.PlatformRoleMiddleware(middleware.Write))
 		roles.DELETE("/unassign/platform-role", roleHandler.RemovePlatformRole, middleware.PlatformRoleMiddleware(middleware.Write))
 		// 사용자에게 워크스페이스 역할 할당
-		roles.POST("/assign/workspace-role", roleHandler.AssignWorkspaceRole, middleware.PlatformRoleMiddleware(middleware.Write))
-		roles.DELETE("/unassign/workspace-role", roleHandler.RemoveWorkspaceRole, middleware.PlatformRoleMiddleware(middleware.Write))
+		roles.POST("/assign/workspace-role", roleHandler.AssignWorkspaceRole, middleware.RequirePermission("mc-iam-manager:workspace-member:create"))
+		roles.DELETE("/unassign/workspace-role", roleHandler.RemoveWorkspaceRole, middleware.RequirePermission("mc-iam-manager:workspace-member:delete"))
 
 		// csp role 매핑 관리
 		roles.POST("/csp-roles", roleHandler.AddCspRoleMappings, middleware.PlatformRoleMiddleware(middleware.Manage))
@@ -358,6 +514,8 @@ func main() {
 	{
 		users.POST("/list", userHandler.ListUsers, middleware.PlatformRoleMiddleware(middleware.Read))
 		users.POST("", userHandler.CreateUser, middleware.PlatformRoleMiddleware(middleware.Write))
+		users.POST("/import", userBulkHandler.ImportUsers, middleware.PlatformRoleMiddleware(middleware.Write))
+		users.GET("/export", userBulkHandler.ExportUsers, middleware.PlatformRoleMiddleware(middleware.Read))
 		users.GET("/id/:userId", userHandler.GetUserByID, middleware.PlatformRoleMiddleware(middleware.Read))
 		users.GET("/kc/:kcUserId", userHandler.GetUserByKcID, middleware.PlatformRoleMiddleware(middleware.Read))
 		users.GET("/name/:username", userHandler.GetUserByUsername, middleware.PlatformRoleMiddleware(middleware.Read))
@@ -365,14 +523,18 @@ func main() {
 		users.DELETE("/id/:userId", userHandler.DeleteUser, middleware.PlatformRoleMiddleware(middleware.Write))
 		users.POST("/id/:userId/status", userHandler.UpdateUserStatus, middleware.PlatformRoleMiddleware(middleware.Write))
 		users.PUT("/id/:userId/password", userHandler.ResetUserPassword, middleware.PlatformRoleMiddleware(middleware.Write))
-		users.GET("/me", userHandler.GetMyInfo)                                                              // 사용자 본인 정보 조회
-		users.PUT("/me/password", userHandler.ChangeMyPassword)                                              // 사용자 본인 패스워드 변경
-		users.GET("/me/platform-roles", userHandler.GetMyPlatformRoles)                                      // 내 유효 플랫폼 역할 목록
-		users.GET("/me/workspace-roles", userHandler.GetMyWorkspaceRoles)                                    // 내 유효 워크스페이스 역할 목록
-		users.PUT("/id/:userId/deactivate", userHandler.DeactivateUser, middleware.PlatformAdminMiddleware)  // 사용자 계정 비활성화
-		users.PUT("/id/:userId/activate", userHandler.ActivateUser, middleware.PlatformAdminMiddleware)      // 사용자 계정 재활성화
-		users.POST("/me/withdrawal", userHandler.RequestWithdrawal)                                          // 탈퇴 신청
-		users.PUT("/id/:userId/withdraw", userHandler.ProcessWithdrawal, middleware.PlatformAdminMiddleware) // 탈퇴 처리
+		users.GET("/me", userHandler.GetMyInfo)                                                                                   // 사용자 본인 정보 조회
+		users.PUT("/me/password", userHandler.ChangeMyPassword)                                                                   // 사용자 본인 패스워드 변경
+		users.GET("/me/platform-roles", userHandler.GetMyPlatformRoles)                                                           // 내 유효 플랫폼 역할 목록
+		users.GET("/me/workspace-roles", userHandler.GetMyWorkspaceRoles)                                                         // 내 유효 워크스페이스 역할 목록
+		users.PUT("/id/:userId/deactivate", userHandler.DeactivateUser, middleware.PlatformAdminMiddleware)                       // 사용자 계정 비활성화
+		users.PUT("/id/:userId/activate", userHandler.ActivateUser, middleware.PlatformAdminMiddleware)                           // 사용자 계정 재활성화
+		users.POST("/me/withdrawal", userHandler.RequestWithdrawal)                                                               // 탈퇴 신청
+		users.PUT("/id/:userId/withdraw", userHandler.ProcessWithdrawal, middleware.PlatformAdminMiddleware)                      // 탈퇴 처리
+		users.DELETE("/id/:userId/workspace-tickets", authHandler.RevokeUserWorkspaceTickets, middleware.PlatformAdminMiddleware) // 워크스페이스 티켓 일괄 폐기
+		users.GET("/id/:userId/sessions", userSessionHandler.ListUserSessions, middleware.PlatformAdminMiddleware)                // 사용자 Keycloak 세션 목록
+		users.DELETE("/id/:userId/sessions/:sessionId", userSessionHandler.RevokeUserSession, middleware.PlatformAdminMiddleware) // 세션 종료 및 토큰 폐기
+		users.DELETE("/id/:userId/sessions", userSessionHandler.RevokeAllUserSessions, middleware.PlatformAdminMiddleware)        // 전체 세션 종료 및 토큰 폐기
 
 		users.POST("/menus-tree/list", menuHandler.ListUserMenuTree)
 		users.POST("/menus/list", menuHandler.ListUserMenu)
@@ -399,6 +561,38 @@ func main() {
 		invitations.PUT("/:invitationId/reject", workspaceInvitationHandler.RejectInvitationByAdmin, middleware.PlatformRoleMiddleware(middleware.Write))
 	}
 
+	// JIT 권한 상승 요청 라우트 (승인 권한은 서비스에서 워크스페이스/플랫폼 관리자 여부로 확인)
+	accessRequests := api.Group("/access-requests")
+	{
+		accessRequests.POST("", accessRequestHandler.CreateAccessRequest)
+		accessRequests.GET("", accessRequestHandler.ListAccessRequests)
+		accessRequests.GET("/id/:requestId", accessRequestHandler.GetAccessRequest)
+		accessRequests.PUT("/id/:requestId/approve", accessRequestHandler.ApproveAccessRequest)
+		accessRequests.PUT("/id/:requestId/reject", accessRequestHandler.RejectAccessRequest)
+		accessRequests.PUT("/id/:requestId/cancel", accessRequestHandler.CancelAccessRequest)
+	}
+
+	// 셀프 가입 승인 대기열 라우트
+	signups := api.Group("/signups")
+	{
+		signups.GET("", signupHandler.ListSignups, middleware.PlatformRoleMiddleware(middleware.Write))
+		signups.GET("/id/:signupId", signupHandler.GetSignup, middleware.PlatformRoleMiddleware(middleware.Write))
+		signups.PUT("/id/:signupId/approve", signupHandler.ApproveSignup, middleware.PlatformRoleMiddleware(middleware.Write))
+		signups.PUT("/id/:signupId/reject", signupHandler.RejectSignup, middleware.PlatformRoleMiddleware(middleware.Write))
+		signups.POST("/id/:signupId/verification-email", signupHandler.ResendSignupVerification, middleware.PlatformRoleMiddleware(middleware.Write))
+	}
+
+	// 사용자 오프보딩 라우트 (유예 기간 후 소유 자원 이관 및 탈퇴 처리)
+	offboardings := api.Group("/offboardings", middleware.PlatformAdminMiddleware, middleware.DefaultTenantOnlyMiddleware)
+	{
+		offboardings.POST("/preview", offboardingHandler.PreviewOffboarding)
+		offboardings.POST("", offboardingHandler.ScheduleOffboarding)
+		offboardings.GET("", offboardingHandler.ListOffboardings)
+		offboardings.GET("/id/:offboardingId", offboardingHandler.GetOffboarding)
+		offboardings.PUT("/id/:offboardingId/cancel", offboardingHandler.CancelOffboarding)
+		offboardings.POST("/id/:offboardingId/execute", offboardingHandler.ExecuteOffboarding)
+	}
+
 	// 메뉴 라우트
 	menusMng := api.Group("/menus")
 	{
@@ -479,6 +673,8 @@ func main() {
 		cspAccounts.POST("/id/:accountId/validate", cspAccountHandler.ValidateCspAccount, middleware.PlatformAdminMiddleware)
 		cspAccounts.POST("/id/:accountId/activate", cspAccountHandler.ActivateCspAccount, middleware.PlatformAdminMiddleware)
 		cspAccounts.POST("/id/:accountId/deactivate", cspAccountHandler.DeactivateCspAccount, middleware.PlatformAdminMiddleware)
+		cspAccounts.GET("/health-status", cspHealthHandler.ListCspAccountHealthStatus)
+		cspAccounts.GET("/id/:accountId/health-history", cspHealthHandler.GetCspAccountHealthHistory)
 	}
 
 	// CSP IDP 설정 관리 라우트
@@ -494,6 +690,8 @@ func main() {
 		cspIdpConfigs.POST("/id/:configId/deactivate", cspIdpConfigHandler.DeactivateCspIdpConfig, middleware.PlatformAdminMiddleware)
 		cspIdpConfigs.GET("/summary", cspIdpConfigHandler.GetCspIdpSummary)
 		cspIdpConfigs.POST("/health-check", cspIdpConfigHandler.BulkHealthCheck, middleware.PlatformAdminMiddleware)
+		cspIdpConfigs.GET("/health-status", cspHealthHandler.ListCspIdpHealthStatus)
+		cspIdpConfigs.GET("/id/:configId/health-history", cspHealthHandler.GetCspIdpHealthHistory)
 	}
 
 	// 조직 관리 라우트 (admin 이상)
@@ -576,6 +774,50 @@ func main() {
 		cspPolicies.GET("/role/:roleId", cspPolicyHandler.GetRolePolicies)
 	}
 
+	// 권한 판단(PDP) 라우트 (downstream 프레임워크용)
+	authz := api.Group("/authz")
+	{
+		authz.POST("/check", authzHandler.CheckAuthorization)
+		authz.POST("/check-batch", authzHandler.CheckAuthorizationBatch)
+		authz.GET("/permissions", authzHandler.GetMyPermissions)
+		authz.POST("/dry-run", authzHandler.DryRunAuthorization, middleware.PlatformRoleMiddleware(middleware.Write))
+	}
+
+	// 명시적 허용/거부 정책 규칙 라우트 (platformAdmin 전용)
+	policyRules := api.Group("/policy-rules", middleware.PlatformAdminMiddleware)
+	{
+		policyRules.POST("", policyRuleHandler.CreatePolicyRule)
+		policyRules.GET("", policyRuleHandler.ListPolicyRules)
+		policyRules.GET("/id/:ruleId", policyRuleHandler.GetPolicyRule)
+		policyRules.PUT("/id/:ruleId", policyRuleHandler.UpdatePolicyRule)
+		policyRules.DELETE("/id/:ruleId", policyRuleHandler.DeletePolicyRule)
+	}
+
+	// 감사 로그 조회 라우트 (platformAdmin 전용)
+	auditEvents := api.Group("/audit-events", middleware.PlatformAdminMiddleware, middleware.DefaultTenantOnlyMiddleware)
+	{
+		auditEvents.POST("/list", auditHandler.ListAuditEvents)
+	}
+
+	// 서비스 계정 라우트 (platformAdmin 전용)
+	serviceAccounts := api.Group("/service-accounts", middleware.PlatformAdminMiddleware)
+	{
+		serviceAccounts.POST("", serviceAccountHandler.CreateServiceAccount)
+		serviceAccounts.GET("", serviceAccountHandler.ListServiceAccounts)
+		serviceAccounts.GET("/id/:serviceAccountId", serviceAccountHandler.GetServiceAccount)
+		serviceAccounts.PUT("/id/:serviceAccountId", serviceAccountHandler.UpdateServiceAccount)
+		serviceAccounts.DELETE("/id/:serviceAccountId", serviceAccountHandler.DeleteServiceAccount)
+		serviceAccounts.POST("/id/:serviceAccountId/client-secret", serviceAccountHandler.RegenerateServiceAccountSecret)
+		serviceAccounts.GET("/id/:serviceAccountId/roles", serviceAccountHandler.GetServiceAccountRoles)
+		serviceAccounts.POST("/id/:serviceAccountId/platform-roles", serviceAccountHandler.AssignServiceAccountPlatformRole)
+		serviceAccounts.DELETE("/id/:serviceAccountId/platform-roles/:roleId", serviceAccountHandler.RemoveServiceAccountPlatformRole)
+		serviceAccounts.POST("/id/:serviceAccountId/workspace-roles", serviceAccountHandler.AssignServiceAccountWorkspaceRole)
+		serviceAccounts.DELETE("/id/:serviceAccountId/workspaces/:workspaceId/roles/:roleId", serviceAccountHandler.RemoveServiceAccountWorkspaceRole)
+		serviceAccounts.POST("/id/:serviceAccountId/api-keys", serviceAccountHandler.CreateServiceAccountApiKey)
+		serviceAccounts.GET("/id/:serviceAccountId/api-keys", serviceAccountHandler.ListServiceAccountApiKeys)
+		serviceAccounts.DELETE("/id/:serviceAccountId/api-keys/:keyId", serviceAccountHandler.RevokeServiceAccountApiKey)
+	}
+
 	// CSP IAM 직접 관리 라우트 (CSP IAM Role CRUD)
 	cspIAM := api.Group("/csp/iam", middleware.PlatformAdminMiddleware)
 	{
@@ -600,10 +842,34 @@ func main() {
 		}
 	}()
 
+	// 만료된 역할 할당 회수 작업 (DB + Keycloak realm role)
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	if interval := config.RoleGrantReapInterval(); interval > 0 {
+		go service.NewRoleGrantReaper(db).Run(workerCtx, interval)
+	}
+	// mc-infra-manager 네임스페이스 → 프로젝트 주기 동기화
+	if interval := config.ProjectSyncInterval(); interval > 0 {
+		go projectSyncWorker.Run(workerCtx, interval)
+	}
+	// CSP IdP 설정/계정 주기 헬스 체크 (이력 및 상태 전이 기록)
+	if interval := config.CspHealthCheckInterval(); interval > 0 {
+		go service.NewCspHealthMonitor(db).Run(workerCtx, interval)
+	}
+	// 유예 기간이 지난 사용자 오프보딩 실행
+	if interval := config.OffboardingInterval(); interval > 0 {
+		go service.NewOffboardingService(db).Run(workerCtx, interval)
+	}
+	// Keycloak ↔ DB 사용자 정합성 주기 점검 (MC_IAM_MANAGER_USER_RECONCILE_FIX이면 불일치 수정)
+	if interval := config.UserReconcileInterval(); interval > 0 {
+		go userReconciler.Run(workerCtx, interval)
+	}
+
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
+	stopWorkers()
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	if err := e.Shutdown(ctx); err != nil {
@@ -611,6 +877,42 @@ func main() {
 	}
 }
 
+// rotateEncryptionKeys 새 키를 활성화하고(로컬 키 파일) 기존 암호화 데이터를 새 키로 재암호화
+// --reencrypt-only: 키 생성 없이 현재 활성 키로 재암호화만 수행 (KMS 등 외부에서 키를 교체한 경우, 평문 데이터 마이그레이션)
+// 실행 중인 인스턴스는 기동 시 읽은 키 파일을 메모리에 유지하므로 교체 후 모든 인스턴스를 재시작해야 새 키로 암호화된 값을 읽을 수 있다.
+func rotateEncryptionKeys(db *gorm.DB, provider envelope.KeyProvider, args []string) error {
+	fs := flag.NewFlagSet("rotate-encryption-keys", flag.ContinueOnError)
+	reencryptOnly := fs.Bool("reencrypt-only", false, "re-encrypt existing rows with the current active key without generating a new key")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if provider == nil {
+		return fmt.Errorf("encryption provider is disabled")
+	}
+
+	if !*reencryptOnly {
+		rotatable, ok := provider.(envelope.RotatableKeyProvider)
+		if !ok {
+			return fmt.Errorf("provider %s does not support key generation, use --reencrypt-only", config.EncryptionProviderName())
+		}
+		previous := rotatable.ActiveKeyID()
+		keyID, err := rotatable.AddKey()
+		if err != nil {
+			return err
+		}
+		log.Printf("Activated new encryption key %s (previous: %s)", keyID, previous)
+	}
+
+	results, err := service.NewEncryptionRotationService(db).ReEncryptAll()
+	for _, r := range results {
+		log.Printf("  %s: %d rows", r.Table, r.Rows)
+	}
+	if err == nil && !*reencryptOnly {
+		log.Printf("Restart all running mc-iam-manager instances to load the new keyring")
+	}
+	return err
+}
+
 // CustomValidator 커스텀 validator 구조체
 type CustomValidator struct {
 	validator *validator.Validate
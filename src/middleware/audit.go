@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"gorm.io/gorm"
+)
+
+// 감사 로그에 저장할 요청 본문 최대 크기
+const maxAuditBodySize = 64 * 1024
+
+// auditSkipPathSuffixes 변경이 아닌(조회/토큰 발급/프록시) POST 경로. 감사 대상에서 제외
+var auditSkipPathSuffixes = []string{
+	"/list",
+	"/auth/login",
+	"/auth/logout",
+	"/auth/refresh",
+	"/auth/validate",
+	"/workspaces/workspace-ticket",
+	"/workspace-ticket/refresh",
+	"/workspace-ticket/introspect",
+	"/workspaces/temporary-credentials",
+	"/workspaces/credentials/validate",
+	"/mcmp-apis/call",
+	"/authz/check",
+	"/authz/check-batch",
+	"/csp-idp-configs/health-check",
+}
+
+// AuditMiddleware 변경(POST/PUT/PATCH/DELETE) 요청이 성공하면 감사 이벤트를 기록하는 미들웨어
+// 핸들러는 constants.AuditContext* 키로 액션명, 대상, 변경 전/후 스냅샷을 보강할 수 있다.
+func AuditMiddleware(db *gorm.DB) echo.MiddlewareFunc {
+	auditService := service.NewAuditService(db)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if !isAuditableRequest(req) {
+				return next(c)
+			}
+
+			// 핸들러가 본문을 읽기 전에 복사해 둔다
+			var body []byte
+			if req.Body != nil {
+				b, err := io.ReadAll(io.LimitReader(req.Body, maxAuditBodySize+1))
+				if err == nil {
+					rest := req.Body
+					req.Body = io.NopCloser(io.MultiReader(bytes.NewReader(b), rest))
+					if len(b) <= maxAuditBodySize {
+						body = b
+					}
+				}
+			}
+
+			err := next(c)
+
+			status := c.Response().Status
+			if err != nil {
+				if he, ok := err.(*echo.HTTPError); ok {
+					status = he.Code
+				} else {
+					status = http.StatusInternalServerError
+				}
+			}
+			if status >= http.StatusBadRequest {
+				return err
+			}
+			if skip, _ := c.Get(constants.AuditContextSkip).(bool); skip {
+				return err
+			}
+
+			event := buildAuditEvent(c, status, body)
+			if recErr := auditService.Record(event); recErr != nil {
+				log.Printf("[WARN] failed to record audit event (action=%s, requestId=%s): %v", event.Action, event.RequestID, recErr)
+			}
+			return err
+		}
+	}
+}
+
+// isAuditableRequest 감사 대상 요청인지 판단
+func isAuditableRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return false
+	}
+	path := req.URL.Path
+	for _, suffix := range auditSkipPathSuffixes {
+		if strings.HasSuffix(path, suffix) {
+			return false
+		}
+	}
+	return true
+}
+
+// buildAuditEvent 컨텍스트 정보로 감사 이벤트 구성
+func buildAuditEvent(c echo.Context, status int, body []byte) *model.AuditEvent {
+	req := c.Request()
+
+	action, _ := c.Get(constants.AuditContextAction).(string)
+	if action == "" {
+		action = fmt.Sprintf("%s %s", req.Method, c.Path())
+	}
+
+	targetType, _ := c.Get(constants.AuditContextTargetType).(string)
+	targetID, _ := c.Get(constants.AuditContextTargetID).(string)
+	if targetType == "" && targetID == "" {
+		targetType, targetID = auditTargetFromPath(c)
+	}
+
+	actor, _ := c.Get("kcUserId").(string)
+
+	requestID := req.Header.Get(echo.HeaderXRequestID)
+	if requestID == "" {
+		requestID = c.Response().Header().Get(echo.HeaderXRequestID)
+	}
+
+	after := c.Get(constants.AuditContextAfter)
+	if after == nil && len(body) > 0 {
+		after = body
+	}
+
+	return &model.AuditEvent{
+		ActorKcUserID: actor,
+		Action:        action,
+		TargetType:    targetType,
+		TargetID:      targetID,
+		Method:        req.Method,
+		Path:          req.URL.Path,
+		StatusCode:    status,
+		Before:        service.ToAuditSnapshot(c.Get(constants.AuditContextBefore)),
+		After:         service.ToAuditSnapshot(after),
+		RequestID:     requestID,
+		SourceIP:      c.RealIP(),
+	}
+}
+
+// auditTargetFromPath 라우트 경로 파라미터에서 대상 유형/ID 추정 (마지막 파라미터 기준)
+// 예: /api/users/id/:userId/deactivate → ("userId", "12")
+func auditTargetFromPath(c echo.Context) (string, string) {
+	names := c.ParamNames()
+	if len(names) == 0 {
+		return "", ""
+	}
+	name := names[len(names)-1]
+	return name, c.Param(name)
+}
@@ -2,14 +2,18 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5" // Needed for jwt.Token if used later
 	"github.com/labstack/echo/v4"
 	"github.com/m-cmp/mc-iam-manager/config"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/pkg/metrics"
 	"github.com/m-cmp/mc-iam-manager/util"
 	// "github.com/m-cmp/mc-iam-manager/model/mcmpapi" // No longer needed here
 	// gocloak import might not be needed here if types aren't directly used
@@ -26,9 +30,15 @@ const (
 // Main middleware used in routes
 func AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
+		// 0. 서비스 계정 API 키 (X-API-Key 또는 Authorization: Bearer mciam_...)
+		if apiKey := apiKeyFromRequest(c); apiKey != "" && apiKeyAuthenticator != nil {
+			return authenticateApiKey(c, next, apiKey)
+		}
+
 		// 1. Authorization 헤더에서 토큰 추출
 		authHeader := c.Request().Header.Get("Authorization")
 		if authHeader == "" {
+			metrics.IncAuthFailure(metrics.AuthFailureMissingHeader)
 			return echo.NewHTTPError(http.StatusUnauthorized, "Authorization header is required")
 		}
 
@@ -36,25 +46,35 @@ func AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 		parts := strings.Split(authHeader, " ")
 		c.Logger().Debug("authHeader: ", authHeader)
 		if len(parts) != 2 || parts[0] != "Bearer" {
+			metrics.IncAuthFailure(metrics.AuthFailureMalformedHeader)
 			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid authorization header format")
 		}
 
 		accessToken := parts[1]
 		if accessToken == "" {
+			metrics.IncAuthFailure(metrics.AuthFailureMalformedHeader)
 			return echo.NewHTTPError(http.StatusUnauthorized, "Access token is required")
 		}
 
 		c.Set("access_token", accessToken)
 
-		// 2. 토큰 검증
-		claimsInterface, err := util.ValidateToken(accessToken)
+		// 2. 토큰 검증 (멀티테넌트 모드에서는 iss의 realm으로 회사 선택)
+		kc, err := resolveTokenTenant(c, accessToken)
+		if err != nil {
+			c.Logger().Debugf("Tenant resolution failed: %v", err)
+			metrics.IncAuthFailure(metrics.AuthFailureUnknownTenant)
+			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid token")
+		}
+		claimsInterface, err := util.ValidateTokenFor(kc, accessToken)
 		if err != nil {
 			c.Logger().Debugf("Token validation failed: %v", err)
+			metrics.IncAuthFailure(tokenFailureReason(err))
 			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid token")
 		}
 
 		if claimsInterface == nil {
 			c.Logger().Debug("Claims are nil after decoding")
+			metrics.IncAuthFailure(metrics.AuthFailureInvalidClaims)
 			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to process token claims")
 		}
 
@@ -64,9 +84,19 @@ func AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 		kcUserId, err := (*claimsInterface).GetSubject()
 		if err != nil || kcUserId == "" {
 			c.Logger().Debugf("Failed to get subject (kcUserId) from claims: %v", err)
+			metrics.IncAuthFailure(metrics.AuthFailureInvalidClaims)
 			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user ID from token")
 		}
+
+		if isTokenRevoked(kcUserId, *claimsInterface) {
+			c.Logger().Debugf("Revoked token rejected for user %s", kcUserId)
+			metrics.IncAuthFailure(metrics.AuthFailureRevoked)
+			return echo.NewHTTPError(http.StatusUnauthorized, "Token has been revoked")
+		}
 		c.Set("kcUserId", kcUserId)
+		if config.MultiTenantEnabled() {
+			setTenant(c, kc)
+		}
 
 		// Store token and user ID in context
 		ctx := context.WithValue(c.Request().Context(), AccessTokenKey, accessToken)
@@ -87,7 +117,7 @@ func AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 		}
 
 		// 3.2 realm_access.roles == platform role 확인
-		realm := config.KC.Realm
+		realm := kc.Realm
 		if realm == "" {
 			realm = "mcmp-demo" // 기본값 설정
 		}
@@ -128,6 +158,100 @@ func AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	}
 }
 
+// TokenRevocationChecker 로컬 폐기 목록 조회 (jti, sid, 발급 시각 기준)
+type TokenRevocationChecker interface {
+	IsRevoked(kcUserID, tokenID, sessionID string, issuedAt *time.Time) bool
+}
+
+var tokenRevocationChecker TokenRevocationChecker
+
+// SetTokenRevocationChecker AuthMiddleware에서 사용할 폐기 목록 설정 (nil이면 확인 생략)
+func SetTokenRevocationChecker(checker TokenRevocationChecker) {
+	tokenRevocationChecker = checker
+}
+
+func isTokenRevoked(kcUserId string, claims jwt.MapClaims) bool {
+	if tokenRevocationChecker == nil {
+		return false
+	}
+	jti, _ := claims["jti"].(string)
+	sid, _ := claims["sid"].(string)
+	if sid == "" {
+		sid, _ = claims["session_state"].(string) // 이전 Keycloak 버전
+	}
+	var issuedAt *time.Time
+	if iat, err := claims.GetIssuedAt(); err == nil && iat != nil {
+		issuedAt = &iat.Time
+	}
+	return tokenRevocationChecker.IsRevoked(kcUserId, jti, sid, issuedAt)
+}
+
+// ApiKeyAuthenticator 서비스 계정 API 키 검증
+type ApiKeyAuthenticator interface {
+	AuthenticateApiKey(ctx context.Context, key, clientIP string) (*model.ServiceAccountPrincipal, error)
+}
+
+var apiKeyAuthenticator ApiKeyAuthenticator
+
+// SetApiKeyAuthenticator AuthMiddleware에서 사용할 API 키 검증기 설정 (nil이면 API 키 인증 비활성)
+func SetApiKeyAuthenticator(authenticator ApiKeyAuthenticator) {
+	apiKeyAuthenticator = authenticator
+}
+
+// apiKeyFromRequest X-API-Key 헤더 또는 mciam_ 접두어를 가진 Bearer 값
+func apiKeyFromRequest(c echo.Context) string {
+	if key := c.Request().Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+	if token, ok := strings.CutPrefix(c.Request().Header.Get("Authorization"), "Bearer "); ok && strings.HasPrefix(token, model.ServiceAccountApiKeyPrefix) {
+		return token
+	}
+	return ""
+}
+
+// authenticateApiKey API 키로 인증하고 JWT 인증과 같은 컨텍스트 값(kcUserId, platformRoles) 설정
+func authenticateApiKey(c echo.Context, next echo.HandlerFunc, apiKey string) error {
+	principal, err := apiKeyAuthenticator.AuthenticateApiKey(c.Request().Context(), apiKey, c.RealIP())
+	if err != nil {
+		c.Logger().Debugf("API key authentication failed: %v", err)
+		metrics.IncAuthFailure(metrics.AuthFailureInvalidApiKey)
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid API key")
+	}
+	c.Set("kcUserId", principal.KcUserID)
+	c.Set("platformRoles", principal.PlatformRoles)
+	c.Set("serviceAccountId", principal.ServiceAccountID)
+	// 서비스 계정은 기본 realm 소속 (X-Tenant로 다른 회사를 지정할 수 없음)
+	if config.MultiTenantEnabled() {
+		if config.KeycloakFor(c.Request().Context()) != config.KC {
+			metrics.IncAuthFailure(metrics.AuthFailureUnknownTenant)
+			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid API key")
+		}
+		setTenant(c, config.KC)
+	}
+
+	ctx := context.WithValue(c.Request().Context(), KcUserIdKey, principal.KcUserID)
+	c.SetRequest(c.Request().WithContext(ctx))
+	return next(c)
+}
+
+// tokenFailureReason 토큰 검증 오류를 인증 실패 지표 사유로 분류
+func tokenFailureReason(err error) string {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return metrics.AuthFailureTokenExpired
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid):
+		return metrics.AuthFailureInvalidSignature
+	case errors.Is(err, config.ErrUnknownSigningKey):
+		return metrics.AuthFailureUnknownKey
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return metrics.AuthFailureInvalidIssuer
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return metrics.AuthFailureInvalidAudience
+	default:
+		return metrics.AuthFailureInvalidToken
+	}
+}
+
 // McmpApiAuthMiddleware RPT 토큰을 검증하고 명시된 권한을 확인하는 미들웨어
 // requiredPermission: 이 라우트에 필요한 권한 문자열 (예: "compute#create_vm", "storage#read")
 func McmpApiAuthMiddleware(requiredPermission string) echo.MiddlewareFunc {
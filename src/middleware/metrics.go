@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/pkg/metrics"
+)
+
+// MetricsMiddleware 라우트별 요청 수와 지연 시간을 기록하는 미들웨어
+// 라벨에는 실제 경로 대신 echo 라우트 패턴(c.Path())을 사용하여 카디널리티를 제한한다.
+func MetricsMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		err := next(c)
+
+		status := c.Response().Status
+		if err != nil {
+			// 에러 응답은 이 미들웨어 이후 echo HTTPErrorHandler에서 작성된다
+			var httpErr *echo.HTTPError
+			if errors.As(err, &httpErr) {
+				status = httpErr.Code
+			} else {
+				status = http.StatusInternalServerError
+			}
+		}
+		route := c.Path()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.ObserveHTTPRequest(c.Request().Method, route, status, time.Since(start))
+		return err
+	}
+}
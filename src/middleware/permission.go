@@ -177,61 +177,6 @@ func checkPlatformPermission(ctx context.Context, userPlatformRoles []string, le
 	return hasPermission(permissions, requiredPermission), nil
 }
 
-// checkWorkspacePermission는 사용자가 필요한 워크스페이스 권한을 가지고 있는지 확인합니다.
-func checkWorkspacePermission(ctx context.Context, roles []string, level PermissionLevel, workspaceID string) (bool, error) {
-	keycloakService := service.NewKeycloakService()
-
-	// Get workspace ticket from context
-	workspaceTicket, ok := ctx.Value("workspace_ticket").(string)
-	if !ok {
-		return false, fmt.Errorf("workspace ticket not found in context")
-	}
-
-	// Validate workspace ticket and get claims
-	claims, err := keycloakService.ValidateTokenAndGetClaims(ctx, workspaceTicket)
-	if err != nil {
-		return false, fmt.Errorf("failed to validate workspace ticket: %w", err)
-	}
-
-	// Extract permissions from workspace ticket
-	authorization, ok := (*claims)["authorization"].(map[string]interface{})
-	if !ok {
-		return false, fmt.Errorf("authorization claim not found in workspace ticket")
-	}
-
-	permissions, ok := authorization["permissions"].([]interface{})
-	if !ok || len(permissions) == 0 {
-		return false, fmt.Errorf("permissions not found in workspace ticket")
-	}
-
-	// Check if the workspace ID matches and has required permission
-	for _, perm := range permissions {
-		permission, ok := perm.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		rsid, ok := permission["rsid"].(string)
-		if !ok || rsid != workspaceID {
-			continue
-		}
-
-		scopes, ok := permission["scopes"].([]interface{})
-		if !ok {
-			continue
-		}
-
-		requiredScope := fmt.Sprintf("workspace:%s", level)
-		for _, scope := range scopes {
-			if scopeStr, ok := scope.(string); ok && scopeStr == requiredScope {
-				return true, nil
-			}
-		}
-	}
-
-	return false, nil
-}
-
 // hasPermission는 사용자가 특정 권한을 가지고 있는지 확인합니다.
 func hasPermission(permissions []string, requiredPermission string) bool {
 	for _, permission := range permissions {
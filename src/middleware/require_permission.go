@@ -0,0 +1,228 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+)
+
+const (
+	// permissionCacheTTL 토큰별 유효 권한 캐시 유지 시간 (역할 변경 반영 지연 상한)
+	permissionCacheTTL = 30 * time.Second
+	// permissionCacheMaxEntries 캐시 항목 상한. 초과 시 만료 항목 정리 후에도 넘치면 비운다
+	permissionCacheMaxEntries = 10000
+)
+
+// PermissionResolver 요청자의 유효 MciamPermission 조회
+type PermissionResolver interface {
+	EffectivePermissions(kcUserID string, workspaceID uint, platformRoles []string, reqCtx *model.AuthzRequestContext) (*model.EffectivePermissions, error)
+}
+
+var (
+	permissionResolver PermissionResolver
+	permissionCache    = newEffectivePermissionCache()
+)
+
+// SetPermissionResolver RequirePermission에서 사용할 권한 조회기 설정 (nil이면 모두 거부)
+func SetPermissionResolver(resolver PermissionResolver) {
+	permissionResolver = resolver
+	permissionCache.reset()
+}
+
+// RequirePermission 요청자가 MciamPermission(<framework>:<resourceType>:<action>)을 가지고 있는지 확인하는 미들웨어
+// 워크스페이스 역할은 경로(:workspaceId, :wsId), 쿼리 또는 JSON 본문의 workspaceId 기준으로 평가하며,
+// 유효 권한은 토큰(API 키), 워크스페이스, 요청 IP 단위로 잠시 캐시한다 (시간 조건 정책 규칙도 캐시 시간만큼 늦게 반영).
+// platformAdmin도 유효 권한으로 판단하므로 deny 정책 규칙이 적용된다.
+func RequirePermission(permissionID string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			kcUserID, _ := c.Get("kcUserId").(string)
+			if kcUserID == "" {
+				return echo.NewHTTPError(http.StatusUnauthorized, "사용자 정보를 가져올 수 없습니다")
+			}
+			if permissionResolver == nil {
+				return echo.NewHTTPError(http.StatusForbidden, "권한이 부족합니다")
+			}
+
+			workspaceID, err := workspaceIDFromRequest(c)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "잘못된 workspaceId 입니다")
+			}
+			permissions, err := resolveEffectivePermissions(c, kcUserID, workspaceID)
+			if err != nil {
+				log.Printf("[ERROR] failed to resolve permissions for %s: %v", kcUserID, err)
+				return echo.NewHTTPError(http.StatusInternalServerError, "권한을 확인할 수 없습니다")
+			}
+			if !permissions.Allows(permissionID) {
+				return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("권한이 부족합니다 (%s)", permissionID))
+			}
+			return next(c)
+		}
+	}
+}
+
+// resolveEffectivePermissions 캐시를 거쳐 요청자의 유효 권한 조회
+func resolveEffectivePermissions(c echo.Context, kcUserID string, workspaceID uint) (*model.EffectivePermissions, error) {
+	key, expiresAt := permissionCacheKey(c, workspaceID)
+	if key != "" {
+		if permissions, ok := permissionCache.get(key); ok {
+			return permissions, nil
+		}
+	}
+
+	platformRoles, _ := c.Get("platformRoles").([]string)
+	effective, err := permissionResolver.EffectivePermissions(kcUserID, workspaceID, platformRoles, RequestAuthzContext(c))
+	if err != nil {
+		return nil, err
+	}
+	if key != "" {
+		permissionCache.set(key, effective, expiresAt)
+	}
+	return effective, nil
+}
+
+// permissionCacheKey 요청 자격 증명(access token 또는 API 키) 해시 + 워크스페이스 + 요청 IP. 토큰 만료 시각을 넘겨 캐시하지 않는다
+func permissionCacheKey(c echo.Context, workspaceID uint) (string, time.Time) {
+	expiresAt := time.Now().Add(permissionCacheTTL)
+	credential, _ := c.Get("access_token").(string)
+	if credential == "" {
+		credential = apiKeyFromRequest(c)
+	}
+	if credential == "" {
+		return "", expiresAt
+	}
+	if claims, ok := c.Get("token_claims").(*jwt.MapClaims); ok && claims != nil {
+		if exp, err := claims.GetExpirationTime(); err == nil && exp != nil && exp.Before(expiresAt) {
+			expiresAt = exp.Time
+		}
+	}
+	sum := sha256.Sum256([]byte(credential))
+	return hex.EncodeToString(sum[:]) + ":" + strconv.FormatUint(uint64(workspaceID), 10) + ":" + c.RealIP(), expiresAt
+}
+
+// RequestAuthzContext 조건부 정책 규칙 평가용 요청 정보 (현재 시각, 요청 IP, 토큰 amr 클레임)
+func RequestAuthzContext(c echo.Context) *model.AuthzRequestContext {
+	now := time.Now().UTC()
+	reqCtx := &model.AuthzRequestContext{Time: &now, SourceIP: c.RealIP()}
+	if claims, ok := c.Get("token_claims").(*jwt.MapClaims); ok && claims != nil {
+		if values, ok := (*claims)["amr"].([]interface{}); ok {
+			for _, value := range values {
+				if method, ok := value.(string); ok {
+					reqCtx.AMR = append(reqCtx.AMR, method)
+				}
+			}
+		}
+	}
+	return reqCtx
+}
+
+// workspaceIDFromRequest 경로 파라미터, 쿼리, JSON 본문 순으로 workspaceId 추출 (없으면 0)
+func workspaceIDFromRequest(c echo.Context) (uint, error) {
+	for _, name := range []string{"workspaceId", "wsId"} {
+		if value := c.Param(name); value != "" {
+			return parseWorkspaceID(value)
+		}
+	}
+	if value := c.QueryParam("workspaceId"); value != "" {
+		return parseWorkspaceID(value)
+	}
+	return workspaceIDFromBody(c)
+}
+
+// workspaceIDFromBody JSON 본문의 workspaceId (문자열/숫자). 핸들러가 다시 읽을 수 있도록 본문을 복원한다
+func workspaceIDFromBody(c echo.Context) (uint, error) {
+	req := c.Request()
+	if req.Body == nil || !strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		return 0, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return 0, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	var payload struct {
+		WorkspaceID json.RawMessage `json:"workspaceId"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil || len(payload.WorkspaceID) == 0 {
+		return 0, nil // 본문 형식 오류는 핸들러에서 처리
+	}
+	value := strings.Trim(string(payload.WorkspaceID), `"`)
+	if value == "" || value == "null" {
+		return 0, nil
+	}
+	return parseWorkspaceID(value)
+}
+
+func parseWorkspaceID(value string) (uint, error) {
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+
+// effectivePermissionCache 토큰별 유효 권한 캐시
+type effectivePermissionCache struct {
+	mu      sync.Mutex
+	entries map[string]effectivePermissionEntry
+}
+
+type effectivePermissionEntry struct {
+	permissions *model.EffectivePermissions
+	expiresAt   time.Time
+}
+
+func newEffectivePermissionCache() *effectivePermissionCache {
+	return &effectivePermissionCache{entries: make(map[string]effectivePermissionEntry)}
+}
+
+func (c *effectivePermissionCache) get(key string) (*model.EffectivePermissions, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !time.Now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.permissions, true
+}
+
+func (c *effectivePermissionCache) set(key string, permissions *model.EffectivePermissions, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= permissionCacheMaxEntries {
+		now := time.Now()
+		for k, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= permissionCacheMaxEntries {
+			c.entries = make(map[string]effectivePermissionEntry)
+		}
+	}
+	c.entries[key] = effectivePermissionEntry{permissions: permissions, expiresAt: expiresAt}
+}
+
+func (c *effectivePermissionCache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]effectivePermissionEntry)
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const requirePermissionTestPermission = "mc-iam-manager:audit:read"
+
+func newTestPermissionResolverDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&model.User{},
+		&model.RoleMaster{},
+		&model.RoleSub{},
+		&model.Workspace{},
+		&model.UserPlatformRole{},
+		&model.UserWorkspaceRole{},
+		&model.Organization{},
+		&model.UserOrganization{},
+		&model.GroupPlatformRole{},
+		&model.GroupWorkspaceRole{},
+		&model.MciamRoleMciamPermission{},
+		&model.PolicyRule{},
+	))
+	SetPermissionResolver(service.NewAuthzService(db))
+	t.Cleanup(func() { SetPermissionResolver(nil) })
+	return db
+}
+
+func callRequirePermission(kcUserID string, platformRoles []string, permissionID string) int {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/audit-events", nil), rec)
+	c.Set("kcUserId", kcUserID)
+	c.Set("platformRoles", platformRoles)
+	err := RequirePermission(permissionID)(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})(c)
+	if httpErr, ok := err.(*echo.HTTPError); ok {
+		return httpErr.Code
+	}
+	return rec.Code
+}
+
+// platformAdmin도 deny 정책 규칙이 적용된 권한은 거부
+func TestRequirePermission_DenyRuleAppliesToPlatformAdmin(t *testing.T) {
+	db := newTestPermissionResolverDB(t)
+	user := &model.User{Username: "admin", KcId: "kc-admin"}
+	require.NoError(t, db.Create(user).Error)
+	adminRole := &model.RoleMaster{Name: "platformAdmin"}
+	require.NoError(t, db.Create(adminRole).Error)
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: user.ID, RoleID: adminRole.ID}).Error)
+
+	roles := []string{"platformAdmin"}
+	assert.Equal(t, http.StatusOK, callRequirePermission(user.KcId, roles, requirePermissionTestPermission))
+
+	require.NoError(t, db.Create(&model.PolicyRule{
+		Name: "admin-no-audit", Effect: model.PolicyRuleEffectDeny, SubjectType: model.PolicyRuleSubjectRole, SubjectID: adminRole.ID,
+		PermissionID: "mc-iam-manager:audit:*", Enabled: true,
+	}).Error)
+	assert.Equal(t, http.StatusForbidden, callRequirePermission(user.KcId, roles, requirePermissionTestPermission))
+	assert.Equal(t, http.StatusOK, callRequirePermission(user.KcId, roles, "mc-iam-manager:user:read"), "규칙 대상이 아닌 권한은 그대로 허용")
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/config"
+	"github.com/m-cmp/mc-iam-manager/model"
+)
+
+// ScimAuthMiddleware SCIM 전용 Bearer 토큰 인증 (Keycloak 토큰, API 키와 별개)
+// 토큰이 설정되지 않으면 모든 요청을 거부한다. 멀티테넌트 모드에서는 기본 realm만 프로비저닝한다.
+func ScimAuthMiddleware(token string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			given, ok := strings.CutPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+			if token == "" || !ok || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(given)), []byte(token)) != 1 {
+				return c.JSON(http.StatusUnauthorized, model.NewScimError(http.StatusUnauthorized, "", "Invalid SCIM token"))
+			}
+			if config.MultiTenantEnabled() {
+				if config.KeycloakFor(c.Request().Context()) != config.KC {
+					return c.JSON(http.StatusUnauthorized, model.NewScimError(http.StatusUnauthorized, "", "Invalid SCIM token"))
+				}
+				setTenant(c, config.KC)
+			}
+			c.Set("kcUserId", model.ScimActor)
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/m-cmp/mc-iam-manager/config"
+	"github.com/m-cmp/mc-iam-manager/model"
+)
+
+// TenantMiddleware 멀티테넌트 모드에서 X-Tenant 헤더(realm 이름)로 회사를 선택한다.
+// 토큰이 없는 요청(로그인, 토큰 갱신 등)에 사용하며, 토큰이 있으면 AuthMiddleware가 iss의 realm과 일치하는지 다시 확인한다.
+func TenantMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !config.MultiTenantEnabled() {
+			return next(c)
+		}
+		realm := c.Request().Header.Get(config.TenantHeader)
+		if realm == "" {
+			return next(c)
+		}
+		kc := config.KeycloakForRealm(realm)
+		if kc == nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("unknown tenant: %s", realm)})
+		}
+		setTenant(c, kc)
+		return next(c)
+	}
+}
+
+// DefaultTenantOnlyMiddleware 멀티테넌트 모드에서 기본 realm(MC_IAM_MANAGER_KEYCLOAK_REALM) 사용자만 허용 (회사 목록/생성 등 운영자 기능)
+func DefaultTenantOnlyMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if config.MultiTenantEnabled() && config.KeycloakFor(c.Request().Context()) != config.KC {
+			return c.JSON(http.StatusForbidden, map[string]string{"error": "Only available in the default tenant"})
+		}
+		return next(c)
+	}
+}
+
+// setTenant 회사 Keycloak 설정을 요청 컨텍스트에, 회사 ID와 realm을 echo 컨텍스트(companyId, tenantRealm)에 저장
+func setTenant(c echo.Context, kc *config.KeycloakConfig) {
+	c.Set("companyId", kc.CompanyID)
+	c.Set("tenantRealm", kc.Realm)
+	c.SetRequest(c.Request().WithContext(config.WithKeycloak(c.Request().Context(), kc)))
+}
+
+// resolveTokenTenant 서명 검증 전에 토큰 iss의 realm으로 회사 Keycloak 설정 선택
+// 서명, iss, aud는 선택한 realm 설정으로 검증하므로 여기서는 realm 조회와 X-Tenant 일치 여부만 확인한다.
+func resolveTokenTenant(c echo.Context, accessToken string) (*config.KeycloakConfig, error) {
+	if !config.MultiTenantEnabled() {
+		return config.KC, nil
+	}
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(accessToken, claims); err != nil {
+		return nil, err
+	}
+	iss, _ := claims.GetIssuer()
+	kc := config.KeycloakForRealm(config.RealmFromIssuer(iss))
+	if kc == nil {
+		return nil, fmt.Errorf("unknown tenant issuer %q", iss)
+	}
+	if realm := c.Request().Header.Get(config.TenantHeader); realm != "" && realm != kc.Realm {
+		return nil, fmt.Errorf("tenant header %q does not match token realm %q", realm, kc.Realm)
+	}
+	return kc, nil
+}
+
+// TenantResourceResolver 워크스페이스/역할/CSP 계정의 소유 회사 조회 (found=false 이면 리소스 없음)
+type TenantResourceResolver interface {
+	ResourceCompany(kind string, id uint) (companyID *uint, found bool, err error)
+}
+
+var tenantResourceResolver TenantResourceResolver
+
+// SetTenantResourceResolver TenantScopeMiddleware에서 사용할 소유 회사 조회기 설정
+func SetTenantResourceResolver(resolver TenantResourceResolver) {
+	tenantResourceResolver = resolver
+}
+
+// TenantScopeMiddleware 멀티테넌트 모드에서 경로의 워크스페이스(:workspaceId, :wsId, /workspaces/id/:id), 역할(:roleId), CSP 계정(:accountId), 가입 신청(:signupId)이
+// 요청자의 회사 소유인지 확인한다. 다른 회사의 리소스는 존재를 드러내지 않도록 404를 반환하며, 공용 역할(company_id 없음)은 허용한다.
+func TenantScopeMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !config.MultiTenantEnabled() || tenantResourceResolver == nil {
+			return next(c)
+		}
+		companyID, ok := c.Get("companyId").(uint)
+		if !ok || companyID == 0 {
+			return next(c)
+		}
+
+		for _, ref := range tenantResourceRefs(c) {
+			id, err := strconv.ParseUint(ref.value, 10, 32)
+			if err != nil {
+				// 형식 오류는 각 핸들러의 검증에 맡긴다
+				continue
+			}
+			allowed, err := tenantResourceAllowed(companyID, ref.kind, uint(id))
+			if err != nil {
+				return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to check tenant ownership"})
+			}
+			if !allowed {
+				return c.JSON(http.StatusNotFound, map[string]string{"error": fmt.Sprintf("%s not found", ref.kind)})
+			}
+		}
+		return next(c)
+	}
+}
+
+// TenantResourceAllowed 요청 본문 등 경로 밖에서 받은 리소스가 요청자 회사 소유인지 TenantScopeMiddleware와 같은 규칙으로 확인
+// 단일 테넌트 모드, 회사 정보가 없는 요청, 존재하지 않는 리소스는 허용한다 (존재 여부는 각 핸들러가 확인).
+func TenantResourceAllowed(c echo.Context, kind string, id uint) (bool, error) {
+	if !config.MultiTenantEnabled() || tenantResourceResolver == nil {
+		return true, nil
+	}
+	companyID, ok := c.Get("companyId").(uint)
+	if !ok || companyID == 0 {
+		return true, nil
+	}
+	return tenantResourceAllowed(companyID, kind, id)
+}
+
+// tenantResourceAllowed 리소스 소유 회사가 companyID인지 확인. 공용 역할(company_id 없음)은 허용
+func tenantResourceAllowed(companyID uint, kind string, id uint) (bool, error) {
+	owner, found, err := tenantResourceResolver.ResourceCompany(kind, id)
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		return true, nil
+	}
+	if owner == nil {
+		return kind == model.TenantResourceRole, nil
+	}
+	return *owner == companyID, nil
+}
+
+type tenantResourceRef struct {
+	kind  string
+	value string
+}
+
+// tenantResourceRefs 경로 파라미터 중 회사 소유권을 확인할 리소스 목록
+// CSP 역할(/roles/csp, /csp-policies)의 :roleId는 역할 마스터가 아니므로 제외한다.
+func tenantResourceRefs(c echo.Context) []tenantResourceRef {
+	path := c.Path()
+	var refs []tenantResourceRef
+	for _, name := range []string{"workspaceId", "wsId"} {
+		if v := c.Param(name); v != "" {
+			refs = append(refs, tenantResourceRef{kind: model.TenantResourceWorkspace, value: v})
+		}
+	}
+	if v := c.Param("id"); v != "" && strings.Contains(path, "/workspaces/id/:id") {
+		refs = append(refs, tenantResourceRef{kind: model.TenantResourceWorkspace, value: v})
+	}
+	if v := c.Param("roleId"); v != "" && !strings.Contains(path, "/csp") {
+		refs = append(refs, tenantResourceRef{kind: model.TenantResourceRole, value: v})
+	}
+	if v := c.Param("accountId"); v != "" && strings.Contains(path, "/csp-accounts") {
+		refs = append(refs, tenantResourceRef{kind: model.TenantResourceCspAccount, value: v})
+	}
+	if v := c.Param("signupId"); v != "" {
+		refs = append(refs, tenantResourceRef{kind: model.TenantResourceSignup, value: v})
+	}
+	return refs
+}
@@ -0,0 +1,84 @@
+package model
+
+import "time"
+
+// AccessRequestType 권한 상승 요청 유형
+type AccessRequestType string
+
+const (
+	AccessRequestTypeWorkspaceRole AccessRequestType = "WORKSPACE_ROLE" // 상위 워크스페이스 역할
+	AccessRequestTypeCspRole       AccessRequestType = "CSP_ROLE"       // 특정 CSP 역할 매핑
+)
+
+// AccessRequestStatus 권한 상승 요청 상태
+type AccessRequestStatus string
+
+const (
+	AccessRequestStatusPendingApproval AccessRequestStatus = "PENDING_APPROVAL"
+	AccessRequestStatusApproved        AccessRequestStatus = "APPROVED"
+	AccessRequestStatusRejected        AccessRequestStatus = "REJECTED"
+	AccessRequestStatusCancelled       AccessRequestStatus = "CANCELLED"
+)
+
+// AccessRequest JIT 권한 상승 요청 모델 (DB 테이블: mcmp_access_requests)
+// 승인 시 기간 제한 워크스페이스 역할이 부여되고, 만료되면 RoleGrantReaper가 회수한다.
+type AccessRequest struct {
+	ID              uint                 `json:"id" gorm:"primaryKey;column:id"`
+	RequesterUserID uint                 `json:"requesterUserId" gorm:"column:requester_user_id;not null;index"`
+	Type            AccessRequestType    `json:"type" gorm:"column:type;not null"`
+	WorkspaceID     uint                 `json:"workspaceId" gorm:"column:workspace_id;not null;index"`
+	RoleID          uint                 `json:"roleId" gorm:"column:role_id;not null"`
+	CspRoleID       *uint                `json:"cspRoleId,omitempty" gorm:"column:csp_role_id"`
+	DurationHours   int                  `json:"durationHours" gorm:"column:duration_hours;not null"`
+	Justification   string               `json:"justification" gorm:"column:justification;type:text;not null"`
+	Status          AccessRequestStatus  `json:"status" gorm:"column:status;not null;default:'PENDING_APPROVAL';index"`
+	ApproverUserID  *uint                `json:"approverUserId,omitempty" gorm:"column:approver_user_id"`
+	DecisionComment string               `json:"decisionComment,omitempty" gorm:"column:decision_comment;type:text"`
+	DecidedAt       *time.Time           `json:"decidedAt,omitempty" gorm:"column:decided_at"`
+	GrantExpiresAt  *time.Time           `json:"grantExpiresAt,omitempty" gorm:"column:grant_expires_at"` // 승인으로 부여된 역할의 만료 시각
+	CreatedAt       time.Time            `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt       time.Time            `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+	History         []AccessRequestEvent `json:"history,omitempty" gorm:"foreignKey:AccessRequestID"`
+}
+
+// TableName AccessRequest의 테이블 이름 지정
+func (AccessRequest) TableName() string {
+	return "mcmp_access_requests"
+}
+
+// AccessRequestEvent 권한 상승 요청 상태 변경 이력 (DB 테이블: mcmp_access_request_events)
+type AccessRequestEvent struct {
+	ID              uint                `json:"id" gorm:"primaryKey;column:id"`
+	AccessRequestID uint                `json:"accessRequestId" gorm:"column:access_request_id;not null;index"`
+	Status          AccessRequestStatus `json:"status" gorm:"column:status;not null"`
+	ActorUserID     uint                `json:"actorUserId" gorm:"column:actor_user_id;not null"`
+	Comment         string              `json:"comment,omitempty" gorm:"column:comment;type:text"`
+	CreatedAt       time.Time           `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName AccessRequestEvent의 테이블 이름 지정
+func (AccessRequestEvent) TableName() string {
+	return "mcmp_access_request_events"
+}
+
+// CreateAccessRequestRequest 권한 상승 요청 생성
+// WORKSPACE_ROLE은 roleId 필수, CSP_ROLE은 cspRoleId 필수 (roleId 생략 시 매핑에서 결정, 해당 CSP 역할만 부여하는 역할이어야 함)
+type CreateAccessRequestRequest struct {
+	Type          AccessRequestType `json:"type" validate:"required"`
+	WorkspaceID   uint              `json:"workspaceId" validate:"required"`
+	RoleID        uint              `json:"roleId,omitempty"`
+	CspRoleID     *uint             `json:"cspRoleId,omitempty"`
+	DurationHours int               `json:"durationHours" validate:"required"`
+	Justification string            `json:"justification" validate:"required"`
+}
+
+// AccessRequestDecisionRequest 권한 상승 요청 승인/거절/취소 사유
+type AccessRequestDecisionRequest struct {
+	Comment string `json:"comment,omitempty"`
+}
+
+// AccessRequestFilterRequest 권한 상승 요청 목록 필터
+type AccessRequestFilterRequest struct {
+	Status      string `query:"status"`
+	WorkspaceID uint   `query:"workspaceId"`
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// AuditEvent IAM 변경 감사 이벤트 (DB 테이블: mcmp_audit_events)
+// append-only: 생성 이후 수정/삭제하지 않는다.
+type AuditEvent struct {
+	ID            uint           `json:"id" gorm:"primaryKey;column:id"`
+	ActorKcUserID string         `json:"actorKcUserId" gorm:"column:actor_kc_user_id;size:255;index"`
+	Action        string         `json:"action" gorm:"column:action;size:255;not null;index"`
+	TargetType    string         `json:"targetType" gorm:"column:target_type;size:100;index:idx_audit_target"`
+	TargetID      string         `json:"targetId" gorm:"column:target_id;size:255;index:idx_audit_target"`
+	Method        string         `json:"method" gorm:"column:method;size:10"`
+	Path          string         `json:"path" gorm:"column:path;size:1000"`
+	StatusCode    int            `json:"statusCode" gorm:"column:status_code"`
+	Before        datatypes.JSON `json:"before,omitempty" gorm:"column:before;type:jsonb" swaggertype:"object"`
+	After         datatypes.JSON `json:"after,omitempty" gorm:"column:after;type:jsonb" swaggertype:"object"`
+	RequestID     string         `json:"requestId" gorm:"column:request_id;size:100;index"`
+	SourceIP      string         `json:"sourceIp" gorm:"column:source_ip;size:100"`
+	CreatedAt     time.Time      `json:"createdAt" gorm:"column:created_at;autoCreateTime;index"`
+}
+
+// TableName AuditEvent의 테이블 이름 지정
+func (AuditEvent) TableName() string {
+	return "mcmp_audit_events"
+}
+
+// AuditEventFilterRequest 감사 이벤트 목록 조회 요청
+type AuditEventFilterRequest struct {
+	ActorKcUserID string     `json:"actorKcUserId,omitempty"`
+	Action        string     `json:"action,omitempty"` // 접두사 일치 (예: "role.assign")
+	TargetType    string     `json:"targetType,omitempty"`
+	TargetID      string     `json:"targetId,omitempty"`
+	RequestID     string     `json:"requestId,omitempty"`
+	StartTime     *time.Time `json:"startTime,omitempty"`
+	EndTime       *time.Time `json:"endTime,omitempty"`
+	Page          int        `json:"page,omitempty"`
+	Size          int        `json:"size,omitempty"`
+}
+
+// AuditEventListResponse 감사 이벤트 목록 응답
+type AuditEventListResponse struct {
+	Total  int64        `json:"total"`
+	Page   int          `json:"page"`
+	Size   int          `json:"size"`
+	Events []AuditEvent `json:"events"`
+}
@@ -0,0 +1,115 @@
+package model
+
+// 권한 판단(PDP) 결과 값
+const (
+	AuthzDecisionAllow = "allow"
+	AuthzDecisionDeny  = "deny"
+)
+
+// 권한 부여 경로 유형
+const (
+	AuthzSourcePlatformAdmin      = "platform_admin"
+	AuthzSourcePlatformRole       = "platform_role"
+	AuthzSourceGroupPlatformRole  = "group_platform_role"
+	AuthzSourceWorkspaceRole      = "workspace_role"
+	AuthzSourceGroupWorkspaceRole = "group_workspace_role"
+	AuthzSourcePolicyRule         = "policy_rule"
+)
+
+// AuthzCheckRequest 권한 판단 요청
+// permissionId(MciamPermission ID) 또는 serviceName+actionName(McmpApiAction) 중 하나를 지정한다.
+type AuthzCheckRequest struct {
+	KcUserID     string `json:"kcUserId,omitempty"` // 미지정 시 요청자 본인
+	PermissionID string `json:"permissionId,omitempty"`
+	ServiceName  string `json:"serviceName,omitempty"`
+	ActionName   string `json:"actionName,omitempty"`
+	WorkspaceID  string `json:"workspaceId,omitempty"`
+	ProjectID    string `json:"projectId,omitempty"`
+	// Context 조건부 정책 규칙 평가용 요청 정보 (관리자가 최종 사용자 요청을 대신 판단하는 경우 전달, 본인 판단 시 무시)
+	Context *AuthzRequestContext `json:"context,omitempty"`
+	// PlatformRoles 요청 토큰(또는 API 키 scope)의 플랫폼 역할. nil이 아니면 DB 플랫폼 역할 중 이 역할만 평가한다 (서버에서만 설정)
+	PlatformRoles []string `json:"-"`
+}
+
+// AuthzCheckBatchRequest 권한 판단 일괄 요청
+type AuthzCheckBatchRequest struct {
+	KcUserID string              `json:"kcUserId,omitempty"` // 각 항목에 kcUserId가 없으면 이 값을 사용
+	Checks   []AuthzCheckRequest `json:"checks" validate:"required"`
+}
+
+// AuthzGrantPath 권한을 부여한 경로 (설명 가능성)
+type AuthzGrantPath struct {
+	Source       string `json:"source"`
+	RuleID       uint   `json:"ruleId,omitempty"` // policy_rule 경로의 규칙 ID
+	RoleID       uint   `json:"roleId"`
+	RoleName     string `json:"roleName"`
+	GroupID      uint   `json:"groupId,omitempty"`
+	WorkspaceID  uint   `json:"workspaceId,omitempty"`
+	PermissionID string `json:"permissionId,omitempty"`
+	// InheritedFromRoleID 권한이 상위 역할(parent_id)에서 상속된 경우 실제 매핑을 가진 역할 ID
+	InheritedFromRoleID uint `json:"inheritedFromRoleId,omitempty"`
+}
+
+// AuthzCheckResponse 권한 판단 결과
+type AuthzCheckResponse struct {
+	Allowed             bool              `json:"allowed"`
+	Decision            string            `json:"decision"`
+	Reason              string            `json:"reason"`
+	Request             AuthzCheckRequest `json:"request"`
+	RequiredPermissions []string          `json:"requiredPermissions,omitempty"`
+	GrantedBy           []AuthzGrantPath  `json:"grantedBy,omitempty"`
+	MatchedRules        []AuthzRuleMatch  `json:"matchedRules,omitempty"` // 일치한 정책 규칙 (deny 규칙이 있으면 거부)
+}
+
+// AuthzCheckBatchResponse 권한 판단 일괄 결과
+type AuthzCheckBatchResponse struct {
+	Results []AuthzCheckResponse `json:"results"`
+}
+
+// McmpApiCallDeniedResponse McmpApiCall 권한 거부(403) 응답
+type McmpApiCallDeniedResponse struct {
+	Error               string   `json:"error"`
+	Reason              string   `json:"reason"`
+	ServiceName         string   `json:"serviceName"`
+	ActionName          string   `json:"actionName"`
+	WorkspaceID         string   `json:"workspaceId,omitempty"`
+	ProjectID           string   `json:"projectId,omitempty"`
+	RequiredPermissions []string `json:"requiredPermissions,omitempty"`
+}
+
+// EffectivePermissions 요청자의 유효 MciamPermission 목록 (플랫폼 역할 + 워크스페이스 역할)
+// platformAdmin은 DeniedPermissions(적용 중인 deny 규칙 패턴)에 걸리지 않는 모든 권한을 가진다.
+type EffectivePermissions struct {
+	KcUserID          string   `json:"kcUserId"`
+	WorkspaceID       uint     `json:"workspaceId,omitempty"`
+	PlatformAdmin     bool     `json:"platformAdmin"`
+	Permissions       []string `json:"permissions"`
+	DeniedPermissions []string `json:"deniedPermissions,omitempty"`
+}
+
+// Allows permissionID 보유 여부
+func (e *EffectivePermissions) Allows(permissionID string) bool {
+	for _, id := range e.Permissions {
+		if id == permissionID {
+			return true
+		}
+	}
+	if !e.PlatformAdmin {
+		return false
+	}
+	for _, pattern := range e.DeniedPermissions {
+		if PermissionPatternMatches(pattern, permissionID) {
+			return false
+		}
+	}
+	return true
+}
+
+// AuthzRoleGrant 사용자에게 부여된 역할 (직접 할당 또는 그룹 상속)
+type AuthzRoleGrant struct {
+	RoleID      uint   `json:"roleId" gorm:"column:role_id"`
+	RoleName    string `json:"roleName" gorm:"column:role_name"`
+	GroupID     uint   `json:"groupId" gorm:"column:group_id"`
+	WorkspaceID uint   `json:"workspaceId" gorm:"column:workspace_id"`
+	Source      string `json:"source" gorm:"column:source"`
+}
@@ -4,7 +4,15 @@ import (
 	"time"
 )
 
-// Company 회사 정보 모델 (싱글톤 — 플랫폼당 1개)
+// 멀티테넌트 모드에서 회사 소유권을 검사하는 리소스 종류
+const (
+	TenantResourceWorkspace  = "workspace"
+	TenantResourceRole       = "role"
+	TenantResourceCspAccount = "csp_account"
+	TenantResourceSignup     = "signup"
+)
+
+// Company 회사 정보 모델 (단일 테넌트 모드에서는 플랫폼당 1개, 멀티테넌트 모드에서는 회사마다 별도 realm)
 // table: mcmp_companies
 type Company struct {
 	ID             uint      `gorm:"primaryKey" json:"id"`
@@ -12,7 +20,7 @@ type Company struct {
 	Description    string    `gorm:"size:500" json:"description"`
 	RealmName      string    `gorm:"size:255;uniqueIndex" json:"realm_name"`
 	KcClientID     string    `gorm:"size:255" json:"kc_client_id"`
-	KcClientSecret string    `gorm:"size:255" json:"-"` // 응답에서 제외
+	KcClientSecret string    `gorm:"type:text;serializer:encrypted" json:"-"` // 응답에서 제외, 저장 시 암호화
 	Status         string    `gorm:"size:20;default:'active'" json:"status"`
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
@@ -13,6 +13,7 @@ type CspAccount struct {
 	AccountInfo map[string]string `gorm:"type:jsonb;serializer:json" json:"account_info"`
 	IsActive    bool              `gorm:"default:true" json:"is_active"`
 	Description string            `gorm:"size:500" json:"description"`
+	CompanyID   *uint             `gorm:"index" json:"company_id,omitempty"` // 멀티테넌트 모드의 소유 회사
 	CreatedAt   time.Time         `json:"created_at"`
 	UpdatedAt   time.Time         `json:"updated_at"`
 }
@@ -103,9 +104,10 @@ func (c *CspAccount) GetTencentAppID() string {
 
 // CspAccountFilter CSP 계정 조회 필터
 type CspAccountFilter struct {
-	CspType  string `json:"csp_type,omitempty"`
-	IsActive *bool  `json:"is_active,omitempty"`
-	Name     string `json:"name,omitempty"`
+	CspType   string `json:"csp_type,omitempty"`
+	IsActive  *bool  `json:"is_active,omitempty"`
+	Name      string `json:"name,omitempty"`
+	CompanyID *uint  `json:"-"` // 멀티테넌트 모드: 요청 회사의 계정만
 }
 
 // CreateCspAccountRequest CSP 계정 생성 요청
@@ -114,6 +116,7 @@ type CreateCspAccountRequest struct {
 	CspType     string            `json:"csp_type" binding:"required,oneof=aws gcp azure alibaba tencent ibm ncp nhn kt openstack"`
 	AccountInfo map[string]string `json:"account_info"`
 	Description string            `json:"description"`
+	CompanyID   *uint             `json:"-"` // 멀티테넌트 모드: 요청 회사
 }
 
 // UpdateCspAccountRequest CSP 계정 수정 요청
@@ -4,40 +4,45 @@ import "time"
 
 // CspCredentialRequest CSP 임시 자격 증명 발급 요청 모델
 type CspCredentialRequest struct {
-	WorkspaceID string `json:"workspaceId"`         // 대상 워크스페이스 ID
-	CspType     string `json:"cspType"`             // 대상 CSP 타입
-	Region      string `json:"region"`              // AWS 리전 (선택적)
-	AuthMethod  string `json:"authMethod,omitempty"` // 인증방식 (OIDC/SAML/SECRET_KEY), 미지정 시 매핑에서 결정
+	WorkspaceID  string `json:"workspaceId"`            // 대상 워크스페이스 ID
+	CspType      string `json:"cspType"`                // 대상 CSP 타입
+	Region       string `json:"region"`                 // AWS 리전 (선택적)
+	AuthMethod   string `json:"authMethod,omitempty"`   // 인증방식 (OIDC/SAML/SECRET_KEY), 미지정 시 매핑에서 결정
+	ForceRefresh bool   `json:"forceRefresh,omitempty"` // true면 캐시를 무시하고 새로 발급
 }
 
 // CspCredentialResponse CSP 임시 자격 증명 발급 응답 모델
 type CspCredentialResponse struct {
-	CspType         string    `json:"cspType"`                    // e.g., "aws", "gcp"
-	AccessKeyId     string    `json:"accessKeyId,omitempty"`      // AWS Access Key ID
-	SecretAccessKey string    `json:"secretAccessKey,omitempty"`  // AWS Secret Access Key
-	SessionToken    string    `json:"sessionToken,omitempty"`     // AWS Session Token
-	AccessToken     string    `json:"accessToken,omitempty"`      // GCP OAuth2 Access Token
-	TokenType       string    `json:"tokenType,omitempty"`        // GCP Token Type (Bearer)
-	AccessKeySecret string    `json:"accessKeySecret,omitempty"`  // Alibaba STS Access Key Secret
-	SecurityToken   string    `json:"securityToken,omitempty"`    // Alibaba STS Security Token
-	Expiration      time.Time `json:"expiration"`                 // Expiration time
-	Region          string    `json:"region,omitempty"`           // Optional: AWS/Alibaba Region
+	CspType         string    `json:"cspType"`                   // e.g., "aws", "gcp"
+	AccessKeyId     string    `json:"accessKeyId,omitempty"`     // AWS Access Key ID
+	SecretAccessKey string    `json:"secretAccessKey,omitempty"` // AWS Secret Access Key
+	SessionToken    string    `json:"sessionToken,omitempty"`    // AWS Session Token
+	AccessToken     string    `json:"accessToken,omitempty"`     // GCP OAuth2 Access Token
+	TokenType       string    `json:"tokenType,omitempty"`       // GCP Token Type (Bearer)
+	AccessKeySecret string    `json:"accessKeySecret,omitempty"` // Alibaba STS Access Key Secret
+	SecurityToken   string    `json:"securityToken,omitempty"`   // Alibaba STS Security Token
+	Expiration      time.Time `json:"expiration"`                // Expiration time
+	Region          string    `json:"region,omitempty"`          // Optional: AWS/Alibaba Region
+	Cached          bool      `json:"cached,omitempty"`          // 캐시된 자격 증명 반환 여부
 }
 
 // TempCredential 임시 자격 증명 관리 테이블 모델
 type TempCredential struct {
 	ID              uint      `json:"id" gorm:"primaryKey"`
-	Provider        string    `json:"provider" gorm:"not null"`        // aws, gcp, azure, nhn
-	AuthType        string    `json:"authType" gorm:"not null"`        // oidc, saml, secret_key
-	AccessKeyId     string    `json:"accessKeyId" gorm:"not null"`     // Access Key ID
-	SecretAccessKey string    `json:"secretAccessKey" gorm:"not null"` // Secret Access Key
-	SessionToken    string    `json:"sessionToken"`                    // Session Token (OIDC/SAML용)
-	Region          string    `json:"region" gorm:"not null"`          // 리전
-	IssuedAt        time.Time `json:"issuedAt" gorm:"not null"`        // 발급 시간
-	ExpiresAt       time.Time `json:"expiresAt" gorm:"not null"`       // 만료 시간
-	IsActive        bool      `json:"isActive" gorm:"default:true"`    // 활성 상태
-	IssuedBy        string    `json:"issuedBy" gorm:"not null"`        // 발급 요청자 (Keycloak User ID)
-	RoleMasterID    *uint     `json:"roleMasterId"`                    // RoleMaster ID (선택적)
+	Provider        string    `json:"provider" gorm:"not null"`                                       // aws, gcp, azure, nhn
+	AuthType        string    `json:"authType" gorm:"not null"`                                       // oidc, saml, secret_key
+	AccessKeyId     string    `json:"accessKeyId" gorm:"not null"`                                    // Access Key ID
+	SecretAccessKey string    `json:"secretAccessKey" gorm:"type:text;not null;serializer:encrypted"` // Secret Access Key (저장 시 암호화)
+	SessionToken    string    `json:"sessionToken" gorm:"type:text;serializer:encrypted"`             // Session Token (OIDC/SAML용, 저장 시 암호화)
+	Region          string    `json:"region" gorm:"not null"`                                         // 리전
+	IssuedAt        time.Time `json:"issuedAt" gorm:"not null"`                                       // 발급 시간
+	ExpiresAt       time.Time `json:"expiresAt" gorm:"not null"`                                      // 만료 시간
+	IsActive        bool      `json:"isActive" gorm:"default:true"`                                   // 활성 상태
+	IssuedBy        string    `json:"issuedBy" gorm:"not null"`                                       // 발급 요청자 (Keycloak User ID)
+	RoleMasterID    *uint     `json:"roleMasterId"`                                                   // RoleMaster ID (선택적)
+	WorkspaceID     *uint     `json:"workspaceId,omitempty"`                                          // 발급 요청 워크스페이스 (발급 캐시 키)
+	RoleArn         string    `json:"roleArn,omitempty" gorm:"size:512"`                              // 발급에 사용한 CSP 역할 (발급 캐시 키)
+	Payload         string    `json:"-" gorm:"type:text;serializer:encrypted"`                        // CspCredentialResponse JSON (발급 캐시용, 저장 시 암호화)
 	CreatedAt       time.Time `json:"createdAt"`
 	UpdatedAt       time.Time `json:"updatedAt"`
 }
@@ -47,6 +52,18 @@ func (TempCredential) TableName() string {
 	return "mcmp_temp_credentials"
 }
 
+// TempCredentialRevokeRequest 캐시된 임시 자격 증명 폐기 요청 (userId/kcUserId, roleId 중 하나 이상)
+type TempCredentialRevokeRequest struct {
+	UserID   string `json:"userId,omitempty"`
+	KcUserID string `json:"kcUserId,omitempty"`
+	RoleID   string `json:"roleId,omitempty"`
+}
+
+// TempCredentialRevokeResponse 캐시된 임시 자격 증명 폐기 결과
+type TempCredentialRevokeResponse struct {
+	Revoked int64 `json:"revoked"`
+}
+
 // IsExpired 만료 여부 확인
 func (tc *TempCredential) IsExpired() bool {
 	return time.Now().After(tc.ExpiresAt)
@@ -0,0 +1,72 @@
+package model
+
+import "time"
+
+// CspHealthTargetType 헬스 체크 대상 유형
+type CspHealthTargetType string
+
+const (
+	CspHealthTargetIdpConfig CspHealthTargetType = "IDP_CONFIG"  // CspIdpConfig.TestConnection
+	CspHealthTargetAccount   CspHealthTargetType = "CSP_ACCOUNT" // CspAccountService.ValidateCspAccount
+)
+
+// 헬스 체크 상태 (BulkHealthCheck의 HealthCheckResult.Status와 동일한 값)
+const (
+	CspHealthStatusConnected = "CONNECTED"
+	CspHealthStatusFailed    = "FAILED"
+	CspHealthStatusTimeout   = "TIMEOUT"
+)
+
+// CspHealthCheck 주기적 헬스 체크 결과 시계열 (mcmp_csp_health_checks)
+type CspHealthCheck struct {
+	ID             uint                `gorm:"primaryKey" json:"id"`
+	TargetType     CspHealthTargetType `gorm:"size:20;not null;index:idx_csp_health_check_target" json:"target_type"`
+	TargetID       uint                `gorm:"not null;index:idx_csp_health_check_target" json:"target_id"`
+	TargetName     string              `gorm:"size:255" json:"target_name"`
+	CspType        string              `gorm:"size:50" json:"csp_type"`
+	AuthMethod     string              `gorm:"size:20" json:"auth_method,omitempty"`
+	Status         string              `gorm:"size:20;not null" json:"status"`
+	PreviousStatus string              `gorm:"size:20" json:"previous_status,omitempty"` // 직전 상태 (최초 체크면 "")
+	Transition     bool                `gorm:"not null;default:false" json:"transition"` // 직전 상태와 다른 경우
+	ErrorMsg       string              `gorm:"type:text" json:"error_message,omitempty"`
+	LatencyMs      int64               `json:"latency_ms"`
+	CheckedAt      time.Time           `gorm:"not null;index" json:"checked_at"`
+}
+
+// TableName CspHealthCheck 테이블 이름 반환
+func (CspHealthCheck) TableName() string {
+	return "mcmp_csp_health_checks"
+}
+
+// CspHealthState 대상별 현재 헬스 상태 (mcmp_csp_health_states)
+type CspHealthState struct {
+	TargetType          CspHealthTargetType `gorm:"primaryKey;size:20" json:"target_type"`
+	TargetID            uint                `gorm:"primaryKey;autoIncrement:false" json:"target_id"`
+	TargetName          string              `gorm:"size:255" json:"target_name"`
+	CspType             string              `gorm:"size:50" json:"csp_type"`
+	AuthMethod          string              `gorm:"size:20" json:"auth_method,omitempty"`
+	Status              string              `gorm:"size:20;not null" json:"status"`
+	ErrorMsg            string              `gorm:"type:text" json:"error_message,omitempty"`
+	ConsecutiveFailures int                 `gorm:"not null;default:0" json:"consecutive_failures"`
+	LastCheckedAt       time.Time           `json:"last_checked_at"`
+	LastChangedAt       time.Time           `json:"last_changed_at"` // 상태가 마지막으로 바뀐 시각
+	LastSuccessAt       *time.Time          `json:"last_success_at,omitempty"`
+}
+
+// TableName CspHealthState 테이블 이름 반환
+func (CspHealthState) TableName() string {
+	return "mcmp_csp_health_states"
+}
+
+// CspHealthHistoryFilter 헬스 체크 이력 조회 조건 (query)
+type CspHealthHistoryFilter struct {
+	Since          string `query:"since"`          // RFC3339, 미지정 시 전체
+	Limit          int    `query:"limit"`          // 기본 100, 최대 1000
+	TransitionOnly bool   `query:"transitionOnly"` // 상태 전이만 조회
+}
+
+// CspHealthHistoryResponse 대상별 현재 상태와 이력
+type CspHealthHistoryResponse struct {
+	State   *CspHealthState  `json:"state,omitempty"`
+	History []CspHealthCheck `json:"history"`
+}
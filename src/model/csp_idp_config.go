@@ -21,7 +21,7 @@ type CspIdpConfig struct {
 	CspAccountID uint              `gorm:"not null" json:"csp_account_id"`
 	CspAccount   *CspAccount       `gorm:"foreignKey:CspAccountID" json:"csp_account,omitempty"`
 	AuthMethod   AuthMethodType    `gorm:"size:50;not null" json:"auth_method"` // OIDC, SAML, SECRET_KEY
-	Config       map[string]string `gorm:"type:jsonb;serializer:json" json:"config"`
+	Config       map[string]string `gorm:"type:jsonb;serializer:encryptedjson" json:"config"`
 	IsActive     bool              `gorm:"default:true" json:"is_active"`
 	Description  string            `gorm:"size:500" json:"description"`
 	CreatedAt    time.Time         `json:"created_at"`
@@ -0,0 +1,5 @@
+package model
+
+// 민감 필드는 `gorm:"serializer:encrypted"`(문자열) 또는 `gorm:"serializer:encryptedjson"`(map)으로
+// 저장 시 envelope 암호화된다. serializer는 envelope 패키지 init에서 등록된다.
+import _ "github.com/m-cmp/mc-iam-manager/pkg/envelope"
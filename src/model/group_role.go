@@ -20,11 +20,13 @@ func (GroupPlatformRole) TableName() string {
 // GroupWorkspaceRole 그룹-워크스페이스-역할 매핑 (DB 테이블: mcmp_group_workspace_roles)
 // DB 전용 관리 (Keycloak 미사용)
 type GroupWorkspaceRole struct {
-	GroupID     uint      `gorm:"primaryKey;column:group_id" json:"group_id"`
-	WorkspaceID uint      `gorm:"primaryKey;column:workspace_id" json:"workspace_id"`
-	RoleID      uint      `gorm:"column:role_id;not null" json:"role_id"`
-	CreatedAt   time.Time `gorm:"autoCreateTime;column:created_at" json:"created_at"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime;column:updated_at" json:"updated_at"`
+	GroupID     uint       `gorm:"primaryKey;column:group_id" json:"group_id"`
+	WorkspaceID uint       `gorm:"primaryKey;column:workspace_id" json:"workspace_id"`
+	RoleID      uint       `gorm:"column:role_id;not null" json:"role_id"`
+	StartsAt    *time.Time `gorm:"column:starts_at" json:"starts_at,omitempty"`         // nil이면 즉시 유효
+	ExpiresAt   *time.Time `gorm:"column:expires_at;index" json:"expires_at,omitempty"` // nil이면 만료 없음
+	CreatedAt   time.Time  `gorm:"autoCreateTime;column:created_at" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime;column:updated_at" json:"updated_at"`
 
 	Group     *Organization `gorm:"foreignKey:GroupID" json:"group,omitempty"`
 	Workspace *Workspace    `gorm:"foreignKey:WorkspaceID" json:"workspace,omitempty"`
@@ -42,13 +44,36 @@ type AssignGroupPlatformRoleRequest struct {
 
 // AssignGroupWorkspaceRequest 그룹-워크스페이스 매핑 요청
 type AssignGroupWorkspaceRequest struct {
-	WorkspaceID uint `json:"workspace_id" validate:"required"`
-	RoleID      uint `json:"role_id" validate:"required"`
+	WorkspaceID uint       `json:"workspace_id" validate:"required"`
+	RoleID      uint       `json:"role_id" validate:"required"`
+	StartsAt    *time.Time `json:"starts_at,omitempty"`  // 유효 시작 시각 (생략 시 즉시)
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"` // 만료 시각 (생략 시 만료 없음)
 }
 
 // UpdateGroupWorkspaceRoleRequest 그룹 워크스페이스 역할 변경 요청
+// starts_at/expires_at을 생략하면 저장된 유효 기간을 유지하고, clear_* 가 true이면 해당 제한을 해제한다.
 type UpdateGroupWorkspaceRoleRequest struct {
-	RoleID uint `json:"role_id" validate:"required"`
+	RoleID         uint       `json:"role_id" validate:"required"`
+	StartsAt       *time.Time `json:"starts_at,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	ClearStartsAt  bool       `json:"clear_starts_at,omitempty"`  // 시작 시각 제한 해제 (즉시 유효)
+	ClearExpiresAt bool       `json:"clear_expires_at,omitempty"` // 만료 해제
+}
+
+// Period 저장된 유효 기간에 요청의 변경 사항을 반영한 기간
+func (r *UpdateGroupWorkspaceRoleRequest) Period(current RoleGrantPeriod) RoleGrantPeriod {
+	period := current
+	if r.StartsAt != nil {
+		period.StartsAt = r.StartsAt
+	} else if r.ClearStartsAt {
+		period.StartsAt = nil
+	}
+	if r.ExpiresAt != nil {
+		period.ExpiresAt = r.ExpiresAt
+	} else if r.ClearExpiresAt {
+		period.ExpiresAt = nil
+	}
+	return period
 }
 
 // GroupPlatformRoleResponse 그룹 플랫폼 역할 목록 응답
@@ -62,13 +87,15 @@ type GroupPlatformRoleResponse struct {
 
 // GroupWorkspaceRoleResponse 그룹 워크스페이스 역할 목록 응답
 type GroupWorkspaceRoleResponse struct {
-	GroupID       uint      `json:"group_id"`
-	GroupName     string    `json:"group_name"`
-	WorkspaceID   uint      `json:"workspace_id"`
-	WorkspaceName string    `json:"workspace_name"`
-	RoleID        uint      `json:"role_id"`
-	RoleName      string    `json:"role_name"`
-	CreatedAt     time.Time `json:"created_at"`
+	GroupID       uint       `json:"group_id"`
+	GroupName     string     `json:"group_name"`
+	WorkspaceID   uint       `json:"workspace_id"`
+	WorkspaceName string     `json:"workspace_name"`
+	RoleID        uint       `json:"role_id"`
+	RoleName      string     `json:"role_name"`
+	StartsAt      *time.Time `json:"starts_at,omitempty"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
 }
 
 // AvailablePlatformRoleResponse 미할당 플랫폼 역할 응답
@@ -0,0 +1,78 @@
+package model
+
+// IamBundle 선언적 IAM 구성 번들 (kind: iam-bundle)
+// 역할/권한/메뉴·CSP 매핑/그룹 바인딩을 이름 기준으로 직렬화하여 환경 간(dev → prod) 승격에 사용한다.
+// 숫자 ID는 환경마다 다르므로 사용하지 않는다.
+type IamBundle struct {
+	Kind        string                `json:"kind" yaml:"kind"`
+	Version     string                `json:"version" yaml:"version"`
+	ExportedAt  string                `json:"exportedAt,omitempty" yaml:"exportedAt,omitempty"`
+	Source      string                `json:"source,omitempty" yaml:"source,omitempty"`
+	Permissions []IamBundlePermission `json:"permissions" yaml:"permissions"`
+	Roles       []IamBundleRole       `json:"roles" yaml:"roles"`
+	Groups      []IamBundleGroup      `json:"groups" yaml:"groups"`
+}
+
+// IamBundlePermission MC-IAM 권한 (mcmp_mciam_permissions)
+type IamBundlePermission struct {
+	ID             string `json:"id" yaml:"id"`
+	FrameworkID    string `json:"frameworkId" yaml:"frameworkId"`
+	ResourceTypeID string `json:"resourceTypeId" yaml:"resourceTypeId"`
+	Action         string `json:"action" yaml:"action"`
+	Name           string `json:"name" yaml:"name"`
+	Description    string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// IamBundleRole 역할과 역할 단위 매핑 (role subs, 메뉴, MC-IAM 권한, CSP 역할)
+type IamBundleRole struct {
+	Name                 string                `json:"name" yaml:"name"`
+	Description          string                `json:"description,omitempty" yaml:"description,omitempty"`
+	Predefined           bool                  `json:"predefined,omitempty" yaml:"predefined,omitempty"`
+	Parent               string                `json:"parent,omitempty" yaml:"parent,omitempty"`
+	RoleTypes            []string              `json:"roleTypes" yaml:"roleTypes"`
+	Menus                []string              `json:"menus,omitempty" yaml:"menus,omitempty"`
+	PlatformPermissions  []string              `json:"platformPermissions,omitempty" yaml:"platformPermissions,omitempty"`
+	WorkspacePermissions []string              `json:"workspacePermissions,omitempty" yaml:"workspacePermissions,omitempty"`
+	CspRoles             []IamBundleCspRoleRef `json:"cspRoles,omitempty" yaml:"cspRoles,omitempty"`
+}
+
+// IamBundleCspRoleRef CSP 역할 매핑 참조 (CSP 역할은 대상 환경에 미리 존재해야 함)
+type IamBundleCspRoleRef struct {
+	CspType    string `json:"cspType" yaml:"cspType"`
+	Name       string `json:"name" yaml:"name"`
+	AuthMethod string `json:"authMethod" yaml:"authMethod"`
+}
+
+// IamBundleGroup 그룹(조직)과 역할 바인딩. 기간 제한 바인딩은 운영 데이터로 보고 포함하지 않는다.
+type IamBundleGroup struct {
+	Code           string                        `json:"code" yaml:"code"`
+	Name           string                        `json:"name" yaml:"name"`
+	Description    string                        `json:"description,omitempty" yaml:"description,omitempty"`
+	Parent         string                        `json:"parent,omitempty" yaml:"parent,omitempty"` // 상위 그룹 code
+	PlatformRoles  []string                      `json:"platformRoles,omitempty" yaml:"platformRoles,omitempty"`
+	WorkspaceRoles []IamBundleGroupWorkspaceRole `json:"workspaceRoles,omitempty" yaml:"workspaceRoles,omitempty"`
+}
+
+// IamBundleGroupWorkspaceRole 그룹-워크스페이스 역할 바인딩 (워크스페이스는 이름으로 참조)
+type IamBundleGroupWorkspaceRole struct {
+	Workspace string `json:"workspace" yaml:"workspace"`
+	Role      string `json:"role" yaml:"role"`
+}
+
+// IamBundleChange 번들 적용 시 발생하는 단일 변경
+type IamBundleChange struct {
+	Resource string      `json:"resource"` // permission, role, role.type, role.menu, role.permission, role.csp-role, group, group.platform-role, group.workspace-role
+	Action   string      `json:"action"`   // create, update, delete
+	Key      string      `json:"key"`
+	Before   interface{} `json:"before,omitempty"`
+	After    interface{} `json:"after,omitempty"`
+}
+
+// IamBundlePlan diff/apply 결과
+type IamBundlePlan struct {
+	Mode     string            `json:"mode"`
+	DryRun   bool              `json:"dryRun"`
+	Applied  bool              `json:"applied"`
+	Changes  []IamBundleChange `json:"changes"`
+	Warnings []string          `json:"warnings,omitempty"`
+}
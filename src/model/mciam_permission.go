@@ -47,3 +47,25 @@ type MciamRoleMciamPermission struct {
 func (MciamRoleMciamPermission) TableName() string { // Renamed receiver
 	return "mcmp_mciam_role_permissions" // Updated table name
 }
+
+// MciamPermissionSeedData 프레임워크 자체 리소스 유형/권한 시드 (asset/permission/<framework>.yaml)
+type MciamPermissionSeedData struct {
+	FrameworkID     string                    `yaml:"framework_id"`
+	ResourceTypes   []MciamResourceTypeSeed   `yaml:"resource_types"`
+	RolePermissions []MciamRolePermissionSeed `yaml:"role_permissions"`
+}
+
+// MciamResourceTypeSeed 리소스 유형과 허용 액션 (권한 ID = <framework_id>:<id>:<action>)
+type MciamResourceTypeSeed struct {
+	ID          string   `yaml:"id"`
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description"`
+	Actions     []string `yaml:"actions"`
+}
+
+// MciamRolePermissionSeed 역할별 기본 권한 매핑
+type MciamRolePermissionSeed struct {
+	Role        string                `yaml:"role"`
+	RoleType    constants.IAMRoleType `yaml:"role_type"`
+	Permissions []string              `yaml:"permissions"`
+}
@@ -1,6 +1,10 @@
 package mcmpapi // Change package name
 
-import "time"
+import (
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/pkg/apiparser"
+)
 
 // McmpApiAction represents the mcmp_api_actions table. (Renamed)
 type McmpApiAction struct {
@@ -12,6 +16,11 @@ type McmpApiAction struct {
 	Description  string    `gorm:"column:description;type:text"`
 	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt    time.Time `gorm:"column:updated_at;autoUpdateTime"`
+
+	// API 스펙에서 가져온 파라미터/요청 본문/보안 요구사항 ($ref 해석 완료)
+	Parameters  []apiparser.ActionParameter     `gorm:"column:parameters;type:jsonb;serializer:json"`
+	RequestBody *apiparser.ActionRequestBody    `gorm:"column:request_body;type:jsonb;serializer:json"`
+	Security    []apiparser.SecurityRequirement `gorm:"column:security;type:jsonb;serializer:json"`
 	// McmpApiService McmpApiService `gorm:"foreignKey:ServiceName;references:Name"` // Define relationship if needed (Use renamed service struct)
 }
 
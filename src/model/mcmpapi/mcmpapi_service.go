@@ -1,6 +1,10 @@
 package mcmpapi // Change package name
 
-import "time"
+import (
+	"time"
+
+	_ "github.com/m-cmp/mc-iam-manager/pkg/envelope" // encrypted serializer 등록
+)
 
 // McmpApiService represents the mcmp_api_services table. (Renamed)
 type McmpApiService struct {
@@ -9,8 +13,8 @@ type McmpApiService struct {
 	BaseURL   string    `gorm:"column:base_url;type:varchar(255)"`
 	AuthType  string    `gorm:"column:auth_type;type:varchar(50)"`
 	AuthUser  string    `gorm:"column:auth_user;type:varchar(100)"`
-	AuthPass  string    `gorm:"column:auth_pass;type:varchar(255)"`      // Consider encryption for password
-	IsActive  bool      `gorm:"column:is_active;default:false;not null"` // Added IsActive field
+	AuthPass  string    `gorm:"column:auth_pass;type:text;serializer:encrypted"` // 저장 시 암호화
+	IsActive  bool      `gorm:"column:is_active;default:false;not null"`         // Added IsActive field
 	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
 }
@@ -8,6 +8,7 @@ type McmpApiServiceMeta struct {
 	Version     string    `gorm:"column:version;type:varchar(50)"`
 	Repository  string    `gorm:"column:repository;type:varchar(255)"`
 	GeneratedAt time.Time `gorm:"column:generated_at"`
+	Servers     []string  `gorm:"column:servers;type:jsonb;serializer:json"` // 스펙의 basePath/servers URL
 	SyncedAt    time.Time `gorm:"column:synced_at;autoUpdateTime"`
 	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
 }
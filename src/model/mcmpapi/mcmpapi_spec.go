@@ -1,5 +1,7 @@
 package mcmpapi // Change package name
 
+import "github.com/m-cmp/mc-iam-manager/pkg/apiparser"
+
 // McmpApiAuthInfo holds authentication details for a service. (Renamed)
 type McmpApiAuthInfo struct {
 	Type     string `yaml:"type"`
@@ -16,9 +18,12 @@ type McmpApiServiceDefinition struct {
 
 // McmpApiServiceAction defines a single API action for a service. (Renamed)
 type McmpApiServiceAction struct {
-	Method       string `yaml:"method"`
-	ResourcePath string `yaml:"resourcePath"`
-	Description  string `yaml:"description"`
+	Method       string                          `yaml:"method"`
+	ResourcePath string                          `yaml:"resourcePath"`
+	Description  string                          `yaml:"description"`
+	Parameters   []apiparser.ActionParameter     `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	RequestBody  *apiparser.ActionRequestBody    `yaml:"requestBody,omitempty" json:"requestBody,omitempty"`
+	Security     []apiparser.SecurityRequirement `yaml:"security,omitempty" json:"security,omitempty"`
 }
 
 // McmpApiDefinitions holds all parsed service and action definitions. (Renamed)
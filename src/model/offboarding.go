@@ -0,0 +1,101 @@
+package model
+
+import "time"
+
+// OffboardingStatus 사용자 오프보딩 상태
+type OffboardingStatus string
+
+const (
+	OffboardingStatusScheduled OffboardingStatus = "SCHEDULED" // 유예 기간 중 (취소 가능)
+	OffboardingStatusCompleted OffboardingStatus = "COMPLETED"
+	OffboardingStatusCancelled OffboardingStatus = "CANCELLED"
+	OffboardingStatusFailed    OffboardingStatus = "FAILED" // 최종 실행 실패 (대상 보완 후 재실행 가능)
+)
+
+// Offboarding 사용자 오프보딩 모델 (DB 테이블: mcmp_user_offboardings)
+// 유예 기간이 지나면 소유 자원을 이관한 뒤 역할 매핑 삭제, Keycloak 비활성화, WITHDRAWN 처리한다.
+type Offboarding struct {
+	ID                     uint                      `json:"id" gorm:"primaryKey;column:id"`
+	UserID                 uint                      `json:"userId" gorm:"column:user_id;not null;index"`
+	KcUserID               string                    `json:"kcUserId" gorm:"column:kc_user_id;size:255"`
+	Status                 OffboardingStatus         `json:"status" gorm:"column:status;size:50;not null;default:'SCHEDULED';index"`
+	ReassignToUserID       *uint                     `json:"reassignToUserId,omitempty" gorm:"column:reassign_to_user_id"` // 기본 이관 대상
+	WorkspaceReassignments []OffboardingReassignment `json:"workspaceReassignments,omitempty" gorm:"column:workspace_reassignments;type:text;serializer:json"`
+	Reason                 string                    `json:"reason,omitempty" gorm:"column:reason;type:text"`
+	RequestedBy            string                    `json:"requestedBy,omitempty" gorm:"column:requested_by;size:255"` // 요청자 Keycloak ID
+	ScheduledAt            time.Time                 `json:"scheduledAt" gorm:"column:scheduled_at;not null;index"`     // 최종 실행 예정 시각
+	ExecutedAt             *time.Time                `json:"executedAt,omitempty" gorm:"column:executed_at"`
+	Report                 *OffboardingReport        `json:"report,omitempty" gorm:"column:report;type:text;serializer:json"` // 예약 시 사전 점검 결과, 실행 후 처리 결과
+	LastError              string                    `json:"lastError,omitempty" gorm:"column:last_error;type:text"`
+	CreatedAt              time.Time                 `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt              time.Time                 `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName Offboarding의 테이블 이름 지정
+func (Offboarding) TableName() string {
+	return "mcmp_user_offboardings"
+}
+
+// OffboardingReassignment 워크스페이스별 관리자 이관 대상 (기본 이관 대상보다 우선)
+type OffboardingReassignment struct {
+	WorkspaceID uint `json:"workspaceId"`
+	UserID      uint `json:"userId"`
+}
+
+// OffboardingRequest 오프보딩 사전 점검/예약 요청
+type OffboardingRequest struct {
+	UserID                 uint                      `json:"userId" validate:"required"`
+	ReassignToUserID       *uint                     `json:"reassignToUserId,omitempty"`
+	WorkspaceReassignments []OffboardingReassignment `json:"workspaceReassignments,omitempty"`
+	GraceDays              *int                      `json:"graceDays,omitempty"` // 생략 시 MC_IAM_MANAGER_OFFBOARDING_GRACE_DAYS, 0이면 다음 실행 주기에 처리
+	Reason                 string                    `json:"reason,omitempty"`
+}
+
+// OffboardingFilterRequest 오프보딩 목록 필터
+type OffboardingFilterRequest struct {
+	Status string `query:"status"`
+	UserID uint   `query:"userId"`
+}
+
+// OffboardingReport 오프보딩 사전 점검 결과. Blockers가 남아 있으면 최종 실행하지 않는다.
+type OffboardingReport struct {
+	UserID                uint                           `json:"userId"`
+	Username              string                         `json:"username"`
+	SoleAdminWorkspaces   []OffboardingWorkspaceTransfer `json:"soleAdminWorkspaces"`   // 유일한 관리자인 워크스페이스
+	SoleMemberGroups      []OffboardingGroupTransfer     `json:"soleMemberGroups"`      // 유일한 구성원인 그룹(조직)
+	PendingInvitations    []OffboardingInvitation        `json:"pendingInvitations"`    // 보낸 대기 중 초대 (이관 대상이 없으면 거절 처리)
+	PendingAccessRequests []uint                         `json:"pendingAccessRequests"` // 승인 대기 중 권한 상승 요청 (취소 처리)
+	OwnedServiceAccounts  []OffboardingServiceAccount    `json:"ownedServiceAccounts"`  // 생성한 서비스 계정
+	CachedCredentialCount int64                          `json:"cachedCredentialCount"` // 캐시된 CSP 임시 자격 증명 (폐기 처리)
+	Blockers              []string                       `json:"blockers,omitempty"`    // 실행을 막는 문제 (예: 관리자 없는 워크스페이스)
+	Warnings              []string                       `json:"warnings,omitempty"`
+}
+
+// OffboardingWorkspaceTransfer 관리자 역할 이관 대상 워크스페이스
+type OffboardingWorkspaceTransfer struct {
+	WorkspaceID      uint   `json:"workspaceId"`
+	WorkspaceName    string `json:"workspaceName"`
+	ReassignToUserID *uint  `json:"reassignToUserId,omitempty"`
+}
+
+// OffboardingGroupTransfer 구성원 이관 대상 그룹(조직)
+type OffboardingGroupTransfer struct {
+	OrganizationID   uint   `json:"organizationId"`
+	Name             string `json:"name"`
+	ReassignToUserID *uint  `json:"reassignToUserId,omitempty"`
+}
+
+// OffboardingInvitation 이관 또는 거절할 워크스페이스 초대
+type OffboardingInvitation struct {
+	InvitationID     uint  `json:"invitationId"`
+	WorkspaceID      uint  `json:"workspaceId"`
+	InviteeUserID    uint  `json:"inviteeUserId"`
+	ReassignToUserID *uint `json:"reassignToUserId,omitempty"`
+}
+
+// OffboardingServiceAccount 소유권을 이관할 서비스 계정
+type OffboardingServiceAccount struct {
+	ServiceAccountID uint   `json:"serviceAccountId"`
+	Name             string `json:"name"`
+	ReassignToUserID *uint  `json:"reassignToUserId,omitempty"`
+}
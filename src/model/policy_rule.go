@@ -0,0 +1,123 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// 정책 규칙 효과
+const (
+	PolicyRuleEffectAllow = "allow"
+	PolicyRuleEffectDeny  = "deny"
+)
+
+// 정책 규칙 적용 대상 유형
+const (
+	PolicyRuleSubjectRole  = "role"
+	PolicyRuleSubjectGroup = "group"
+)
+
+// PolicyRule 역할 또는 그룹에 붙는 명시적 허용/거부 규칙 (DB 테이블: mcmp_policy_rules)
+// 대상(permissionId 패턴 또는 serviceName+actionName)과 조건이 모두 일치하면 적용되며, deny가 allow보다 우선한다.
+// 역할 규칙은 해당 역할을 상속한 하위 역할(parent_id)에도 적용된다. platformAdmin은 규칙과 관계없이 허용된다.
+// permissionId 끝의 '*'는 접두어 일치 (예: mc-infra-manager:vm:*)
+type PolicyRule struct {
+	ID           uint                 `json:"id" gorm:"primaryKey"`
+	Name         string               `json:"name" gorm:"column:name;size:100;not null;uniqueIndex"`
+	Description  string               `json:"description,omitempty" gorm:"column:description;size:1000"`
+	Effect       string               `json:"effect" gorm:"column:effect;size:10;not null"`                // allow | deny
+	SubjectType  string               `json:"subjectType" gorm:"column:subject_type;size:10;not null"`     // role | group
+	SubjectID    uint                 `json:"subjectId" gorm:"column:subject_id;not null;index"`           // 역할 ID 또는 그룹(조직) ID
+	RoleType     string               `json:"roleType,omitempty" gorm:"column:role_type;size:20"`          // role 대상: platform | workspace
+	WorkspaceID  *uint                `json:"workspaceId,omitempty" gorm:"column:workspace_id;index"`      // 지정 시 해당 워크스페이스 평가에만 적용
+	PermissionID string               `json:"permissionId,omitempty" gorm:"column:permission_id;size:255"` // 끝의 '*'는 접두어 일치 (allow는 정확한 ID만)
+	ServiceName  string               `json:"serviceName,omitempty" gorm:"column:service_name;size:100"`   // McmpApiAction 대상
+	ActionName   string               `json:"actionName,omitempty" gorm:"column:action_name;size:255"`     // '*'이면 서비스의 모든 액션
+	Conditions   PolicyRuleConditions `json:"conditions" gorm:"column:conditions;type:jsonb;serializer:json"`
+	Enabled      bool                 `json:"enabled" gorm:"column:enabled;not null;default:true"`
+	CreatedBy    string               `json:"createdBy,omitempty" gorm:"column:created_by;size:255"`
+	CreatedAt    time.Time            `json:"createdAt" gorm:"column:created_at"`
+	UpdatedAt    time.Time            `json:"updatedAt" gorm:"column:updated_at"`
+}
+
+// TableName PolicyRule의 테이블 이름 지정
+func (PolicyRule) TableName() string {
+	return "mcmp_policy_rules"
+}
+
+// PermissionPatternMatches 규칙의 permissionId 패턴 비교. 끝의 '*'는 접두어 일치
+func PermissionPatternMatches(pattern, permissionID string) bool {
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(permissionID, strings.TrimSuffix(pattern, "*"))
+	}
+	return pattern == permissionID
+}
+
+// PolicyRuleConditions 규칙 적용 조건. 지정한 조건이 모두 만족될 때 규칙이 일치하며, Negate이면 결과를 반전한다.
+// 예) 업무시간 외 거부: deny + timeWindows(09:00-18:00) + negate, MFA 미사용 거부: deny + requiredAmr[mfa] + negate
+type PolicyRuleConditions struct {
+	TimeWindows []PolicyTimeWindow `json:"timeWindows,omitempty"` // 요청 시각이 하나라도 포함되면 만족
+	SourceCIDRs []string           `json:"sourceCidrs,omitempty"` // 요청 IP가 하나라도 포함되면 만족
+	RequiredAMR []string           `json:"requiredAmr,omitempty"` // 토큰 amr 클레임에 모두 있으면 만족
+	Negate      bool               `json:"negate,omitempty"`
+}
+
+// IsEmpty 조건 미지정 여부 (항상 일치)
+func (c PolicyRuleConditions) IsEmpty() bool {
+	return len(c.TimeWindows) == 0 && len(c.SourceCIDRs) == 0 && len(c.RequiredAMR) == 0
+}
+
+// PolicyTimeWindow 요일/시간 범위. Start > End이면 자정을 넘는 범위 (예: 22:00-06:00)
+type PolicyTimeWindow struct {
+	Days     []string `json:"days,omitempty"` // mon..sun, 비어 있으면 매일
+	Start    string   `json:"start"`          // HH:MM
+	End      string   `json:"end"`            // HH:MM (미포함)
+	Timezone string   `json:"timezone,omitempty"`
+}
+
+// AuthzRequestContext 조건 평가용 요청 정보 (미지정 시각은 현재 시각)
+type AuthzRequestContext struct {
+	Time     *time.Time `json:"time,omitempty"`
+	SourceIP string     `json:"sourceIp,omitempty"`
+	AMR      []string   `json:"amr,omitempty"`
+}
+
+// PolicyRuleFilter 정책 규칙 목록 조회 조건
+type PolicyRuleFilter struct {
+	SubjectType string `query:"subjectType"`
+	SubjectID   uint   `query:"subjectId"`
+	Effect      string `query:"effect"`
+}
+
+// AuthzRuleMatch 권한 판단에서 일치한 정책 규칙
+type AuthzRuleMatch struct {
+	RuleID      uint   `json:"ruleId"`
+	Name        string `json:"name"`
+	Effect      string `json:"effect"`
+	SubjectType string `json:"subjectType"`
+	SubjectID   uint   `json:"subjectId"`
+	WorkspaceID uint   `json:"workspaceId,omitempty"`
+	Target      string `json:"target"` // 일치한 permissionId 또는 service/action
+}
+
+// AuthzRuleEvaluation dry-run에서 평가한 규칙과 결과
+type AuthzRuleEvaluation struct {
+	RuleID  uint   `json:"ruleId"`
+	Name    string `json:"name"`
+	Effect  string `json:"effect"`
+	Matched bool   `json:"matched"`
+	Reason  string `json:"reason"` // 불일치 사유 또는 일치 대상
+}
+
+// AuthzDryRunRequest 권한 판단 dry-run 요청. 후보 규칙(candidateRules)은 저장하지 않고 활성 규칙처럼 함께 평가한다.
+type AuthzDryRunRequest struct {
+	AuthzCheckRequest
+	CandidateRules []PolicyRule `json:"candidateRules,omitempty"`
+}
+
+// AuthzDryRunResponse 권한 판단 dry-run 결과
+type AuthzDryRunResponse struct {
+	AuthzCheckResponse
+	Context        AuthzRequestContext   `json:"context"`
+	EvaluatedRules []AuthzRuleEvaluation `json:"evaluatedRules"`
+}
@@ -1,6 +1,10 @@
 package model
 
-import "github.com/m-cmp/mc-iam-manager/constants"
+import (
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+)
 
 // 각종 요청에 대한 구조체 정의
 // naming convention : XxxRequest
@@ -41,6 +45,8 @@ type McmpApiRequestParams struct {
 type McmpApiCallRequest struct {
 	ServiceName   string               `json:"serviceName" validate:"required"` // Target service name
 	ActionName    string               `json:"actionName" validate:"required"`  // Target action name (operationId)
+	WorkspaceID   string               `json:"workspaceId,omitempty"`           // 권한 평가 워크스페이스 (X-Workspace-Id 헤더로도 지정 가능)
+	ProjectID     string               `json:"projectId,omitempty"`             // 권한 평가 프로젝트 (선택)
 	RequestParams McmpApiRequestParams `json:"requestParams"`                   // Parameters for the external API call
 }
 
@@ -52,6 +58,8 @@ type AssignRoleRequest struct {
 	RoleName    string `json:"roleName,omitempty"`    // 역할명
 	RoleType    string `json:"roleType"`              // 역할 타입 (platform/workspace)
 	WorkspaceID string `json:"workspaceId,omitempty"` // 워크스페이스 ID (문자열로 받음)
+	// 만료 시각 (플랫폼 역할 할당 시, 생략 시 만료 없음). Keycloak realm role과 동기화되므로 시작 시각은 지원하지 않음
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
 }
 
 // RoleMasterSubRequest 역할 생성 요청 구조체
@@ -71,6 +79,7 @@ type RoleFilterRequest struct {
 	RoleID    string                  `json:"roleId,omitempty"`
 	RoleName  string                  `json:"roleName,omitempty"`
 	RoleTypes []constants.IAMRoleType `json:"roleTypes,omitempty"`
+	CompanyID *uint                   `json:"-"` // 멀티테넌트 모드: 해당 회사 역할 + 공용 역할
 }
 
 // 워크스페이스와 프로젝트 매핑 추가 또는 해제
@@ -87,6 +96,7 @@ type WorkspaceFilterRequest struct {
 	ProjectID     string `json:"projectId,omitempty"`
 	UserID        string `json:"userId,omitempty"`
 	RoleID        string `json:"roleId,omitempty"`
+	CompanyID     *uint  `json:"-"` // 멀티테넌트 모드: 요청 회사의 워크스페이스만
 }
 
 type CreateCspRoleRequest struct {
@@ -154,11 +164,13 @@ type MenuMappingFilterRequest struct {
 
 // AssignWorkspaceRoleRequest 워크스페이스 역할 할당 요청 구조체
 type AssignWorkspaceRoleRequest struct {
-	UserID      string `json:"userId,omitempty"`   // 사용자 ID (문자열로 받음)
-	Username    string `json:"username,omitempty"` // 사용자명
-	RoleID      string `json:"roleId,omitempty"`   // 역할 ID (문자열로 받음)
-	RoleName    string `json:"roleName,omitempty"` // 역할명
-	WorkspaceID string `json:"workspaceId"`        // 워크스페이스 ID (문자열로 받음)
+	UserID      string     `json:"userId,omitempty"`    // 사용자 ID (문자열로 받음)
+	Username    string     `json:"username,omitempty"`  // 사용자명
+	RoleID      string     `json:"roleId,omitempty"`    // 역할 ID (문자열로 받음)
+	RoleName    string     `json:"roleName,omitempty"`  // 역할명
+	WorkspaceID string     `json:"workspaceId"`         // 워크스페이스 ID (문자열로 받음)
+	StartsAt    *time.Time `json:"startsAt,omitempty"`  // 유효 시작 시각 (생략 시 즉시)
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"` // 만료 시각 (생략 시 만료 없음)
 }
 
 // RemoveWorkspaceRoleRequest 워크스페이스 역할 제거 요청 구조체
@@ -362,3 +374,37 @@ type ProjectSyncApplyResponse struct {
 	Skipped  []ProjectSyncApplySkippedItem  `json:"skipped"`
 	Failed   []ProjectSyncApplyFailedItem   `json:"failed"`
 }
+
+// ProjectSyncOrphanItem local project whose namespace no longer exists in infra
+type ProjectSyncOrphanItem struct {
+	ID     uint   `json:"id"`
+	NsId   string `json:"nsId"`
+	Name   string `json:"name"`
+	Action string `json:"action"` // keep, unassign, delete (dry-run에서는 적용 예정 동작)
+}
+
+// ProjectSyncRun result of a single background project sync run
+type ProjectSyncRun struct {
+	StartedAt  time.Time                      `json:"startedAt"`
+	FinishedAt time.Time                      `json:"finishedAt"`
+	DryRun     bool                           `json:"dryRun"`
+	Success    bool                           `json:"success"`
+	Error      string                         `json:"error,omitempty"`
+	Workspace  string                         `json:"workspace"`
+	Diff       ProjectSyncDiffResponse        `json:"diff"`
+	Orphans    []ProjectSyncOrphanItem        `json:"orphans"`
+	Created    []ProjectSyncApplyCreatedItem  `json:"created"`
+	Assigned   []ProjectSyncApplyAssignedItem `json:"assigned"`
+	Failed     []ProjectSyncApplyFailedItem   `json:"failed"`
+}
+
+// ProjectSyncStatusResponse GET /api/setup/projects/sync-status response
+type ProjectSyncStatusResponse struct {
+	Enabled         bool            `json:"enabled"`
+	IntervalSeconds int             `json:"intervalSeconds"`
+	DryRun          bool            `json:"dryRun"`
+	Workspace       string          `json:"workspace"`
+	OrphanPolicy    string          `json:"orphanPolicy"`
+	Running         bool            `json:"running"`
+	LastRun         *ProjectSyncRun `json:"lastRun,omitempty"`
+}
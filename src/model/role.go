@@ -1,6 +1,7 @@
 package model
 
 import (
+	"errors"
 	"time"
 
 	"github.com/m-cmp/mc-iam-manager/constants"
@@ -13,6 +14,7 @@ type RoleMaster struct {
 	Name            string                      `json:"name" gorm:"column:name;size:255;not null;unique"`
 	Description     string                      `json:"description" gorm:"column:description;size:1000"`
 	Predefined      bool                        `json:"predefined" gorm:"column:predefined;not null;default:false"`
+	CompanyID       *uint                       `json:"company_id,omitempty" gorm:"column:company_id;index"` // 회사 전용 역할 (nil이면 모든 회사 공용)
 	CreatedAt       time.Time                   `json:"created_at" gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt       time.Time                   `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
 	Parent          *RoleMaster                 `json:"parent,omitempty" gorm:"foreignKey:ParentID"`
@@ -44,6 +46,7 @@ func (RoleSub) TableName() string {
 type UserPlatformRole struct {
 	UserID    uint       `json:"user_id" gorm:"primaryKey;column:user_id"`
 	RoleID    uint       `json:"role_id" gorm:"primaryKey;column:role_id"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" gorm:"column:expires_at;index"` // nil이면 만료 없음
 	CreatedAt time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
 	User      User       `json:"-" gorm:"foreignKey:UserID"`
 	Role      RoleMaster `json:"-" gorm:"foreignKey:RoleID"`
@@ -66,6 +69,8 @@ type UserWorkspaceRole struct {
 	Username      string      `json:"username" gorm:"column:username"`
 	WorkspaceName string      `json:"workspace_name" gorm:"column:workspace_name"`
 	RoleName      string      `json:"role_name" gorm:"column:role_name"`
+	StartsAt      *time.Time  `json:"starts_at,omitempty" gorm:"column:starts_at"`         // nil이면 즉시 유효
+	ExpiresAt     *time.Time  `json:"expires_at,omitempty" gorm:"column:expires_at;index"` // nil이면 만료 없음
 	CreatedAt     time.Time   `json:"created_at" gorm:"column:created_at;autoCreateTime"`
 	User          *User       `json:"user,omitempty" gorm:"foreignKey:UserID"`
 	Workspace     *Workspace  `json:"workspace,omitempty" gorm:"foreignKey:WorkspaceID;references:ID"`
@@ -103,6 +108,56 @@ type RoleMasterMapping struct {
 	RoleMasterCspRoleMappings []RoleMasterCspRoleMapping `json:"role_master_csp_role_mappings" gorm:"-"`
 }
 
+// RoleGrantPeriod 역할 할당 유효 기간 (nil이면 제한 없음)
+type RoleGrantPeriod struct {
+	StartsAt  *time.Time
+	ExpiresAt *time.Time
+}
+
+// Validate 만료 시각은 현재 및 시작 시각 이후여야 한다
+func (p RoleGrantPeriod) Validate(now time.Time) error {
+	if p.ExpiresAt == nil {
+		return nil
+	}
+	if !p.ExpiresAt.After(now) {
+		return ErrRoleGrantAlreadyExpired
+	}
+	if p.StartsAt != nil && !p.ExpiresAt.After(*p.StartsAt) {
+		return ErrRoleGrantInvalidPeriod
+	}
+	return nil
+}
+
+// UTC 저장/비교 기준을 맞추기 위해 UTC로 변환
+func (p RoleGrantPeriod) UTC() RoleGrantPeriod {
+	var out RoleGrantPeriod
+	if p.StartsAt != nil {
+		t := p.StartsAt.UTC()
+		out.StartsAt = &t
+	}
+	if p.ExpiresAt != nil {
+		t := p.ExpiresAt.UTC()
+		out.ExpiresAt = &t
+	}
+	return out
+}
+
+var (
+	ErrRoleGrantAlreadyExpired = errors.New("expires_at must be in the future")
+	ErrRoleGrantInvalidPeriod  = errors.New("expires_at must be after starts_at")
+)
+
+// ExpiredRoleGrant 만료되어 회수 대상인 역할 할당
+type ExpiredRoleGrant struct {
+	RoleType    constants.IAMRoleType `json:"role_type"`
+	UserID      uint                  `json:"user_id,omitempty"`
+	GroupID     uint                  `json:"group_id,omitempty"`
+	WorkspaceID uint                  `json:"workspace_id,omitempty"`
+	RoleID      uint                  `json:"role_id"`
+	RoleName    string                `json:"role_name"`
+	KcUserID    string                `json:"-"`
+}
+
 // EffectiveWorkspaceRole 사용자의 유효 워크스페이스 역할 응답 (직접 할당 + 그룹 상속 통합)
 type EffectiveWorkspaceRole struct {
 	WorkspaceID   uint   `json:"workspace_id"`
@@ -110,3 +165,39 @@ type EffectiveWorkspaceRole struct {
 	RoleID        uint   `json:"role_id"`
 	RoleName      string `json:"role_name"`
 }
+
+// RoleRef 역할 ID/이름
+type RoleRef struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// RoleEffectiveGrant 역할의 유효 부여 항목 (메뉴 ID 또는 MciamPermission ID). 직접 부여 또는 상위 역할에서 상속
+type RoleEffectiveGrant struct {
+	ID             string `json:"id"`
+	SourceRoleID   uint   `json:"sourceRoleId"`
+	SourceRoleName string `json:"sourceRoleName"`
+	Inherited      bool   `json:"inherited"`
+}
+
+// RoleEffectiveCspRole 역할의 유효 CSP 역할 매핑
+type RoleEffectiveCspRole struct {
+	CspRoleID      uint   `json:"cspRoleId"`
+	Name           string `json:"name"`
+	CspType        string `json:"cspType"`
+	AuthMethod     string `json:"authMethod"`
+	SourceRoleID   uint   `json:"sourceRoleId"`
+	SourceRoleName string `json:"sourceRoleName"`
+	Inherited      bool   `json:"inherited"`
+}
+
+// RoleEffectiveGrantsResponse 역할의 유효 부여 항목 (자신 + 상위 역할 상속). 같은 항목은 가까운 역할 기준으로 표시
+type RoleEffectiveGrantsResponse struct {
+	RoleID               uint                   `json:"roleId"`
+	RoleName             string                 `json:"roleName"`
+	Ancestors            []RoleRef              `json:"ancestors"` // 가까운 순
+	Menus                []RoleEffectiveGrant   `json:"menus"`
+	PlatformPermissions  []RoleEffectiveGrant   `json:"platformPermissions"`
+	WorkspacePermissions []RoleEffectiveGrant   `json:"workspacePermissions"`
+	CspRoles             []RoleEffectiveCspRole `json:"cspRoles"`
+}
@@ -0,0 +1,119 @@
+package model
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// ScimBasePath SCIM 2.0 프로비저닝 API 기본 경로 (MC_IAM_MANAGER_SCIM_TOKEN으로 별도 보호)
+const ScimBasePath = "/scim/v2"
+
+// ScimActor SCIM 요청의 감사 로그 행위자
+const ScimActor = "system:scim"
+
+// SCIM 2.0 스키마 URN (RFC 7643, RFC 7644)
+const (
+	ScimSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	ScimSchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ScimSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	ScimSchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	ScimSchemaSchema                = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+	ScimSchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	ScimSchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ScimSchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// ScimUser SCIM User 리소스 (id = Keycloak 사용자 ID)
+// 이름/이메일/활성 상태는 Keycloak, 사용자명과 상태는 mcmp_users에 반영한다. externalId는 저장하지 않는다.
+type ScimUser struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	ExternalID  string       `json:"externalId,omitempty"`
+	UserName    string       `json:"userName"`
+	Name        *ScimName    `json:"name,omitempty"`
+	DisplayName string       `json:"displayName,omitempty"`
+	Emails      []ScimEmail  `json:"emails,omitempty"`
+	Active      *bool        `json:"active,omitempty"`
+	Groups      []ScimMember `json:"groups,omitempty"` // 읽기 전용 (소속 조직)
+	Meta        *ScimMeta    `json:"meta,omitempty"`
+}
+
+// ScimName SCIM User name 속성
+type ScimName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// ScimEmail SCIM User emails 속성 값 (primary 또는 첫 번째 값만 Keycloak 이메일로 사용)
+type ScimEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// ScimGroup SCIM Group 리소스 (id = 조직 ID, members = 소속 사용자)
+type ScimGroup struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	ExternalID  string       `json:"externalId,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []ScimMember `json:"members,omitempty"`
+	Meta        *ScimMeta    `json:"meta,omitempty"`
+}
+
+// ScimMember 그룹 구성원 또는 사용자 소속 그룹 참조
+type ScimMember struct {
+	Value   string `json:"value"`
+	Ref     string `json:"$ref,omitempty"`
+	Display string `json:"display,omitempty"`
+}
+
+// ScimMeta SCIM 리소스 메타 정보
+type ScimMeta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location,omitempty"`
+}
+
+// ScimListResponse SCIM 목록 조회 응답
+type ScimListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+// ScimPatchRequest SCIM PATCH 요청
+type ScimPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []ScimPatchOperation `json:"Operations"`
+}
+
+// ScimPatchOperation SCIM PATCH 연산 (op: add/remove/replace, 대소문자 무관)
+type ScimPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// ScimError SCIM 오류 응답
+type ScimError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// NewScimError SCIM 오류 응답 생성
+func NewScimError(status int, scimType, detail string) *ScimError {
+	return &ScimError{
+		Schemas:  []string{ScimSchemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	}
+}
@@ -0,0 +1,129 @@
+package model
+
+import "time"
+
+// ServiceAccountApiKeyPrefix 서비스 계정 API 키 접두어 (Authorization: Bearer mciam_... 로 JWT와 구분)
+const ServiceAccountApiKeyPrefix = "mciam_"
+
+// ServiceAccountAuthType 서비스 계정 인증 방식
+type ServiceAccountAuthType string
+
+const (
+	// ServiceAccountAuthApiKey mc-iam-manager가 발급한 API 키로만 인증
+	ServiceAccountAuthApiKey ServiceAccountAuthType = "API_KEY"
+	// ServiceAccountAuthClientCredentials Keycloak client credentials 클라이언트로 access token 발급 (API 키도 발급 가능)
+	ServiceAccountAuthClientCredentials ServiceAccountAuthType = "CLIENT_CREDENTIALS"
+)
+
+// ServiceAccount 기계 클라이언트용 서비스 계정 (DB 테이블: mcmp_service_accounts)
+// 역할은 UserID의 mcmp_users 행에 사용자와 동일하게 할당되며, 인증 시 KcUserID가 kcUserId로 사용된다.
+type ServiceAccount struct {
+	ID           uint                   `json:"id" gorm:"primaryKey"`
+	Name         string                 `json:"name" gorm:"column:name;size:100;not null;uniqueIndex"`
+	Description  string                 `json:"description,omitempty" gorm:"column:description;size:1000"`
+	AuthType     ServiceAccountAuthType `json:"authType" gorm:"column:auth_type;size:30;not null"`
+	UserID       uint                   `json:"userId" gorm:"column:user_id;not null;uniqueIndex"`
+	KcUserID     string                 `json:"kcUserId" gorm:"column:kc_user_id;size:255;not null;index"`
+	KcClientID   string                 `json:"kcClientId,omitempty" gorm:"column:kc_client_id;size:255"`    // CLIENT_CREDENTIALS: Keycloak clientId
+	KcClientUUID string                 `json:"kcClientUuid,omitempty" gorm:"column:kc_client_uuid;size:64"` // CLIENT_CREDENTIALS: Keycloak client 내부 ID
+	Enabled      bool                   `json:"enabled" gorm:"column:enabled;not null;default:true"`
+	CreatedBy    string                 `json:"createdBy,omitempty" gorm:"column:created_by;size:255"`
+	CreatedAt    time.Time              `json:"createdAt" gorm:"column:created_at"`
+	UpdatedAt    time.Time              `json:"updatedAt" gorm:"column:updated_at"`
+}
+
+// TableName ServiceAccount의 테이블 이름 지정
+func (ServiceAccount) TableName() string {
+	return "mcmp_service_accounts"
+}
+
+// ServiceAccountApiKey 서비스 계정 API 키 (DB 테이블: mcmp_service_account_api_keys)
+// 키 원문은 발급 시 한 번만 반환하고 SHA-256 해시만 저장한다. Prefix로 키를 조회한다.
+type ServiceAccountApiKey struct {
+	ID               uint       `json:"id" gorm:"primaryKey"`
+	ServiceAccountID uint       `json:"serviceAccountId" gorm:"column:service_account_id;not null;index"`
+	Name             string     `json:"name" gorm:"column:name;size:100"`
+	Prefix           string     `json:"prefix" gorm:"column:prefix;size:16;not null;uniqueIndex"`
+	KeyHash          string     `json:"-" gorm:"column:key_hash;size:64;not null"`
+	Scopes           []string   `json:"scopes,omitempty" gorm:"column:scopes;type:jsonb;serializer:json"` // 허용 플랫폼 역할 (비어 있으면 서비스 계정 역할 전체)
+	ExpiresAt        *time.Time `json:"expiresAt,omitempty" gorm:"column:expires_at"`
+	LastUsedAt       *time.Time `json:"lastUsedAt,omitempty" gorm:"column:last_used_at"`
+	LastUsedIP       string     `json:"lastUsedIp,omitempty" gorm:"column:last_used_ip;size:64"`
+	RevokedAt        *time.Time `json:"revokedAt,omitempty" gorm:"column:revoked_at"`
+	CreatedBy        string     `json:"createdBy,omitempty" gorm:"column:created_by;size:255"`
+	CreatedAt        time.Time  `json:"createdAt" gorm:"column:created_at"`
+}
+
+// TableName ServiceAccountApiKey의 테이블 이름 지정
+func (ServiceAccountApiKey) TableName() string {
+	return "mcmp_service_account_api_keys"
+}
+
+// CreateServiceAccountRequest 서비스 계정 생성 요청
+type CreateServiceAccountRequest struct {
+	Name        string                 `json:"name" validate:"required"` // 소문자, 숫자, '-' (2~63자)
+	Description string                 `json:"description,omitempty"`
+	AuthType    ServiceAccountAuthType `json:"authType,omitempty"` // 기본값 API_KEY
+}
+
+// UpdateServiceAccountRequest 서비스 계정 수정 요청 (nil 필드는 변경하지 않음)
+type UpdateServiceAccountRequest struct {
+	Description *string `json:"description,omitempty"`
+	Enabled     *bool   `json:"enabled,omitempty"`
+}
+
+// ServiceAccountClientCredentials Keycloak client credentials 발급 정보 (생성/재발급 시 한 번만 반환)
+type ServiceAccountClientCredentials struct {
+	ClientID      string `json:"clientId"`
+	ClientSecret  string `json:"clientSecret"`
+	TokenEndpoint string `json:"tokenEndpoint"`
+}
+
+// ServiceAccountResponse 서비스 계정 생성 응답
+type ServiceAccountResponse struct {
+	ServiceAccount
+	Credentials *ServiceAccountClientCredentials `json:"credentials,omitempty"`
+}
+
+// KeycloakServiceAccountClient Keycloak에 생성된 client credentials 클라이언트
+type KeycloakServiceAccountClient struct {
+	ID       string // client 내부 ID
+	ClientID string
+	Secret   string
+	UserID   string // 서비스 계정 사용자 ID (access token의 sub)
+	Username string
+}
+
+// ServiceAccountRoleRequest 서비스 계정 역할 할당 요청 (WorkspaceID는 워크스페이스 역할에만 사용)
+type ServiceAccountRoleRequest struct {
+	RoleID      uint `json:"roleId" validate:"required"`
+	WorkspaceID uint `json:"workspaceId,omitempty"`
+}
+
+// ServiceAccountRolesResponse 서비스 계정의 유효 역할
+type ServiceAccountRolesResponse struct {
+	PlatformRoles  []RoleMaster             `json:"platformRoles"`
+	WorkspaceRoles []EffectiveWorkspaceRole `json:"workspaceRoles"`
+}
+
+// CreateApiKeyRequest API 키 발급 요청
+type CreateApiKeyRequest struct {
+	Name      string     `json:"name,omitempty"`
+	Scopes    []string   `json:"scopes,omitempty"`    // 허용 플랫폼 역할 이름 (비어 있으면 서비스 계정 역할 전체)
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"` // 비어 있으면 MC_IAM_MANAGER_API_KEY_DEFAULT_TTL_DAYS 적용
+}
+
+// CreateApiKeyResponse API 키 발급 응답 (Key는 이 응답에서만 확인 가능)
+type CreateApiKeyResponse struct {
+	ServiceAccountApiKey
+	Key string `json:"key"`
+}
+
+// ServiceAccountPrincipal API 키 인증 결과
+type ServiceAccountPrincipal struct {
+	ServiceAccountID uint
+	Name             string
+	KcUserID         string
+	ApiKeyID         uint
+	PlatformRoles    []string
+}
@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+// SignupStatus 셀프 가입 신청 상태
+type SignupStatus string
+
+const (
+	SignupStatusPendingVerification SignupStatus = "PENDING_VERIFICATION" // 이메일 인증 대기
+	SignupStatusPendingApproval     SignupStatus = "PENDING_APPROVAL"     // 관리자 승인 대기
+	SignupStatusApproved            SignupStatus = "APPROVED"
+	SignupStatusRejected            SignupStatus = "REJECTED"
+)
+
+// Signup 셀프 가입 신청 모델 (DB 테이블: mcmp_signups)
+// Keycloak 사용자는 승인 전까지 비활성 상태이며, 이메일 인증이 필요한 경우 인증 완료 후 승인 대기로 전환된다.
+type Signup struct {
+	ID                   uint         `json:"id" gorm:"primaryKey;column:id"`
+	KcUserID             string       `json:"kcUserId" gorm:"column:kc_user_id;size:255;not null;uniqueIndex"`
+	Username             string       `json:"username" gorm:"column:username;size:255;not null"`
+	Email                string       `json:"email" gorm:"column:email;size:255;not null;index"`
+	EmailDomain          string       `json:"emailDomain" gorm:"column:email_domain;size:255;index"`
+	FirstName            string       `json:"firstName" gorm:"column:first_name;size:255"`
+	LastName             string       `json:"lastName" gorm:"column:last_name;size:255"`
+	Organization         string       `json:"organization,omitempty" gorm:"column:organization;size:255"` // 가입 시 입력한 소속 (자유 입력)
+	Status               SignupStatus `json:"status" gorm:"column:status;size:50;not null;default:'PENDING_APPROVAL';index"`
+	AutoApproved         bool         `json:"autoApproved" gorm:"column:auto_approved;not null;default:false"`
+	AssignedPlatformRole string       `json:"assignedPlatformRole,omitempty" gorm:"column:assigned_platform_role;size:255"`
+	AssignedOrgIDs       []uint       `json:"assignedOrganizationIds,omitempty" gorm:"column:assigned_org_ids;type:text;serializer:json"`
+	Reason               string       `json:"reason,omitempty" gorm:"column:reason;type:text"` // 승인 메모 또는 거절 사유
+	ReviewerUserID       *uint        `json:"reviewerUserId,omitempty" gorm:"column:reviewer_user_id"`
+	EmailVerifiedAt      *time.Time   `json:"emailVerifiedAt,omitempty" gorm:"column:email_verified_at"`
+	DecidedAt            *time.Time   `json:"decidedAt,omitempty" gorm:"column:decided_at"`
+	CompanyID            *uint        `json:"company_id,omitempty" gorm:"column:company_id;index"` // 멀티테넌트 모드의 소속 회사
+	CreatedAt            time.Time    `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt            time.Time    `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName Signup의 테이블 이름 지정
+func (Signup) TableName() string {
+	return "mcmp_signups"
+}
+
+// SignupFilterRequest 가입 신청 목록 필터
+type SignupFilterRequest struct {
+	Status    string `query:"status"`
+	Email     string `query:"email"`  // 부분 일치
+	Domain    string `query:"domain"` // 이메일 도메인 일치
+	CompanyID *uint  `query:"-"`
+}
+
+// SignupApproveRequest 가입 승인 요청 (초기 플랫폼 역할 및 조직 할당)
+type SignupApproveRequest struct {
+	PlatformRole    string `json:"platformRole,omitempty"` // 생략 시 MC_IAM_MANAGER_SIGNUP_DEFAULT_PLATFORM_ROLE
+	OrganizationIDs []uint `json:"organizationIds,omitempty"`
+	Comment         string `json:"comment,omitempty"`
+}
+
+// SignupRejectRequest 가입 거절 요청
+type SignupRejectRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
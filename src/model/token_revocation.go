@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// TokenRevocation 로컬 access token 폐기 목록 (DB 테이블: mcmp_token_revocations)
+// TokenID(jti)가 있으면 해당 토큰 하나를, SessionID(sid)가 있으면 그 세션에서 IssuedBefore 이전 발급된 토큰을,
+// 둘 다 없으면 KcUserID 사용자의 IssuedBefore 이전 발급 토큰 전체를 무효화한다.
+// ExpiresAt 이후에는 대상 토큰이 모두 만료되었으므로 정리 대상이다.
+type TokenRevocation struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	TokenID      string     `json:"tokenId,omitempty" gorm:"column:token_id;size:64;index"`
+	SessionID    string     `json:"sessionId,omitempty" gorm:"column:session_id;size:64;index"`
+	KcUserID     string     `json:"kcUserId" gorm:"column:kc_user_id;size:255;not null;index"`
+	IssuedBefore *time.Time `json:"issuedBefore,omitempty" gorm:"column:issued_before"`
+	Reason       string     `json:"reason" gorm:"column:reason;size:255"`
+	RevokedBy    string     `json:"revokedBy,omitempty" gorm:"column:revoked_by;size:255"`
+	ExpiresAt    time.Time  `json:"expiresAt" gorm:"column:expires_at;not null;index"`
+	CreatedAt    time.Time  `json:"createdAt" gorm:"column:created_at"`
+}
+
+// TableName TokenRevocation의 테이블 이름 지정
+func (TokenRevocation) TableName() string {
+	return "mcmp_token_revocations"
+}
+
+// UserSession Keycloak 사용자 세션
+type UserSession struct {
+	ID         string            `json:"id"`
+	IPAddress  string            `json:"ipAddress,omitempty"`
+	Start      *time.Time        `json:"start,omitempty"`
+	LastAccess *time.Time        `json:"lastAccess,omitempty"`
+	Clients    map[string]string `json:"clients,omitempty"` // client UUID → clientId
+}
+
+// RevokeUserSessionsResponse 세션 종료/토큰 폐기 결과
+type RevokeUserSessionsResponse struct {
+	KcUserID     string    `json:"kcUserId"`
+	SessionID    string    `json:"sessionId,omitempty"`
+	IssuedBefore time.Time `json:"issuedBefore"` // 이 시각 이전에 발급된 access token은 거부된다
+}
@@ -0,0 +1,58 @@
+package model
+
+// UserImportRowStatus 일괄 가져오기 행 처리 결과
+type UserImportRowStatus string
+
+const (
+	UserImportRowCreated   UserImportRowStatus = "CREATED"   // 사용자 생성 (및 할당)
+	UserImportRowUpdated   UserImportRowStatus = "UPDATED"   // 기존 사용자에 누락된 할당 추가
+	UserImportRowUnchanged UserImportRowStatus = "UNCHANGED" // 이미 모두 반영됨 (재실행 시)
+	UserImportRowValid     UserImportRowStatus = "VALID"     // 검증 전용 모드에서 통과
+	UserImportRowFailed    UserImportRowStatus = "FAILED"
+)
+
+// UserImportRow 일괄 가져오기/내보내기 사용자 행
+// CSV 컬럼: username,email,first_name,last_name,organization_codes,platform_roles,workspace_roles
+// 여러 값은 ';'로 구분하고 워크스페이스 역할은 "workspace:role" 형식이다.
+type UserImportRow struct {
+	Username          string                    `json:"username"`
+	Email             string                    `json:"email"`
+	FirstName         string                    `json:"firstName,omitempty"`
+	LastName          string                    `json:"lastName,omitempty"`
+	OrganizationCodes []string                  `json:"organizationCodes,omitempty"`
+	PlatformRoles     []string                  `json:"platformRoles,omitempty"`
+	WorkspaceRoles    []UserImportWorkspaceRole `json:"workspaceRoles,omitempty"`
+}
+
+// UserImportWorkspaceRole 워크스페이스 이름과 워크스페이스 역할 이름 쌍
+type UserImportWorkspaceRole struct {
+	Workspace string `json:"workspace"`
+	Role      string `json:"role"`
+}
+
+// UserImportRequest JSON 일괄 가져오기 요청 (행 배열만 보내도 된다)
+type UserImportRequest struct {
+	Users []UserImportRow `json:"users"`
+}
+
+// UserImportResult 일괄 가져오기 결과. 행 단위로 처리하며 실패한 행이 있어도 나머지 행은 반영된다.
+type UserImportResult struct {
+	DryRun    bool                  `json:"dryRun"`
+	Total     int                   `json:"total"`
+	Created   int                   `json:"created"`
+	Updated   int                   `json:"updated"`
+	Unchanged int                   `json:"unchanged"`
+	Valid     int                   `json:"valid"`
+	Failed    int                   `json:"failed"`
+	Rows      []UserImportRowResult `json:"rows"`
+}
+
+// UserImportRowResult 행별 처리 결과
+type UserImportRowResult struct {
+	Row      int                 `json:"row"` // 1부터 시작 (CSV는 헤더 제외)
+	Username string              `json:"username"`
+	Status   UserImportRowStatus `json:"status"`
+	UserID   uint                `json:"userId,omitempty"`
+	Changes  []string            `json:"changes,omitempty"` // 반영(또는 검증 전용 모드에서 반영 예정)된 변경
+	Errors   []string            `json:"errors,omitempty"`
+}
@@ -0,0 +1,57 @@
+package model
+
+import "time"
+
+// Keycloak ↔ DB 사용자 불일치 유형
+const (
+	UserDriftMissingInDB        = "missing_in_db"            // Keycloak 활성 사용자가 mcmp_users에 없음
+	UserDriftMissingInKeycloak  = "missing_in_keycloak"      // DB 사용자가 Keycloak에 없음 (Keycloak에서 직접 삭제)
+	UserDriftDisabledInKeycloak = "disabled_in_keycloak"     // Keycloak에서 비활성화되었으나 DB는 ACTIVE
+	UserDriftEnabledInKeycloak  = "enabled_in_keycloak"      // DB는 INACTIVE/WITHDRAWN이나 Keycloak은 활성
+	UserDriftUsernameMismatch   = "username_mismatch"        // 사용자명 불일치
+	UserDriftRoleMissingInKC    = "role_missing_in_keycloak" // DB 플랫폼 역할에 대응하는 realm role이 없음
+	UserDriftRoleMissingInDB    = "role_missing_in_db"       // 플랫폼 역할 realm role이 있으나 DB 할당이 없음
+)
+
+// 불일치 처리 결과
+const (
+	UserDriftActionReported = "reported" // 보고만 함 (점검 전용 실행 또는 자동 수정 대상 아님)
+	UserDriftActionFixed    = "fixed"
+	UserDriftActionFailed   = "failed"
+)
+
+// UserReconcileDrift Keycloak ↔ DB 사용자 불일치 항목
+type UserReconcileDrift struct {
+	Type     string `json:"type"`
+	Realm    string `json:"realm,omitempty"`
+	KcUserID string `json:"kcUserId"`
+	UserID   uint   `json:"userId,omitempty"`
+	Username string `json:"username"`
+	Role     string `json:"role,omitempty"`
+	Detail   string `json:"detail"`
+	Action   string `json:"action"`
+	Error    string `json:"error,omitempty"`
+}
+
+// UserReconcileRun 사용자 정합성 점검 1회 실행 결과
+type UserReconcileRun struct {
+	StartedAt     time.Time            `json:"startedAt"`
+	FinishedAt    time.Time            `json:"finishedAt"`
+	Fix           bool                 `json:"fix"`
+	Success       bool                 `json:"success"`
+	Error         string               `json:"error,omitempty"`
+	KeycloakUsers int                  `json:"keycloakUsers"`
+	DbUsers       int                  `json:"dbUsers"`
+	Fixed         int                  `json:"fixed"`
+	Failed        int                  `json:"failed"`
+	Drifts        []UserReconcileDrift `json:"drifts"`
+}
+
+// UserReconcileStatusResponse GET /api/setup/users/reconcile-status response
+type UserReconcileStatusResponse struct {
+	Enabled         bool              `json:"enabled"`
+	IntervalSeconds int               `json:"intervalSeconds"`
+	Fix             bool              `json:"fix"`
+	Running         bool              `json:"running"`
+	LastRun         *UserReconcileRun `json:"lastRun,omitempty"`
+}
@@ -9,6 +9,7 @@ type Workspace struct {
 	ID          uint       `json:"id" gorm:"primaryKey;column:id"`
 	Name        string     `json:"name" gorm:"column:name;size:255;not null"`
 	Description string     `json:"description" gorm:"column:description;size:1000"`
+	CompanyID   *uint      `json:"company_id,omitempty" gorm:"column:company_id;index"` // 멀티테넌트 모드의 소유 회사
 	CreatedAt   time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt   time.Time  `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
 	Projects    []*Project `json:"projects,omitempty" gorm:"many2many:mcmp_workspace_projects;"`
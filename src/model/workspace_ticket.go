@@ -6,15 +6,53 @@ import (
 	"gorm.io/datatypes"
 )
 
-// WorkspaceTicket 워크스페이스 티켓 정보를 저장하는 모델
+// WorkspaceTicket 워크스페이스 티켓 발급 이력 (DB 테이블: mcmp_workspace_tickets)
+// 티켓 자체는 mc-iam-manager가 서명한 JWT이며, 폐기 여부 확인을 위해 jti(TicketID)로 관리한다.
 type WorkspaceTicket struct {
-	ID          uint           `gorm:"primaryKey"`
-	KcUserID    string         `gorm:"not null"`
-	WorkspaceID uint           `gorm:"not null"`
-	Ticket      string         `gorm:"not null"`
-	Permissions datatypes.JSON `gorm:"type:jsonb;not null"`
-	ExpiresAt   time.Time      `gorm:"not null"`
-	LastUsedAt  time.Time      `gorm:"not null"`
-	CreatedAt   time.Time      `gorm:"not null"`
-	UpdatedAt   time.Time      `gorm:"not null"`
+	ID            uint           `json:"id" gorm:"primaryKey"`
+	TicketID      string         `json:"ticketId" gorm:"column:ticket_id;size:64;uniqueIndex"` // JWT jti
+	KcUserID      string         `json:"kcUserId" gorm:"column:kc_user_id;not null;index:idx_workspace_ticket_user"`
+	UserID        uint           `json:"userId" gorm:"column:user_id;index"`
+	WorkspaceID   uint           `json:"workspaceId" gorm:"column:workspace_id;not null;index:idx_workspace_ticket_user"`
+	KeyID         string         `json:"keyId" gorm:"column:key_id;size:64"`                             // 서명 키 kid (현재 서명 키와 다르면 재사용하지 않음)
+	Ticket        string         `json:"-" gorm:"column:ticket;type:text;not null;serializer:encrypted"` // 발급한 티켓 (저장 시 암호화)
+	Permissions   datatypes.JSON `json:"permissions" gorm:"column:permissions;type:jsonb;not null" swaggertype:"object"`
+	ExpiresAt     time.Time      `json:"expiresAt" gorm:"column:expires_at;not null"`
+	RevokedAt     *time.Time     `json:"revokedAt,omitempty" gorm:"column:revoked_at"`
+	RevokedReason string         `json:"revokedReason,omitempty" gorm:"column:revoked_reason;size:255"`
+	LastUsedAt    time.Time      `json:"lastUsedAt" gorm:"column:last_used_at;not null"`
+	CreatedAt     time.Time      `json:"createdAt" gorm:"column:created_at;not null"`
+	UpdatedAt     time.Time      `json:"updatedAt" gorm:"column:updated_at;not null"`
+}
+
+// TableName WorkspaceTicket의 테이블 이름 지정
+func (WorkspaceTicket) TableName() string {
+	return "mcmp_workspace_tickets"
+}
+
+// WorkspaceTicketResponse 워크스페이스 티켓 발급/갱신 응답
+type WorkspaceTicketResponse struct {
+	Ticket      string    `json:"ticket"`
+	TokenType   string    `json:"token_type"`
+	ExpiresIn   int       `json:"expires_in"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	WorkspaceID string    `json:"workspace_id"`
+	Roles       []string  `json:"roles"`
+	Permissions []string  `json:"permissions"`
+}
+
+// WorkspaceTicketTokenRequest 티켓 갱신/폐기/검증 요청
+type WorkspaceTicketTokenRequest struct {
+	Ticket string `json:"ticket" validate:"required"`
+}
+
+// WorkspaceTicketIntrospectResponse 티켓 검증 결과 (downstream 프레임워크용)
+type WorkspaceTicketIntrospectResponse struct {
+	Active      bool     `json:"active"`
+	Subject     string   `json:"sub,omitempty"`
+	WorkspaceID string   `json:"workspace_id,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	ExpiresAt   int64    `json:"exp,omitempty"`
+	TicketID    string   `json:"jti,omitempty"`
 }
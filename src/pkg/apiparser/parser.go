@@ -69,3 +69,38 @@ func (s *SwaggerSpec) GetSourceType() SourceType {
 	}
 	return SourceTypeSwagger // default
 }
+
+// GetServerURLs returns the base URLs declared by the spec.
+// Swagger 2.0: scheme://host + basePath (basePath only when host is omitted).
+// OpenAPI 3.0: servers[].url with variables replaced by their defaults.
+func (s *SwaggerSpec) GetServerURLs() []string {
+	var urls []string
+	if s.Swagger != "" {
+		if s.Host == "" {
+			if s.BasePath != "" {
+				urls = append(urls, s.BasePath)
+			}
+			return urls
+		}
+		schemes := s.Schemes
+		if len(schemes) == 0 {
+			schemes = []string{"http"}
+		}
+		for _, scheme := range schemes {
+			urls = append(urls, scheme+"://"+s.Host+s.BasePath)
+		}
+		return urls
+	}
+
+	for _, server := range s.Servers {
+		if server.URL == "" {
+			continue
+		}
+		url := server.URL
+		for name, variable := range server.Variables {
+			url = strings.ReplaceAll(url, "{"+name+"}", variable.Default)
+		}
+		urls = append(urls, url)
+	}
+	return urls
+}
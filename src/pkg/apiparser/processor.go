@@ -51,6 +51,7 @@ func (p *Processor) ProcessFramework(name, version, repository, sourceType, sour
 		return result
 	}
 
+	result.Servers = spec.GetServerURLs()
 	result.Actions = actions
 	result.ActionCount = len(actions)
 
@@ -79,6 +80,7 @@ func (p *Processor) ProcessFrameworkFromBytes(name, version, repository string,
 		return result
 	}
 
+	result.Servers = spec.GetServerURLs()
 	result.Actions = actions
 	result.ActionCount = len(actions)
 
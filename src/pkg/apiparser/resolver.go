@@ -0,0 +1,146 @@
+package apiparser
+
+import (
+	"strings"
+)
+
+// maxRefDepth limits chained $ref lookups (e.g. a parameter that refers to another parameter)
+const maxRefDepth = 16
+
+// refResolver resolves local $ref pointers against definitions/parameters (Swagger 2.0)
+// and components (OpenAPI 3.0). External references are left as-is.
+type refResolver struct {
+	spec *SwaggerSpec
+}
+
+func newRefResolver(spec *SwaggerSpec) *refResolver {
+	return &refResolver{spec: spec}
+}
+
+// refName returns the component name if ref points into one of the given prefixes
+func refName(ref string, prefixes ...string) (string, bool) {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(ref, prefix) {
+			return unescapeJSONPointer(strings.TrimPrefix(ref, prefix)), true
+		}
+	}
+	return "", false
+}
+
+// unescapeJSONPointer decodes a JSON pointer token (RFC 6901)
+func unescapeJSONPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
+
+// parameter follows a parameter $ref and resolves its schemas
+func (r *refResolver) parameter(p Parameter) (Parameter, bool) {
+	for depth := 0; p.Ref != ""; depth++ {
+		if depth >= maxRefDepth {
+			return p, false
+		}
+		name, ok := refName(p.Ref, "#/parameters/", "#/components/parameters/")
+		if !ok {
+			return p, false
+		}
+		target, found := r.spec.Parameters[name]
+		if !found {
+			target, found = r.spec.Components.Parameters[name]
+		}
+		if !found {
+			return p, false
+		}
+		p = target
+	}
+	p.Schema = r.schema(p.Schema)
+	p.Items = r.schema(p.Items)
+	return p, true
+}
+
+// requestBody follows a request body $ref and resolves its schemas
+func (r *refResolver) requestBody(b *RequestBody) (*RequestBody, bool) {
+	if b == nil {
+		return nil, true
+	}
+	body := *b
+	for depth := 0; body.Ref != ""; depth++ {
+		if depth >= maxRefDepth {
+			return &body, false
+		}
+		name, ok := refName(body.Ref, "#/components/requestBodies/")
+		if !ok {
+			return &body, false
+		}
+		target, found := r.spec.Components.RequestBodies[name]
+		if !found {
+			return &body, false
+		}
+		body = target
+	}
+	content := make(map[string]MediaType, len(body.Content))
+	for contentType, media := range body.Content {
+		content[contentType] = MediaType{Schema: r.schema(media.Schema)}
+	}
+	body.Content = content
+	return &body, true
+}
+
+// schema returns a copy of s with every local schema $ref inlined.
+// Recursive references are kept as $ref at the point where they repeat.
+func (r *refResolver) schema(s Schema) Schema {
+	if s == nil {
+		return nil
+	}
+	resolved, _ := r.resolveValue(map[string]interface{}(s), map[string]bool{}).(map[string]interface{})
+	return Schema(resolved)
+}
+
+func (r *refResolver) resolveValue(v interface{}, visiting map[string]bool) interface{} {
+	switch node := v.(type) {
+	case map[string]interface{}:
+		if ref, ok := node["$ref"].(string); ok {
+			target, found := r.lookupSchema(ref)
+			if !found || visiting[ref] {
+				return copyMap(node)
+			}
+			visiting[ref] = true
+			out := r.resolveValue(map[string]interface{}(target), visiting)
+			delete(visiting, ref)
+			return out
+		}
+		out := make(map[string]interface{}, len(node))
+		for k, child := range node {
+			out[k] = r.resolveValue(child, visiting)
+		}
+		return out
+	case Schema:
+		return r.resolveValue(map[string]interface{}(node), visiting)
+	case []interface{}:
+		out := make([]interface{}, len(node))
+		for i, child := range node {
+			out[i] = r.resolveValue(child, visiting)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func (r *refResolver) lookupSchema(ref string) (Schema, bool) {
+	if name, ok := refName(ref, "#/definitions/"); ok {
+		s, found := r.spec.Definitions[name]
+		return s, found
+	}
+	if name, ok := refName(ref, "#/components/schemas/"); ok {
+		s, found := r.spec.Components.Schemas[name]
+		return s, found
+	}
+	return nil, false
+}
+
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
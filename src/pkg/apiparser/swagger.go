@@ -7,51 +7,58 @@ func (s *SwaggerSpec) GetOperations() []OperationInfo {
 	for path, pathItem := range s.Paths {
 		if pathItem.Get != nil && pathItem.Get.OperationID != "" {
 			operations = append(operations, OperationInfo{
-				Path:      path,
-				Method:    "get",
-				Operation: pathItem.Get,
+				Path:       path,
+				Method:     "get",
+				Operation:  pathItem.Get,
+				Parameters: pathItem.Parameters,
 			})
 		}
 		if pathItem.Post != nil && pathItem.Post.OperationID != "" {
 			operations = append(operations, OperationInfo{
-				Path:      path,
-				Method:    "post",
-				Operation: pathItem.Post,
+				Path:       path,
+				Method:     "post",
+				Operation:  pathItem.Post,
+				Parameters: pathItem.Parameters,
 			})
 		}
 		if pathItem.Put != nil && pathItem.Put.OperationID != "" {
 			operations = append(operations, OperationInfo{
-				Path:      path,
-				Method:    "put",
-				Operation: pathItem.Put,
+				Path:       path,
+				Method:     "put",
+				Operation:  pathItem.Put,
+				Parameters: pathItem.Parameters,
 			})
 		}
 		if pathItem.Delete != nil && pathItem.Delete.OperationID != "" {
 			operations = append(operations, OperationInfo{
-				Path:      path,
-				Method:    "delete",
-				Operation: pathItem.Delete,
+				Path:       path,
+				Method:     "delete",
+				Operation:  pathItem.Delete,
+				Parameters: pathItem.Parameters,
 			})
 		}
 		if pathItem.Patch != nil && pathItem.Patch.OperationID != "" {
 			operations = append(operations, OperationInfo{
-				Path:      path,
-				Method:    "patch",
-				Operation: pathItem.Patch,
+				Path:       path,
+				Method:     "patch",
+				Operation:  pathItem.Patch,
+				Parameters: pathItem.Parameters,
 			})
 		}
 		if pathItem.Options != nil && pathItem.Options.OperationID != "" {
 			operations = append(operations, OperationInfo{
-				Path:      path,
-				Method:    "options",
-				Operation: pathItem.Options,
+				Path:       path,
+				Method:     "options",
+				Operation:  pathItem.Options,
+				Parameters: pathItem.Parameters,
 			})
 		}
 		if pathItem.Head != nil && pathItem.Head.OperationID != "" {
 			operations = append(operations, OperationInfo{
-				Path:      path,
-				Method:    "head",
-				Operation: pathItem.Head,
+				Path:       path,
+				Method:     "head",
+				Operation:  pathItem.Head,
+				Parameters: pathItem.Parameters,
 			})
 		}
 	}
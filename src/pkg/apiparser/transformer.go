@@ -1,8 +1,15 @@
 package apiparser
 
+import (
+	"sort"
+)
+
+const defaultContentType = "application/json"
+
 // Transform converts a SwaggerSpec to a map of ServiceActions
 func Transform(spec *SwaggerSpec) (map[string]ServiceAction, error) {
 	actions := make(map[string]ServiceAction)
+	resolver := newRefResolver(spec)
 
 	operations := spec.GetOperations()
 
@@ -19,12 +26,119 @@ func Transform(spec *SwaggerSpec) (map[string]ServiceAction, error) {
 			description = op.Operation.Summary
 		}
 
+		params, body := transformParameters(spec, resolver, op)
+		if op.Operation.RequestBody != nil {
+			body = transformRequestBody(resolver, op.Operation.RequestBody)
+		}
+
+		security := spec.Security
+		if op.Operation.Security != nil {
+			security = *op.Operation.Security
+		}
+
 		actions[actionName] = ServiceAction{
 			Method:       op.Method,
 			ResourcePath: op.Path,
 			Description:  description,
+			Parameters:   params,
+			RequestBody:  body,
+			Security:     security,
 		}
 	}
 
 	return actions, nil
 }
+
+// transformParameters merges path-level and operation-level parameters (operation wins on the same name+in)
+// and splits off a Swagger 2.0 body parameter as the request body.
+func transformParameters(spec *SwaggerSpec, resolver *refResolver, op OperationInfo) ([]ActionParameter, *ActionRequestBody) {
+	var (
+		params []ActionParameter
+		body   *ActionRequestBody
+		index  = make(map[string]int)
+	)
+	for _, raw := range append(append([]Parameter{}, op.Parameters...), op.Operation.Parameters...) {
+		p, ok := resolver.parameter(raw)
+		if !ok || p.Name == "" || p.In == "" {
+			continue // Unresolvable or external $ref
+		}
+		if p.In == "body" {
+			body = &ActionRequestBody{
+				Required:    p.Required,
+				ContentType: firstOr(op.Operation.Consumes, firstOr(spec.Consumes, defaultContentType)),
+				Description: p.Description,
+				Schema:      p.Schema,
+			}
+			continue
+		}
+
+		param := ActionParameter{
+			Name:        p.Name,
+			In:          p.In,
+			Required:    p.Required || p.In == "path",
+			Description: p.Description,
+			Schema:      p.Schema,
+		}
+		if param.Schema == nil && p.Type != "" {
+			param.Schema = swagger2ParameterSchema(p)
+		}
+
+		key := p.In + ":" + p.Name
+		if i, exists := index[key]; exists {
+			params[i] = param
+			continue
+		}
+		index[key] = len(params)
+		params = append(params, param)
+	}
+	return params, body
+}
+
+// transformRequestBody picks the JSON media type when available, otherwise the first one by name
+func transformRequestBody(resolver *refResolver, raw *RequestBody) *ActionRequestBody {
+	b, ok := resolver.requestBody(raw)
+	if !ok {
+		return nil
+	}
+	body := &ActionRequestBody{
+		Required:    b.Required,
+		Description: b.Description,
+	}
+	if media, exists := b.Content[defaultContentType]; exists {
+		body.ContentType = defaultContentType
+		body.Schema = media.Schema
+		return body
+	}
+	contentTypes := make([]string, 0, len(b.Content))
+	for contentType := range b.Content {
+		contentTypes = append(contentTypes, contentType)
+	}
+	sort.Strings(contentTypes)
+	if len(contentTypes) > 0 {
+		body.ContentType = contentTypes[0]
+		body.Schema = b.Content[contentTypes[0]].Schema
+	}
+	return body
+}
+
+// swagger2ParameterSchema builds a schema from the inline type fields of a Swagger 2.0 parameter
+func swagger2ParameterSchema(p Parameter) Schema {
+	schema := Schema{"type": p.Type}
+	if p.Format != "" {
+		schema["format"] = p.Format
+	}
+	if len(p.Enum) > 0 {
+		schema["enum"] = p.Enum
+	}
+	if p.Items != nil {
+		schema["items"] = map[string]interface{}(p.Items)
+	}
+	return schema
+}
+
+func firstOr(values []string, fallback string) string {
+	if len(values) > 0 && values[0] != "" {
+		return values[0]
+	}
+	return fallback
+}
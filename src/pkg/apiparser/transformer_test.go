@@ -0,0 +1,184 @@
+package apiparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const swagger2Spec = `{
+  "swagger": "2.0",
+  "info": {"title": "tumblebug", "version": "0.10.0"},
+  "host": "localhost:1323",
+  "basePath": "/tumblebug",
+  "schemes": ["http"],
+  "security": [{"BasicAuth": []}],
+  "parameters": {
+    "nsId": {"name": "nsId", "in": "path", "required": true, "type": "string", "default": "default"}
+  },
+  "paths": {
+    "/ns/{nsId}/mci": {
+      "parameters": [{"$ref": "#/parameters/nsId"}],
+      "get": {
+        "operationId": "GetAllMci",
+        "summary": "List MCI",
+        "parameters": [
+          {"name": "option", "in": "query", "type": "string", "enum": ["id", "status"]},
+          {"name": "filterKey", "in": "query", "type": "array", "items": {"type": "string"}}
+        ]
+      },
+      "post": {
+        "operationId": "PostMci",
+        "consumes": ["application/json"],
+        "security": [],
+        "parameters": [
+          {"name": "mciReq", "in": "body", "required": true, "schema": {"$ref": "#/definitions/model.MciReq"}}
+        ]
+      }
+    }
+  },
+  "definitions": {
+    "model.MciReq": {
+      "type": "object",
+      "required": ["name"],
+      "properties": {
+        "name": {"type": "string"},
+        "vm": {"type": "array", "items": {"$ref": "#/definitions/model.VmReq"}}
+      }
+    },
+    "model.VmReq": {
+      "type": "object",
+      "properties": {"name": {"type": "string"}, "subGroup": {"$ref": "#/definitions/model.VmReq"}}
+    }
+  }
+}`
+
+const openAPI3Spec = `
+openapi: 3.0.1
+info:
+  title: mc-infra-manager
+  version: 1.0.0
+servers:
+  - url: "{scheme}://api.example.com/v1"
+    variables:
+      scheme:
+        default: https
+  - url: /v1
+paths:
+  /workspaces/{workspaceId}:
+    put:
+      operationId: updateWorkspace
+      parameters:
+        - $ref: "#/components/parameters/WorkspaceId"
+        - name: X-Request-Id
+          in: header
+          schema:
+            type: string
+      requestBody:
+        $ref: "#/components/requestBodies/WorkspaceBody"
+      security:
+        - BearerAuth: [workspace:write]
+components:
+  parameters:
+    WorkspaceId:
+      name: workspaceId
+      in: path
+      required: true
+      schema:
+        type: integer
+  requestBodies:
+    WorkspaceBody:
+      required: true
+      content:
+        application/xml:
+          schema:
+            type: string
+        application/json:
+          schema:
+            $ref: "#/components/schemas/Workspace"
+  schemas:
+    Workspace:
+      type: object
+      properties:
+        name:
+          type: string
+`
+
+func TestTransform_Swagger2ResolvesParametersAndBody(t *testing.T) {
+	spec, err := ParseBytes([]byte(swagger2Spec))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"http://localhost:1323/tumblebug"}, spec.GetServerURLs())
+
+	actions, err := Transform(spec)
+	require.NoError(t, err)
+
+	list := actions["GetAllMci"]
+	require.Len(t, list.Parameters, 3)
+	assert.Equal(t, ActionParameter{Name: "nsId", In: "path", Required: true, Schema: Schema{"type": "string"}}, list.Parameters[0])
+	assert.Equal(t, "query", list.Parameters[1].In)
+	assert.Equal(t, []interface{}{"id", "status"}, list.Parameters[1].Schema["enum"])
+	assert.Equal(t, map[string]interface{}{"type": "string"}, list.Parameters[2].Schema["items"])
+	assert.Nil(t, list.RequestBody)
+	assert.Equal(t, []SecurityRequirement{{"BasicAuth": []string{}}}, list.Security)
+
+	create := actions["PostMci"]
+	require.Len(t, create.Parameters, 1, "body 파라미터는 requestBody로 분리")
+	require.NotNil(t, create.RequestBody)
+	assert.True(t, create.RequestBody.Required)
+	assert.Equal(t, "application/json", create.RequestBody.ContentType)
+	assert.Equal(t, []interface{}{"name"}, create.RequestBody.Schema["required"])
+	vm := create.RequestBody.Schema["properties"].(map[string]interface{})["vm"].(map[string]interface{})
+	item := vm["items"].(map[string]interface{})
+	assert.Equal(t, "object", item["type"])
+	// 재귀 참조는 반복 지점에서 $ref로 남는다
+	subGroup := item["properties"].(map[string]interface{})["subGroup"].(map[string]interface{})
+	assert.Equal(t, "#/definitions/model.VmReq", subGroup["$ref"])
+	assert.NotNil(t, create.Security)
+	assert.Empty(t, create.Security, "빈 security는 전역 보안 해제")
+}
+
+func TestTransform_OpenAPI3ResolvesComponents(t *testing.T) {
+	spec, err := ParseBytes([]byte(openAPI3Spec))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://api.example.com/v1", "/v1"}, spec.GetServerURLs())
+
+	actions, err := Transform(spec)
+	require.NoError(t, err)
+
+	update := actions["updateWorkspace"]
+	require.Len(t, update.Parameters, 2)
+	assert.Equal(t, ActionParameter{Name: "workspaceId", In: "path", Required: true, Schema: Schema{"type": "integer"}}, update.Parameters[0])
+	assert.Equal(t, "header", update.Parameters[1].In)
+	assert.False(t, update.Parameters[1].Required)
+
+	require.NotNil(t, update.RequestBody)
+	assert.True(t, update.RequestBody.Required)
+	assert.Equal(t, "application/json", update.RequestBody.ContentType)
+	assert.Equal(t, "object", update.RequestBody.Schema["type"])
+	assert.Equal(t, []SecurityRequirement{{"BearerAuth": []string{"workspace:write"}}}, update.Security)
+}
+
+func TestTransform_OperationParameterOverridesPathLevel(t *testing.T) {
+	spec := &SwaggerSpec{
+		OpenAPI: "3.0.0",
+		Paths: map[string]PathItem{
+			"/items/{id}": {
+				Parameters: []Parameter{{Name: "id", In: "path", Description: "shared"}},
+				Get: &Operation{
+					OperationID: "getItem",
+					Parameters: []Parameter{
+						{Name: "id", In: "path", Description: "override", Schema: Schema{"type": "string"}},
+						{Ref: "#/components/parameters/Missing"},
+					},
+				},
+			},
+		},
+	}
+	actions, err := Transform(spec)
+	require.NoError(t, err)
+	params := actions["getItem"].Parameters
+	require.Len(t, params, 1, "해석할 수 없는 $ref는 제외")
+	assert.Equal(t, "override", params[0].Description)
+	assert.True(t, params[0].Required, "path 파라미터는 항상 필수")
+}
@@ -10,10 +10,70 @@ const (
 
 // SwaggerSpec represents a Swagger 2.0 or OpenAPI 3.0 specification
 type SwaggerSpec struct {
-	Swagger string              `json:"swagger" yaml:"swagger"` // Swagger 2.0
-	OpenAPI string              `json:"openapi" yaml:"openapi"` // OpenAPI 3.0+
-	Info    SwaggerInfo         `json:"info" yaml:"info"`
-	Paths   map[string]PathItem `json:"paths" yaml:"paths"`
+	Swagger     string                `json:"swagger" yaml:"swagger"` // Swagger 2.0
+	OpenAPI     string                `json:"openapi" yaml:"openapi"` // OpenAPI 3.0+
+	Info        SwaggerInfo           `json:"info" yaml:"info"`
+	Host        string                `json:"host" yaml:"host"`         // Swagger 2.0
+	BasePath    string                `json:"basePath" yaml:"basePath"` // Swagger 2.0
+	Schemes     []string              `json:"schemes" yaml:"schemes"`   // Swagger 2.0
+	Consumes    []string              `json:"consumes" yaml:"consumes"` // Swagger 2.0
+	Servers     []Server              `json:"servers" yaml:"servers"`   // OpenAPI 3.0+
+	Paths       map[string]PathItem   `json:"paths" yaml:"paths"`
+	Definitions map[string]Schema     `json:"definitions" yaml:"definitions"` // Swagger 2.0
+	Parameters  map[string]Parameter  `json:"parameters" yaml:"parameters"`   // Swagger 2.0
+	Components  Components            `json:"components" yaml:"components"`   // OpenAPI 3.0+
+	Security    []SecurityRequirement `json:"security" yaml:"security"`
+}
+
+// Server represents an OpenAPI 3.0 server entry
+type Server struct {
+	URL       string                    `json:"url" yaml:"url"`
+	Variables map[string]ServerVariable `json:"variables" yaml:"variables"`
+}
+
+// ServerVariable represents a substitution variable in a server URL
+type ServerVariable struct {
+	Default string `json:"default" yaml:"default"`
+}
+
+// Components holds reusable OpenAPI 3.0 objects referenced by $ref
+type Components struct {
+	Schemas       map[string]Schema      `json:"schemas" yaml:"schemas"`
+	Parameters    map[string]Parameter   `json:"parameters" yaml:"parameters"`
+	RequestBodies map[string]RequestBody `json:"requestBodies" yaml:"requestBodies"`
+}
+
+// Schema is a JSON schema object kept in its raw form
+type Schema map[string]interface{}
+
+// SecurityRequirement maps a security scheme name to its required scopes
+type SecurityRequirement map[string][]string
+
+// Parameter represents an operation parameter (Swagger 2.0 / OpenAPI 3.0)
+type Parameter struct {
+	Ref         string        `json:"$ref" yaml:"$ref"`
+	Name        string        `json:"name" yaml:"name"`
+	In          string        `json:"in" yaml:"in"` // path, query, header, cookie, body(2.0), formData(2.0)
+	Description string        `json:"description" yaml:"description"`
+	Required    bool          `json:"required" yaml:"required"`
+	Type        string        `json:"type" yaml:"type"`     // Swagger 2.0 non-body
+	Format      string        `json:"format" yaml:"format"` // Swagger 2.0 non-body
+	Enum        []interface{} `json:"enum" yaml:"enum"`     // Swagger 2.0 non-body
+	Items       Schema        `json:"items" yaml:"items"`   // Swagger 2.0 array
+	Schema      Schema        `json:"schema" yaml:"schema"` // Swagger 2.0 body / OpenAPI 3.0
+}
+
+// RequestBody represents an OpenAPI 3.0 request body
+type RequestBody struct {
+	Ref         string               `json:"$ref" yaml:"$ref"`
+	Description string               `json:"description" yaml:"description"`
+	Required    bool                 `json:"required" yaml:"required"`
+	Content     map[string]MediaType `json:"content" yaml:"content"`
+}
+
+// MediaType represents an OpenAPI 3.0 media type entry
+type MediaType struct {
+	Schema Schema `json:"schema" yaml:"schema"`
 }
 
 // SwaggerInfo contains API metadata
@@ -32,6 +92,8 @@ type PathItem struct {
 	Patch   *Operation `json:"patch" yaml:"patch"`
 	Options *Operation `json:"options" yaml:"options"`
 	Head    *Operation `json:"head" yaml:"head"`
+
+	Parameters []Parameter `json:"parameters" yaml:"parameters"` // Shared by all operations on the path
 }
 
 // Operation represents a single API operation
@@ -40,13 +102,20 @@ type Operation struct {
 	Summary     string   `json:"summary" yaml:"summary"`
 	Description string   `json:"description" yaml:"description"`
 	Tags        []string `json:"tags" yaml:"tags"`
+
+	Parameters  []Parameter  `json:"parameters" yaml:"parameters"`
+	RequestBody *RequestBody `json:"requestBody" yaml:"requestBody"` // OpenAPI 3.0+
+	Consumes    []string     `json:"consumes" yaml:"consumes"`       // Swagger 2.0
+	// Security nil means the spec-level security applies; an empty list disables it
+	Security *[]SecurityRequirement `json:"security" yaml:"security"`
 }
 
 // OperationInfo holds operation details with path and method
 type OperationInfo struct {
-	Path      string
-	Method    string
-	Operation *Operation
+	Path       string
+	Method     string
+	Operation  *Operation
+	Parameters []Parameter // Path-level parameters
 }
 
 // FrameworkMeta contains metadata for a framework
@@ -58,9 +127,29 @@ type FrameworkMeta struct {
 
 // ServiceAction represents a single service action
 type ServiceAction struct {
-	Method       string `yaml:"method" json:"method"`
-	ResourcePath string `yaml:"resourcePath" json:"resourcePath"`
-	Description  string `yaml:"description" json:"description"`
+	Method       string                `yaml:"method" json:"method"`
+	ResourcePath string                `yaml:"resourcePath" json:"resourcePath"`
+	Description  string                `yaml:"description" json:"description"`
+	Parameters   []ActionParameter     `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	RequestBody  *ActionRequestBody    `yaml:"requestBody,omitempty" json:"requestBody,omitempty"`
+	Security     []SecurityRequirement `yaml:"security,omitempty" json:"security,omitempty"`
+}
+
+// ActionParameter is a resolved path/query/header/cookie/formData parameter of an action
+type ActionParameter struct {
+	Name        string `yaml:"name" json:"name"`
+	In          string `yaml:"in" json:"in"`
+	Required    bool   `yaml:"required" json:"required"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Schema      Schema `yaml:"schema,omitempty" json:"schema,omitempty"`
+}
+
+// ActionRequestBody is a resolved request body of an action
+type ActionRequestBody struct {
+	Required    bool   `yaml:"required" json:"required"`
+	ContentType string `yaml:"contentType" json:"contentType"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Schema      Schema `yaml:"schema,omitempty" json:"schema,omitempty"`
 }
 
 // FrameworkResult holds the result of processing a single framework
@@ -68,6 +157,7 @@ type FrameworkResult struct {
 	Name        string
 	Version     string
 	Repository  string
+	Servers     []string
 	Actions     map[string]ServiceAction
 	ActionCount int
 	Error       error
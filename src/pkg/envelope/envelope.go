@@ -0,0 +1,178 @@
+// Package envelope 민감 값의 저장 시 암호화(envelope encryption)
+//
+// 값마다 임의의 데이터 키(DEK)를 생성해 AES-256-GCM으로 암호화하고, 데이터 키는
+// KeyProvider(로컬 키 파일, KMS 등)의 키 암호화 키(KEK)로 래핑하여 함께 저장한다.
+// 저장 형식: enc:v1:<keyID>:<base64(래핑된 DEK)>:<base64(nonce||ciphertext)>
+package envelope
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+const (
+	prefix     = "enc:v1:"
+	dataKeyLen = 32
+)
+
+var (
+	ErrNoProvider       = errors.New("envelope: no key provider configured")
+	ErrMalformed        = errors.New("envelope: malformed ciphertext")
+	ErrUnknownKey       = errors.New("envelope: unknown key id")
+	ErrInvalidKeyLength = errors.New("envelope: key must be 32 bytes")
+)
+
+// KeyProvider 데이터 키를 래핑/언래핑하는 키 암호화 키(KEK) 제공자 (KMS 스타일)
+type KeyProvider interface {
+	// ActiveKeyID 새로 암호화할 때 사용하는 키 ID
+	ActiveKeyID() string
+	// WrapKey 데이터 키를 활성 키로 래핑. 사용한 키 ID와 래핑된 키 반환
+	WrapKey(ctx context.Context, dataKey []byte) (keyID string, wrapped []byte, err error)
+	// UnwrapKey 지정 키 ID로 래핑된 데이터 키 복원
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// RotatableKeyProvider 새 키를 생성해 활성 키로 교체할 수 있는 KeyProvider (로컬 키 파일 등)
+// KMS처럼 외부에서 키를 교체하는 제공자는 구현하지 않는다.
+type RotatableKeyProvider interface {
+	KeyProvider
+	AddKey() (string, error)
+}
+
+var (
+	defaultMu       sync.RWMutex
+	defaultProvider KeyProvider
+)
+
+// SetDefault 전역 KeyProvider 설정 (GORM serializer가 사용). nil이면 암호화하지 않는다.
+func SetDefault(p KeyProvider) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultProvider = p
+}
+
+// Default 전역 KeyProvider 조회
+func Default() KeyProvider {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultProvider
+}
+
+// IsEncrypted 값이 envelope 형식으로 암호화되어 있는지 확인
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// KeyIDOf 암호화된 값의 키 ID. 암호화되지 않은 값이면 빈 문자열
+func KeyIDOf(value string) string {
+	if !IsEncrypted(value) {
+		return ""
+	}
+	parts := strings.SplitN(strings.TrimPrefix(value, prefix), ":", 3)
+	if len(parts) != 3 {
+		return ""
+	}
+	return parts[0]
+}
+
+// Encrypt 평문을 envelope 형식으로 암호화
+func Encrypt(ctx context.Context, p KeyProvider, plaintext string) (string, error) {
+	if p == nil {
+		return "", ErrNoProvider
+	}
+	dataKey := make([]byte, dataKeyLen)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", fmt.Errorf("envelope: failed to generate data key: %w", err)
+	}
+	sealed, err := seal(dataKey, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	keyID, wrapped, err := p.WrapKey(ctx, dataKey)
+	if err != nil {
+		return "", fmt.Errorf("envelope: failed to wrap data key: %w", err)
+	}
+	return prefix + keyID + ":" +
+		base64.RawStdEncoding.EncodeToString(wrapped) + ":" +
+		base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt envelope 형식 값을 복호화. 암호화되지 않은 값(마이그레이션 전 평문)은 그대로 반환한다.
+func Decrypt(ctx context.Context, p KeyProvider, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	if p == nil {
+		return "", ErrNoProvider
+	}
+	parts := strings.SplitN(strings.TrimPrefix(value, prefix), ":", 3)
+	if len(parts) != 3 {
+		return "", ErrMalformed
+	}
+	wrapped, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", ErrMalformed
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", ErrMalformed
+	}
+	dataKey, err := p.UnwrapKey(ctx, parts[0], wrapped)
+	if err != nil {
+		return "", fmt.Errorf("envelope: failed to unwrap data key: %w", err)
+	}
+	plaintext, err := open(dataKey, sealed)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// seal AES-256-GCM 암호화. 결과는 nonce||ciphertext
+func seal(key, plaintext []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("envelope: failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// open seal 결과 복호화
+func open(key, sealed []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrMalformed
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("envelope: failed to decrypt: %w", err)
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != dataKeyLen {
+		return nil, ErrInvalidKeyLength
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("envelope: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
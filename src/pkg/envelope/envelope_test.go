@@ -0,0 +1,70 @@
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecrypt_RoundTrip(t *testing.T) {
+	p, err := NewStaticKeyProvider("k1", bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+
+	encrypted, err := Encrypt(context.Background(), p, "super-secret")
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(encrypted))
+	assert.Equal(t, "k1", KeyIDOf(encrypted))
+	assert.NotContains(t, encrypted, "super-secret")
+
+	again, err := Encrypt(context.Background(), p, "super-secret")
+	require.NoError(t, err)
+	assert.NotEqual(t, encrypted, again, "값마다 데이터 키와 nonce가 달라야 한다")
+
+	plaintext, err := Decrypt(context.Background(), p, encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "super-secret", plaintext)
+
+	// 평문(마이그레이션 전 데이터)은 그대로 반환
+	plaintext, err = Decrypt(context.Background(), p, "legacy")
+	require.NoError(t, err)
+	assert.Equal(t, "legacy", plaintext)
+
+	// 다른 키로는 복호화 불가
+	other, err := NewStaticKeyProvider("k1", bytes.Repeat([]byte{2}, 32))
+	require.NoError(t, err)
+	_, err = Decrypt(context.Background(), other, encrypted)
+	assert.Error(t, err)
+}
+
+func TestFileKeyProvider_RotateKeepsOldKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keyring.json")
+
+	_, err := NewFileKeyProvider(path, false)
+	assert.Error(t, err, "create=false이면 없는 키 파일은 오류")
+
+	p, err := NewFileKeyProvider(path, true)
+	require.NoError(t, err)
+	oldKeyID := p.ActiveKeyID()
+	encrypted, err := Encrypt(context.Background(), p, "value")
+	require.NoError(t, err)
+
+	newKeyID, err := p.AddKey()
+	require.NoError(t, err)
+	assert.NotEqual(t, oldKeyID, newKeyID)
+
+	// 다시 로드해도 이전 키로 암호화된 값을 복호화할 수 있고, 새 암호화는 새 키를 사용
+	reloaded, err := NewFileKeyProvider(path, false)
+	require.NoError(t, err)
+	assert.Equal(t, newKeyID, reloaded.ActiveKeyID())
+	plaintext, err := Decrypt(context.Background(), reloaded, encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "value", plaintext)
+
+	reencrypted, err := Encrypt(context.Background(), reloaded, plaintext)
+	require.NoError(t, err)
+	assert.Equal(t, newKeyID, KeyIDOf(reencrypted))
+}
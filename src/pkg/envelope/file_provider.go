@@ -0,0 +1,190 @@
+package envelope
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// keyringFile 로컬 키 파일 형식
+//
+//	{
+//	  "activeKeyId": "k20261017T120000",
+//	  "keys": [{"id": "k20261017T120000", "key": "<base64 32 bytes>", "createdAt": "..."}]
+//	}
+type keyringFile struct {
+	ActiveKeyID string       `json:"activeKeyId"`
+	Keys        []keyringKey `json:"keys"`
+}
+
+type keyringKey struct {
+	ID        string    `json:"id"`
+	Key       string    `json:"key"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// FileKeyProvider 로컬 키 파일 기반 KeyProvider (개발/단일 노드용)
+// 이전 키는 복호화를 위해 보존하고, 새 암호화에는 activeKeyId 키만 사용한다.
+type FileKeyProvider struct {
+	path   string
+	mu     sync.RWMutex
+	active string
+	keys   map[string][]byte
+}
+
+// NewFileKeyProvider 키 파일을 로드. create가 true이고 파일이 없으면 새 키로 생성한다.
+func NewFileKeyProvider(path string, create bool) (*FileKeyProvider, error) {
+	p := &FileKeyProvider{path: path}
+	err := p.load()
+	if errors.Is(err, os.ErrNotExist) && create {
+		if _, err := p.AddKey(); err != nil {
+			return nil, err
+		}
+		return p, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// NewStaticKeyProvider 메모리 내 단일 키 KeyProvider (테스트용)
+func NewStaticKeyProvider(keyID string, key []byte) (*FileKeyProvider, error) {
+	if len(key) != dataKeyLen {
+		return nil, ErrInvalidKeyLength
+	}
+	return &FileKeyProvider{active: keyID, keys: map[string][]byte{keyID: key}}, nil
+}
+
+// ActiveKeyID 현재 활성 키 ID
+func (p *FileKeyProvider) ActiveKeyID() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.active
+}
+
+// WrapKey 활성 키로 데이터 키 래핑
+func (p *FileKeyProvider) WrapKey(_ context.Context, dataKey []byte) (string, []byte, error) {
+	p.mu.RLock()
+	keyID, kek := p.active, p.keys[p.active]
+	p.mu.RUnlock()
+	if kek == nil {
+		return "", nil, ErrUnknownKey
+	}
+	wrapped, err := seal(kek, dataKey)
+	if err != nil {
+		return "", nil, err
+	}
+	return keyID, wrapped, nil
+}
+
+// UnwrapKey 지정 키로 데이터 키 복원
+func (p *FileKeyProvider) UnwrapKey(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	p.mu.RLock()
+	kek := p.keys[keyID]
+	p.mu.RUnlock()
+	if kek == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+	return open(kek, wrapped)
+}
+
+// AddKey 새 키를 생성해 활성 키로 지정하고 파일에 저장 (키 교체). 새 키 ID 반환
+func (p *FileKeyProvider) AddKey() (string, error) {
+	key := make([]byte, dataKeyLen)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", fmt.Errorf("envelope: failed to generate key: %w", err)
+	}
+	now := time.Now().UTC()
+	keyID := "k" + now.Format("20060102T150405")
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.keys == nil {
+		p.keys = make(map[string][]byte)
+	}
+	if _, exists := p.keys[keyID]; exists {
+		keyID = fmt.Sprintf("%s-%d", keyID, len(p.keys))
+	}
+	p.keys[keyID] = key
+	p.active = keyID
+	if p.path == "" {
+		return keyID, nil
+	}
+	return keyID, p.saveLocked(now)
+}
+
+func (p *FileKeyProvider) load() error {
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return err
+	}
+	var kf keyringFile
+	if err := json.Unmarshal(data, &kf); err != nil {
+		return fmt.Errorf("envelope: invalid keyring file %s: %w", p.path, err)
+	}
+	keys := make(map[string][]byte, len(kf.Keys))
+	for _, k := range kf.Keys {
+		if k.ID == "" || strings.Contains(k.ID, ":") {
+			return fmt.Errorf("envelope: invalid key id %q in %s", k.ID, p.path)
+		}
+		raw, err := base64.StdEncoding.DecodeString(k.Key)
+		if err != nil || len(raw) != dataKeyLen {
+			return fmt.Errorf("envelope: key %s in %s must be a base64 encoded 32-byte key", k.ID, p.path)
+		}
+		keys[k.ID] = raw
+	}
+	if _, ok := keys[kf.ActiveKeyID]; !ok {
+		return fmt.Errorf("envelope: active key %q not found in %s", kf.ActiveKeyID, p.path)
+	}
+	p.active = kf.ActiveKeyID
+	p.keys = keys
+	return nil
+}
+
+// saveLocked 키 파일 저장 (임시 파일 작성 후 rename). 호출자가 p.mu를 잡고 있어야 한다.
+func (p *FileKeyProvider) saveLocked(now time.Time) error {
+	var existing keyringFile
+	if data, err := os.ReadFile(p.path); err == nil {
+		_ = json.Unmarshal(data, &existing)
+	}
+	createdAt := make(map[string]time.Time, len(existing.Keys))
+	for _, k := range existing.Keys {
+		createdAt[k.ID] = k.CreatedAt
+	}
+
+	kf := keyringFile{ActiveKeyID: p.active}
+	for id, key := range p.keys {
+		ts, ok := createdAt[id]
+		if !ok {
+			ts = now
+		}
+		kf.Keys = append(kf.Keys, keyringKey{ID: id, Key: base64.StdEncoding.EncodeToString(key), CreatedAt: ts})
+	}
+	data, err := json.MarshalIndent(kf, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(p.path); dir != "" {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return fmt.Errorf("envelope: failed to create keyring directory: %w", err)
+		}
+	}
+	tmp := p.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("envelope: failed to write keyring file: %w", err)
+	}
+	if err := os.Rename(tmp, p.path); err != nil {
+		return fmt.Errorf("envelope: failed to write keyring file: %w", err)
+	}
+	return nil
+}
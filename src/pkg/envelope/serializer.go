@@ -0,0 +1,128 @@
+package envelope
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm/schema"
+)
+
+// GORM serializer 이름
+//   - encrypted:     문자열 필드 전체를 암호화 (`gorm:"serializer:encrypted"`)
+//   - encryptedjson: map[string]string을 JSON으로 저장하되 비밀 키의 값만 암호화 (`gorm:"serializer:encryptedjson"`)
+const (
+	SerializerName     = "encrypted"
+	JSONSerializerName = "encryptedjson"
+)
+
+func init() {
+	schema.RegisterSerializer(SerializerName, StringSerializer{})
+	schema.RegisterSerializer(JSONSerializerName, JSONMapSerializer{})
+}
+
+// IsSecretKey encryptedjson에서 값을 암호화할 키인지 판단
+func IsSecretKey(key string) bool {
+	k := strings.ToLower(key)
+	return strings.Contains(k, "secret") || strings.Contains(k, "password") ||
+		k == "private_key" || k == "api_key"
+}
+
+// StringSerializer 문자열 필드 암호화 serializer
+// 빈 문자열은 암호화하지 않으며, 전역 KeyProvider가 없으면 평문으로 저장한다.
+type StringSerializer struct{}
+
+// Scan DB 값을 복호화하여 필드에 설정
+func (StringSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var raw string
+	switch v := dbValue.(type) {
+	case nil:
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	default:
+		return fmt.Errorf("envelope: unsupported db value type %T for %s", dbValue, field.Name)
+	}
+	plaintext, err := Decrypt(ctx, Default(), raw)
+	if err != nil {
+		return fmt.Errorf("%s: %w", field.Name, err)
+	}
+	return field.Set(ctx, dst, plaintext)
+}
+
+// Value 필드 값을 암호화하여 DB 값으로 반환
+func (StringSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	plaintext, ok := fieldValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("envelope: %s must be a string field", field.Name)
+	}
+	p := Default()
+	if plaintext == "" || p == nil || IsEncrypted(plaintext) {
+		return plaintext, nil
+	}
+	return Encrypt(ctx, p, plaintext)
+}
+
+// JSONMapSerializer map[string]string 필드의 비밀 값만 암호화하는 JSON serializer
+// 비밀이 아닌 값은 평문으로 남아 jsonb 조회가 가능하다.
+type JSONMapSerializer struct{}
+
+// Scan DB JSON을 읽어 비밀 값을 복호화
+func (JSONMapSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var data []byte
+	switch v := dbValue.(type) {
+	case nil:
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("envelope: unsupported db value type %T for %s", dbValue, field.Name)
+	}
+	var m map[string]string
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &m); err != nil {
+			return fmt.Errorf("%s: %w", field.Name, err)
+		}
+	}
+	for k, v := range m {
+		plaintext, err := Decrypt(ctx, Default(), v)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", field.Name, k, err)
+		}
+		m[k] = plaintext
+	}
+	field.ReflectValueOf(ctx, dst).Set(reflect.ValueOf(m))
+	return nil
+}
+
+// Value 비밀 값을 암호화한 JSON 문자열 반환
+func (JSONMapSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	m, ok := fieldValue.(map[string]string)
+	if !ok {
+		return nil, fmt.Errorf("envelope: %s must be a map[string]string field", field.Name)
+	}
+	if m == nil {
+		return nil, nil
+	}
+	p := Default()
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		if p != nil && v != "" && IsSecretKey(k) && !IsEncrypted(v) {
+			encrypted, err := Encrypt(ctx, p, v)
+			if err != nil {
+				return nil, err
+			}
+			v = encrypted
+		}
+		out[k] = v
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,209 @@
+// Package metrics IAM 운영 지표(Prometheus) 수집 및 /metrics 노출
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "mciam"
+
+// AuthMiddleware 인증 실패 사유 (mciam_auth_failures_total{reason})
+const (
+	AuthFailureMissingHeader    = "missing_header"
+	AuthFailureMalformedHeader  = "malformed_header"
+	AuthFailureTokenExpired     = "token_expired"
+	AuthFailureInvalidSignature = "invalid_signature"
+	AuthFailureUnknownKey       = "unknown_key"
+	AuthFailureInvalidIssuer    = "invalid_issuer"
+	AuthFailureInvalidAudience  = "invalid_audience"
+	AuthFailureInvalidToken     = "invalid_token"
+	AuthFailureInvalidClaims    = "invalid_claims"
+	AuthFailureRevoked          = "revoked"
+	AuthFailureInvalidApiKey    = "invalid_api_key"
+	AuthFailureUnknownTenant    = "unknown_tenant"
+)
+
+// CSP 임시 자격 증명 발급 결과 (mciam_csp_credential_requests_total{outcome})
+const (
+	CredentialOutcomeIssued = "issued"
+	CredentialOutcomeCached = "cached"
+	CredentialOutcomeError  = "error"
+)
+
+// Registry 애플리케이션 지표 레지스트리 (Go 런타임/프로세스 지표 포함)
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	authFailuresTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "auth_failures_total",
+		Help:      "Requests rejected by the authentication middleware by reason.",
+	}, []string{"reason"})
+
+	cspCredentialRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "csp_credential_requests_total",
+		Help:      "Temporary CSP credential requests by CSP type, auth method and outcome.",
+	}, []string{"csp_type", "auth_method", "outcome"})
+
+	cspCredentialDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "csp_credential_duration_seconds",
+		Help:      "Temporary CSP credential issuance latency by CSP type and auth method.",
+		Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"csp_type", "auth_method"})
+
+	keycloakRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "keycloak_request_duration_seconds",
+		Help:      "Keycloak admin/token API latency by HTTP method, resource and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "resource", "status"})
+
+	mcmpApiCallsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "mcmp_api_calls_total",
+		Help:      "Upstream MCMP API calls by service, action and status code.",
+	}, []string{"service", "action", "status"})
+
+	mcmpApiCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "mcmp_api_call_duration_seconds",
+		Help:      "Upstream MCMP API call latency by service and action.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"service", "action"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequestsTotal,
+		httpRequestDuration,
+		authFailuresTotal,
+		cspCredentialRequestsTotal,
+		cspCredentialDuration,
+		keycloakRequestDuration,
+		mcmpApiCallsTotal,
+		mcmpApiCallDuration,
+	)
+}
+
+// RegisterDBStats DB 커넥션 풀 지표(go_sql_*{db_name}) 등록
+func RegisterDBStats(db *sql.DB, dbName string) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, dbName))
+}
+
+// Handler Prometheus text exposition 핸들러
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// ObserveHTTPRequest HTTP 요청 수와 지연 시간 기록 (route는 echo 라우트 패턴)
+func ObserveHTTPRequest(method, route string, status int, elapsed time.Duration) {
+	httpRequestsTotal.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	httpRequestDuration.WithLabelValues(method, route).Observe(elapsed.Seconds())
+}
+
+// IncAuthFailure 인증 실패 건수 증가
+func IncAuthFailure(reason string) {
+	authFailuresTotal.WithLabelValues(reason).Inc()
+}
+
+// ObserveCspCredential CSP 임시 자격 증명 발급 결과 기록
+func ObserveCspCredential(cspType, authMethod, outcome string, elapsed time.Duration) {
+	cspType = CspTypeLabel(cspType)
+	authMethod = authMethodLabel(authMethod)
+	cspCredentialRequestsTotal.WithLabelValues(cspType, authMethod, outcome).Inc()
+	if outcome != CredentialOutcomeCached {
+		cspCredentialDuration.WithLabelValues(cspType, authMethod).Observe(elapsed.Seconds())
+	}
+}
+
+// ObserveKeycloakRequest Keycloak API 호출 지연 시간 기록 (status가 0이면 전송 실패)
+func ObserveKeycloakRequest(method, path string, status int, elapsed time.Duration) {
+	statusLabel := "error"
+	if status > 0 {
+		statusLabel = strconv.Itoa(status)
+	}
+	keycloakRequestDuration.WithLabelValues(method, KeycloakResource(path), statusLabel).Observe(elapsed.Seconds())
+}
+
+// ObserveMcmpApiCall 외부 MCMP API 호출 결과 기록 (status가 0이면 전송 실패)
+func ObserveMcmpApiCall(service, action string, status int, elapsed time.Duration) {
+	statusLabel := "error"
+	if status > 0 {
+		statusLabel = strconv.Itoa(status)
+	}
+	mcmpApiCallsTotal.WithLabelValues(service, action, statusLabel).Inc()
+	mcmpApiCallDuration.WithLabelValues(service, action).Observe(elapsed.Seconds())
+}
+
+var knownCspTypes = map[constants.CSPType]bool{
+	constants.CSPTypeAWS: true, constants.CSPTypeGCP: true, constants.CSPTypeAzure: true,
+	constants.CSPTypeAlibaba: true, constants.CSPTypeTencent: true, constants.CSPTypeIBM: true,
+	constants.CSPTypeNCP: true, constants.CSPTypeNHN: true, constants.CSPTypeKT: true,
+	constants.CSPTypeOpenStack: true,
+}
+
+// CspTypeLabel 요청 값이 그대로 라벨이 되지 않도록 알려진 CSP 타입 외에는 "other"
+func CspTypeLabel(cspType string) string {
+	cspType = strings.ToLower(cspType)
+	if knownCspTypes[constants.CSPType(cspType)] {
+		return cspType
+	}
+	return "other"
+}
+
+func authMethodLabel(authMethod string) string {
+	switch constants.AuthMethod(strings.ToUpper(authMethod)) {
+	case constants.AuthMethodOIDC, constants.AuthMethodSAML, constants.AuthMethodSecretKey:
+		return strings.ToUpper(authMethod)
+	case "":
+		return "unknown"
+	default:
+		return "other"
+	}
+}
+
+// KeycloakResource Keycloak URL 경로를 낮은 카디널리티의 리소스 이름으로 변환
+// 예: /admin/realms/{realm}/users/{id}/role-mappings/realm → users, /realms/{realm}/protocol/openid-connect/token → token
+func KeycloakResource(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, seg := range segments {
+		if seg != "realms" || i+2 >= len(segments) {
+			continue
+		}
+		next := segments[i+2]
+		if i > 0 && segments[i-1] == "admin" {
+			return next
+		}
+		if next == "protocol" && i+4 < len(segments) {
+			return segments[i+4] // token, certs, userinfo, token/introspect ...
+		}
+		return next
+	}
+	return "other"
+}
@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeycloakResource(t *testing.T) {
+	cases := map[string]string{
+		"/admin/realms/mciam/users/0f6c/role-mappings/realm": "users",
+		"/admin/realms/mciam/groups":                         "groups",
+		"/auth/admin/realms/mciam/roles/admin":               "roles",
+		"/realms/mciam/protocol/openid-connect/token":        "token",
+		"/realms/mciam/protocol/openid-connect/certs":        "certs",
+		"/admin/realms": "other",
+		"":              "other",
+	}
+	for path, want := range cases {
+		assert.Equal(t, want, KeycloakResource(path), path)
+	}
+}
+
+func TestCredentialLabelsAreBounded(t *testing.T) {
+	assert.Equal(t, "aws", CspTypeLabel("AWS"))
+	assert.Equal(t, "other", CspTypeLabel("unknown-cloud"))
+	assert.Equal(t, "OIDC", authMethodLabel("oidc"))
+	assert.Equal(t, "unknown", authMethodLabel(""))
+	assert.Equal(t, "other", authMethodLabel("kerberos"))
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+// AccessRequestRepository JIT 권한 상승 요청 레포지토리
+type AccessRequestRepository struct {
+	db *gorm.DB
+}
+
+// NewAccessRequestRepository 새 AccessRequestRepository 인스턴스 생성
+func NewAccessRequestRepository(db *gorm.DB) *AccessRequestRepository {
+	return &AccessRequestRepository{db: db}
+}
+
+// Create 요청 생성 및 최초 이력 기록
+func (r *AccessRequestRepository) Create(req *model.AccessRequest) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("History").Create(req).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model.AccessRequestEvent{
+			AccessRequestID: req.ID,
+			Status:          req.Status,
+			ActorUserID:     req.RequesterUserID,
+			Comment:         req.Justification,
+		}).Error
+	})
+}
+
+// FindByID ID로 요청 조회 (이력 포함)
+func (r *AccessRequestRepository) FindByID(id uint) (*model.AccessRequest, error) {
+	var req model.AccessRequest
+	if err := r.db.Preload("History", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC, id ASC")
+	}).First(&req, id).Error; err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// List 요청 목록 조회. requesterUserID 또는 workspaceIDs 중 하나라도 일치하는 요청 (둘 다 비어 있으면 전체)
+func (r *AccessRequestRepository) List(filter *model.AccessRequestFilterRequest, requesterUserID uint, workspaceIDs []uint) ([]model.AccessRequest, error) {
+	var requests []model.AccessRequest
+	query := r.db.Order("created_at DESC, id DESC")
+	if filter != nil {
+		if filter.Status != "" {
+			query = query.Where("status = ?", filter.Status)
+		}
+		if filter.WorkspaceID != 0 {
+			query = query.Where("workspace_id = ?", filter.WorkspaceID)
+		}
+	}
+	switch {
+	case requesterUserID != 0 && len(workspaceIDs) > 0:
+		query = query.Where("requester_user_id = ? OR workspace_id IN ?", requesterUserID, workspaceIDs)
+	case requesterUserID != 0:
+		query = query.Where("requester_user_id = ?", requesterUserID)
+	case len(workspaceIDs) > 0:
+		query = query.Where("workspace_id IN ?", workspaceIDs)
+	}
+	if err := query.Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// HasPendingRequest 동일 사용자/워크스페이스/역할의 승인 대기 요청 존재 여부
+func (r *AccessRequestRepository) HasPendingRequest(requesterUserID, workspaceID, roleID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.AccessRequest{}).
+		Where("requester_user_id = ? AND workspace_id = ? AND role_id = ? AND status = ?",
+			requesterUserID, workspaceID, roleID, model.AccessRequestStatusPendingApproval).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// UpdateDecision 상태 전이 및 이력 기록. 현재 상태가 PENDING_APPROVAL이 아니면 false 반환
+func (r *AccessRequestRepository) UpdateDecision(tx *gorm.DB, req *model.AccessRequest, actorUserID uint) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+	result := tx.Model(&model.AccessRequest{}).
+		Where("id = ? AND status = ?", req.ID, model.AccessRequestStatusPendingApproval).
+		Updates(map[string]interface{}{
+			"status":           req.Status,
+			"approver_user_id": req.ApproverUserID,
+			"decision_comment": req.DecisionComment,
+			"decided_at":       req.DecidedAt,
+			"grant_expires_at": req.GrantExpiresAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	return true, tx.Create(&model.AccessRequestEvent{
+		AccessRequestID: req.ID,
+		Status:          req.Status,
+		ActorUserID:     actorUserID,
+		Comment:         req.DecisionComment,
+	}).Error
+}
+
+// FindAdminWorkspaceIDs 사용자가 지정 역할(예: admin)로 유효하게 소속된 워크스페이스 ID 목록
+func (r *AccessRequestRepository) FindAdminWorkspaceIDs(userID uint, roleName string) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&model.UserWorkspaceRole{}).
+		Joins("JOIN mcmp_role_masters ON mcmp_role_masters.id = mcmp_user_workspace_roles.role_id").
+		Where("mcmp_user_workspace_roles.user_id = ? AND mcmp_role_masters.name = ?", userID, roleName).
+		Where(activeGrantClause("mcmp_user_workspace_roles"), grantNow(), grantNow()).
+		Distinct().
+		Pluck("mcmp_user_workspace_roles.workspace_id", &ids).Error
+	return ids, err
+}
+
+// FindRoleIDsByCspRoleID CSP 역할에 매핑된 역할(RoleMaster) ID 목록
+func (r *AccessRequestRepository) FindRoleIDsByCspRoleID(cspRoleID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&model.RoleMasterCspRoleMapping{}).
+		Where("csp_role_id = ?", cspRoleID).
+		Distinct().
+		Pluck("role_id", &ids).Error
+	return ids, err
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+// AuditEventRepository 감사 이벤트 레포지토리 (append-only)
+type AuditEventRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditEventRepository 새 AuditEventRepository 인스턴스 생성
+func NewAuditEventRepository(db *gorm.DB) *AuditEventRepository {
+	return &AuditEventRepository{db: db}
+}
+
+// Create 감사 이벤트 저장
+func (r *AuditEventRepository) Create(event *model.AuditEvent) error {
+	return r.db.Create(event).Error
+}
+
+// List 필터 조건에 맞는 감사 이벤트 목록 조회 (최신순)
+func (r *AuditEventRepository) List(filter *model.AuditEventFilterRequest, offset, limit int) ([]model.AuditEvent, int64, error) {
+	query := r.db.Model(&model.AuditEvent{})
+	if filter.ActorKcUserID != "" {
+		query = query.Where("actor_kc_user_id = ?", filter.ActorKcUserID)
+	}
+	if filter.Action != "" {
+		query = query.Where(`action LIKE ? ESCAPE '\'`, escapeLike(filter.Action)+"%")
+	}
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.StartTime != nil {
+		query = query.Where("created_at >= ?", *filter.StartTime)
+	}
+	if filter.EndTime != nil {
+		query = query.Where("created_at < ?", *filter.EndTime)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var events []model.AuditEvent
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&events).Error; err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+// escapeLike LIKE 패턴의 특수문자 이스케이프
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
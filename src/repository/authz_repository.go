@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+// AuthzRepository 권한 판단(PDP)에 필요한 역할/권한 조회
+type AuthzRepository struct {
+	db *gorm.DB
+}
+
+// NewAuthzRepository 새 AuthzRepository 인스턴스 생성
+func NewAuthzRepository(db *gorm.DB) *AuthzRepository {
+	return &AuthzRepository{db: db}
+}
+
+// FindPlatformRoleGrants 사용자의 플랫폼 역할 (직접 할당 + 그룹 상속, 부여 경로 포함)
+func (r *AuthzRepository) FindPlatformRoleGrants(userID uint) ([]model.AuthzRoleGrant, error) {
+	var grants []model.AuthzRoleGrant
+	now := grantNow()
+	err := r.db.Raw(`
+		SELECT upr.role_id, rm.name AS role_name, 0 AS group_id, 0 AS workspace_id, ? AS source
+		FROM mcmp_user_platform_roles upr
+		JOIN mcmp_role_masters rm ON rm.id = upr.role_id
+		WHERE upr.user_id = ? AND `+notExpiredClause("upr")+`
+		UNION
+		SELECT gpr.role_id, rm.name AS role_name, gpr.group_id, 0 AS workspace_id, ? AS source
+		FROM mcmp_group_platform_roles gpr
+		JOIN mcmp_user_organizations uo ON uo.organization_id = gpr.group_id
+		JOIN mcmp_role_masters rm ON rm.id = gpr.role_id
+		WHERE uo.user_id = ?
+	`, model.AuthzSourcePlatformRole, userID, now, model.AuthzSourceGroupPlatformRole, userID).Scan(&grants).Error
+	if err != nil {
+		return nil, err
+	}
+	return grants, nil
+}
+
+// FindWorkspaceRoleGrants 지정 워크스페이스들에서의 사용자 워크스페이스 역할 (직접 할당 + 그룹 상속, 부여 경로 포함)
+func (r *AuthzRepository) FindWorkspaceRoleGrants(userID uint, workspaceIDs []uint) ([]model.AuthzRoleGrant, error) {
+	if len(workspaceIDs) == 0 {
+		return nil, nil
+	}
+	var grants []model.AuthzRoleGrant
+	now := grantNow()
+	err := r.db.Raw(`
+		SELECT uwr.role_id, rm.name AS role_name, 0 AS group_id, uwr.workspace_id, ? AS source
+		FROM mcmp_user_workspace_roles uwr
+		JOIN mcmp_role_masters rm ON rm.id = uwr.role_id
+		WHERE uwr.user_id = ? AND uwr.workspace_id IN ? AND `+activeGrantClause("uwr")+`
+		UNION
+		SELECT gwr.role_id, rm.name AS role_name, gwr.group_id, gwr.workspace_id, ? AS source
+		FROM mcmp_group_workspace_roles gwr
+		JOIN mcmp_user_organizations uo ON uo.organization_id = gwr.group_id
+		JOIN mcmp_role_masters rm ON rm.id = gwr.role_id
+		WHERE uo.user_id = ? AND gwr.workspace_id IN ? AND `+activeGrantClause("gwr")+`
+	`, model.AuthzSourceWorkspaceRole, userID, workspaceIDs, now, now,
+		model.AuthzSourceGroupWorkspaceRole, userID, workspaceIDs, now, now).Scan(&grants).Error
+	if err != nil {
+		return nil, err
+	}
+	return grants, nil
+}
+
+// FindRolePermissionIDs 역할별 MciamPermission ID 목록 (roleID → permissionIDs)
+func (r *AuthzRepository) FindRolePermissionIDs(roleType constants.IAMRoleType, roleIDs []uint) (map[uint][]string, error) {
+	result := make(map[uint][]string)
+	if len(roleIDs) == 0 {
+		return result, nil
+	}
+	var mappings []model.MciamRoleMciamPermission
+	if err := r.db.Where("role_type = ? AND role_id IN ?", roleType, roleIDs).Find(&mappings).Error; err != nil {
+		return nil, err
+	}
+	for _, m := range mappings {
+		result[m.RoleID] = append(result[m.RoleID], m.PermissionID)
+	}
+	return result, nil
+}
+
+// FindRoleAncestors 역할별 상위 역할 ID 목록 (가까운 순)
+func (r *AuthzRepository) FindRoleAncestors(roleIDs []uint) (map[uint][]uint, error) {
+	return findRoleAncestors(r.db, roleIDs)
+}
+
+// FindWorkspaceIDsByProject 프로젝트가 속한 워크스페이스 ID 목록
+func (r *AuthzRepository) FindWorkspaceIDsByProject(projectID uint) ([]uint, error) {
+	var workspaceIDs []uint
+	err := r.db.Model(&model.WorkspaceProject{}).
+		Where("project_id = ?", projectID).
+		Pluck("workspace_id", &workspaceIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return workspaceIDs, nil
+}
+
+// FindProjectIDByNsId 네임스페이스(nsId)에 매핑된 프로젝트 ID (없으면 0)
+func (r *AuthzRepository) FindProjectIDByNsId(nsID string) (uint, error) {
+	var ids []uint
+	err := r.db.Model(&model.Project{}).
+		Where("nsid = ?", nsID).
+		Limit(1).
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	return ids[0], nil
+}
+
+// FindUserGroupIDs 사용자가 속한 그룹(조직) ID 목록
+func (r *AuthzRepository) FindUserGroupIDs(userID uint) ([]uint, error) {
+	var groupIDs []uint
+	err := r.db.Model(&model.UserOrganization{}).
+		Where("user_id = ?", userID).
+		Pluck("organization_id", &groupIDs).Error
+	return groupIDs, err
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"database/sql"
 	"fmt"
 
 	"github.com/m-cmp/mc-iam-manager/model"
@@ -62,3 +63,87 @@ func (r *CompanyRepository) Count() (int64, error) {
 	}
 	return count, nil
 }
+
+// FindByID ID로 회사 조회 (없으면 nil)
+func (r *CompanyRepository) FindByID(id uint) (*model.Company, error) {
+	var company model.Company
+	if err := r.db.First(&company, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	return &company, nil
+}
+
+// FindByRealmName realm_name으로 회사 조회 (없으면 nil)
+func (r *CompanyRepository) FindByRealmName(realmName string) (*model.Company, error) {
+	var company model.Company
+	if err := r.db.Where("realm_name = ?", realmName).First(&company).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get company by realm_name: %w", err)
+	}
+	return &company, nil
+}
+
+// List 회사 목록 조회 (status가 비어 있으면 전체)
+func (r *CompanyRepository) List(status string) ([]model.Company, error) {
+	var companies []model.Company
+	query := r.db.Order("id")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Find(&companies).Error; err != nil {
+		return nil, fmt.Errorf("failed to list companies: %w", err)
+	}
+	return companies, nil
+}
+
+// AssignUnowned company_id가 없는 기존 워크스페이스/CSP 계정/가입 신청을 지정한 회사로 이관
+func (r *CompanyRepository) AssignUnowned(companyID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Workspace{}).Where("company_id IS NULL").Update("company_id", companyID).Error; err != nil {
+			return fmt.Errorf("failed to assign workspaces to company: %w", err)
+		}
+		if err := tx.Model(&model.CspAccount{}).Where("company_id IS NULL").Update("company_id", companyID).Error; err != nil {
+			return fmt.Errorf("failed to assign csp accounts to company: %w", err)
+		}
+		if err := tx.Model(&model.Signup{}).Where("company_id IS NULL").Update("company_id", companyID).Error; err != nil {
+			return fmt.Errorf("failed to assign signups to company: %w", err)
+		}
+		return nil
+	})
+}
+
+// FindResourceCompanyID 테넌트 리소스(워크스페이스/역할/CSP 계정)의 소유 회사 조회
+// found=false 이면 리소스가 없음
+func (r *CompanyRepository) FindResourceCompanyID(kind string, id uint) (companyID *uint, found bool, err error) {
+	var target interface{}
+	switch kind {
+	case model.TenantResourceWorkspace:
+		target = &model.Workspace{}
+	case model.TenantResourceRole:
+		target = &model.RoleMaster{}
+	case model.TenantResourceCspAccount:
+		target = &model.CspAccount{}
+	case model.TenantResourceSignup:
+		target = &model.Signup{}
+	default:
+		return nil, false, fmt.Errorf("unknown tenant resource kind: %s", kind)
+	}
+
+	var owners []sql.NullInt64
+	if err := r.db.Model(target).Where("id = ?", id).Limit(1).Pluck("company_id", &owners).Error; err != nil {
+		return nil, false, fmt.Errorf("failed to get %s owner: %w", kind, err)
+	}
+	if len(owners) == 0 {
+		return nil, false, nil
+	}
+	if !owners[0].Valid {
+		return nil, true, nil
+	}
+	owner := uint(owners[0].Int64)
+	return &owner, true, nil
+}
@@ -124,3 +124,104 @@ func TestCompanyRepository_Count_WithData(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, int64(1), count)
 }
+
+func setupTenantTestDB(t *testing.T) *gorm.DB {
+	db := setupCompanyTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.Workspace{}, &model.CspAccount{}, &model.RoleMaster{}, &model.RoleSub{}, &model.Signup{}))
+	return db
+}
+
+func TestCompanyRepository_AssignUnowned(t *testing.T) {
+	db := setupTenantTestDB(t)
+	repo := NewCompanyRepository(db)
+
+	other := uint(99)
+	require.NoError(t, db.Create(&model.Workspace{Name: "legacy-ws"}).Error)
+	require.NoError(t, db.Create(&model.Workspace{Name: "other-ws", CompanyID: &other}).Error)
+	require.NoError(t, db.Create(&model.CspAccount{Name: "legacy-account", CspType: "aws"}).Error)
+	require.NoError(t, db.Create(&model.Signup{KcUserID: "kc-legacy", Username: "legacy", Email: "legacy@example.com"}).Error)
+
+	require.NoError(t, repo.AssignUnowned(1))
+
+	var legacy, owned model.Workspace
+	require.NoError(t, db.Where("name = ?", "legacy-ws").First(&legacy).Error)
+	require.NoError(t, db.Where("name = ?", "other-ws").First(&owned).Error)
+	require.NotNil(t, legacy.CompanyID)
+	assert.Equal(t, uint(1), *legacy.CompanyID)
+	assert.Equal(t, other, *owned.CompanyID, "이미 소유 회사가 있는 워크스페이스는 유지")
+
+	var account model.CspAccount
+	require.NoError(t, db.First(&account).Error)
+	require.NotNil(t, account.CompanyID)
+	assert.Equal(t, uint(1), *account.CompanyID)
+
+	var signup model.Signup
+	require.NoError(t, db.First(&signup).Error)
+	require.NotNil(t, signup.CompanyID)
+	assert.Equal(t, uint(1), *signup.CompanyID)
+}
+
+func TestCompanyRepository_FindResourceCompanyID(t *testing.T) {
+	db := setupTenantTestDB(t)
+	repo := NewCompanyRepository(db)
+
+	companyID := uint(2)
+	ws := &model.Workspace{Name: "ws", CompanyID: &companyID}
+	require.NoError(t, db.Create(ws).Error)
+	shared := &model.RoleMaster{Name: "shared-role"}
+	require.NoError(t, db.Create(shared).Error)
+
+	owner, found, err := repo.FindResourceCompanyID(model.TenantResourceWorkspace, ws.ID)
+	require.NoError(t, err)
+	assert.True(t, found)
+	require.NotNil(t, owner)
+	assert.Equal(t, companyID, *owner)
+
+	owner, found, err = repo.FindResourceCompanyID(model.TenantResourceRole, shared.ID)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Nil(t, owner, "공용 역할은 소유 회사 없음")
+
+	_, found, err = repo.FindResourceCompanyID(model.TenantResourceCspAccount, 12345)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	_, _, err = repo.FindResourceCompanyID("unknown", 1)
+	assert.Error(t, err)
+}
+
+func TestTenantScopedFilters(t *testing.T) {
+	db := setupTenantTestDB(t)
+	companyA, companyB := uint(1), uint(2)
+
+	require.NoError(t, db.Create(&model.Workspace{Name: "ws-a", CompanyID: &companyA}).Error)
+	require.NoError(t, db.Create(&model.Workspace{Name: "ws-b", CompanyID: &companyB}).Error)
+	require.NoError(t, db.Create(&model.RoleMaster{Name: "role-shared"}).Error)
+	require.NoError(t, db.Create(&model.RoleMaster{Name: "role-a", CompanyID: &companyA}).Error)
+	require.NoError(t, db.Create(&model.RoleMaster{Name: "role-b", CompanyID: &companyB}).Error)
+	require.NoError(t, db.Create(&model.CspAccount{Name: "acc-a", CspType: "aws", CompanyID: &companyA}).Error)
+	require.NoError(t, db.Create(&model.CspAccount{Name: "acc-b", CspType: "aws", CompanyID: &companyB}).Error)
+
+	workspaces, err := NewWorkspaceRepository(db).FindWorkspaces(&model.WorkspaceFilterRequest{CompanyID: &companyA})
+	require.NoError(t, err)
+	require.Len(t, workspaces, 1)
+	assert.Equal(t, "ws-a", workspaces[0].Name)
+
+	roles, err := NewRoleRepository(db).FindRoles(&model.RoleFilterRequest{CompanyID: &companyA})
+	require.NoError(t, err)
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		names = append(names, role.Name)
+	}
+	assert.ElementsMatch(t, []string{"role-shared", "role-a"}, names)
+
+	accounts, err := NewCspAccountRepository(db).List(&model.CspAccountFilter{CompanyID: &companyB})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	assert.Equal(t, "acc-b", accounts[0].Name)
+
+	// 단일 테넌트 모드(CompanyID 없음)에서는 필터링하지 않음
+	workspaces, err = NewWorkspaceRepository(db).FindWorkspaces(&model.WorkspaceFilterRequest{})
+	require.NoError(t, err)
+	assert.Len(t, workspaces, 2)
+}
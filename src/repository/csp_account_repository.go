@@ -76,6 +76,9 @@ func (r *CspAccountRepository) List(filter *model.CspAccountFilter) ([]*model.Cs
 		if filter.Name != "" {
 			query = query.Where("name LIKE ?", "%"+filter.Name+"%")
 		}
+		if filter.CompanyID != nil {
+			query = query.Where("company_id = ?", *filter.CompanyID)
+		}
 	}
 
 	if err := query.Order("created_at DESC").Find(&accounts).Error; err != nil {
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+// CspHealthRepository CSP IdP 설정/계정 헬스 체크 이력 및 현재 상태 데이터 접근
+type CspHealthRepository struct {
+	db *gorm.DB
+}
+
+// NewCspHealthRepository 새 CspHealthRepository 인스턴스 생성
+func NewCspHealthRepository(db *gorm.DB) *CspHealthRepository {
+	return &CspHealthRepository{db: db}
+}
+
+// RecordCheck 헬스 체크 결과를 이력에 추가하고 현재 상태를 갱신
+// check.PreviousStatus, check.Transition은 저장된 직전 상태로 채워진다.
+func (r *CspHealthRepository) RecordCheck(check *model.CspHealthCheck) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var state model.CspHealthState
+		err := tx.Where("target_type = ? AND target_id = ?", check.TargetType, check.TargetID).First(&state).Error
+		exists := err == nil
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to get CSP health state: %w", err)
+		}
+
+		if exists {
+			check.PreviousStatus = state.Status
+			check.Transition = state.Status != check.Status
+		}
+		if err := tx.Create(check).Error; err != nil {
+			return fmt.Errorf("failed to create CSP health check: %w", err)
+		}
+
+		if !exists || check.Transition {
+			state.LastChangedAt = check.CheckedAt
+		}
+		state.TargetType = check.TargetType
+		state.TargetID = check.TargetID
+		state.TargetName = check.TargetName
+		state.CspType = check.CspType
+		state.AuthMethod = check.AuthMethod
+		state.Status = check.Status
+		state.ErrorMsg = check.ErrorMsg
+		state.LastCheckedAt = check.CheckedAt
+		if check.Status == model.CspHealthStatusConnected {
+			state.ConsecutiveFailures = 0
+			checkedAt := check.CheckedAt
+			state.LastSuccessAt = &checkedAt
+		} else {
+			state.ConsecutiveFailures++
+		}
+		if err := tx.Save(&state).Error; err != nil {
+			return fmt.Errorf("failed to save CSP health state: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListStates 대상 유형별 현재 헬스 상태 목록
+func (r *CspHealthRepository) ListStates(targetType model.CspHealthTargetType) ([]model.CspHealthState, error) {
+	states := make([]model.CspHealthState, 0)
+	if err := r.db.Where("target_type = ?", targetType).Order("target_id").Find(&states).Error; err != nil {
+		return nil, fmt.Errorf("failed to list CSP health states: %w", err)
+	}
+	return states, nil
+}
+
+// GetState 대상의 현재 헬스 상태 (없으면 nil)
+func (r *CspHealthRepository) GetState(targetType model.CspHealthTargetType, targetID uint) (*model.CspHealthState, error) {
+	var state model.CspHealthState
+	if err := r.db.Where("target_type = ? AND target_id = ?", targetType, targetID).First(&state).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get CSP health state: %w", err)
+	}
+	return &state, nil
+}
+
+// ListHistory 대상의 헬스 체크 이력 (최신순)
+func (r *CspHealthRepository) ListHistory(targetType model.CspHealthTargetType, targetID uint, since *time.Time, transitionOnly bool, limit int) ([]model.CspHealthCheck, error) {
+	history := make([]model.CspHealthCheck, 0)
+	query := r.db.Where("target_type = ? AND target_id = ?", targetType, targetID)
+	if since != nil {
+		query = query.Where("checked_at >= ?", *since)
+	}
+	if transitionOnly {
+		query = query.Where("transition = ?", true)
+	}
+	if err := query.Order("checked_at DESC, id DESC").Limit(limit).Find(&history).Error; err != nil {
+		return nil, fmt.Errorf("failed to list CSP health history: %w", err)
+	}
+	return history, nil
+}
+
+// PruneHistory before 이전의 헬스 체크 이력 삭제
+func (r *CspHealthRepository) PruneHistory(before time.Time) (int64, error) {
+	result := r.db.Where("checked_at < ?", before).Delete(&model.CspHealthCheck{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to prune CSP health history: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -120,10 +120,17 @@ func (r *GroupRoleRepository) DeleteGroupPlatformRole(groupID, roleID uint) erro
 
 // CreateGroupWorkspaceRole 그룹-워크스페이스-역할 매핑 생성
 func (r *GroupRoleRepository) CreateGroupWorkspaceRole(groupID, workspaceID, roleID uint) error {
+	return r.CreateGroupWorkspaceRoleWithPeriod(groupID, workspaceID, roleID, model.RoleGrantPeriod{})
+}
+
+// CreateGroupWorkspaceRoleWithPeriod 유효 기간을 지정한 그룹-워크스페이스-역할 매핑 생성
+func (r *GroupRoleRepository) CreateGroupWorkspaceRoleWithPeriod(groupID, workspaceID, roleID uint, period model.RoleGrantPeriod) error {
 	record := &model.GroupWorkspaceRole{
 		GroupID:     groupID,
 		WorkspaceID: workspaceID,
 		RoleID:      roleID,
+		StartsAt:    period.StartsAt,
+		ExpiresAt:   period.ExpiresAt,
 	}
 	if err := r.db.Create(record).Error; err != nil {
 		if isGroupDuplicateError(err) {
@@ -138,7 +145,7 @@ func (r *GroupRoleRepository) CreateGroupWorkspaceRole(groupID, workspaceID, rol
 func (r *GroupRoleRepository) FindGroupWorkspaceRoles(groupID uint) ([]model.GroupWorkspaceRoleResponse, error) {
 	results := make([]model.GroupWorkspaceRoleResponse, 0)
 	err := r.db.Table("mcmp_group_workspace_roles gwr").
-		Select("gwr.group_id, o.name as group_name, gwr.workspace_id, w.name as workspace_name, gwr.role_id, rm.name as role_name, gwr.created_at").
+		Select("gwr.group_id, o.name as group_name, gwr.workspace_id, w.name as workspace_name, gwr.role_id, rm.name as role_name, gwr.starts_at, gwr.expires_at, gwr.created_at").
 		Joins("JOIN mcmp_organizations o ON o.id = gwr.group_id").
 		Joins("JOIN mcmp_workspaces w ON w.id = gwr.workspace_id").
 		Joins("JOIN mcmp_role_masters rm ON rm.id = gwr.role_id").
@@ -154,7 +161,7 @@ func (r *GroupRoleRepository) FindGroupWorkspaceRoles(groupID uint) ([]model.Gro
 func (r *GroupRoleRepository) FindGroupsByWorkspaceRoleID(roleID uint) ([]model.GroupWorkspaceRoleResponse, error) {
 	results := make([]model.GroupWorkspaceRoleResponse, 0)
 	err := r.db.Table("mcmp_group_workspace_roles gwr").
-		Select("gwr.group_id, o.name as group_name, gwr.workspace_id, w.name as workspace_name, gwr.role_id, rm.name as role_name, gwr.created_at").
+		Select("gwr.group_id, o.name as group_name, gwr.workspace_id, w.name as workspace_name, gwr.role_id, rm.name as role_name, gwr.starts_at, gwr.expires_at, gwr.created_at").
 		Joins("JOIN mcmp_organizations o ON o.id = gwr.group_id").
 		Joins("JOIN mcmp_workspaces w ON w.id = gwr.workspace_id").
 		Joins("JOIN mcmp_role_masters rm ON rm.id = gwr.role_id").
@@ -166,6 +173,18 @@ func (r *GroupRoleRepository) FindGroupsByWorkspaceRoleID(roleID uint) ([]model.
 	return results, nil
 }
 
+// FindGroupWorkspaceRole 특정 그룹-워크스페이스 매핑 조회
+func (r *GroupRoleRepository) FindGroupWorkspaceRole(groupID, workspaceID uint) (*model.GroupWorkspaceRole, error) {
+	var record model.GroupWorkspaceRole
+	if err := r.db.Where("group_id = ? AND workspace_id = ?", groupID, workspaceID).First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGroupWorkspaceRoleNotFound
+		}
+		return nil, err
+	}
+	return &record, nil
+}
+
 // UpdateGroupWorkspaceRole 그룹-워크스페이스 역할 변경
 func (r *GroupRoleRepository) UpdateGroupWorkspaceRole(groupID, workspaceID, roleID uint) error {
 	result := r.db.Model(&model.GroupWorkspaceRole{}).
@@ -180,6 +199,24 @@ func (r *GroupRoleRepository) UpdateGroupWorkspaceRole(groupID, workspaceID, rol
 	return nil
 }
 
+// UpdateGroupWorkspaceRoleWithPeriod 그룹-워크스페이스 역할과 유효 기간 변경 (nil이면 제한 해제)
+func (r *GroupRoleRepository) UpdateGroupWorkspaceRoleWithPeriod(groupID, workspaceID, roleID uint, period model.RoleGrantPeriod) error {
+	result := r.db.Model(&model.GroupWorkspaceRole{}).
+		Where("group_id = ? AND workspace_id = ?", groupID, workspaceID).
+		Updates(map[string]interface{}{
+			"role_id":    roleID,
+			"starts_at":  period.StartsAt,
+			"expires_at": period.ExpiresAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("error updating group workspace role: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrGroupWorkspaceRoleNotFound
+	}
+	return nil
+}
+
 // DeleteGroupWorkspaceRole 그룹-워크스페이스 매핑 삭제
 func (r *GroupRoleRepository) DeleteGroupWorkspaceRole(groupID, workspaceID uint) error {
 	result := r.db.Where("group_id = ? AND workspace_id = ?", groupID, workspaceID).Delete(&model.GroupWorkspaceRole{})
@@ -239,8 +276,8 @@ func (r *GroupRoleRepository) FindEffectivePlatformRolesByUserID(userID uint) ([
 		SELECT rm.id as role_id, rm.name as role_name, rm.description, 'direct' as source
 		FROM mcmp_user_platform_roles upr
 		JOIN mcmp_role_masters rm ON rm.id = upr.role_id
-		WHERE upr.user_id = ?
-	`, userID).Scan(&directRows).Error
+		WHERE upr.user_id = ? AND `+notExpiredClause("upr")+`
+	`, userID, grantNow()).Scan(&directRows).Error
 	if err != nil {
 		return nil, fmt.Errorf("error finding direct platform roles for user %d: %w", userID, err)
 	}
@@ -295,9 +332,9 @@ func (r *GroupRoleRepository) FindDirectPlatformRolesByUserID(userID uint) ([]mo
 		SELECT rm.id as role_id, rm.name as role_name, rm.description
 		FROM mcmp_user_platform_roles upr
 		JOIN mcmp_role_masters rm ON rm.id = upr.role_id
-		WHERE upr.user_id = ?
+		WHERE upr.user_id = ? AND `+notExpiredClause("upr")+`
 		ORDER BY rm.name ASC
-	`, userID).Scan(&results).Error
+	`, userID, grantNow()).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("error finding direct platform roles for user %d: %w", userID, err)
 	}
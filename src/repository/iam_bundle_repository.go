@@ -0,0 +1,498 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IamBundleRepository IAM 구성 번들 내보내기/적용 데이터 접근
+// 모든 참조는 이름(역할명, 그룹 code, 워크스페이스명 등)으로 해석한다.
+type IamBundleRepository struct {
+	db *gorm.DB
+}
+
+// NewIamBundleRepository 새 IamBundleRepository 인스턴스 생성
+func NewIamBundleRepository(db *gorm.DB) *IamBundleRepository {
+	return &IamBundleRepository{db: db}
+}
+
+// WithTx 트랜잭션에 묶인 IamBundleRepository 반환
+func (r *IamBundleRepository) WithTx(tx *gorm.DB) *IamBundleRepository {
+	return &IamBundleRepository{db: tx}
+}
+
+type iamBundleCspRow struct {
+	RoleID     uint
+	CspType    string
+	Name       string
+	AuthMethod string
+}
+
+type iamBundleGroupWorkspaceRow struct {
+	GroupID   uint
+	Workspace string
+	Role      string
+}
+
+// Export 현재 DB 상태를 번들로 변환 (모든 목록은 이름 순 정렬)
+func (r *IamBundleRepository) Export() (*model.IamBundle, error) {
+	bundle := &model.IamBundle{
+		Permissions: []model.IamBundlePermission{},
+		Roles:       []model.IamBundleRole{},
+		Groups:      []model.IamBundleGroup{},
+	}
+
+	var permissions []model.MciamPermission
+	if err := r.db.Order("id").Find(&permissions).Error; err != nil {
+		return nil, fmt.Errorf("error listing permissions: %w", err)
+	}
+	for _, p := range permissions {
+		bundle.Permissions = append(bundle.Permissions, model.IamBundlePermission{
+			ID: p.ID, FrameworkID: p.FrameworkID, ResourceTypeID: p.ResourceTypeID,
+			Action: p.Action, Name: p.Name, Description: p.Description,
+		})
+	}
+
+	var roles []model.RoleMaster
+	if err := r.db.Preload("RoleSubs").Order("name").Find(&roles).Error; err != nil {
+		return nil, fmt.Errorf("error listing roles: %w", err)
+	}
+	roleNames := make(map[uint]string, len(roles))
+	for _, role := range roles {
+		roleNames[role.ID] = role.Name
+	}
+
+	var menuRows []model.RoleMenuMapping
+	if err := r.db.Order("menu_id").Find(&menuRows).Error; err != nil {
+		return nil, fmt.Errorf("error listing role menu mappings: %w", err)
+	}
+	var permissionRows []model.MciamRoleMciamPermission
+	if err := r.db.Order("permission_id").Find(&permissionRows).Error; err != nil {
+		return nil, fmt.Errorf("error listing role permissions: %w", err)
+	}
+	var cspRows []iamBundleCspRow
+	if err := r.db.Table("mcmp_role_csp_role_mappings m").
+		Select("m.role_id, c.csp_type, c.name, m.auth_method").
+		Joins("JOIN mcmp_role_csp_roles c ON c.id = m.csp_role_id").
+		Where("c.deleted_at IS NULL").
+		Order("c.csp_type, c.name, m.auth_method").
+		Scan(&cspRows).Error; err != nil {
+		return nil, fmt.Errorf("error listing role csp mappings: %w", err)
+	}
+
+	menusByRole := make(map[uint][]string)
+	for _, m := range menuRows {
+		menusByRole[m.RoleID] = append(menusByRole[m.RoleID], m.MenuID)
+	}
+	permissionsByRole := make(map[uint]map[constants.IAMRoleType][]string)
+	for _, p := range permissionRows {
+		if permissionsByRole[p.RoleID] == nil {
+			permissionsByRole[p.RoleID] = make(map[constants.IAMRoleType][]string)
+		}
+		permissionsByRole[p.RoleID][p.RoleType] = append(permissionsByRole[p.RoleID][p.RoleType], p.PermissionID)
+	}
+	cspByRole := make(map[uint][]model.IamBundleCspRoleRef)
+	for _, c := range cspRows {
+		cspByRole[c.RoleID] = append(cspByRole[c.RoleID], model.IamBundleCspRoleRef{CspType: c.CspType, Name: c.Name, AuthMethod: c.AuthMethod})
+	}
+
+	for _, role := range roles {
+		entry := model.IamBundleRole{
+			Name:                 role.Name,
+			Description:          role.Description,
+			Predefined:           role.Predefined,
+			RoleTypes:            []string{},
+			Menus:                menusByRole[role.ID],
+			PlatformPermissions:  permissionsByRole[role.ID][constants.RoleTypePlatform],
+			WorkspacePermissions: permissionsByRole[role.ID][constants.RoleTypeWorkspace],
+			CspRoles:             cspByRole[role.ID],
+		}
+		if role.ParentID != nil {
+			entry.Parent = roleNames[*role.ParentID]
+		}
+		for _, sub := range role.RoleSubs {
+			entry.RoleTypes = append(entry.RoleTypes, string(sub.RoleType))
+		}
+		sort.Strings(entry.RoleTypes)
+		bundle.Roles = append(bundle.Roles, entry)
+	}
+
+	var orgs []model.Organization
+	if err := r.db.Order("organization_code").Find(&orgs).Error; err != nil {
+		return nil, fmt.Errorf("error listing groups: %w", err)
+	}
+	orgCodes := make(map[uint]string, len(orgs))
+	for _, org := range orgs {
+		orgCodes[org.ID] = org.OrganizationCode
+	}
+
+	var platformRows []model.GroupPlatformRole
+	if err := r.db.Find(&platformRows).Error; err != nil {
+		return nil, fmt.Errorf("error listing group platform roles: %w", err)
+	}
+	platformByGroup := make(map[uint][]string)
+	for _, row := range platformRows {
+		if name, ok := roleNames[row.RoleID]; ok {
+			platformByGroup[row.GroupID] = append(platformByGroup[row.GroupID], name)
+		}
+	}
+	var workspaceRows []iamBundleGroupWorkspaceRow
+	if err := r.db.Table("mcmp_group_workspace_roles g").
+		Select("g.group_id, w.name AS workspace, rm.name AS role").
+		Joins("JOIN mcmp_workspaces w ON w.id = g.workspace_id").
+		Joins("JOIN mcmp_role_masters rm ON rm.id = g.role_id").
+		Where("g.starts_at IS NULL AND g.expires_at IS NULL").
+		Order("w.name").
+		Scan(&workspaceRows).Error; err != nil {
+		return nil, fmt.Errorf("error listing group workspace roles: %w", err)
+	}
+	workspaceByGroup := make(map[uint][]model.IamBundleGroupWorkspaceRole)
+	for _, row := range workspaceRows {
+		workspaceByGroup[row.GroupID] = append(workspaceByGroup[row.GroupID], model.IamBundleGroupWorkspaceRole{Workspace: row.Workspace, Role: row.Role})
+	}
+
+	for _, org := range orgs {
+		entry := model.IamBundleGroup{
+			Code:           org.OrganizationCode,
+			Name:           org.Name,
+			Description:    org.Description,
+			PlatformRoles:  platformByGroup[org.ID],
+			WorkspaceRoles: workspaceByGroup[org.ID],
+		}
+		if org.ParentID != nil {
+			entry.Parent = orgCodes[*org.ParentID]
+		}
+		sort.Strings(entry.PlatformRoles)
+		bundle.Groups = append(bundle.Groups, entry)
+	}
+	return bundle, nil
+}
+
+// ListMenuIDs 대상 환경의 메뉴 ID 집합
+func (r *IamBundleRepository) ListMenuIDs() (map[string]bool, error) {
+	var ids []string
+	if err := r.db.Model(&model.Menu{}).Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return toStringSet(ids), nil
+}
+
+// ListWorkspaceNames 대상 환경의 워크스페이스 이름 집합
+func (r *IamBundleRepository) ListWorkspaceNames() (map[string]bool, error) {
+	var names []string
+	if err := r.db.Model(&model.Workspace{}).Pluck("name", &names).Error; err != nil {
+		return nil, err
+	}
+	return toStringSet(names), nil
+}
+
+// ListCspRoleKeys 대상 환경의 CSP 역할 집합 (키: cspType/name)
+func (r *IamBundleRepository) ListCspRoleKeys() (map[string]bool, error) {
+	var roles []model.CspRole
+	if err := r.db.Select("csp_type", "name").Where("deleted_at IS NULL").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	keys := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		keys[role.CspType+"/"+role.Name] = true
+	}
+	return keys, nil
+}
+
+// ListRoleCompanyIDs 대상 환경의 역할별 소유 회사 (키: 역할명, 공용 역할은 nil)
+func (r *IamBundleRepository) ListRoleCompanyIDs() (map[string]*uint, error) {
+	var roles []model.RoleMaster
+	if err := r.db.Select("name", "company_id").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	companies := make(map[string]*uint, len(roles))
+	for _, role := range roles {
+		companies[role.Name] = role.CompanyID
+	}
+	return companies, nil
+}
+
+func toStringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+// UpsertPermission 권한 생성 또는 갱신 (ID 기준)
+func (r *IamBundleRepository) UpsertPermission(p model.IamBundlePermission) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"framework_id", "resource_type_id", "action", "name", "description", "updated_at"}),
+	}).Create(&model.MciamPermission{
+		ID: p.ID, FrameworkID: p.FrameworkID, ResourceTypeID: p.ResourceTypeID,
+		Action: p.Action, Name: p.Name, Description: p.Description,
+	}).Error
+}
+
+// DeletePermission 권한 및 역할-권한 매핑 삭제
+func (r *IamBundleRepository) DeletePermission(id string) error {
+	if err := r.db.Where("permission_id = ?", id).Delete(&model.MciamRoleMciamPermission{}).Error; err != nil {
+		return err
+	}
+	return r.db.Where("id = ?", id).Delete(&model.MciamPermission{}).Error
+}
+
+// roleID 역할명으로 ID 조회
+func (r *IamBundleRepository) roleID(name string) (uint, error) {
+	var role model.RoleMaster
+	if err := r.db.Select("id").Where("name = ?", name).First(&role).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, fmt.Errorf("role not found: %s", name)
+		}
+		return 0, err
+	}
+	return role.ID, nil
+}
+
+// groupID 그룹 code로 ID 조회
+func (r *IamBundleRepository) groupID(code string) (uint, error) {
+	var org model.Organization
+	if err := r.db.Select("id").Where("organization_code = ?", code).First(&org).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, fmt.Errorf("group not found: %s", code)
+		}
+		return 0, err
+	}
+	return org.ID, nil
+}
+
+// UpsertRole 역할 생성 또는 설명/predefined 갱신
+func (r *IamBundleRepository) UpsertRole(role model.IamBundleRole) error {
+	var existing model.RoleMaster
+	err := r.db.Where("name = ?", role.Name).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return r.db.Create(&model.RoleMaster{Name: role.Name, Description: role.Description, Predefined: role.Predefined}).Error
+	}
+	if err != nil {
+		return err
+	}
+	return r.db.Model(&existing).Updates(map[string]interface{}{
+		"description": role.Description,
+		"predefined":  role.Predefined,
+	}).Error
+}
+
+// SetRoleParent 상위 역할 지정 (parent가 빈 문자열이면 해제)
+func (r *IamBundleRepository) SetRoleParent(name, parent string) error {
+	id, err := r.roleID(name)
+	if err != nil {
+		return err
+	}
+	var parentID *uint
+	if parent != "" {
+		pid, err := r.roleID(parent)
+		if err != nil {
+			return err
+		}
+		parentID = &pid
+	}
+	return r.db.Model(&model.RoleMaster{}).Where("id = ?", id).Update("parent_id", parentID).Error
+}
+
+// AddRoleType 역할 서브 타입 추가
+func (r *IamBundleRepository) AddRoleType(name string, roleType constants.IAMRoleType) error {
+	id, err := r.roleID(name)
+	if err != nil {
+		return err
+	}
+	return r.db.Create(&model.RoleSub{RoleID: id, RoleType: roleType}).Error
+}
+
+// DeleteRoleType 역할 서브 타입 삭제
+func (r *IamBundleRepository) DeleteRoleType(name string, roleType constants.IAMRoleType) error {
+	id, err := r.roleID(name)
+	if err != nil {
+		return err
+	}
+	return r.db.Where("role_id = ? AND role_type = ?", id, roleType).Delete(&model.RoleSub{}).Error
+}
+
+// AddRoleMenu 역할-메뉴 매핑 추가
+func (r *IamBundleRepository) AddRoleMenu(name, menuID string) error {
+	id, err := r.roleID(name)
+	if err != nil {
+		return err
+	}
+	return r.db.Create(&model.RoleMenuMapping{RoleID: id, MenuID: menuID}).Error
+}
+
+// DeleteRoleMenu 역할-메뉴 매핑 삭제
+func (r *IamBundleRepository) DeleteRoleMenu(name, menuID string) error {
+	id, err := r.roleID(name)
+	if err != nil {
+		return err
+	}
+	return r.db.Where("role_id = ? AND menu_id = ?", id, menuID).Delete(&model.RoleMenuMapping{}).Error
+}
+
+// AddRolePermission 역할-MC-IAM 권한 매핑 추가
+func (r *IamBundleRepository) AddRolePermission(name string, roleType constants.IAMRoleType, permissionID string) error {
+	id, err := r.roleID(name)
+	if err != nil {
+		return err
+	}
+	return r.db.Create(&model.MciamRoleMciamPermission{RoleType: roleType, RoleID: id, PermissionID: permissionID}).Error
+}
+
+// DeleteRolePermission 역할-MC-IAM 권한 매핑 삭제
+func (r *IamBundleRepository) DeleteRolePermission(name string, roleType constants.IAMRoleType, permissionID string) error {
+	id, err := r.roleID(name)
+	if err != nil {
+		return err
+	}
+	return r.db.Where("role_type = ? AND role_id = ? AND permission_id = ?", roleType, id, permissionID).
+		Delete(&model.MciamRoleMciamPermission{}).Error
+}
+
+// cspRoleID CSP 역할 (cspType, name)으로 ID 조회
+func (r *IamBundleRepository) cspRoleID(ref model.IamBundleCspRoleRef) (uint, error) {
+	var cspRole model.CspRole
+	if err := r.db.Select("id").Where("csp_type = ? AND name = ? AND deleted_at IS NULL", ref.CspType, ref.Name).
+		First(&cspRole).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, fmt.Errorf("csp role not found: %s/%s", ref.CspType, ref.Name)
+		}
+		return 0, err
+	}
+	return cspRole.ID, nil
+}
+
+// AddRoleCspRole 역할-CSP 역할 매핑 추가
+func (r *IamBundleRepository) AddRoleCspRole(name string, ref model.IamBundleCspRoleRef) error {
+	id, err := r.roleID(name)
+	if err != nil {
+		return err
+	}
+	cspRoleID, err := r.cspRoleID(ref)
+	if err != nil {
+		return err
+	}
+	return r.db.Create(&model.RoleMasterCspRoleMapping{
+		RoleID: id, CspRoleID: cspRoleID, AuthMethod: constants.AuthMethod(ref.AuthMethod),
+	}).Error
+}
+
+// DeleteRoleCspRole 역할-CSP 역할 매핑 삭제
+func (r *IamBundleRepository) DeleteRoleCspRole(name string, ref model.IamBundleCspRoleRef) error {
+	id, err := r.roleID(name)
+	if err != nil {
+		return err
+	}
+	cspRoleID, err := r.cspRoleID(ref)
+	if err != nil {
+		return err
+	}
+	return r.db.Where("role_id = ? AND csp_role_id = ? AND auth_method = ?", id, cspRoleID, ref.AuthMethod).
+		Delete(&model.RoleMasterCspRoleMapping{}).Error
+}
+
+// UpsertGroup 그룹 생성 또는 이름/설명/상위 그룹 갱신 (code 기준)
+func (r *IamBundleRepository) UpsertGroup(group model.IamBundleGroup) error {
+	var parentID *uint
+	if group.Parent != "" {
+		pid, err := r.groupID(group.Parent)
+		if err != nil {
+			return err
+		}
+		parentID = &pid
+	}
+	var existing model.Organization
+	err := r.db.Where("organization_code = ?", group.Code).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return r.db.Create(&model.Organization{
+			OrganizationCode: group.Code, Name: group.Name, Description: group.Description, ParentID: parentID,
+		}).Error
+	}
+	if err != nil {
+		return err
+	}
+	return r.db.Model(&existing).Updates(map[string]interface{}{
+		"name":        group.Name,
+		"description": group.Description,
+		"parent_id":   parentID,
+	}).Error
+}
+
+// AddGroupPlatformRole 그룹-플랫폼 역할 바인딩 추가 (DB만, Keycloak 동기화는 서비스에서 수행)
+func (r *IamBundleRepository) AddGroupPlatformRole(code, roleName string) error {
+	groupID, err := r.groupID(code)
+	if err != nil {
+		return err
+	}
+	roleID, err := r.roleID(roleName)
+	if err != nil {
+		return err
+	}
+	return r.db.Create(&model.GroupPlatformRole{GroupID: groupID, RoleID: roleID}).Error
+}
+
+// DeleteGroupPlatformRole 그룹-플랫폼 역할 바인딩 삭제
+func (r *IamBundleRepository) DeleteGroupPlatformRole(code, roleName string) error {
+	groupID, err := r.groupID(code)
+	if err != nil {
+		return err
+	}
+	roleID, err := r.roleID(roleName)
+	if err != nil {
+		return err
+	}
+	return r.db.Where("group_id = ? AND role_id = ?", groupID, roleID).Delete(&model.GroupPlatformRole{}).Error
+}
+
+// workspaceID 워크스페이스 이름으로 ID 조회
+func (r *IamBundleRepository) workspaceID(name string) (uint, error) {
+	var ws model.Workspace
+	if err := r.db.Select("id").Where("name = ?", name).First(&ws).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, fmt.Errorf("workspace not found: %s", name)
+		}
+		return 0, err
+	}
+	return ws.ID, nil
+}
+
+// SetGroupWorkspaceRole 그룹-워크스페이스 역할 바인딩 생성 또는 역할 변경 (기간 제한 해제)
+func (r *IamBundleRepository) SetGroupWorkspaceRole(code string, binding model.IamBundleGroupWorkspaceRole) error {
+	groupID, err := r.groupID(code)
+	if err != nil {
+		return err
+	}
+	workspaceID, err := r.workspaceID(binding.Workspace)
+	if err != nil {
+		return err
+	}
+	roleID, err := r.roleID(binding.Role)
+	if err != nil {
+		return err
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "group_id"}, {Name: "workspace_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"role_id": roleID, "starts_at": nil, "expires_at": nil}),
+	}).Create(&model.GroupWorkspaceRole{GroupID: groupID, WorkspaceID: workspaceID, RoleID: roleID}).Error
+}
+
+// DeleteGroupWorkspaceRole 그룹-워크스페이스 역할 바인딩 삭제
+func (r *IamBundleRepository) DeleteGroupWorkspaceRole(code, workspace string) error {
+	groupID, err := r.groupID(code)
+	if err != nil {
+		return err
+	}
+	workspaceID, err := r.workspaceID(workspace)
+	if err != nil {
+		return err
+	}
+	return r.db.Where("group_id = ? AND workspace_id = ?", groupID, workspaceID).Delete(&model.GroupWorkspaceRole{}).Error
+}
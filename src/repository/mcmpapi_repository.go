@@ -1,11 +1,13 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 
 	"github.com/m-cmp/mc-iam-manager/model/mcmpapi"
+	"github.com/m-cmp/mc-iam-manager/pkg/envelope"
 	"gorm.io/gorm"
 )
 
@@ -206,6 +208,9 @@ func (r *mcmpApiRepository) GetAllAPIDefinitions(serviceNameFilter, actionNameFi
 			Method:       dbAction.Method,
 			ResourcePath: dbAction.ResourcePath,
 			Description:  dbAction.Description,
+			Parameters:   dbAction.Parameters,
+			RequestBody:  dbAction.RequestBody,
+			Security:     dbAction.Security,
 		}
 	}
 
@@ -245,6 +250,11 @@ func (r *mcmpApiRepository) UpdateService(serviceName string, updates map[string
 		return errors.New("no updatable fields provided")
 	}
 
+	// map 업데이트는 serializer를 거치지 않으므로 auth_pass는 직접 암호화
+	if err := encryptAuthPassUpdate(updates); err != nil {
+		return err
+	}
+
 	// Ensure the map uses correct DB column names if necessary,
 	// but GORM usually handles mapping from struct field names in Updates.
 	// The issue was likely the presence of a key not matching a column.
@@ -401,3 +411,23 @@ func (r *mcmpApiRepository) UpsertService(tx *gorm.DB, service *mcmpapi.McmpApiS
 	log.Printf("Upserted service: %s (version: %s, baseURL: %s)", service.Name, service.Version, service.BaseURL)
 	return nil
 }
+
+// encryptAuthPassUpdate map 업데이트에 포함된 auth_pass 값을 암호화 (키 제공자가 없으면 평문 유지)
+func encryptAuthPassUpdate(updates map[string]interface{}) error {
+	provider := envelope.Default()
+	if provider == nil {
+		return nil
+	}
+	for _, key := range []string{"auth_pass", "AuthPass"} {
+		value, ok := updates[key].(string)
+		if !ok || value == "" || envelope.IsEncrypted(value) {
+			continue
+		}
+		encrypted, err := envelope.Encrypt(context.Background(), provider, value)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt auth_pass: %w", err)
+		}
+		updates[key] = encrypted
+	}
+	return nil
+}
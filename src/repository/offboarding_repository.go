@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+// OffboardingRepository 사용자 오프보딩 및 이관 대상 자원 조회 레포지토리
+type OffboardingRepository struct {
+	db *gorm.DB
+}
+
+// NewOffboardingRepository 새 OffboardingRepository 인스턴스 생성
+func NewOffboardingRepository(db *gorm.DB) *OffboardingRepository {
+	return &OffboardingRepository{db: db}
+}
+
+// Create 오프보딩 예약 생성
+func (r *OffboardingRepository) Create(offboarding *model.Offboarding) error {
+	return r.db.Create(offboarding).Error
+}
+
+// FindByID ID로 오프보딩 조회
+func (r *OffboardingRepository) FindByID(id uint) (*model.Offboarding, error) {
+	var offboarding model.Offboarding
+	if err := r.db.First(&offboarding, id).Error; err != nil {
+		return nil, err
+	}
+	return &offboarding, nil
+}
+
+// FindOpenByUserID 사용자의 진행 중(SCHEDULED/FAILED) 오프보딩 조회 (없으면 nil)
+func (r *OffboardingRepository) FindOpenByUserID(userID uint) (*model.Offboarding, error) {
+	var offboarding model.Offboarding
+	err := r.db.Where("user_id = ? AND status IN ?", userID,
+		[]model.OffboardingStatus{model.OffboardingStatusScheduled, model.OffboardingStatusFailed}).
+		First(&offboarding).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &offboarding, nil
+}
+
+// List 오프보딩 목록 조회 (실행 예정 순)
+func (r *OffboardingRepository) List(filter *model.OffboardingFilterRequest) ([]model.Offboarding, error) {
+	var offboardings []model.Offboarding
+	query := r.db.Order("scheduled_at ASC, id ASC")
+	if filter != nil {
+		if filter.Status != "" {
+			query = query.Where("status = ?", filter.Status)
+		}
+		if filter.UserID != 0 {
+			query = query.Where("user_id = ?", filter.UserID)
+		}
+	}
+	if err := query.Find(&offboardings).Error; err != nil {
+		return nil, err
+	}
+	return offboardings, nil
+}
+
+// FindDue 실행 예정 시각이 지난 SCHEDULED 오프보딩 목록
+func (r *OffboardingRepository) FindDue(now time.Time) ([]model.Offboarding, error) {
+	var offboardings []model.Offboarding
+	err := r.db.Where("status = ? AND scheduled_at <= ?", model.OffboardingStatusScheduled, now).
+		Order("scheduled_at ASC, id ASC").
+		Find(&offboardings).Error
+	return offboardings, err
+}
+
+// UpdateFromStatus 현재 상태가 from 중 하나인 경우에만 변경 (동시 실행/취소 방지). 변경되지 않으면 false 반환
+func (r *OffboardingRepository) UpdateFromStatus(offboarding *model.Offboarding, from ...model.OffboardingStatus) (bool, error) {
+	result := r.db.Model(offboarding).
+		Where("status IN ?", from).
+		Select("status", "executed_at", "report", "last_error").
+		Updates(offboarding)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// FindSoleAdminWorkspaces 사용자가 지정 역할(예: admin)을 가진 워크스페이스 중 다른 유효 관리자가 없는 워크스페이스 목록
+// 탈퇴 처리된 사용자의 할당은 관리자로 보지 않는다.
+func (r *OffboardingRepository) FindSoleAdminWorkspaces(userID uint, roleName string) ([]model.Workspace, error) {
+	adminWorkspaces := func() *gorm.DB {
+		return r.db.Model(&model.UserWorkspaceRole{}).
+			Joins("JOIN mcmp_role_masters ON mcmp_role_masters.id = mcmp_user_workspace_roles.role_id").
+			Joins("JOIN mcmp_users ON mcmp_users.id = mcmp_user_workspace_roles.user_id").
+			Where("mcmp_role_masters.name = ?", roleName).
+			Where("mcmp_users.status IS NULL OR mcmp_users.status <> ?", model.UserStatusWithdrawn).
+			Where(activeGrantClause("mcmp_user_workspace_roles"), grantNow(), grantNow()).
+			Select("mcmp_user_workspace_roles.workspace_id")
+	}
+
+	var workspaces []model.Workspace
+	err := r.db.Where("id IN (?)", adminWorkspaces().Where("mcmp_user_workspace_roles.user_id = ?", userID)).
+		Where("id NOT IN (?)", adminWorkspaces().Where("mcmp_user_workspace_roles.user_id <> ?", userID)).
+		Order("id").
+		Find(&workspaces).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find sole admin workspaces: %w", err)
+	}
+	return workspaces, nil
+}
+
+// FindSoleMemberGroups 사용자가 유일한 구성원인 그룹(조직) 목록
+func (r *OffboardingRepository) FindSoleMemberGroups(userID uint) ([]model.Organization, error) {
+	soleMember := r.db.Model(&model.UserOrganization{}).
+		Select("organization_id").
+		Group("organization_id").
+		Having("COUNT(*) = 1")
+
+	var groups []model.Organization
+	err := r.db.Joins("JOIN mcmp_user_organizations ON mcmp_user_organizations.organization_id = mcmp_organizations.id").
+		Where("mcmp_user_organizations.user_id = ?", userID).
+		Where("mcmp_organizations.id IN (?)", soleMember).
+		Order("mcmp_organizations.id").
+		Find(&groups).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find sole member groups: %w", err)
+	}
+	return groups, nil
+}
+
+// FindPendingInvitationsByInviter 사용자가 보낸 대기 중(PENDING/PENDING_APPROVAL) 워크스페이스 초대 목록
+func (r *OffboardingRepository) FindPendingInvitationsByInviter(userID uint) ([]model.WorkspaceInvitation, error) {
+	var invitations []model.WorkspaceInvitation
+	err := r.db.Where("inviter_user_id = ? AND status IN ?", userID,
+		[]model.InvitationStatus{model.InvitationStatusPending, model.InvitationStatusPendingApproval}).
+		Order("id").
+		Find(&invitations).Error
+	return invitations, err
+}
+
+// FindPendingAccessRequestIDs 사용자가 요청한 승인 대기 중 권한 상승 요청 ID 목록
+func (r *OffboardingRepository) FindPendingAccessRequestIDs(userID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&model.AccessRequest{}).
+		Where("requester_user_id = ? AND status = ?", userID, model.AccessRequestStatusPendingApproval).
+		Order("id").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// FindServiceAccountsByCreator 사용자가 생성한 서비스 계정 목록
+func (r *OffboardingRepository) FindServiceAccountsByCreator(kcUserID string) ([]model.ServiceAccount, error) {
+	var accounts []model.ServiceAccount
+	if kcUserID == "" {
+		return accounts, nil
+	}
+	err := r.db.Where("created_by = ?", kcUserID).Order("id").Find(&accounts).Error
+	return accounts, err
+}
+
+// CountCachedCredentials 사용자에게 발급되어 캐시된 유효 임시 자격 증명 수
+func (r *OffboardingRepository) CountCachedCredentials(kcUserID string) (int64, error) {
+	var count int64
+	if kcUserID == "" {
+		return 0, nil
+	}
+	err := r.db.Model(&model.TempCredential{}).
+		Where("issued_by = ? AND is_active = ? AND payload <> ''", kcUserID, true).
+		Count(&count).Error
+	return count, err
+}
+
+// TransferOwnership 사전 점검 결과의 이관 대상에 따라 워크스페이스 관리자 역할, 그룹 구성원, 초대, 서비스 계정 소유권을 한 트랜잭션으로 이관
+// 이관 대상이 없는 대기 중 초대는 거절 처리한다.
+func (r *OffboardingRepository) TransferOwnership(report *model.OffboardingReport, adminRoleID uint, kcIDs map[uint]string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, ws := range report.SoleAdminWorkspaces {
+			if ws.ReassignToUserID == nil {
+				continue
+			}
+			mapping := model.UserWorkspaceRole{UserID: *ws.ReassignToUserID, WorkspaceID: ws.WorkspaceID, RoleID: adminRoleID}
+			// 기간 제한 할당이 있으면 만료 없이 유효하도록 갱신
+			if err := tx.Where(model.UserWorkspaceRole{UserID: mapping.UserID, WorkspaceID: mapping.WorkspaceID, RoleID: mapping.RoleID}).
+				Assign(map[string]interface{}{"starts_at": nil, "expires_at": nil}).
+				FirstOrCreate(&mapping).Error; err != nil {
+				return fmt.Errorf("failed to assign workspace %d admin to user %d: %w", ws.WorkspaceID, mapping.UserID, err)
+			}
+		}
+		for _, group := range report.SoleMemberGroups {
+			if group.ReassignToUserID == nil {
+				continue
+			}
+			mapping := model.UserOrganization{UserID: *group.ReassignToUserID, OrganizationID: group.OrganizationID}
+			if err := tx.Where(mapping).FirstOrCreate(&mapping).Error; err != nil {
+				return fmt.Errorf("failed to add user %d to group %d: %w", mapping.UserID, group.OrganizationID, err)
+			}
+		}
+		for _, inv := range report.PendingInvitations {
+			updates := map[string]interface{}{"status": model.InvitationStatusRejected}
+			if inv.ReassignToUserID != nil {
+				updates = map[string]interface{}{"inviter_user_id": *inv.ReassignToUserID}
+			}
+			if err := tx.Model(&model.WorkspaceInvitation{}).Where("id = ?", inv.InvitationID).Updates(updates).Error; err != nil {
+				return fmt.Errorf("failed to transfer invitation %d: %w", inv.InvitationID, err)
+			}
+		}
+		for _, account := range report.OwnedServiceAccounts {
+			if account.ReassignToUserID == nil {
+				continue
+			}
+			if err := tx.Model(&model.ServiceAccount{}).Where("id = ?", account.ServiceAccountID).
+				Update("created_by", kcIDs[*account.ReassignToUserID]).Error; err != nil {
+				return fmt.Errorf("failed to transfer service account %d: %w", account.ServiceAccountID, err)
+			}
+		}
+		return nil
+	})
+}
@@ -487,6 +487,35 @@ func (r *OrganizationRepository) FindOrganizationUsers(orgID uint) ([]model.User
 	return users, nil
 }
 
+// FindDirectUserOrganizations 사용자가 직접 소속된 조직 목록 조회 (조직 코드순, 계층 정보 없음)
+func (r *OrganizationRepository) FindDirectUserOrganizations(userID uint) ([]model.Organization, error) {
+	var orgs []model.Organization
+	if err := r.db.Joins("JOIN mcmp_user_organizations uo ON uo.organization_id = mcmp_organizations.id").
+		Where("uo.user_id = ?", userID).
+		Order("mcmp_organizations.organization_code ASC").
+		Find(&orgs).Error; err != nil {
+		return nil, fmt.Errorf("error finding organizations for user %d: %w", userID, err)
+	}
+	return orgs, nil
+}
+
+// FindUserOrganizationMappings 전체 사용자-조직 매핑 조회 (사용자 정보 포함)
+func (r *OrganizationRepository) FindUserOrganizationMappings() ([]model.UserOrganization, error) {
+	var mappings []model.UserOrganization
+	if err := r.db.Preload("User").Order("organization_id ASC, user_id ASC").Find(&mappings).Error; err != nil {
+		return nil, fmt.Errorf("error finding user organization mappings: %w", err)
+	}
+	return mappings, nil
+}
+
+// RemoveAllOrganizationUsers 조직의 사용자 매핑 전체 제거
+func (r *OrganizationRepository) RemoveAllOrganizationUsers(orgID uint) error {
+	if err := r.db.Where("organization_id = ?", orgID).Delete(&model.UserOrganization{}).Error; err != nil {
+		return fmt.Errorf("error removing users from organization %d: %w", orgID, err)
+	}
+	return nil
+}
+
 // CountUserOrganizations 사용자 소속 조직 수 조회
 func (r *OrganizationRepository) CountUserOrganizations(userID uint) (int64, error) {
 	var count int64
@@ -8,6 +8,7 @@ import (
 	"github.com/m-cmp/mc-iam-manager/constants"
 	"github.com/m-cmp/mc-iam-manager/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var (
@@ -182,4 +183,61 @@ func (r *MciamPermissionRepository) CheckRoleMciamPermission(roleType constants.
 	return count > 0, nil
 }
 
+// SeedFrameworkPermissions 프레임워크 리소스 유형/권한을 등록(이름·설명 갱신)하고 기본 역할-권한 매핑을 추가
+// 기본 매핑은 해당 프레임워크 권한이 하나도 없는 역할에만 적용한다. 추가된 매핑 수를 반환
+func (r *MciamPermissionRepository) SeedFrameworkPermissions(frameworkID string, resourceTypes []model.ResourceType, permissions []model.MciamPermission, rolePermissions []model.MciamRolePermissionSeed) (int, error) {
+	assigned := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if len(resourceTypes) > 0 {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "framework_id"}, {Name: "id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"name", "description", "updated_at"}),
+			}).Create(&resourceTypes).Error; err != nil {
+				return fmt.Errorf("failed to upsert resource types: %w", err)
+			}
+		}
+		if len(permissions) > 0 {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"name", "description", "updated_at"}),
+			}).Create(&permissions).Error; err != nil {
+				return fmt.Errorf("failed to upsert permissions: %w", err)
+			}
+		}
+
+		for _, seed := range rolePermissions {
+			var roleIDs []uint
+			if err := tx.Model(&model.RoleMaster{}).
+				Joins("JOIN mcmp_role_subs ON mcmp_role_masters.id = mcmp_role_subs.role_id").
+				Where("mcmp_role_masters.name = ? AND mcmp_role_subs.role_type = ?", seed.Role, seed.RoleType).
+				Pluck("mcmp_role_masters.id", &roleIDs).Error; err != nil {
+				return fmt.Errorf("failed to find role %s: %w", seed.Role, err)
+			}
+			if len(roleIDs) == 0 || len(seed.Permissions) == 0 {
+				continue // 역할이 아직 없으면 다음 시드 때 적용
+			}
+			var existing int64
+			if err := tx.Model(&model.MciamRoleMciamPermission{}).
+				Where("role_type = ? AND role_id = ? AND permission_id LIKE ?", seed.RoleType, roleIDs[0], frameworkID+":%").
+				Count(&existing).Error; err != nil {
+				return err
+			}
+			if existing > 0 {
+				continue
+			}
+			mappings := make([]model.MciamRoleMciamPermission, 0, len(seed.Permissions))
+			for _, permissionID := range seed.Permissions {
+				mappings = append(mappings, model.MciamRoleMciamPermission{RoleType: seed.RoleType, RoleID: roleIDs[0], PermissionID: permissionID})
+			}
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&mappings)
+			if result.Error != nil {
+				return fmt.Errorf("failed to assign default permissions to role %s: %w", seed.Role, result.Error)
+			}
+			assigned += int(result.RowsAffected)
+		}
+		return nil
+	})
+	return assigned, err
+}
+
 // Note: Need similar functions for mcmp_csp_permissions and mciam_role_csp_permissions later.
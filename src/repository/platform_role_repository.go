@@ -115,6 +115,7 @@ func (r *PlatformRoleRepository) GetUserRoles(userID uint) ([]model.RoleMaster,
 		Joins("JOIN mcmp_user_platform_roles ON mcmp_role_masters.id = mcmp_user_platform_roles.role_id").
 		Joins("JOIN mcmp_role_subs ON mcmp_role_masters.id = mcmp_role_subs.role_id").
 		Where("mcmp_user_platform_roles.user_id = ? AND mcmp_role_subs.role_type = ?", userID, constants.RoleTypePlatform).
+		Where(notExpiredClause("mcmp_user_platform_roles"), grantNow()).
 		Find(&roles).Error; err != nil {
 		return nil, err
 	}
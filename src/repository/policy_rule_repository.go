@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+// PolicyRuleRepository 명시적 허용/거부 정책 규칙 데이터 접근
+type PolicyRuleRepository struct {
+	db *gorm.DB
+}
+
+// NewPolicyRuleRepository 새 PolicyRuleRepository 인스턴스 생성
+func NewPolicyRuleRepository(db *gorm.DB) *PolicyRuleRepository {
+	return &PolicyRuleRepository{db: db}
+}
+
+// Create 정책 규칙 생성
+func (r *PolicyRuleRepository) Create(rule *model.PolicyRule) error {
+	if err := r.db.Create(rule).Error; err != nil {
+		return fmt.Errorf("failed to create policy rule: %w", err)
+	}
+	return nil
+}
+
+// Update 정책 규칙 수정
+func (r *PolicyRuleRepository) Update(rule *model.PolicyRule) error {
+	if err := r.db.Save(rule).Error; err != nil {
+		return fmt.Errorf("failed to update policy rule: %w", err)
+	}
+	return nil
+}
+
+// Delete 정책 규칙 삭제
+func (r *PolicyRuleRepository) Delete(id uint) error {
+	if err := r.db.Delete(&model.PolicyRule{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete policy rule: %w", err)
+	}
+	return nil
+}
+
+// FindByID ID로 정책 규칙 조회 (없으면 nil)
+func (r *PolicyRuleRepository) FindByID(id uint) (*model.PolicyRule, error) {
+	var rule model.PolicyRule
+	if err := r.db.First(&rule, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get policy rule: %w", err)
+	}
+	return &rule, nil
+}
+
+// ExistsByName 이름 중복 확인 (excludeID는 수정 대상 자신)
+func (r *PolicyRuleRepository) ExistsByName(name string, excludeID uint) (bool, error) {
+	var count int64
+	query := r.db.Model(&model.PolicyRule{}).Where("name = ?", name)
+	if excludeID != 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check policy rule name: %w", err)
+	}
+	return count > 0, nil
+}
+
+// List 정책 규칙 목록
+func (r *PolicyRuleRepository) List(filter *model.PolicyRuleFilter) ([]model.PolicyRule, error) {
+	rules := make([]model.PolicyRule, 0)
+	query := r.db.Model(&model.PolicyRule{})
+	if filter != nil {
+		if filter.SubjectType != "" {
+			query = query.Where("subject_type = ?", filter.SubjectType)
+		}
+		if filter.SubjectID != 0 {
+			query = query.Where("subject_id = ?", filter.SubjectID)
+		}
+		if filter.Effect != "" {
+			query = query.Where("effect = ?", filter.Effect)
+		}
+	}
+	if err := query.Order("id").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to list policy rules: %w", err)
+	}
+	return rules, nil
+}
+
+// FindEnabledBySubjects 지정 역할/그룹에 붙은 활성 정책 규칙
+func (r *PolicyRuleRepository) FindEnabledBySubjects(roleIDs, groupIDs []uint) ([]model.PolicyRule, error) {
+	rules := make([]model.PolicyRule, 0)
+	if len(roleIDs) == 0 && len(groupIDs) == 0 {
+		return rules, nil
+	}
+	subjects := r.db.Where("1 = 0")
+	if len(roleIDs) > 0 {
+		subjects = subjects.Or("subject_type = ? AND subject_id IN ?", model.PolicyRuleSubjectRole, roleIDs)
+	}
+	if len(groupIDs) > 0 {
+		subjects = subjects.Or("subject_type = ? AND subject_id IN ?", model.PolicyRuleSubjectGroup, groupIDs)
+	}
+	if err := r.db.Where("enabled = ?", true).Where(subjects).Order("id").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to find policy rules: %w", err)
+	}
+	return rules, nil
+}
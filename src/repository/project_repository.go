@@ -214,3 +214,13 @@ func (r *ProjectRepository) FindAssignedWorkspaces(projectID uint) ([]*model.Wor
 	}
 	return workspaces, nil
 }
+
+// ClearProjectWorkspaceAssociations 프로젝트의 모든 워크스페이스 연결 제거
+func (r *ProjectRepository) ClearProjectWorkspaceAssociations(projectID uint) error {
+	project := model.Project{ID: projectID}
+	if err := r.db.Model(&project).Association("Workspaces").Clear(); err != nil {
+		return err
+	}
+	log.Printf("ClearProjectWorkspaceAssociations: Project ID %d", projectID)
+	return nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+// notExpiredClause 만료되지 않은 역할 할당 조건 (인자: now 1개)
+func notExpiredClause(table string) string {
+	return fmt.Sprintf("(%[1]s.expires_at IS NULL OR %[1]s.expires_at > ?)", table)
+}
+
+// activeGrantClause 유효 기간 내(시작됨 + 만료되지 않음) 역할 할당 조건 (인자: now 2개)
+func activeGrantClause(table string) string {
+	return fmt.Sprintf("(%[1]s.starts_at IS NULL OR %[1]s.starts_at <= ?) AND (%[1]s.expires_at IS NULL OR %[1]s.expires_at > ?)", table)
+}
+
+// grantNow 유효 기간 비교 기준 시각 (저장 값과 동일하게 UTC)
+func grantNow() time.Time {
+	return time.Now().UTC()
+}
+
+// RoleGrantRepository 기간 제한 역할 할당(만료 회수) 데이터 접근
+type RoleGrantRepository struct {
+	db *gorm.DB
+}
+
+// NewRoleGrantRepository 새 RoleGrantRepository 인스턴스 생성
+func NewRoleGrantRepository(db *gorm.DB) *RoleGrantRepository {
+	return &RoleGrantRepository{db: db}
+}
+
+// FindExpiredGrants 만료 시각이 지난 역할 할당 목록 (사용자 플랫폼/워크스페이스, 그룹 워크스페이스)
+func (r *RoleGrantRepository) FindExpiredGrants(now time.Time) ([]model.ExpiredRoleGrant, error) {
+	grants := make([]model.ExpiredRoleGrant, 0)
+
+	var platformGrants []model.ExpiredRoleGrant
+	err := r.db.Table("mcmp_user_platform_roles upr").
+		Select("upr.user_id, upr.role_id, rm.name AS role_name, u.kc_id AS kc_user_id").
+		Joins("JOIN mcmp_role_masters rm ON rm.id = upr.role_id").
+		Joins("JOIN mcmp_users u ON u.id = upr.user_id").
+		Where("upr.expires_at IS NOT NULL AND upr.expires_at <= ?", now).
+		Scan(&platformGrants).Error
+	if err != nil {
+		return nil, fmt.Errorf("error finding expired platform role grants: %w", err)
+	}
+	for _, g := range platformGrants {
+		g.RoleType = constants.RoleTypePlatform
+		grants = append(grants, g)
+	}
+
+	var workspaceGrants []model.ExpiredRoleGrant
+	err = r.db.Table("mcmp_user_workspace_roles uwr").
+		Select("uwr.user_id, uwr.workspace_id, uwr.role_id, rm.name AS role_name").
+		Joins("JOIN mcmp_role_masters rm ON rm.id = uwr.role_id").
+		Where("uwr.expires_at IS NOT NULL AND uwr.expires_at <= ?", now).
+		Scan(&workspaceGrants).Error
+	if err != nil {
+		return nil, fmt.Errorf("error finding expired workspace role grants: %w", err)
+	}
+	for _, g := range workspaceGrants {
+		g.RoleType = constants.RoleTypeWorkspace
+		grants = append(grants, g)
+	}
+
+	var groupGrants []model.ExpiredRoleGrant
+	err = r.db.Table("mcmp_group_workspace_roles gwr").
+		Select("gwr.group_id, gwr.workspace_id, gwr.role_id, rm.name AS role_name").
+		Joins("JOIN mcmp_role_masters rm ON rm.id = gwr.role_id").
+		Where("gwr.expires_at IS NOT NULL AND gwr.expires_at <= ?", now).
+		Scan(&groupGrants).Error
+	if err != nil {
+		return nil, fmt.Errorf("error finding expired group workspace role grants: %w", err)
+	}
+	for _, g := range groupGrants {
+		g.RoleType = constants.RoleTypeWorkspace
+		grants = append(grants, g)
+	}
+
+	return grants, nil
+}
+
+// DeleteExpiredGrant 만료된 역할 할당 삭제. 그 사이 기간이 연장된 경우 삭제하지 않는다 (삭제 여부 반환)
+func (r *RoleGrantRepository) DeleteExpiredGrant(grant model.ExpiredRoleGrant, now time.Time) (bool, error) {
+	query := r.db.Where("expires_at IS NOT NULL AND expires_at <= ?", now)
+	var result *gorm.DB
+	switch {
+	case grant.GroupID != 0:
+		result = query.Where("group_id = ? AND workspace_id = ? AND role_id = ?", grant.GroupID, grant.WorkspaceID, grant.RoleID).
+			Delete(&model.GroupWorkspaceRole{})
+	case grant.RoleType == constants.RoleTypePlatform:
+		result = query.Where("user_id = ? AND role_id = ?", grant.UserID, grant.RoleID).
+			Delete(&model.UserPlatformRole{})
+	default:
+		result = query.Where("user_id = ? AND workspace_id = ? AND role_id = ?", grant.UserID, grant.WorkspaceID, grant.RoleID).
+			Delete(&model.UserWorkspaceRole{})
+	}
+	if result.Error != nil {
+		return false, fmt.Errorf("error deleting expired role grant: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+// maxRoleHierarchyDepth 역할 상속 탐색 최대 깊이 (순환 데이터가 있어도 재귀가 끝나도록 제한)
+const maxRoleHierarchyDepth = 32
+
+// findRoleAncestors 역할별 상위 역할 ID 목록 (가까운 순, 자신과 중복 제외)
+func findRoleAncestors(db *gorm.DB, roleIDs []uint) (map[uint][]uint, error) {
+	result := make(map[uint][]uint)
+	if len(roleIDs) == 0 {
+		return result, nil
+	}
+	var rows []struct {
+		RoleID     uint
+		AncestorID uint
+		Depth      int
+	}
+	err := db.Raw(`
+		WITH RECURSIVE ancestors AS (
+			SELECT id AS role_id, parent_id AS ancestor_id, 1 AS depth
+			FROM mcmp_role_masters
+			WHERE id IN ? AND parent_id IS NOT NULL
+			UNION ALL
+			SELECT a.role_id, rm.parent_id, a.depth + 1
+			FROM ancestors a
+			INNER JOIN mcmp_role_masters rm ON rm.id = a.ancestor_id
+			WHERE rm.parent_id IS NOT NULL AND a.depth < ?
+		)
+		SELECT role_id, ancestor_id, depth FROM ancestors ORDER BY role_id, depth
+	`, roleIDs, maxRoleHierarchyDepth).Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find role ancestors: %w", err)
+	}
+
+	seen := make(map[[2]uint]bool)
+	for _, row := range rows {
+		key := [2]uint{row.RoleID, row.AncestorID}
+		if row.AncestorID == row.RoleID || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result[row.RoleID] = append(result[row.RoleID], row.AncestorID)
+	}
+	return result, nil
+}
+
+// expandRoleIDs 역할 ID 목록에 상위 역할 ID를 더한 목록 (입력 순서 유지, 중복 제거)
+func expandRoleIDs(db *gorm.DB, roleIDs []uint) ([]uint, error) {
+	ancestors, err := findRoleAncestors(db, roleIDs)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[uint]bool)
+	var expanded []uint
+	for _, id := range roleIDs {
+		for _, candidate := range append([]uint{id}, ancestors[id]...) {
+			if !seen[candidate] {
+				seen[candidate] = true
+				expanded = append(expanded, candidate)
+			}
+		}
+	}
+	return expanded, nil
+}
+
+// FindRoleAncestors 역할별 상위 역할 ID 목록 (가까운 순)
+func (r *RoleRepository) FindRoleAncestors(roleIDs []uint) (map[uint][]uint, error) {
+	return findRoleAncestors(r.db, roleIDs)
+}
+
+// ExpandRoleIDs 역할 ID 목록에 상속 대상 상위 역할 ID를 더한 목록
+func (r *RoleRepository) ExpandRoleIDs(roleIDs []uint) ([]uint, error) {
+	return expandRoleIDs(r.db, roleIDs)
+}
+
+// FindRoleNames 역할 ID → 이름
+func (r *RoleRepository) FindRoleNames(roleIDs []uint) (map[uint]string, error) {
+	names := make(map[uint]string)
+	if len(roleIDs) == 0 {
+		return names, nil
+	}
+	var roles []model.RoleMaster
+	if err := r.db.Select("id", "name").Where("id IN ?", roleIDs).Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	for _, role := range roles {
+		names[role.ID] = role.Name
+	}
+	return names, nil
+}
+
+// FindRoleMenuIDs 역할별 매핑된 메뉴 ID 목록
+func (r *RoleRepository) FindRoleMenuIDs(roleIDs []uint) (map[uint][]string, error) {
+	result := make(map[uint][]string)
+	if len(roleIDs) == 0 {
+		return result, nil
+	}
+	var mappings []model.RoleMenuMapping
+	if err := r.db.Where("role_id IN ?", roleIDs).Order("menu_id").Find(&mappings).Error; err != nil {
+		return nil, err
+	}
+	for _, m := range mappings {
+		result[m.RoleID] = append(result[m.RoleID], m.MenuID)
+	}
+	return result, nil
+}
+
+// FindRolePermissionIDs 역할별 MciamPermission ID 목록
+func (r *RoleRepository) FindRolePermissionIDs(roleType constants.IAMRoleType, roleIDs []uint) (map[uint][]string, error) {
+	result := make(map[uint][]string)
+	if len(roleIDs) == 0 {
+		return result, nil
+	}
+	var mappings []model.MciamRoleMciamPermission
+	if err := r.db.Where("role_type = ? AND role_id IN ?", roleType, roleIDs).Order("permission_id").Find(&mappings).Error; err != nil {
+		return nil, err
+	}
+	for _, m := range mappings {
+		result[m.RoleID] = append(result[m.RoleID], m.PermissionID)
+	}
+	return result, nil
+}
+
+// FindRoleCspRoles 역할별 CSP 역할 매핑 (CSP 역할 정보 포함)
+func (r *RoleRepository) FindRoleCspRoles(roleIDs []uint) (map[uint][]model.RoleEffectiveCspRole, error) {
+	result := make(map[uint][]model.RoleEffectiveCspRole)
+	if len(roleIDs) == 0 {
+		return result, nil
+	}
+	var rows []struct {
+		RoleID     uint
+		CspRoleID  uint
+		Name       string
+		CspType    string
+		AuthMethod string
+	}
+	err := r.db.Table("mcmp_role_csp_role_mappings m").
+		Select("m.role_id, m.csp_role_id, cr.name, cr.csp_type, m.auth_method").
+		Joins("JOIN mcmp_role_csp_roles cr ON cr.id = m.csp_role_id").
+		Where("m.role_id IN ?", roleIDs).
+		Order("cr.csp_type, cr.name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		result[row.RoleID] = append(result[row.RoleID], model.RoleEffectiveCspRole{
+			CspRoleID:  row.CspRoleID,
+			Name:       row.Name,
+			CspType:    row.CspType,
+			AuthMethod: row.AuthMethod,
+		})
+	}
+	return result, nil
+}
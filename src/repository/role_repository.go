@@ -3,6 +3,7 @@ package repository
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/m-cmp/mc-iam-manager/constants"
 	"github.com/m-cmp/mc-iam-manager/model"
@@ -44,6 +45,11 @@ func (r *RoleRepository) FindRoles(req *model.RoleFilterRequest) ([]*model.RoleM
 		query = query.Where("mcmp_role_masters.name = ?", req.RoleName)
 	}
 
+	// 회사 전용 역할 + 공용 역할
+	if req.CompanyID != nil {
+		query = query.Where("mcmp_role_masters.company_id IS NULL OR mcmp_role_masters.company_id = ?", *req.CompanyID)
+	}
+
 	if err := query.Find(&roles).Error; err != nil {
 		return nil, fmt.Errorf("역할 목록 조회 실패: %w", err)
 	}
@@ -265,8 +271,8 @@ func (r *RoleRepository) UpdateRoleWithSubs(role model.RoleMaster, roleTypes []c
 func (r *RoleRepository) UpdateRoleWithSubsWithTx(tx *gorm.DB, role model.RoleMaster, roleTypes []constants.IAMRoleType) (*model.RoleMaster, error) {
 	var updatedRole *model.RoleMaster
 
-	// 1. 역할 마스터 수정
-	if err := tx.Save(&role).Error; err != nil {
+	// 1. 역할 마스터 수정 (소유 회사는 생성 시에만 지정)
+	if err := tx.Omit("company_id").Save(&role).Error; err != nil {
 		return nil, fmt.Errorf("역할 수정 실패: %w", err)
 	}
 
@@ -296,13 +302,26 @@ func (r *RoleRepository) UpdateRoleWithSubsWithTx(tx *gorm.DB, role model.RoleMa
 
 // AssignPlatformRole 플랫폼 역할 할당
 func (r *RoleRepository) AssignPlatformRole(userID, roleID uint) error {
+	return r.AssignPlatformRoleWithPeriod(userID, roleID, model.RoleGrantPeriod{})
+}
+
+// AssignPlatformRoleWithPeriod 만료 시각을 지정한 플랫폼 역할 할당 (플랫폼 역할은 시작 시각 미지원)
+func (r *RoleRepository) AssignPlatformRoleWithPeriod(userID, roleID uint, period model.RoleGrantPeriod) error {
 	userRole := model.UserPlatformRole{
-		UserID: userID,
-		RoleID: roleID,
+		UserID:    userID,
+		RoleID:    roleID,
+		ExpiresAt: period.ExpiresAt,
 	}
 	return r.db.Create(&userRole).Error
 }
 
+// UpdatePlatformRoleExpiry 이미 할당된 플랫폼 역할의 만료 시각 변경 (nil이면 만료 없음)
+func (r *RoleRepository) UpdatePlatformRoleExpiry(userID, roleID uint, expiresAt *time.Time) error {
+	return r.db.Model(&model.UserPlatformRole{}).
+		Where("user_id = ? AND role_id = ?", userID, roleID).
+		Update("expires_at", expiresAt).Error
+}
+
 // RemovePlatformRole 플랫폼 역할 제거
 func (r *RoleRepository) RemovePlatformRole(userID, roleID uint) error {
 	return r.db.Where("user_id = ? AND role_id = ?", userID, roleID).
@@ -311,10 +330,17 @@ func (r *RoleRepository) RemovePlatformRole(userID, roleID uint) error {
 
 // AssignWorkspaceRole 워크스페이스 역할 할당
 func (r *RoleRepository) AssignWorkspaceRole(userID, workspaceID, roleID uint) error {
+	return r.AssignWorkspaceRoleWithPeriod(userID, workspaceID, roleID, model.RoleGrantPeriod{})
+}
+
+// AssignWorkspaceRoleWithPeriod 유효 기간을 지정한 워크스페이스 역할 할당
+func (r *RoleRepository) AssignWorkspaceRoleWithPeriod(userID, workspaceID, roleID uint, period model.RoleGrantPeriod) error {
 	userWorkspaceRole := model.UserWorkspaceRole{
 		UserID:      userID,
 		WorkspaceID: workspaceID,
 		RoleID:      roleID,
+		StartsAt:    period.StartsAt,
+		ExpiresAt:   period.ExpiresAt,
 	}
 	return r.db.Create(&userWorkspaceRole).Error
 }
@@ -334,7 +360,8 @@ func (r *RoleRepository) FindUserWorkspaceRoles(userID, workspaceID uint) ([]mod
 		Joins("JOIN mcmp_users ON mcmp_users.id = mcmp_user_workspace_roles.user_id").
 		Joins("JOIN mcmp_workspaces ON mcmp_workspaces.id = mcmp_user_workspace_roles.workspace_id").
 		Select("mcmp_user_workspace_roles.*, mcmp_users.username, mcmp_role_masters.name as role_name, mcmp_workspaces.name as workspace_name").
-		Where("mcmp_user_workspace_roles.user_id = ? AND mcmp_role_subs.role_type = ?", userID, constants.RoleTypeWorkspace)
+		Where("mcmp_user_workspace_roles.user_id = ? AND mcmp_role_subs.role_type = ?", userID, constants.RoleTypeWorkspace).
+		Where(activeGrantClause("mcmp_user_workspace_roles"), grantNow(), grantNow())
 
 	if workspaceID != 0 {
 		query = query.Where("mcmp_user_workspace_roles.workspace_id = ?", workspaceID)
@@ -354,6 +381,7 @@ func (r *RoleRepository) FindUserPlatformRoles(userID uint) ([]model.RoleMaster,
 		Joins("JOIN mcmp_user_platform_roles ON mcmp_role_masters.id = mcmp_user_platform_roles.role_id").
 		Joins("JOIN mcmp_role_subs ON mcmp_role_masters.id = mcmp_role_subs.role_id").
 		Where("mcmp_user_platform_roles.user_id = ? AND mcmp_role_subs.role_type = ?", userID, constants.RoleTypePlatform).
+		Where(notExpiredClause("mcmp_user_platform_roles"), grantNow()).
 		Find(&roles).Error
 	if err != nil {
 		return nil, err
@@ -416,6 +444,15 @@ func (r *RoleRepository) DeleteRoleCspRoleMappings(roleID uint) error {
 		Delete(&model.RoleMasterCspRoleMapping{}).Error
 }
 
+// FindCspRoleMappingsByRoleID 해당 Role 의 모든 csp 역할 매핑 조회 (auth method 구분 없음)
+func (r *RoleRepository) FindCspRoleMappingsByRoleID(roleID uint) ([]model.RoleMasterCspRoleMapping, error) {
+	var mappings []model.RoleMasterCspRoleMapping
+	if err := r.db.Where("role_id = ?", roleID).Find(&mappings).Error; err != nil {
+		return nil, err
+	}
+	return mappings, nil
+}
+
 // CreateWorkspaceRoleCspRoleMapping 워크스페이스 역할-CSP 역할 매핑 생성
 // RoleSub = 'workspace' 가 없으면 생성하고 RoleSub = 'csp' 가 없으면 생성
 func (r *RoleRepository) CreateWorkspaceRoleCspRoleMapping(req *model.CreateCspRolesMappingRequest) error {
@@ -747,6 +784,7 @@ func (r *RoleRepository) GetUserWorkspaceRoles(userID, workspaceID uint) ([]mode
 		Joins("JOIN mcmp_role_subs ON mcmp_role_masters.id = mcmp_role_subs.role_id").
 		Where("mcmp_user_workspace_roles.user_id = ? AND mcmp_user_workspace_roles.workspace_id = ? AND mcmp_role_subs.role_type = ?",
 			userID, workspaceID, constants.RoleTypeWorkspace).
+		Where(activeGrantClause("mcmp_user_workspace_roles"), grantNow(), grantNow()).
 		Find(&roles).Error; err != nil {
 		return nil, err
 	}
@@ -815,6 +853,7 @@ func (r *RoleRepository) CheckUserInWorkspace(workspaceID, userID uint) (bool, e
 	var count int64
 	err := r.db.Model(&model.UserWorkspaceRole{}).
 		Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+		Where(activeGrantClause("mcmp_user_workspace_roles"), grantNow(), grantNow()).
 		Count(&count).Error
 	if err != nil {
 		return false, err
@@ -1109,17 +1148,18 @@ func (r *RoleRepository) FindRoleMasterMappings(req *model.FilterRoleMasterMappi
 // FindEffectivePlatformRoles 사용자의 유효 플랫폼 역할 목록 조회 (직접 할당 + 그룹 상속 통합, 중복 제거)
 func (r *RoleRepository) FindEffectivePlatformRoles(userID uint) ([]model.RoleMaster, error) {
 	var roles []model.RoleMaster
+	now := grantNow()
 	err := r.db.Raw(`
 		SELECT DISTINCT rm.*
 		FROM mcmp_role_masters rm
 		WHERE rm.id IN (
-			SELECT role_id FROM mcmp_user_platform_roles WHERE user_id = ?
+			SELECT upr.role_id FROM mcmp_user_platform_roles upr WHERE upr.user_id = ? AND `+notExpiredClause("upr")+`
 			UNION
 			SELECT gpr.role_id FROM mcmp_group_platform_roles gpr
 			JOIN mcmp_user_organizations uo ON uo.organization_id = gpr.group_id
 			WHERE uo.user_id = ?
 		)
-	`, userID, userID).Scan(&roles).Error
+	`, userID, now, userID).Scan(&roles).Error
 	if err != nil {
 		return nil, err
 	}
@@ -1129,18 +1169,20 @@ func (r *RoleRepository) FindEffectivePlatformRoles(userID uint) ([]model.RoleMa
 // FindEffectiveWorkspaceRoles 사용자의 유효 워크스페이스 역할 목록 조회 (직접 할당 + 그룹 상속 통합, 중복 제거)
 func (r *RoleRepository) FindEffectiveWorkspaceRoles(userID uint) ([]model.EffectiveWorkspaceRole, error) {
 	var roles []model.EffectiveWorkspaceRole
+	now := grantNow()
 	err := r.db.Raw(`
 		SELECT DISTINCT uwr.workspace_id, w.name AS workspace_name, uwr.role_id, rm.name AS role_name
 		FROM (
-			SELECT workspace_id, role_id FROM mcmp_user_workspace_roles WHERE user_id = ?
+			SELECT d.workspace_id, d.role_id FROM mcmp_user_workspace_roles d
+			WHERE d.user_id = ? AND `+activeGrantClause("d")+`
 			UNION
 			SELECT gwr.workspace_id, gwr.role_id FROM mcmp_group_workspace_roles gwr
 			JOIN mcmp_user_organizations uo ON uo.organization_id = gwr.group_id
-			WHERE uo.user_id = ?
+			WHERE uo.user_id = ? AND `+activeGrantClause("gwr")+`
 		) uwr
 		JOIN mcmp_workspaces w ON w.id = uwr.workspace_id
 		JOIN mcmp_role_masters rm ON rm.id = uwr.role_id
-	`, userID, userID).Scan(&roles).Error
+	`, userID, now, now, userID, now, now).Scan(&roles).Error
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+// ServiceAccountRepository 서비스 계정 및 API 키 데이터 접근
+type ServiceAccountRepository struct {
+	db *gorm.DB
+}
+
+// NewServiceAccountRepository 새 ServiceAccountRepository 인스턴스 생성
+func NewServiceAccountRepository(db *gorm.DB) *ServiceAccountRepository {
+	return &ServiceAccountRepository{db: db}
+}
+
+// CreateWithUser 역할 할당용 사용자 행과 서비스 계정을 함께 생성
+func (r *ServiceAccountRepository) CreateWithUser(account *model.ServiceAccount, user *model.User) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return fmt.Errorf("failed to create service account user: %w", err)
+		}
+		account.UserID = user.ID
+		account.KcUserID = user.KcId
+		if err := tx.Create(account).Error; err != nil {
+			return fmt.Errorf("failed to create service account: %w", err)
+		}
+		return nil
+	})
+}
+
+// FindByID ID로 서비스 계정 조회 (없으면 nil)
+func (r *ServiceAccountRepository) FindByID(id uint) (*model.ServiceAccount, error) {
+	var account model.ServiceAccount
+	if err := r.db.First(&account, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get service account: %w", err)
+	}
+	return &account, nil
+}
+
+// ExistsByName 이름 중복 확인
+func (r *ServiceAccountRepository) ExistsByName(name string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&model.ServiceAccount{}).Where("name = ?", name).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check service account name: %w", err)
+	}
+	return count > 0, nil
+}
+
+// List 서비스 계정 목록
+func (r *ServiceAccountRepository) List() ([]model.ServiceAccount, error) {
+	accounts := make([]model.ServiceAccount, 0)
+	if err := r.db.Order("id").Find(&accounts).Error; err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+	return accounts, nil
+}
+
+// Update 서비스 계정 저장
+func (r *ServiceAccountRepository) Update(account *model.ServiceAccount) error {
+	if err := r.db.Save(account).Error; err != nil {
+		return fmt.Errorf("failed to update service account: %w", err)
+	}
+	return nil
+}
+
+// DeleteWithUser 서비스 계정, API 키, 역할 할당, 사용자 행 삭제
+func (r *ServiceAccountRepository) DeleteWithUser(account *model.ServiceAccount) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("service_account_id = ?", account.ID).Delete(&model.ServiceAccountApiKey{}).Error; err != nil {
+			return fmt.Errorf("failed to delete api keys: %w", err)
+		}
+		if err := tx.Delete(&model.ServiceAccount{}, account.ID).Error; err != nil {
+			return fmt.Errorf("failed to delete service account: %w", err)
+		}
+		if err := tx.Where("user_id = ?", account.UserID).Delete(&model.UserPlatformRole{}).Error; err != nil {
+			return fmt.Errorf("failed to delete platform roles: %w", err)
+		}
+		if err := tx.Where("user_id = ?", account.UserID).Delete(&model.UserWorkspaceRole{}).Error; err != nil {
+			return fmt.Errorf("failed to delete workspace roles: %w", err)
+		}
+		if err := tx.Delete(&model.User{}, account.UserID).Error; err != nil {
+			return fmt.Errorf("failed to delete service account user: %w", err)
+		}
+		return nil
+	})
+}
+
+// CreateApiKey API 키 저장
+func (r *ServiceAccountRepository) CreateApiKey(key *model.ServiceAccountApiKey) error {
+	if err := r.db.Create(key).Error; err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+	return nil
+}
+
+// ListApiKeys 서비스 계정의 API 키 목록 (최신순)
+func (r *ServiceAccountRepository) ListApiKeys(serviceAccountID uint) ([]model.ServiceAccountApiKey, error) {
+	keys := make([]model.ServiceAccountApiKey, 0)
+	if err := r.db.Where("service_account_id = ?", serviceAccountID).Order("id DESC").Find(&keys).Error; err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	return keys, nil
+}
+
+// FindApiKeyByPrefix 접두어로 API 키 조회 (없으면 nil)
+func (r *ServiceAccountRepository) FindApiKeyByPrefix(prefix string) (*model.ServiceAccountApiKey, error) {
+	var key model.ServiceAccountApiKey
+	if err := r.db.Where("prefix = ?", prefix).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	return &key, nil
+}
+
+// RevokeApiKey 서비스 계정의 API 키 폐기 (이미 폐기되었거나 없으면 false)
+func (r *ServiceAccountRepository) RevokeApiKey(serviceAccountID, keyID uint, now time.Time) (bool, error) {
+	result := r.db.Model(&model.ServiceAccountApiKey{}).
+		Where("id = ? AND service_account_id = ? AND revoked_at IS NULL", keyID, serviceAccountID).
+		Update("revoked_at", now)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to revoke api key: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// TouchApiKey 마지막 사용 시각/IP 기록
+func (r *ServiceAccountRepository) TouchApiKey(keyID uint, now time.Time, ip string) error {
+	return r.db.Model(&model.ServiceAccountApiKey{}).Where("id = ?", keyID).
+		Updates(map[string]interface{}{"last_used_at": now, "last_used_ip": ip}).Error
+}
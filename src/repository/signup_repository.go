@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+// SignupRepository 셀프 가입 신청 레포지토리
+type SignupRepository struct {
+	db *gorm.DB
+}
+
+// NewSignupRepository 새 SignupRepository 인스턴스 생성
+func NewSignupRepository(db *gorm.DB) *SignupRepository {
+	return &SignupRepository{db: db}
+}
+
+// Create 가입 신청 생성
+func (r *SignupRepository) Create(signup *model.Signup) error {
+	return r.db.Create(signup).Error
+}
+
+// FindByID ID로 가입 신청 조회
+func (r *SignupRepository) FindByID(id uint) (*model.Signup, error) {
+	var signup model.Signup
+	if err := r.db.First(&signup, id).Error; err != nil {
+		return nil, err
+	}
+	return &signup, nil
+}
+
+// FindByKcUserID Keycloak 사용자 ID로 가입 신청 조회 (없으면 nil)
+func (r *SignupRepository) FindByKcUserID(kcUserID string) (*model.Signup, error) {
+	var signup model.Signup
+	if err := r.db.Where("kc_user_id = ?", kcUserID).First(&signup).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &signup, nil
+}
+
+// List 가입 신청 목록 조회 (최근 신청 순)
+func (r *SignupRepository) List(filter *model.SignupFilterRequest) ([]model.Signup, error) {
+	var signups []model.Signup
+	query := r.db.Order("created_at DESC, id DESC")
+	if filter != nil {
+		if filter.Status != "" {
+			query = query.Where("status = ?", filter.Status)
+		}
+		if filter.Email != "" {
+			query = query.Where("LOWER(email) LIKE ?", "%"+strings.ToLower(filter.Email)+"%")
+		}
+		if filter.Domain != "" {
+			query = query.Where("email_domain = ?", strings.ToLower(strings.TrimPrefix(filter.Domain, "@")))
+		}
+		if filter.CompanyID != nil {
+			query = query.Where("company_id = ?", *filter.CompanyID)
+		}
+	}
+	if err := query.Find(&signups).Error; err != nil {
+		return nil, err
+	}
+	return signups, nil
+}
+
+// UpdateFromStatus 현재 상태가 from인 경우에만 변경 (동시 승인/거절 방지). 변경되지 않으면 false 반환
+func (r *SignupRepository) UpdateFromStatus(signup *model.Signup, from model.SignupStatus) (bool, error) {
+	result := r.db.Model(signup).
+		Where("status = ?", from).
+		Select("status", "auto_approved", "assigned_platform_role", "assigned_org_ids",
+			"reason", "reviewer_user_id", "email_verified_at", "decided_at").
+		Updates(signup)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
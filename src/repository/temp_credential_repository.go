@@ -189,3 +189,56 @@ func (r *TempCredentialRepository) GetCredentialsByIssuedBy(issuedBy string) ([]
 
 	return credentials, nil
 }
+
+// FindCachedCredential 발급 캐시 조회 (key의 사용자, 워크스페이스, 역할, CSP 역할, CSP, 인증방식, 리전 기준, 잔여 시간이 minRemaining 이상인 것만)
+func (r *TempCredentialRepository) FindCachedCredential(key *model.TempCredential, minRemaining time.Duration) (*model.TempCredential, error) {
+	var credential model.TempCredential
+	err := cachedCredentialKeyQuery(r.db, key).
+		Where("expires_at > ? AND payload <> ''", time.Now().Add(minRemaining)).
+		Order("expires_at DESC").
+		First(&credential).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get cached credential: %w", err)
+	}
+	return &credential, nil
+}
+
+// SaveCachedCredential 발급 캐시 저장. 같은 키(사용자, 워크스페이스, 역할, CSP 역할, CSP, 인증방식, 리전)의 기존 캐시는 비활성화한다.
+func (r *TempCredentialRepository) SaveCachedCredential(credential *model.TempCredential) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := cachedCredentialKeyQuery(tx.Model(&model.TempCredential{}), credential).
+			Update("is_active", false).Error
+		if err != nil {
+			return fmt.Errorf("failed to deactivate cached credentials: %w", err)
+		}
+		if err := tx.Create(credential).Error; err != nil {
+			return fmt.Errorf("failed to create cached credential: %w", err)
+		}
+		return nil
+	})
+}
+
+// cachedCredentialKeyQuery 발급 캐시 키 조건 (활성 항목만)
+func cachedCredentialKeyQuery(db *gorm.DB, key *model.TempCredential) *gorm.DB {
+	return db.Where("provider = ? AND auth_type = ? AND region = ? AND role_master_id = ? AND workspace_id = ? AND role_arn = ? AND issued_by = ? AND is_active = ?",
+		key.Provider, key.AuthType, key.Region, key.RoleMasterID, key.WorkspaceID, key.RoleArn, key.IssuedBy, true)
+}
+
+// RevokeCachedCredentials 사용자 및/또는 역할의 발급 캐시 비활성화. 비활성화된 건수 반환
+func (r *TempCredentialRepository) RevokeCachedCredentials(issuedBy string, roleMasterID *uint) (int64, error) {
+	query := r.db.Model(&model.TempCredential{}).Where("is_active = ? AND payload <> ''", true)
+	if issuedBy != "" {
+		query = query.Where("issued_by = ?", issuedBy)
+	}
+	if roleMasterID != nil {
+		query = query.Where("role_master_id = ?", *roleMasterID)
+	}
+	result := query.Update("is_active", false)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to revoke cached credentials: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+// TokenRevocationRepository access token 폐기 목록 데이터 접근
+type TokenRevocationRepository struct {
+	db *gorm.DB
+}
+
+// NewTokenRevocationRepository 새 TokenRevocationRepository 인스턴스 생성
+func NewTokenRevocationRepository(db *gorm.DB) *TokenRevocationRepository {
+	return &TokenRevocationRepository{db: db}
+}
+
+// Create 폐기 항목 저장
+func (r *TokenRevocationRepository) Create(revocation *model.TokenRevocation) error {
+	if err := r.db.Create(revocation).Error; err != nil {
+		return fmt.Errorf("failed to create token revocation: %w", err)
+	}
+	return nil
+}
+
+// FindActive now 기준 아직 유효한(대상 토큰이 만료되지 않은) 폐기 항목 목록
+func (r *TokenRevocationRepository) FindActive(now time.Time) ([]model.TokenRevocation, error) {
+	revocations := make([]model.TokenRevocation, 0)
+	if err := r.db.Where("expires_at > ?", now).Order("id").Find(&revocations).Error; err != nil {
+		return nil, fmt.Errorf("failed to list token revocations: %w", err)
+	}
+	return revocations, nil
+}
+
+// DeleteExpired 만료된 폐기 항목 삭제
+func (r *TokenRevocationRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at <= ?", now).Delete(&model.TokenRevocation{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired token revocations: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
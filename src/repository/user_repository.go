@@ -95,6 +95,15 @@ func (r *UserRepository) GetUsersByKcIDs(kcIDs []string) ([]model.User, error) {
 	return dbUsers, nil
 }
 
+// FindAll retrieves all users from the local DB ordered by id (탈퇴 사용자 포함, Keycloak 정합성 점검용).
+func (r *UserRepository) FindAll() ([]model.User, error) {
+	var dbUsers []model.User
+	if err := r.db.Order("id").Find(&dbUsers).Error; err != nil {
+		return nil, fmt.Errorf("error fetching users from db: %w", err)
+	}
+	return dbUsers, nil
+}
+
 // CreateDbUser creates a new user record in the local database.
 func (r *UserRepository) Create(user *model.User) (*model.User, error) {
 	// Ensure ID is not set, let DB generate it
@@ -202,6 +211,7 @@ func (r *UserRepository) FindWorkspacesByUserID(userID uint) ([]*model.Workspace
 	// Select distinct workspaces associated with the user through the join table
 	err := r.db.Joins("JOIN mcmp_user_workspace_roles uwr ON uwr.workspace_id = mcmp_workspaces.id").
 		Where("uwr.user_id = ?", userID).
+		Where(activeGrantClause("uwr"), grantNow(), grantNow()).
 		Distinct("mcmp_workspaces.*").           // Select distinct workspace fields
 		Preload("Users", "user_id = ?", userID). // Preload users for the specific user
 		Preload("Users.User").                   // Preload user details
@@ -217,6 +227,10 @@ func (r *UserRepository) FindWorkspacesByUserID(userID uint) ([]*model.Workspace
 func (r *UserRepository) FindUserRoleInWorkspace(userID, workspaceID uint) (*model.UserWorkspaceRole, error) {
 	var userWorkspaceRole model.UserWorkspaceRole
 	err := r.db.Where("user_id = ? AND workspace_id = ?", userID, workspaceID).
+		Where(activeGrantClause("mcmp_user_workspace_roles"), grantNow(), grantNow()).
+		// 기간 제한(JIT) 할당을 상시 할당보다 우선한다
+		Order("CASE WHEN mcmp_user_workspace_roles.expires_at IS NULL THEN 1 ELSE 0 END").
+		Order("mcmp_user_workspace_roles.created_at DESC").
 		Preload("Workspace").
 		Preload("Role").
 		First(&userWorkspaceRole).Error
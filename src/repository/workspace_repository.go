@@ -66,6 +66,10 @@ func (r *WorkspaceRepository) FindWorkspaces(req *model.WorkspaceFilterRequest)
 	query := r.db.Model(&model.Workspace{})
 	log.Printf("req: %+v", req)
 
+	if req.CompanyID != nil {
+		query = query.Where("mcmp_workspaces.company_id = ?", *req.CompanyID)
+	}
+
 	if req.WorkspaceID != "" {
 		workspaceIdInt, err := util.StringToUint(req.WorkspaceID)
 		if err != nil {
@@ -135,6 +139,9 @@ func (r *WorkspaceRepository) FindWorkspacesProjects(req *model.WorkspaceFilterR
 	var workspacesProjects []*model.WorkspaceWithProjects
 	query := r.db.Model(&model.WorkspaceWithProjects{}).
 		Preload("Projects")
+	if req.CompanyID != nil {
+		query = query.Where("mcmp_workspaces.company_id = ?", *req.CompanyID)
+	}
 
 	// Single record query when filtering by WorkspaceID
 	if req.WorkspaceID != "" {
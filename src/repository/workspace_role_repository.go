@@ -93,6 +93,7 @@ func (r *WorkspaceRoleRepository) GetUserRoles(userID, workspaceID uint) ([]mode
 		Joins("JOIN mcmp_role_subs ON mcmp_role_masters.id = mcmp_role_subs.role_id").
 		Where("mcmp_user_workspace_roles.user_id = ? AND mcmp_user_workspace_roles.workspace_id = ? AND mcmp_role_subs.role_type = ?",
 			userID, workspaceID, constants.RoleTypeWorkspace).
+		Where(activeGrantClause("mcmp_user_workspace_roles"), grantNow(), grantNow()).
 		Find(&roles).Error; err != nil {
 		return nil, err
 	}
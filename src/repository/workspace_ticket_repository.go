@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"gorm.io/gorm"
+)
+
+// ErrWorkspaceTicketNotFound 워크스페이스 티켓 없음
+var ErrWorkspaceTicketNotFound = errors.New("workspace ticket not found")
+
+// WorkspaceTicketRepository 워크스페이스 티켓 발급 이력 관리
+type WorkspaceTicketRepository struct {
+	db *gorm.DB
+}
+
+// NewWorkspaceTicketRepository 새 WorkspaceTicketRepository 인스턴스 생성
+func NewWorkspaceTicketRepository(db *gorm.DB) *WorkspaceTicketRepository {
+	return &WorkspaceTicketRepository{db: db}
+}
+
+// Create 티켓 발급 이력 저장
+func (r *WorkspaceTicketRepository) Create(ticket *model.WorkspaceTicket) error {
+	return r.db.Create(ticket).Error
+}
+
+// FindByTicketID jti로 티켓 조회
+func (r *WorkspaceTicketRepository) FindByTicketID(ticketID string) (*model.WorkspaceTicket, error) {
+	var ticket model.WorkspaceTicket
+	if err := r.db.Where("ticket_id = ?", ticketID).First(&ticket).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWorkspaceTicketNotFound
+		}
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+// FindActiveByUserAndWorkspace keyID로 서명되어 폐기되지 않고 validUntil 이후까지 유효한 최신 티켓 조회
+func (r *WorkspaceTicketRepository) FindActiveByUserAndWorkspace(kcUserID string, workspaceID uint, keyID string, validUntil time.Time) (*model.WorkspaceTicket, error) {
+	var ticket model.WorkspaceTicket
+	err := r.db.Where("kc_user_id = ? AND workspace_id = ? AND key_id = ? AND revoked_at IS NULL AND expires_at > ?", kcUserID, workspaceID, keyID, validUntil).
+		Order("expires_at DESC").
+		First(&ticket).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWorkspaceTicketNotFound
+		}
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+// TouchLastUsed 마지막 사용 시각 갱신
+func (r *WorkspaceTicketRepository) TouchLastUsed(ticketID string) error {
+	return r.db.Model(&model.WorkspaceTicket{}).
+		Where("ticket_id = ?", ticketID).
+		Update("last_used_at", time.Now()).Error
+}
+
+// RevokeByTicketID 단일 티켓 폐기
+func (r *WorkspaceTicketRepository) RevokeByTicketID(ticketID, reason string) error {
+	result := r.db.Model(&model.WorkspaceTicket{}).
+		Where("ticket_id = ? AND revoked_at IS NULL", ticketID).
+		Updates(map[string]interface{}{"revoked_at": time.Now(), "revoked_reason": reason})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrWorkspaceTicketNotFound
+	}
+	return nil
+}
+
+// RevokeByGroupAndWorkspace 그룹 소속 사용자의 워크스페이스 티켓 일괄 폐기 (workspaceID 0이면 그룹에 역할이 있는 전체 워크스페이스)
+func (r *WorkspaceTicketRepository) RevokeByGroupAndWorkspace(groupID, workspaceID uint, reason string) (int64, error) {
+	members := r.db.Model(&model.UserOrganization{}).Select("user_id").Where("organization_id = ?", groupID)
+	query := r.db.Model(&model.WorkspaceTicket{}).
+		Where("user_id IN (?) AND revoked_at IS NULL AND expires_at > ?", members, time.Now())
+	if workspaceID != 0 {
+		query = query.Where("workspace_id = ?", workspaceID)
+	} else {
+		query = query.Where("workspace_id IN (?)",
+			r.db.Model(&model.GroupWorkspaceRole{}).Select("workspace_id").Where("group_id = ?", groupID))
+	}
+	result := query.Updates(map[string]interface{}{"revoked_at": time.Now(), "revoked_reason": reason})
+	return result.RowsAffected, result.Error
+}
+
+// RevokeByUserAndGroupWorkspaces 그룹에서 제외되는 사용자의, 그룹에 역할이 있는 워크스페이스 티켓 일괄 폐기
+func (r *WorkspaceTicketRepository) RevokeByUserAndGroupWorkspaces(userID, groupID uint, reason string) (int64, error) {
+	result := r.db.Model(&model.WorkspaceTicket{}).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Where("workspace_id IN (?)",
+			r.db.Model(&model.GroupWorkspaceRole{}).Select("workspace_id").Where("group_id = ?", groupID)).
+		Updates(map[string]interface{}{"revoked_at": time.Now(), "revoked_reason": reason})
+	return result.RowsAffected, result.Error
+}
+
+// RevokeByUserAndWorkspace 사용자의 워크스페이스 티켓 일괄 폐기 (workspaceID 0이면 전체 워크스페이스)
+func (r *WorkspaceTicketRepository) RevokeByUserAndWorkspace(userID, workspaceID uint, reason string) (int64, error) {
+	query := r.db.Model(&model.WorkspaceTicket{}).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now())
+	if workspaceID != 0 {
+		query = query.Where("workspace_id = ?", workspaceID)
+	}
+	result := query.Updates(map[string]interface{}{"revoked_at": time.Now(), "revoked_reason": reason})
+	return result.RowsAffected, result.Error
+}
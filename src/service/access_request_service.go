@@ -0,0 +1,378 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/config"
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+// workspaceAdminRoleName 워크스페이스 내 권한 상승 요청을 승인할 수 있는 워크스페이스 역할
+const workspaceAdminRoleName = "admin"
+
+var (
+	// ErrAccessRequestNotFound 요청 없음
+	ErrAccessRequestNotFound = errors.New("access request not found")
+	// ErrAccessRequestInvalid 요청 내용 오류
+	ErrAccessRequestInvalid = errors.New("invalid access request")
+	// ErrAccessRequestForbidden 요청에 대한 권한 없음
+	ErrAccessRequestForbidden = errors.New("forbidden")
+	// ErrAccessRequestNotPending 승인 대기 상태가 아님
+	ErrAccessRequestNotPending = errors.New("access request is not in PENDING_APPROVAL state")
+)
+
+// AccessRequestService JIT 권한 상승 요청 서비스
+// 승인 시 기간 제한 워크스페이스 역할을 부여하며, 만료 회수는 RoleGrantReaper가 담당한다.
+type AccessRequestService struct {
+	db             *gorm.DB
+	requestRepo    *repository.AccessRequestRepository
+	workspaceRepo  *repository.WorkspaceRepository
+	roleRepository *repository.RoleRepository
+}
+
+// NewAccessRequestService 새 AccessRequestService 인스턴스 생성
+func NewAccessRequestService(db *gorm.DB) *AccessRequestService {
+	return &AccessRequestService{
+		db:             db,
+		requestRepo:    repository.NewAccessRequestRepository(db),
+		workspaceRepo:  repository.NewWorkspaceRepository(db),
+		roleRepository: repository.NewRoleRepository(db),
+	}
+}
+
+// CreateAccessRequest 권한 상승 요청 생성
+func (s *AccessRequestService) CreateAccessRequest(requesterUserID uint, req *model.CreateAccessRequestRequest) (*model.AccessRequest, error) {
+	maxHours := config.AccessRequestMaxHours()
+	if req.DurationHours <= 0 || req.DurationHours > maxHours {
+		return nil, fmt.Errorf("%w: durationHours must be between 1 and %d", ErrAccessRequestInvalid, maxHours)
+	}
+	if req.Justification == "" {
+		return nil, fmt.Errorf("%w: justification is required", ErrAccessRequestInvalid)
+	}
+
+	ws, err := s.workspaceRepo.FindWorkspaceByID(req.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if ws == nil {
+		return nil, fmt.Errorf("%w: workspace not found", ErrAccessRequestInvalid)
+	}
+
+	roleID, err := s.resolveRoleID(req)
+	if err != nil {
+		return nil, err
+	}
+	role, err := s.roleRepository.FindRoleByRoleID(roleID, constants.RoleTypeWorkspace)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, fmt.Errorf("%w: workspace role not found", ErrAccessRequestInvalid)
+	}
+
+	// 만료 없이 이미 보유한 역할은 요청할 필요가 없다
+	existing, err := s.findWorkspaceRoleGrant(s.db, requesterUserID, req.WorkspaceID, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.ExpiresAt == nil {
+		return nil, fmt.Errorf("%w: role is already assigned without expiry", ErrAccessRequestInvalid)
+	}
+
+	hasPending, err := s.requestRepo.HasPendingRequest(requesterUserID, req.WorkspaceID, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if hasPending {
+		return nil, fmt.Errorf("%w: pending access request already exists for this role", ErrAccessRequestInvalid)
+	}
+
+	accessRequest := &model.AccessRequest{
+		RequesterUserID: requesterUserID,
+		Type:            req.Type,
+		WorkspaceID:     req.WorkspaceID,
+		RoleID:          roleID,
+		CspRoleID:       req.CspRoleID,
+		DurationHours:   req.DurationHours,
+		Justification:   req.Justification,
+		Status:          model.AccessRequestStatusPendingApproval,
+	}
+	if err := s.requestRepo.Create(accessRequest); err != nil {
+		return nil, err
+	}
+	return s.requestRepo.FindByID(accessRequest.ID)
+}
+
+// resolveRoleID 요청 유형에 따라 부여할 워크스페이스 역할 결정
+// CSP_ROLE은 해당 CSP 역할에 매핑된 워크스페이스 역할이어야 하며, 그 역할이 요청한 CSP 역할만 부여해야 한다.
+func (s *AccessRequestService) resolveRoleID(req *model.CreateAccessRequestRequest) (uint, error) {
+	switch req.Type {
+	case model.AccessRequestTypeWorkspaceRole:
+		if req.RoleID == 0 {
+			return 0, fmt.Errorf("%w: roleId is required", ErrAccessRequestInvalid)
+		}
+		return req.RoleID, nil
+	case model.AccessRequestTypeCspRole:
+		if req.CspRoleID == nil || *req.CspRoleID == 0 {
+			return 0, fmt.Errorf("%w: cspRoleId is required", ErrAccessRequestInvalid)
+		}
+		roleIDs, err := s.requestRepo.FindRoleIDsByCspRoleID(*req.CspRoleID)
+		if err != nil {
+			return 0, err
+		}
+		if len(roleIDs) == 0 {
+			return 0, fmt.Errorf("%w: csp role %d is not mapped to any role", ErrAccessRequestInvalid, *req.CspRoleID)
+		}
+		roleID := uint(0)
+		if req.RoleID == 0 {
+			if len(roleIDs) > 1 {
+				return 0, fmt.Errorf("%w: csp role %d is mapped to multiple roles, roleId is required", ErrAccessRequestInvalid, *req.CspRoleID)
+			}
+			roleID = roleIDs[0]
+		}
+		for _, id := range roleIDs {
+			if id == req.RoleID {
+				roleID = id
+			}
+		}
+		if roleID == 0 {
+			return 0, fmt.Errorf("%w: role %d is not mapped to csp role %d", ErrAccessRequestInvalid, req.RoleID, *req.CspRoleID)
+		}
+		if err := s.ensureCspRoleOnly(roleID, *req.CspRoleID); err != nil {
+			return 0, err
+		}
+		return roleID, nil
+	default:
+		return 0, fmt.Errorf("%w: unsupported type %q", ErrAccessRequestInvalid, req.Type)
+	}
+}
+
+// ensureCspRoleOnly 역할이 요청한 CSP 역할 외의 권한(다른 CSP 역할, MciamPermission, 상위 역할)을 갖지 않는지 확인
+// CSP_ROLE 요청 승인으로 워크스페이스 역할 전체가 부여되지 않도록 한다.
+func (s *AccessRequestService) ensureCspRoleOnly(roleID, cspRoleID uint) error {
+	role, err := s.roleRepository.FindRoleByRoleID(roleID, constants.RoleTypeWorkspace)
+	if err != nil {
+		return err
+	}
+	if role != nil && role.ParentID != nil {
+		return fmt.Errorf("%w: role %d inherits from a parent role, request it as WORKSPACE_ROLE", ErrAccessRequestInvalid, roleID)
+	}
+	mappings, err := s.roleRepository.FindCspRoleMappingsByRoleID(roleID)
+	if err != nil {
+		return err
+	}
+	for _, m := range mappings {
+		if m.CspRoleID != cspRoleID {
+			return fmt.Errorf("%w: role %d grants csp roles other than %d, request it as WORKSPACE_ROLE", ErrAccessRequestInvalid, roleID, cspRoleID)
+		}
+	}
+	permissions, err := s.roleRepository.FindRolePermissionIDs(constants.RoleTypeWorkspace, []uint{roleID})
+	if err != nil {
+		return err
+	}
+	if len(permissions[roleID]) > 0 {
+		return fmt.Errorf("%w: role %d grants workspace permissions besides csp role %d, request it as WORKSPACE_ROLE", ErrAccessRequestInvalid, roleID, cspRoleID)
+	}
+	return nil
+}
+
+// ListAccessRequests 요청 목록 조회
+// 플랫폼 관리자는 전체, 그 외 사용자는 본인 요청과 관리하는 워크스페이스의 요청만 조회한다.
+func (s *AccessRequestService) ListAccessRequests(callerUserID uint, isPlatformAdmin bool, filter *model.AccessRequestFilterRequest) ([]model.AccessRequest, error) {
+	if isPlatformAdmin {
+		return s.requestRepo.List(filter, 0, nil)
+	}
+	adminWorkspaceIDs, err := s.requestRepo.FindAdminWorkspaceIDs(callerUserID, workspaceAdminRoleName)
+	if err != nil {
+		return nil, err
+	}
+	return s.requestRepo.List(filter, callerUserID, adminWorkspaceIDs)
+}
+
+// GetAccessRequest 요청 상세 조회 (이력 포함). 요청자 또는 승인 가능한 사용자만 조회 가능
+func (s *AccessRequestService) GetAccessRequest(requestID, callerUserID uint, isPlatformAdmin bool) (*model.AccessRequest, error) {
+	accessRequest, err := s.findRequest(requestID)
+	if err != nil {
+		return nil, err
+	}
+	if accessRequest.RequesterUserID == callerUserID {
+		return accessRequest, nil
+	}
+	if err := s.checkApprover(accessRequest, callerUserID, isPlatformAdmin); err != nil {
+		return nil, err
+	}
+	return accessRequest, nil
+}
+
+// ApproveAccessRequest 요청 승인. 요청 시간만큼 만료되는 워크스페이스 역할을 부여한다.
+func (s *AccessRequestService) ApproveAccessRequest(requestID, approverUserID uint, isPlatformAdmin bool, comment string) (*model.AccessRequest, error) {
+	accessRequest, err := s.findPendingForDecision(requestID, approverUserID, isPlatformAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	accessRequest.Status = model.AccessRequestStatusApproved
+	accessRequest.ApproverUserID = &approverUserID
+	accessRequest.DecisionComment = comment
+	accessRequest.DecidedAt = &now
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		expiresAt, err := s.grantTimeBoundRole(tx, accessRequest, now.Add(time.Duration(accessRequest.DurationHours)*time.Hour))
+		if err != nil {
+			return err
+		}
+		accessRequest.GrantExpiresAt = &expiresAt
+		updated, err := s.requestRepo.UpdateDecision(tx, accessRequest, approverUserID)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return ErrAccessRequestNotPending
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.requestRepo.FindByID(requestID)
+}
+
+// grantTimeBoundRole 기간 제한 역할 부여. 이미 기간 제한으로 보유 중이면 즉시 유효하게 하고 만료 시각을 연장한다.
+// 실제 적용된 만료 시각 반환
+func (s *AccessRequestService) grantTimeBoundRole(tx *gorm.DB, accessRequest *model.AccessRequest, expiresAt time.Time) (time.Time, error) {
+	existing, err := s.findWorkspaceRoleGrant(tx, accessRequest.RequesterUserID, accessRequest.WorkspaceID, accessRequest.RoleID)
+	if err != nil {
+		return expiresAt, err
+	}
+	if existing == nil {
+		return expiresAt, repository.NewRoleRepository(tx).AssignWorkspaceRoleWithPeriod(
+			accessRequest.RequesterUserID, accessRequest.WorkspaceID, accessRequest.RoleID,
+			model.RoleGrantPeriod{ExpiresAt: &expiresAt})
+	}
+	if existing.ExpiresAt == nil {
+		return expiresAt, fmt.Errorf("%w: role is already assigned without expiry", ErrAccessRequestInvalid)
+	}
+	if existing.ExpiresAt.After(expiresAt) {
+		expiresAt = existing.ExpiresAt.UTC()
+	}
+	return expiresAt, tx.Model(&model.UserWorkspaceRole{}).
+		Where("user_id = ? AND workspace_id = ? AND role_id = ?",
+			accessRequest.RequesterUserID, accessRequest.WorkspaceID, accessRequest.RoleID).
+		Updates(map[string]interface{}{"starts_at": nil, "expires_at": expiresAt}).Error
+}
+
+// RejectAccessRequest 요청 거절
+func (s *AccessRequestService) RejectAccessRequest(requestID, approverUserID uint, isPlatformAdmin bool, comment string) (*model.AccessRequest, error) {
+	accessRequest, err := s.findPendingForDecision(requestID, approverUserID, isPlatformAdmin)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	accessRequest.Status = model.AccessRequestStatusRejected
+	accessRequest.ApproverUserID = &approverUserID
+	accessRequest.DecisionComment = comment
+	accessRequest.DecidedAt = &now
+	return s.applyDecision(accessRequest, approverUserID)
+}
+
+// CancelAccessRequest 요청자 본인의 승인 대기 요청 취소
+func (s *AccessRequestService) CancelAccessRequest(requestID, requesterUserID uint, comment string) (*model.AccessRequest, error) {
+	accessRequest, err := s.findRequest(requestID)
+	if err != nil {
+		return nil, err
+	}
+	if accessRequest.RequesterUserID != requesterUserID {
+		return nil, fmt.Errorf("%w: not your access request", ErrAccessRequestForbidden)
+	}
+	if accessRequest.Status != model.AccessRequestStatusPendingApproval {
+		return nil, ErrAccessRequestNotPending
+	}
+	now := time.Now().UTC()
+	accessRequest.Status = model.AccessRequestStatusCancelled
+	accessRequest.DecisionComment = comment
+	accessRequest.DecidedAt = &now
+	return s.applyDecision(accessRequest, requesterUserID)
+}
+
+func (s *AccessRequestService) applyDecision(accessRequest *model.AccessRequest, actorUserID uint) (*model.AccessRequest, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		updated, err := s.requestRepo.UpdateDecision(tx, accessRequest, actorUserID)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return ErrAccessRequestNotPending
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.requestRepo.FindByID(accessRequest.ID)
+}
+
+// findPendingForDecision 승인/거절 대상 요청 조회 및 승인자 권한 확인
+func (s *AccessRequestService) findPendingForDecision(requestID, approverUserID uint, isPlatformAdmin bool) (*model.AccessRequest, error) {
+	accessRequest, err := s.findRequest(requestID)
+	if err != nil {
+		return nil, err
+	}
+	if accessRequest.RequesterUserID == approverUserID {
+		return nil, fmt.Errorf("%w: cannot decide your own access request", ErrAccessRequestForbidden)
+	}
+	if err := s.checkApprover(accessRequest, approverUserID, isPlatformAdmin); err != nil {
+		return nil, err
+	}
+	if accessRequest.Status != model.AccessRequestStatusPendingApproval {
+		return nil, ErrAccessRequestNotPending
+	}
+	return accessRequest, nil
+}
+
+// checkApprover 플랫폼 관리자 또는 대상 워크스페이스 관리자인지 확인
+func (s *AccessRequestService) checkApprover(accessRequest *model.AccessRequest, userID uint, isPlatformAdmin bool) error {
+	if isPlatformAdmin {
+		return nil
+	}
+	workspaceIDs, err := s.requestRepo.FindAdminWorkspaceIDs(userID, workspaceAdminRoleName)
+	if err != nil {
+		return err
+	}
+	for _, id := range workspaceIDs {
+		if id == accessRequest.WorkspaceID {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: workspace admin or platform admin required", ErrAccessRequestForbidden)
+}
+
+func (s *AccessRequestService) findRequest(requestID uint) (*model.AccessRequest, error) {
+	accessRequest, err := s.requestRepo.FindByID(requestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAccessRequestNotFound
+		}
+		return nil, err
+	}
+	return accessRequest, nil
+}
+
+// findWorkspaceRoleGrant 기간과 무관하게 사용자/워크스페이스/역할 할당 행 조회 (없으면 nil)
+func (s *AccessRequestService) findWorkspaceRoleGrant(db *gorm.DB, userID, workspaceID, roleID uint) (*model.UserWorkspaceRole, error) {
+	var grant model.UserWorkspaceRole
+	err := db.Where("user_id = ? AND workspace_id = ? AND role_id = ?", userID, workspaceID, roleID).
+		Take(&grant).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &grant, nil
+}
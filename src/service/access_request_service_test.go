@@ -0,0 +1,244 @@
+package service
+
+// access_request_service_test.go
+// JIT 권한 상승 요청 서비스 단위 테스트 (SQLite in-memory DB)
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/config"
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type accessRequestFixture struct {
+	db        *gorm.DB
+	svc       *AccessRequestService
+	requester *model.User
+	wsAdmin   *model.User
+	outsider  *model.User
+	ws        *model.Workspace
+	viewer    *model.RoleMaster
+	operator  *model.RoleMaster
+}
+
+func newAccessRequestFixture(t *testing.T) *accessRequestFixture {
+	t.Helper()
+	db := setupGroupRoleTestDB(t)
+	require.NoError(t, db.AutoMigrate(
+		&model.AccessRequest{},
+		&model.AccessRequestEvent{},
+		&model.RoleMasterCspRoleMapping{},
+		&model.MciamRoleMciamPermission{},
+	))
+
+	f := &accessRequestFixture{
+		db:        db,
+		svc:       NewAccessRequestService(db),
+		requester: createGRTestUser(t, db, "dev", "kc-dev"),
+		wsAdmin:   createGRTestUser(t, db, "lead", "kc-lead"),
+		outsider:  createGRTestUser(t, db, "other", "kc-other"),
+		ws:        createGRTestWorkspace(t, db, "ws-prod"),
+		viewer:    createGRTestRole(t, db, "viewer"),
+		operator:  createGRTestRole(t, db, "operator"),
+	}
+	admin := createGRTestRole(t, db, workspaceAdminRoleName)
+
+	roleRepo := repository.NewRoleRepository(db)
+	require.NoError(t, roleRepo.AssignWorkspaceRole(f.requester.ID, f.ws.ID, f.viewer.ID))
+	require.NoError(t, roleRepo.AssignWorkspaceRole(f.wsAdmin.ID, f.ws.ID, admin.ID))
+	return f
+}
+
+func (f *accessRequestFixture) create(t *testing.T) *model.AccessRequest {
+	t.Helper()
+	req, err := f.svc.CreateAccessRequest(f.requester.ID, &model.CreateAccessRequestRequest{
+		Type:          model.AccessRequestTypeWorkspaceRole,
+		WorkspaceID:   f.ws.ID,
+		RoleID:        f.operator.ID,
+		DurationHours: 2,
+		Justification: "incident INC-42",
+	})
+	require.NoError(t, err)
+	return req
+}
+
+func TestCreateAccessRequest_Validation(t *testing.T) {
+	f := newAccessRequestFixture(t)
+
+	base := model.CreateAccessRequestRequest{
+		Type: model.AccessRequestTypeWorkspaceRole, WorkspaceID: f.ws.ID, RoleID: f.operator.ID,
+		DurationHours: 2, Justification: "incident",
+	}
+	cases := map[string]func(r *model.CreateAccessRequestRequest){
+		"duration too long":   func(r *model.CreateAccessRequestRequest) { r.DurationHours = config.AccessRequestMaxHours() + 1 },
+		"no justification":    func(r *model.CreateAccessRequestRequest) { r.Justification = "" },
+		"unknown workspace":   func(r *model.CreateAccessRequestRequest) { r.WorkspaceID = 9999 },
+		"unknown type":        func(r *model.CreateAccessRequestRequest) { r.Type = "PLATFORM_ROLE" },
+		"permanent role held": func(r *model.CreateAccessRequestRequest) { r.RoleID = f.viewer.ID },
+		"csp role missing":    func(r *model.CreateAccessRequestRequest) { r.Type = model.AccessRequestTypeCspRole },
+	}
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := base
+			mutate(&r)
+			_, err := f.svc.CreateAccessRequest(f.requester.ID, &r)
+			assert.ErrorIs(t, err, ErrAccessRequestInvalid)
+		})
+	}
+
+	f.create(t)
+	_, err := f.svc.CreateAccessRequest(f.requester.ID, &base)
+	assert.ErrorIs(t, err, ErrAccessRequestInvalid, "동일 역할의 승인 대기 요청 중복")
+}
+
+func TestApproveAccessRequest_GrantsTimeBoundRole(t *testing.T) {
+	f := newAccessRequestFixture(t)
+	req := f.create(t)
+
+	// 요청자 본인 및 관리 권한이 없는 사용자는 승인 불가
+	_, err := f.svc.ApproveAccessRequest(req.ID, f.requester.ID, true, "")
+	assert.ErrorIs(t, err, ErrAccessRequestForbidden)
+	_, err = f.svc.ApproveAccessRequest(req.ID, f.outsider.ID, false, "")
+	assert.ErrorIs(t, err, ErrAccessRequestForbidden)
+
+	approved, err := f.svc.ApproveAccessRequest(req.ID, f.wsAdmin.ID, false, "ok for incident")
+	require.NoError(t, err)
+	assert.Equal(t, model.AccessRequestStatusApproved, approved.Status)
+	require.NotNil(t, approved.GrantExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(2*time.Hour), *approved.GrantExpiresAt, time.Minute)
+	require.Len(t, approved.History, 2)
+	assert.Equal(t, model.AccessRequestStatusPendingApproval, approved.History[0].Status)
+	assert.Equal(t, model.AccessRequestStatusApproved, approved.History[1].Status)
+	assert.Equal(t, f.wsAdmin.ID, approved.History[1].ActorUserID)
+
+	var grant model.UserWorkspaceRole
+	require.NoError(t, f.db.Where("user_id = ? AND workspace_id = ? AND role_id = ?",
+		f.requester.ID, f.ws.ID, f.operator.ID).Take(&grant).Error)
+	require.NotNil(t, grant.ExpiresAt)
+
+	// CSP 자격증명 발급 시 기간 제한 역할이 우선 사용된다
+	current, err := repository.NewUserRepository(f.db).FindUserRoleInWorkspace(f.requester.ID, f.ws.ID)
+	require.NoError(t, err)
+	assert.Equal(t, f.operator.ID, current.RoleID)
+
+	_, err = f.svc.ApproveAccessRequest(req.ID, f.wsAdmin.ID, false, "")
+	assert.ErrorIs(t, err, ErrAccessRequestNotPending)
+}
+
+func TestApproveAccessRequest_ExtendsExistingTimeBoundGrant(t *testing.T) {
+	f := newAccessRequestFixture(t)
+	soon := time.Now().UTC().Add(10 * time.Minute)
+	require.NoError(t, repository.NewRoleRepository(f.db).AssignWorkspaceRoleWithPeriod(f.requester.ID, f.ws.ID, f.operator.ID,
+		model.RoleGrantPeriod{ExpiresAt: &soon}))
+
+	req := f.create(t)
+	approved, err := f.svc.ApproveAccessRequest(req.ID, f.outsider.ID, true, "")
+	require.NoError(t, err)
+
+	var count int64
+	f.db.Model(&model.UserWorkspaceRole{}).Where("user_id = ? AND role_id = ?", f.requester.ID, f.operator.ID).Count(&count)
+	assert.Equal(t, int64(1), count)
+	var grant model.UserWorkspaceRole
+	require.NoError(t, f.db.Where("user_id = ? AND role_id = ?", f.requester.ID, f.operator.ID).Take(&grant).Error)
+	assert.WithinDuration(t, *approved.GrantExpiresAt, *grant.ExpiresAt, time.Second)
+	assert.True(t, grant.ExpiresAt.After(soon))
+}
+
+func TestRejectAndCancelAccessRequest(t *testing.T) {
+	f := newAccessRequestFixture(t)
+
+	rejected, err := f.svc.RejectAccessRequest(f.create(t).ID, f.wsAdmin.ID, false, "not needed")
+	require.NoError(t, err)
+	assert.Equal(t, model.AccessRequestStatusRejected, rejected.Status)
+	assert.Equal(t, "not needed", rejected.DecisionComment)
+
+	req := f.create(t)
+	_, err = f.svc.CancelAccessRequest(req.ID, f.wsAdmin.ID, "")
+	assert.ErrorIs(t, err, ErrAccessRequestForbidden)
+	cancelled, err := f.svc.CancelAccessRequest(req.ID, f.requester.ID, "resolved")
+	require.NoError(t, err)
+	assert.Equal(t, model.AccessRequestStatusCancelled, cancelled.Status)
+
+	var count int64
+	f.db.Model(&model.UserWorkspaceRole{}).Where("role_id = ?", f.operator.ID).Count(&count)
+	assert.Zero(t, count, "거절/취소된 요청은 역할을 부여하지 않는다")
+
+	_, err = f.svc.GetAccessRequest(req.ID, f.outsider.ID, false)
+	assert.ErrorIs(t, err, ErrAccessRequestForbidden)
+	_, err = f.svc.GetAccessRequest(9999, f.requester.ID, false)
+	assert.ErrorIs(t, err, ErrAccessRequestNotFound)
+}
+
+func TestCreateAccessRequest_CspRoleResolvesMappedRole(t *testing.T) {
+	f := newAccessRequestFixture(t)
+	require.NoError(t, f.db.Create(&model.RoleMasterCspRoleMapping{RoleID: f.operator.ID, AuthMethod: "OIDC", CspRoleID: 7}).Error)
+
+	cspRoleID := uint(7)
+	req, err := f.svc.CreateAccessRequest(f.requester.ID, &model.CreateAccessRequestRequest{
+		Type: model.AccessRequestTypeCspRole, WorkspaceID: f.ws.ID, CspRoleID: &cspRoleID,
+		DurationHours: 1, Justification: "debug IAM policy",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, f.operator.ID, req.RoleID)
+
+	other := uint(8)
+	_, err = f.svc.CreateAccessRequest(f.requester.ID, &model.CreateAccessRequestRequest{
+		Type: model.AccessRequestTypeCspRole, WorkspaceID: f.ws.ID, CspRoleID: &other,
+		DurationHours: 1, Justification: "unmapped",
+	})
+	assert.ErrorIs(t, err, ErrAccessRequestInvalid)
+}
+
+func TestCreateAccessRequest_CspRoleRejectsBroaderRole(t *testing.T) {
+	f := newAccessRequestFixture(t)
+	cspRoleID := uint(7)
+	create := func() error {
+		_, err := f.svc.CreateAccessRequest(f.requester.ID, &model.CreateAccessRequestRequest{
+			Type: model.AccessRequestTypeCspRole, WorkspaceID: f.ws.ID, CspRoleID: &cspRoleID,
+			DurationHours: 1, Justification: "debug IAM policy",
+		})
+		return err
+	}
+
+	// 다른 CSP 역할도 매핑된 워크스페이스 역할
+	require.NoError(t, f.db.Create(&model.RoleMasterCspRoleMapping{RoleID: f.operator.ID, AuthMethod: "OIDC", CspRoleID: 7}).Error)
+	require.NoError(t, f.db.Create(&model.RoleMasterCspRoleMapping{RoleID: f.operator.ID, AuthMethod: "OIDC", CspRoleID: 9}).Error)
+	assert.ErrorIs(t, create(), ErrAccessRequestInvalid)
+
+	// MciamPermission을 가진 워크스페이스 역할
+	require.NoError(t, f.db.Where("role_id = ? AND csp_role_id = ?", f.operator.ID, 9).Delete(&model.RoleMasterCspRoleMapping{}).Error)
+	require.NoError(t, f.db.Create(&model.MciamRoleMciamPermission{
+		RoleType: constants.RoleTypeWorkspace, RoleID: f.operator.ID, PermissionID: "mc-infra-manager:vm:create",
+	}).Error)
+	assert.ErrorIs(t, create(), ErrAccessRequestInvalid)
+
+	require.NoError(t, f.db.Where("role_id = ?", f.operator.ID).Delete(&model.MciamRoleMciamPermission{}).Error)
+	assert.NoError(t, create())
+}
+
+func TestListAccessRequests_ScopedByRole(t *testing.T) {
+	f := newAccessRequestFixture(t)
+	f.create(t)
+
+	mine, err := f.svc.ListAccessRequests(f.requester.ID, false, &model.AccessRequestFilterRequest{})
+	require.NoError(t, err)
+	assert.Len(t, mine, 1)
+
+	approvable, err := f.svc.ListAccessRequests(f.wsAdmin.ID, false, &model.AccessRequestFilterRequest{Status: string(model.AccessRequestStatusPendingApproval)})
+	require.NoError(t, err)
+	assert.Len(t, approvable, 1)
+
+	none, err := f.svc.ListAccessRequests(f.outsider.ID, false, &model.AccessRequestFilterRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, none)
+
+	all, err := f.svc.ListAccessRequests(f.outsider.ID, true, &model.AccessRequestFilterRequest{Status: string(model.AccessRequestStatusApproved)})
+	require.NoError(t, err)
+	assert.Empty(t, all)
+}
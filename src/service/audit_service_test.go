@@ -0,0 +1,103 @@
+package service
+
+// audit_service_test.go
+// 감사 로그 서비스 단위 테스트
+//
+// 테스트 범위:
+//   - Record: action 필수 검증, 정상 저장
+//   - ListAuditEvents: 행위자/대상/액션 접두사/시간 범위 필터, 잘못된 시간 범위
+//   - ToAuditSnapshot: 민감 필드 마스킹, JSON이 아닌 본문 무시
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newTestAuditService(t *testing.T) (*AuditService, *gorm.DB) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&model.AuditEvent{}))
+	return NewAuditService(db), db
+}
+
+func TestAuditService_Record_RequiresAction(t *testing.T) {
+	svc, _ := newTestAuditService(t)
+	err := svc.Record(&model.AuditEvent{ActorKcUserID: "kc-1"})
+	assert.Error(t, err)
+}
+
+func TestAuditService_ListAuditEvents_Filters(t *testing.T) {
+	svc, db := newTestAuditService(t)
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []model.AuditEvent{
+		{ActorKcUserID: "admin-1", Action: "role.assign.platform", TargetType: "user", TargetID: "10", CreatedAt: base},
+		{ActorKcUserID: "admin-1", Action: "role.unassign.platform", TargetType: "user", TargetID: "10", CreatedAt: base.Add(time.Hour)},
+		{ActorKcUserID: "admin-2", Action: "user.deactivate", TargetType: "user", TargetID: "11", CreatedAt: base.Add(2 * time.Hour)},
+		{ActorKcUserID: "admin-2", Action: "role_assign_x", TargetType: "group", TargetID: "3", CreatedAt: base.Add(3 * time.Hour)},
+	}
+	for i := range events {
+		require.NoError(t, db.Create(&events[i]).Error)
+	}
+
+	res, err := svc.ListAuditEvents(&model.AuditEventFilterRequest{ActorKcUserID: "admin-1"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.Total)
+
+	res, err = svc.ListAuditEvents(&model.AuditEventFilterRequest{TargetType: "user", TargetID: "11"})
+	require.NoError(t, err)
+	require.Len(t, res.Events, 1)
+	assert.Equal(t, "user.deactivate", res.Events[0].Action)
+
+	// "_" 는 와일드카드가 아닌 문자 그대로 비교되어야 한다
+	res, err = svc.ListAuditEvents(&model.AuditEventFilterRequest{Action: "role_"})
+	require.NoError(t, err)
+	require.Len(t, res.Events, 1)
+	assert.Equal(t, "role_assign_x", res.Events[0].Action)
+
+	res, err = svc.ListAuditEvents(&model.AuditEventFilterRequest{Action: "role."})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.Total)
+
+	start := base.Add(30 * time.Minute)
+	end := base.Add(150 * time.Minute)
+	res, err = svc.ListAuditEvents(&model.AuditEventFilterRequest{StartTime: &start, EndTime: &end})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.Total)
+	assert.Equal(t, 1, res.Page)
+	assert.Equal(t, defaultAuditPageSize, res.Size)
+}
+
+func TestAuditService_ListAuditEvents_InvalidTimeRange(t *testing.T) {
+	svc, _ := newTestAuditService(t)
+	start := time.Now()
+	end := start.Add(-time.Hour)
+	_, err := svc.ListAuditEvents(&model.AuditEventFilterRequest{StartTime: &start, EndTime: &end})
+	assert.ErrorIs(t, err, ErrInvalidAuditTimeRange)
+}
+
+func TestToAuditSnapshot_MasksSensitiveFields(t *testing.T) {
+	snapshot := ToAuditSnapshot([]byte(`{"username":"kim","password":"p@ss","nested":{"clientSecret":"s"},"items":[{"accessToken":"t"}]}`))
+	require.NotNil(t, snapshot)
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(snapshot, &decoded))
+	assert.Equal(t, "kim", decoded["username"])
+	assert.Equal(t, "***", decoded["password"])
+	assert.Equal(t, "***", decoded["nested"].(map[string]interface{})["clientSecret"])
+	assert.Equal(t, "***", decoded["items"].([]interface{})[0].(map[string]interface{})["accessToken"])
+
+	assert.Nil(t, ToAuditSnapshot([]byte("mode: additive")))
+	assert.Nil(t, ToAuditSnapshot(nil))
+}
@@ -0,0 +1,601 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	// platformAdminRoleName 모든 권한을 가지는 플랫폼 역할
+	platformAdminRoleName = "platformAdmin"
+	// maxAuthzBatchSize check-batch 요청당 최대 항목 수
+	maxAuthzBatchSize = 100
+)
+
+var (
+	ErrAuthzInvalidRequest = errors.New("invalid authorization check request")
+	ErrAuthzBatchTooLarge  = fmt.Errorf("too many checks in a batch (max %d)", maxAuthzBatchSize)
+)
+
+// AuthzService downstream 프레임워크용 권한 판단(PDP) 서비스
+// 플랫폼 역할, 워크스페이스 역할(직접/그룹), 역할-권한 매핑, 권한-API 액션 매핑을 평가한다.
+type AuthzService struct {
+	db                *gorm.DB
+	authzRepo         *repository.AuthzRepository
+	userRepo          *repository.UserRepository
+	mcmpApiRepo       repository.McmpApiRepository
+	actionMappingRepo *repository.McmpApiPermissionActionMappingRepository
+	policyRuleRepo    *repository.PolicyRuleRepository
+}
+
+// NewAuthzService 새 AuthzService 인스턴스 생성
+func NewAuthzService(db *gorm.DB) *AuthzService {
+	return &AuthzService{
+		db:                db,
+		authzRepo:         repository.NewAuthzRepository(db),
+		userRepo:          repository.NewUserRepository(db),
+		mcmpApiRepo:       repository.NewMcmpApiRepository(db),
+		actionMappingRepo: repository.NewMcmpApiPermissionActionMappingRepository(db),
+		policyRuleRepo:    repository.NewPolicyRuleRepository(db),
+	}
+}
+
+// Check 단일 권한 판단. 역할 매핑과 allow 정책 규칙으로 허용하며, 일치하는 deny 정책 규칙이 있으면 거부한다.
+func (s *AuthzService) Check(ctx context.Context, req *model.AuthzCheckRequest) (*model.AuthzCheckResponse, error) {
+	return s.check(ctx, req, nil, nil)
+}
+
+// DryRun 저장하지 않은 후보 규칙을 포함해 권한 판단을 수행하고 평가한 규칙별 결과를 반환
+func (s *AuthzService) DryRun(ctx context.Context, req *model.AuthzDryRunRequest) (*model.AuthzDryRunResponse, error) {
+	for i := range req.CandidateRules {
+		if err := validatePolicyRule(&req.CandidateRules[i]); err != nil {
+			return nil, fmt.Errorf("%w: candidateRules[%d]: %v", ErrAuthzInvalidRequest, i, err)
+		}
+	}
+	evaluations := make([]model.AuthzRuleEvaluation, 0)
+	res, err := s.check(ctx, &req.AuthzCheckRequest, req.CandidateRules, &evaluations)
+	if err != nil {
+		return nil, err
+	}
+	return &model.AuthzDryRunResponse{
+		AuthzCheckResponse: *res,
+		Context:            authzRequestContext(req.Context),
+		EvaluatedRules:     evaluations,
+	}, nil
+}
+
+// check 권한 판단. candidateRules는 활성 규칙과 함께 평가하고, trace가 있으면 규칙별 평가 결과를 기록한다.
+func (s *AuthzService) check(ctx context.Context, req *model.AuthzCheckRequest, candidateRules []model.PolicyRule, trace *[]model.AuthzRuleEvaluation) (*model.AuthzCheckResponse, error) {
+	if err := validateAuthzCheckRequest(req); err != nil {
+		return nil, err
+	}
+	res := &model.AuthzCheckResponse{Request: *req}
+
+	// 1. 대상 사용자
+	user, err := s.userRepo.FindByKcID(req.KcUserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return denyAuthz(res, "user not found"), nil
+	}
+	if user.Status == model.UserStatusInactive || user.Status == model.UserStatusWithdrawn {
+		return denyAuthz(res, fmt.Sprintf("user is %s", user.Status)), nil
+	}
+
+	// 2. 플랫폼 역할 조회 (요청 토큰/API 키 scope로 제한). platformAdmin은 매핑 여부와 관계없이 허용하되 deny 규칙은 적용
+	platformGrants, err := s.authzRepo.FindPlatformRoleGrants(user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get platform roles: %w", err)
+	}
+	if req.PlatformRoles != nil {
+		platformGrants = filterGrantsByRoleNames(platformGrants, req.PlatformRoles)
+	}
+	for _, grant := range platformGrants {
+		if grant.RoleName == platformAdminRoleName {
+			res.GrantedBy = append(res.GrantedBy, model.AuthzGrantPath{
+				Source:   model.AuthzSourcePlatformAdmin,
+				RoleID:   grant.RoleID,
+				RoleName: grant.RoleName,
+				GroupID:  grant.GroupID,
+			})
+		}
+	}
+	platformAdmin := len(res.GrantedBy) > 0
+
+	// 3. 요청 액션에 필요한 권한 목록
+	required, reason, err := s.resolveRequiredPermissions(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res.RequiredPermissions = required
+	if reason != "" && !platformAdmin {
+		return denyAuthz(res, reason), nil
+	}
+	noGrantReason := "no role grants the required permission"
+	if len(required) == 0 {
+		noGrantReason = fmt.Sprintf("no permission is mapped to action %s/%s", req.ServiceName, req.ActionName)
+	}
+
+	// 4. 평가 대상 워크스페이스 (platformAdmin은 워크스페이스 조건과 관계없이 규칙만 평가)
+	workspaceIDs, reason, err := s.resolveWorkspaces(req)
+	if err != nil {
+		return nil, err
+	}
+	if reason != "" {
+		if !platformAdmin {
+			return denyAuthz(res, reason), nil
+		}
+		workspaceIDs = nil
+	}
+
+	// 5. 플랫폼 역할-권한 매핑 평가
+	if !platformAdmin {
+		paths, err := s.matchGrants(constants.RoleTypePlatform, platformGrants, required)
+		if err != nil {
+			return nil, err
+		}
+		res.GrantedBy = append(res.GrantedBy, paths...)
+	}
+
+	// 6. 워크스페이스 역할 평가 (직접 할당 + 그룹 상속). platformAdmin도 규칙 대상 판단을 위해 조회
+	workspaceGrants, err := s.authzRepo.FindWorkspaceRoleGrants(user.ID, workspaceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace roles: %w", err)
+	}
+	if !platformAdmin {
+		paths, err := s.matchGrants(constants.RoleTypeWorkspace, workspaceGrants, required)
+		if err != nil {
+			return nil, err
+		}
+		res.GrantedBy = append(res.GrantedBy, paths...)
+	}
+
+	// 7. 정책 규칙 평가 (deny 우선, platformAdmin 포함)
+	results, err := s.evaluatePolicyRules(user.ID, platformGrants, workspaceGrants, workspaceIDs, policyTarget{
+		permissions: required,
+		serviceName: req.ServiceName,
+		actionName:  req.ActionName,
+	}, authzRequestContext(req.Context), candidateRules, trace)
+	if err != nil {
+		return nil, err
+	}
+	var denied *model.AuthzRuleMatch
+	for i := range results {
+		match := results[i].match
+		res.MatchedRules = append(res.MatchedRules, match)
+		if match.Effect == model.PolicyRuleEffectDeny {
+			if denied == nil {
+				denied = &res.MatchedRules[len(res.MatchedRules)-1]
+			}
+			continue
+		}
+		res.GrantedBy = append(res.GrantedBy, policyRuleGrantPath(results[i]))
+	}
+	if denied != nil {
+		matched := res.MatchedRules
+		res = denyAuthz(res, fmt.Sprintf("denied by policy rule %q", denied.Name))
+		res.MatchedRules = matched
+		return res, nil
+	}
+
+	if len(res.GrantedBy) == 0 {
+		return denyAuthz(res, noGrantReason), nil
+	}
+	return allowAuthz(res), nil
+}
+
+// evaluatePolicyRules 사용자에게 적용되는 활성 규칙(+후보 규칙)을 평가해 일치한 규칙만 반환
+func (s *AuthzService) evaluatePolicyRules(userID uint, platformGrants, workspaceGrants []model.AuthzRoleGrant, workspaceIDs []uint, target policyTarget, reqCtx model.AuthzRequestContext, candidateRules []model.PolicyRule, trace *[]model.AuthzRuleEvaluation) ([]policyRuleResult, error) {
+	subjects, roleIDs, groupIDs, err := s.loadPolicySubjects(userID, platformGrants, workspaceGrants)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := s.policyRuleRepo.FindEnabledBySubjects(roleIDs, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	rules = append(rules, candidateRules...)
+
+	var matched []policyRuleResult
+	for _, rule := range rules {
+		result := evaluatePolicyRule(rule, subjects, workspaceIDs, target, reqCtx)
+		if trace != nil {
+			*trace = append(*trace, model.AuthzRuleEvaluation{
+				RuleID: rule.ID, Name: rule.Name, Effect: rule.Effect, Matched: result.matched, Reason: result.reason,
+			})
+		}
+		if result.matched {
+			matched = append(matched, result)
+		}
+	}
+	return matched, nil
+}
+
+// policyRuleGrantPath allow 규칙 부여 경로
+func policyRuleGrantPath(result policyRuleResult) model.AuthzGrantPath {
+	path := model.AuthzGrantPath{
+		Source:       model.AuthzSourcePolicyRule,
+		RuleID:       result.rule.ID,
+		WorkspaceID:  result.match.WorkspaceID,
+		PermissionID: result.rule.PermissionID,
+	}
+	if result.rule.SubjectType == model.PolicyRuleSubjectGroup {
+		path.GroupID = result.rule.SubjectID
+	} else {
+		path.RoleID = result.rule.SubjectID
+	}
+	return path
+}
+
+// authzRequestContext 조건 평가용 요청 정보 (시각 미지정 시 현재 시각)
+func authzRequestContext(reqCtx *model.AuthzRequestContext) model.AuthzRequestContext {
+	var result model.AuthzRequestContext
+	if reqCtx != nil {
+		result = *reqCtx
+	}
+	if result.Time == nil {
+		now := time.Now().UTC()
+		result.Time = &now
+	}
+	return result
+}
+
+// CheckBatch 여러 권한 판단을 한 번에 수행. 개별 항목 검증 오류는 deny 결과로 반환한다.
+func (s *AuthzService) CheckBatch(ctx context.Context, req *model.AuthzCheckBatchRequest) (*model.AuthzCheckBatchResponse, error) {
+	if len(req.Checks) > maxAuthzBatchSize {
+		return nil, ErrAuthzBatchTooLarge
+	}
+	results := make([]model.AuthzCheckResponse, 0, len(req.Checks))
+	for i := range req.Checks {
+		check := req.Checks[i]
+		if check.KcUserID == "" {
+			check.KcUserID = req.KcUserID
+		}
+		res, err := s.Check(ctx, &check)
+		if err != nil {
+			if !errors.Is(err, ErrAuthzInvalidRequest) {
+				return nil, err
+			}
+			res = denyAuthz(&model.AuthzCheckResponse{Request: check}, err.Error())
+		}
+		results = append(results, *res)
+	}
+	return &model.AuthzCheckBatchResponse{Results: results}, nil
+}
+
+// EffectivePermissions 사용자의 유효 MciamPermission 목록 (플랫폼 역할 + 지정 워크스페이스의 역할, 직접/그룹)
+// 플랫폼 역할은 DB에 부여된 역할 중 요청 토큰(또는 API 키 scope)에 담긴 platformRoles만 평가한다.
+// permissionId 대상 정책 규칙 중 allow는 권한을 더하고 deny는 일치하는 권한을 뺀다 (platformAdmin 포함, service/action 대상 규칙은 Check에서만 평가).
+func (s *AuthzService) EffectivePermissions(kcUserID string, workspaceID uint, platformRoles []string, reqCtx *model.AuthzRequestContext) (*model.EffectivePermissions, error) {
+	res := &model.EffectivePermissions{KcUserID: kcUserID, WorkspaceID: workspaceID, Permissions: []string{}}
+	user, err := s.userRepo.FindByKcID(kcUserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.Status == model.UserStatusInactive || user.Status == model.UserStatusWithdrawn {
+		return res, nil
+	}
+
+	platformGrants, err := s.authzRepo.FindPlatformRoleGrants(user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get platform roles: %w", err)
+	}
+	grants := filterGrantsByRoleNames(platformGrants, platformRoles)
+	for _, grant := range grants {
+		if grant.RoleName == platformAdminRoleName {
+			res.PlatformAdmin = true
+		}
+	}
+
+	permissions := make(map[string]struct{})
+	if err := s.collectPermissions(constants.RoleTypePlatform, grants, permissions); err != nil {
+		return nil, err
+	}
+	var workspaceGrants []model.AuthzRoleGrant
+	var workspaceIDs []uint
+	if workspaceID != 0 {
+		workspaceIDs = []uint{workspaceID}
+		workspaceGrants, err = s.authzRepo.FindWorkspaceRoleGrants(user.ID, workspaceIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workspace roles: %w", err)
+		}
+		if err := s.collectPermissions(constants.RoleTypeWorkspace, workspaceGrants, permissions); err != nil {
+			return nil, err
+		}
+	}
+	denied, err := s.applyPolicyRulesToPermissions(user.ID, grants, workspaceGrants, workspaceIDs, authzRequestContext(reqCtx), permissions)
+	if err != nil {
+		return nil, err
+	}
+	if res.PlatformAdmin {
+		res.DeniedPermissions = denied
+	}
+
+	for id := range permissions {
+		res.Permissions = append(res.Permissions, id)
+	}
+	sort.Strings(res.Permissions)
+	return res, nil
+}
+
+// filterGrantsByRoleNames 역할 이름이 roleNames에 포함된 부여만 남긴다
+func filterGrantsByRoleNames(grants []model.AuthzRoleGrant, roleNames []string) []model.AuthzRoleGrant {
+	allowed := make(map[string]struct{}, len(roleNames))
+	for _, role := range roleNames {
+		allowed[role] = struct{}{}
+	}
+	var filtered []model.AuthzRoleGrant
+	for _, grant := range grants {
+		if _, ok := allowed[grant.RoleName]; ok {
+			filtered = append(filtered, grant)
+		}
+	}
+	return filtered
+}
+
+// applyPolicyRulesToPermissions permissionId 대상 정책 규칙 반영. allow(정확한 ID)는 추가, deny(패턴)는 제거하고 적용된 deny 패턴 반환
+func (s *AuthzService) applyPolicyRulesToPermissions(userID uint, platformGrants, workspaceGrants []model.AuthzRoleGrant, workspaceIDs []uint, reqCtx model.AuthzRequestContext, permissions map[string]struct{}) ([]string, error) {
+	subjects, roleIDs, groupIDs, err := s.loadPolicySubjects(userID, platformGrants, workspaceGrants)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := s.policyRuleRepo.FindEnabledBySubjects(roleIDs, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var denies []model.PolicyRule
+	for _, rule := range rules {
+		if rule.PermissionID == "" {
+			continue
+		}
+		if _, ok, _ := policyRuleSubjectMatches(rule, subjects, workspaceIDs); !ok {
+			continue
+		}
+		if ok, _ := policyConditionsMatch(rule.Conditions, reqCtx); !ok {
+			continue
+		}
+		if rule.Effect == model.PolicyRuleEffectDeny {
+			denies = append(denies, rule)
+			continue
+		}
+		permissions[rule.PermissionID] = struct{}{}
+	}
+	var denied []string
+	for _, rule := range denies {
+		denied = append(denied, rule.PermissionID)
+		for id := range permissions {
+			if policyPermissionMatches(rule.PermissionID, id) {
+				delete(permissions, id)
+			}
+		}
+	}
+	return denied, nil
+}
+
+// collectPermissions 역할들에 매핑된 권한 ID를 permissions에 추가
+func (s *AuthzService) collectPermissions(roleType constants.IAMRoleType, grants []model.AuthzRoleGrant, permissions map[string]struct{}) error {
+	if len(grants) == 0 {
+		return nil
+	}
+	rolePermissions, err := s.inheritedRolePermissions(roleType, grants)
+	if err != nil {
+		return err
+	}
+	for _, inherited := range rolePermissions {
+		for id := range inherited {
+			permissions[id] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// inheritedRolePermissions 역할별 유효 권한 (자신 + 상위 역할 상속). permissionID → 매핑을 가진 역할 ID (가까운 역할 우선)
+func (s *AuthzService) inheritedRolePermissions(roleType constants.IAMRoleType, grants []model.AuthzRoleGrant) (map[uint]map[string]uint, error) {
+	roleIDs := make([]uint, 0, len(grants))
+	for _, grant := range grants {
+		roleIDs = append(roleIDs, grant.RoleID)
+	}
+	ancestors, err := s.authzRepo.FindRoleAncestors(roleIDs)
+	if err != nil {
+		return nil, err
+	}
+	allRoleIDs := append([]uint{}, roleIDs...)
+	for _, ids := range ancestors {
+		allRoleIDs = append(allRoleIDs, ids...)
+	}
+	rolePermissions, err := s.authzRepo.FindRolePermissionIDs(roleType, allRoleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role permissions: %w", err)
+	}
+
+	result := make(map[uint]map[string]uint, len(roleIDs))
+	for _, roleID := range roleIDs {
+		if _, ok := result[roleID]; ok {
+			continue
+		}
+		inherited := make(map[string]uint)
+		for _, sourceID := range append([]uint{roleID}, ancestors[roleID]...) {
+			for _, permissionID := range rolePermissions[sourceID] {
+				if _, ok := inherited[permissionID]; !ok {
+					inherited[permissionID] = sourceID
+				}
+			}
+		}
+		result[roleID] = inherited
+	}
+	return result, nil
+}
+
+// resolveRequiredPermissions 요청 액션을 MciamPermission ID 목록으로 변환. 거부 사유가 있으면 reason 반환
+func (s *AuthzService) resolveRequiredPermissions(ctx context.Context, req *model.AuthzCheckRequest) ([]string, string, error) {
+	if req.PermissionID != "" {
+		return []string{req.PermissionID}, "", nil
+	}
+
+	action, err := s.mcmpApiRepo.GetServiceAction(req.ServiceName, req.ActionName)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Sprintf("unknown action %s/%s", req.ServiceName, req.ActionName), nil
+		}
+		return nil, "", fmt.Errorf("failed to get action: %w", err)
+	}
+	mappings, err := s.actionMappingRepo.FindPermissionsByActionID(ctx, action.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(mappings) == 0 {
+		return nil, "", nil // 매핑 없음: 정책 규칙(service/action 대상)만 평가
+	}
+	required := make([]string, 0, len(mappings))
+	for _, m := range mappings {
+		required = append(required, m.PermissionID)
+	}
+	return required, "", nil
+}
+
+// BindTargetNamespace 대상 API 경로의 nsId로 권한 평가 프로젝트를 결정한다.
+// 요청자가 지정한 projectId와 다르면 오류. 등록되지 않은 네임스페이스는 워크스페이스 역할을 적용하지 않는다.
+func (s *AuthzService) BindTargetNamespace(req *model.AuthzCheckRequest, nsID string) error {
+	if nsID == "" {
+		return nil
+	}
+	projectID, err := s.authzRepo.FindProjectIDByNsId(nsID)
+	if err != nil {
+		return fmt.Errorf("failed to get project of namespace: %w", err)
+	}
+	if projectID == 0 {
+		if req.ProjectID != "" {
+			return fmt.Errorf("%w: projectId does not match target nsId %s", ErrAuthzInvalidRequest, nsID)
+		}
+		req.WorkspaceID = ""
+		return nil
+	}
+	target := strconv.FormatUint(uint64(projectID), 10)
+	if req.ProjectID != "" && req.ProjectID != target {
+		return fmt.Errorf("%w: projectId does not match target nsId %s", ErrAuthzInvalidRequest, nsID)
+	}
+	req.ProjectID = target
+	return nil
+}
+
+// resolveWorkspaces 평가할 워크스페이스 ID 목록. 프로젝트가 워크스페이스에 속하지 않으면 거부 사유 반환
+func (s *AuthzService) resolveWorkspaces(req *model.AuthzCheckRequest) ([]uint, string, error) {
+	var workspaceID, projectID uint
+	if req.WorkspaceID != "" {
+		id, _ := strconv.ParseUint(req.WorkspaceID, 10, 32)
+		workspaceID = uint(id)
+	}
+	if req.ProjectID != "" {
+		id, _ := strconv.ParseUint(req.ProjectID, 10, 32)
+		projectID = uint(id)
+	}
+
+	if projectID == 0 {
+		if workspaceID == 0 {
+			return nil, "", nil
+		}
+		return []uint{workspaceID}, "", nil
+	}
+
+	projectWorkspaces, err := s.authzRepo.FindWorkspaceIDsByProject(projectID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get project workspaces: %w", err)
+	}
+	if workspaceID == 0 {
+		if len(projectWorkspaces) == 0 {
+			return nil, "project is not assigned to any workspace", nil
+		}
+		return projectWorkspaces, "", nil
+	}
+	for _, id := range projectWorkspaces {
+		if id == workspaceID {
+			return []uint{workspaceID}, "", nil
+		}
+	}
+	return nil, "project does not belong to the workspace", nil
+}
+
+// matchGrants 역할이 가진 권한과 필요한 권한이 겹치는 부여 경로 목록
+func (s *AuthzService) matchGrants(roleType constants.IAMRoleType, grants []model.AuthzRoleGrant, required []string) ([]model.AuthzGrantPath, error) {
+	if len(grants) == 0 {
+		return nil, nil
+	}
+	rolePermissions, err := s.inheritedRolePermissions(roleType, grants)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []model.AuthzGrantPath
+	for _, grant := range grants {
+		for _, permissionID := range required {
+			sourceID, ok := rolePermissions[grant.RoleID][permissionID]
+			if !ok {
+				continue
+			}
+			path := model.AuthzGrantPath{
+				Source:       grant.Source,
+				RoleID:       grant.RoleID,
+				RoleName:     grant.RoleName,
+				GroupID:      grant.GroupID,
+				WorkspaceID:  grant.WorkspaceID,
+				PermissionID: permissionID,
+			}
+			if sourceID != grant.RoleID {
+				path.InheritedFromRoleID = sourceID
+			}
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+func validateAuthzCheckRequest(req *model.AuthzCheckRequest) error {
+	if req.KcUserID == "" {
+		return fmt.Errorf("%w: kcUserId is required", ErrAuthzInvalidRequest)
+	}
+	hasPermission := req.PermissionID != ""
+	hasAction := req.ServiceName != "" || req.ActionName != ""
+	if hasPermission == hasAction {
+		return fmt.Errorf("%w: either permissionId or serviceName+actionName is required", ErrAuthzInvalidRequest)
+	}
+	if hasAction && (req.ServiceName == "" || req.ActionName == "") {
+		return fmt.Errorf("%w: both serviceName and actionName are required", ErrAuthzInvalidRequest)
+	}
+	if req.WorkspaceID != "" {
+		if _, err := strconv.ParseUint(req.WorkspaceID, 10, 32); err != nil {
+			return fmt.Errorf("%w: invalid workspaceId", ErrAuthzInvalidRequest)
+		}
+	}
+	if req.ProjectID != "" {
+		if _, err := strconv.ParseUint(req.ProjectID, 10, 32); err != nil {
+			return fmt.Errorf("%w: invalid projectId", ErrAuthzInvalidRequest)
+		}
+	}
+	return nil
+}
+
+func allowAuthz(res *model.AuthzCheckResponse) *model.AuthzCheckResponse {
+	res.Allowed = true
+	res.Decision = model.AuthzDecisionAllow
+	res.Reason = "granted"
+	return res
+}
+
+func denyAuthz(res *model.AuthzCheckResponse, reason string) *model.AuthzCheckResponse {
+	res.Allowed = false
+	res.Decision = model.AuthzDecisionDeny
+	res.Reason = reason
+	res.GrantedBy = nil
+	return res
+}
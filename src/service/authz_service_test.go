@@ -0,0 +1,330 @@
+package service
+
+// authz_service_test.go
+// 권한 판단(PDP) 서비스 단위 테스트 (SQLite in-memory DB)
+//
+// 테스트 범위:
+//   - 직접 할당 워크스페이스 역할 / 그룹 상속 워크스페이스 역할 허용 및 부여 경로
+//   - McmpApiAction → 권한 매핑 평가, 매핑 없는 액션 거부
+//   - 프로젝트-워크스페이스 소속 검증
+//   - platformAdmin 허용, 비활성 사용자 거부, 잘못된 요청 / 일괄 판단
+//   - platformAdmin에도 적용되는 deny 정책 규칙
+//   - 토큰(API 키 scope)에 없는 플랫폼 역할 제외
+//   - 상위 역할(parent_id)에서 상속된 권한
+
+import (
+	"context"
+	"testing"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/model/mcmpapi"
+	"github.com/m-cmp/mc-iam-manager/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const authzTestPermission = "mc-infra-manager:vm:create"
+
+type authzFixture struct {
+	user      *model.User
+	workspace *model.Workspace
+	role      *model.RoleMaster
+}
+
+func newTestAuthzService(t *testing.T) (*AuthzService, *gorm.DB, authzFixture) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&model.User{},
+		&model.RoleMaster{},
+		&model.RoleSub{},
+		&model.Workspace{},
+		&model.Project{},
+		&model.WorkspaceProject{},
+		&model.UserPlatformRole{},
+		&model.UserWorkspaceRole{},
+		&model.Organization{},
+		&model.UserOrganization{},
+		&model.GroupPlatformRole{},
+		&model.GroupWorkspaceRole{},
+		&model.MciamRoleMciamPermission{},
+		&mcmpapi.McmpApiAction{},
+		&mcmpapi.McmpApiPermissionActionMapping{},
+		&model.PolicyRule{},
+	))
+
+	user := &model.User{Username: "authz-user", KcId: "kc-authz-user"}
+	require.NoError(t, db.Create(user).Error)
+	ws := &model.Workspace{Name: "authz-ws"}
+	require.NoError(t, db.Create(ws).Error)
+	role := &model.RoleMaster{Name: "operator"}
+	require.NoError(t, db.Create(role).Error)
+	require.NoError(t, db.Create(&model.RoleSub{RoleID: role.ID, RoleType: constants.RoleTypeWorkspace}).Error)
+	require.NoError(t, db.Create(&model.MciamRoleMciamPermission{
+		RoleType: constants.RoleTypeWorkspace, RoleID: role.ID, PermissionID: authzTestPermission,
+	}).Error)
+
+	return NewAuthzService(db), db, authzFixture{user: user, workspace: ws, role: role}
+}
+
+func TestAuthzCheck_DirectWorkspaceRole(t *testing.T) {
+	svc, db, f := newTestAuthzService(t)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: f.user.ID, WorkspaceID: f.workspace.ID, RoleID: f.role.ID}).Error)
+
+	res, err := svc.Check(context.Background(), &model.AuthzCheckRequest{
+		KcUserID: f.user.KcId, PermissionID: authzTestPermission, WorkspaceID: util.UintToString(f.workspace.ID),
+	})
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, model.AuthzDecisionAllow, res.Decision)
+	require.Len(t, res.GrantedBy, 1)
+	assert.Equal(t, model.AuthzSourceWorkspaceRole, res.GrantedBy[0].Source)
+	assert.Equal(t, f.workspace.ID, res.GrantedBy[0].WorkspaceID)
+
+	// 워크스페이스를 지정하지 않으면 워크스페이스 역할은 평가하지 않는다
+	res, err = svc.Check(context.Background(), &model.AuthzCheckRequest{
+		KcUserID: f.user.KcId, PermissionID: authzTestPermission,
+	})
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+}
+
+func TestAuthzCheck_GroupWorkspaceRole(t *testing.T) {
+	svc, db, f := newTestAuthzService(t)
+	org := &model.Organization{Name: "authz-group", OrganizationCode: "AZ01"}
+	require.NoError(t, db.Create(org).Error)
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: f.user.ID, OrganizationID: org.ID}).Error)
+	require.NoError(t, db.Create(&model.GroupWorkspaceRole{GroupID: org.ID, WorkspaceID: f.workspace.ID, RoleID: f.role.ID}).Error)
+
+	res, err := svc.Check(context.Background(), &model.AuthzCheckRequest{
+		KcUserID: f.user.KcId, PermissionID: authzTestPermission, WorkspaceID: util.UintToString(f.workspace.ID),
+	})
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	require.Len(t, res.GrantedBy, 1)
+	assert.Equal(t, model.AuthzSourceGroupWorkspaceRole, res.GrantedBy[0].Source)
+	assert.Equal(t, org.ID, res.GrantedBy[0].GroupID)
+}
+
+func TestAuthzCheck_ApiActionMapping(t *testing.T) {
+	svc, db, f := newTestAuthzService(t)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: f.user.ID, WorkspaceID: f.workspace.ID, RoleID: f.role.ID}).Error)
+	mapped := &mcmpapi.McmpApiAction{ServiceName: "mc-infra-manager", ActionName: "PostMciDynamic", Method: "POST"}
+	require.NoError(t, db.Create(mapped).Error)
+	require.NoError(t, db.Create(&mcmpapi.McmpApiPermissionActionMapping{
+		PermissionID: authzTestPermission, ActionID: mapped.ID, ActionName: mapped.ActionName,
+	}).Error)
+	unmapped := &mcmpapi.McmpApiAction{ServiceName: "mc-infra-manager", ActionName: "DelMci", Method: "DELETE"}
+	require.NoError(t, db.Create(unmapped).Error)
+
+	res, err := svc.Check(context.Background(), &model.AuthzCheckRequest{
+		KcUserID: f.user.KcId, ServiceName: "mc-infra-manager", ActionName: "PostMciDynamic", WorkspaceID: util.UintToString(f.workspace.ID),
+	})
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, []string{authzTestPermission}, res.RequiredPermissions)
+
+	res, err = svc.Check(context.Background(), &model.AuthzCheckRequest{
+		KcUserID: f.user.KcId, ServiceName: "mc-infra-manager", ActionName: "DelMci", WorkspaceID: util.UintToString(f.workspace.ID),
+	})
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Contains(t, res.Reason, "no permission is mapped")
+}
+
+func TestAuthzCheck_ProjectMustBelongToWorkspace(t *testing.T) {
+	svc, db, f := newTestAuthzService(t)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: f.user.ID, WorkspaceID: f.workspace.ID, RoleID: f.role.ID}).Error)
+	project := &model.Project{Name: "authz-project", NsId: "authz-project"}
+	require.NoError(t, db.Create(project).Error)
+
+	req := &model.AuthzCheckRequest{
+		KcUserID: f.user.KcId, PermissionID: authzTestPermission,
+		WorkspaceID: util.UintToString(f.workspace.ID), ProjectID: util.UintToString(project.ID),
+	}
+	res, err := svc.Check(context.Background(), req)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, "project does not belong to the workspace", res.Reason)
+
+	require.NoError(t, db.Create(&model.WorkspaceProject{WorkspaceID: f.workspace.ID, ProjectID: project.ID}).Error)
+	res, err = svc.Check(context.Background(), req)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+}
+
+func TestAuthzCheck_PlatformAdminAndInactiveUser(t *testing.T) {
+	svc, db, f := newTestAuthzService(t)
+	adminRole := &model.RoleMaster{Name: platformAdminRoleName}
+	require.NoError(t, db.Create(adminRole).Error)
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: f.user.ID, RoleID: adminRole.ID}).Error)
+
+	res, err := svc.Check(context.Background(), &model.AuthzCheckRequest{KcUserID: f.user.KcId, PermissionID: "any:thing:do"})
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, model.AuthzSourcePlatformAdmin, res.GrantedBy[0].Source)
+
+	// platformAdmin은 권한 매핑이 없는 API 액션도 허용
+	unmapped := &mcmpapi.McmpApiAction{ServiceName: "mc-infra-manager", ActionName: "DelMci", Method: "DELETE"}
+	require.NoError(t, db.Create(unmapped).Error)
+	res, err = svc.Check(context.Background(), &model.AuthzCheckRequest{KcUserID: f.user.KcId, ServiceName: "mc-infra-manager", ActionName: "DelMci"})
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+
+	require.NoError(t, db.Model(f.user).Update("status", model.UserStatusInactive).Error)
+	res, err = svc.Check(context.Background(), &model.AuthzCheckRequest{KcUserID: f.user.KcId, PermissionID: "any:thing:do"})
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+}
+
+func TestAuthzCheck_DenyRuleAppliesToPlatformAdmin(t *testing.T) {
+	svc, db, f := newTestAuthzService(t)
+	adminRole := &model.RoleMaster{Name: platformAdminRoleName}
+	require.NoError(t, db.Create(adminRole).Error)
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: f.user.ID, RoleID: adminRole.ID}).Error)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: f.user.ID, WorkspaceID: f.workspace.ID, RoleID: f.role.ID}).Error)
+	require.NoError(t, db.Create(&model.PolicyRule{
+		Name: "admin-no-vm-create", Effect: model.PolicyRuleEffectDeny, SubjectType: model.PolicyRuleSubjectRole, SubjectID: adminRole.ID,
+		PermissionID: authzTestPermission, Enabled: true,
+	}).Error)
+
+	res, err := svc.Check(context.Background(), &model.AuthzCheckRequest{
+		KcUserID: f.user.KcId, PermissionID: authzTestPermission, WorkspaceID: util.UintToString(f.workspace.ID),
+	})
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Contains(t, res.Reason, `denied by policy rule "admin-no-vm-create"`)
+
+	// 규칙 대상이 아닌 권한은 그대로 허용
+	res, err = svc.Check(context.Background(), &model.AuthzCheckRequest{KcUserID: f.user.KcId, PermissionID: "any:thing:do"})
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+
+	perms, err := svc.EffectivePermissions(f.user.KcId, f.workspace.ID, []string{platformAdminRoleName}, nil)
+	require.NoError(t, err)
+	assert.True(t, perms.PlatformAdmin)
+	assert.NotContains(t, perms.Permissions, authzTestPermission)
+	assert.Equal(t, []string{authzTestPermission}, perms.DeniedPermissions)
+	assert.False(t, perms.Allows(authzTestPermission))
+	assert.True(t, perms.Allows("any:thing:do"))
+}
+
+func TestAuthzCheck_PlatformRolesLimitedToTokenScope(t *testing.T) {
+	svc, db, f := newTestAuthzService(t)
+	adminRole := &model.RoleMaster{Name: platformAdminRoleName}
+	require.NoError(t, db.Create(adminRole).Error)
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: f.user.ID, RoleID: adminRole.ID}).Error)
+	auditor := &model.RoleMaster{Name: "workspace-auditor"}
+	require.NoError(t, db.Create(auditor).Error)
+	require.NoError(t, db.Create(&model.MciamRoleMciamPermission{
+		RoleType: constants.RoleTypePlatform, RoleID: auditor.ID, PermissionID: "mc-iam-manager:audit:read",
+	}).Error)
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: f.user.ID, RoleID: auditor.ID}).Error)
+
+	// viewer scope API 키: DB의 platformAdmin/workspace-auditor 역할은 평가하지 않음
+	res, err := svc.Check(context.Background(), &model.AuthzCheckRequest{
+		KcUserID: f.user.KcId, PermissionID: "mc-iam-manager:audit:read", PlatformRoles: []string{"viewer"},
+	})
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Empty(t, res.GrantedBy)
+
+	// 빈 scope도 플랫폼 역할 없이 평가
+	res, err = svc.Check(context.Background(), &model.AuthzCheckRequest{
+		KcUserID: f.user.KcId, PermissionID: "mc-iam-manager:audit:read", PlatformRoles: []string{},
+	})
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+
+	res, err = svc.Check(context.Background(), &model.AuthzCheckRequest{
+		KcUserID: f.user.KcId, PermissionID: "mc-iam-manager:audit:read", PlatformRoles: []string{"workspace-auditor"},
+	})
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	require.Len(t, res.GrantedBy, 1)
+	assert.Equal(t, model.AuthzSourcePlatformRole, res.GrantedBy[0].Source)
+}
+
+func TestAuthzCheck_InvalidRequestAndBatch(t *testing.T) {
+	svc, _, f := newTestAuthzService(t)
+
+	_, err := svc.Check(context.Background(), &model.AuthzCheckRequest{KcUserID: f.user.KcId})
+	assert.ErrorIs(t, err, ErrAuthzInvalidRequest)
+
+	res, err := svc.CheckBatch(context.Background(), &model.AuthzCheckBatchRequest{
+		KcUserID: f.user.KcId,
+		Checks: []model.AuthzCheckRequest{
+			{PermissionID: authzTestPermission},
+			{PermissionID: authzTestPermission, WorkspaceID: "abc"},
+			{KcUserID: "unknown-user", PermissionID: authzTestPermission},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Results, 3)
+	assert.Equal(t, f.user.KcId, res.Results[0].Request.KcUserID)
+	assert.False(t, res.Results[0].Allowed)
+	assert.Contains(t, res.Results[1].Reason, "invalid workspaceId")
+	assert.Equal(t, "user not found", res.Results[2].Reason)
+}
+
+func TestAuthzEffectivePermissions_TokenRolesAndWorkspace(t *testing.T) {
+	svc, db, f := newTestAuthzService(t)
+	auditor := &model.RoleMaster{Name: "workspace-auditor"}
+	require.NoError(t, db.Create(auditor).Error)
+	require.NoError(t, db.Create(&model.MciamRoleMciamPermission{
+		RoleType: constants.RoleTypePlatform, RoleID: auditor.ID, PermissionID: "mc-iam-manager:audit:read",
+	}).Error)
+	require.NoError(t, db.Create(&model.UserPlatformRole{UserID: f.user.ID, RoleID: auditor.ID}).Error)
+
+	org := &model.Organization{Name: "effective-group", OrganizationCode: "EP01"}
+	require.NoError(t, db.Create(org).Error)
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: f.user.ID, OrganizationID: org.ID}).Error)
+	require.NoError(t, db.Create(&model.GroupWorkspaceRole{GroupID: org.ID, WorkspaceID: f.workspace.ID, RoleID: f.role.ID}).Error)
+
+	res, err := svc.EffectivePermissions(f.user.KcId, f.workspace.ID, []string{"workspace-auditor"}, nil)
+	require.NoError(t, err)
+	assert.False(t, res.PlatformAdmin)
+	assert.Equal(t, []string{"mc-iam-manager:audit:read", authzTestPermission}, res.Permissions)
+
+	// 워크스페이스 미지정 시 플랫폼 역할 권한만
+	res, err = svc.EffectivePermissions(f.user.KcId, 0, []string{"workspace-auditor"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mc-iam-manager:audit:read"}, res.Permissions)
+
+	// 토큰(API 키 scope)에 없는 플랫폼 역할은 평가하지 않음
+	res, err = svc.EffectivePermissions(f.user.KcId, 0, []string{"viewer"}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, res.Permissions)
+
+	require.NoError(t, db.Model(f.user).Update("status", model.UserStatusInactive).Error)
+	res, err = svc.EffectivePermissions(f.user.KcId, f.workspace.ID, []string{"workspace-auditor"}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, res.Permissions)
+}
+
+func TestAuthzCheck_InheritedFromParentRole(t *testing.T) {
+	svc, db, f := newTestAuthzService(t)
+	junior := &model.RoleMaster{Name: "junior-operator", ParentID: &f.role.ID}
+	require.NoError(t, db.Create(junior).Error)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: f.user.ID, WorkspaceID: f.workspace.ID, RoleID: junior.ID}).Error)
+
+	res, err := svc.Check(context.Background(), &model.AuthzCheckRequest{
+		KcUserID: f.user.KcId, PermissionID: authzTestPermission, WorkspaceID: util.UintToString(f.workspace.ID),
+	})
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	require.Len(t, res.GrantedBy, 1)
+	assert.Equal(t, junior.ID, res.GrantedBy[0].RoleID)
+	assert.Equal(t, f.role.ID, res.GrantedBy[0].InheritedFromRoleID)
+
+	perms, err := svc.EffectivePermissions(f.user.KcId, f.workspace.ID, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{authzTestPermission}, perms.Permissions)
+}
@@ -42,6 +42,12 @@ func (s *CompanyService) CreateCompany(req *model.CompanyRequest) (*model.Compan
 	if err := s.ensureRealm(req.RealmName); err != nil {
 		return nil, fmt.Errorf("REALM_ERROR: %w", err)
 	}
+	// 멀티테넌트 모드에서는 회사 realm에 로그인/관리용 클라이언트도 준비
+	if config.MultiTenantEnabled() {
+		if err := s.ensureClient(req.RealmName, req.KcClientID, req.KcClientSecret); err != nil {
+			return nil, fmt.Errorf("REALM_ERROR: %w", err)
+		}
+	}
 
 	company := &model.Company{
 		Name:           req.Name,
@@ -56,30 +62,69 @@ func (s *CompanyService) CreateCompany(req *model.CompanyRequest) (*model.Compan
 		return nil, fmt.Errorf("failed to create company: %w", err)
 	}
 
+	if config.MultiTenantEnabled() {
+		config.RegisterTenant(company.ID, company.RealmName, company.KcClientID, company.KcClientSecret)
+	}
+
 	log.Printf("[INFO] Company created: name=%s, realm_name=%s", company.Name, company.RealmName)
 	return company.ToResponse(), nil
 }
 
-// GetCompany 회사 조회 (COMP-002, 싱글톤)
-func (s *CompanyService) GetCompany() (*model.CompanyResponse, error) {
-	company, err := s.companyRepo.First()
+// ListCompanies 회사 목록 조회 (멀티테넌트 모드)
+func (s *CompanyService) ListCompanies() ([]*model.CompanyResponse, error) {
+	companies, err := s.companyRepo.List("")
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]*model.CompanyResponse, 0, len(companies))
+	for i := range companies {
+		resp = append(resp, companies[i].ToResponse())
+	}
+	return resp, nil
+}
+
+// findCompany ID로 회사 조회. id가 0이면 첫 번째(기본) 회사
+func (s *CompanyService) findCompany(id uint) (*model.Company, error) {
+	var company *model.Company
+	var err error
+	if id == 0 {
+		company, err = s.companyRepo.First()
+	} else {
+		company, err = s.companyRepo.FindByID(id)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get company: %w", err)
 	}
 	if company == nil {
 		return nil, fmt.Errorf("company not found")
 	}
+	return company, nil
+}
+
+// GetCompany 회사 조회 (COMP-002, 싱글톤)
+func (s *CompanyService) GetCompany() (*model.CompanyResponse, error) {
+	return s.GetCompanyByID(0)
+}
+
+// GetCompanyByID 회사 조회 (id가 0이면 기본 회사)
+func (s *CompanyService) GetCompanyByID(id uint) (*model.CompanyResponse, error) {
+	company, err := s.findCompany(id)
+	if err != nil {
+		return nil, err
+	}
 	return company.ToResponse(), nil
 }
 
 // UpdateCompany 회사 수정 (COMP-003, name/description만 변경 가능)
 func (s *CompanyService) UpdateCompany(req *model.CompanyUpdateRequest) (*model.CompanyResponse, error) {
-	company, err := s.companyRepo.First()
+	return s.UpdateCompanyByID(0, req)
+}
+
+// UpdateCompanyByID 회사 수정 (id가 0이면 기본 회사)
+func (s *CompanyService) UpdateCompanyByID(id uint, req *model.CompanyUpdateRequest) (*model.CompanyResponse, error) {
+	company, err := s.findCompany(id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get company: %w", err)
-	}
-	if company == nil {
-		return nil, fmt.Errorf("company not found")
+		return nil, err
 	}
 
 	company.Name = req.Name
@@ -95,18 +140,23 @@ func (s *CompanyService) UpdateCompany(req *model.CompanyUpdateRequest) (*model.
 
 // DeactivateCompany 회사 비활성화 (COMP-004, 멱등 처리)
 func (s *CompanyService) DeactivateCompany() (*model.CompanyResponse, error) {
-	company, err := s.companyRepo.First()
+	return s.DeactivateCompanyByID(0)
+}
+
+// DeactivateCompanyByID 회사 비활성화 (id가 0이면 기본 회사). 멀티테넌트 모드에서는 realm 등록 해제
+func (s *CompanyService) DeactivateCompanyByID(id uint) (*model.CompanyResponse, error) {
+	company, err := s.findCompany(id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get company: %w", err)
-	}
-	if company == nil {
-		return nil, fmt.Errorf("company not found")
+		return nil, err
 	}
 
 	company.Status = "inactive"
 	if err := s.companyRepo.Save(company); err != nil {
 		return nil, fmt.Errorf("failed to deactivate company: %w", err)
 	}
+	if config.MultiTenantEnabled() {
+		config.UnregisterTenant(company.RealmName)
+	}
 
 	log.Printf("[INFO] Company deactivated: id=%d", company.ID)
 	return company.ToResponse(), nil
@@ -114,18 +164,23 @@ func (s *CompanyService) DeactivateCompany() (*model.CompanyResponse, error) {
 
 // ActivateCompany 회사 활성화 (COMP-005, 멱등 처리)
 func (s *CompanyService) ActivateCompany() (*model.CompanyResponse, error) {
-	company, err := s.companyRepo.First()
+	return s.ActivateCompanyByID(0)
+}
+
+// ActivateCompanyByID 회사 활성화 (id가 0이면 기본 회사). 멀티테넌트 모드에서는 realm 등록
+func (s *CompanyService) ActivateCompanyByID(id uint) (*model.CompanyResponse, error) {
+	company, err := s.findCompany(id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get company: %w", err)
-	}
-	if company == nil {
-		return nil, fmt.Errorf("company not found")
+		return nil, err
 	}
 
 	company.Status = "active"
 	if err := s.companyRepo.Save(company); err != nil {
 		return nil, fmt.Errorf("failed to activate company: %w", err)
 	}
+	if config.MultiTenantEnabled() {
+		config.RegisterTenant(company.ID, company.RealmName, company.KcClientID, company.KcClientSecret)
+	}
 
 	log.Printf("[INFO] Company activated: id=%d", company.ID)
 	return company.ToResponse(), nil
@@ -167,6 +222,70 @@ func (s *CompanyService) CreateDefaultCompany() error {
 	return nil
 }
 
+// LoadTenants 활성 회사들의 realm/클라이언트를 테넌트로 등록 (멀티테넌트 모드 기동 시)
+// company_id가 없는 기존 워크스페이스/CSP 계정/가입 신청은 기본 realm(KC.Realm) 회사로 이관한다.
+func (s *CompanyService) LoadTenants() error {
+	companies, err := s.companyRepo.List("active")
+	if err != nil {
+		return err
+	}
+	for _, company := range companies {
+		config.RegisterTenant(company.ID, company.RealmName, company.KcClientID, company.KcClientSecret)
+		log.Printf("[INFO] Tenant registered: company_id=%d, realm=%s", company.ID, company.RealmName)
+	}
+
+	if config.KC == nil {
+		return nil
+	}
+	defaultCompany, err := s.companyRepo.FindByRealmName(config.KC.Realm)
+	if err != nil {
+		return err
+	}
+	if defaultCompany == nil {
+		log.Printf("[WARN] No company bound to default realm '%s', skipping tenant backfill", config.KC.Realm)
+		return nil
+	}
+	return s.companyRepo.AssignUnowned(defaultCompany.ID)
+}
+
+// ResourceCompany 워크스페이스/역할/CSP 계정/가입 신청의 소유 회사 조회 (middleware.TenantResourceResolver)
+func (s *CompanyService) ResourceCompany(kind string, id uint) (*uint, bool, error) {
+	return s.companyRepo.FindResourceCompanyID(kind, id)
+}
+
+// ensureClient 회사 realm에 confidential 클라이언트가 없으면 생성
+func (s *CompanyService) ensureClient(realmName, clientID, clientSecret string) error {
+	adminToken, err := s.keycloakService.KeycloakAdminLogin(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get admin token: %w", err)
+	}
+
+	clients, err := config.KC.Client.GetClients(context.Background(), adminToken.AccessToken, realmName, gocloak.GetClientsParams{ClientID: &clientID})
+	if err != nil {
+		return fmt.Errorf("failed to get clients of realm '%s': %w", realmName, err)
+	}
+	if len(clients) > 0 {
+		log.Printf("[INFO] Client '%s' already exists in realm '%s'", clientID, realmName)
+		return nil
+	}
+
+	_, err = config.KC.Client.CreateClient(context.Background(), adminToken.AccessToken, realmName, gocloak.Client{
+		ClientID:                  &clientID,
+		Secret:                    &clientSecret,
+		Enabled:                   gocloak.BoolP(true),
+		PublicClient:              gocloak.BoolP(false),
+		StandardFlowEnabled:       gocloak.BoolP(true),
+		DirectAccessGrantsEnabled: gocloak.BoolP(true),
+		ServiceAccountsEnabled:    gocloak.BoolP(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create client '%s' in realm '%s': %w", clientID, realmName, err)
+	}
+
+	log.Printf("[INFO] Client '%s' created in realm '%s'", clientID, realmName)
+	return nil
+}
+
 // ensureRealm Keycloak에 realm이 없으면 생성
 func (s *CompanyService) ensureRealm(realmName string) error {
 	if config.KC == nil {
@@ -4,6 +4,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/m-cmp/mc-iam-manager/config"
 	"github.com/m-cmp/mc-iam-manager/model"
 	"github.com/m-cmp/mc-iam-manager/repository"
 	"github.com/stretchr/testify/assert"
@@ -234,3 +235,74 @@ func TestCompanyService_CreateDefaultCompany_Idempotent(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, int64(1), count)
 }
+
+// TestCompanyService_GetCompanyByID 멀티테넌트 모드에서 회사별 조회/상태 변경
+func TestCompanyService_GetCompanyByID(t *testing.T) {
+	db := setupServiceTestDB(t)
+	svc := NewCompanyService(db)
+
+	seedCompany(t, db, "Default", "default-realm", "active")
+	acme := seedCompany(t, db, "Acme", "acme-realm", "active")
+
+	resp, err := svc.GetCompanyByID(acme.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", resp.Name)
+
+	resp, err = svc.GetCompanyByID(0)
+	require.NoError(t, err)
+	assert.Equal(t, "Default", resp.Name, "0이면 기본(첫 번째) 회사")
+
+	resp, err = svc.DeactivateCompanyByID(acme.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "inactive", resp.Status)
+	def, err := svc.GetCompany()
+	require.NoError(t, err)
+	assert.Equal(t, "active", def.Status, "다른 회사 상태는 변경되지 않음")
+
+	_, err = svc.GetCompanyByID(9999)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	list, err := svc.ListCompanies()
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+}
+
+// TestCompanyService_LoadTenants 활성 회사 realm 등록 및 기존 리소스의 기본 회사 이관
+func TestCompanyService_LoadTenants(t *testing.T) {
+	db := setupServiceTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.Workspace{}, &model.CspAccount{}, &model.Signup{}))
+	svc := NewCompanyService(db)
+
+	prevKC := config.KC
+	config.KC = &config.KeycloakConfig{Realm: "default-realm", Host: "http://keycloak:8080"}
+	defer func() {
+		config.KC = prevKC
+		config.UnregisterTenant("default-realm")
+		config.UnregisterTenant("acme-realm")
+	}()
+
+	def := seedCompany(t, db, "Default", "default-realm", "active")
+	acme := &model.Company{Name: "Acme", RealmName: "acme-realm", KcClientID: "acme-client", KcClientSecret: "acme-secret", Status: "active"}
+	require.NoError(t, repository.NewCompanyRepository(db).Create(acme))
+	seedCompany(t, db, "Closed", "closed-realm", "inactive")
+	require.NoError(t, db.Create(&model.Workspace{Name: "legacy-ws"}).Error)
+
+	require.NoError(t, svc.LoadTenants())
+
+	assert.Equal(t, def.ID, config.KC.CompanyID)
+	assert.Same(t, config.KC, config.KeycloakForRealm("default-realm"))
+
+	acmeKC := config.KeycloakForRealm("acme-realm")
+	require.NotNil(t, acmeKC)
+	assert.Equal(t, acme.ID, acmeKC.CompanyID)
+	assert.Equal(t, "acme-client", acmeKC.ClientName)
+	assert.Equal(t, "http://keycloak:8080", acmeKC.Host, "호스트는 기본 설정과 공유")
+	assert.Nil(t, config.KeycloakForRealm("closed-realm"), "비활성 회사는 등록하지 않음")
+	assert.Equal(t, "acme-realm", config.RealmFromIssuer("http://keycloak:8080/realms/acme-realm"))
+
+	var ws model.Workspace
+	require.NoError(t, db.Where("name = ?", "legacy-ws").First(&ws).Error)
+	require.NotNil(t, ws.CompanyID)
+	assert.Equal(t, def.ID, *ws.CompanyID)
+}
@@ -51,6 +51,7 @@ func (s *CspAccountService) CreateCspAccount(req *model.CreateCspAccountRequest)
 		AccountInfo: req.AccountInfo,
 		IsActive:    true,
 		Description: req.Description,
+		CompanyID:   req.CompanyID,
 	}
 
 	if err := s.cspAccountRepo.Create(account); err != nil {
@@ -0,0 +1,183 @@
+package service
+
+// csp_credential_cache_test.go
+// 임시 자격 증명 발급 캐시 단위 테스트 (SQLite in-memory DB)
+//
+// 테스트 범위:
+//   - 같은 키(사용자, 워크스페이스, 역할, CSP 역할, CSP, 인증방식, 리전) 재요청 시 캐시 반환, forceRefresh 시 재발급
+//   - 다른 워크스페이스 또는 CSP 역할 매핑 변경 시 재발급
+//   - 잔여 시간이 최소 잔여 시간보다 짧으면 재발급
+//   - 캐시 저장 시 비밀값 암호화
+//   - 사용자/역할 단위 캐시 폐기
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/pkg/envelope"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// countingAwsCredService STS 호출 횟수를 기록하는 AWS mock
+type countingAwsCredService struct {
+	mockAwsCredService
+	calls      int
+	expiration time.Time
+}
+
+func (m *countingAwsCredService) AssumeRoleWithWebIdentity(_ context.Context, roleArn, kcUserId, token, idpArn, region string) (*model.CspCredentialResponse, error) {
+	m.calls++
+	return &model.CspCredentialResponse{
+		CspType:         "aws",
+		AccessKeyId:     "ASIA_CACHED",
+		SecretAccessKey: "secret_cached",
+		SessionToken:    "token_cached",
+		Expiration:      m.expiration,
+		Region:          region,
+	}, nil
+}
+
+func newCachedCredService(t *testing.T, aws *countingAwsCredService) (*CspCredentialService, *gorm.DB) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&model.User{}, &model.TempCredential{}))
+	provider, err := envelope.NewStaticKeyProvider("test", bytes.Repeat([]byte{7}, 32))
+	require.NoError(t, err)
+	envelope.SetDefault(provider)
+	t.Cleanup(func() { envelope.SetDefault(nil) })
+
+	svc := newCredServiceWithMocks(credServiceDeps{
+		aws:      &mockAwsCredService{},
+		gcp:      &mockGcpCredService{},
+		alibaba:  &mockAlibabaCredService{},
+		kc:       oidcKC(),
+		userRepo: &mockUserRepoForCred{role: stdUserRole()},
+		mapRepo:  &mockCspMappingRepo{mapping: buildMapping(constants.AuthMethodOIDC, idpArn, roleArn, model.AuthMethodOIDC, nil)},
+	})
+	svc.awsCredService = aws
+	svc.db = db
+	svc.userRepo = repository.NewUserRepository(db)
+	svc.tempCredRepo = repository.NewTempCredentialRepository(db)
+	return svc, db
+}
+
+func TestGetTemporaryCredentials_CacheHitAndForceRefresh(t *testing.T) {
+	aws := &countingAwsCredService{expiration: time.Now().Add(time.Hour)}
+	svc, db := newCachedCredService(t, aws)
+
+	first, err := svc.GetTemporaryCredentials(context.Background(), 1, "kc_user_id", req("aws", "OIDC"))
+	require.NoError(t, err)
+	assert.False(t, first.Cached)
+
+	second, err := svc.GetTemporaryCredentials(context.Background(), 1, "kc_user_id", req("aws", "OIDC"))
+	require.NoError(t, err)
+	assert.True(t, second.Cached)
+	assert.Equal(t, "secret_cached", second.SecretAccessKey)
+	assert.Equal(t, 1, aws.calls, "캐시 적중 시 STS를 호출하지 않아야 한다")
+
+	// 다른 사용자는 캐시를 공유하지 않는다
+	_, err = svc.GetTemporaryCredentials(context.Background(), 2, "other_user", req("aws", "OIDC"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, aws.calls)
+
+	forced := req("aws", "OIDC")
+	forced.ForceRefresh = true
+	_, err = svc.GetTemporaryCredentials(context.Background(), 1, "kc_user_id", forced)
+	require.NoError(t, err)
+	assert.Equal(t, 3, aws.calls)
+
+	// 비밀값은 평문으로 저장되지 않는다
+	var payloads []string
+	require.NoError(t, db.Table("mcmp_temp_credentials").Pluck("payload", &payloads).Error)
+	require.Len(t, payloads, 3)
+	for _, payload := range payloads {
+		assert.True(t, envelope.IsEncrypted(payload))
+		assert.NotContains(t, payload, "secret_cached")
+	}
+	var active int64
+	require.NoError(t, db.Model(&model.TempCredential{}).Where("issued_by = ? AND is_active = ?", "kc_user_id", true).Count(&active).Error)
+	assert.Equal(t, int64(1), active, "재발급 시 기존 캐시는 비활성화되어야 한다")
+}
+
+func TestGetTemporaryCredentials_CacheKeyedByWorkspaceAndCspRole(t *testing.T) {
+	aws := &countingAwsCredService{expiration: time.Now().Add(time.Hour)}
+	svc, _ := newCachedCredService(t, aws)
+
+	_, err := svc.GetTemporaryCredentials(context.Background(), 1, "kc_user_id", req("aws", "OIDC"))
+	require.NoError(t, err)
+
+	// 다른 워크스페이스 요청은 캐시를 공유하지 않는다
+	other := req("aws", "OIDC")
+	other.WorkspaceID = "2"
+	cred, err := svc.GetTemporaryCredentials(context.Background(), 1, "kc_user_id", other)
+	require.NoError(t, err)
+	assert.False(t, cred.Cached)
+	assert.Equal(t, 2, aws.calls)
+
+	// 역할의 CSP 역할 매핑이 바뀌면 기존 캐시를 사용하지 않는다
+	svc.mappingRepoIface = &mockCspMappingRepo{mapping: buildMapping(constants.AuthMethodOIDC, idpArn, "arn:aws:iam::123456789012:role/mciam-other", model.AuthMethodOIDC, nil)}
+	cred, err = svc.GetTemporaryCredentials(context.Background(), 1, "kc_user_id", req("aws", "OIDC"))
+	require.NoError(t, err)
+	assert.False(t, cred.Cached)
+	assert.Equal(t, 3, aws.calls)
+
+	cred, err = svc.GetTemporaryCredentials(context.Background(), 1, "kc_user_id", req("aws", "OIDC"))
+	require.NoError(t, err)
+	assert.True(t, cred.Cached)
+	assert.Equal(t, 3, aws.calls)
+}
+
+func TestGetTemporaryCredentials_CacheSkipsNearExpiry(t *testing.T) {
+	// 기본 최소 잔여 시간(300초)보다 짧게 남은 자격 증명은 재사용하지 않는다
+	aws := &countingAwsCredService{expiration: time.Now().Add(2 * time.Minute)}
+	svc, _ := newCachedCredService(t, aws)
+
+	for i := 0; i < 2; i++ {
+		cred, err := svc.GetTemporaryCredentials(context.Background(), 1, "kc_user_id", req("aws", "OIDC"))
+		require.NoError(t, err)
+		assert.False(t, cred.Cached)
+	}
+	assert.Equal(t, 2, aws.calls)
+}
+
+func TestRevokeCachedCredentials(t *testing.T) {
+	aws := &countingAwsCredService{expiration: time.Now().Add(time.Hour)}
+	svc, db := newCachedCredService(t, aws)
+	user := &model.User{Username: "cred-user", KcId: "kc_user_id"}
+	require.NoError(t, db.Create(user).Error)
+
+	_, err := svc.GetTemporaryCredentials(context.Background(), user.ID, user.KcId, req("aws", "OIDC"))
+	require.NoError(t, err)
+
+	_, err = svc.RevokeCachedCredentials(0, "", nil)
+	assert.ErrorIs(t, err, ErrTempCredentialRevokeFilterRequired)
+	_, err = svc.RevokeCachedCredentials(9999, "", nil)
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	revoked, err := svc.RevokeCachedCredentials(user.ID, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), revoked)
+
+	cred, err := svc.GetTemporaryCredentials(context.Background(), user.ID, user.KcId, req("aws", "OIDC"))
+	require.NoError(t, err)
+	assert.False(t, cred.Cached)
+	assert.Equal(t, 2, aws.calls)
+
+	// 역할 단위 폐기
+	roleID := stdUserRole().RoleID
+	revoked, err = svc.RevokeCachedCredentials(0, "", &roleID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), revoked)
+}
@@ -2,13 +2,17 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/m-cmp/mc-iam-manager/config"
 	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/pkg/metrics"
 	"github.com/m-cmp/mc-iam-manager/repository"
 	"github.com/m-cmp/mc-iam-manager/util"
 	"gorm.io/gorm"
@@ -30,6 +34,7 @@ var (
 	ErrNoCspRoleMappingFound  = errors.New("no suitable CSP role mapping found for the user's roles in this workspace")
 	ErrUnsupportedCspType     = errors.New("unsupported CSP type requested")
 	ErrUnsupportedAuthMethod  = errors.New("unsupported auth method for this CSP type")
+	ErrTempCredentialRevokeFilterRequired = errors.New("userId or roleId is required to revoke cached credentials")
 )
 
 // CspCredentialService CSP 임시 자격 증명 발급 조율 서비스
@@ -46,6 +51,8 @@ type CspCredentialService struct {
 	tencentCredService  TencentCredentialService
 	ibmCredService      IbmCredentialService
 	keycloakService     KeycloakService
+	tempCredRepo        *repository.TempCredentialRepository // 발급 캐시 (nil이면 캐시 미사용)
+	roleRepo            *repository.RoleRepository           // 상위 역할 조회 (nil이면 역할 상속 미적용)
 }
 
 // NewCspCredentialService 새 CspCredentialService 인스턴스 생성
@@ -70,6 +77,8 @@ func NewCspCredentialService(db *gorm.DB) *CspCredentialService {
 		tencentCredService: tencentCredService,
 		ibmCredService:     ibmCredService,
 		keycloakService:    keycloakService,
+		tempCredRepo:       repository.NewTempCredentialRepository(db),
+		roleRepo:           repository.NewRoleRepository(db),
 	}
 }
 
@@ -89,10 +98,30 @@ func (s *CspCredentialService) resolveMappingRepo() credMappingRepo {
 	return s.mappingRepo
 }
 
+// roleWithAncestors 역할 ID와 상위 역할 ID 목록 (가까운 순)
+func (s *CspCredentialService) roleWithAncestors(roleID uint) ([]uint, error) {
+	if s.roleRepo == nil {
+		return []uint{roleID}, nil
+	}
+	ancestors, err := s.roleRepo.FindRoleAncestors([]uint{roleID})
+	if err != nil {
+		return nil, err
+	}
+	return append([]uint{roleID}, ancestors[roleID]...), nil
+}
+
 // GetTemporaryCredentials 사용자의 워크스페이스 역할에 기반하여 CSP 임시 자격 증명 발급
 func (s *CspCredentialService) GetTemporaryCredentials(ctx context.Context, userID uint, kcUserId string, req *model.CspCredentialRequest) (*model.CspCredentialResponse, error) {
 	log.Printf("[CSP_CREDENTIAL] Starting GetTemporaryCredentials - UserID: %d, WorkspaceID: %s, CspType: %s", userID, req.WorkspaceID, req.CspType)
 
+	// 발급 결과 지표 (csp/인증방식/결과별)
+	start := time.Now()
+	outcome := metrics.CredentialOutcomeError
+	authMethod := model.AuthMethodType(req.AuthMethod)
+	defer func() {
+		metrics.ObserveCspCredential(req.CspType, string(authMethod), outcome, time.Since(start))
+	}()
+
 	workspaceIDInt, err := util.StringToUint(req.WorkspaceID)
 	if err != nil {
 		log.Printf("[CSP_CREDENTIAL] Error converting workspace ID: %v", err)
@@ -127,10 +156,22 @@ func (s *CspCredentialService) GetTemporaryCredentials(ctx context.Context, user
 	log.Printf("[CSP_CREDENTIAL] Found user workspace role - RoleID: %d", userWorkspaceRole.RoleID)
 
 	// 2. Find the first matching CSP role mapping (authMethod 지정 시 해당 방식 매핑만 조회)
-	log.Printf("[CSP_CREDENTIAL] Finding CSP role mappings for role %d, csp type %s, authMethod %s", userWorkspaceRole.RoleID, cspType, req.AuthMethod)
-	targetMapping, err := s.resolveMappingRepo().FindCspRoleMappingsByRoleIDAndCspType(userWorkspaceRole.RoleID, cspType, req.AuthMethod)
+	// 역할에 매핑이 없으면 상위 역할(parent_id)을 가까운 순으로 조회
+	candidateRoleIDs, err := s.roleWithAncestors(userWorkspaceRole.RoleID)
 	if err != nil {
-		log.Printf("[CSP_CREDENTIAL] Error finding CSP role mapping for role %d: %v", userWorkspaceRole.RoleID, err)
+		log.Printf("[CSP_CREDENTIAL] Error resolving role hierarchy for role %d: %v", userWorkspaceRole.RoleID, err)
+		return nil, fmt.Errorf("failed to resolve role hierarchy: %w", err)
+	}
+	var targetMapping *model.RoleMasterCspRoleMapping
+	for _, candidateRoleID := range candidateRoleIDs {
+		log.Printf("[CSP_CREDENTIAL] Finding CSP role mappings for role %d, csp type %s, authMethod %s", candidateRoleID, cspType, req.AuthMethod)
+		targetMapping, err = s.resolveMappingRepo().FindCspRoleMappingsByRoleIDAndCspType(candidateRoleID, cspType, req.AuthMethod)
+		if err != nil {
+			log.Printf("[CSP_CREDENTIAL] Error finding CSP role mapping for role %d: %v", candidateRoleID, err)
+		}
+		if targetMapping != nil {
+			break
+		}
 	}
 
 	if targetMapping == nil {
@@ -167,7 +208,7 @@ func (s *CspCredentialService) GetTemporaryCredentials(ctx context.Context, user
 	log.Printf("[CSP_CREDENTIAL] Role ARN: %s", roleArn)
 
 	// 5. Determine auth method from CspIdpConfig (with backward-compat defaults)
-	authMethod := model.AuthMethodType("")
+	authMethod = ""
 	if targetCspRole.CspIdpConfig != nil {
 		authMethod = targetCspRole.CspIdpConfig.AuthMethod
 	}
@@ -179,7 +220,40 @@ func (s *CspCredentialService) GetTemporaryCredentials(ctx context.Context, user
 	}
 	log.Printf("[CSP_CREDENTIAL] Auth method resolved: cspType=%s, authMethod=%s", cspType, authMethod)
 
-	// 6. Dispatch by (cspType, authMethod)
+	// 6. 캐시된 자격 증명 재사용 (사용자, 워크스페이스, 워크스페이스 역할, CSP 역할, CSP, 인증방식, 리전 기준)
+	// CSP 역할 매핑이 바뀌면 roleArn이 달라져 캐시를 사용하지 않는다
+	roleID := userWorkspaceRole.RoleID
+	cacheKey := &model.TempCredential{
+		Provider:     cspType,
+		AuthType:     string(authMethod),
+		Region:       region,
+		IssuedBy:     kcUserId,
+		RoleMasterID: &roleID,
+		WorkspaceID:  &workspaceIDInt,
+		RoleArn:      roleArn,
+	}
+	if !req.ForceRefresh {
+		if cached := s.getCachedCredential(cacheKey); cached != nil {
+			log.Printf("[CSP_CREDENTIAL] Returning cached credential - RoleID: %d, CspType: %s, AuthMethod: %s, ExpiresAt: %s", roleID, cspType, authMethod, cached.Expiration)
+			outcome = metrics.CredentialOutcomeCached
+			return cached, nil
+		}
+	}
+
+	// 7. Dispatch by (cspType, authMethod)
+	credential, err := s.issueTemporaryCredentials(ctx, kcUserId, cspType, region, authMethod, targetCspRole, idpArn, roleArn)
+	if err != nil {
+		return nil, err
+	}
+	if authMethod != model.AuthMethodSecretKey {
+		s.cacheCredential(cacheKey, credential)
+	}
+	outcome = metrics.CredentialOutcomeIssued
+	return credential, nil
+}
+
+// issueTemporaryCredentials (cspType, authMethod)에 따라 CSP STS 등을 호출하여 자격 증명 발급
+func (s *CspCredentialService) issueTemporaryCredentials(ctx context.Context, kcUserId, cspType, region string, authMethod model.AuthMethodType, targetCspRole *model.CspRole, idpArn, roleArn string) (*model.CspCredentialResponse, error) {
 	switch cspType {
 	case "aws":
 		switch authMethod {
@@ -442,6 +516,72 @@ func (s *CspCredentialService) GetTemporaryCredentials(ctx context.Context, user
 	}
 }
 
+// getCachedCredential 재사용 가능한 캐시된 자격 증명 조회. 없거나 조회 실패 시 nil
+func (s *CspCredentialService) getCachedCredential(key *model.TempCredential) *model.CspCredentialResponse {
+	if s.tempCredRepo == nil || !config.TempCredentialCacheEnabled() {
+		return nil
+	}
+	minRemaining := time.Duration(config.TempCredentialMinRemainingSec()) * time.Second
+	cached, err := s.tempCredRepo.FindCachedCredential(key, minRemaining)
+	if err != nil {
+		log.Printf("[CSP_CREDENTIAL] Failed to look up cached credential: %v", err)
+		return nil
+	}
+	if cached == nil {
+		return nil
+	}
+
+	var credential model.CspCredentialResponse
+	if err := json.Unmarshal([]byte(cached.Payload), &credential); err != nil {
+		log.Printf("[CSP_CREDENTIAL] Discarding malformed cached credential %d: %v", cached.ID, err)
+		_ = s.tempCredRepo.DeactivateCredential(cached.ID)
+		return nil
+	}
+	credential.Cached = true
+	return &credential
+}
+
+// cacheCredential 발급된 자격 증명을 저장 (Payload는 저장 시 암호화). 만료 시간이 없는 자격 증명은 캐시하지 않는다.
+func (s *CspCredentialService) cacheCredential(key *model.TempCredential, credential *model.CspCredentialResponse) {
+	if s.tempCredRepo == nil || !config.TempCredentialCacheEnabled() || credential == nil || credential.Expiration.IsZero() {
+		return
+	}
+	payload, err := json.Marshal(credential)
+	if err != nil {
+		log.Printf("[CSP_CREDENTIAL] Failed to marshal credential for cache: %v", err)
+		return
+	}
+
+	entry := *key
+	entry.AccessKeyId = credential.AccessKeyId
+	entry.IssuedAt = time.Now()
+	entry.ExpiresAt = credential.Expiration
+	entry.IsActive = true
+	entry.Payload = string(payload) // 저장 시 encrypted serializer로 암호화
+	if err := s.tempCredRepo.SaveCachedCredential(&entry); err != nil {
+		log.Printf("[CSP_CREDENTIAL] Failed to cache credential: %v", err)
+	}
+}
+
+// RevokeCachedCredentials 사용자 및/또는 워크스페이스 역할의 캐시된 임시 자격 증명 폐기
+// userID가 지정되면 해당 사용자의 Keycloak ID로 변환하여 사용한다.
+func (s *CspCredentialService) RevokeCachedCredentials(userID uint, kcUserId string, roleID *uint) (int64, error) {
+	if userID != 0 {
+		user, err := s.userRepo.FindUserByID(userID)
+		if err != nil {
+			if errors.Is(err, repository.ErrUserNotFound) {
+				return 0, ErrUserNotFound
+			}
+			return 0, err
+		}
+		kcUserId = user.KcId
+	}
+	if kcUserId == "" && roleID == nil {
+		return 0, ErrTempCredentialRevokeFilterRequired
+	}
+	return s.tempCredRepo.RevokeCachedCredentials(kcUserId, roleID)
+}
+
 // getSecretKeyCredentials SECRET_KEY 방식: CspIdpConfig에 저장된 키를 직접 반환
 func getSecretKeyCredentials(cspType string, idpConfig *model.CspIdpConfig, region string) (*model.CspCredentialResponse, error) {
 	if idpConfig == nil {
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/config"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+// cspHealthCheckTimeout 대상 1건의 헬스 체크 제한 시간 (BulkHealthCheck와 동일)
+const cspHealthCheckTimeout = 30 * time.Second
+
+// CspHealthMonitor 활성 CSP IdP 설정과 CSP 계정을 주기적으로 헬스 체크하여 이력과 현재 상태를 기록하는 백그라운드 작업
+type CspHealthMonitor struct {
+	idpConfigRepo   *repository.CspIdpConfigRepository
+	accountRepo     *repository.CspAccountRepository
+	healthRepo      *repository.CspHealthRepository
+	retention       time.Duration
+	testIdpConfig   func(ctx context.Context, configID uint) error
+	validateAccount func(ctx context.Context, accountID uint) (*model.CspAccountValidationResponse, error)
+}
+
+// NewCspHealthMonitor 새 CspHealthMonitor 인스턴스 생성
+func NewCspHealthMonitor(db *gorm.DB) *CspHealthMonitor {
+	idpConfigService := NewCspIdpConfigService(db, NewKeycloakService())
+	accountService := NewCspAccountService(db)
+	return &CspHealthMonitor{
+		idpConfigRepo:   repository.NewCspIdpConfigRepository(db),
+		accountRepo:     repository.NewCspAccountRepository(db),
+		healthRepo:      repository.NewCspHealthRepository(db),
+		retention:       config.CspHealthRetention(),
+		testIdpConfig:   idpConfigService.TestConnection,
+		validateAccount: accountService.ValidateCspAccount,
+	}
+}
+
+// Run interval마다 CheckAll 실행. ctx가 취소되면 종료
+func (m *CspHealthMonitor) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := m.CheckAll(ctx); err != nil {
+			log.Printf("[WARN] csp health check: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckAll 활성 IdP 설정과 활성 CSP 계정을 동시에 헬스 체크하고 결과 기록
+func (m *CspHealthMonitor) CheckAll(ctx context.Context) error {
+	configs, err := m.idpConfigRepo.GetActiveConfigs()
+	if err != nil {
+		return err
+	}
+	accounts, err := m.accountRepo.GetActiveAccounts()
+	if err != nil {
+		return err
+	}
+
+	checks := make([]*model.CspHealthCheck, 0, len(configs)+len(accounts))
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	collect := func(check *model.CspHealthCheck) {
+		mu.Lock()
+		checks = append(checks, check)
+		mu.Unlock()
+	}
+	for _, cfg := range configs {
+		wg.Add(1)
+		go func(cfg *model.CspIdpConfig) {
+			defer wg.Done()
+			collect(m.checkIdpConfig(ctx, cfg))
+		}(cfg)
+	}
+	for _, account := range accounts {
+		wg.Add(1)
+		go func(account *model.CspAccount) {
+			defer wg.Done()
+			collect(m.checkAccount(ctx, account))
+		}(account)
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, check := range checks {
+		if err := m.healthRepo.RecordCheck(check); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if check.Transition {
+			log.Printf("[WARN] csp health: %s %d (%s) %s -> %s: %s",
+				check.TargetType, check.TargetID, check.TargetName, check.PreviousStatus, check.Status, check.ErrorMsg)
+		}
+	}
+
+	if m.retention > 0 {
+		if _, err := m.healthRepo.PruneHistory(time.Now().UTC().Add(-m.retention)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m *CspHealthMonitor) checkIdpConfig(ctx context.Context, cfg *model.CspIdpConfig) *model.CspHealthCheck {
+	check := &model.CspHealthCheck{
+		TargetType: model.CspHealthTargetIdpConfig,
+		TargetID:   cfg.ID,
+		TargetName: cfg.Name,
+		AuthMethod: string(cfg.AuthMethod),
+	}
+	if cfg.CspAccount != nil {
+		check.CspType = cfg.CspAccount.CspType
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, cspHealthCheckTimeout)
+	defer cancel()
+	start := time.Now()
+	err := m.testIdpConfig(checkCtx, cfg.ID)
+	finishCheck(checkCtx, check, start, err)
+	return check
+}
+
+// checkAccount 계정 검증 결과 중 하나라도 유효하지 않으면 FAILED
+func (m *CspHealthMonitor) checkAccount(ctx context.Context, account *model.CspAccount) *model.CspHealthCheck {
+	check := &model.CspHealthCheck{
+		TargetType: model.CspHealthTargetAccount,
+		TargetID:   account.ID,
+		TargetName: account.Name,
+		CspType:    account.CspType,
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, cspHealthCheckTimeout)
+	defer cancel()
+	start := time.Now()
+	resp, err := m.validateAccount(checkCtx, account.ID)
+	if err == nil && resp != nil {
+		var failures []string
+		for _, r := range resp.Results {
+			if !r.Valid {
+				failures = append(failures, fmt.Sprintf("%s: %s", r.CspRoleName, r.Error))
+			}
+		}
+		if len(failures) > 0 {
+			err = errors.New(strings.Join(failures, "; "))
+		}
+	}
+	finishCheck(checkCtx, check, start, err)
+	return check
+}
+
+// finishCheck 소요 시간과 상태(CONNECTED/FAILED/TIMEOUT) 설정
+func finishCheck(ctx context.Context, check *model.CspHealthCheck, start time.Time, err error) {
+	check.CheckedAt = time.Now().UTC()
+	check.LatencyMs = time.Since(start).Milliseconds()
+	switch {
+	case err == nil:
+		check.Status = model.CspHealthStatusConnected
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		check.Status = model.CspHealthStatusTimeout
+		check.ErrorMsg = err.Error()
+	default:
+		check.Status = model.CspHealthStatusFailed
+		check.ErrorMsg = err.Error()
+	}
+}
@@ -0,0 +1,105 @@
+package service
+
+// csp_health_monitor_test.go
+// CSP IdP 설정/계정 주기 헬스 체크(CspHealthMonitor) 단위 테스트 (SQLite in-memory DB)
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newCspHealthTestMonitor(t *testing.T) (*CspHealthMonitor, *gorm.DB) {
+	t.Helper()
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.CspHealthCheck{}, &model.CspHealthState{}))
+	m := &CspHealthMonitor{
+		idpConfigRepo: repository.NewCspIdpConfigRepository(db),
+		accountRepo:   repository.NewCspAccountRepository(db),
+		healthRepo:    repository.NewCspHealthRepository(db),
+		retention:     24 * time.Hour,
+		testIdpConfig: func(ctx context.Context, configID uint) error { return nil },
+		validateAccount: func(ctx context.Context, accountID uint) (*model.CspAccountValidationResponse, error) {
+			return &model.CspAccountValidationResponse{AccountID: accountID}, nil
+		},
+	}
+	return m, db
+}
+
+func TestCspHealthMonitor_RecordsTransitions(t *testing.T) {
+	m, db := newCspHealthTestMonitor(t)
+	account := &model.CspAccount{Name: "aws-main", CspType: "aws", IsActive: true}
+	require.NoError(t, db.Create(account).Error)
+	cfg := &model.CspIdpConfig{Name: "aws-oidc", CspAccountID: account.ID, AuthMethod: model.AuthMethodOIDC, Config: map[string]string{"audience": "x"}, IsActive: true}
+	require.NoError(t, db.Create(cfg).Error)
+
+	require.NoError(t, m.CheckAll(context.Background()))
+
+	// IdP 연결 실패 + 계정 역할 검증 실패
+	m.testIdpConfig = func(ctx context.Context, configID uint) error { return errors.New("sts: access denied") }
+	m.validateAccount = func(ctx context.Context, accountID uint) (*model.CspAccountValidationResponse, error) {
+		return &model.CspAccountValidationResponse{AccountID: accountID, Results: []model.CspAccountValidationResult{
+			{CspRoleName: "ok-role", Valid: true},
+			{CspRoleName: "broken-role", Valid: false, Error: "role not found"},
+		}}, nil
+	}
+	require.NoError(t, m.CheckAll(context.Background()))
+	require.NoError(t, m.CheckAll(context.Background()))
+
+	svc := NewCspHealthService(db)
+	states, err := svc.ListStatus(model.CspHealthTargetIdpConfig)
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, cfg.ID, states[0].TargetID)
+	assert.Equal(t, "aws", states[0].CspType)
+	assert.Equal(t, model.CspHealthStatusFailed, states[0].Status)
+	assert.Equal(t, 2, states[0].ConsecutiveFailures)
+	assert.NotNil(t, states[0].LastSuccessAt)
+
+	resp, err := svc.GetHistory(model.CspHealthTargetIdpConfig, cfg.ID, nil)
+	require.NoError(t, err)
+	require.Len(t, resp.History, 3)
+	assert.Equal(t, model.CspHealthStatusConnected, resp.History[2].Status)
+	assert.False(t, resp.History[2].Transition, "최초 체크는 전이가 아니다")
+	assert.True(t, resp.History[1].Transition)
+	assert.Equal(t, model.CspHealthStatusConnected, resp.History[1].PreviousStatus)
+	assert.False(t, resp.History[0].Transition)
+	assert.Equal(t, "sts: access denied", resp.History[0].ErrorMsg)
+
+	transitions, err := svc.GetHistory(model.CspHealthTargetAccount, account.ID, &model.CspHealthHistoryFilter{TransitionOnly: true})
+	require.NoError(t, err)
+	require.Len(t, transitions.History, 1)
+	assert.Equal(t, model.CspHealthStatusFailed, transitions.History[0].Status)
+	assert.Contains(t, transitions.History[0].ErrorMsg, "broken-role: role not found")
+	require.NotNil(t, transitions.State)
+	assert.Equal(t, transitions.History[0].CheckedAt.Unix(), transitions.State.LastChangedAt.Unix())
+}
+
+func TestCspHealthMonitor_SkipsInactiveAndPrunesHistory(t *testing.T) {
+	m, db := newCspHealthTestMonitor(t)
+	account := &model.CspAccount{Name: "gcp-old", CspType: "gcp", IsActive: true}
+	require.NoError(t, db.Create(account).Error)
+	require.NoError(t, db.Model(account).Update("is_active", false).Error)
+
+	stale := &model.CspHealthCheck{TargetType: model.CspHealthTargetAccount, TargetID: account.ID, Status: model.CspHealthStatusConnected, CheckedAt: time.Now().UTC().Add(-48 * time.Hour)}
+	require.NoError(t, db.Create(stale).Error)
+
+	require.NoError(t, m.CheckAll(context.Background()))
+
+	var count int64
+	db.Model(&model.CspHealthCheck{}).Count(&count)
+	assert.Zero(t, count, "비활성 대상은 체크하지 않고 보관 기간이 지난 이력은 삭제")
+}
+
+func TestCspHealthService_RejectsInvalidSince(t *testing.T) {
+	_, db := newCspHealthTestMonitor(t)
+	_, err := NewCspHealthService(db).GetHistory(model.CspHealthTargetIdpConfig, 1, &model.CspHealthHistoryFilter{Since: "yesterday"})
+	assert.ErrorIs(t, err, ErrInvalidCspHealthFilter)
+}
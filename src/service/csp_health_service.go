@@ -0,0 +1,66 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	cspHealthHistoryDefaultLimit = 100
+	cspHealthHistoryMaxLimit     = 1000
+)
+
+// ErrInvalidCspHealthFilter 헬스 이력 조회 조건 오류
+var ErrInvalidCspHealthFilter = errors.New("invalid health history filter")
+
+// CspHealthService CSP IdP 설정/계정 헬스 상태 및 이력 조회 서비스
+type CspHealthService struct {
+	healthRepo *repository.CspHealthRepository
+}
+
+// NewCspHealthService 새 CspHealthService 인스턴스 생성
+func NewCspHealthService(db *gorm.DB) *CspHealthService {
+	return &CspHealthService{
+		healthRepo: repository.NewCspHealthRepository(db),
+	}
+}
+
+// ListStatus 대상 유형별 현재 헬스 상태 목록
+func (s *CspHealthService) ListStatus(targetType model.CspHealthTargetType) ([]model.CspHealthState, error) {
+	return s.healthRepo.ListStates(targetType)
+}
+
+// GetHistory 대상의 현재 상태와 헬스 체크 이력 (최신순)
+func (s *CspHealthService) GetHistory(targetType model.CspHealthTargetType, targetID uint, filter *model.CspHealthHistoryFilter) (*model.CspHealthHistoryResponse, error) {
+	limit := cspHealthHistoryDefaultLimit
+	var since *time.Time
+	transitionOnly := false
+	if filter != nil {
+		if filter.Limit > 0 {
+			limit = min(filter.Limit, cspHealthHistoryMaxLimit)
+		}
+		if filter.Since != "" {
+			t, err := time.Parse(time.RFC3339, filter.Since)
+			if err != nil {
+				return nil, fmt.Errorf("%w: since must be RFC3339: %v", ErrInvalidCspHealthFilter, err)
+			}
+			since = &t
+		}
+		transitionOnly = filter.TransitionOnly
+	}
+
+	state, err := s.healthRepo.GetState(targetType, targetID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.healthRepo.ListHistory(targetType, targetID, since, transitionOnly, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &model.CspHealthHistoryResponse{State: state, History: history}, nil
+}
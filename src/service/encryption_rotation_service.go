@@ -0,0 +1,80 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/model/mcmpapi"
+	"gorm.io/gorm"
+)
+
+// encryptedColumns 저장 시 암호화되는 모델과 컬럼
+type encryptedColumns struct {
+	model   interface{}
+	columns []string
+}
+
+// encryptedModels 암호화 대상 모델 목록. serializer:encrypted / encryptedjson 필드를 추가하면 여기에도 등록한다.
+var encryptedModels = []encryptedColumns{
+	{model: &model.CspIdpConfig{}, columns: []string{"config"}},
+	{model: &mcmpapi.McmpApiService{}, columns: []string{"auth_pass"}},
+	{model: &model.Company{}, columns: []string{"kc_client_secret"}},
+	{model: &model.TempCredential{}, columns: []string{"secret_access_key", "session_token", "payload"}},
+	{model: &model.WorkspaceTicket{}, columns: []string{"ticket"}},
+}
+
+// EncryptionRotationResult 테이블별 재암호화 결과
+type EncryptionRotationResult struct {
+	Table string
+	Rows  int
+}
+
+// EncryptionRotationService 저장된 비밀 값을 현재 활성 키로 재암호화하는 서비스
+type EncryptionRotationService struct {
+	db *gorm.DB
+}
+
+// NewEncryptionRotationService 새 EncryptionRotationService 인스턴스 생성
+func NewEncryptionRotationService(db *gorm.DB) *EncryptionRotationService {
+	return &EncryptionRotationService{db: db}
+}
+
+// ReEncryptAll 모든 암호화 대상 행을 읽어(이전 키 또는 평문) 활성 키로 다시 저장
+func (s *EncryptionRotationService) ReEncryptAll() ([]EncryptionRotationResult, error) {
+	results := make([]EncryptionRotationResult, 0, len(encryptedModels))
+	for _, target := range encryptedModels {
+		stmt := &gorm.Statement{DB: s.db}
+		if err := stmt.Parse(target.model); err != nil {
+			return results, fmt.Errorf("failed to parse model %T: %w", target.model, err)
+		}
+		table := stmt.Schema.Table
+
+		rows, err := s.reEncryptTable(target)
+		results = append(results, EncryptionRotationResult{Table: table, Rows: rows})
+		if err != nil {
+			return results, fmt.Errorf("failed to re-encrypt %s: %w", table, err)
+		}
+		log.Printf("Re-encrypted %d rows in %s", rows, table)
+	}
+	return results, nil
+}
+
+// reEncryptTable 배치 단위로 행을 읽어 암호화 컬럼만 다시 저장
+func (s *EncryptionRotationService) reEncryptTable(target encryptedColumns) (int, error) {
+	batch := reflect.New(reflect.SliceOf(reflect.TypeOf(target.model).Elem()))
+	count := 0
+	result := s.db.Model(target.model).FindInBatches(batch.Interface(), 100, func(tx *gorm.DB, _ int) error {
+		rows := batch.Elem()
+		for i := 0; i < rows.Len(); i++ {
+			row := rows.Index(i).Addr().Interface()
+			if err := s.db.Model(row).Select(target.columns).Updates(row).Error; err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	return count, result.Error
+}
@@ -0,0 +1,107 @@
+package service
+
+// encryption_rotation_service_test.go
+// 저장 시 암호화(encrypted serializer) 및 키 교체 재암호화 단위 테스트 (SQLite in-memory DB)
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/model/mcmpapi"
+	"github.com/m-cmp/mc-iam-manager/pkg/envelope"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newEncryptionTestDB(t *testing.T) (*gorm.DB, *envelope.FileKeyProvider) {
+	t.Helper()
+	provider, err := envelope.NewFileKeyProvider(filepath.Join(t.TempDir(), "keyring.json"), true)
+	require.NoError(t, err)
+	envelope.SetDefault(provider)
+	t.Cleanup(func() { envelope.SetDefault(nil) })
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&model.CspIdpConfig{}, &mcmpapi.McmpApiService{}, &model.Company{}, &model.TempCredential{}, &model.WorkspaceTicket{}))
+	return db, provider
+}
+
+// rawColumn serializer를 거치지 않은 DB 저장 값
+func rawColumn(t *testing.T, db *gorm.DB, table, column string) string {
+	t.Helper()
+	var value string
+	require.NoError(t, db.Table(table).Select(column).Limit(1).Scan(&value).Error)
+	return value
+}
+
+func TestEncryptedSerializer_TransparentEncryption(t *testing.T) {
+	db, _ := newEncryptionTestDB(t)
+
+	require.NoError(t, db.Create(&model.Company{Name: "C", RealmName: "r", KcClientSecret: "kc-secret"}).Error)
+	require.NoError(t, db.Create(&model.CspIdpConfig{
+		Name: "aws-secret", CspAccountID: 1, AuthMethod: model.AuthMethodSecretKey,
+		Config: map[string]string{"access_key_id": "AKIA", "secret_access_key": "aws-secret"},
+	}).Error)
+
+	raw := rawColumn(t, db, "mcmp_companies", "kc_client_secret")
+	assert.True(t, envelope.IsEncrypted(raw))
+	rawConfig := rawColumn(t, db, "mcmp_csp_idp_configs", "config")
+	assert.Contains(t, rawConfig, `"access_key_id":"AKIA"`, "비밀이 아닌 값은 평문 유지")
+	assert.NotContains(t, rawConfig, "aws-secret")
+
+	var company model.Company
+	require.NoError(t, db.First(&company).Error)
+	assert.Equal(t, "kc-secret", company.KcClientSecret)
+	var idpConfig model.CspIdpConfig
+	require.NoError(t, db.First(&idpConfig).Error)
+	assert.Equal(t, "aws-secret", idpConfig.GetSecretAccessKey())
+}
+
+func TestEncryptionRotation_ReEncryptsWithActiveKey(t *testing.T) {
+	db, provider := newEncryptionTestDB(t)
+
+	// 암호화 도입 전 평문 행
+	require.NoError(t, db.Exec("INSERT INTO mcmp_api_services (name, auth_pass, is_active) VALUES (?, ?, ?)", "svc", "legacy-pass", true).Error)
+	require.NoError(t, db.Create(&model.Company{Name: "C", RealmName: "r", KcClientSecret: "kc-secret"}).Error)
+	oldKeyID := envelope.KeyIDOf(rawColumn(t, db, "mcmp_companies", "kc_client_secret"))
+
+	newKeyID, err := provider.AddKey()
+	require.NoError(t, err)
+
+	results, err := NewEncryptionRotationService(db).ReEncryptAll()
+	require.NoError(t, err)
+	rows := map[string]int{}
+	for _, r := range results {
+		rows[r.Table] = r.Rows
+	}
+	assert.Equal(t, 1, rows["mcmp_api_services"])
+	assert.Equal(t, 1, rows["mcmp_companies"])
+
+	assert.NotEqual(t, oldKeyID, newKeyID)
+	assert.Equal(t, newKeyID, envelope.KeyIDOf(rawColumn(t, db, "mcmp_companies", "kc_client_secret")))
+	assert.Equal(t, newKeyID, envelope.KeyIDOf(rawColumn(t, db, "mcmp_api_services", "auth_pass")))
+
+	var svc mcmpapi.McmpApiService
+	require.NoError(t, db.First(&svc, "name = ?", "svc").Error)
+	assert.Equal(t, "legacy-pass", svc.AuthPass)
+}
+
+func TestMcmpApiServiceMapUpdate_EncryptsAuthPass(t *testing.T) {
+	db, _ := newEncryptionTestDB(t)
+	require.NoError(t, db.Create(&mcmpapi.McmpApiService{Name: "svc", Version: "v1", AuthPass: "old"}).Error)
+
+	// map 업데이트는 serializer를 거치지 않으므로 repository에서 암호화해야 한다
+	require.NoError(t, repository.NewMcmpApiRepository(db).UpdateService("svc", map[string]interface{}{"auth_pass": "new-pass"}))
+	assert.True(t, envelope.IsEncrypted(rawColumn(t, db, "mcmp_api_services", "auth_pass")))
+
+	var svc mcmpapi.McmpApiService
+	require.NoError(t, db.First(&svc, "name = ?", "svc").Error)
+	assert.Equal(t, "new-pass", svc.AuthPass)
+}
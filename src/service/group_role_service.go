@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"time"
 
 	"github.com/m-cmp/mc-iam-manager/constants"
 	"github.com/m-cmp/mc-iam-manager/model"
@@ -17,6 +19,7 @@ type GroupRoleService struct {
 	groupRoleRepo *repository.GroupRoleRepository
 	orgRepo       *repository.OrganizationRepository
 	roleRepo      *repository.RoleRepository
+	ticketRepo    *repository.WorkspaceTicketRepository
 	kcService     KeycloakService
 }
 
@@ -27,6 +30,7 @@ func NewGroupRoleService(db *gorm.DB) *GroupRoleService {
 		groupRoleRepo: repository.NewGroupRoleRepository(db),
 		orgRepo:       repository.NewOrganizationRepository(db),
 		roleRepo:      repository.NewRoleRepository(db),
+		ticketRepo:    repository.NewWorkspaceTicketRepository(db),
 		kcService:     NewKeycloakService(),
 	}
 }
@@ -125,6 +129,15 @@ func (s *GroupRoleService) RemoveGroupPlatformRole(ctx context.Context, groupID,
 // AssignGroupWorkspace 그룹-워크스페이스 매핑 생성 (DB 전용)
 // workspace_id, role_id 존재 여부 pre-validation 포함
 func (s *GroupRoleService) AssignGroupWorkspace(groupID, workspaceID, roleID uint) error {
+	return s.AssignGroupWorkspaceWithPeriod(groupID, workspaceID, roleID, model.RoleGrantPeriod{})
+}
+
+// AssignGroupWorkspaceWithPeriod 유효 기간을 지정한 그룹-워크스페이스 매핑 생성
+func (s *GroupRoleService) AssignGroupWorkspaceWithPeriod(groupID, workspaceID, roleID uint, period model.RoleGrantPeriod) error {
+	period = period.UTC()
+	if err := period.Validate(time.Now()); err != nil {
+		return err
+	}
 	// workspace 존재 여부 확인
 	var workspace model.Workspace
 	if err := s.db.First(&workspace, workspaceID).Error; err != nil {
@@ -141,7 +154,7 @@ func (s *GroupRoleService) AssignGroupWorkspace(groupID, workspaceID, roleID uin
 	if role == nil {
 		return repository.ErrRoleMasterNotFound
 	}
-	return s.groupRoleRepo.CreateGroupWorkspaceRole(groupID, workspaceID, roleID)
+	return s.groupRoleRepo.CreateGroupWorkspaceRoleWithPeriod(groupID, workspaceID, roleID, period)
 }
 
 // GetGroupWorkspaces 그룹의 워크스페이스 매핑 목록 조회
@@ -159,12 +172,58 @@ func (s *GroupRoleService) UpdateGroupWorkspaceRole(groupID, workspaceID, roleID
 	if role == nil {
 		return repository.ErrRoleMasterNotFound
 	}
-	return s.groupRoleRepo.UpdateGroupWorkspaceRole(groupID, workspaceID, roleID)
+	if err := s.groupRoleRepo.UpdateGroupWorkspaceRole(groupID, workspaceID, roleID); err != nil {
+		return err
+	}
+	s.revokeGroupTickets(groupID, workspaceID)
+	return nil
+}
+
+// UpdateGroupWorkspaceRoleWithPeriod 그룹-워크스페이스 역할과 유효 기간 변경 (요청에 없는 기간 필드는 저장된 값 유지)
+func (s *GroupRoleService) UpdateGroupWorkspaceRoleWithPeriod(groupID, workspaceID uint, req *model.UpdateGroupWorkspaceRoleRequest) error {
+	current, err := s.groupRoleRepo.FindGroupWorkspaceRole(groupID, workspaceID)
+	if err != nil {
+		return err
+	}
+	period := req.Period(model.RoleGrantPeriod{StartsAt: current.StartsAt, ExpiresAt: current.ExpiresAt}).UTC()
+	if err := period.Validate(time.Now()); err != nil {
+		return err
+	}
+	role, err := s.roleRepo.FindRoleByRoleID(req.RoleID, constants.RoleTypeWorkspace)
+	if err != nil {
+		return fmt.Errorf("error checking role: %w", err)
+	}
+	if role == nil {
+		return repository.ErrRoleMasterNotFound
+	}
+	if err := s.groupRoleRepo.UpdateGroupWorkspaceRoleWithPeriod(groupID, workspaceID, req.RoleID, period); err != nil {
+		return err
+	}
+	s.revokeGroupTickets(groupID, workspaceID)
+	return nil
 }
 
 // RemoveGroupWorkspaceRole 그룹-워크스페이스 매핑 제거
 func (s *GroupRoleService) RemoveGroupWorkspaceRole(groupID, workspaceID uint) error {
-	return s.groupRoleRepo.DeleteGroupWorkspaceRole(groupID, workspaceID)
+	if err := s.groupRoleRepo.DeleteGroupWorkspaceRole(groupID, workspaceID); err != nil {
+		return err
+	}
+	s.revokeGroupTickets(groupID, workspaceID)
+	return nil
+}
+
+// revokeGroupTickets 변경/제거된 그룹 역할이 담긴 소속 사용자의 워크스페이스 티켓 폐기 (실패 시 경고만 기록)
+func (s *GroupRoleService) revokeGroupTickets(groupID, workspaceID uint) {
+	if _, err := s.ticketRepo.RevokeByGroupAndWorkspace(groupID, workspaceID, workspaceTicketRevokedByGroup); err != nil {
+		log.Printf("[WARN] failed to revoke workspace tickets (groupID=%d, workspaceID=%d): %v", groupID, workspaceID, err)
+	}
+}
+
+// revokeMemberTickets 그룹에서 제외된 사용자의 그룹 워크스페이스 티켓 폐기 (실패 시 경고만 기록)
+func (s *GroupRoleService) revokeMemberTickets(userID, groupID uint) {
+	if _, err := s.ticketRepo.RevokeByUserAndGroupWorkspaces(userID, groupID, workspaceTicketRevokedByGroup); err != nil {
+		log.Printf("[WARN] failed to revoke workspace tickets (userID=%d, groupID=%d): %v", userID, groupID, err)
+	}
 }
 
 // --- User-Group (with Keycloak sync) ---
@@ -242,6 +301,7 @@ func (s *GroupRoleService) RemoveUsersFromGroup(ctx context.Context, groupID uin
 		if err := s.orgRepo.RemoveUserFromOrganization(userID, groupID); err != nil {
 			return fmt.Errorf("failed to remove user %d from group in DB: %w", userID, err)
 		}
+		s.revokeMemberTickets(userID, groupID)
 
 		// Keycloak 동기화
 		if user.KcId != "" {
@@ -289,6 +349,7 @@ func (s *GroupRoleService) RemoveUserFromGroup(ctx context.Context, userID, grou
 	if err := s.orgRepo.RemoveUserFromOrganization(userID, groupID); err != nil {
 		return err
 	}
+	s.revokeMemberTickets(userID, groupID)
 
 	// Keycloak 그룹에서 제거
 	if kcUserID != "" {
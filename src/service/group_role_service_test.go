@@ -38,6 +38,8 @@ func setupGroupRoleTestDB(t *testing.T) *gorm.DB {
 		&model.Workspace{},
 		&model.GroupPlatformRole{},
 		&model.GroupWorkspaceRole{},
+		&model.UserPlatformRole{},
+		&model.UserWorkspaceRole{},
 	))
 	return db
 }
@@ -50,6 +52,7 @@ func newTestGroupRoleService(t *testing.T) (*GroupRoleService, *gorm.DB) {
 		groupRoleRepo: repository.NewGroupRoleRepository(db),
 		orgRepo:       repository.NewOrganizationRepository(db),
 		roleRepo:      repository.NewRoleRepository(db),
+		ticketRepo:    repository.NewWorkspaceTicketRepository(db),
 		kcService:     nil, // KC 불필요한 메서드만 테스트
 	}
 	return svc, db
@@ -0,0 +1,736 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+const (
+	iamBundleKind    = "iam-bundle"
+	iamBundleVersion = "v1"
+
+	// IamBundleModeMerge 번들 항목을 생성/갱신만 하고 삭제하지 않음
+	IamBundleModeMerge = "merge"
+	// IamBundleModeReplace 번들에 포함된 역할/그룹의 매핑을 번들과 정확히 일치시킴 (번들에 없는 매핑 삭제)
+	// 권한 목록이 비어 있지 않으면 번들에 없는 MC-IAM 권한도 삭제한다. 역할/그룹 자체는 삭제하지 않는다.
+	IamBundleModeReplace = "replace"
+
+	iamBundleActionCreate = "create"
+	iamBundleActionUpdate = "update"
+	iamBundleActionDelete = "delete"
+)
+
+// ErrInvalidIamBundle 번들 형식/참조 오류
+var ErrInvalidIamBundle = errors.New("invalid iam bundle")
+
+// IamBundleService 선언적 IAM 구성 번들 export/diff/apply 서비스
+type IamBundleService struct {
+	db         *gorm.DB
+	bundleRepo *repository.IamBundleRepository
+	kcService  KeycloakService
+}
+
+// NewIamBundleService 새 IamBundleService 인스턴스 생성
+func NewIamBundleService(db *gorm.DB) *IamBundleService {
+	return &IamBundleService{
+		db:         db,
+		bundleRepo: repository.NewIamBundleRepository(db),
+		kcService:  NewKeycloakService(),
+	}
+}
+
+// iamBundleOp 계획된 변경과 실행 함수
+type iamBundleOp struct {
+	change model.IamBundleChange
+	apply  func(repo *repository.IamBundleRepository) error
+	// kcSync DB 커밋 이후 실행할 Keycloak 동기화 (그룹 플랫폼 역할)
+	kcSync func(ctx context.Context) error
+}
+
+// Export 현재 IAM 구성을 번들로 내보내기
+func (s *IamBundleService) Export() (*model.IamBundle, error) {
+	bundle, err := s.bundleRepo.Export()
+	if err != nil {
+		return nil, err
+	}
+	bundle.Kind = iamBundleKind
+	bundle.Version = iamBundleVersion
+	bundle.ExportedAt = time.Now().UTC().Format(time.RFC3339)
+	bundle.Source = "db"
+	return bundle, nil
+}
+
+// ParseIamBundle YAML 또는 JSON 번들 파싱 (JSON은 YAML의 부분집합)
+func ParseIamBundle(body []byte) (*model.IamBundle, error) {
+	var bundle model.IamBundle
+	if err := yaml.Unmarshal(body, &bundle); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIamBundle, err)
+	}
+	return &bundle, nil
+}
+
+// Diff 번들 적용 시 변경 사항 계산 (dry-run, DB 변경 없음)
+func (s *IamBundleService) Diff(bundle *model.IamBundle, mode string) (*model.IamBundlePlan, error) {
+	mode, err := normalizeIamBundleMode(mode)
+	if err != nil {
+		return nil, err
+	}
+	ops, warnings, err := s.plan(bundle, mode)
+	if err != nil {
+		return nil, err
+	}
+	return newIamBundlePlan(mode, true, ops, warnings), nil
+}
+
+// Apply 번들 적용. DB 변경은 단일 트랜잭션으로 수행하고, Keycloak 동기화 실패는 경고로 반환한다.
+func (s *IamBundleService) Apply(ctx context.Context, bundle *model.IamBundle, mode string) (*model.IamBundlePlan, error) {
+	mode, err := normalizeIamBundleMode(mode)
+	if err != nil {
+		return nil, err
+	}
+	ops, warnings, err := s.plan(bundle, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.bundleRepo.WithTx(tx)
+		for _, op := range ops {
+			if err := op.apply(repo); err != nil {
+				return fmt.Errorf("failed to %s %s %s: %w", op.change.Action, op.change.Resource, op.change.Key, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range ops {
+		if op.kcSync == nil || s.kcService == nil {
+			continue
+		}
+		if err := op.kcSync(ctx); err != nil {
+			log.Printf("[WARN] iam bundle keycloak sync failed for %s %s: %v", op.change.Resource, op.change.Key, err)
+			warnings = append(warnings, fmt.Sprintf("keycloak sync failed for %s %s: %v", op.change.Resource, op.change.Key, err))
+		}
+	}
+
+	plan := newIamBundlePlan(mode, false, ops, warnings)
+	plan.Applied = true
+	return plan, nil
+}
+
+func newIamBundlePlan(mode string, dryRun bool, ops []iamBundleOp, warnings []string) *model.IamBundlePlan {
+	plan := &model.IamBundlePlan{
+		Mode:     mode,
+		DryRun:   dryRun,
+		Changes:  make([]model.IamBundleChange, 0, len(ops)),
+		Warnings: warnings,
+	}
+	for _, op := range ops {
+		plan.Changes = append(plan.Changes, op.change)
+	}
+	return plan
+}
+
+func normalizeIamBundleMode(mode string) (string, error) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	switch mode {
+	case "":
+		return IamBundleModeMerge, nil
+	case IamBundleModeMerge, IamBundleModeReplace:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w: invalid mode %q (use %s or %s)", ErrInvalidIamBundle, mode, IamBundleModeMerge, IamBundleModeReplace)
+	}
+}
+
+// iamBundleEnv 대상 환경에 미리 존재해야 하는 참조 (메뉴, 워크스페이스, CSP 역할)
+type iamBundleEnv struct {
+	menus      map[string]bool
+	workspaces map[string]bool
+	cspRoles   map[string]bool
+}
+
+// plan 현재 상태와 번들을 비교하여 실행 순서대로 정렬된 변경 목록 생성
+func (s *IamBundleService) plan(desired *model.IamBundle, mode string) ([]iamBundleOp, []string, error) {
+	if desired == nil {
+		return nil, nil, fmt.Errorf("%w: bundle is empty", ErrInvalidIamBundle)
+	}
+	if desired.Kind != "" && desired.Kind != iamBundleKind {
+		return nil, nil, fmt.Errorf("%w: kind %q (expected %q)", ErrInvalidIamBundle, desired.Kind, iamBundleKind)
+	}
+	current, err := s.bundleRepo.Export()
+	if err != nil {
+		return nil, nil, err
+	}
+	roleCompanies, err := s.bundleRepo.ListRoleCompanyIDs()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := validateIamBundle(desired, current, mode, roleCompanies); err != nil {
+		return nil, nil, err
+	}
+
+	var env iamBundleEnv
+	if env.menus, err = s.bundleRepo.ListMenuIDs(); err != nil {
+		return nil, nil, err
+	}
+	if env.workspaces, err = s.bundleRepo.ListWorkspaceNames(); err != nil {
+		return nil, nil, err
+	}
+	if env.cspRoles, err = s.bundleRepo.ListCspRoleKeys(); err != nil {
+		return nil, nil, err
+	}
+
+	p := &iamBundlePlanner{mode: mode, env: env, kcService: s.kcService}
+	p.planPermissions(desired, current)
+	p.planRoles(desired, current)
+	p.planGroups(desired, current)
+
+	ops := make([]iamBundleOp, 0)
+	ops = append(ops, p.upserts...)
+	ops = append(ops, p.links...)
+	ops = append(ops, p.unlinks...)
+	ops = append(ops, p.removals...)
+	return ops, p.warnings, nil
+}
+
+// validateIamBundle 이름 중복, 역할 타입, 번들/DB 어디에도 없는 참조, 적용 후 역할 계층 검증
+// roleCompanies는 대상 환경 역할의 소유 회사 (번들로 생성되는 역할은 공용)
+func validateIamBundle(desired, current *model.IamBundle, mode string, roleCompanies map[string]*uint) error {
+	permissions := make(map[string]bool)
+	// replace 모드에서 번들에 권한 목록이 있으면 번들 밖의 권한은 삭제되므로 참조할 수 없다
+	if mode != IamBundleModeReplace || len(desired.Permissions) == 0 {
+		for _, p := range current.Permissions {
+			permissions[p.ID] = true
+		}
+	}
+	seen := make(map[string]bool)
+	for _, p := range desired.Permissions {
+		if p.ID == "" {
+			return fmt.Errorf("%w: permission id is required", ErrInvalidIamBundle)
+		}
+		if seen[p.ID] {
+			return fmt.Errorf("%w: duplicate permission %s", ErrInvalidIamBundle, p.ID)
+		}
+		seen[p.ID] = true
+		permissions[p.ID] = true
+	}
+
+	roles := make(map[string]bool)
+	for _, r := range current.Roles {
+		roles[r.Name] = true
+	}
+	seen = make(map[string]bool)
+	for _, r := range desired.Roles {
+		if r.Name == "" {
+			return fmt.Errorf("%w: role name is required", ErrInvalidIamBundle)
+		}
+		if seen[r.Name] {
+			return fmt.Errorf("%w: duplicate role %s", ErrInvalidIamBundle, r.Name)
+		}
+		seen[r.Name] = true
+		roles[r.Name] = true
+		for _, t := range r.RoleTypes {
+			switch constants.IAMRoleType(t) {
+			case constants.RoleTypePlatform, constants.RoleTypeWorkspace, constants.RoleTypeCSP:
+			default:
+				return fmt.Errorf("%w: role %s has invalid role type %q", ErrInvalidIamBundle, r.Name, t)
+			}
+		}
+		for _, id := range append(append([]string{}, r.PlatformPermissions...), r.WorkspacePermissions...) {
+			if !permissions[id] {
+				return fmt.Errorf("%w: role %s references unknown permission %s", ErrInvalidIamBundle, r.Name, id)
+			}
+		}
+	}
+	for _, r := range desired.Roles {
+		if r.Parent != "" && !roles[r.Parent] {
+			return fmt.Errorf("%w: role %s references unknown parent role %s", ErrInvalidIamBundle, r.Name, r.Parent)
+		}
+	}
+	if err := validateIamBundleRoleParents(desired, current, mode, roleCompanies); err != nil {
+		return err
+	}
+
+	groups := make(map[string]bool)
+	for _, g := range current.Groups {
+		groups[g.Code] = true
+	}
+	seen = make(map[string]bool)
+	for _, g := range desired.Groups {
+		if g.Code == "" || g.Name == "" {
+			return fmt.Errorf("%w: group code and name are required", ErrInvalidIamBundle)
+		}
+		if seen[g.Code] {
+			return fmt.Errorf("%w: duplicate group %s", ErrInvalidIamBundle, g.Code)
+		}
+		seen[g.Code] = true
+		groups[g.Code] = true
+		for _, role := range g.PlatformRoles {
+			if !roles[role] {
+				return fmt.Errorf("%w: group %s references unknown role %s", ErrInvalidIamBundle, g.Code, role)
+			}
+		}
+		workspaces := make(map[string]bool)
+		for _, b := range g.WorkspaceRoles {
+			if !roles[b.Role] {
+				return fmt.Errorf("%w: group %s references unknown role %s", ErrInvalidIamBundle, g.Code, b.Role)
+			}
+			if workspaces[b.Workspace] {
+				return fmt.Errorf("%w: group %s has multiple roles for workspace %s", ErrInvalidIamBundle, g.Code, b.Workspace)
+			}
+			workspaces[b.Workspace] = true
+		}
+	}
+	for _, g := range desired.Groups {
+		if g.Parent != "" && !groups[g.Parent] {
+			return fmt.Errorf("%w: group %s references unknown parent group %s", ErrInvalidIamBundle, g.Code, g.Parent)
+		}
+	}
+	return nil
+}
+
+// validateIamBundleRoleParents 번들 적용 후의 역할 계층 전체에 순환이 없고, 상위 역할이 공용이거나 같은 회사 소유인지 확인
+// (RoleService.ValidateRoleParent와 같은 규칙)
+func validateIamBundleRoleParents(desired, current *model.IamBundle, mode string, roleCompanies map[string]*uint) error {
+	parents := make(map[string]string, len(current.Roles))
+	for _, r := range current.Roles {
+		parents[r.Name] = r.Parent
+	}
+	// planRoles와 같이 merge 모드의 빈 parent는 기존 상위 역할 유지
+	for _, r := range desired.Roles {
+		if r.Parent != "" || mode == IamBundleModeReplace {
+			parents[r.Name] = r.Parent
+		}
+	}
+
+	for _, r := range desired.Roles {
+		parent := parents[r.Name]
+		if parent == "" {
+			continue
+		}
+		if !roleParentCompanyAllowed(roleCompanies[r.Name], roleCompanies[parent]) {
+			return fmt.Errorf("%w: role %s: %v: %s", ErrInvalidIamBundle, r.Name, ErrRoleParentNotFound, parent)
+		}
+		visited := map[string]bool{r.Name: true}
+		for name := parent; name != ""; name = parents[name] {
+			if visited[name] {
+				return fmt.Errorf("%w: role %s: %v", ErrInvalidIamBundle, r.Name, ErrRoleHierarchyCycle)
+			}
+			visited[name] = true
+		}
+	}
+	return nil
+}
+
+// iamBundlePlanner 변경 목록을 실행 단계별로 수집
+// upserts(엔티티 생성/갱신) → links(매핑 추가) → unlinks(매핑 삭제) → removals(엔티티 삭제)
+type iamBundlePlanner struct {
+	mode      string
+	env       iamBundleEnv
+	kcService KeycloakService
+
+	upserts  []iamBundleOp
+	links    []iamBundleOp
+	unlinks  []iamBundleOp
+	removals []iamBundleOp
+	warnings []string
+}
+
+func (p *iamBundlePlanner) replace() bool {
+	return p.mode == IamBundleModeReplace
+}
+
+func (p *iamBundlePlanner) warn(format string, args ...interface{}) {
+	p.warnings = append(p.warnings, fmt.Sprintf(format, args...))
+}
+
+func (p *iamBundlePlanner) planPermissions(desired, current *model.IamBundle) {
+	existing := make(map[string]model.IamBundlePermission, len(current.Permissions))
+	for _, perm := range current.Permissions {
+		existing[perm.ID] = perm
+	}
+	wanted := make(map[string]bool, len(desired.Permissions))
+	for _, perm := range desired.Permissions {
+		perm := perm
+		wanted[perm.ID] = true
+		before, ok := existing[perm.ID]
+		if ok && before == perm {
+			continue
+		}
+		change := model.IamBundleChange{Resource: "permission", Action: iamBundleActionCreate, Key: perm.ID, After: perm}
+		if ok {
+			change.Action = iamBundleActionUpdate
+			change.Before = before
+		}
+		p.upserts = append(p.upserts, iamBundleOp{change: change, apply: func(repo *repository.IamBundleRepository) error {
+			return repo.UpsertPermission(perm)
+		}})
+	}
+
+	if !p.replace() || len(desired.Permissions) == 0 {
+		return
+	}
+	for _, perm := range current.Permissions {
+		if wanted[perm.ID] {
+			continue
+		}
+		id := perm.ID
+		p.removals = append(p.removals, iamBundleOp{
+			change: model.IamBundleChange{Resource: "permission", Action: iamBundleActionDelete, Key: id, Before: perm},
+			apply: func(repo *repository.IamBundleRepository) error {
+				return repo.DeletePermission(id)
+			},
+		})
+	}
+}
+
+func (p *iamBundlePlanner) planRoles(desired, current *model.IamBundle) {
+	existing := make(map[string]model.IamBundleRole, len(current.Roles))
+	for _, role := range current.Roles {
+		existing[role.Name] = role
+	}
+	var parents []iamBundleOp
+	for _, role := range desired.Roles {
+		role := role
+		before, ok := existing[role.Name]
+		if !ok || before.Description != role.Description || before.Predefined != role.Predefined {
+			change := model.IamBundleChange{Resource: "role", Action: iamBundleActionCreate, Key: role.Name,
+				After: map[string]interface{}{"description": role.Description, "predefined": role.Predefined}}
+			if ok {
+				change.Action = iamBundleActionUpdate
+				change.Before = map[string]interface{}{"description": before.Description, "predefined": before.Predefined}
+			}
+			p.upserts = append(p.upserts, iamBundleOp{change: change, apply: func(repo *repository.IamBundleRepository) error {
+				return repo.UpsertRole(role)
+			}})
+		}
+		// 상위 역할은 모든 역할 생성 이후 지정
+		if before.Parent != role.Parent && (role.Parent != "" || p.replace()) {
+			parents = append(parents, iamBundleOp{
+				change: model.IamBundleChange{Resource: "role", Action: iamBundleActionUpdate, Key: role.Name,
+					Before: map[string]string{"parent": before.Parent}, After: map[string]string{"parent": role.Parent}},
+				apply: func(repo *repository.IamBundleRepository) error {
+					return repo.SetRoleParent(role.Name, role.Parent)
+				},
+			})
+		}
+
+		p.planRoleTypes(role, before.RoleTypes)
+		p.planRoleMenus(role, before.Menus)
+		p.planRolePermissions(role, constants.RoleTypePlatform, role.PlatformPermissions, before.PlatformPermissions)
+		p.planRolePermissions(role, constants.RoleTypeWorkspace, role.WorkspacePermissions, before.WorkspacePermissions)
+		p.planRoleCspRoles(role, before.CspRoles)
+	}
+	p.upserts = append(p.upserts, parents...)
+}
+
+func (p *iamBundlePlanner) planRoleTypes(role model.IamBundleRole, current []string) {
+	add, remove := diffStringSets(current, role.RoleTypes)
+	for _, t := range add {
+		roleType := constants.IAMRoleType(t)
+		p.links = append(p.links, iamBundleOp{
+			change: model.IamBundleChange{Resource: "role.type", Action: iamBundleActionCreate, Key: role.Name + "/" + t},
+			apply: func(repo *repository.IamBundleRepository) error {
+				return repo.AddRoleType(role.Name, roleType)
+			},
+		})
+	}
+	if !p.replace() {
+		return
+	}
+	for _, t := range remove {
+		roleType := constants.IAMRoleType(t)
+		p.unlinks = append(p.unlinks, iamBundleOp{
+			change: model.IamBundleChange{Resource: "role.type", Action: iamBundleActionDelete, Key: role.Name + "/" + t},
+			apply: func(repo *repository.IamBundleRepository) error {
+				return repo.DeleteRoleType(role.Name, roleType)
+			},
+		})
+	}
+}
+
+func (p *iamBundlePlanner) planRoleMenus(role model.IamBundleRole, current []string) {
+	add, remove := diffStringSets(current, role.Menus)
+	for _, menuID := range add {
+		menuID := menuID
+		if !p.env.menus[menuID] {
+			p.warn("role %s: menu %s does not exist in target, skipped", role.Name, menuID)
+			continue
+		}
+		p.links = append(p.links, iamBundleOp{
+			change: model.IamBundleChange{Resource: "role.menu", Action: iamBundleActionCreate, Key: role.Name + "/" + menuID},
+			apply: func(repo *repository.IamBundleRepository) error {
+				return repo.AddRoleMenu(role.Name, menuID)
+			},
+		})
+	}
+	if !p.replace() {
+		return
+	}
+	for _, menuID := range remove {
+		menuID := menuID
+		p.unlinks = append(p.unlinks, iamBundleOp{
+			change: model.IamBundleChange{Resource: "role.menu", Action: iamBundleActionDelete, Key: role.Name + "/" + menuID},
+			apply: func(repo *repository.IamBundleRepository) error {
+				return repo.DeleteRoleMenu(role.Name, menuID)
+			},
+		})
+	}
+}
+
+func (p *iamBundlePlanner) planRolePermissions(role model.IamBundleRole, roleType constants.IAMRoleType, desired, current []string) {
+	add, remove := diffStringSets(current, desired)
+	for _, id := range add {
+		id := id
+		p.links = append(p.links, iamBundleOp{
+			change: model.IamBundleChange{Resource: "role.permission", Action: iamBundleActionCreate, Key: role.Name + "/" + string(roleType) + "/" + id},
+			apply: func(repo *repository.IamBundleRepository) error {
+				return repo.AddRolePermission(role.Name, roleType, id)
+			},
+		})
+	}
+	if !p.replace() {
+		return
+	}
+	for _, id := range remove {
+		id := id
+		p.unlinks = append(p.unlinks, iamBundleOp{
+			change: model.IamBundleChange{Resource: "role.permission", Action: iamBundleActionDelete, Key: role.Name + "/" + string(roleType) + "/" + id},
+			apply: func(repo *repository.IamBundleRepository) error {
+				return repo.DeleteRolePermission(role.Name, roleType, id)
+			},
+		})
+	}
+}
+
+func (p *iamBundlePlanner) planRoleCspRoles(role model.IamBundleRole, current []model.IamBundleCspRoleRef) {
+	key := func(ref model.IamBundleCspRoleRef) string {
+		return ref.CspType + "/" + ref.Name + "/" + ref.AuthMethod
+	}
+	have := make(map[string]bool, len(current))
+	for _, ref := range current {
+		have[key(ref)] = true
+	}
+	want := make(map[string]bool, len(role.CspRoles))
+	for _, ref := range role.CspRoles {
+		ref := ref
+		if ref.AuthMethod == "" {
+			ref.AuthMethod = string(constants.AuthMethodOIDC)
+		}
+		want[key(ref)] = true
+		if have[key(ref)] {
+			continue
+		}
+		if !p.env.cspRoles[ref.CspType+"/"+ref.Name] {
+			p.warn("role %s: csp role %s/%s does not exist in target, skipped", role.Name, ref.CspType, ref.Name)
+			continue
+		}
+		p.links = append(p.links, iamBundleOp{
+			change: model.IamBundleChange{Resource: "role.csp-role", Action: iamBundleActionCreate, Key: role.Name + "/" + key(ref)},
+			apply: func(repo *repository.IamBundleRepository) error {
+				return repo.AddRoleCspRole(role.Name, ref)
+			},
+		})
+	}
+	if !p.replace() {
+		return
+	}
+	for _, ref := range current {
+		ref := ref
+		if want[key(ref)] {
+			continue
+		}
+		p.unlinks = append(p.unlinks, iamBundleOp{
+			change: model.IamBundleChange{Resource: "role.csp-role", Action: iamBundleActionDelete, Key: role.Name + "/" + key(ref)},
+			apply: func(repo *repository.IamBundleRepository) error {
+				return repo.DeleteRoleCspRole(role.Name, ref)
+			},
+		})
+	}
+}
+
+func (p *iamBundlePlanner) planGroups(desired, current *model.IamBundle) {
+	existing := make(map[string]model.IamBundleGroup, len(current.Groups))
+	for _, group := range current.Groups {
+		existing[group.Code] = group
+	}
+	for _, group := range sortGroupsParentFirst(desired.Groups) {
+		group := group
+		before, ok := existing[group.Code]
+		if !ok || before.Name != group.Name || before.Description != group.Description || before.Parent != group.Parent {
+			change := model.IamBundleChange{Resource: "group", Action: iamBundleActionCreate, Key: group.Code,
+				After: map[string]string{"name": group.Name, "description": group.Description, "parent": group.Parent}}
+			if ok {
+				change.Action = iamBundleActionUpdate
+				change.Before = map[string]string{"name": before.Name, "description": before.Description, "parent": before.Parent}
+			}
+			p.upserts = append(p.upserts, iamBundleOp{change: change, apply: func(repo *repository.IamBundleRepository) error {
+				return repo.UpsertGroup(group)
+			}})
+			if ok && before.Name != group.Name && len(before.PlatformRoles) > 0 {
+				p.warn("group %s: renamed from %s, keycloak group is not renamed", group.Code, before.Name)
+			}
+		}
+		p.planGroupPlatformRoles(group, before.PlatformRoles)
+		p.planGroupWorkspaceRoles(group, before.WorkspaceRoles)
+	}
+}
+
+func (p *iamBundlePlanner) planGroupPlatformRoles(group model.IamBundleGroup, current []string) {
+	add, remove := diffStringSets(current, group.PlatformRoles)
+	for _, roleName := range add {
+		roleName := roleName
+		p.links = append(p.links, iamBundleOp{
+			change: model.IamBundleChange{Resource: "group.platform-role", Action: iamBundleActionCreate, Key: group.Code + "/" + roleName},
+			apply: func(repo *repository.IamBundleRepository) error {
+				return repo.AddGroupPlatformRole(group.Code, roleName)
+			},
+			kcSync: func(ctx context.Context) error {
+				exists, err := p.kcService.CheckRealmRoleExists(ctx, roleName)
+				if err != nil {
+					return err
+				}
+				if !exists {
+					if err := p.kcService.CreateRealmRoleAndWait(ctx, roleName); err != nil {
+						return err
+					}
+				}
+				return p.kcService.AddRealmRoleToGroup(ctx, group.Name, roleName)
+			},
+		})
+	}
+	if !p.replace() {
+		return
+	}
+	for _, roleName := range remove {
+		roleName := roleName
+		p.unlinks = append(p.unlinks, iamBundleOp{
+			change: model.IamBundleChange{Resource: "group.platform-role", Action: iamBundleActionDelete, Key: group.Code + "/" + roleName},
+			apply: func(repo *repository.IamBundleRepository) error {
+				return repo.DeleteGroupPlatformRole(group.Code, roleName)
+			},
+			kcSync: func(ctx context.Context) error {
+				return p.kcService.RemoveRealmRoleFromGroup(ctx, group.Name, roleName)
+			},
+		})
+	}
+}
+
+func (p *iamBundlePlanner) planGroupWorkspaceRoles(group model.IamBundleGroup, current []model.IamBundleGroupWorkspaceRole) {
+	have := make(map[string]string, len(current))
+	for _, b := range current {
+		have[b.Workspace] = b.Role
+	}
+	want := make(map[string]bool, len(group.WorkspaceRoles))
+	for _, b := range group.WorkspaceRoles {
+		b := b
+		want[b.Workspace] = true
+		role, ok := have[b.Workspace]
+		if ok && role == b.Role {
+			continue
+		}
+		if !p.env.workspaces[b.Workspace] {
+			p.warn("group %s: workspace %s does not exist in target, skipped", group.Code, b.Workspace)
+			continue
+		}
+		change := model.IamBundleChange{Resource: "group.workspace-role", Action: iamBundleActionCreate,
+			Key: group.Code + "/" + b.Workspace, After: b.Role}
+		if ok {
+			change.Action = iamBundleActionUpdate
+			change.Before = role
+		}
+		p.links = append(p.links, iamBundleOp{change: change, apply: func(repo *repository.IamBundleRepository) error {
+			return repo.SetGroupWorkspaceRole(group.Code, b)
+		}})
+	}
+	if !p.replace() {
+		return
+	}
+	for _, b := range current {
+		b := b
+		if want[b.Workspace] {
+			continue
+		}
+		p.unlinks = append(p.unlinks, iamBundleOp{
+			change: model.IamBundleChange{Resource: "group.workspace-role", Action: iamBundleActionDelete,
+				Key: group.Code + "/" + b.Workspace, Before: b.Role},
+			apply: func(repo *repository.IamBundleRepository) error {
+				return repo.DeleteGroupWorkspaceRole(group.Code, b.Workspace)
+			},
+		})
+	}
+}
+
+// sortGroupsParentFirst 번들 내 상위 그룹이 하위 그룹보다 먼저 오도록 정렬
+func sortGroupsParentFirst(groups []model.IamBundleGroup) []model.IamBundleGroup {
+	inBundle := make(map[string]bool, len(groups))
+	for _, g := range groups {
+		inBundle[g.Code] = true
+	}
+	placed := make(map[string]bool, len(groups))
+	out := make([]model.IamBundleGroup, 0, len(groups))
+	for len(out) < len(groups) {
+		progressed := false
+		for _, g := range groups {
+			if placed[g.Code] {
+				continue
+			}
+			if g.Parent == "" || !inBundle[g.Parent] || placed[g.Parent] {
+				out = append(out, g)
+				placed[g.Code] = true
+				progressed = true
+			}
+		}
+		if !progressed {
+			// 순환 참조: 남은 그룹은 원래 순서대로 (적용 시 상위 그룹 조회 오류로 드러남)
+			for _, g := range groups {
+				if !placed[g.Code] {
+					out = append(out, g)
+					placed[g.Code] = true
+				}
+			}
+		}
+	}
+	return out
+}
+
+// diffStringSets current 대비 desired에 추가/삭제할 값 (정렬됨)
+func diffStringSets(current, desired []string) (add, remove []string) {
+	have := make(map[string]bool, len(current))
+	for _, v := range current {
+		have[v] = true
+	}
+	want := make(map[string]bool, len(desired))
+	for _, v := range desired {
+		if v == "" || want[v] {
+			continue
+		}
+		want[v] = true
+		if !have[v] {
+			add = append(add, v)
+		}
+	}
+	for v := range have {
+		if !want[v] {
+			remove = append(remove, v)
+		}
+	}
+	sort.Strings(add)
+	sort.Strings(remove)
+	return add, remove
+}
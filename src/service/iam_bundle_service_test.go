@@ -0,0 +1,253 @@
+package service
+
+// iam_bundle_service_test.go
+// 선언적 IAM 번들 export/diff/apply 단위 테스트 (SQLite in-memory DB)
+
+import (
+	"context"
+	"testing"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// groupRecordingKeycloakService 그룹 realm role 추가/제거 호출을 기록하는 KeycloakService 스텁
+type groupRecordingKeycloakService struct {
+	mockKeycloakService
+	added   []string
+	removed []string
+}
+
+func (m *groupRecordingKeycloakService) AddRealmRoleToGroup(ctx context.Context, groupName, roleName string) error {
+	m.added = append(m.added, groupName+"/"+roleName)
+	return nil
+}
+
+func (m *groupRecordingKeycloakService) RemoveRealmRoleFromGroup(ctx context.Context, groupName, roleName string) error {
+	m.removed = append(m.removed, groupName+"/"+roleName)
+	return nil
+}
+
+func newIamBundleTestService(t *testing.T) (*IamBundleService, *groupRecordingKeycloakService, *gorm.DB) {
+	t.Helper()
+	db := setupGroupRoleTestDB(t)
+	require.NoError(t, db.AutoMigrate(
+		&model.Menu{},
+		&model.RoleMenuMapping{},
+		&model.MciamRoleMciamPermission{},
+		&model.CspRole{},
+		&model.RoleMasterCspRoleMapping{},
+	))
+	// MciamPermission 모델의 default:now()는 SQLite에서 지원되지 않으므로 직접 생성
+	require.NoError(t, db.Exec(`CREATE TABLE mcmp_mciam_permissions (
+		id varchar(255) PRIMARY KEY,
+		framework_id varchar(100) NOT NULL,
+		resource_type_id varchar(100) NOT NULL,
+		action varchar(100) NOT NULL,
+		name varchar(100) NOT NULL,
+		description varchar(1000),
+		created_at datetime,
+		updated_at datetime
+	)`).Error)
+
+	kc := &groupRecordingKeycloakService{}
+	svc := NewIamBundleService(db)
+	svc.kcService = kc
+	return svc, kc, db
+}
+
+func sampleIamBundle() *model.IamBundle {
+	return &model.IamBundle{
+		Kind:    iamBundleKind,
+		Version: iamBundleVersion,
+		Permissions: []model.IamBundlePermission{
+			{ID: "mc-iam-manager:workspace:read", FrameworkID: "mc-iam-manager", ResourceTypeID: "workspace", Action: "read", Name: "Read workspace"},
+			{ID: "mc-iam-manager:workspace:update", FrameworkID: "mc-iam-manager", ResourceTypeID: "workspace", Action: "update", Name: "Update workspace"},
+		},
+		Roles: []model.IamBundleRole{
+			{
+				Name:                 "operator",
+				Description:          "workspace operator",
+				RoleTypes:            []string{string(constants.RoleTypePlatform), string(constants.RoleTypeWorkspace)},
+				Menus:                []string{"workspaces"},
+				PlatformPermissions:  []string{"mc-iam-manager:workspace:read"},
+				WorkspacePermissions: []string{"mc-iam-manager:workspace:read", "mc-iam-manager:workspace:update"},
+				CspRoles:             []model.IamBundleCspRoleRef{{CspType: "aws", Name: "mciam-operator", AuthMethod: "OIDC"}},
+			},
+			{Name: "senior-operator", Parent: "operator", RoleTypes: []string{string(constants.RoleTypeWorkspace)}},
+		},
+		Groups: []model.IamBundleGroup{
+			{Code: "ops-seoul", Name: "Ops Seoul", Parent: "ops"},
+			{
+				Code:           "ops",
+				Name:           "Ops",
+				PlatformRoles:  []string{"operator"},
+				WorkspaceRoles: []model.IamBundleGroupWorkspaceRole{{Workspace: "ws-prod", Role: "operator"}},
+			},
+		},
+	}
+}
+
+func TestIamBundleApply_MergeCreatesConfiguration(t *testing.T) {
+	svc, kc, db := newIamBundleTestService(t)
+	createGRTestWorkspace(t, db, "ws-prod")
+	require.NoError(t, db.Create(&model.Menu{ID: "workspaces", DisplayName: "Workspaces", ResType: "menu", Priority: 1, MenuNumber: 1}).Error)
+	require.NoError(t, db.Create(&model.CspRole{Name: "mciam-operator", CspType: "aws"}).Error)
+
+	plan, err := svc.Apply(context.Background(), sampleIamBundle(), "")
+	require.NoError(t, err)
+	assert.Equal(t, IamBundleModeMerge, plan.Mode)
+	assert.True(t, plan.Applied)
+	assert.Empty(t, plan.Warnings)
+	assert.Equal(t, []string{"Ops/operator"}, kc.added)
+
+	exported, err := svc.Export()
+	require.NoError(t, err)
+	require.Len(t, exported.Permissions, 2)
+	require.Len(t, exported.Roles, 2)
+	operator := exported.Roles[0]
+	assert.Equal(t, "operator", operator.Name)
+	assert.Equal(t, []string{"workspaces"}, operator.Menus)
+	assert.Equal(t, []string{"mc-iam-manager:workspace:read"}, operator.PlatformPermissions)
+	assert.Len(t, operator.WorkspacePermissions, 2)
+	assert.Len(t, operator.CspRoles, 1)
+	assert.Equal(t, "operator", exported.Roles[1].Parent)
+
+	require.Len(t, exported.Groups, 2)
+	assert.Equal(t, "ops", exported.Groups[0].Code)
+	assert.Equal(t, []string{"operator"}, exported.Groups[0].PlatformRoles)
+	assert.Equal(t, []model.IamBundleGroupWorkspaceRole{{Workspace: "ws-prod", Role: "operator"}}, exported.Groups[0].WorkspaceRoles)
+	assert.Equal(t, "ops", exported.Groups[1].Parent)
+
+	// 같은 번들을 다시 적용하면 변경 사항이 없어야 한다
+	again, err := svc.Diff(exported, IamBundleModeReplace)
+	require.NoError(t, err)
+	assert.Empty(t, again.Changes)
+}
+
+func TestIamBundleDiff_DoesNotModifyDB(t *testing.T) {
+	svc, kc, db := newIamBundleTestService(t)
+
+	plan, err := svc.Diff(sampleIamBundle(), IamBundleModeMerge)
+	require.NoError(t, err)
+	assert.True(t, plan.DryRun)
+	assert.False(t, plan.Applied)
+	assert.NotEmpty(t, plan.Changes)
+	// 대상 환경에 없는 메뉴/CSP 역할/워크스페이스는 경고 후 건너뛴다
+	assert.Len(t, plan.Warnings, 3)
+
+	var roles int64
+	db.Model(&model.RoleMaster{}).Count(&roles)
+	assert.Zero(t, roles)
+	assert.Empty(t, kc.added)
+}
+
+func TestIamBundleApply_ReplaceRemovesExtraMappings(t *testing.T) {
+	svc, kc, db := newIamBundleTestService(t)
+	createGRTestWorkspace(t, db, "ws-prod")
+	createGRTestWorkspace(t, db, "ws-dev")
+	_, err := svc.Apply(context.Background(), sampleIamBundle(), IamBundleModeMerge)
+	require.NoError(t, err)
+
+	bundle := sampleIamBundle()
+	bundle.Permissions = bundle.Permissions[:1]
+	bundle.Roles[0].WorkspacePermissions = []string{"mc-iam-manager:workspace:read"}
+	bundle.Roles[0].RoleTypes = []string{string(constants.RoleTypeWorkspace)}
+	bundle.Roles[0].PlatformPermissions = nil
+	bundle.Groups[1].PlatformRoles = nil
+	bundle.Groups[1].WorkspaceRoles = []model.IamBundleGroupWorkspaceRole{{Workspace: "ws-dev", Role: "senior-operator"}}
+
+	// merge 모드는 삭제하지 않는다
+	merge, err := svc.Diff(bundle, IamBundleModeMerge)
+	require.NoError(t, err)
+	for _, c := range merge.Changes {
+		assert.NotEqual(t, iamBundleActionDelete, c.Action, c.Resource+" "+c.Key)
+	}
+
+	plan, err := svc.Apply(context.Background(), bundle, IamBundleModeReplace)
+	require.NoError(t, err)
+	assert.True(t, plan.Applied)
+	assert.Equal(t, []string{"Ops/operator"}, kc.removed)
+
+	exported, err := svc.Export()
+	require.NoError(t, err)
+	require.Len(t, exported.Permissions, 1)
+	assert.Equal(t, []string{string(constants.RoleTypeWorkspace)}, exported.Roles[0].RoleTypes)
+	assert.Empty(t, exported.Roles[0].PlatformPermissions)
+	assert.Equal(t, []string{"mc-iam-manager:workspace:read"}, exported.Roles[0].WorkspacePermissions)
+	assert.Empty(t, exported.Groups[0].PlatformRoles)
+	assert.Equal(t, []model.IamBundleGroupWorkspaceRole{{Workspace: "ws-dev", Role: "senior-operator"}}, exported.Groups[0].WorkspaceRoles)
+
+	var perms int64
+	db.Model(&model.MciamRoleMciamPermission{}).Where("permission_id = ?", "mc-iam-manager:workspace:update").Count(&perms)
+	assert.Zero(t, perms, "삭제된 권한의 역할 매핑도 삭제된다")
+}
+
+func TestIamBundle_InvalidBundle(t *testing.T) {
+	svc, _, _ := newIamBundleTestService(t)
+
+	cases := map[string]func(b *model.IamBundle){
+		"wrong kind":         func(b *model.IamBundle) { b.Kind = "role-bundle" },
+		"duplicate role":     func(b *model.IamBundle) { b.Roles = append(b.Roles, b.Roles[0]) },
+		"invalid role type":  func(b *model.IamBundle) { b.Roles[0].RoleTypes = []string{"tenant"} },
+		"unknown permission": func(b *model.IamBundle) { b.Roles[0].PlatformPermissions = []string{"x:y:z"} },
+		"unknown parent":     func(b *model.IamBundle) { b.Roles[1].Parent = "ghost" },
+		"self parent":        func(b *model.IamBundle) { b.Roles[0].Parent = "operator" },
+		"parent cycle":       func(b *model.IamBundle) { b.Roles[0].Parent = "senior-operator" },
+		"unknown group role": func(b *model.IamBundle) { b.Groups[1].PlatformRoles = []string{"ghost"} },
+		"unknown group":      func(b *model.IamBundle) { b.Groups[0].Parent = "ghost" },
+	}
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			b := sampleIamBundle()
+			mutate(b)
+			_, err := svc.Diff(b, IamBundleModeMerge)
+			assert.ErrorIs(t, err, ErrInvalidIamBundle)
+		})
+	}
+
+	_, err := svc.Diff(sampleIamBundle(), "overwrite")
+	assert.ErrorIs(t, err, ErrInvalidIamBundle)
+	_, err = ParseIamBundle([]byte("kind: [unterminated"))
+	assert.ErrorIs(t, err, ErrInvalidIamBundle)
+}
+
+// 기존 역할과 합친 역할 계층의 순환, 다른 회사 전용 상위 역할 거부
+func TestIamBundle_RejectsInvalidRoleHierarchy(t *testing.T) {
+	svc, _, db := newIamBundleTestService(t)
+	companyID := uint(7)
+	require.NoError(t, db.Create(&model.RoleMaster{Name: "tenant-admin", CompanyID: &companyID}).Error)
+	lead := &model.RoleMaster{Name: "lead"}
+	require.NoError(t, db.Create(lead).Error)
+	require.NoError(t, db.Create(&model.RoleMaster{Name: "member", ParentID: &lead.ID}).Error)
+
+	// 공용 역할은 회사 전용 역할을 상위 역할로 가질 수 없음
+	b := sampleIamBundle()
+	b.Roles[0].Parent = "tenant-admin"
+	_, err := svc.Diff(b, IamBundleModeMerge)
+	assert.ErrorIs(t, err, ErrInvalidIamBundle)
+	assert.ErrorContains(t, err, ErrRoleParentNotFound.Error())
+
+	// DB의 member → lead 관계와 합쳐 순환 (lead → member)
+	b = sampleIamBundle()
+	b.Roles = append(b.Roles, model.IamBundleRole{Name: "lead", Parent: "member", RoleTypes: []string{}})
+	_, err = svc.Diff(b, IamBundleModeMerge)
+	assert.ErrorIs(t, err, ErrInvalidIamBundle)
+	assert.ErrorContains(t, err, ErrRoleHierarchyCycle.Error())
+
+	// replace 모드에서 member의 상위 역할을 해제하면 순환이 아님
+	b.Roles = append(b.Roles, model.IamBundleRole{Name: "member", RoleTypes: []string{}})
+	_, err = svc.Diff(b, IamBundleModeReplace)
+	assert.NoError(t, err)
+}
+
+func TestParseIamBundle_AcceptsJSONAndYAML(t *testing.T) {
+	fromJSON, err := ParseIamBundle([]byte(`{"kind":"iam-bundle","version":"v1","roles":[{"name":"viewer","roleTypes":["platform"]}]}`))
+	require.NoError(t, err)
+	fromYAML, err := ParseIamBundle([]byte("kind: iam-bundle\nversion: v1\nroles:\n  - name: viewer\n    roleTypes: [platform]\n"))
+	require.NoError(t, err)
+	assert.Equal(t, fromJSON, fromYAML)
+}
@@ -29,6 +29,8 @@ type KeycloakService interface {
 	GetUser(ctx context.Context, kcId string) (*gocloak.User, error)
 	GetUserByUsername(ctx context.Context, username string) (*gocloak.User, error)
 	GetUsers(ctx context.Context, enabled *bool) ([]*gocloak.User, error)
+	// GetUsersPage 사용자 목록 페이지 조회 (first부터 최대 max명, 전체 순회용)
+	GetUsersPage(ctx context.Context, first, max int) ([]*gocloak.User, error)
 	CreateUser(ctx context.Context, user *model.User) (string, error)
 	UpdateUser(ctx context.Context, user *model.User) error
 	DeleteUser(ctx context.Context, kcId string) error
@@ -78,14 +80,17 @@ type KeycloakService interface {
 	RemoveRealmRoleFromUser(ctx context.Context, kcUserId, roleName string) error
 	// IsRealmRoleAssignedToUser checks if a realm role is already assigned to a user
 	IsRealmRoleAssignedToUser(ctx context.Context, kcUserId, roleName string) (bool, error)
-	// IssueWorkspaceTicket 워크스페이스 티켓을 발행합니다.
-	IssueWorkspaceTicket(ctx context.Context, kcUserId string, workspaceID uint) (string, map[string]interface{}, error)
+	// GetUserRealmRoles 사용자에게 직접 할당된 realm role 이름 목록 (그룹으로 상속된 역할 제외)
+	GetUserRealmRoles(ctx context.Context, kcUserId string) ([]string, error)
 	// 기본 Role 정의
 	SetupPredefinedRoles(ctx context.Context, accessToken string) error
 	// GetClientCredentialsToken 클라이언트 자격 증명으로 토큰을 발급받습니다.
 	GetClientCredentialsToken(ctx context.Context) (*gocloak.JWT, error)
 	// CreatePendingUser creates a user in pending state (enabled=false) with password
-	CreatePendingUser(ctx context.Context, req *model.SignupRequest) (string, error)
+	// requireEmailVerification이면 VERIFY_EMAIL 필수 동작과 함께 인증 메일을 받을 수 있도록 활성 상태로 생성
+	CreatePendingUser(ctx context.Context, req *model.SignupRequest, requireEmailVerification bool) (string, error)
+	// SendVerifyEmail 사용자에게 Keycloak 이메일 인증(VERIFY_EMAIL) 메일 발송
+	SendVerifyEmail(ctx context.Context, kcUserID string) error
 	// ResetPassword resets a user's password
 	ResetPassword(ctx context.Context, kcUserID, newPassword string) error
 	// AddRealmRoleToGroup adds a realm role to a Keycloak group (creates group if not exists)
@@ -96,9 +101,23 @@ type KeycloakService interface {
 	DeleteGroup(ctx context.Context, groupName string) error
 	// CheckSAMLClientConfig Keycloak SAML 클라이언트 존재 및 protocol mapper 구성 확인
 	CheckSAMLClientConfig(ctx context.Context, clientID string) (string, error)
+	// GetUserSessions 사용자의 활성 Keycloak 세션 목록
+	GetUserSessions(ctx context.Context, kcUserID string) ([]*gocloak.UserSessionRepresentation, error)
+	// LogoutUserSession 세션 하나 종료
+	LogoutUserSession(ctx context.Context, sessionID string) error
+	// LogoutAllUserSessions 사용자의 모든 세션 종료
+	LogoutAllUserSessions(ctx context.Context, kcUserID string) error
+	// CreateServiceAccountClient 서비스 계정용 client credentials 클라이언트 생성
+	CreateServiceAccountClient(ctx context.Context, clientID, description string) (*model.KeycloakServiceAccountClient, error)
+	// RegenerateServiceAccountClientSecret 클라이언트 secret 재발급
+	RegenerateServiceAccountClientSecret(ctx context.Context, idOfClient string) (string, error)
+	// SetClientEnabled 클라이언트 활성/비활성 (비활성 클라이언트는 토큰 발급 불가)
+	SetClientEnabled(ctx context.Context, idOfClient string, enabled bool) error
+	// DeleteServiceAccountClient 서비스 계정 클라이언트 삭제
+	DeleteServiceAccountClient(ctx context.Context, idOfClient string) error
 }
 
-// keycloakService is now stateless, methods directly use config.KC
+// keycloakService is now stateless, methods directly use config.KeycloakFor(ctx)
 type keycloakService struct {
 }
 
@@ -111,7 +130,7 @@ func NewKeycloakService() KeycloakService {
 func (s *keycloakService) KeycloakAdminLogin(ctx context.Context) (*gocloak.JWT, error) {
 	// 1. Admin 로그인
 	log.Printf("[DEBUG] Attempting to login as admin")
-	adminToken, err := config.KC.LoginAdmin(ctx)
+	adminToken, err := config.KeycloakFor(ctx).LoginAdmin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("admin login failed: %w", err)
 	}
@@ -121,14 +140,14 @@ func (s *keycloakService) KeycloakAdminLogin(ctx context.Context) (*gocloak.JWT,
 
 // GetUser retrieves a user from Keycloak by their Keycloak ID.
 func (s *keycloakService) GetUser(ctx context.Context, kcId string) (*gocloak.User, error) {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return nil, fmt.Errorf("keycloak configuration not initialized")
 	}
-	token, err := config.KC.GetAdminToken(ctx)
+	token, err := config.KeycloakFor(ctx).GetAdminToken(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get admin token: %w", err)
 	}
-	user, err := config.KC.Client.GetUserByID(ctx, token.AccessToken, config.KC.Realm, kcId)
+	user, err := config.KeycloakFor(ctx).Client.GetUserByID(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, kcId)
 	if err != nil {
 		if strings.Contains(err.Error(), "404") {
 			return nil, fmt.Errorf("user not found in keycloak (kcId: %s): %w", kcId, repository.ErrUserNotFound)
@@ -140,14 +159,14 @@ func (s *keycloakService) GetUser(ctx context.Context, kcId string) (*gocloak.Us
 
 // GetUserByUsername retrieves a user from Keycloak by username.
 func (s *keycloakService) GetUserByUsername(ctx context.Context, username string) (*gocloak.User, error) {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return nil, fmt.Errorf("keycloak configuration not initialized")
 	}
-	token, err := config.KC.GetAdminToken(ctx)
+	token, err := config.KeycloakFor(ctx).GetAdminToken(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get admin token: %v", err)
 	}
-	users, err := config.KC.Client.GetUsers(ctx, token.AccessToken, config.KC.Realm, gocloak.GetUsersParams{
+	users, err := config.KeycloakFor(ctx).Client.GetUsers(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, gocloak.GetUsersParams{
 		Username: gocloak.StringP(username),
 		Exact:    gocloak.BoolP(true),
 	})
@@ -163,20 +182,41 @@ func (s *keycloakService) GetUserByUsername(ctx context.Context, username string
 	return users[0], nil
 }
 
+// GetUsersPage 사용자 목록 페이지 조회 (first부터 최대 max명, 전체 순회용)
+// GetUsers는 Keycloak 기본 페이지 크기(100명)까지만 반환하므로 전체 사용자가 필요하면 빈 페이지가 나올 때까지 호출한다.
+func (s *keycloakService) GetUsersPage(ctx context.Context, first, max int) ([]*gocloak.User, error) {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
+		return nil, fmt.Errorf("keycloak configuration not initialized")
+	}
+	token, err := config.KeycloakFor(ctx).LoginAdmin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin token: %w", err)
+	}
+
+	kcUsers, err := config.KeycloakFor(ctx).Client.GetUsers(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, gocloak.GetUsersParams{
+		First: gocloak.IntP(first),
+		Max:   gocloak.IntP(max),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users from keycloak (first=%d, max=%d): %w", first, max, err)
+	}
+	return kcUsers, nil
+}
+
 // GetUsers retrieves users from Keycloak, optionally filtered by enabled status.
 // gocloak.GetUsersParams.Enabled uses json:"omitempty" which drops false values,
 // so we pass enabled as a manual query param to avoid the omitempty bug.
 func (s *keycloakService) GetUsers(ctx context.Context, enabled *bool) ([]*gocloak.User, error) {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return nil, fmt.Errorf("keycloak configuration not initialized")
 	}
-	token, err := config.KC.LoginAdmin(ctx)
+	token, err := config.KeycloakFor(ctx).LoginAdmin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get admin token: %w", err)
 	}
 
 	if enabled == nil {
-		kcUsers, err := config.KC.Client.GetUsers(ctx, token.AccessToken, config.KC.Realm, gocloak.GetUsersParams{})
+		kcUsers, err := config.KeycloakFor(ctx).Client.GetUsers(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, gocloak.GetUsersParams{})
 		if err != nil {
 			return nil, fmt.Errorf("failed to get users from keycloak: %w", err)
 		}
@@ -186,8 +226,8 @@ func (s *keycloakService) GetUsers(ctx context.Context, enabled *bool) ([]*goclo
 	// enabled 값이 false일 때 omitempty로 쿼리 파라미터가 누락되는 gocloak 버그 우회:
 	// Keycloak Admin REST API를 직접 호출하여 ?enabled=false 명시 전송
 	var result []*gocloak.User
-	url := fmt.Sprintf("%s/admin/realms/%s/users?enabled=%v", config.KC.Host, config.KC.Realm, *enabled)
-	resp, err := config.KC.Client.GetRequestWithBearerAuth(ctx, token.AccessToken).
+	url := fmt.Sprintf("%s/admin/realms/%s/users?enabled=%v", config.KeycloakFor(ctx).Host, config.KeycloakFor(ctx).Realm, *enabled)
+	resp, err := config.KeycloakFor(ctx).Client.GetRequestWithBearerAuth(ctx, token.AccessToken).
 		SetResult(&result).
 		Get(url)
 	if err != nil {
@@ -201,11 +241,11 @@ func (s *keycloakService) GetUsers(ctx context.Context, enabled *bool) ([]*goclo
 
 // CreateUser creates a user in Keycloak.
 func (s *keycloakService) CreateUser(ctx context.Context, user *model.User) (string, error) {
-	// Directly use config.KC
-	if config.KC == nil || config.KC.Client == nil {
+	// Directly use config.KeycloakFor(ctx)
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return "", fmt.Errorf("keycloak configuration not initialized")
 	}
-	token, err := config.KC.LoginAdmin(ctx) // Use admin token
+	token, err := config.KeycloakFor(ctx).LoginAdmin(ctx) // Use admin token
 	if err != nil {
 		return "", fmt.Errorf("failed to get admin token: %w", err)
 	}
@@ -224,7 +264,7 @@ func (s *keycloakService) CreateUser(ctx context.Context, user *model.User) (str
 	// 	}
 	// }
 
-	kcId, err := config.KC.Client.CreateUser(ctx, token.AccessToken, config.KC.Realm, keycloakUser)
+	kcId, err := config.KeycloakFor(ctx).Client.CreateUser(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, keycloakUser)
 	if err != nil {
 		// Check for conflict (user exists)
 		if strings.Contains(err.Error(), "409") {
@@ -245,12 +285,14 @@ func (s *keycloakService) CreateUser(ctx context.Context, user *model.User) (str
 }
 
 // CreatePendingUser creates a user in pending state (enabled=false) with password
-func (s *keycloakService) CreatePendingUser(ctx context.Context, req *model.SignupRequest) (string, error) {
-	if config.KC == nil || config.KC.Client == nil {
+// 이메일 인증이 필요하면 Keycloak이 인증 메일을 보낼 수 있도록 활성 상태 + VERIFY_EMAIL 필수 동작으로 생성한다.
+// (필수 동작이 남아 있으면 토큰이 발급되지 않으며, 인증 완료 후 SignupService가 승인 전까지 다시 비활성화한다)
+func (s *keycloakService) CreatePendingUser(ctx context.Context, req *model.SignupRequest, requireEmailVerification bool) (string, error) {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return "", fmt.Errorf("keycloak configuration not initialized")
 	}
 
-	token, err := config.KC.LoginAdmin(ctx)
+	token, err := config.KeycloakFor(ctx).LoginAdmin(ctx)
 	if err != nil {
 		return "", fmt.Errorf("failed to get admin token: %w", err)
 	}
@@ -263,14 +305,17 @@ func (s *keycloakService) CreatePendingUser(ctx context.Context, req *model.Sign
 		Email:         &req.Email,
 		FirstName:     &req.FirstName,
 		LastName:      &req.LastName,
-		Enabled:       gocloak.BoolP(false),       // 승인 대기 상태
+		Enabled:       gocloak.BoolP(requireEmailVerification), // 승인 대기 상태 (이메일 인증 시에만 임시 활성)
 		EmailVerified: gocloak.BoolP(false),       // 이메일 미확인
 		Attributes: &map[string][]string{
 			"organization": {req.Organization},    // 조직 정보 저장
 		},
 	}
+	if requireEmailVerification {
+		keycloakUser.RequiredActions = &[]string{"VERIFY_EMAIL"}
+	}
 
-	kcId, err := config.KC.Client.CreateUser(ctx, token.AccessToken, config.KC.Realm, keycloakUser)
+	kcId, err := config.KeycloakFor(ctx).Client.CreateUser(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, keycloakUser)
 	if err != nil {
 		if strings.Contains(err.Error(), "409") {
 			return "", fmt.Errorf("Email already in use")
@@ -279,30 +324,53 @@ func (s *keycloakService) CreatePendingUser(ctx context.Context, req *model.Sign
 	}
 
 	// 비밀번호 설정
-	err = config.KC.Client.SetPassword(ctx, token.AccessToken, kcId, config.KC.Realm, req.Password, false)
+	err = config.KeycloakFor(ctx).Client.SetPassword(ctx, token.AccessToken, kcId, config.KeycloakFor(ctx).Realm, req.Password, false)
 	if err != nil {
 		// 사용자 생성은 성공했으나 비밀번호 설정 실패 - 사용자 삭제
-		config.KC.Client.DeleteUser(ctx, token.AccessToken, config.KC.Realm, kcId)
+		config.KeycloakFor(ctx).Client.DeleteUser(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, kcId)
 		return "", fmt.Errorf("failed to set password: %w", err)
 	}
 
 	return kcId, nil
 }
 
+// SendVerifyEmail 사용자에게 Keycloak 이메일 인증(VERIFY_EMAIL) 메일 발송
+func (s *keycloakService) SendVerifyEmail(ctx context.Context, kcUserID string) error {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
+		return fmt.Errorf("keycloak configuration not initialized")
+	}
+
+	token, err := config.KeycloakFor(ctx).LoginAdmin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get admin token: %w", err)
+	}
+
+	clientID := config.KeycloakFor(ctx).OIDCClientName
+	err = config.KeycloakFor(ctx).Client.ExecuteActionsEmail(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, gocloak.ExecuteActionsEmail{
+		UserID:   &kcUserID,
+		ClientID: &clientID,
+		Actions:  &[]string{"VERIFY_EMAIL"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send verify email to user %s: %w", kcUserID, err)
+	}
+	return nil
+}
+
 // ResetPassword resets a user's password
 func (s *keycloakService) ResetPassword(ctx context.Context, kcUserID, newPassword string) error {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return fmt.Errorf("keycloak configuration not initialized")
 	}
 
 	// Admin 토큰 획득
-	adminToken, err := config.KC.LoginAdmin(ctx)
+	adminToken, err := config.KeycloakFor(ctx).LoginAdmin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get admin token: %w", err)
 	}
 
 	// 사용자 존재 확인
-	existingUser, err := config.KC.Client.GetUserByID(ctx, adminToken.AccessToken, config.KC.Realm, kcUserID)
+	existingUser, err := config.KeycloakFor(ctx).Client.GetUserByID(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, kcUserID)
 	if err != nil {
 		return fmt.Errorf("failed to get user: %w", err)
 	}
@@ -311,7 +379,7 @@ func (s *keycloakService) ResetPassword(ctx context.Context, kcUserID, newPasswo
 	}
 
 	// 비밀번호 재설정 (temporary=false: 영구 비밀번호)
-	err = config.KC.Client.SetPassword(ctx, adminToken.AccessToken, kcUserID, config.KC.Realm, newPassword, false)
+	err = config.KeycloakFor(ctx).Client.SetPassword(ctx, adminToken.AccessToken, kcUserID, config.KeycloakFor(ctx).Realm, newPassword, false)
 	if err != nil {
 		return fmt.Errorf("failed to reset password: %w", err)
 	}
@@ -322,14 +390,14 @@ func (s *keycloakService) ResetPassword(ctx context.Context, kcUserID, newPasswo
 
 // UpdateUser updates a user in Keycloak.
 func (s *keycloakService) UpdateUser(ctx context.Context, user *model.User) error {
-	// Directly use config.KC
-	if config.KC == nil || config.KC.Client == nil {
+	// Directly use config.KeycloakFor(ctx)
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return fmt.Errorf("keycloak configuration not initialized")
 	}
 	if user.KcId == "" {
 		return fmt.Errorf("cannot update keycloak user without KcId")
 	}
-	token, err := config.KC.LoginAdmin(ctx)
+	token, err := config.KeycloakFor(ctx).LoginAdmin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get admin token for keycloak update: %w", err)
 	}
@@ -344,7 +412,7 @@ func (s *keycloakService) UpdateUser(ctx context.Context, user *model.User) erro
 		Enabled:   &user.Enabled,
 		// Attributes: &user.Attributes, // If attributes are managed
 	}
-	err = config.KC.Client.UpdateUser(ctx, token.AccessToken, config.KC.Realm, keycloakUser)
+	err = config.KeycloakFor(ctx).Client.UpdateUser(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, keycloakUser)
 	if err != nil {
 		return fmt.Errorf("failed to update user in keycloak (kcId: %s): %w", user.KcId, err)
 	}
@@ -353,7 +421,7 @@ func (s *keycloakService) UpdateUser(ctx context.Context, user *model.User) erro
 
 // GetRequestingPartyToken requests an RPT token from Keycloak using provided options.
 func (s *keycloakService) GetRequestingPartyToken(ctx context.Context, accessToken string, options gocloak.RequestingPartyTokenOptions) (*gocloak.JWT, error) {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return nil, fmt.Errorf("keycloak configuration not initialized")
 	}
 
@@ -368,11 +436,11 @@ func (s *keycloakService) GetRequestingPartyToken(ctx context.Context, accessTok
 
 	// The Audience for RPT is often the resource server (client acting as resource server)
 	if options.Audience == nil {
-		options.Audience = &config.KC.ClientName
+		options.Audience = &config.KeycloakFor(ctx).ClientName
 	}
 
 	// Call the gocloak function to get the RPT
-	rpt, err := config.KC.Client.GetRequestingPartyToken(ctx, accessToken, config.KC.Realm, options)
+	rpt, err := config.KeycloakFor(ctx).Client.GetRequestingPartyToken(ctx, accessToken, config.KeycloakFor(ctx).Realm, options)
 	if err != nil {
 		// Handle specific errors, e.g., 403 Forbidden if permissions are denied
 		return nil, fmt.Errorf("failed to get requesting party token: %w", err)
@@ -387,11 +455,11 @@ func (s *keycloakService) GetRequestingPartyToken(ctx context.Context, accessTok
 
 // ValidateTokenAndGetClaims validates the token signature/expiry and returns claims.
 func (s *keycloakService) ValidateTokenAndGetClaims(ctx context.Context, token string) (*jwt.MapClaims, error) { // Changed return type
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return nil, fmt.Errorf("keycloak configuration not initialized")
 	}
 	// DecodeAccessToken performs local validation (signature, expiry) based on realm keys
-	_, claims, err := config.KC.Client.DecodeAccessToken(ctx, token, config.KC.Realm)
+	_, claims, err := config.KeycloakFor(ctx).Client.DecodeAccessToken(ctx, token, config.KeycloakFor(ctx).Realm)
 	if err != nil {
 		return nil, fmt.Errorf("token validation/decoding failed: %w", err)
 	}
@@ -405,17 +473,17 @@ func (s *keycloakService) ValidateTokenAndGetClaims(ctx context.Context, token s
 
 // DeleteUser deletes a user from Keycloak.
 func (s *keycloakService) DeleteUser(ctx context.Context, kcId string) error {
-	// Directly use config.KC
-	if config.KC == nil || config.KC.Client == nil {
+	// Directly use config.KeycloakFor(ctx)
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return fmt.Errorf("keycloak configuration not initialized")
 	}
-	token, err := config.KC.LoginAdmin(ctx)
+	token, err := config.KeycloakFor(ctx).LoginAdmin(ctx)
 	if err != nil {
 		// Log warning but maybe don't fail the whole operation? Depends on desired behavior.
 		log.Printf("Warning: failed to get admin token to delete user %s from keycloak: %v.", kcId, err)
 		return fmt.Errorf("failed to get admin token for keycloak delete: %w", err)
 	}
-	err = config.KC.Client.DeleteUser(ctx, token.AccessToken, config.KC.Realm, kcId)
+	err = config.KeycloakFor(ctx).Client.DeleteUser(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, kcId)
 	if err != nil {
 		if strings.Contains(err.Error(), "404") {
 			log.Printf("User %s not found in Keycloak for deletion (already deleted?).", kcId)
@@ -430,16 +498,16 @@ func (s *keycloakService) DeleteUser(ctx context.Context, kcId string) error {
 
 // EnableUser enables a user in Keycloak.
 func (s *keycloakService) EnableUser(ctx context.Context, kcUserID string) error {
-	// Directly use config.KC
-	if config.KC == nil || config.KC.Client == nil {
+	// Directly use config.KeycloakFor(ctx)
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return fmt.Errorf("keycloak configuration not initialized")
 	}
-	adminToken, err := config.KC.LoginAdmin(ctx)
+	adminToken, err := config.KeycloakFor(ctx).LoginAdmin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get admin token to enable user: %w", err)
 	}
 	// Get user first to ensure they exist
-	user, err := config.KC.Client.GetUserByID(ctx, adminToken.AccessToken, config.KC.Realm, kcUserID)
+	user, err := config.KeycloakFor(ctx).Client.GetUserByID(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, kcUserID)
 	if err != nil {
 		return fmt.Errorf("failed to get user %s from keycloak before enabling: %w", kcUserID, err)
 	}
@@ -451,7 +519,7 @@ func (s *keycloakService) EnableUser(ctx context.Context, kcUserID string) error
 		ID:      &kcUserID,
 		Enabled: gocloak.BoolP(true),
 	}
-	err = config.KC.Client.UpdateUser(ctx, adminToken.AccessToken, config.KC.Realm, userToUpdate)
+	err = config.KeycloakFor(ctx).Client.UpdateUser(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, userToUpdate)
 	if err != nil {
 		return fmt.Errorf("failed to enable user %s in keycloak: %w", kcUserID, err)
 	}
@@ -461,14 +529,14 @@ func (s *keycloakService) EnableUser(ctx context.Context, kcUserID string) error
 
 // DisableUser disables a user in Keycloak.
 func (s *keycloakService) DisableUser(ctx context.Context, kcUserID string) error {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return fmt.Errorf("keycloak configuration not initialized")
 	}
-	adminToken, err := config.KC.LoginAdmin(ctx)
+	adminToken, err := config.KeycloakFor(ctx).LoginAdmin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get admin token to disable user: %w", err)
 	}
-	user, err := config.KC.Client.GetUserByID(ctx, adminToken.AccessToken, config.KC.Realm, kcUserID)
+	user, err := config.KeycloakFor(ctx).Client.GetUserByID(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, kcUserID)
 	if err != nil {
 		return fmt.Errorf("failed to get user %s from keycloak before disabling: %w", kcUserID, err)
 	}
@@ -479,7 +547,7 @@ func (s *keycloakService) DisableUser(ctx context.Context, kcUserID string) erro
 		ID:      &kcUserID,
 		Enabled: gocloak.BoolP(false),
 	}
-	err = config.KC.Client.UpdateUser(ctx, adminToken.AccessToken, config.KC.Realm, userToUpdate)
+	err = config.KeycloakFor(ctx).Client.UpdateUser(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, userToUpdate)
 	if err != nil {
 		return fmt.Errorf("failed to disable user %s in keycloak: %w", kcUserID, err)
 	}
@@ -489,11 +557,11 @@ func (s *keycloakService) DisableUser(ctx context.Context, kcUserID string) erro
 
 // CheckAdminLogin checks if admin login to Keycloak is successful.
 func (s *keycloakService) CheckAdminLogin(ctx context.Context) (bool, error) {
-	// Directly use config.KC
-	if config.KC == nil {
+	// Directly use config.KeycloakFor(ctx)
+	if config.KeycloakFor(ctx) == nil {
 		return false, fmt.Errorf("keycloak configuration not initialized")
 	}
-	_, err := config.KC.LoginAdmin(ctx)
+	_, err := config.KeycloakFor(ctx).LoginAdmin(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -502,13 +570,13 @@ func (s *keycloakService) CheckAdminLogin(ctx context.Context) (bool, error) {
 
 // CheckRealm checks if the configured realm exists. Requires admin token.
 func (s *keycloakService) CheckRealm(ctx context.Context) (bool, error) {
-	// Directly use config.KC
-	if config.KC == nil || config.KC.Client == nil {
+	// Directly use config.KeycloakFor(ctx)
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		log.Printf("[DEBUG] Keycloak configuration not initialized")
 		return false, fmt.Errorf("keycloak configuration not initialized")
 	}
 	log.Printf("[DEBUG] Attempting to login as admin to check realm")
-	token, err := config.KC.LoginAdmin(ctx)
+	token, err := config.KeycloakFor(ctx).LoginAdmin(ctx)
 	if err != nil {
 		log.Printf("[DEBUG] Admin login failed: %v", err)
 		return false, fmt.Errorf("admin login failed, cannot check realm: %w", err)
@@ -517,16 +585,16 @@ func (s *keycloakService) CheckRealm(ctx context.Context) (bool, error) {
 
 	// Check client permissions
 	log.Printf("[DEBUG] Checking client permissions for realm management")
-	clients, err := config.KC.Client.GetClients(ctx, token.AccessToken, config.KC.Realm, gocloak.GetClientsParams{
-		ClientID: &config.KC.ClientName, // 클라이언트 이름으로 조회
+	clients, err := config.KeycloakFor(ctx).Client.GetClients(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, gocloak.GetClientsParams{
+		ClientID: &config.KeycloakFor(ctx).ClientName, // 클라이언트 이름으로 조회
 	})
 	if err != nil {
 		log.Printf("[DEBUG] Failed to get client info: %v", err)
 		return false, fmt.Errorf("failed to get client info: %w", err)
 	}
 	if len(clients) == 0 {
-		log.Printf("[DEBUG] Client '%s' not found", config.KC.ClientName)
-		return false, fmt.Errorf("client '%s' not found", config.KC.ClientName)
+		log.Printf("[DEBUG] Client '%s' not found", config.KeycloakFor(ctx).ClientName)
+		return false, fmt.Errorf("client '%s' not found", config.KeycloakFor(ctx).ClientName)
 	}
 
 	// Log required permissions
@@ -539,7 +607,7 @@ func (s *keycloakService) CheckRealm(ctx context.Context) (bool, error) {
 
 	// Get all realms first
 	log.Printf("[DEBUG] Fetching all realms from Keycloak")
-	realms, err := config.KC.Client.GetRealms(ctx, token.AccessToken)
+	realms, err := config.KeycloakFor(ctx).Client.GetRealms(ctx, token.AccessToken)
 	if err != nil {
 		log.Printf("[DEBUG] Failed to get realms list: %v", err)
 		log.Printf("[DEBUG] This might be due to missing realm-management permissions")
@@ -557,28 +625,28 @@ func (s *keycloakService) CheckRealm(ctx context.Context) (bool, error) {
 	}
 
 	// Check if our realm exists
-	log.Printf("[DEBUG] Checking if realm '%s' exists", config.KC.Realm)
-	_, err = config.KC.Client.GetRealm(ctx, token.AccessToken, config.KC.Realm)
+	log.Printf("[DEBUG] Checking if realm '%s' exists", config.KeycloakFor(ctx).Realm)
+	_, err = config.KeycloakFor(ctx).Client.GetRealm(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm)
 	if err != nil {
-		log.Printf("[DEBUG] Failed to get realm '%s': %v", config.KC.Realm, err)
+		log.Printf("[DEBUG] Failed to get realm '%s': %v", config.KeycloakFor(ctx).Realm, err)
 		log.Printf("[DEBUG] This might be due to missing realm-management permissions")
-		return false, fmt.Errorf("failed to get realm '%s': %w", config.KC.Realm, err)
+		return false, fmt.Errorf("failed to get realm '%s': %w", config.KeycloakFor(ctx).Realm, err)
 	}
-	log.Printf("[DEBUG] Realm '%s' exists and is accessible", config.KC.Realm)
+	log.Printf("[DEBUG] Realm '%s' exists and is accessible", config.KeycloakFor(ctx).Realm)
 	return true, nil
 }
 
 func (s *keycloakService) CreateRealm(ctx context.Context, accessToken string) (bool, error) {
 	lifespanSec := config.AccessTokenLifespanSec()
 	newRealm := gocloak.RealmRepresentation{
-		Realm:               gocloak.StringP(config.KC.Realm),
+		Realm:               gocloak.StringP(config.KeycloakFor(ctx).Realm),
 		Enabled:             gocloak.BoolP(true),
-		DisplayName:         gocloak.StringP(config.KC.Realm),
+		DisplayName:         gocloak.StringP(config.KeycloakFor(ctx).Realm),
 		AccessTokenLifespan: gocloak.IntP(lifespanSec),
 	}
-	realmInfo, err := config.KC.Client.CreateRealm(ctx, accessToken, newRealm)
+	realmInfo, err := config.KeycloakFor(ctx).Client.CreateRealm(ctx, accessToken, newRealm)
 	if err != nil {
-		return false, fmt.Errorf("failed to create realm '%s': %w", config.KC.Realm, err)
+		return false, fmt.Errorf("failed to create realm '%s': %w", config.KeycloakFor(ctx).Realm, err)
 	}
 	log.Printf("[DEBUG] Realm '%s' created successfully", realmInfo)
 	return true, nil
@@ -586,18 +654,18 @@ func (s *keycloakService) CreateRealm(ctx context.Context, accessToken string) (
 
 func (s *keycloakService) ensureAccessTokenLifespan(ctx context.Context, accessToken string) error {
 	lifespanSec := config.AccessTokenLifespanSec()
-	realm, err := config.KC.Client.GetRealm(ctx, accessToken, config.KC.Realm)
+	realm, err := config.KeycloakFor(ctx).Client.GetRealm(ctx, accessToken, config.KeycloakFor(ctx).Realm)
 	if err != nil {
-		return fmt.Errorf("failed to get realm '%s': %w", config.KC.Realm, err)
+		return fmt.Errorf("failed to get realm '%s': %w", config.KeycloakFor(ctx).Realm, err)
 	}
 	if realm.AccessTokenLifespan != nil && *realm.AccessTokenLifespan == lifespanSec {
 		return nil
 	}
 	realm.AccessTokenLifespan = gocloak.IntP(lifespanSec)
-	if err := config.KC.Client.UpdateRealm(ctx, accessToken, *realm); err != nil {
-		return fmt.Errorf("failed to update access token lifespan for realm '%s': %w", config.KC.Realm, err)
+	if err := config.KeycloakFor(ctx).Client.UpdateRealm(ctx, accessToken, *realm); err != nil {
+		return fmt.Errorf("failed to update access token lifespan for realm '%s': %w", config.KeycloakFor(ctx).Realm, err)
 	}
-	log.Printf("[INFO] Keycloak realm '%s' access token lifespan set to %ds", config.KC.Realm, lifespanSec)
+	log.Printf("[INFO] Keycloak realm '%s' access token lifespan set to %ds", config.KeycloakFor(ctx).Realm, lifespanSec)
 	return nil
 }
 
@@ -605,12 +673,12 @@ func (s *keycloakService) ensureAccessTokenLifespan(ctx context.Context, accessT
 func (s *keycloakService) ExistRealm(ctx context.Context, accessToken string) (bool, error) {
 
 	// Check if our realm exists
-	log.Printf("[DEBUG] Checking if realm '%s' exists", config.KC.Realm)
-	realmInfo, err := config.KC.Client.GetRealm(ctx, accessToken, config.KC.Realm)
+	log.Printf("[DEBUG] Checking if realm '%s' exists", config.KeycloakFor(ctx).Realm)
+	realmInfo, err := config.KeycloakFor(ctx).Client.GetRealm(ctx, accessToken, config.KeycloakFor(ctx).Realm)
 	if err != nil {
-		log.Printf("[DEBUG] Failed to get realm '%s': %v", config.KC.Realm, err)
+		log.Printf("[DEBUG] Failed to get realm '%s': %v", config.KeycloakFor(ctx).Realm, err)
 		log.Printf("[DEBUG] This might be due to missing realm-management permissions")
-		return false, fmt.Errorf("failed to get realm '%s': %w", config.KC.Realm, err)
+		return false, fmt.Errorf("failed to get realm '%s': %w", config.KeycloakFor(ctx).Realm, err)
 	}
 	log.Printf("[DEBUG] Realm '%s' exists and is accessible", realmInfo)
 	return true, nil
@@ -618,20 +686,20 @@ func (s *keycloakService) ExistRealm(ctx context.Context, accessToken string) (b
 
 // CheckClient checks if the configured client ID exists within the realm. Requires admin token.
 func (s *keycloakService) CheckClient(ctx context.Context) (bool, error) {
-	// Directly use config.KC
-	if config.KC == nil || config.KC.Client == nil {
+	// Directly use config.KeycloakFor(ctx)
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return false, fmt.Errorf("keycloak configuration not initialized")
 	}
-	token, err := config.KC.LoginAdmin(ctx)
+	token, err := config.KeycloakFor(ctx).LoginAdmin(ctx)
 	if err != nil {
 		return false, fmt.Errorf("admin login failed, cannot check client: %w", err)
 	}
-	clients, err := config.KC.Client.GetClients(ctx, token.AccessToken, config.KC.Realm, gocloak.GetClientsParams{ClientID: &config.KC.ClientName})
+	clients, err := config.KeycloakFor(ctx).Client.GetClients(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, gocloak.GetClientsParams{ClientID: &config.KeycloakFor(ctx).ClientName})
 	if err != nil {
-		return false, fmt.Errorf("failed to get client '%s': %w", config.KC.ClientName, err)
+		return false, fmt.Errorf("failed to get client '%s': %w", config.KeycloakFor(ctx).ClientName, err)
 	}
 	if len(clients) == 0 {
-		return false, fmt.Errorf("client '%s' not found", config.KC.ClientName)
+		return false, fmt.Errorf("client '%s' not found", config.KeycloakFor(ctx).ClientName)
 	}
 	return true, nil
 }
@@ -639,28 +707,28 @@ func (s *keycloakService) CheckClient(ctx context.Context) (bool, error) {
 // CheckClient checks if the configured client ID exists within the realm. Requires admin token.
 func (s *keycloakService) ExistClient(ctx context.Context, accessToken string) (bool, error) {
 
-	clients, err := config.KC.Client.GetClients(ctx, accessToken, config.KC.Realm, gocloak.GetClientsParams{ClientID: &config.KC.ClientName})
+	clients, err := config.KeycloakFor(ctx).Client.GetClients(ctx, accessToken, config.KeycloakFor(ctx).Realm, gocloak.GetClientsParams{ClientID: &config.KeycloakFor(ctx).ClientName})
 	if err != nil {
-		return false, fmt.Errorf("failed to get client '%s': %w", config.KC.ClientName, err)
+		return false, fmt.Errorf("failed to get client '%s': %w", config.KeycloakFor(ctx).ClientName, err)
 	}
 	if len(clients) == 0 {
-		return false, fmt.Errorf("client '%s' not found", config.KC.ClientName)
+		return false, fmt.Errorf("client '%s' not found", config.KeycloakFor(ctx).ClientName)
 	}
 	return true, nil
 }
 
 func (s *keycloakService) CreateClient(ctx context.Context, accessToken string) (bool, error) {
 	newClient := gocloak.Client{
-		ClientID:                  gocloak.StringP(config.KC.ClientName),
-		Secret:                    gocloak.StringP(config.KC.ClientSecret),
+		ClientID:                  gocloak.StringP(config.KeycloakFor(ctx).ClientName),
+		Secret:                    gocloak.StringP(config.KeycloakFor(ctx).ClientSecret),
 		Enabled:                   gocloak.BoolP(true),
 		PublicClient:              gocloak.BoolP(false), // 'false'는 confidential client (비밀번호 필요)
 		ServiceAccountsEnabled:    gocloak.BoolP(true),  // 서비스 계정 활성화
 		DirectAccessGrantsEnabled: gocloak.BoolP(true),  // Direct access grants 활성화
 	}
-	clientInfo, err := config.KC.Client.CreateClient(ctx, accessToken, config.KC.Realm, newClient)
+	clientInfo, err := config.KeycloakFor(ctx).Client.CreateClient(ctx, accessToken, config.KeycloakFor(ctx).Realm, newClient)
 	if err != nil {
-		return false, fmt.Errorf("failed to create client '%s': %w", config.KC.ClientName, err)
+		return false, fmt.Errorf("failed to create client '%s': %w", config.KeycloakFor(ctx).ClientName, err)
 	}
 	log.Printf("[DEBUG] Client '%s' created successfully", clientInfo)
 	return true, nil
@@ -668,14 +736,14 @@ func (s *keycloakService) CreateClient(ctx context.Context, accessToken string)
 
 // GetUserIDFromToken extracts the user ID (subject) from a JWT token.
 func (s *keycloakService) GetUserIDFromToken(ctx context.Context, token *gocloak.JWT) (string, error) {
-	// Directly use config.KC
-	if config.KC == nil || config.KC.Client == nil {
+	// Directly use config.KeycloakFor(ctx)
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return "", fmt.Errorf("keycloak configuration not initialized")
 	}
 	if token == nil {
 		return "", fmt.Errorf("provided token is nil")
 	}
-	_, claims, err := config.KC.Client.DecodeAccessToken(ctx, token.AccessToken, config.KC.Realm)
+	_, claims, err := config.KeycloakFor(ctx).Client.DecodeAccessToken(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm)
 	if err != nil {
 		return "", fmt.Errorf("failed to decode access token: %w", err)
 	}
@@ -691,27 +759,27 @@ func (s *keycloakService) GetUserIDFromToken(ctx context.Context, token *gocloak
 
 // Login performs user login via Keycloak using username and password.
 func (s *keycloakService) Login(ctx context.Context, username, password string) (*gocloak.JWT, error) {
-	// Directly use config.KC
-	if config.KC == nil || config.KC.Client == nil {
+	// Directly use config.KeycloakFor(ctx)
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return nil, fmt.Errorf("keycloak configuration not initialized")
 	}
 
 	// Add debug logging for Keycloak configuration
 	log.Printf("[DEBUG] Keycloak Login Configuration:")
-	log.Printf("[DEBUG] - Host: %s", config.KC.Host)
-	log.Printf("[DEBUG] - Realm: %s", config.KC.Realm)
-	log.Printf("[DEBUG] - ClientID: %s", config.KC.ClientName)
-	log.Printf("[DEBUG] - ClientSecret: %s", config.KC.ClientSecret)
+	log.Printf("[DEBUG] - Host: %s", config.KeycloakFor(ctx).Host)
+	log.Printf("[DEBUG] - Realm: %s", config.KeycloakFor(ctx).Realm)
+	log.Printf("[DEBUG] - ClientID: %s", config.KeycloakFor(ctx).ClientName)
+	log.Printf("[DEBUG] - ClientSecret: %s", config.KeycloakFor(ctx).ClientSecret)
 	log.Printf("[DEBUG] - Username: %s", username)
 
-	token, err := config.KC.Client.Login(ctx, config.KC.ClientName, config.KC.ClientSecret, config.KC.Realm, username, password)
+	token, err := config.KeycloakFor(ctx).Client.Login(ctx, config.KeycloakFor(ctx).ClientName, config.KeycloakFor(ctx).ClientSecret, config.KeycloakFor(ctx).Realm, username, password)
 	if err != nil {
 		// Consider more specific error handling for invalid credentials vs other errors
 		return nil, fmt.Errorf("keycloak login failed: %w", err)
 	}
 
 	// 사용자 정보 가져오기
-	userInfo, err := config.KC.Client.GetUserInfo(ctx, token.AccessToken, config.KC.Realm)
+	userInfo, err := config.KeycloakFor(ctx).Client.GetUserInfo(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm)
 	if err != nil {
 		log.Printf("[DEBUG] 사용자 정보 조회 실패: %v", err)
 		return token, nil // 토큰은 성공했으므로 반환
@@ -729,11 +797,11 @@ func (s *keycloakService) Login(ctx context.Context, username, password string)
 
 // RefreshToken refreshes the JWT token using a refresh token.
 func (s *keycloakService) RefreshToken(ctx context.Context, refreshToken string) (*gocloak.JWT, error) {
-	// Directly use config.KC
-	if config.KC == nil || config.KC.Client == nil {
+	// Directly use config.KeycloakFor(ctx)
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return nil, fmt.Errorf("keycloak configuration not initialized")
 	}
-	newToken, err := config.KC.Client.RefreshToken(ctx, refreshToken, config.KC.ClientName, config.KC.ClientSecret, config.KC.Realm)
+	newToken, err := config.KeycloakFor(ctx).Client.RefreshToken(ctx, refreshToken, config.KeycloakFor(ctx).ClientName, config.KeycloakFor(ctx).ClientSecret, config.KeycloakFor(ctx).Realm)
 	if err != nil {
 		return nil, fmt.Errorf("keycloak token refresh failed: %w", err)
 	}
@@ -744,7 +812,7 @@ func (s *keycloakService) RefreshToken(ctx context.Context, refreshToken string)
 
 // findGroupByName finds a group by name and returns its ID. Returns empty string if not found.
 func (s *keycloakService) findGroupByName(ctx context.Context, token, groupName string) (string, error) {
-	groups, err := config.KC.Client.GetGroups(ctx, token, config.KC.Realm, gocloak.GetGroupsParams{
+	groups, err := config.KeycloakFor(ctx).Client.GetGroups(ctx, token, config.KeycloakFor(ctx).Realm, gocloak.GetGroupsParams{
 		Search: &groupName,
 		Exact:  gocloak.BoolP(true),
 	})
@@ -765,10 +833,10 @@ func (s *keycloakService) findGroupByName(ctx context.Context, token, groupName
 
 // EnsureGroupExistsAndAssignUser ensures a group exists and assigns a user to it.
 func (s *keycloakService) EnsureGroupExistsAndAssignUser(ctx context.Context, kcUserId, groupName string) error {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return fmt.Errorf("keycloak configuration not initialized")
 	}
-	adminToken, err := config.KC.LoginAdmin(ctx)
+	adminToken, err := config.KeycloakFor(ctx).LoginAdmin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get admin token for group operation: %w", err)
 	}
@@ -783,7 +851,7 @@ func (s *keycloakService) EnsureGroupExistsAndAssignUser(ctx context.Context, kc
 	if groupID == "" {
 		log.Printf("Keycloak group '%s' not found, creating it.", groupName)
 		newGroup := gocloak.Group{Name: &groupName}
-		groupID, err = config.KC.Client.CreateGroup(ctx, adminToken.AccessToken, config.KC.Realm, newGroup)
+		groupID, err = config.KeycloakFor(ctx).Client.CreateGroup(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, newGroup)
 		if err != nil {
 			// Handle potential conflict if group was created concurrently
 			if strings.Contains(err.Error(), "409") {
@@ -803,7 +871,7 @@ func (s *keycloakService) EnsureGroupExistsAndAssignUser(ctx context.Context, kc
 	}
 
 	// 3. Assign user to group
-	err = config.KC.Client.AddUserToGroup(ctx, adminToken.AccessToken, config.KC.Realm, kcUserId, groupID)
+	err = config.KeycloakFor(ctx).Client.AddUserToGroup(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, kcUserId, groupID)
 	if err != nil {
 		// Handle potential errors like user already in group (might not be an error depending on gocloak)
 		// Or user not found (should have been checked before calling this service method ideally)
@@ -815,10 +883,10 @@ func (s *keycloakService) EnsureGroupExistsAndAssignUser(ctx context.Context, kc
 
 // RemoveUserFromGroup removes a user from a specific group.
 func (s *keycloakService) RemoveUserFromGroup(ctx context.Context, kcUserId, groupName string) error {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return fmt.Errorf("keycloak configuration not initialized")
 	}
-	adminToken, err := config.KC.LoginAdmin(ctx)
+	adminToken, err := config.KeycloakFor(ctx).LoginAdmin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get admin token for group operation: %w", err)
 	}
@@ -836,7 +904,7 @@ func (s *keycloakService) RemoveUserFromGroup(ctx context.Context, kcUserId, gro
 	}
 
 	// 3. Remove user from group
-	err = config.KC.Client.DeleteUserFromGroup(ctx, adminToken.AccessToken, config.KC.Realm, kcUserId, groupID)
+	err = config.KeycloakFor(ctx).Client.DeleteUserFromGroup(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, kcUserId, groupID)
 	if err != nil {
 		// Handle potential errors like user not in group (might not be an error) or user not found
 		if strings.Contains(err.Error(), "404") {
@@ -858,7 +926,7 @@ func (s *keycloakService) RemoveUserFromGroup(ctx context.Context, kcUserId, gro
 // 5. Releam Role 생성 : platformAdmin by default
 // 6. KC Role 할당 : platformAdmin to user
 func (s *keycloakService) SetupInitialKeycloakAdmin(ctx context.Context, adminToken *gocloak.JWT) (string, error) {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return "", fmt.Errorf("keycloak configuration not initialized")
 	}
 
@@ -922,7 +990,7 @@ func (s *keycloakService) SetupInitialKeycloakAdmin(ctx context.Context, adminTo
 		EmailVerified:   gocloak.BoolP(true),
 		RequiredActions: &[]string{""},
 	}
-	kcUsers, err := config.KC.Client.GetUsers(ctx, adminToken.AccessToken, config.KC.Realm, gocloak.GetUsersParams{
+	kcUsers, err := config.KeycloakFor(ctx).Client.GetUsers(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, gocloak.GetUsersParams{
 		Username: gocloak.StringP(platformAdminID),
 		Exact:    gocloak.BoolP(true),
 	})
@@ -945,7 +1013,7 @@ func (s *keycloakService) SetupInitialKeycloakAdmin(ctx context.Context, adminTo
 		userID = *kcUser.ID
 		log.Printf("[DEBUG] User exists : %s", userID)
 	} else {
-		kcId, err := config.KC.Client.CreateUser(ctx, adminToken.AccessToken, config.KC.Realm, user)
+		kcId, err := config.KeycloakFor(ctx).Client.CreateUser(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, user)
 		if err != nil {
 			return "", fmt.Errorf("failed to create user: %w", err)
 		}
@@ -956,7 +1024,7 @@ func (s *keycloakService) SetupInitialKeycloakAdmin(ctx context.Context, adminTo
 		userID = kcId
 
 		// 비밀번호 설정
-		err = config.KC.Client.SetPassword(ctx, adminToken.AccessToken, userID, config.KC.Realm, platformAdminPassword, false)
+		err = config.KeycloakFor(ctx).Client.SetPassword(ctx, adminToken.AccessToken, userID, config.KeycloakFor(ctx).Realm, platformAdminPassword, false)
 		if err != nil {
 			return "", fmt.Errorf("failed to set password: %w", err)
 		}
@@ -968,7 +1036,7 @@ func (s *keycloakService) SetupInitialKeycloakAdmin(ctx context.Context, adminTo
 	// 	log.Printf("[DEBUG] PlatformAdmin role not exists")
 	// }
 	// //if len(rolesToAssign) > 0 {
-	// 	err = config.KC.Client.AddRealmRoleToUser(ctx, adminToken.AccessToken, config.KC.Realm, userID, rolesToAssign)
+	// 	err = config.KeycloakFor(ctx).Client.AddRealmRoleToUser(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, userID, rolesToAssign)
 	// 	if err != nil {
 	// 		log.Printf("failed to assign default roles %s", rolesToAssign[0].Name)
 	// 		return nil, fmt.Errorf("failed to assign default roles: %w", err)
@@ -980,14 +1048,14 @@ func (s *keycloakService) SetupInitialKeycloakAdmin(ctx context.Context, adminTo
 	log.Printf("[DEBUG] Setting platformAdmin role")
 	platformAdminRoleName := "platformAdmin"
 	var platformAdminRole gocloak.Role
-	realmRole, err := config.KC.Client.GetRealmRole(ctx, adminToken.AccessToken, config.KC.Realm, platformAdminRoleName)
+	realmRole, err := config.KeycloakFor(ctx).Client.GetRealmRole(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, platformAdminRoleName)
 	if err != nil {
 		log.Printf("failed to get platformAdmin role: %v", err)
 		newRole := gocloak.Role{
 			Name:        gocloak.StringP(platformAdminRoleName),
 			Description: gocloak.StringP("Predefined platform role"),
 		}
-		result, err := config.KC.Client.CreateRealmRole(ctx, adminToken.AccessToken, config.KC.Realm, newRole)
+		result, err := config.KeycloakFor(ctx).Client.CreateRealmRole(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, newRole)
 		if err != nil {
 			log.Printf("Failed to create realm role %s, %s: %v", platformAdminRoleName, result, err)
 			return "", fmt.Errorf("failed to create platformAdmin role: %w", err)
@@ -998,7 +1066,7 @@ func (s *keycloakService) SetupInitialKeycloakAdmin(ctx context.Context, adminTo
 		time.Sleep(5 * time.Second)
 
 		// 다시 조회
-		realmRoleResult, err := config.KC.Client.GetRealmRole(ctx, adminToken.AccessToken, config.KC.Realm, platformAdminRoleName)
+		realmRoleResult, err := config.KeycloakFor(ctx).Client.GetRealmRole(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, platformAdminRoleName)
 		if err != nil {
 			log.Printf("failed to get platformAdmin role again: %v", err)
 			return userID, fmt.Errorf("failed to get platformAdmin role again: %w", err)
@@ -1008,13 +1076,13 @@ func (s *keycloakService) SetupInitialKeycloakAdmin(ctx context.Context, adminTo
 		platformAdminRole = *realmRole
 	}
 	log.Printf("platformAdminRole: %+v", platformAdminRole)
-	// platformAdminRole, err := config.KC.Client.GetRealmRole(ctx, adminToken.AccessToken, config.KC.Realm, "platformAdmin")
+	// platformAdminRole, err := config.KeycloakFor(ctx).Client.GetRealmRole(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, "platformAdmin")
 	// if err != nil {
 	// 	log.Printf("failed to get platformAdmin role: %v", err)
 	// 	return nil, fmt.Errorf("failed to get platformAdmin role: %w", err)
 	// }
 
-	err = config.KC.Client.AddRealmRoleToUser(ctx, adminToken.AccessToken, config.KC.Realm, userID, []gocloak.Role{platformAdminRole})
+	err = config.KeycloakFor(ctx).Client.AddRealmRoleToUser(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, userID, []gocloak.Role{platformAdminRole})
 	if err != nil {
 		log.Printf("failed to assign platformAdmin role: %v", err)
 		return userID, fmt.Errorf("failed to assign platformAdmin role: %w", err)
@@ -1026,25 +1094,25 @@ func (s *keycloakService) SetupInitialKeycloakAdmin(ctx context.Context, adminTo
 
 // CheckUserRoles checks and logs all roles assigned to a user
 func (s *keycloakService) CheckUserRoles(ctx context.Context, username string) error {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return fmt.Errorf("keycloak configuration not initialized")
 	}
 
 	// Admin 로그인
 	log.Printf("[DEBUG] === Keycloak 설정 정보 ===")
-	log.Printf("[DEBUG] Realm: %s", config.KC.Realm)
-	log.Printf("[DEBUG] ClientName: %s", config.KC.ClientName)
-	log.Printf("[DEBUG] Host: %s", config.KC.Host)
+	log.Printf("[DEBUG] Realm: %s", config.KeycloakFor(ctx).Realm)
+	log.Printf("[DEBUG] ClientName: %s", config.KeycloakFor(ctx).ClientName)
+	log.Printf("[DEBUG] Host: %s", config.KeycloakFor(ctx).Host)
 	log.Printf("[DEBUG] ======================")
 
-	adminToken, err := config.KC.LoginAdmin(ctx)
+	adminToken, err := config.KeycloakFor(ctx).LoginAdmin(ctx)
 	if err != nil {
 		return fmt.Errorf("admin login failed: %w", err)
 	}
 	log.Printf("[DEBUG] Admin 로그인 성공")
 
 	// 모든 사용자 목록 가져오기
-	users, err := config.KC.Client.GetUsers(ctx, adminToken.AccessToken, config.KC.Realm, gocloak.GetUsersParams{})
+	users, err := config.KeycloakFor(ctx).Client.GetUsers(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, gocloak.GetUsersParams{})
 	if err != nil {
 		log.Printf("[DEBUG] 사용자 목록 조회 실패: %v", err)
 		return fmt.Errorf("failed to get users: %w", err)
@@ -1076,7 +1144,7 @@ func (s *keycloakService) CheckUserRoles(ctx context.Context, username string) e
 	log.Printf("[DEBUG] 찾은 사용자 ID: %s", userID)
 
 	// Realm 역할 확인
-	realmRoles, err := config.KC.Client.GetRealmRolesByUserID(ctx, adminToken.AccessToken, config.KC.Realm, userID)
+	realmRoles, err := config.KeycloakFor(ctx).Client.GetRealmRolesByUserID(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, userID)
 	if err != nil {
 		log.Printf("[DEBUG] Realm 역할 조회 실패: %v", err)
 	} else {
@@ -1090,22 +1158,22 @@ func (s *keycloakService) CheckUserRoles(ctx context.Context, username string) e
 	}
 
 	// 클라이언트 정보 가져오기
-	clients, err := config.KC.Client.GetClients(ctx, adminToken.AccessToken, config.KC.Realm, gocloak.GetClientsParams{
-		ClientID: &config.KC.ClientName,
+	clients, err := config.KeycloakFor(ctx).Client.GetClients(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, gocloak.GetClientsParams{
+		ClientID: &config.KeycloakFor(ctx).ClientName,
 	})
 	if err != nil {
 		log.Printf("[DEBUG] 클라이언트 목록 조회 실패: %v", err)
 		return fmt.Errorf("클라이언트 정보 조회 실패: %w", err)
 	}
 	if len(clients) == 0 {
-		log.Printf("[DEBUG] 클라이언트를 찾을 수 없음: %s", config.KC.ClientName)
-		return fmt.Errorf("클라이언트를 찾을 수 없습니다: %s", config.KC.ClientName)
+		log.Printf("[DEBUG] 클라이언트를 찾을 수 없음: %s", config.KeycloakFor(ctx).ClientName)
+		return fmt.Errorf("클라이언트를 찾을 수 없습니다: %s", config.KeycloakFor(ctx).ClientName)
 	}
 	clientID := *clients[0].ID
 	log.Printf("[DEBUG] 클라이언트 ID: %s", clientID)
 
 	// 클라이언트 역할 확인
-	clientRoles, err := config.KC.Client.GetClientRolesByUserID(ctx, adminToken.AccessToken, config.KC.Realm, clientID, userID)
+	clientRoles, err := config.KeycloakFor(ctx).Client.GetClientRolesByUserID(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, clientID, userID)
 	if err != nil {
 		log.Printf("[DEBUG] 클라이언트 역할 조회 실패: %v", err)
 	} else {
@@ -1119,7 +1187,7 @@ func (s *keycloakService) CheckUserRoles(ctx context.Context, username string) e
 	}
 
 	// 기본 역할 확인
-	defaultRoles, err := config.KC.Client.GetRealmRoles(ctx, adminToken.AccessToken, config.KC.Realm, gocloak.GetRoleParams{
+	defaultRoles, err := config.KeycloakFor(ctx).Client.GetRealmRoles(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, gocloak.GetRoleParams{
 		Search: gocloak.StringP("default"),
 	})
 	if err != nil {
@@ -1139,19 +1207,19 @@ func (s *keycloakService) CheckUserRoles(ctx context.Context, username string) e
 
 // GetUserPermissions gets all permissions for the given roles
 func (s *keycloakService) GetUserPermissions(ctx context.Context, roles []string) ([]string, error) {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return nil, fmt.Errorf("keycloak configuration not initialized")
 	}
 
 	// Get admin token
-	token, err := config.KC.GetAdminToken(ctx)
+	token, err := config.KeycloakFor(ctx).GetAdminToken(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get admin token: %w", err)
 	}
 
 	// Get client ID
-	clients, err := config.KC.Client.GetClients(ctx, token.AccessToken, config.KC.Realm, gocloak.GetClientsParams{
-		ClientID: &config.KC.ClientName,
+	clients, err := config.KeycloakFor(ctx).Client.GetClients(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, gocloak.GetClientsParams{
+		ClientID: &config.KeycloakFor(ctx).ClientName,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -1165,7 +1233,7 @@ func (s *keycloakService) GetUserPermissions(ctx context.Context, roles []string
 	var allPermissions []string
 	for _, role := range roles {
 		// Get role details
-		roleDetails, err := config.KC.Client.GetClientRole(ctx, token.AccessToken, config.KC.Realm, clientID, role)
+		roleDetails, err := config.KeycloakFor(ctx).Client.GetClientRole(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, clientID, role)
 		if err != nil {
 			log.Printf("Warning: Failed to get role details for %s: %v", role, err)
 			continue
@@ -1191,7 +1259,7 @@ func (s *keycloakService) GetUserPermissions(ctx context.Context, roles []string
 
 // GetImpersonationToken gets an impersonation token for a user
 func (s *keycloakService) GetImpersonationToken(ctx context.Context) (*gocloak.JWT, error) {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return nil, fmt.Errorf("keycloak configuration not initialized")
 	}
 
@@ -1207,7 +1275,7 @@ func (s *keycloakService) GetImpersonationToken(ctx context.Context) (*gocloak.J
 		return nil, fmt.Errorf("user ID not found in context")
 	}
 
-	// adminToken, err := config.KC.GetAdminToken(ctx)
+	// adminToken, err := config.KeycloakFor(ctx).GetAdminToken(ctx)
 	// if err != nil {
 	// 	return nil, fmt.Errorf("admin login failed: %w", err)
 	// }
@@ -1222,14 +1290,14 @@ func (s *keycloakService) GetImpersonationToken(ctx context.Context) (*gocloak.J
 		SubjectToken: gocloak.StringP(accessToken),
 		// SubjectToken:       gocloak.StringP(adminToken.AccessToken),
 		RequestedTokenType: gocloak.StringP("urn:ietf:params:oauth:token-type:refresh_token"),
-		ClientID:           &config.KC.OIDCClientID,
-		ClientSecret:       &config.KC.OIDCClientSecret,
+		ClientID:           &config.KeycloakFor(ctx).OIDCClientID,
+		ClientSecret:       &config.KeycloakFor(ctx).OIDCClientSecret,
 		RequestedSubject:   &kcUserId,
 		Username:           &username,
 	}
 	log.Printf("[DEBUG] adminToken: %s", accessToken)
 	// Get impersonation token using TokenExchange
-	token, err := config.KC.Client.GetToken(ctx, config.KC.Realm, tokenOptions)
+	token, err := config.KeycloakFor(ctx).Client.GetToken(ctx, config.KeycloakFor(ctx).Realm, tokenOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get impersonation token: %w", err)
 	}
@@ -1241,14 +1309,14 @@ func (s *keycloakService) GetImpersonationToken(ctx context.Context) (*gocloak.J
 func (s *keycloakService) GetImpersonationTokenByAdminToken(ctx context.Context, userID string, targetClientID string) (string, error) {
 
 	// 1. admin 계정으로 로그인
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return "", fmt.Errorf("keycloak configuration not initialized")
 	}
-	adminToken, err := config.KC.GetAdminToken(ctx)
+	adminToken, err := config.KeycloakFor(ctx).GetAdminToken(ctx)
 	if err != nil {
 		return "", fmt.Errorf("admin login failed: %w", err)
 	}
-	//user, err := config.KC.Client.GetUserByID(ctx, adminToken.AccessToken, config.KC.Realm, kcId)
+	//user, err := config.KeycloakFor(ctx).Client.GetUserByID(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, kcId)
 	// var result User
 	// resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
 	// 	SetResult(&result).
@@ -1260,7 +1328,7 @@ func (s *keycloakService) GetImpersonationTokenByAdminToken(ctx context.Context,
 
 	log.Printf("[DEBUG] adminToken: %s", adminToken.AccessToken)
 	// 2. Keycloak REST API로 impersonation 요청
-	url := fmt.Sprintf("%s/admin/realms/%s/users/%s/impersonation", config.KC.Host, config.KC.Realm, userID)
+	url := fmt.Sprintf("%s/admin/realms/%s/users/%s/impersonation", config.KeycloakFor(ctx).Host, config.KeycloakFor(ctx).Realm, userID)
 	body := map[string]interface{}{}
 	// if targetClientID != "" {
 	// 	body["client_id"] = targetClientID
@@ -1320,14 +1388,14 @@ func (s *keycloakService) GetImpersonationTokenByAdminToken(ctx context.Context,
 
 // GetImpersonationTokenByServiceAccount: 서비스 계정을 이용해 특정 클라이언트에 로그인한 토큰을 발급
 func (s *keycloakService) GetImpersonationTokenByServiceAccount(ctx context.Context) (*gocloak.JWT, error) {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return nil, fmt.Errorf("keycloak configuration not initialized")
 	}
 
 	// KeycloakConfig에서 OIDC 클라이언트 ID와 시크릿 가져오기
-	clientID := config.KC.OIDCClientID
-	clientName := config.KC.OIDCClientName
-	clientSecret := config.KC.OIDCClientSecret
+	clientID := config.KeycloakFor(ctx).OIDCClientID
+	clientName := config.KeycloakFor(ctx).OIDCClientName
+	clientSecret := config.KeycloakFor(ctx).OIDCClientSecret
 
 	if clientID == "" || clientSecret == "" {
 		return nil, fmt.Errorf("OIDC client ID or secret not configured in KeycloakConfig")
@@ -1336,11 +1404,11 @@ func (s *keycloakService) GetImpersonationTokenByServiceAccount(ctx context.Cont
 	log.Printf("[DEBUG] Impersonation clientID: %s", clientID)
 	log.Printf("[DEBUG] Impersonation clientName: %s", clientName)
 	log.Printf("[DEBUG] Impersonation clientSecret: %s", clientSecret)
-	log.Printf("[DEBUG] Impersonation realm: %s", config.KC.Realm)
+	log.Printf("[DEBUG] Impersonation realm: %s", config.KeycloakFor(ctx).Realm)
 
 	// 서비스 계정으로 로그인 (openid scope 포함 → id_token 발급)
 	// Alibaba STS AssumeRoleWithOIDC는 단일 aud 문자열의 id_token을 요구함
-	token, err := config.KC.Client.LoginClient(ctx, clientName, clientSecret, config.KC.Realm, "openid")
+	token, err := config.KeycloakFor(ctx).Client.LoginClient(ctx, clientName, clientSecret, config.KeycloakFor(ctx).Realm, "openid")
 	if err != nil {
 		return nil, fmt.Errorf("failed to login with service account: %w", err)
 	}
@@ -1359,12 +1427,12 @@ func (s *keycloakService) GetImpersonationTokenByServiceAccount(ctx context.Cont
 //
 // samlClientAudience: Keycloak에 등록된 SAML 클라이언트 ID (e.g., "urn:amazon:webservices")
 func (s *keycloakService) GetSamlAssertionByServiceAccount(ctx context.Context, samlClientAudience string) (string, error) {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return "", fmt.Errorf("keycloak configuration not initialized")
 	}
 
-	clientName := config.KC.OIDCClientName
-	clientSecret := config.KC.OIDCClientSecret
+	clientName := config.KeycloakFor(ctx).OIDCClientName
+	clientSecret := config.KeycloakFor(ctx).OIDCClientSecret
 	if clientName == "" || clientSecret == "" {
 		return "", fmt.Errorf("OIDC client credentials not configured")
 	}
@@ -1377,13 +1445,13 @@ func (s *keycloakService) GetSamlAssertionByServiceAccount(ctx context.Context,
 		return "", fmt.Errorf("platform admin credentials not configured (MC_IAM_MANAGER_PLATFORMADMIN_ID/PASSWORD required for SAML exchange)")
 	}
 
-	userToken, err := config.KC.Client.Login(ctx, clientName, clientSecret, config.KC.Realm, platformAdminID, platformAdminPW)
+	userToken, err := config.KeycloakFor(ctx).Client.Login(ctx, clientName, clientSecret, config.KeycloakFor(ctx).Realm, platformAdminID, platformAdminPW)
 	if err != nil {
 		return "", fmt.Errorf("failed to get user token for SAML exchange: %w", err)
 	}
 
 	// Step 2: RFC 8693 토큰 교환 — access token → SAML2 assertion (base64url)
-	tokenURL := fmt.Sprintf("%s/realms/%s/protocol/openid-connect/token", config.KC.Host, config.KC.Realm)
+	tokenURL := fmt.Sprintf("%s/realms/%s/protocol/openid-connect/token", config.KeycloakFor(ctx).Host, config.KeycloakFor(ctx).Realm)
 
 	formData := url.Values{}
 	formData.Set("grant_type", "urn:ietf:params:oauth:grant-type:token-exchange")
@@ -1497,17 +1565,17 @@ func buildSAMLResponse(assertionXML string, destination string) string {
 
 // AssignRealmRoleToUser assigns a realm role to a user
 func (s *keycloakService) AssignRealmRoleToUser(ctx context.Context, kcUserId, roleName string) error {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return fmt.Errorf("keycloak configuration not initialized")
 	}
 
-	token, err := config.KC.GetAdminToken(ctx)
+	token, err := config.KeycloakFor(ctx).GetAdminToken(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get admin token: %w", err)
 	}
 
 	// Get the role by name
-	roles, err := config.KC.Client.GetRealmRoles(ctx, token.AccessToken, config.KC.Realm, gocloak.GetRoleParams{
+	roles, err := config.KeycloakFor(ctx).Client.GetRealmRoles(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, gocloak.GetRoleParams{
 		Search: &roleName,
 	})
 	if err != nil {
@@ -1521,7 +1589,7 @@ func (s *keycloakService) AssignRealmRoleToUser(ctx context.Context, kcUserId, r
 	}
 
 	// Assign the role to the user
-	err = config.KC.Client.AddRealmRoleToUser(ctx, token.AccessToken, config.KC.Realm, kcUserId, []gocloak.Role{*roles[0]})
+	err = config.KeycloakFor(ctx).Client.AddRealmRoleToUser(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, kcUserId, []gocloak.Role{*roles[0]})
 	if err != nil {
 		return fmt.Errorf("failed to assign realm role %s to user %s: %w", roleName, kcUserId, err)
 	}
@@ -1530,31 +1598,15 @@ func (s *keycloakService) AssignRealmRoleToUser(ctx context.Context, kcUserId, r
 	return nil
 }
 
-// IssueWorkspaceTicket 워크스페이스 티켓을 발행합니다.
-func (s *keycloakService) IssueWorkspaceTicket(ctx context.Context, kcUserId string, workspaceID uint) (string, map[string]interface{}, error) {
-	// Keycloak에 워크스페이스 티켓 발행 요청
-	// TODO: 실제 Keycloak API 호출 구현
-	ticket := fmt.Sprintf("workspace_ticket_%s_%d", kcUserId, workspaceID)
-
-	// 임시 권한 정보 생성
-	permissions := map[string]interface{}{
-		"workspace_id": workspaceID,
-		"kc_user_id":   kcUserId,
-		"roles":        []string{"admin"}, // TODO: 실제 사용자 역할 조회
-	}
-
-	return ticket, permissions, nil
-}
-
 // SetupPredefinedRoles retrieves all realm roles for a specific realm
 // 특정 Realm의 모든 RealmRole 목록을 조회합니다.
 func (s *keycloakService) SetupPredefinedRoles(ctx context.Context, accessToken string) error {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return fmt.Errorf("keycloak client is not initialized")
 	}
 
 	// Get all realm roles
-	realmRoles, err := config.KC.Client.GetRealmRoles(ctx, accessToken, config.KC.Realm, gocloak.GetRoleParams{})
+	realmRoles, err := config.KeycloakFor(ctx).Client.GetRealmRoles(ctx, accessToken, config.KeycloakFor(ctx).Realm, gocloak.GetRoleParams{})
 	if err != nil {
 		log.Printf("[DEBUG] Get Realm roles failed : %v", err)
 		return fmt.Errorf("failed to get realm roles: %w", err)
@@ -1582,18 +1634,18 @@ func (s *keycloakService) SetupPredefinedRoles(ctx context.Context, accessToken
 		// 역할이 없으면 생성
 		if !roleExists {
 			log.Printf("Creating predefined role: %s", roleName)
-			log.Printf("target realm %s, client %s", config.KC.Realm, config.KC.ClientID)
+			log.Printf("target realm %s, client %s", config.KeycloakFor(ctx).Realm, config.KeycloakFor(ctx).ClientID)
 			newRole := gocloak.Role{
 				Name:        &roleName,
 				Description: gocloak.StringP("Predefined platform role"),
 			}
 
-			result, err := config.KC.Client.CreateRealmRole(ctx, accessToken, config.KC.Realm, newRole)
+			result, err := config.KeycloakFor(ctx).Client.CreateRealmRole(ctx, accessToken, config.KeycloakFor(ctx).Realm, newRole)
 			if err != nil {
 				log.Printf("Failed to create realm role %s, %s: %v", roleName, result, err)
 				continue
 			}
-			// _, err := config.KC.Client.CreateClientRole(ctx, adminToken.AccessToken, config.KC.Realm, config.KC.ClientID, newRole)
+			// _, err := config.KeycloakFor(ctx).Client.CreateClientRole(ctx, adminToken.AccessToken, config.KeycloakFor(ctx).Realm, config.KeycloakFor(ctx).ClientID, newRole)
 			// if err != nil {
 			// 	log.Printf("Failed to create role %s: %v", roleName, err)
 			// 	continue
@@ -1613,7 +1665,7 @@ func (s *keycloakService) SetupPredefinedRoles(ctx context.Context, accessToken
 
 func (s *keycloakService) GetCerts(ctx context.Context) (*gocloak.CertResponse, error) {
 
-	cert, err := config.KC.Client.GetCerts(ctx, config.KC.Realm)
+	cert, err := config.KeycloakFor(ctx).Client.GetCerts(ctx, config.KeycloakFor(ctx).Realm)
 	if err != nil {
 		log.Println(err)
 		return nil, err
@@ -1623,15 +1675,15 @@ func (s *keycloakService) GetCerts(ctx context.Context) (*gocloak.CertResponse,
 
 // GetClientCredentialsToken 클라이언트 자격 증명으로 토큰을 발급받습니다.
 func (s *keycloakService) GetClientCredentialsToken(ctx context.Context) (*gocloak.JWT, error) {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return nil, fmt.Errorf("keycloak configuration not initialized")
 	}
 
 	// Get client credentials
-	realm := config.KC.Realm
-	oidcClientID := config.KC.OIDCClientID
-	oidcClientName := config.KC.OIDCClientName
-	oidcClientSecret := config.KC.OIDCClientSecret
+	realm := config.KeycloakFor(ctx).Realm
+	oidcClientID := config.KeycloakFor(ctx).OIDCClientID
+	oidcClientName := config.KeycloakFor(ctx).OIDCClientName
+	oidcClientSecret := config.KeycloakFor(ctx).OIDCClientSecret
 
 	if oidcClientID == "" || oidcClientSecret == "" {
 		return nil, fmt.Errorf("OIDC client ID or secret not configured in KeycloakConfig")
@@ -1643,7 +1695,7 @@ func (s *keycloakService) GetClientCredentialsToken(ctx context.Context) (*goclo
 	log.Printf("[DEBUG] Impersonation clientSecret: %s", oidcClientSecret)
 
 	// Login with client credentials
-	token, err := config.KC.Client.LoginClient(ctx, oidcClientName, oidcClientSecret, realm)
+	token, err := config.KeycloakFor(ctx).Client.LoginClient(ctx, oidcClientName, oidcClientSecret, realm)
 	if err != nil {
 		log.Printf("[DEBUG] KC.Client.LoginClient failed : %s", err)
 		return nil, fmt.Errorf("failed to login with client credentials: %w", err)
@@ -1654,16 +1706,16 @@ func (s *keycloakService) GetClientCredentialsToken(ctx context.Context) (*goclo
 
 // CheckRealmRoleExists checks if a realm role exists
 func (s *keycloakService) CheckRealmRoleExists(ctx context.Context, roleName string) (bool, error) {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return false, fmt.Errorf("keycloak configuration not initialized")
 	}
 
-	token, err := config.KC.GetAdminToken(ctx)
+	token, err := config.KeycloakFor(ctx).GetAdminToken(ctx)
 	if err != nil {
 		return false, fmt.Errorf("failed to get admin token: %w", err)
 	}
 
-	_, err = config.KC.Client.GetRealmRole(ctx, token.AccessToken, config.KC.Realm, roleName)
+	_, err = config.KeycloakFor(ctx).Client.GetRealmRole(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, roleName)
 	if err != nil {
 		// Role not found
 		return false, nil
@@ -1673,11 +1725,11 @@ func (s *keycloakService) CheckRealmRoleExists(ctx context.Context, roleName str
 
 // CreateRealmRole creates a realm role
 func (s *keycloakService) CreateRealmRole(ctx context.Context, roleName string) error {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return fmt.Errorf("keycloak configuration not initialized")
 	}
 
-	token, err := config.KC.GetAdminToken(ctx)
+	token, err := config.KeycloakFor(ctx).GetAdminToken(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get admin token: %w", err)
 	}
@@ -1687,7 +1739,7 @@ func (s *keycloakService) CreateRealmRole(ctx context.Context, roleName string)
 		Description: gocloak.StringP("Platform role"),
 	}
 
-	result, err := config.KC.Client.CreateRealmRole(ctx, token.AccessToken, config.KC.Realm, newRole)
+	result, err := config.KeycloakFor(ctx).Client.CreateRealmRole(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, newRole)
 	if err != nil {
 		return fmt.Errorf("failed to create realm role %s: %w", roleName, err)
 	}
@@ -1698,17 +1750,17 @@ func (s *keycloakService) CreateRealmRole(ctx context.Context, roleName string)
 
 // RemoveRealmRoleFromUser removes a realm role from a user
 func (s *keycloakService) RemoveRealmRoleFromUser(ctx context.Context, kcUserId, roleName string) error {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return fmt.Errorf("keycloak configuration not initialized")
 	}
 
-	token, err := config.KC.GetAdminToken(ctx)
+	token, err := config.KeycloakFor(ctx).GetAdminToken(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get admin token: %w", err)
 	}
 
 	// Get the role by name
-	roles, err := config.KC.Client.GetRealmRoles(ctx, token.AccessToken, config.KC.Realm, gocloak.GetRoleParams{
+	roles, err := config.KeycloakFor(ctx).Client.GetRealmRoles(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, gocloak.GetRoleParams{
 		Search: &roleName,
 	})
 	if err != nil {
@@ -1723,7 +1775,7 @@ func (s *keycloakService) RemoveRealmRoleFromUser(ctx context.Context, kcUserId,
 	}
 
 	// Remove the role from the user
-	err = config.KC.Client.DeleteRealmRoleFromUser(ctx, token.AccessToken, config.KC.Realm, kcUserId, []gocloak.Role{*roles[0]})
+	err = config.KeycloakFor(ctx).Client.DeleteRealmRoleFromUser(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, kcUserId, []gocloak.Role{*roles[0]})
 	if err != nil {
 		return fmt.Errorf("failed to remove realm role %s from user %s: %w", roleName, kcUserId, err)
 	}
@@ -1734,11 +1786,11 @@ func (s *keycloakService) RemoveRealmRoleFromUser(ctx context.Context, kcUserId,
 
 // CreateRealmRoleAndWait creates a realm role and waits for it to be available
 func (s *keycloakService) CreateRealmRoleAndWait(ctx context.Context, roleName string) error {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return fmt.Errorf("keycloak configuration not initialized")
 	}
 
-	token, err := config.KC.GetAdminToken(ctx)
+	token, err := config.KeycloakFor(ctx).GetAdminToken(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get admin token: %w", err)
 	}
@@ -1748,7 +1800,7 @@ func (s *keycloakService) CreateRealmRoleAndWait(ctx context.Context, roleName s
 		Description: gocloak.StringP("Platform role"),
 	}
 
-	result, err := config.KC.Client.CreateRealmRole(ctx, token.AccessToken, config.KC.Realm, newRole)
+	result, err := config.KeycloakFor(ctx).Client.CreateRealmRole(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, newRole)
 	if err != nil {
 		return fmt.Errorf("failed to create realm role %s: %w", roleName, err)
 	}
@@ -1777,18 +1829,43 @@ func (s *keycloakService) CreateRealmRoleAndWait(ctx context.Context, roleName s
 	return fmt.Errorf("realm role %s was not available after %d attempts", roleName, maxRetries)
 }
 
+// GetUserRealmRoles 사용자에게 직접 할당된 realm role 이름 목록 (그룹으로 상속된 역할 제외)
+func (s *keycloakService) GetUserRealmRoles(ctx context.Context, kcUserId string) ([]string, error) {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
+		return nil, fmt.Errorf("keycloak configuration not initialized")
+	}
+
+	token, err := config.KeycloakFor(ctx).GetAdminToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin token: %w", err)
+	}
+
+	roles, err := config.KeycloakFor(ctx).Client.GetRealmRolesByUserID(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, kcUserId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get realm roles for user %s: %w", kcUserId, err)
+	}
+
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if role.Name != nil {
+			names = append(names, *role.Name)
+		}
+	}
+	return names, nil
+}
+
 // IsRealmRoleAssignedToUser checks if a specific realm role is assigned to the given user
 func (s *keycloakService) IsRealmRoleAssignedToUser(ctx context.Context, kcUserId, roleName string) (bool, error) {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return false, fmt.Errorf("keycloak configuration not initialized")
 	}
 
-	token, err := config.KC.GetAdminToken(ctx)
+	token, err := config.KeycloakFor(ctx).GetAdminToken(ctx)
 	if err != nil {
 		return false, fmt.Errorf("failed to get admin token: %w", err)
 	}
 
-	roles, err := config.KC.Client.GetRealmRolesByUserID(ctx, token.AccessToken, config.KC.Realm, kcUserId)
+	roles, err := config.KeycloakFor(ctx).Client.GetRealmRolesByUserID(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, kcUserId)
 	if err != nil {
 		return false, fmt.Errorf("failed to get realm roles for user %s: %w", kcUserId, err)
 	}
@@ -1803,11 +1880,11 @@ func (s *keycloakService) IsRealmRoleAssignedToUser(ctx context.Context, kcUserI
 
 // AddRealmRoleToGroup adds a realm role to a Keycloak group (creates group if not exists)
 func (s *keycloakService) AddRealmRoleToGroup(ctx context.Context, groupName, roleName string) error {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return fmt.Errorf("keycloak configuration not initialized")
 	}
 
-	token, err := config.KC.GetAdminToken(ctx)
+	token, err := config.KeycloakFor(ctx).GetAdminToken(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get admin token: %w", err)
 	}
@@ -1820,7 +1897,7 @@ func (s *keycloakService) AddRealmRoleToGroup(ctx context.Context, groupName, ro
 	if groupID == "" {
 		log.Printf("Keycloak group '%s' not found, creating it for role assignment.", groupName)
 		newGroup := gocloak.Group{Name: &groupName}
-		groupID, err = config.KC.Client.CreateGroup(ctx, token.AccessToken, config.KC.Realm, newGroup)
+		groupID, err = config.KeycloakFor(ctx).Client.CreateGroup(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, newGroup)
 		if err != nil {
 			if strings.Contains(err.Error(), "409") {
 				groupID, err = s.findGroupByName(ctx, token.AccessToken, groupName)
@@ -1835,7 +1912,7 @@ func (s *keycloakService) AddRealmRoleToGroup(ctx context.Context, groupName, ro
 	}
 
 	// Get realm role by name
-	roles, err := config.KC.Client.GetRealmRoles(ctx, token.AccessToken, config.KC.Realm, gocloak.GetRoleParams{
+	roles, err := config.KeycloakFor(ctx).Client.GetRealmRoles(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, gocloak.GetRoleParams{
 		Search: &roleName,
 	})
 	if err != nil {
@@ -1846,7 +1923,7 @@ func (s *keycloakService) AddRealmRoleToGroup(ctx context.Context, groupName, ro
 	}
 
 	// Add role to group
-	if err := config.KC.Client.AddRealmRoleToGroup(ctx, token.AccessToken, config.KC.Realm, groupID, []gocloak.Role{*roles[0]}); err != nil {
+	if err := config.KeycloakFor(ctx).Client.AddRealmRoleToGroup(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, groupID, []gocloak.Role{*roles[0]}); err != nil {
 		return fmt.Errorf("failed to add realm role '%s' to group '%s': %w", roleName, groupName, err)
 	}
 
@@ -1856,11 +1933,11 @@ func (s *keycloakService) AddRealmRoleToGroup(ctx context.Context, groupName, ro
 
 // RemoveRealmRoleFromGroup removes a realm role from a Keycloak group
 func (s *keycloakService) RemoveRealmRoleFromGroup(ctx context.Context, groupName, roleName string) error {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return fmt.Errorf("keycloak configuration not initialized")
 	}
 
-	token, err := config.KC.GetAdminToken(ctx)
+	token, err := config.KeycloakFor(ctx).GetAdminToken(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get admin token: %w", err)
 	}
@@ -1876,7 +1953,7 @@ func (s *keycloakService) RemoveRealmRoleFromGroup(ctx context.Context, groupNam
 	}
 
 	// Get realm role by name
-	roles, err := config.KC.Client.GetRealmRoles(ctx, token.AccessToken, config.KC.Realm, gocloak.GetRoleParams{
+	roles, err := config.KeycloakFor(ctx).Client.GetRealmRoles(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, gocloak.GetRoleParams{
 		Search: &roleName,
 	})
 	if err != nil {
@@ -1888,7 +1965,7 @@ func (s *keycloakService) RemoveRealmRoleFromGroup(ctx context.Context, groupNam
 	}
 
 	// Remove role from group
-	if err := config.KC.Client.DeleteRealmRoleFromGroup(ctx, token.AccessToken, config.KC.Realm, groupID, []gocloak.Role{*roles[0]}); err != nil {
+	if err := config.KeycloakFor(ctx).Client.DeleteRealmRoleFromGroup(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, groupID, []gocloak.Role{*roles[0]}); err != nil {
 		return fmt.Errorf("failed to remove realm role '%s' from group '%s': %w", roleName, groupName, err)
 	}
 
@@ -1898,11 +1975,11 @@ func (s *keycloakService) RemoveRealmRoleFromGroup(ctx context.Context, groupNam
 
 // DeleteGroup deletes a Keycloak group by name. No-op (not an error) if the group doesn't exist.
 func (s *keycloakService) DeleteGroup(ctx context.Context, groupName string) error {
-	if config.KC == nil || config.KC.Client == nil {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
 		return fmt.Errorf("keycloak configuration not initialized")
 	}
 
-	token, err := config.KC.GetAdminToken(ctx)
+	token, err := config.KeycloakFor(ctx).GetAdminToken(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get admin token: %w", err)
 	}
@@ -1916,7 +1993,7 @@ func (s *keycloakService) DeleteGroup(ctx context.Context, groupName string) err
 		return nil
 	}
 
-	if err := config.KC.Client.DeleteGroup(ctx, token.AccessToken, config.KC.Realm, groupID); err != nil {
+	if err := config.KeycloakFor(ctx).Client.DeleteGroup(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, groupID); err != nil {
 		return fmt.Errorf("failed to delete keycloak group '%s': %w", groupName, err)
 	}
 
@@ -1935,13 +2012,13 @@ type kcProtocolMapperForService struct {
 // CheckSAMLClientConfig Keycloak SAML 클라이언트 존재 및 protocol mapper 구성 확인
 // AWS SAML 연동에 필요한 클라이언트와 Role attribute mapper가 설정되어 있는지 검증한다.
 func (s *keycloakService) CheckSAMLClientConfig(ctx context.Context, clientID string) (string, error) {
-	adminToken, err := config.KC.LoginAdmin(ctx)
+	adminToken, err := config.KeycloakFor(ctx).LoginAdmin(ctx)
 	if err != nil {
 		return "", fmt.Errorf("Keycloak admin 로그인 실패: %v", err)
 	}
 
-	realm := config.KC.Realm
-	kcHost := config.KC.Host
+	realm := config.KeycloakFor(ctx).Realm
+	kcHost := config.KeycloakFor(ctx).Host
 
 	// 1. 클라이언트 존재 확인
 	clientsURL := fmt.Sprintf("%s/admin/realms/%s/clients?clientId=%s", kcHost, realm, clientID)
@@ -2007,3 +2084,155 @@ func kcAdminGetRequest(ctx context.Context, url, token string) ([]byte, error) {
 	}
 	return io.ReadAll(resp.Body)
 }
+
+// GetUserSessions 사용자의 활성 Keycloak 세션 목록
+func (s *keycloakService) GetUserSessions(ctx context.Context, kcUserID string) ([]*gocloak.UserSessionRepresentation, error) {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
+		return nil, fmt.Errorf("keycloak configuration not initialized")
+	}
+	token, err := config.KeycloakFor(ctx).GetAdminToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin token: %w", err)
+	}
+	sessions, err := config.KeycloakFor(ctx).Client.GetUserSessions(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, kcUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions for user %s: %w", kcUserID, err)
+	}
+	return sessions, nil
+}
+
+// LogoutUserSession 세션 하나 종료
+func (s *keycloakService) LogoutUserSession(ctx context.Context, sessionID string) error {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
+		return fmt.Errorf("keycloak configuration not initialized")
+	}
+	token, err := config.KeycloakFor(ctx).GetAdminToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get admin token: %w", err)
+	}
+	if err := config.KeycloakFor(ctx).Client.LogoutUserSession(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, sessionID); err != nil {
+		return fmt.Errorf("failed to logout session %s: %w", sessionID, err)
+	}
+	return nil
+}
+
+// LogoutAllUserSessions 사용자의 모든 세션 종료
+func (s *keycloakService) LogoutAllUserSessions(ctx context.Context, kcUserID string) error {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
+		return fmt.Errorf("keycloak configuration not initialized")
+	}
+	token, err := config.KeycloakFor(ctx).GetAdminToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get admin token: %w", err)
+	}
+	if err := config.KeycloakFor(ctx).Client.LogoutAllSessions(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, kcUserID); err != nil {
+		return fmt.Errorf("failed to logout sessions for user %s: %w", kcUserID, err)
+	}
+	return nil
+}
+
+// CreateServiceAccountClient 서비스 계정용 client credentials 클라이언트 생성
+// 발급되는 access token이 AuthMiddleware의 aud 검증을 통과하도록 IAM Manager 클라이언트를 audience로 추가한다.
+func (s *keycloakService) CreateServiceAccountClient(ctx context.Context, clientID, description string) (*model.KeycloakServiceAccountClient, error) {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
+		return nil, fmt.Errorf("keycloak configuration not initialized")
+	}
+	token, err := config.KeycloakFor(ctx).GetAdminToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin token: %w", err)
+	}
+	newClient := gocloak.Client{
+		ClientID:                  gocloak.StringP(clientID),
+		Description:               gocloak.StringP(description),
+		Enabled:                   gocloak.BoolP(true),
+		PublicClient:              gocloak.BoolP(false),
+		ServiceAccountsEnabled:    gocloak.BoolP(true),
+		StandardFlowEnabled:       gocloak.BoolP(false),
+		DirectAccessGrantsEnabled: gocloak.BoolP(false),
+		ImplicitFlowEnabled:       gocloak.BoolP(false),
+		ProtocolMappers: &[]gocloak.ProtocolMapperRepresentation{{
+			Name:           gocloak.StringP("mciam-audience"),
+			Protocol:       gocloak.StringP("openid-connect"),
+			ProtocolMapper: gocloak.StringP("oidc-audience-mapper"),
+			Config: &map[string]string{
+				"included.client.audience": config.KeycloakFor(ctx).ClientName,
+				"access.token.claim":       "true",
+				"id.token.claim":           "false",
+			},
+		}},
+	}
+	idOfClient, err := config.KeycloakFor(ctx).Client.CreateClient(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, newClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client '%s': %w", clientID, err)
+	}
+
+	result := &model.KeycloakServiceAccountClient{ID: idOfClient, ClientID: clientID}
+	secret, err := config.KeycloakFor(ctx).Client.GetClientSecret(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, idOfClient)
+	if err == nil && secret.Value != nil {
+		result.Secret = *secret.Value
+	}
+	var saUser *gocloak.User
+	if err == nil {
+		saUser, err = config.KeycloakFor(ctx).Client.GetClientServiceAccount(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, idOfClient)
+	}
+	if err != nil || saUser == nil || saUser.ID == nil {
+		if delErr := config.KeycloakFor(ctx).Client.DeleteClient(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, idOfClient); delErr != nil {
+			log.Printf("[WARN] failed to clean up client '%s': %v", clientID, delErr)
+		}
+		return nil, fmt.Errorf("failed to get service account of client '%s': %v", clientID, err)
+	}
+	result.UserID = *saUser.ID
+	result.Username = gocloak.PString(saUser.Username)
+	return result, nil
+}
+
+// RegenerateServiceAccountClientSecret 클라이언트 secret 재발급
+func (s *keycloakService) RegenerateServiceAccountClientSecret(ctx context.Context, idOfClient string) (string, error) {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
+		return "", fmt.Errorf("keycloak configuration not initialized")
+	}
+	token, err := config.KeycloakFor(ctx).GetAdminToken(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get admin token: %w", err)
+	}
+	secret, err := config.KeycloakFor(ctx).Client.RegenerateClientSecret(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, idOfClient)
+	if err != nil {
+		return "", fmt.Errorf("failed to regenerate client secret: %w", err)
+	}
+	return gocloak.PString(secret.Value), nil
+}
+
+// SetClientEnabled 클라이언트 활성/비활성 (비활성 클라이언트는 토큰 발급 불가)
+func (s *keycloakService) SetClientEnabled(ctx context.Context, idOfClient string, enabled bool) error {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
+		return fmt.Errorf("keycloak configuration not initialized")
+	}
+	token, err := config.KeycloakFor(ctx).GetAdminToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get admin token: %w", err)
+	}
+	client, err := config.KeycloakFor(ctx).Client.GetClient(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, idOfClient)
+	if err != nil {
+		return fmt.Errorf("failed to get client: %w", err)
+	}
+	client.Enabled = gocloak.BoolP(enabled)
+	if err := config.KeycloakFor(ctx).Client.UpdateClient(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, *client); err != nil {
+		return fmt.Errorf("failed to update client: %w", err)
+	}
+	return nil
+}
+
+// DeleteServiceAccountClient 서비스 계정 클라이언트 삭제
+func (s *keycloakService) DeleteServiceAccountClient(ctx context.Context, idOfClient string) error {
+	if config.KeycloakFor(ctx) == nil || config.KeycloakFor(ctx).Client == nil {
+		return fmt.Errorf("keycloak configuration not initialized")
+	}
+	token, err := config.KeycloakFor(ctx).GetAdminToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get admin token: %w", err)
+	}
+	if err := config.KeycloakFor(ctx).Client.DeleteClient(ctx, token.AccessToken, config.KeycloakFor(ctx).Realm, idOfClient); err != nil {
+		return fmt.Errorf("failed to delete client: %w", err)
+	}
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/m-cmp/mc-iam-manager/model"
 	"github.com/m-cmp/mc-iam-manager/model/mcmpapi"
 	"github.com/m-cmp/mc-iam-manager/pkg/apiparser"
+	"github.com/m-cmp/mc-iam-manager/pkg/metrics"
 	"github.com/m-cmp/mc-iam-manager/repository"
 	"gopkg.in/yaml.v3"
 	"gorm.io/gorm"
@@ -29,6 +30,9 @@ const localServiceActionsPath = "asset/mcmpapi/service-actions.yaml"
 
 var ErrFrameworkServiceAlreadyExists = errors.New("framework service already exists")
 
+// ErrMcmpApiMissingParams 액션 정의상 필수 파라미터 누락
+var ErrMcmpApiMissingParams = errors.New("missing required parameters")
+
 // McmpApiService defines the interface for mcmp API operations
 type McmpApiService interface {
 	GetDB() *gorm.DB
@@ -185,13 +189,7 @@ func (s *mcmpApiService) syncServicesAndActions(serviceActionsRaw map[string]int
 					continue
 				}
 
-				action := &mcmpapi.McmpApiAction{
-					ServiceName:  serviceName,
-					ActionName:   actionName,
-					Method:       s.getString(actionDef, "method"),
-					ResourcePath: s.getString(actionDef, "resourcePath"),
-					Description:  s.getString(actionDef, "description"),
-				}
+				action := newMcmpApiAction(serviceName, actionName, s.decodeServiceAction(serviceName, actionName, actionDef))
 
 				if err := s.repo.CreateAction(tx, action); err != nil {
 					tx.Rollback()
@@ -241,6 +239,7 @@ func (s *mcmpApiService) extractMeta(serviceName string, actionsMap map[string]i
 		Version:     s.getString(metaRaw, "version"),
 		Repository:  s.getString(metaRaw, "repository"),
 		GeneratedAt: generatedAt,
+		Servers:     s.getStringSlice(metaRaw, "servers"),
 	}, nil
 }
 
@@ -279,6 +278,80 @@ func (s *mcmpApiService) getString(m map[string]interface{}, key string) string
 	return ""
 }
 
+// getStringSlice safely extracts a string list from a map
+func (s *mcmpApiService) getStringSlice(m map[string]interface{}, key string) []string {
+	raw, ok := m[key].([]interface{})
+	if !ok {
+		return nil
+	}
+	values := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if str, ok := v.(string); ok {
+			values = append(values, str)
+		}
+	}
+	return values
+}
+
+// decodeServiceAction converts a raw YAML action entry (method, resourcePath, description, parameters, requestBody, security)
+func (s *mcmpApiService) decodeServiceAction(serviceName, actionName string, actionDef map[string]interface{}) apiparser.ServiceAction {
+	def := apiparser.ServiceAction{
+		Method:       s.getString(actionDef, "method"),
+		ResourcePath: s.getString(actionDef, "resourcePath"),
+		Description:  s.getString(actionDef, "description"),
+	}
+	raw, err := yaml.Marshal(actionDef)
+	if err == nil {
+		err = yaml.Unmarshal(raw, &def)
+	}
+	if err != nil {
+		log.Printf("Warning: failed to decode parameters of %s/%s: %v", serviceName, actionName, err)
+	}
+	return def
+}
+
+// newMcmpApiAction builds an mcmp_api_actions row from a parsed action definition
+func newMcmpApiAction(serviceName, actionName string, def apiparser.ServiceAction) *mcmpapi.McmpApiAction {
+	return &mcmpapi.McmpApiAction{
+		ServiceName:  serviceName,
+		ActionName:   actionName,
+		Method:       def.Method,
+		ResourcePath: def.ResourcePath,
+		Description:  def.Description,
+		Parameters:   def.Parameters,
+		RequestBody:  def.RequestBody,
+		Security:     def.Security,
+	}
+}
+
+// validateMcmpApiCallParams 액션 정의의 필수 path/query 파라미터와 요청 본문 누락 여부 확인
+// header/cookie/formData 파라미터는 McmpApiCall에서 전달하지 않으므로 검증하지 않는다.
+func validateMcmpApiCallParams(action *mcmpapi.McmpApiAction, params *model.McmpApiRequestParams) error {
+	var missing []string
+	for _, p := range action.Parameters {
+		if !p.Required {
+			continue
+		}
+		switch p.In {
+		case "path":
+			if params.PathParams[p.Name] == "" {
+				missing = append(missing, "path."+p.Name)
+			}
+		case "query":
+			if _, ok := params.QueryParams[p.Name]; !ok {
+				missing = append(missing, "query."+p.Name)
+			}
+		}
+	}
+	if action.RequestBody != nil && action.RequestBody.Required && params.Body == nil {
+		missing = append(missing, "body")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrMcmpApiMissingParams, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 // SetActiveVersion sets the specified version of a service as active.
 func (s *mcmpApiService) SetActiveVersion(serviceName, version string) error {
 	return s.repo.SetActiveVersion(serviceName, version)
@@ -357,6 +430,11 @@ func (s *mcmpApiService) McmpApiCall(ctx context.Context, req *model.McmpApiCall
 		return http.StatusNotFound, nil, serviceVersion, calledURL, err
 	}
 
+	// 2-1. Validate required parameters declared by the API spec
+	if err := validateMcmpApiCallParams(actionInfo, &req.RequestParams); err != nil {
+		return http.StatusBadRequest, nil, serviceVersion, calledURL, err
+	}
+
 	// 3. Build URL with Path and Query Params
 	targetURL := serviceInfo.BaseURL
 	endpointPath := actionInfo.ResourcePath
@@ -457,14 +535,17 @@ func (s *mcmpApiService) McmpApiCall(ctx context.Context, req *model.McmpApiCall
 
 	// 8. Execute Request
 	client := &http.Client{}
+	callStart := time.Now()
 	resp, err := client.Do(httpReq)
 	if err != nil {
+		metrics.ObserveMcmpApiCall(req.ServiceName, req.ActionName, 0, time.Since(callStart))
 		log.Printf("Error executing request for %s/%s: %v", req.ServiceName, req.ActionName, err)
 		// Return 503 Service Unavailable for network errors?
 		err = fmt.Errorf("error calling external API: %w", err)
 		return http.StatusServiceUnavailable, nil, serviceVersion, calledURL, err
 	}
 	defer resp.Body.Close()
+	metrics.ObserveMcmpApiCall(req.ServiceName, req.ActionName, resp.StatusCode, time.Since(callStart))
 
 	// 9. Read Response Body
 	respBody, ioerr := io.ReadAll(resp.Body) // Assign to the named return variable
@@ -520,10 +601,10 @@ func (s *mcmpApiService) ImportAPIs(req *model.ImportApiRequest) (*model.ImportA
 			var err error
 			if fw.BaseURL != "" {
 				// Use the new method that saves service info
-				err = s.syncFrameworkWithServiceInfo(fw.Name, fw.Version, fw.Repository, fw.BaseURL, fw.AuthType, fw.AuthUser, fw.AuthPass, fwResult.Actions)
+				err = s.syncFrameworkWithServiceInfo(fw.Name, fw.Version, fw.Repository, fw.BaseURL, fw.AuthType, fw.AuthUser, fw.AuthPass, fwResult.Servers, fwResult.Actions)
 			} else {
 				// Use the old method (meta + actions only)
-				err = s.syncFrameworkToDatabase(fw.Name, fw.Version, fw.Repository, fwResult.Actions)
+				err = s.syncFrameworkToDatabase(fw.Name, fw.Version, fw.Repository, fwResult.Servers, fwResult.Actions)
 				log.Printf("Warning: Framework '%s' imported without service info (baseUrl not provided)", fw.Name)
 			}
 
@@ -547,7 +628,7 @@ func (s *mcmpApiService) ImportAPIs(req *model.ImportApiRequest) (*model.ImportA
 }
 
 // syncFrameworkToDatabase saves a single framework's actions to the database
-func (s *mcmpApiService) syncFrameworkToDatabase(serviceName, version, repository string, actions map[string]apiparser.ServiceAction) error {
+func (s *mcmpApiService) syncFrameworkToDatabase(serviceName, version, repository string, servers []string, actions map[string]apiparser.ServiceAction) error {
 	tx := s.db.Begin()
 	if tx.Error != nil {
 		return fmt.Errorf("failed to begin transaction: %w", tx.Error)
@@ -565,6 +646,7 @@ func (s *mcmpApiService) syncFrameworkToDatabase(serviceName, version, repositor
 		Version:     version,
 		Repository:  repository,
 		GeneratedAt: time.Now(),
+		Servers:     servers,
 	}
 	if err := s.repo.UpsertServiceMeta(tx, meta); err != nil {
 		tx.Rollback()
@@ -579,13 +661,7 @@ func (s *mcmpApiService) syncFrameworkToDatabase(serviceName, version, repositor
 
 	// Create new actions
 	for actionName, actionDef := range actions {
-		action := &mcmpapi.McmpApiAction{
-			ServiceName:  serviceName,
-			ActionName:   actionName,
-			Method:       actionDef.Method,
-			ResourcePath: actionDef.ResourcePath,
-			Description:  actionDef.Description,
-		}
+		action := newMcmpApiAction(serviceName, actionName, actionDef)
 
 		if err := s.repo.CreateAction(tx, action); err != nil {
 			tx.Rollback()
@@ -601,7 +677,7 @@ func (s *mcmpApiService) syncFrameworkToDatabase(serviceName, version, repositor
 }
 
 // syncFrameworkWithServiceInfo saves a framework's actions and service info to the database
-func (s *mcmpApiService) syncFrameworkWithServiceInfo(serviceName, version, repository, baseURL, authType, authUser, authPass string, actions map[string]apiparser.ServiceAction) error {
+func (s *mcmpApiService) syncFrameworkWithServiceInfo(serviceName, version, repository, baseURL, authType, authUser, authPass string, servers []string, actions map[string]apiparser.ServiceAction) error {
 	tx := s.db.Begin()
 	if tx.Error != nil {
 		return fmt.Errorf("failed to begin transaction: %w", tx.Error)
@@ -619,6 +695,7 @@ func (s *mcmpApiService) syncFrameworkWithServiceInfo(serviceName, version, repo
 		Version:     version,
 		Repository:  repository,
 		GeneratedAt: time.Now(),
+		Servers:     servers,
 	}
 	if err := s.repo.UpsertServiceMeta(tx, meta); err != nil {
 		tx.Rollback()
@@ -648,13 +725,7 @@ func (s *mcmpApiService) syncFrameworkWithServiceInfo(serviceName, version, repo
 
 	// Create new actions
 	for actionName, actionDef := range actions {
-		action := &mcmpapi.McmpApiAction{
-			ServiceName:  serviceName,
-			ActionName:   actionName,
-			Method:       actionDef.Method,
-			ResourcePath: actionDef.ResourcePath,
-			Description:  actionDef.Description,
-		}
+		action := newMcmpApiAction(serviceName, actionName, actionDef)
 
 		if err := s.repo.CreateAction(tx, action); err != nil {
 			tx.Rollback()
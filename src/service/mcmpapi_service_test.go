@@ -0,0 +1,39 @@
+package service
+
+// mcmpapi_service_test.go
+// MCMP API 호출 전 필수 파라미터 검증 단위 테스트
+
+import (
+	"testing"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/model/mcmpapi"
+	"github.com/m-cmp/mc-iam-manager/pkg/apiparser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMcmpApiCallParams(t *testing.T) {
+	action := &mcmpapi.McmpApiAction{
+		Parameters: []apiparser.ActionParameter{
+			{Name: "nsId", In: "path", Required: true},
+			{Name: "option", In: "query", Required: true},
+			{Name: "filterKey", In: "query"},
+			{Name: "X-Request-Id", In: "header", Required: true},
+		},
+		RequestBody: &apiparser.ActionRequestBody{Required: true, ContentType: "application/json"},
+	}
+
+	err := validateMcmpApiCallParams(action, &model.McmpApiRequestParams{})
+	assert.ErrorIs(t, err, ErrMcmpApiMissingParams)
+	assert.Contains(t, err.Error(), "path.nsId, query.option, body")
+
+	err = validateMcmpApiCallParams(action, &model.McmpApiRequestParams{
+		PathParams:  map[string]string{"nsId": "default"},
+		QueryParams: map[string]string{"option": ""},
+		Body:        map[string]interface{}{"name": "mci01"},
+	})
+	assert.NoError(t, err, "header 파라미터는 검증하지 않는다")
+
+	// 스펙 정보가 없는 기존 액션은 검증하지 않는다
+	assert.NoError(t, validateMcmpApiCallParams(&mcmpapi.McmpApiAction{}, &model.McmpApiRequestParams{}))
+}
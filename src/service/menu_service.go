@@ -130,7 +130,18 @@ func (s *MenuService) GetAllMenusTree(req *model.MenuFilterRequest) ([]*model.Me
 
 // BuildUserMenuTree 사용자의 플랫폼 역할에 따른 메뉴 트리 구성
 func (s *MenuService) BuildUserMenuTree(ctx context.Context, platformRoleIDs []uint) ([]*model.MenuTreeNode, error) {
+	if len(platformRoleIDs) == 0 {
+		return []*model.MenuTreeNode{}, nil
+	}
+	// 상위 역할(parent_id)에 매핑된 메뉴도 상속
+	roleIDs, err := s.roleRepo.ExpandRoleIDs(platformRoleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve role hierarchy: %w", err)
+	}
 	req := &model.MenuMappingFilterRequest{}
+	for _, roleID := range roleIDs {
+		req.RoleIDs = append(req.RoleIDs, strconv.FormatUint(uint64(roleID), 10))
+	}
 	var allMenus []*model.Menu
 
 	// 1. 각 플랫폼 역할에 매핑된 메뉴 ID들을 조회
@@ -198,6 +209,28 @@ func (s *MenuService) BuildUserMenuTree(ctx context.Context, platformRoleIDs []u
 
 // Role에 따른 메뉴 목록록
 func (s *MenuService) MenuList(req *model.MenuMappingFilterRequest) ([]*model.Menu, error) {
+	// 0. 상위 역할(parent_id)에 매핑된 메뉴도 상속
+	if len(req.RoleIDs) > 0 {
+		roleIDs := make([]uint, 0, len(req.RoleIDs))
+		for _, roleID := range req.RoleIDs {
+			id, err := util.StringToUint(roleID)
+			if err != nil {
+				return nil, err
+			}
+			roleIDs = append(roleIDs, id)
+		}
+		expanded, err := s.roleRepo.ExpandRoleIDs(roleIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve role hierarchy: %w", err)
+		}
+		expandedReq := *req
+		expandedReq.RoleIDs = nil
+		for _, id := range expanded {
+			expandedReq.RoleIDs = append(expandedReq.RoleIDs, strconv.FormatUint(uint64(id), 10))
+		}
+		req = &expandedReq
+	}
+
 	// 1. 각 플랫폼 역할에 매핑된 메뉴 ID들을 조회
 	menuIDMap := make(map[string]bool)
 	menuIDs, err := s.menuMappingRepo.FindMappedMenuIDs(req)
@@ -23,6 +23,9 @@ func (m *mockKeycloakService) GetUserByUsername(ctx context.Context, username st
 func (m *mockKeycloakService) GetUsers(ctx context.Context, enabled *bool) ([]*gocloak.User, error) {
 	return nil, nil
 }
+func (m *mockKeycloakService) GetUsersPage(ctx context.Context, first, max int) ([]*gocloak.User, error) {
+	return nil, nil
+}
 func (m *mockKeycloakService) CreateUser(ctx context.Context, user *model.User) (string, error) {
 	return "", nil
 }
@@ -104,8 +107,8 @@ func (m *mockKeycloakService) RemoveRealmRoleFromUser(ctx context.Context, kcUse
 func (m *mockKeycloakService) IsRealmRoleAssignedToUser(ctx context.Context, kcUserId, roleName string) (bool, error) {
 	return false, nil
 }
-func (m *mockKeycloakService) IssueWorkspaceTicket(ctx context.Context, kcUserId string, workspaceID uint) (string, map[string]interface{}, error) {
-	return "", nil, nil
+func (m *mockKeycloakService) GetUserRealmRoles(ctx context.Context, kcUserId string) ([]string, error) {
+	return nil, nil
 }
 func (m *mockKeycloakService) SetupPredefinedRoles(ctx context.Context, accessToken string) error {
 	return nil
@@ -113,9 +116,12 @@ func (m *mockKeycloakService) SetupPredefinedRoles(ctx context.Context, accessTo
 func (m *mockKeycloakService) GetClientCredentialsToken(ctx context.Context) (*gocloak.JWT, error) {
 	return nil, nil
 }
-func (m *mockKeycloakService) CreatePendingUser(ctx context.Context, req *model.SignupRequest) (string, error) {
+func (m *mockKeycloakService) CreatePendingUser(ctx context.Context, req *model.SignupRequest, requireEmailVerification bool) (string, error) {
 	return "", nil
 }
+func (m *mockKeycloakService) SendVerifyEmail(ctx context.Context, kcUserID string) error {
+	return nil
+}
 func (m *mockKeycloakService) ResetPassword(ctx context.Context, kcUserID, newPassword string) error {
 	return nil
 }
@@ -131,3 +137,24 @@ func (m *mockKeycloakService) DeleteGroup(ctx context.Context, groupName string)
 func (m *mockKeycloakService) CheckSAMLClientConfig(ctx context.Context, clientID string) (string, error) {
 	return "", nil
 }
+func (m *mockKeycloakService) GetUserSessions(ctx context.Context, kcUserID string) ([]*gocloak.UserSessionRepresentation, error) {
+	return nil, nil
+}
+func (m *mockKeycloakService) LogoutUserSession(ctx context.Context, sessionID string) error {
+	return nil
+}
+func (m *mockKeycloakService) LogoutAllUserSessions(ctx context.Context, kcUserID string) error {
+	return nil
+}
+func (m *mockKeycloakService) CreateServiceAccountClient(ctx context.Context, clientID, description string) (*model.KeycloakServiceAccountClient, error) {
+	return nil, nil
+}
+func (m *mockKeycloakService) RegenerateServiceAccountClientSecret(ctx context.Context, idOfClient string) (string, error) {
+	return "", nil
+}
+func (m *mockKeycloakService) SetClientEnabled(ctx context.Context, idOfClient string, enabled bool) error {
+	return nil
+}
+func (m *mockKeycloakService) DeleteServiceAccountClient(ctx context.Context, idOfClient string) error {
+	return nil
+}
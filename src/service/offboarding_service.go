@@ -0,0 +1,459 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/config"
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	offboardingActor             = "system:offboarding"
+	tokenRevokedByOffboarding    = "user offboarded"
+	accessRequestCancelledReason = "requester offboarded"
+)
+
+var (
+	// ErrOffboardingNotFound 오프보딩 없음
+	ErrOffboardingNotFound = errors.New("offboarding not found")
+	// ErrOffboardingInvalid 요청 내용 오류 (대상 사용자, 이관 대상, 유예 기간)
+	ErrOffboardingInvalid = errors.New("invalid offboarding request")
+	// ErrOffboardingExists 이미 진행 중인 오프보딩이 있음
+	ErrOffboardingExists = errors.New("user already has an open offboarding")
+	// ErrOffboardingNotOpen 취소/실행할 수 없는 상태
+	ErrOffboardingNotOpen = errors.New("offboarding is not scheduled")
+	// ErrOffboardingBlocked 사전 점검 차단 항목이 남아 있어 실행 불가
+	ErrOffboardingBlocked = errors.New("offboarding is blocked")
+)
+
+// OffboardingService 사용자 오프보딩 서비스
+// 유예 기간 동안 예약 상태로 두었다가, 소유 자원(관리자 역할, 그룹, 초대, 서비스 계정)을 이관하고
+// 캐시된 임시 자격 증명과 세션을 폐기한 뒤 탈퇴(WITHDRAWN) 처리한다.
+type OffboardingService struct {
+	db             *gorm.DB
+	repo           *repository.OffboardingRepository
+	userRepo       *repository.UserRepository
+	roleRepo       *repository.RoleRepository
+	tempCredRepo   *repository.TempCredentialRepository
+	accessRequests *AccessRequestService
+	auditService   *AuditService
+	kcService      KeycloakService
+	revocations    *TokenRevocationService
+}
+
+// NewOffboardingService 새 OffboardingService 인스턴스 생성
+func NewOffboardingService(db *gorm.DB) *OffboardingService {
+	return &OffboardingService{
+		db:             db,
+		repo:           repository.NewOffboardingRepository(db),
+		userRepo:       repository.NewUserRepository(db),
+		roleRepo:       repository.NewRoleRepository(db),
+		tempCredRepo:   repository.NewTempCredentialRepository(db),
+		accessRequests: NewAccessRequestService(db),
+		auditService:   NewAuditService(db),
+		kcService:      NewKeycloakService(),
+		revocations:    NewTokenRevocationService(db),
+	}
+}
+
+// Preview 오프보딩 사전 점검. 이관 대상을 반영해 무엇이 이관/폐기되고 무엇이 깨지는지 보고한다.
+func (s *OffboardingService) Preview(req *model.OffboardingRequest) (*model.OffboardingReport, error) {
+	user, err := s.findOffboardingUser(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildReport(user, req.ReassignToUserID, req.WorkspaceReassignments)
+}
+
+// Schedule 유예 기간 후 실행할 오프보딩 예약. 사전 점검 결과를 함께 저장한다.
+func (s *OffboardingService) Schedule(req *model.OffboardingRequest, requestedBy string) (*model.Offboarding, error) {
+	graceDays := config.OffboardingGraceDays()
+	if req.GraceDays != nil {
+		if *req.GraceDays < 0 {
+			return nil, fmt.Errorf("%w: graceDays must not be negative", ErrOffboardingInvalid)
+		}
+		graceDays = *req.GraceDays
+	}
+	user, err := s.findOffboardingUser(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	open, err := s.repo.FindOpenByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if open != nil {
+		return nil, fmt.Errorf("%w (offboarding %d)", ErrOffboardingExists, open.ID)
+	}
+	report, err := s.buildReport(user, req.ReassignToUserID, req.WorkspaceReassignments)
+	if err != nil {
+		return nil, err
+	}
+
+	offboarding := &model.Offboarding{
+		UserID:                 user.ID,
+		KcUserID:               user.KcId,
+		Status:                 model.OffboardingStatusScheduled,
+		ReassignToUserID:       req.ReassignToUserID,
+		WorkspaceReassignments: req.WorkspaceReassignments,
+		Reason:                 req.Reason,
+		RequestedBy:            requestedBy,
+		ScheduledAt:            time.Now().UTC().AddDate(0, 0, graceDays),
+		Report:                 report,
+	}
+	if err := s.repo.Create(offboarding); err != nil {
+		return nil, fmt.Errorf("failed to schedule offboarding: %w", err)
+	}
+	return offboarding, nil
+}
+
+// ListOffboardings 오프보딩 목록 조회
+func (s *OffboardingService) ListOffboardings(filter *model.OffboardingFilterRequest) ([]model.Offboarding, error) {
+	return s.repo.List(filter)
+}
+
+// GetOffboarding 오프보딩 상세 조회
+func (s *OffboardingService) GetOffboarding(id uint) (*model.Offboarding, error) {
+	return s.findOffboarding(id)
+}
+
+// CancelOffboarding 예약(또는 실행 실패) 상태의 오프보딩 취소
+func (s *OffboardingService) CancelOffboarding(id uint) (*model.Offboarding, error) {
+	offboarding, err := s.findOffboarding(id)
+	if err != nil {
+		return nil, err
+	}
+	offboarding.Status = model.OffboardingStatusCancelled
+	if err := s.updateFromOpen(offboarding); err != nil {
+		return nil, err
+	}
+	return offboarding, nil
+}
+
+// ExecuteNow 유예 기간과 관계없이 즉시 최종 실행 (예약 또는 실행 실패 상태)
+func (s *OffboardingService) ExecuteNow(ctx context.Context, id uint) (*model.Offboarding, error) {
+	offboarding, err := s.findOffboarding(id)
+	if err != nil {
+		return nil, err
+	}
+	if offboarding.Status != model.OffboardingStatusScheduled && offboarding.Status != model.OffboardingStatusFailed {
+		return nil, ErrOffboardingNotOpen
+	}
+	offboarding, err = s.execute(ctx, offboarding)
+	if err != nil {
+		return nil, err
+	}
+	s.recordAudit(offboarding)
+	return offboarding, nil
+}
+
+// Run interval마다 ExecuteDue 실행. ctx가 취소되면 종료
+func (s *OffboardingService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.ExecuteDue(ctx); err != nil {
+			log.Printf("[WARN] offboarding worker: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ExecuteDue 유예 기간이 지난 예약 오프보딩 실행. 완료된 오프보딩 목록 반환
+// 차단 항목이 남았거나 실패한 오프보딩은 FAILED로 남겨 관리자가 확인 후 재실행/취소한다.
+func (s *OffboardingService) ExecuteDue(ctx context.Context) ([]model.Offboarding, error) {
+	due, err := s.repo.FindDue(time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	completed := make([]model.Offboarding, 0, len(due))
+	var failed int
+	for i := range due {
+		offboarding, err := s.execute(ctx, &due[i])
+		if err != nil {
+			log.Printf("[WARN] failed to execute offboarding %d (userID=%d): %v", due[i].ID, due[i].UserID, err)
+			failed++
+			continue
+		}
+		s.recordAudit(offboarding)
+		completed = append(completed, *offboarding)
+	}
+	if len(completed) > 0 {
+		log.Printf("Executed %d scheduled offboardings", len(completed))
+	}
+	if failed > 0 {
+		return completed, fmt.Errorf("%d scheduled offboardings failed", failed)
+	}
+	return completed, nil
+}
+
+// execute 최종 실행: 사전 점검 재수행 → 소유 자원 이관 → 대기 요청 취소/자격 증명 폐기 → 탈퇴 처리
+func (s *OffboardingService) execute(ctx context.Context, offboarding *model.Offboarding) (*model.Offboarding, error) {
+	user, err := s.findOffboardingUser(offboarding.UserID)
+	if err != nil {
+		return nil, s.markFailed(offboarding, nil, err)
+	}
+	report, err := s.buildReport(user, offboarding.ReassignToUserID, offboarding.WorkspaceReassignments)
+	if err != nil {
+		return nil, s.markFailed(offboarding, nil, err)
+	}
+	if len(report.Blockers) > 0 {
+		return nil, s.markFailed(offboarding, report,
+			fmt.Errorf("%w: %s", ErrOffboardingBlocked, strings.Join(report.Blockers, "; ")))
+	}
+	if err := s.transfer(report); err != nil {
+		return nil, s.markFailed(offboarding, report, err)
+	}
+
+	for _, requestID := range report.PendingAccessRequests {
+		if _, err := s.accessRequests.CancelAccessRequest(requestID, user.ID, accessRequestCancelledReason); err != nil &&
+			!errors.Is(err, ErrAccessRequestNotPending) {
+			log.Printf("[WARN] failed to cancel access request %d of offboarded user %d: %v", requestID, user.ID, err)
+		}
+	}
+	if user.KcId != "" {
+		if _, err := s.tempCredRepo.RevokeCachedCredentials(user.KcId, nil); err != nil {
+			return nil, s.markFailed(offboarding, report, err)
+		}
+	}
+	if err := s.withdraw(ctx, user); err != nil {
+		return nil, s.markFailed(offboarding, report, err)
+	}
+
+	now := time.Now().UTC()
+	offboarding.Status = model.OffboardingStatusCompleted
+	offboarding.ExecutedAt = &now
+	offboarding.Report = report
+	offboarding.LastError = ""
+	if err := s.updateFromOpen(offboarding); err != nil {
+		return nil, err
+	}
+	return offboarding, nil
+}
+
+// transfer 이관 대상 사용자의 Keycloak ID를 조회해 소유 자원 이관
+func (s *OffboardingService) transfer(report *model.OffboardingReport) error {
+	var adminRoleID uint
+	if len(report.SoleAdminWorkspaces) > 0 {
+		adminRole, err := s.roleRepo.FindRoleByRoleName(workspaceAdminRoleName, constants.RoleTypeWorkspace)
+		if err != nil {
+			return err
+		}
+		if adminRole == nil {
+			return fmt.Errorf("workspace role %q not found", workspaceAdminRoleName)
+		}
+		adminRoleID = adminRole.ID
+	}
+
+	kcIDs := make(map[uint]string)
+	for _, account := range report.OwnedServiceAccounts {
+		if account.ReassignToUserID == nil {
+			continue
+		}
+		if _, ok := kcIDs[*account.ReassignToUserID]; ok {
+			continue
+		}
+		target, err := s.userRepo.FindUserByID(*account.ReassignToUserID)
+		if err != nil {
+			return err
+		}
+		kcIDs[target.ID] = target.KcId
+	}
+	return s.repo.TransferOwnership(report, adminRoleID, kcIDs)
+}
+
+// withdraw 역할/조직 매핑 삭제, Keycloak 비활성화, WITHDRAWN 처리 후 세션 폐기 (ProcessWithdrawal과 동일한 최종 단계)
+func (s *OffboardingService) withdraw(ctx context.Context, user *model.User) error {
+	return withdrawUser(ctx, s.userRepo, s.kcService, s.revocations, user, tokenRevokedByOffboarding, offboardingActor)
+}
+
+// buildReport 사용자가 소유한 자원과 이관 대상을 조회해 사전 점검 결과 생성
+func (s *OffboardingService) buildReport(user *model.User, reassignTo *uint, overrides []model.OffboardingReassignment) (*model.OffboardingReport, error) {
+	if err := s.validateTarget(user.ID, reassignTo); err != nil {
+		return nil, err
+	}
+	workspaceTargets := make(map[uint]*uint, len(overrides))
+	for i := range overrides {
+		target := overrides[i].UserID
+		if err := s.validateTarget(user.ID, &target); err != nil {
+			return nil, err
+		}
+		workspaceTargets[overrides[i].WorkspaceID] = &target
+	}
+
+	report := &model.OffboardingReport{
+		UserID:                user.ID,
+		Username:              user.Username,
+		SoleAdminWorkspaces:   []model.OffboardingWorkspaceTransfer{},
+		SoleMemberGroups:      []model.OffboardingGroupTransfer{},
+		PendingInvitations:    []model.OffboardingInvitation{},
+		PendingAccessRequests: []uint{},
+		OwnedServiceAccounts:  []model.OffboardingServiceAccount{},
+	}
+
+	workspaces, err := s.repo.FindSoleAdminWorkspaces(user.ID, workspaceAdminRoleName)
+	if err != nil {
+		return nil, err
+	}
+	for _, ws := range workspaces {
+		target, ok := workspaceTargets[ws.ID]
+		if !ok {
+			target = reassignTo
+		}
+		report.SoleAdminWorkspaces = append(report.SoleAdminWorkspaces, model.OffboardingWorkspaceTransfer{
+			WorkspaceID: ws.ID, WorkspaceName: ws.Name, ReassignToUserID: target,
+		})
+		if target == nil {
+			report.Blockers = append(report.Blockers,
+				fmt.Sprintf("workspace %q (id=%d) would be left without an admin", ws.Name, ws.ID))
+		}
+	}
+
+	groups, err := s.repo.FindSoleMemberGroups(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		report.SoleMemberGroups = append(report.SoleMemberGroups, model.OffboardingGroupTransfer{
+			OrganizationID: group.ID, Name: group.Name, ReassignToUserID: reassignTo,
+		})
+		if reassignTo == nil {
+			report.Warnings = append(report.Warnings,
+				fmt.Sprintf("group %q (id=%d) would be left without members", group.Name, group.ID))
+		}
+	}
+
+	invitations, err := s.repo.FindPendingInvitationsByInviter(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, inv := range invitations {
+		report.PendingInvitations = append(report.PendingInvitations, model.OffboardingInvitation{
+			InvitationID: inv.ID, WorkspaceID: inv.WorkspaceID, InviteeUserID: inv.InviteeUserID, ReassignToUserID: reassignTo,
+		})
+	}
+	if len(invitations) > 0 && reassignTo == nil {
+		report.Warnings = append(report.Warnings,
+			fmt.Sprintf("%d pending invitations will be rejected", len(invitations)))
+	}
+
+	if report.PendingAccessRequests, err = s.repo.FindPendingAccessRequestIDs(user.ID); err != nil {
+		return nil, err
+	}
+
+	accounts, err := s.repo.FindServiceAccountsByCreator(user.KcId)
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range accounts {
+		report.OwnedServiceAccounts = append(report.OwnedServiceAccounts, model.OffboardingServiceAccount{
+			ServiceAccountID: account.ID, Name: account.Name, ReassignToUserID: reassignTo,
+		})
+		if reassignTo == nil {
+			report.Warnings = append(report.Warnings,
+				fmt.Sprintf("service account %q keeps the offboarded user as owner", account.Name))
+		}
+	}
+
+	if report.CachedCredentialCount, err = s.repo.CountCachedCredentials(user.KcId); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// validateTarget 이관 대상은 오프보딩 대상이 아닌 활성 사용자여야 한다
+func (s *OffboardingService) validateTarget(userID uint, target *uint) error {
+	if target == nil {
+		return nil
+	}
+	if *target == userID {
+		return fmt.Errorf("%w: cannot reassign to the offboarded user", ErrOffboardingInvalid)
+	}
+	user, err := s.userRepo.FindUserByID(*target)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return fmt.Errorf("%w: reassignment target user %d not found", ErrOffboardingInvalid, *target)
+		}
+		return err
+	}
+	if user.Status != "" && user.Status != model.UserStatusActive {
+		return fmt.Errorf("%w: reassignment target user %d is %s", ErrOffboardingInvalid, *target, user.Status)
+	}
+	return nil
+}
+
+func (s *OffboardingService) findOffboardingUser(userID uint) (*model.User, error) {
+	user, err := s.userRepo.FindUserByID(userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, fmt.Errorf("%w: user %d not found", ErrOffboardingInvalid, userID)
+		}
+		return nil, err
+	}
+	if user.Status == model.UserStatusWithdrawn {
+		return nil, fmt.Errorf("%w: user %d is already withdrawn", ErrOffboardingInvalid, userID)
+	}
+	return user, nil
+}
+
+func (s *OffboardingService) findOffboarding(id uint) (*model.Offboarding, error) {
+	offboarding, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOffboardingNotFound
+		}
+		return nil, err
+	}
+	return offboarding, nil
+}
+
+// markFailed 실행 실패 기록 후 원래 오류 반환
+func (s *OffboardingService) markFailed(offboarding *model.Offboarding, report *model.OffboardingReport, cause error) error {
+	offboarding.Status = model.OffboardingStatusFailed
+	offboarding.LastError = cause.Error()
+	if report != nil {
+		offboarding.Report = report
+	}
+	if err := s.updateFromOpen(offboarding); err != nil {
+		log.Printf("[WARN] failed to record offboarding %d failure: %v", offboarding.ID, err)
+	}
+	return cause
+}
+
+func (s *OffboardingService) updateFromOpen(offboarding *model.Offboarding) error {
+	updated, err := s.repo.UpdateFromStatus(offboarding, model.OffboardingStatusScheduled, model.OffboardingStatusFailed)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrOffboardingNotOpen
+	}
+	return nil
+}
+
+// recordAudit 오프보딩 실행(예약/즉시) 감사 이벤트 기록 (실패해도 실행 결과는 유지)
+func (s *OffboardingService) recordAudit(offboarding *model.Offboarding) {
+	event := &model.AuditEvent{
+		ActorKcUserID: offboardingActor,
+		Action:        "user.offboard",
+		TargetType:    "user",
+		TargetID:      fmt.Sprint(offboarding.UserID),
+		After:         ToAuditSnapshot(offboarding),
+	}
+	if err := s.auditService.Record(event); err != nil {
+		log.Printf("[WARN] %v", err)
+	}
+}
@@ -0,0 +1,269 @@
+package service
+
+// offboarding_service_test.go
+// 사용자 오프보딩(사전 점검, 예약, 이관 및 최종 실행) 단위 테스트 (SQLite in-memory DB)
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// offboardingKeycloakService 사용자 비활성화 호출을 기록하는 KeycloakService 스텁
+type offboardingKeycloakService struct {
+	mockKeycloakService
+	disabled []string
+}
+
+func (m *offboardingKeycloakService) DisableUser(ctx context.Context, kcUserID string) error {
+	m.disabled = append(m.disabled, kcUserID)
+	return nil
+}
+
+type offboardingFixture struct {
+	db        *gorm.DB
+	svc       *OffboardingService
+	kc        *offboardingKeycloakService
+	leaver    *model.User
+	successor *model.User
+	coAdmin   *model.User
+	soleWs    *model.Workspace
+	sharedWs  *model.Workspace
+	group     *model.Organization
+	admin     *model.RoleMaster
+}
+
+func newOffboardingFixture(t *testing.T) *offboardingFixture {
+	t.Helper()
+	t.Setenv("MC_IAM_MANAGER_OFFBOARDING_GRACE_DAYS", "")
+	db := setupGroupRoleTestDB(t)
+	require.NoError(t, db.AutoMigrate(
+		&model.Offboarding{},
+		&model.WorkspaceInvitation{},
+		&model.AccessRequest{},
+		&model.AccessRequestEvent{},
+		&model.ServiceAccount{},
+		&model.TempCredential{},
+		&model.TokenRevocation{},
+		&model.AuditEvent{},
+	))
+
+	kc := &offboardingKeycloakService{}
+	svc := NewOffboardingService(db)
+	svc.kcService = kc
+	svc.revocations.keycloakService = kc
+
+	f := &offboardingFixture{
+		db:        db,
+		svc:       svc,
+		kc:        kc,
+		leaver:    createGRTestUser(t, db, "leaver", "kc-leaver"),
+		successor: createGRTestUser(t, db, "successor", "kc-successor"),
+		coAdmin:   createGRTestUser(t, db, "co-admin", "kc-co-admin"),
+		soleWs:    createGRTestWorkspace(t, db, "ws-sole"),
+		sharedWs:  createGRTestWorkspace(t, db, "ws-shared"),
+		group:     createGRTestOrg(t, db, "leaver-team", "LT01"),
+		admin:     createGRTestRole(t, db, workspaceAdminRoleName),
+	}
+
+	roleRepo := repository.NewRoleRepository(db)
+	require.NoError(t, roleRepo.AssignWorkspaceRole(f.leaver.ID, f.soleWs.ID, f.admin.ID))
+	require.NoError(t, roleRepo.AssignWorkspaceRole(f.leaver.ID, f.sharedWs.ID, f.admin.ID))
+	require.NoError(t, roleRepo.AssignWorkspaceRole(f.coAdmin.ID, f.sharedWs.ID, f.admin.ID))
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: f.leaver.ID, OrganizationID: f.group.ID}).Error)
+
+	require.NoError(t, db.Create(&model.WorkspaceInvitation{
+		WorkspaceID: f.soleWs.ID, InviterUserID: f.leaver.ID, InviteeUserID: f.coAdmin.ID, Status: model.InvitationStatusPending,
+	}).Error)
+	require.NoError(t, db.Create(&model.AccessRequest{
+		RequesterUserID: f.leaver.ID, Type: model.AccessRequestTypeWorkspaceRole, WorkspaceID: f.sharedWs.ID,
+		RoleID: f.admin.ID, DurationHours: 1, Justification: "incident", Status: model.AccessRequestStatusPendingApproval,
+	}).Error)
+	require.NoError(t, db.Create(&model.ServiceAccount{
+		Name: "leaver-bot", AuthType: model.ServiceAccountAuthApiKey, UserID: 999, KcUserID: "kc-bot", Enabled: true, CreatedBy: "kc-leaver",
+	}).Error)
+	return f
+}
+
+func (f *offboardingFixture) request(reassignTo *uint) *model.OffboardingRequest {
+	return &model.OffboardingRequest{UserID: f.leaver.ID, ReassignToUserID: reassignTo}
+}
+
+func TestOffboardingService_PreviewReportsWhatWouldBreak(t *testing.T) {
+	f := newOffboardingFixture(t)
+
+	report, err := f.svc.Preview(f.request(nil))
+	require.NoError(t, err)
+
+	require.Len(t, report.SoleAdminWorkspaces, 1, "다른 관리자가 있는 워크스페이스는 제외")
+	assert.Equal(t, f.soleWs.ID, report.SoleAdminWorkspaces[0].WorkspaceID)
+	require.Len(t, report.Blockers, 1)
+	assert.Contains(t, report.Blockers[0], "ws-sole")
+	require.Len(t, report.SoleMemberGroups, 1)
+	assert.Equal(t, f.group.ID, report.SoleMemberGroups[0].OrganizationID)
+	assert.Len(t, report.PendingInvitations, 1)
+	assert.Len(t, report.PendingAccessRequests, 1)
+	require.Len(t, report.OwnedServiceAccounts, 1)
+	assert.Equal(t, "leaver-bot", report.OwnedServiceAccounts[0].Name)
+	assert.NotEmpty(t, report.Warnings)
+
+	report, err = f.svc.Preview(f.request(&f.successor.ID))
+	require.NoError(t, err)
+	assert.Empty(t, report.Blockers)
+	require.NotNil(t, report.SoleAdminWorkspaces[0].ReassignToUserID)
+	assert.Equal(t, f.successor.ID, *report.SoleAdminWorkspaces[0].ReassignToUserID)
+}
+
+func TestOffboardingService_PreviewWorkspaceOverride(t *testing.T) {
+	f := newOffboardingFixture(t)
+	req := f.request(nil)
+	req.WorkspaceReassignments = []model.OffboardingReassignment{{WorkspaceID: f.soleWs.ID, UserID: f.coAdmin.ID}}
+
+	report, err := f.svc.Preview(req)
+	require.NoError(t, err)
+	assert.Empty(t, report.Blockers)
+	assert.Equal(t, f.coAdmin.ID, *report.SoleAdminWorkspaces[0].ReassignToUserID)
+}
+
+func TestOffboardingService_ValidatesTargets(t *testing.T) {
+	f := newOffboardingFixture(t)
+
+	_, err := f.svc.Preview(f.request(&f.leaver.ID))
+	assert.ErrorIs(t, err, ErrOffboardingInvalid)
+
+	missing := uint(9999)
+	_, err = f.svc.Preview(f.request(&missing))
+	assert.ErrorIs(t, err, ErrOffboardingInvalid)
+
+	require.NoError(t, repository.NewUserRepository(f.db).UpdateStatus(f.successor.ID, model.UserStatusWithdrawn))
+	_, err = f.svc.Preview(f.request(&f.successor.ID))
+	assert.ErrorIs(t, err, ErrOffboardingInvalid)
+
+	_, err = f.svc.Preview(&model.OffboardingRequest{UserID: f.successor.ID})
+	assert.ErrorIs(t, err, ErrOffboardingInvalid, "이미 탈퇴한 사용자는 오프보딩 불가")
+}
+
+func TestOffboardingService_ScheduleUsesGracePeriod(t *testing.T) {
+	f := newOffboardingFixture(t)
+	t.Setenv("MC_IAM_MANAGER_OFFBOARDING_GRACE_DAYS", "3")
+
+	negative := -1
+	req := f.request(&f.successor.ID)
+	req.GraceDays = &negative
+	_, err := f.svc.Schedule(req, "kc-admin")
+	assert.ErrorIs(t, err, ErrOffboardingInvalid)
+
+	before := time.Now().UTC()
+	offboarding, err := f.svc.Schedule(f.request(&f.successor.ID), "kc-admin")
+	require.NoError(t, err)
+	assert.Equal(t, model.OffboardingStatusScheduled, offboarding.Status)
+	assert.WithinDuration(t, before.AddDate(0, 0, 3), offboarding.ScheduledAt, time.Minute)
+	require.NotNil(t, offboarding.Report)
+	assert.Len(t, offboarding.Report.SoleAdminWorkspaces, 1)
+
+	_, err = f.svc.Schedule(f.request(&f.successor.ID), "kc-admin")
+	assert.ErrorIs(t, err, ErrOffboardingExists)
+
+	// 유예 기간 중에는 실행되지 않음
+	completed, err := f.svc.ExecuteDue(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, completed)
+
+	cancelled, err := f.svc.CancelOffboarding(offboarding.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.OffboardingStatusCancelled, cancelled.Status)
+	_, err = f.svc.CancelOffboarding(offboarding.ID)
+	assert.ErrorIs(t, err, ErrOffboardingNotOpen)
+}
+
+func TestOffboardingService_ExecuteDueTransfersAndWithdraws(t *testing.T) {
+	f := newOffboardingFixture(t)
+	noGrace := 0
+	req := f.request(&f.successor.ID)
+	req.GraceDays = &noGrace
+	offboarding, err := f.svc.Schedule(req, "kc-admin")
+	require.NoError(t, err)
+
+	completed, err := f.svc.ExecuteDue(context.Background())
+	require.NoError(t, err)
+	require.Len(t, completed, 1)
+	assert.Equal(t, offboarding.ID, completed[0].ID)
+
+	stored, err := f.svc.GetOffboarding(offboarding.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.OffboardingStatusCompleted, stored.Status)
+	assert.NotNil(t, stored.ExecutedAt)
+
+	// 워크스페이스 관리자 / 그룹 구성원 이관
+	adminWorkspaces, err := repository.NewAccessRequestRepository(f.db).FindAdminWorkspaceIDs(f.successor.ID, workspaceAdminRoleName)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{f.soleWs.ID}, adminWorkspaces)
+	var membership int64
+	require.NoError(t, f.db.Model(&model.UserOrganization{}).
+		Where("user_id = ? AND organization_id = ?", f.successor.ID, f.group.ID).Count(&membership).Error)
+	assert.Equal(t, int64(1), membership)
+
+	// 초대 발신자, 서비스 계정 소유자 이관 및 대기 요청 취소
+	var invitation model.WorkspaceInvitation
+	require.NoError(t, f.db.First(&invitation).Error)
+	assert.Equal(t, f.successor.ID, invitation.InviterUserID)
+	assert.Equal(t, model.InvitationStatusPending, invitation.Status)
+	var account model.ServiceAccount
+	require.NoError(t, f.db.First(&account).Error)
+	assert.Equal(t, "kc-successor", account.CreatedBy)
+	var accessRequest model.AccessRequest
+	require.NoError(t, f.db.First(&accessRequest).Error)
+	assert.Equal(t, model.AccessRequestStatusCancelled, accessRequest.Status)
+
+	// 탈퇴 처리
+	leaver, err := repository.NewUserRepository(f.db).FindUserByID(f.leaver.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.UserStatusWithdrawn, leaver.Status)
+	var remaining int64
+	require.NoError(t, f.db.Model(&model.UserWorkspaceRole{}).Where("user_id = ?", f.leaver.ID).Count(&remaining).Error)
+	assert.Zero(t, remaining)
+	assert.Equal(t, []string{"kc-leaver"}, f.kc.disabled)
+
+	var audits int64
+	require.NoError(t, f.db.Model(&model.AuditEvent{}).Where("action = ? AND target_id = ?", "user.offboard", "1").Count(&audits).Error)
+	assert.Equal(t, int64(1), audits)
+}
+
+func TestOffboardingService_BlockedExecutionFails(t *testing.T) {
+	f := newOffboardingFixture(t)
+	noGrace := 0
+	req := f.request(nil)
+	req.GraceDays = &noGrace
+	offboarding, err := f.svc.Schedule(req, "kc-admin")
+	require.NoError(t, err)
+
+	_, err = f.svc.ExecuteNow(context.Background(), offboarding.ID)
+	assert.ErrorIs(t, err, ErrOffboardingBlocked)
+
+	stored, err := f.svc.GetOffboarding(offboarding.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.OffboardingStatusFailed, stored.Status)
+	assert.Contains(t, stored.LastError, "ws-sole")
+	leaver, err := repository.NewUserRepository(f.db).FindUserByID(f.leaver.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.UserStatusActive, leaver.Status)
+	assert.Empty(t, f.kc.disabled)
+
+	// 다른 관리자가 생기면 재실행 가능 (이관 대상 없는 초대는 거절)
+	require.NoError(t, repository.NewRoleRepository(f.db).AssignWorkspaceRole(f.coAdmin.ID, f.soleWs.ID, f.admin.ID))
+	executed, err := f.svc.ExecuteNow(context.Background(), offboarding.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.OffboardingStatusCompleted, executed.Status)
+	var audits int64
+	require.NoError(t, f.db.Model(&model.AuditEvent{}).Where("action = ? AND target_id = ?", "user.offboard", "1").Count(&audits).Error)
+	assert.Equal(t, int64(1), audits)
+	var invitation model.WorkspaceInvitation
+	require.NoError(t, f.db.First(&invitation).Error)
+	assert.Equal(t, model.InvitationStatusRejected, invitation.Status)
+}
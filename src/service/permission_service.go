@@ -4,12 +4,17 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"strings"
 
 	// "errors" // Removed unused import
 
 	"github.com/m-cmp/mc-iam-manager/constants"
 	"github.com/m-cmp/mc-iam-manager/model"
 	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/m-cmp/mc-iam-manager/util"
+	"gopkg.in/yaml.v3"
 	"gorm.io/gorm" // Import gorm
 )
 
@@ -93,6 +98,97 @@ func (s *MciamPermissionService) GetRoleMciamPermissions(ctx context.Context, ro
 	return s.permissionRepo.GetRoleMciamPermissions(roleType, roleID) // Use renamed repo method
 }
 
+// --- 프레임워크 자체 권한 시드 ---
+
+// LoadAndRegisterFrameworkPermissionsFromYAML YAML 파일에서 mc-iam-manager 자체 리소스 유형/권한과 기본 역할 매핑을 등록
+// filePath가 빈 문자열이면 기본 경로(asset/permission/mc-iam-manager.yaml) 사용
+// 파일이 없으면 WARN 로그 후 skip (soft failure)
+func (s *MciamPermissionService) LoadAndRegisterFrameworkPermissionsFromYAML(filePath string) error {
+	effectivePath := filePath
+	if effectivePath == "" {
+		effectivePath = filepath.Join(util.GetAssetPath(), "permission", "mc-iam-manager.yaml")
+	}
+
+	data, err := os.ReadFile(effectivePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			log.Printf("[WARN] Permission seed file not found: %s, skipping", effectivePath)
+			return nil
+		}
+		return fmt.Errorf("failed to read permission seed file %s: %w", effectivePath, err)
+	}
+
+	var seedData model.MciamPermissionSeedData
+	if err := yaml.Unmarshal(data, &seedData); err != nil {
+		return fmt.Errorf("failed to parse permission seed YAML: %w", err)
+	}
+	resourceTypes, permissions, err := buildFrameworkPermissionSeed(&seedData)
+	if err != nil {
+		return err
+	}
+
+	assigned, err := s.permissionRepo.SeedFrameworkPermissions(seedData.FrameworkID, resourceTypes, permissions, seedData.RolePermissions)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Registered %d resource types, %d permissions for %s (%d default role mappings added)",
+		len(resourceTypes), len(permissions), seedData.FrameworkID, assigned)
+	return nil
+}
+
+// buildFrameworkPermissionSeed 시드 데이터를 리소스 유형/권한 모델로 변환하고 역할 매핑의 권한 ID를 검증
+func buildFrameworkPermissionSeed(seedData *model.MciamPermissionSeedData) ([]model.ResourceType, []model.MciamPermission, error) {
+	if seedData.FrameworkID == "" {
+		return nil, nil, fmt.Errorf("framework_id is required in permission seed")
+	}
+
+	var resourceTypes []model.ResourceType
+	var permissions []model.MciamPermission
+	known := make(map[string]struct{})
+	for _, rt := range seedData.ResourceTypes {
+		if rt.ID == "" {
+			return nil, nil, fmt.Errorf("resource type id is required in permission seed")
+		}
+		name := rt.Name
+		if name == "" {
+			name = rt.ID
+		}
+		resourceTypes = append(resourceTypes, model.ResourceType{
+			FrameworkID: seedData.FrameworkID,
+			ID:          rt.ID,
+			Name:        name,
+			Description: rt.Description,
+		})
+		for _, action := range rt.Actions {
+			if action == "" {
+				return nil, nil, fmt.Errorf("empty action for resource type %s in permission seed", rt.ID)
+			}
+			id := fmt.Sprintf("%s:%s:%s", seedData.FrameworkID, rt.ID, action)
+			known[id] = struct{}{}
+			permissions = append(permissions, model.MciamPermission{
+				ID:             id,
+				FrameworkID:    seedData.FrameworkID,
+				ResourceTypeID: rt.ID,
+				Action:         action,
+				Name:           fmt.Sprintf("%s %s", strings.ToUpper(action[:1])+action[1:], name),
+				Description:    fmt.Sprintf("Permission to %s %s in %s.", action, name, seedData.FrameworkID),
+			})
+		}
+	}
+
+	for _, seed := range seedData.RolePermissions {
+		if seed.RoleType != constants.RoleTypePlatform && seed.RoleType != constants.RoleTypeWorkspace {
+			return nil, nil, fmt.Errorf("invalid role_type %q for role %s in permission seed", seed.RoleType, seed.Role)
+		}
+		for _, id := range seed.Permissions {
+			if _, ok := known[id]; !ok {
+				return nil, nil, fmt.Errorf("unknown permission %s for role %s in permission seed", id, seed.Role)
+			}
+		}
+	}
+	return resourceTypes, permissions, nil
+}
+
 // Note: Need similar service for CSP permissions and role-csp mappings later.
 
 func (s *MciamPermissionService) checkPermission(ctx context.Context, userID uint, workspaceID string, requiredPermission string) error {
@@ -0,0 +1,129 @@
+package service
+
+// permission_service_test.go
+// mc-iam-manager 자체 리소스 유형/권한 시드 단위 테스트 (SQLite in-memory DB)
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+const testPermissionSeed = `
+framework_id: mc-iam-manager
+resource_types:
+  - id: workspace
+    name: Workspace
+    actions: [read, update]
+  - id: audit
+    name: Audit Event
+    actions: [read]
+role_permissions:
+  - role: admin
+    role_type: platform
+    permissions: [mc-iam-manager:workspace:read, mc-iam-manager:workspace:update]
+  - role: viewer
+    role_type: workspace
+    permissions: [mc-iam-manager:workspace:read]
+`
+
+func newTestPermissionService(t *testing.T) (*MciamPermissionService, *gorm.DB) {
+	t.Helper()
+	db := setupGroupRoleTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.MciamRoleMciamPermission{}))
+	// ResourceType, MciamPermission 모델의 default:now()는 SQLite에서 지원되지 않으므로 직접 생성
+	require.NoError(t, db.Exec(`CREATE TABLE mcmp_resource_types (
+		framework_id varchar(100) NOT NULL,
+		id varchar(100) NOT NULL,
+		name varchar(255) NOT NULL,
+		description varchar(1000),
+		created_at datetime,
+		updated_at datetime,
+		PRIMARY KEY (framework_id, id)
+	)`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE mcmp_mciam_permissions (
+		id varchar(255) PRIMARY KEY,
+		framework_id varchar(100) NOT NULL,
+		resource_type_id varchar(100) NOT NULL,
+		action varchar(100) NOT NULL,
+		name varchar(100) NOT NULL,
+		description varchar(1000),
+		created_at datetime,
+		updated_at datetime
+	)`).Error)
+	return NewMciamPermissionService(db), db
+}
+
+func writeTestPermissionSeed(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "mc-iam-manager.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadFrameworkPermissions_SeedsOnceAndKeepsCustomMappings(t *testing.T) {
+	svc, db := newTestPermissionService(t)
+	admin := createGRTestRole(t, db, "admin")
+	path := writeTestPermissionSeed(t, testPermissionSeed)
+
+	require.NoError(t, svc.LoadAndRegisterFrameworkPermissionsFromYAML(path))
+
+	var types []model.ResourceType
+	require.NoError(t, db.Find(&types).Error)
+	assert.Len(t, types, 2)
+	var perm model.MciamPermission
+	require.NoError(t, db.First(&perm, "id = ?", "mc-iam-manager:workspace:update").Error)
+	assert.Equal(t, "Update Workspace", perm.Name)
+	assert.Equal(t, "workspace", perm.ResourceTypeID)
+
+	adminPerms, err := svc.permissionRepo.GetRoleMciamPermissions(constants.RoleTypePlatform, admin.ID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"mc-iam-manager:workspace:read", "mc-iam-manager:workspace:update"}, adminPerms)
+
+	// 관리자가 조정한 매핑은 다시 시드해도 복원하지 않음. 나중에 생성된 역할에는 기본 매핑 적용
+	require.NoError(t, db.Where("role_id = ? AND permission_id = ?", admin.ID, "mc-iam-manager:workspace:update").
+		Delete(&model.MciamRoleMciamPermission{}).Error)
+	viewer := createGRTestRole(t, db, "viewer")
+	require.NoError(t, svc.LoadAndRegisterFrameworkPermissionsFromYAML(path))
+
+	adminPerms, err = svc.permissionRepo.GetRoleMciamPermissions(constants.RoleTypePlatform, admin.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mc-iam-manager:workspace:read"}, adminPerms)
+	viewerPerms, err := svc.permissionRepo.GetRoleMciamPermissions(constants.RoleTypeWorkspace, viewer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mc-iam-manager:workspace:read"}, viewerPerms)
+	viewerPlatformPerms, err := svc.permissionRepo.GetRoleMciamPermissions(constants.RoleTypePlatform, viewer.ID)
+	require.NoError(t, err)
+	assert.Empty(t, viewerPlatformPerms)
+}
+
+func TestLoadFrameworkPermissions_RejectsUnknownPermissionAndMissingFile(t *testing.T) {
+	svc, _ := newTestPermissionService(t)
+
+	path := writeTestPermissionSeed(t, testPermissionSeed+`
+  - role: operator
+    role_type: platform
+    permissions: [mc-iam-manager:project:delete]
+`)
+	err := svc.LoadAndRegisterFrameworkPermissionsFromYAML(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown permission mc-iam-manager:project:delete")
+
+	assert.NoError(t, svc.LoadAndRegisterFrameworkPermissionsFromYAML(filepath.Join(t.TempDir(), "missing.yaml")))
+}
+
+func TestLoadFrameworkPermissions_BundledSeedIsValid(t *testing.T) {
+	svc, db := newTestPermissionService(t)
+	createGRTestRole(t, db, "admin")
+
+	require.NoError(t, svc.LoadAndRegisterFrameworkPermissionsFromYAML(filepath.Join("..", "..", "asset", "permission", "mc-iam-manager.yaml")))
+	var count int64
+	require.NoError(t, db.Model(&model.MciamPermission{}).Where("id = ?", "mc-iam-manager:workspace:update").Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
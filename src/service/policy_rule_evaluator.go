@@ -0,0 +1,327 @@
+package service
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+)
+
+// policySubjects 정책 규칙 적용 대상 판단용 사용자 역할/그룹 (상위 역할 포함)
+type policySubjects struct {
+	platformRoles  map[uint]bool
+	workspaceRoles map[uint]map[uint]bool // workspaceID → roleIDs
+	groups         map[uint]bool
+}
+
+// policyTarget 규칙 대상 비교용 요청 (permissionId 목록 또는 serviceName+actionName)
+type policyTarget struct {
+	permissions []string
+	serviceName string
+	actionName  string
+}
+
+// policyRuleResult 규칙 하나의 평가 결과
+type policyRuleResult struct {
+	rule    model.PolicyRule
+	matched bool
+	reason  string
+	match   model.AuthzRuleMatch
+}
+
+var policyWeekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// loadPolicySubjects 플랫폼/워크스페이스 역할(상위 역할 포함)과 그룹으로 평가 대상 구성
+func (s *AuthzService) loadPolicySubjects(userID uint, platformGrants, workspaceGrants []model.AuthzRoleGrant) (*policySubjects, []uint, []uint, error) {
+	roleIDs := make([]uint, 0, len(platformGrants)+len(workspaceGrants))
+	for _, grant := range platformGrants {
+		roleIDs = append(roleIDs, grant.RoleID)
+	}
+	for _, grant := range workspaceGrants {
+		roleIDs = append(roleIDs, grant.RoleID)
+	}
+	ancestors, err := s.authzRepo.FindRoleAncestors(roleIDs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	groupIDs, err := s.authzRepo.FindUserGroupIDs(userID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+
+	subjects := &policySubjects{
+		platformRoles:  make(map[uint]bool),
+		workspaceRoles: make(map[uint]map[uint]bool),
+		groups:         make(map[uint]bool),
+	}
+	allRoles := make(map[uint]bool)
+	for _, grant := range platformGrants {
+		for _, id := range append([]uint{grant.RoleID}, ancestors[grant.RoleID]...) {
+			subjects.platformRoles[id] = true
+			allRoles[id] = true
+		}
+	}
+	for _, grant := range workspaceGrants {
+		if subjects.workspaceRoles[grant.WorkspaceID] == nil {
+			subjects.workspaceRoles[grant.WorkspaceID] = make(map[uint]bool)
+		}
+		for _, id := range append([]uint{grant.RoleID}, ancestors[grant.RoleID]...) {
+			subjects.workspaceRoles[grant.WorkspaceID][id] = true
+			allRoles[id] = true
+		}
+	}
+	for _, id := range groupIDs {
+		subjects.groups[id] = true
+	}
+
+	expandedRoleIDs := make([]uint, 0, len(allRoles))
+	for id := range allRoles {
+		expandedRoleIDs = append(expandedRoleIDs, id)
+	}
+	return subjects, expandedRoleIDs, groupIDs, nil
+}
+
+// evaluatePolicyRule 규칙 적용 대상, 대상 액션/권한, 조건을 순서대로 평가
+func evaluatePolicyRule(rule model.PolicyRule, subjects *policySubjects, workspaceIDs []uint, target policyTarget, reqCtx model.AuthzRequestContext) policyRuleResult {
+	result := policyRuleResult{rule: rule}
+
+	workspaceID, ok, reason := policyRuleSubjectMatches(rule, subjects, workspaceIDs)
+	if !ok {
+		result.reason = reason
+		return result
+	}
+	matchedTarget, ok := policyRuleTargetMatches(rule, target)
+	if !ok {
+		result.reason = "target does not match"
+		return result
+	}
+	if ok, reason := policyConditionsMatch(rule.Conditions, reqCtx); !ok {
+		result.reason = reason
+		return result
+	}
+
+	result.matched = true
+	result.reason = "matched " + matchedTarget
+	result.match = model.AuthzRuleMatch{
+		RuleID:      rule.ID,
+		Name:        rule.Name,
+		Effect:      rule.Effect,
+		SubjectType: rule.SubjectType,
+		SubjectID:   rule.SubjectID,
+		WorkspaceID: workspaceID,
+		Target:      matchedTarget,
+	}
+	return result
+}
+
+// policyRuleSubjectMatches 사용자가 규칙의 역할/그룹에 해당하는지 확인. 워크스페이스 역할 규칙은 일치한 워크스페이스 반환
+func policyRuleSubjectMatches(rule model.PolicyRule, subjects *policySubjects, workspaceIDs []uint) (uint, bool, string) {
+	candidates := workspaceIDs
+	if rule.WorkspaceID != nil {
+		candidates = nil
+		for _, id := range workspaceIDs {
+			if id == *rule.WorkspaceID {
+				candidates = []uint{id}
+			}
+		}
+		if len(candidates) == 0 {
+			return 0, false, "workspace scope does not match"
+		}
+	}
+
+	switch rule.SubjectType {
+	case model.PolicyRuleSubjectGroup:
+		if subjects.groups[rule.SubjectID] {
+			return scopedWorkspace(rule), true, ""
+		}
+		return 0, false, "user is not a member of the group"
+	case model.PolicyRuleSubjectRole:
+		if rule.RoleType != string(constants.RoleTypeWorkspace) && subjects.platformRoles[rule.SubjectID] {
+			return scopedWorkspace(rule), true, ""
+		}
+		if rule.RoleType != string(constants.RoleTypePlatform) {
+			for _, id := range candidates {
+				if subjects.workspaceRoles[id][rule.SubjectID] {
+					return id, true, ""
+				}
+			}
+		}
+		return 0, false, "user does not hold the role"
+	}
+	return 0, false, "unknown subject type"
+}
+
+func scopedWorkspace(rule model.PolicyRule) uint {
+	if rule.WorkspaceID != nil {
+		return *rule.WorkspaceID
+	}
+	return 0
+}
+
+// policyRuleTargetMatches 규칙 대상(permissionId 패턴 또는 service/action)이 요청과 일치하는지 확인
+func policyRuleTargetMatches(rule model.PolicyRule, target policyTarget) (string, bool) {
+	if rule.PermissionID != "" {
+		for _, id := range target.permissions {
+			if policyPermissionMatches(rule.PermissionID, id) {
+				return id, true
+			}
+		}
+		return "", false
+	}
+	if rule.ServiceName == "" || target.serviceName == "" || rule.ServiceName != target.serviceName {
+		return "", false
+	}
+	if rule.ActionName != "*" && rule.ActionName != target.actionName {
+		return "", false
+	}
+	return target.serviceName + "/" + target.actionName, true
+}
+
+// policyPermissionMatches permissionId 패턴 비교. 끝의 '*'는 접두어 일치
+func policyPermissionMatches(pattern, permissionID string) bool {
+	return model.PermissionPatternMatches(pattern, permissionID)
+}
+
+// policyConditionsMatch 조건 평가. 지정한 조건을 모두 만족하면 일치하며 Negate이면 반전한다.
+// 요청 정보가 없는 조건(IP, amr)은 만족하지 않은 것으로 본다.
+func policyConditionsMatch(conditions model.PolicyRuleConditions, reqCtx model.AuthzRequestContext) (bool, string) {
+	if conditions.IsEmpty() {
+		return true, ""
+	}
+	satisfied, reason := policyConditionsSatisfied(conditions, reqCtx)
+	if conditions.Negate {
+		if satisfied {
+			return false, "conditions are satisfied (negated)"
+		}
+		return true, ""
+	}
+	return satisfied, reason
+}
+
+func policyConditionsSatisfied(conditions model.PolicyRuleConditions, reqCtx model.AuthzRequestContext) (bool, string) {
+	now := time.Now()
+	if reqCtx.Time != nil {
+		now = *reqCtx.Time
+	}
+	if len(conditions.TimeWindows) > 0 {
+		inWindow := false
+		for _, window := range conditions.TimeWindows {
+			if ok, _ := policyTimeWindowContains(window, now); ok {
+				inWindow = true
+				break
+			}
+		}
+		if !inWindow {
+			return false, "request time is outside the time windows"
+		}
+	}
+	if len(conditions.SourceCIDRs) > 0 {
+		ip := net.ParseIP(reqCtx.SourceIP)
+		if ip == nil {
+			return false, "source IP is unknown"
+		}
+		inRange := false
+		for _, cidr := range conditions.SourceCIDRs {
+			if network, err := parsePolicyCIDR(cidr); err == nil && network.Contains(ip) {
+				inRange = true
+				break
+			}
+		}
+		if !inRange {
+			return false, "source IP is outside the allowed ranges"
+		}
+	}
+	for _, method := range conditions.RequiredAMR {
+		found := false
+		for _, amr := range reqCtx.AMR {
+			if strings.EqualFold(amr, method) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, fmt.Sprintf("amr claim does not include %s", method)
+		}
+	}
+	return true, ""
+}
+
+// policyTimeWindowContains 시각이 요일/시간 범위에 포함되는지 확인 (시간대 미지정 시 UTC)
+func policyTimeWindowContains(window model.PolicyTimeWindow, t time.Time) (bool, error) {
+	location := time.UTC
+	if window.Timezone != "" {
+		loc, err := time.LoadLocation(window.Timezone)
+		if err != nil {
+			return false, err
+		}
+		location = loc
+	}
+	start, err := parsePolicyClock(window.Start)
+	if err != nil {
+		return false, err
+	}
+	end, err := parsePolicyClock(window.End)
+	if err != nil {
+		return false, err
+	}
+
+	local := t.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	day := local.Weekday()
+	if start > end && minute < end {
+		// 자정을 넘는 범위의 뒷부분은 전날 요일 기준
+		day = (day + 6) % 7
+	}
+	if len(window.Days) > 0 {
+		dayMatched := false
+		for _, name := range window.Days {
+			if weekday, ok := policyWeekdays[strings.ToLower(name)]; ok && weekday == day {
+				dayMatched = true
+				break
+			}
+		}
+		if !dayMatched {
+			return false, nil
+		}
+	}
+	if start <= end {
+		return minute >= start && minute < end, nil
+	}
+	return minute >= start || minute < end, nil
+}
+
+// parsePolicyClock HH:MM → 자정 기준 분
+func parsePolicyClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q (HH:MM)", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// parsePolicyCIDR CIDR 또는 단일 IP 파싱
+func parsePolicyCIDR(value string) (*net.IPNet, error) {
+	if !strings.Contains(value, "/") {
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP %q", value)
+		}
+		bits := 128
+		if ip.To4() != nil {
+			ip = ip.To4()
+			bits = 32
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, network, err := net.ParseCIDR(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR %q", value)
+	}
+	return network, nil
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrPolicyRuleNotFound 정책 규칙 없음
+	ErrPolicyRuleNotFound = errors.New("policy rule not found")
+	// ErrPolicyRuleExists 같은 이름의 정책 규칙 존재
+	ErrPolicyRuleExists = errors.New("policy rule already exists")
+	// ErrInvalidPolicyRule 잘못된 정책 규칙 (대상, 조건 형식 등)
+	ErrInvalidPolicyRule = errors.New("invalid policy rule")
+)
+
+// PolicyRuleService 역할/그룹에 붙는 명시적 허용/거부 정책 규칙 관리
+type PolicyRuleService struct {
+	repo          *repository.PolicyRuleRepository
+	roleRepo      *repository.RoleRepository
+	orgRepo       *repository.OrganizationRepository
+	workspaceRepo *repository.WorkspaceRepository
+}
+
+// NewPolicyRuleService 새 PolicyRuleService 인스턴스 생성
+func NewPolicyRuleService(db *gorm.DB) *PolicyRuleService {
+	return &PolicyRuleService{
+		repo:          repository.NewPolicyRuleRepository(db),
+		roleRepo:      repository.NewRoleRepository(db),
+		orgRepo:       repository.NewOrganizationRepository(db),
+		workspaceRepo: repository.NewWorkspaceRepository(db),
+	}
+}
+
+// List 정책 규칙 목록
+func (s *PolicyRuleService) List(filter *model.PolicyRuleFilter) ([]model.PolicyRule, error) {
+	return s.repo.List(filter)
+}
+
+// Get 정책 규칙 조회
+func (s *PolicyRuleService) Get(id uint) (*model.PolicyRule, error) {
+	rule, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return nil, ErrPolicyRuleNotFound
+	}
+	return rule, nil
+}
+
+// Create 정책 규칙 생성
+func (s *PolicyRuleService) Create(rule *model.PolicyRule, createdBy string) (*model.PolicyRule, error) {
+	rule.ID = 0
+	rule.CreatedBy = createdBy
+	if err := s.validate(rule); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// Update 정책 규칙 전체 수정 (생성자/생성 시각 유지)
+func (s *PolicyRuleService) Update(id uint, rule *model.PolicyRule) (*model.PolicyRule, error) {
+	existing, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	rule.ID = existing.ID
+	rule.CreatedBy = existing.CreatedBy
+	rule.CreatedAt = existing.CreatedAt
+	if err := s.validate(rule); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// Delete 정책 규칙 삭제
+func (s *PolicyRuleService) Delete(id uint) error {
+	if _, err := s.Get(id); err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
+
+// validate 규칙 형식 검증 후 대상 역할/그룹/워크스페이스 존재 및 이름 중복 확인
+func (s *PolicyRuleService) validate(rule *model.PolicyRule) error {
+	if err := validatePolicyRule(rule); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPolicyRule, err)
+	}
+	exists, err := s.repo.ExistsByName(rule.Name, rule.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrPolicyRuleExists
+	}
+
+	switch rule.SubjectType {
+	case model.PolicyRuleSubjectRole:
+		role, err := s.roleRepo.FindRoleByRoleID(rule.SubjectID, constants.IAMRoleType(rule.RoleType))
+		if err != nil {
+			return err
+		}
+		if role == nil {
+			return fmt.Errorf("%w: role %d not found", ErrInvalidPolicyRule, rule.SubjectID)
+		}
+	case model.PolicyRuleSubjectGroup:
+		if _, err := s.orgRepo.FindByID(rule.SubjectID); err != nil {
+			if errors.Is(err, repository.ErrOrganizationNotFound) {
+				return fmt.Errorf("%w: group %d not found", ErrInvalidPolicyRule, rule.SubjectID)
+			}
+			return err
+		}
+	}
+	if rule.WorkspaceID != nil {
+		workspace, err := s.workspaceRepo.FindWorkspaceByID(*rule.WorkspaceID)
+		if err != nil {
+			return err
+		}
+		if workspace == nil {
+			return fmt.Errorf("%w: workspace %d not found", ErrInvalidPolicyRule, *rule.WorkspaceID)
+		}
+	}
+	return nil
+}
+
+// validatePolicyRule DB 조회 없이 규칙 형식 검증 (dry-run 후보 규칙에도 사용)
+func validatePolicyRule(rule *model.PolicyRule) error {
+	rule.Name = strings.TrimSpace(rule.Name)
+	if rule.Name == "" || len(rule.Name) > 100 {
+		return errors.New("name is required (max 100 characters)")
+	}
+	switch rule.Effect {
+	case model.PolicyRuleEffectAllow, model.PolicyRuleEffectDeny:
+	default:
+		return errors.New("effect must be allow or deny")
+	}
+	switch rule.SubjectType {
+	case model.PolicyRuleSubjectRole:
+		switch constants.IAMRoleType(rule.RoleType) {
+		case "", constants.RoleTypePlatform, constants.RoleTypeWorkspace:
+		default:
+			return errors.New("roleType must be platform or workspace")
+		}
+	case model.PolicyRuleSubjectGroup:
+		if rule.RoleType != "" {
+			return errors.New("roleType is only allowed for role subjects")
+		}
+	default:
+		return errors.New("subjectType must be role or group")
+	}
+	if rule.SubjectID == 0 {
+		return errors.New("subjectId is required")
+	}
+	if rule.WorkspaceID != nil && *rule.WorkspaceID == 0 {
+		rule.WorkspaceID = nil
+	}
+
+	hasPermission := rule.PermissionID != ""
+	hasAction := rule.ServiceName != "" || rule.ActionName != ""
+	if hasPermission == hasAction {
+		return errors.New("either permissionId or serviceName+actionName is required")
+	}
+	if hasAction && (rule.ServiceName == "" || rule.ActionName == "") {
+		return errors.New("both serviceName and actionName are required ('*' for all actions)")
+	}
+	if hasPermission {
+		if strings.Contains(strings.TrimSuffix(rule.PermissionID, "*"), "*") {
+			return errors.New("'*' is only allowed at the end of permissionId")
+		}
+		if rule.Effect == model.PolicyRuleEffectAllow && strings.HasSuffix(rule.PermissionID, "*") {
+			return errors.New("allow rules require an exact permissionId")
+		}
+	}
+	return validatePolicyConditions(rule.Conditions)
+}
+
+func validatePolicyConditions(conditions model.PolicyRuleConditions) error {
+	if conditions.Negate && conditions.IsEmpty() {
+		return errors.New("negate requires at least one condition")
+	}
+	for _, window := range conditions.TimeWindows {
+		start, err := parsePolicyClock(window.Start)
+		if err != nil {
+			return err
+		}
+		end, err := parsePolicyClock(window.End)
+		if err != nil {
+			return err
+		}
+		if start == end {
+			return errors.New("time window start and end must differ")
+		}
+		for _, day := range window.Days {
+			if _, ok := policyWeekdays[strings.ToLower(day)]; !ok {
+				return fmt.Errorf("invalid day %q (mon..sun)", day)
+			}
+		}
+		if window.Timezone != "" {
+			if _, err := time.LoadLocation(window.Timezone); err != nil {
+				return fmt.Errorf("invalid timezone %q", window.Timezone)
+			}
+		}
+	}
+	for _, cidr := range conditions.SourceCIDRs {
+		if _, err := parsePolicyCIDR(cidr); err != nil {
+			return err
+		}
+	}
+	for _, amr := range conditions.RequiredAMR {
+		if strings.TrimSpace(amr) == "" {
+			return errors.New("requiredAmr must not contain empty values")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package service
+
+// policy_rule_service_test.go
+// 명시적 허용/거부 정책 규칙 단위 테스트 (SQLite in-memory DB)
+//
+// 테스트 범위:
+//   - 규칙 형식 검증 및 대상 역할 존재 확인
+//   - deny 규칙이 역할 권한보다 우선 (permissionId 패턴, service/action)
+//   - 조건 평가 (시간대 + negate, 요청 IP, amr)
+//   - 유효 권한 목록에 allow 추가 / deny 제거
+//   - dry-run 후보 규칙 평가
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/model/mcmpapi"
+	"github.com/m-cmp/mc-iam-manager/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyRuleService_Validate(t *testing.T) {
+	_, db, f := newTestAuthzService(t)
+	svc := NewPolicyRuleService(db)
+
+	invalid := []model.PolicyRule{
+		{Name: "no-target", Effect: model.PolicyRuleEffectDeny, SubjectType: model.PolicyRuleSubjectRole, SubjectID: f.role.ID},
+		{Name: "both-targets", Effect: model.PolicyRuleEffectDeny, SubjectType: model.PolicyRuleSubjectRole, SubjectID: f.role.ID,
+			PermissionID: authzTestPermission, ServiceName: "mc-infra-manager", ActionName: "*"},
+		{Name: "allow-wildcard", Effect: model.PolicyRuleEffectAllow, SubjectType: model.PolicyRuleSubjectRole, SubjectID: f.role.ID,
+			PermissionID: "mc-infra-manager:vm:*"},
+		{Name: "bad-effect", Effect: "block", SubjectType: model.PolicyRuleSubjectRole, SubjectID: f.role.ID, PermissionID: authzTestPermission},
+		{Name: "negate-only", Effect: model.PolicyRuleEffectDeny, SubjectType: model.PolicyRuleSubjectRole, SubjectID: f.role.ID,
+			PermissionID: authzTestPermission, Conditions: model.PolicyRuleConditions{Negate: true}},
+		{Name: "bad-window", Effect: model.PolicyRuleEffectDeny, SubjectType: model.PolicyRuleSubjectRole, SubjectID: f.role.ID,
+			PermissionID: authzTestPermission, Conditions: model.PolicyRuleConditions{TimeWindows: []model.PolicyTimeWindow{{Start: "9am", End: "18:00"}}}},
+		{Name: "bad-cidr", Effect: model.PolicyRuleEffectDeny, SubjectType: model.PolicyRuleSubjectRole, SubjectID: f.role.ID,
+			PermissionID: authzTestPermission, Conditions: model.PolicyRuleConditions{SourceCIDRs: []string{"10.0.0.0/33"}}},
+		{Name: "missing-role", Effect: model.PolicyRuleEffectDeny, SubjectType: model.PolicyRuleSubjectRole, SubjectID: 9999, PermissionID: authzTestPermission},
+	}
+	for _, rule := range invalid {
+		rule := rule
+		_, err := svc.Create(&rule, "admin")
+		assert.True(t, errors.Is(err, ErrInvalidPolicyRule), "%s: %v", rule.Name, err)
+	}
+
+	created, err := svc.Create(&model.PolicyRule{
+		Name: "deny-vm", Effect: model.PolicyRuleEffectDeny, SubjectType: model.PolicyRuleSubjectRole, SubjectID: f.role.ID,
+		RoleType: string(constants.RoleTypeWorkspace), PermissionID: "mc-infra-manager:vm:*", Enabled: true,
+	}, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, "admin", created.CreatedBy)
+
+	_, err = svc.Create(&model.PolicyRule{
+		Name: "deny-vm", Effect: model.PolicyRuleEffectDeny, SubjectType: model.PolicyRuleSubjectRole, SubjectID: f.role.ID, PermissionID: authzTestPermission,
+	}, "admin")
+	assert.ErrorIs(t, err, ErrPolicyRuleExists)
+}
+
+func TestAuthzCheck_DenyRuleOverridesRoleGrant(t *testing.T) {
+	svc, db, f := newTestAuthzService(t)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: f.user.ID, WorkspaceID: f.workspace.ID, RoleID: f.role.ID}).Error)
+	require.NoError(t, db.Create(&model.PolicyRule{
+		Name: "deny-vm", Effect: model.PolicyRuleEffectDeny, SubjectType: model.PolicyRuleSubjectRole, SubjectID: f.role.ID,
+		PermissionID: "mc-infra-manager:vm:*", Enabled: true,
+	}).Error)
+
+	req := &model.AuthzCheckRequest{
+		KcUserID: f.user.KcId, PermissionID: authzTestPermission, WorkspaceID: util.UintToString(f.workspace.ID),
+	}
+	res, err := svc.Check(context.Background(), req)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Contains(t, res.Reason, `denied by policy rule "deny-vm"`)
+	require.Len(t, res.MatchedRules, 1)
+	assert.Equal(t, f.workspace.ID, res.MatchedRules[0].WorkspaceID)
+
+	// 비활성 규칙은 평가하지 않는다
+	require.NoError(t, db.Model(&model.PolicyRule{}).Where("name = ?", "deny-vm").Update("enabled", false).Error)
+	res, err = svc.Check(context.Background(), req)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+}
+
+func TestAuthzCheck_DenyRuleOnApiAction(t *testing.T) {
+	svc, db, f := newTestAuthzService(t)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: f.user.ID, WorkspaceID: f.workspace.ID, RoleID: f.role.ID}).Error)
+	action := &mcmpapi.McmpApiAction{ServiceName: "mc-infra-manager", ActionName: "PostMciDynamic", Method: "POST"}
+	require.NoError(t, db.Create(action).Error)
+	require.NoError(t, db.Create(&mcmpapi.McmpApiPermissionActionMapping{
+		PermissionID: authzTestPermission, ActionID: action.ID, ActionName: action.ActionName,
+	}).Error)
+	org := &model.Organization{Name: "contractors", OrganizationCode: "CT01"}
+	require.NoError(t, db.Create(org).Error)
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: f.user.ID, OrganizationID: org.ID}).Error)
+	require.NoError(t, db.Create(&model.PolicyRule{
+		Name: "contractors-no-infra", Effect: model.PolicyRuleEffectDeny, SubjectType: model.PolicyRuleSubjectGroup, SubjectID: org.ID,
+		ServiceName: "mc-infra-manager", ActionName: "*", Enabled: true,
+	}).Error)
+
+	res, err := svc.Check(context.Background(), &model.AuthzCheckRequest{
+		KcUserID: f.user.KcId, ServiceName: "mc-infra-manager", ActionName: "PostMciDynamic", WorkspaceID: util.UintToString(f.workspace.ID),
+	})
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	require.Len(t, res.MatchedRules, 1)
+	assert.Equal(t, "mc-infra-manager/PostMciDynamic", res.MatchedRules[0].Target)
+}
+
+func TestAuthzCheck_PolicyRuleConditions(t *testing.T) {
+	svc, db, f := newTestAuthzService(t)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: f.user.ID, WorkspaceID: f.workspace.ID, RoleID: f.role.ID}).Error)
+	// 업무시간(평일 09:00-18:00 KST) 외, 사내망 외, MFA 미사용 요청 거부
+	require.NoError(t, db.Create(&model.PolicyRule{
+		Name: "office-hours", Effect: model.PolicyRuleEffectDeny, SubjectType: model.PolicyRuleSubjectRole, SubjectID: f.role.ID,
+		PermissionID: authzTestPermission, Enabled: true,
+		Conditions: model.PolicyRuleConditions{
+			TimeWindows: []model.PolicyTimeWindow{{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "09:00", End: "18:00", Timezone: "Asia/Seoul"}},
+			SourceCIDRs: []string{"10.0.0.0/8"},
+			RequiredAMR: []string{"otp"},
+			Negate:      true,
+		},
+	}).Error)
+
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	require.NoError(t, err)
+	workday := time.Date(2026, 10, 14, 10, 0, 0, 0, seoul) // 수요일
+	weekend := time.Date(2026, 10, 17, 10, 0, 0, 0, seoul) // 토요일
+
+	check := func(reqCtx *model.AuthzRequestContext) *model.AuthzCheckResponse {
+		res, err := svc.Check(context.Background(), &model.AuthzCheckRequest{
+			KcUserID: f.user.KcId, PermissionID: authzTestPermission, WorkspaceID: util.UintToString(f.workspace.ID), Context: reqCtx,
+		})
+		require.NoError(t, err)
+		return res
+	}
+
+	assert.True(t, check(&model.AuthzRequestContext{Time: &workday, SourceIP: "10.1.2.3", AMR: []string{"pwd", "otp"}}).Allowed)
+	assert.False(t, check(&model.AuthzRequestContext{Time: &weekend, SourceIP: "10.1.2.3", AMR: []string{"pwd", "otp"}}).Allowed)
+	assert.False(t, check(&model.AuthzRequestContext{Time: &workday, SourceIP: "192.168.0.10", AMR: []string{"pwd", "otp"}}).Allowed)
+	assert.False(t, check(&model.AuthzRequestContext{Time: &workday, SourceIP: "10.1.2.3", AMR: []string{"pwd"}}).Allowed)
+	// 요청 IP를 알 수 없으면 조건을 만족하지 않은 것으로 본다
+	assert.False(t, check(&model.AuthzRequestContext{Time: &workday, AMR: []string{"otp"}}).Allowed)
+}
+
+func TestPolicyTimeWindowContains_Overnight(t *testing.T) {
+	window := model.PolicyTimeWindow{Days: []string{"fri"}, Start: "22:00", End: "06:00"}
+
+	ok, err := policyTimeWindowContains(window, time.Date(2026, 10, 16, 23, 0, 0, 0, time.UTC)) // 금 23:00
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, _ = policyTimeWindowContains(window, time.Date(2026, 10, 17, 5, 59, 0, 0, time.UTC)) // 토 05:59 (금요일 범위)
+	assert.True(t, ok)
+	ok, _ = policyTimeWindowContains(window, time.Date(2026, 10, 17, 23, 0, 0, 0, time.UTC)) // 토 23:00
+	assert.False(t, ok)
+	ok, _ = policyTimeWindowContains(window, time.Date(2026, 10, 16, 6, 0, 0, 0, time.UTC)) // 금 06:00 (목요일 범위의 끝)
+	assert.False(t, ok)
+}
+
+func TestAuthzEffectivePermissions_PolicyRules(t *testing.T) {
+	svc, db, f := newTestAuthzService(t)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: f.user.ID, WorkspaceID: f.workspace.ID, RoleID: f.role.ID}).Error)
+	require.NoError(t, db.Create(&model.PolicyRule{
+		Name: "allow-vm-read", Effect: model.PolicyRuleEffectAllow, SubjectType: model.PolicyRuleSubjectRole, SubjectID: f.role.ID,
+		PermissionID: "mc-infra-manager:vm:read", Enabled: true,
+	}).Error)
+
+	perms, err := svc.EffectivePermissions(f.user.KcId, f.workspace.ID, nil, nil)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{authzTestPermission, "mc-infra-manager:vm:read"}, perms.Permissions)
+
+	require.NoError(t, db.Create(&model.PolicyRule{
+		Name: "deny-vm-create", Effect: model.PolicyRuleEffectDeny, SubjectType: model.PolicyRuleSubjectRole, SubjectID: f.role.ID,
+		PermissionID: "mc-infra-manager:vm:create*", Enabled: true,
+	}).Error)
+	perms, err = svc.EffectivePermissions(f.user.KcId, f.workspace.ID, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mc-infra-manager:vm:read"}, perms.Permissions)
+}
+
+func TestAuthzDryRun_CandidateRules(t *testing.T) {
+	svc, db, f := newTestAuthzService(t)
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{UserID: f.user.ID, WorkspaceID: f.workspace.ID, RoleID: f.role.ID}).Error)
+	otherWorkspaceID := f.workspace.ID + 1
+	require.NoError(t, db.Create(&model.PolicyRule{
+		Name: "deny-other-workspace", Effect: model.PolicyRuleEffectDeny, SubjectType: model.PolicyRuleSubjectRole, SubjectID: f.role.ID,
+		WorkspaceID: &otherWorkspaceID, PermissionID: authzTestPermission, Enabled: true,
+	}).Error)
+
+	req := &model.AuthzDryRunRequest{
+		AuthzCheckRequest: model.AuthzCheckRequest{
+			KcUserID: f.user.KcId, PermissionID: authzTestPermission, WorkspaceID: util.UintToString(f.workspace.ID),
+		},
+		CandidateRules: []model.PolicyRule{{
+			Name: "candidate-deny", Effect: model.PolicyRuleEffectDeny, SubjectType: model.PolicyRuleSubjectRole, SubjectID: f.role.ID,
+			PermissionID: "mc-infra-manager:*",
+		}},
+	}
+	res, err := svc.DryRun(context.Background(), req)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.NotNil(t, res.Context.Time)
+	require.Len(t, res.EvaluatedRules, 2)
+	assert.False(t, res.EvaluatedRules[0].Matched)
+	assert.Equal(t, "workspace scope does not match", res.EvaluatedRules[0].Reason)
+	assert.True(t, res.EvaluatedRules[1].Matched)
+
+	// 후보 규칙은 저장되지 않는다
+	var count int64
+	require.NoError(t, db.Model(&model.PolicyRule{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	req.CandidateRules[0].PermissionID = "mc-infra-manager:*:create"
+	_, err = svc.DryRun(context.Background(), req)
+	assert.ErrorIs(t, err, ErrAuthzInvalidRequest)
+}
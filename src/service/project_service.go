@@ -450,10 +450,15 @@ func (s *ProjectService) SyncProjectsWithInfraManager(ctx context.Context) error
 	return nil
 }
 
-// GetProjectSyncDiff compares local projects with mc-infra-manager namespaces.
-// Returns namespaces missing in local DB and local projects not assigned to any workspace.
-// Read-only: no DB changes.
-func (s *ProjectService) GetProjectSyncDiff(ctx context.Context) (*model.ProjectSyncDiffResponse, error) {
+// infraNamespace mc-infra-manager GetAllNs 응답의 네임스페이스
+type infraNamespace struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// fetchInfraNamespaces mc-infra-manager의 네임스페이스 목록 조회
+func (s *ProjectService) fetchInfraNamespaces(ctx context.Context) ([]infraNamespace, error) {
 	callReq := &model.McmpApiCallRequest{
 		ServiceName:   "mc-infra-manager",
 		ActionName:    "GetAllNs",
@@ -468,15 +473,22 @@ func (s *ProjectService) GetProjectSyncDiff(ctx context.Context) (*model.Project
 	}
 
 	var infraResp struct {
-		Ns []struct {
-			ID          string `json:"id"`
-			Name        string `json:"name"`
-			Description string `json:"description"`
-		} `json:"ns"`
+		Ns []infraNamespace `json:"ns"`
 	}
 	if err := json.Unmarshal(respBody, &infraResp); err != nil {
 		return nil, fmt.Errorf("failed to parse GetAllNs response: %w", err)
 	}
+	return infraResp.Ns, nil
+}
+
+// GetProjectSyncDiff compares local projects with mc-infra-manager namespaces.
+// Returns namespaces missing in local DB and local projects not assigned to any workspace.
+// Read-only: no DB changes.
+func (s *ProjectService) GetProjectSyncDiff(ctx context.Context) (*model.ProjectSyncDiffResponse, error) {
+	namespaces, err := s.fetchInfraNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	localProjects, err := s.projectRepo.FindProjects(&model.ProjectFilterRequest{})
 	if err != nil {
@@ -499,7 +511,7 @@ func (s *ProjectService) GetProjectSyncDiff(ctx context.Context) (*model.Project
 		UnassignedProjects: []model.ProjectSyncDiffUnassignedItem{},
 	}
 
-	for _, ns := range infraResp.Ns {
+	for _, ns := range namespaces {
 		if _, exists := localByNsId[ns.ID]; !exists {
 			result.MissingProjects = append(result.MissingProjects, model.ProjectSyncDiffMissingItem{
 				NsId:        ns.ID,
@@ -531,38 +543,13 @@ func (s *ProjectService) ApplyProjectSyncToWorkspace(ctx context.Context, worksp
 		return nil, fmt.Errorf("workspace not found")
 	}
 
-	callReq := &model.McmpApiCallRequest{
-		ServiceName:   "mc-infra-manager",
-		ActionName:    "GetAllNs",
-		RequestParams: model.McmpApiRequestParams{},
-	}
-	statusCode, respBody, _, _, err := s.mcmpApiService.McmpApiCall(ctx, callReq)
+	namespaces, err := s.fetchInfraNamespaces(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("mc-infra-manager GetAllNs failed: %w", err)
-	}
-	if statusCode < 200 || statusCode >= 300 {
-		return nil, fmt.Errorf("mc-infra-manager GetAllNs returned status %d", statusCode)
-	}
-
-	var infraResp struct {
-		Ns []struct {
-			ID          string `json:"id"`
-			Name        string `json:"name"`
-			Description string `json:"description"`
-		} `json:"ns"`
-	}
-	if err := json.Unmarshal(respBody, &infraResp); err != nil {
-		return nil, fmt.Errorf("failed to parse GetAllNs response: %w", err)
+		return nil, err
 	}
-	infraByNsId := make(map[string]struct {
-		Name        string
-		Description string
-	}, len(infraResp.Ns))
-	for _, ns := range infraResp.Ns {
-		infraByNsId[ns.ID] = struct {
-			Name        string
-			Description string
-		}{ns.Name, ns.Description}
+	infraByNsId := make(map[string]infraNamespace, len(namespaces))
+	for _, ns := range namespaces {
+		infraByNsId[ns.ID] = ns
 	}
 
 	localProjects, err := s.projectRepo.FindProjects(&model.ProjectFilterRequest{})
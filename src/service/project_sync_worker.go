@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/config"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+const projectSyncWorkerActor = "system:project-sync"
+
+// ErrProjectSyncRunning 이전 동기화가 아직 실행 중
+var ErrProjectSyncRunning = errors.New("project sync is already running")
+
+// ProjectSyncWorker mc-infra-manager 네임스페이스를 주기적으로 로컬 프로젝트에 반영하는 백그라운드 작업
+// 새 네임스페이스는 프로젝트로 생성하여 대상 워크스페이스에 할당하고, 사라진 네임스페이스는 orphan 정책에 따라 처리한다.
+type ProjectSyncWorker struct {
+	projectService *ProjectService
+	projectRepo    *repository.ProjectRepository
+	workspaceRepo  *repository.WorkspaceRepository
+	auditService   *AuditService
+
+	interval      time.Duration
+	dryRun        bool
+	workspaceName string
+	orphanPolicy  string
+
+	runMu   sync.Mutex // 동시 실행 방지
+	mu      sync.RWMutex
+	running bool
+	lastRun *model.ProjectSyncRun
+}
+
+// NewProjectSyncWorker 새 ProjectSyncWorker 인스턴스 생성 (설정은 환경 변수에서 읽음)
+func NewProjectSyncWorker(db *gorm.DB) *ProjectSyncWorker {
+	return &ProjectSyncWorker{
+		projectService: NewProjectService(db),
+		projectRepo:    repository.NewProjectRepository(db),
+		workspaceRepo:  repository.NewWorkspaceRepository(db),
+		auditService:   NewAuditService(db),
+		interval:       config.ProjectSyncInterval(),
+		dryRun:         config.ProjectSyncDryRun(),
+		workspaceName:  config.ProjectSyncWorkspace(),
+		orphanPolicy:   config.ProjectSyncOrphanPolicy(),
+	}
+}
+
+// Run interval마다 RunOnce 실행. ctx가 취소되면 종료
+func (w *ProjectSyncWorker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if run, err := w.RunOnce(ctx); err != nil {
+			log.Printf("[WARN] project sync: %v", err)
+		} else if run.Error != "" {
+			log.Printf("[WARN] project sync failed: %s", run.Error)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Status 설정 및 마지막 실행 결과
+func (w *ProjectSyncWorker) Status() *model.ProjectSyncStatusResponse {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return &model.ProjectSyncStatusResponse{
+		Enabled:         w.interval > 0,
+		IntervalSeconds: int(w.interval / time.Second),
+		DryRun:          w.dryRun,
+		Workspace:       w.workspaceName,
+		OrphanPolicy:    w.orphanPolicy,
+		Running:         w.running,
+		LastRun:         w.lastRun,
+	}
+}
+
+// RunOnce 동기화 1회 실행. 실행 결과는 Status의 LastRun으로 조회 가능
+func (w *ProjectSyncWorker) RunOnce(ctx context.Context) (*model.ProjectSyncRun, error) {
+	if !w.runMu.TryLock() {
+		return nil, ErrProjectSyncRunning
+	}
+	defer w.runMu.Unlock()
+	w.setRunning(true)
+	defer w.setRunning(false)
+
+	run := &model.ProjectSyncRun{
+		StartedAt: time.Now().UTC(),
+		DryRun:    w.dryRun,
+		Workspace: w.workspaceName,
+		Diff: model.ProjectSyncDiffResponse{
+			MissingProjects:    []model.ProjectSyncDiffMissingItem{},
+			UnassignedProjects: []model.ProjectSyncDiffUnassignedItem{},
+		},
+		Orphans:  []model.ProjectSyncOrphanItem{},
+		Created:  []model.ProjectSyncApplyCreatedItem{},
+		Assigned: []model.ProjectSyncApplyAssignedItem{},
+		Failed:   []model.ProjectSyncApplyFailedItem{},
+	}
+	if err := w.sync(ctx, run); err != nil {
+		run.Error = err.Error()
+	}
+	run.FinishedAt = time.Now().UTC()
+	run.Success = run.Error == "" && len(run.Failed) == 0
+
+	if !run.DryRun && (len(run.Created) > 0 || len(run.Assigned) > 0 || w.orphansChanged(run)) {
+		w.recordAudit(run)
+	}
+
+	w.mu.Lock()
+	w.lastRun = run
+	w.mu.Unlock()
+	return run, nil
+}
+
+func (w *ProjectSyncWorker) setRunning(running bool) {
+	w.mu.Lock()
+	w.running = running
+	w.mu.Unlock()
+}
+
+func (w *ProjectSyncWorker) sync(ctx context.Context, run *model.ProjectSyncRun) error {
+	namespaces, err := w.projectService.fetchInfraNamespaces(ctx)
+	if err != nil {
+		return err
+	}
+	localProjects, err := w.projectRepo.FindProjects(&model.ProjectFilterRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list local projects: %w", err)
+	}
+	assignedMap, err := w.projectRepo.FindAllProjectWorkspaceAssignments()
+	if err != nil {
+		return fmt.Errorf("failed to get workspace assignments: %w", err)
+	}
+
+	inInfra := make(map[string]bool, len(namespaces))
+	for _, ns := range namespaces {
+		inInfra[ns.ID] = true
+	}
+	localByNsId := make(map[string]*model.Project, len(localProjects))
+	for _, p := range localProjects {
+		if p.NsId != "" {
+			localByNsId[p.NsId] = p
+		}
+	}
+
+	var missing []infraNamespace
+	for _, ns := range namespaces {
+		if _, exists := localByNsId[ns.ID]; !exists {
+			missing = append(missing, ns)
+			run.Diff.MissingProjects = append(run.Diff.MissingProjects, model.ProjectSyncDiffMissingItem{
+				NsId: ns.ID, Name: ns.Name, Description: ns.Description,
+			})
+		}
+	}
+	var unassigned, orphans []*model.Project
+	for _, p := range localProjects {
+		if _, assigned := assignedMap[p.ID]; !assigned {
+			run.Diff.UnassignedProjects = append(run.Diff.UnassignedProjects, model.ProjectSyncDiffUnassignedItem{
+				ID: p.ID, NsId: p.NsId, Name: p.Name, Description: p.Description,
+			})
+			if inInfra[p.NsId] {
+				unassigned = append(unassigned, p)
+			}
+		}
+		if p.NsId != "" && !inInfra[p.NsId] {
+			orphans = append(orphans, p)
+		}
+	}
+
+	orphanPolicy := w.orphanPolicy
+	if len(namespaces) == 0 && len(orphans) > 0 && orphanPolicy != config.ProjectSyncOrphanKeep {
+		// 빈 목록은 mc-infra-manager 초기화/장애일 수 있으므로 일괄 삭제/해제하지 않는다
+		log.Printf("[WARN] project sync: mc-infra-manager returned no namespaces, keeping %d orphan projects", len(orphans))
+		orphanPolicy = config.ProjectSyncOrphanKeep
+	}
+	for _, p := range orphans {
+		run.Orphans = append(run.Orphans, model.ProjectSyncOrphanItem{ID: p.ID, NsId: p.NsId, Name: p.Name, Action: orphanPolicy})
+	}
+
+	if run.DryRun {
+		return nil
+	}
+
+	if len(missing) > 0 || len(unassigned) > 0 {
+		ws, err := w.workspaceRepo.FindWorkspaceByName(w.workspaceName)
+		if err != nil {
+			return fmt.Errorf("sync workspace %q not found: %w", w.workspaceName, err)
+		}
+		w.createMissing(run, missing, ws.ID)
+		w.assignUnassigned(run, unassigned, ws.ID)
+	}
+	w.handleOrphans(run, orphans, orphanPolicy)
+	return nil
+}
+
+// createMissing 로컬에 없는 네임스페이스를 프로젝트로 생성 (네임스페이스가 이미 있으므로 PostNs는 호출하지 않음)
+func (w *ProjectSyncWorker) createMissing(run *model.ProjectSyncRun, missing []infraNamespace, workspaceID uint) {
+	for _, ns := range missing {
+		project := &model.Project{NsId: ns.ID, Name: ns.Name, Description: ns.Description}
+		if err := w.projectRepo.CreateProject(project); err != nil {
+			run.Failed = append(run.Failed, model.ProjectSyncApplyFailedItem{NsId: ns.ID, Error: err.Error()})
+			continue
+		}
+		if err := w.projectRepo.AddProjectWorkspaceAssociation(project.ID, workspaceID); err != nil {
+			run.Failed = append(run.Failed, model.ProjectSyncApplyFailedItem{NsId: ns.ID, Error: err.Error()})
+			continue
+		}
+		run.Created = append(run.Created, model.ProjectSyncApplyCreatedItem{ID: project.ID, NsId: ns.ID, Name: project.Name})
+	}
+}
+
+func (w *ProjectSyncWorker) assignUnassigned(run *model.ProjectSyncRun, projects []*model.Project, workspaceID uint) {
+	for _, p := range projects {
+		if err := w.projectRepo.AddProjectWorkspaceAssociation(p.ID, workspaceID); err != nil {
+			run.Failed = append(run.Failed, model.ProjectSyncApplyFailedItem{NsId: p.NsId, Error: err.Error()})
+			continue
+		}
+		run.Assigned = append(run.Assigned, model.ProjectSyncApplyAssignedItem{ID: p.ID, NsId: p.NsId, Name: p.Name})
+	}
+}
+
+func (w *ProjectSyncWorker) handleOrphans(run *model.ProjectSyncRun, orphans []*model.Project, policy string) {
+	if policy == config.ProjectSyncOrphanKeep {
+		return
+	}
+	for _, p := range orphans {
+		if err := w.projectRepo.ClearProjectWorkspaceAssociations(p.ID); err != nil {
+			run.Failed = append(run.Failed, model.ProjectSyncApplyFailedItem{NsId: p.NsId, Error: err.Error()})
+			continue
+		}
+		if policy != config.ProjectSyncOrphanDelete {
+			continue
+		}
+		if err := w.projectRepo.DeleteProject(p.ID); err != nil && !errors.Is(err, repository.ErrProjectNotFound) {
+			run.Failed = append(run.Failed, model.ProjectSyncApplyFailedItem{NsId: p.NsId, Error: err.Error()})
+		}
+	}
+}
+
+func (w *ProjectSyncWorker) orphansChanged(run *model.ProjectSyncRun) bool {
+	for _, o := range run.Orphans {
+		if o.Action != config.ProjectSyncOrphanKeep {
+			return true
+		}
+	}
+	return false
+}
+
+// recordAudit 동기화로 인한 변경 감사 이벤트 기록 (실패해도 동기화 결과는 유지)
+func (w *ProjectSyncWorker) recordAudit(run *model.ProjectSyncRun) {
+	event := &model.AuditEvent{
+		ActorKcUserID: projectSyncWorkerActor,
+		Action:        "project.sync",
+		TargetType:    "project",
+		TargetID:      w.workspaceName,
+		After:         ToAuditSnapshot(run),
+	}
+	if err := w.auditService.Record(event); err != nil {
+		log.Printf("[WARN] %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package service
+
+// project_sync_worker_test.go
+// mc-infra-manager 프로젝트 주기 동기화(ProjectSyncWorker) 단위 테스트 (SQLite in-memory DB)
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/m-cmp/mc-iam-manager/config"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// stubInfraMcmpApiService GetAllNs 응답만 흉내내는 McmpApiService 스텁
+type stubInfraMcmpApiService struct {
+	McmpApiService
+	body string
+}
+
+func (m *stubInfraMcmpApiService) McmpApiCall(ctx context.Context, req *model.McmpApiCallRequest) (int, []byte, string, string, error) {
+	return http.StatusOK, []byte(m.body), "", "", nil
+}
+
+func newProjectSyncTestWorker(t *testing.T, nsBody string, dryRun bool, orphanPolicy string) (*ProjectSyncWorker, *gorm.DB) {
+	t.Helper()
+	db := setupGroupRoleTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.Project{}, &model.AuditEvent{}))
+
+	projectRepo := repository.NewProjectRepository(db)
+	workspaceRepo := repository.NewWorkspaceRepository(db)
+	w := &ProjectSyncWorker{
+		projectService: &ProjectService{
+			db:             db,
+			projectRepo:    projectRepo,
+			workspaceRepo:  workspaceRepo,
+			mcmpApiService: &stubInfraMcmpApiService{body: nsBody},
+		},
+		projectRepo:   projectRepo,
+		workspaceRepo: workspaceRepo,
+		auditService:  NewAuditService(db),
+		dryRun:        dryRun,
+		workspaceName: "ws-sync",
+		orphanPolicy:  orphanPolicy,
+	}
+	return w, db
+}
+
+func createSyncTestProject(t *testing.T, db *gorm.DB, nsID string, ws *model.Workspace) *model.Project {
+	t.Helper()
+	p := &model.Project{NsId: nsID, Name: nsID}
+	require.NoError(t, db.Create(p).Error)
+	if ws != nil {
+		require.NoError(t, repository.NewProjectRepository(db).AddProjectWorkspaceAssociation(p.ID, ws.ID))
+	}
+	return p
+}
+
+const projectSyncTestNs = `{"ns":[{"id":"ns-new","name":"ns-new"},{"id":"ns-free","name":"ns-free"},{"id":"ns-kept","name":"ns-kept"}]}`
+
+func TestProjectSyncWorker_CreatesAssignsAndDeletesOrphans(t *testing.T) {
+	w, db := newProjectSyncTestWorker(t, projectSyncTestNs, false, config.ProjectSyncOrphanDelete)
+	ws := createGRTestWorkspace(t, db, "ws-sync")
+	other := createGRTestWorkspace(t, db, "ws-other")
+	createSyncTestProject(t, db, "ns-free", nil)
+	createSyncTestProject(t, db, "ns-kept", other)
+	gone := createSyncTestProject(t, db, "ns-gone", other)
+
+	run, err := w.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.True(t, run.Success, run.Error)
+	require.Len(t, run.Created, 1)
+	assert.Equal(t, "ns-new", run.Created[0].NsId)
+	require.Len(t, run.Assigned, 1)
+	assert.Equal(t, "ns-free", run.Assigned[0].NsId)
+	require.Len(t, run.Orphans, 1)
+	assert.Equal(t, model.ProjectSyncOrphanItem{ID: gone.ID, NsId: "ns-gone", Name: "ns-gone", Action: config.ProjectSyncOrphanDelete}, run.Orphans[0])
+
+	workspaces, err := repository.NewProjectRepository(db).FindAssignedWorkspaces(run.Created[0].ID)
+	require.NoError(t, err)
+	require.Len(t, workspaces, 1)
+	assert.Equal(t, ws.ID, workspaces[0].ID)
+
+	var count int64
+	db.Model(&model.Project{}).Where("nsid = ?", "ns-gone").Count(&count)
+	assert.Zero(t, count)
+	db.Model(&model.AuditEvent{}).Where("action = ?", "project.sync").Count(&count)
+	assert.Equal(t, int64(1), count)
+
+	status := w.Status()
+	assert.False(t, status.Running)
+	assert.Same(t, run, status.LastRun)
+
+	// 두 번째 실행은 변경 사항이 없다
+	again, err := w.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, again.Created)
+	assert.Empty(t, again.Assigned)
+	assert.Empty(t, again.Orphans)
+}
+
+func TestProjectSyncWorker_DryRunOnlyReportsDiff(t *testing.T) {
+	w, db := newProjectSyncTestWorker(t, projectSyncTestNs, true, config.ProjectSyncOrphanUnassign)
+	other := createGRTestWorkspace(t, db, "ws-other")
+	createSyncTestProject(t, db, "ns-gone", other)
+
+	run, err := w.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.True(t, run.Success, "dry-run은 대상 워크스페이스가 없어도 실패하지 않는다")
+	assert.Len(t, run.Diff.MissingProjects, 3)
+	require.Len(t, run.Orphans, 1)
+	assert.Equal(t, config.ProjectSyncOrphanUnassign, run.Orphans[0].Action)
+	assert.Empty(t, run.Created)
+
+	var count int64
+	db.Model(&model.Project{}).Count(&count)
+	assert.Equal(t, int64(1), count)
+	db.Table("mcmp_workspace_projects").Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestProjectSyncWorker_EmptyNamespaceListKeepsOrphans(t *testing.T) {
+	w, db := newProjectSyncTestWorker(t, `{"ns":[]}`, false, config.ProjectSyncOrphanDelete)
+	other := createGRTestWorkspace(t, db, "ws-other")
+	createSyncTestProject(t, db, "ns-a", other)
+
+	run, err := w.RunOnce(context.Background())
+	require.NoError(t, err)
+	require.Len(t, run.Orphans, 1)
+	assert.Equal(t, config.ProjectSyncOrphanKeep, run.Orphans[0].Action)
+
+	var count int64
+	db.Model(&model.Project{}).Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestProjectSyncWorker_MissingWorkspaceFailsRun(t *testing.T) {
+	w, _ := newProjectSyncTestWorker(t, projectSyncTestNs, false, config.ProjectSyncOrphanKeep)
+
+	run, err := w.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.False(t, run.Success)
+	assert.Contains(t, run.Error, "ws-sync")
+	assert.Same(t, run, w.Status().LastRun)
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	roleGrantReaperActor           = "system:role-grant-reaper"
+	workspaceTicketRevokedByExpiry = "workspace role expired"
+)
+
+// RoleGrantReaper 만료된 역할 할당을 주기적으로 회수하는 백그라운드 작업
+// 플랫폼 역할은 Keycloak realm role도 함께 제거한다.
+type RoleGrantReaper struct {
+	grantRepo    *repository.RoleGrantRepository
+	ticketRepo   *repository.WorkspaceTicketRepository
+	auditService *AuditService
+	kcService    KeycloakService
+	revocations  *TokenRevocationService
+}
+
+// NewRoleGrantReaper 새 RoleGrantReaper 인스턴스 생성
+func NewRoleGrantReaper(db *gorm.DB) *RoleGrantReaper {
+	return &RoleGrantReaper{
+		grantRepo:    repository.NewRoleGrantRepository(db),
+		ticketRepo:   repository.NewWorkspaceTicketRepository(db),
+		auditService: NewAuditService(db),
+		kcService:    NewKeycloakService(),
+		revocations:  NewTokenRevocationService(db),
+	}
+}
+
+// Run interval마다 ReapExpired 실행. ctx가 취소되면 종료
+func (r *RoleGrantReaper) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := r.ReapExpired(ctx); err != nil {
+			log.Printf("[WARN] role grant reaper: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReapExpired 만료된 역할 할당 회수. 회수한 할당 목록 반환
+// Keycloak 제거에 실패한 플랫폼 역할은 DB에 남겨 다음 주기에 재시도한다 (유효 역할 조회에서는 이미 제외됨).
+func (r *RoleGrantReaper) ReapExpired(ctx context.Context) ([]model.ExpiredRoleGrant, error) {
+	now := time.Now().UTC()
+	grants, err := r.grantRepo.FindExpiredGrants(now)
+	if err != nil {
+		return nil, err
+	}
+
+	reaped := make([]model.ExpiredRoleGrant, 0, len(grants))
+	var failed int
+	for _, grant := range grants {
+		if err := r.reapGrant(ctx, grant, now); err != nil {
+			log.Printf("[WARN] failed to reap expired role grant %+v: %v", grant, err)
+			failed++
+			continue
+		}
+		reaped = append(reaped, grant)
+	}
+	if len(reaped) > 0 {
+		log.Printf("Reaped %d expired role grants", len(reaped))
+	}
+	if failed > 0 {
+		return reaped, fmt.Errorf("%d expired role grants could not be reaped", failed)
+	}
+	return reaped, nil
+}
+
+func (r *RoleGrantReaper) reapGrant(ctx context.Context, grant model.ExpiredRoleGrant, now time.Time) error {
+	isPlatform := grant.RoleType == constants.RoleTypePlatform
+	if isPlatform && grant.KcUserID != "" {
+		if err := r.kcService.RemoveRealmRoleFromUser(ctx, grant.KcUserID, grant.RoleName); err != nil && !isKeycloakNotFound(err) {
+			return fmt.Errorf("failed to remove realm role: %w", err)
+		}
+	}
+
+	deleted, err := r.grantRepo.DeleteExpiredGrant(grant, now)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		// 회수 도중 기간이 연장됨 — 이미 제거한 realm role 복구
+		if isPlatform && grant.KcUserID != "" {
+			if err := r.kcService.AssignRealmRoleToUser(ctx, grant.KcUserID, grant.RoleName); err != nil {
+				return fmt.Errorf("failed to restore realm role for extended grant: %w", err)
+			}
+		}
+		return nil
+	}
+
+	if isPlatform && grant.KcUserID != "" {
+		// 만료된 역할이 담긴 기존 access token 폐기
+		if _, err := r.revocations.RevokeUserTokens(grant.KcUserID, tokenRevokedByRoleExpiry, roleGrantReaperActor); err != nil {
+			log.Printf("[WARN] failed to revoke tokens of user %s: %v", grant.KcUserID, err)
+		}
+	}
+	if !isPlatform && grant.UserID != 0 {
+		if _, err := r.ticketRepo.RevokeByUserAndWorkspace(grant.UserID, grant.WorkspaceID, workspaceTicketRevokedByExpiry); err != nil {
+			log.Printf("[WARN] failed to revoke workspace tickets (userID=%d, workspaceID=%d): %v", grant.UserID, grant.WorkspaceID, err)
+		}
+	}
+	if !isPlatform && grant.GroupID != 0 {
+		if _, err := r.ticketRepo.RevokeByGroupAndWorkspace(grant.GroupID, grant.WorkspaceID, workspaceTicketRevokedByExpiry); err != nil {
+			log.Printf("[WARN] failed to revoke workspace tickets (groupID=%d, workspaceID=%d): %v", grant.GroupID, grant.WorkspaceID, err)
+		}
+	}
+	r.recordAudit(grant)
+	return nil
+}
+
+// recordAudit 만료 회수 감사 이벤트 기록 (실패해도 회수는 유지)
+func (r *RoleGrantReaper) recordAudit(grant model.ExpiredRoleGrant) {
+	event := &model.AuditEvent{
+		ActorKcUserID: roleGrantReaperActor,
+		Before:        ToAuditSnapshot(grant),
+	}
+	switch {
+	case grant.GroupID != 0:
+		event.Action = "group.workspace-role.expire"
+		event.TargetType = "group"
+		event.TargetID = fmt.Sprint(grant.GroupID)
+	case grant.RoleType == constants.RoleTypePlatform:
+		event.Action = "role.expire.platform"
+		event.TargetType = "user"
+		event.TargetID = fmt.Sprint(grant.UserID)
+	default:
+		event.Action = "role.expire.workspace"
+		event.TargetType = "user"
+		event.TargetID = fmt.Sprint(grant.UserID)
+	}
+	if err := r.auditService.Record(event); err != nil {
+		log.Printf("[WARN] %v", err)
+	}
+}
+
+// isKeycloakNotFound Keycloak 사용자/역할이 이미 없는 경우 (회수 대상에서 성공으로 처리)
+func isKeycloakNotFound(err error) bool {
+	return strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found")
+}
@@ -0,0 +1,194 @@
+package service
+
+// role_grant_reaper_test.go
+// 기간 제한 역할 할당 및 만료 회수(RoleGrantReaper) 단위 테스트 (SQLite in-memory DB)
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// recordingKeycloakService realm role 제거 호출을 기록하는 KeycloakService 스텁
+type recordingKeycloakService struct {
+	mockKeycloakService
+	removed []string
+}
+
+func (m *recordingKeycloakService) RemoveRealmRoleFromUser(ctx context.Context, kcUserId, roleName string) error {
+	m.removed = append(m.removed, kcUserId+"/"+roleName)
+	return nil
+}
+
+func newRoleGrantTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := setupGroupRoleTestDB(t)
+	require.NoError(t, db.AutoMigrate(
+		&model.WorkspaceTicket{},
+		&model.AuditEvent{},
+		&model.TokenRevocation{},
+	))
+	return db
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestRoleGrantPeriod_Validate(t *testing.T) {
+	now := time.Now()
+	assert.NoError(t, model.RoleGrantPeriod{}.Validate(now))
+	assert.NoError(t, model.RoleGrantPeriod{ExpiresAt: timePtr(now.Add(time.Hour))}.Validate(now))
+	assert.ErrorIs(t, model.RoleGrantPeriod{ExpiresAt: timePtr(now.Add(-time.Minute))}.Validate(now), model.ErrRoleGrantAlreadyExpired)
+	assert.ErrorIs(t, model.RoleGrantPeriod{
+		StartsAt:  timePtr(now.Add(2 * time.Hour)),
+		ExpiresAt: timePtr(now.Add(time.Hour)),
+	}.Validate(now), model.ErrRoleGrantInvalidPeriod)
+}
+
+func TestRoleService_UpdatePlatformRolePeriod(t *testing.T) {
+	db := newRoleGrantTestDB(t)
+	user := createGRTestUser(t, db, "contractor", "kc-contractor")
+	role := createGRTestRole(t, db, "operator")
+	roleRepo := repository.NewRoleRepository(db)
+	require.NoError(t, roleRepo.AssignPlatformRoleWithPeriod(user.ID, role.ID, model.RoleGrantPeriod{}))
+	svc := NewRoleService(db)
+
+	// 이미 할당된 역할에 만료 시각 지정
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	require.NoError(t, svc.UpdatePlatformRolePeriod(user.ID, role.ID, model.RoleGrantPeriod{ExpiresAt: &expiresAt}))
+	var grant model.UserPlatformRole
+	require.NoError(t, db.Where("user_id = ? AND role_id = ?", user.ID, role.ID).First(&grant).Error)
+	require.NotNil(t, grant.ExpiresAt)
+	assert.True(t, expiresAt.Equal(*grant.ExpiresAt))
+
+	assert.ErrorIs(t, svc.UpdatePlatformRolePeriod(user.ID, role.ID,
+		model.RoleGrantPeriod{ExpiresAt: timePtr(time.Now().Add(-time.Minute))}), model.ErrRoleGrantAlreadyExpired)
+
+	// 만료 시각 생략 시 만료 없음으로 변경
+	require.NoError(t, svc.UpdatePlatformRolePeriod(user.ID, role.ID, model.RoleGrantPeriod{}))
+	var cleared model.UserPlatformRole
+	require.NoError(t, db.Where("user_id = ? AND role_id = ?", user.ID, role.ID).First(&cleared).Error)
+	assert.Nil(t, cleared.ExpiresAt)
+}
+
+// 그룹 워크스페이스 역할 변경 시 생략한 기간 필드는 유지하고 clear 플래그로만 해제
+func TestGroupRoleService_UpdateGroupWorkspaceRoleKeepsPeriod(t *testing.T) {
+	db := newRoleGrantTestDB(t)
+	org := createGRTestOrg(t, db, "contractors", "CTR")
+	ws := createGRTestWorkspace(t, db, "ws")
+	viewer := createGRTestRole(t, db, "viewer")
+	operator := createGRTestRole(t, db, "operator")
+	startsAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	require.NoError(t, repository.NewGroupRoleRepository(db).CreateGroupWorkspaceRoleWithPeriod(org.ID, ws.ID, viewer.ID,
+		model.RoleGrantPeriod{StartsAt: &startsAt, ExpiresAt: &expiresAt}))
+	svc := NewGroupRoleService(db)
+	load := func() model.GroupWorkspaceRole {
+		var grant model.GroupWorkspaceRole
+		require.NoError(t, db.Where("group_id = ? AND workspace_id = ?", org.ID, ws.ID).First(&grant).Error)
+		return grant
+	}
+
+	// 역할만 변경하면 저장된 기간 유지
+	require.NoError(t, svc.UpdateGroupWorkspaceRoleWithPeriod(org.ID, ws.ID, &model.UpdateGroupWorkspaceRoleRequest{RoleID: operator.ID}))
+	grant := load()
+	assert.Equal(t, operator.ID, grant.RoleID)
+	require.NotNil(t, grant.StartsAt)
+	require.NotNil(t, grant.ExpiresAt)
+	assert.True(t, startsAt.Equal(*grant.StartsAt))
+	assert.True(t, expiresAt.Equal(*grant.ExpiresAt))
+
+	// 만료 시각만 변경
+	extended := expiresAt.Add(time.Hour)
+	require.NoError(t, svc.UpdateGroupWorkspaceRoleWithPeriod(org.ID, ws.ID, &model.UpdateGroupWorkspaceRoleRequest{RoleID: operator.ID, ExpiresAt: &extended}))
+	grant = load()
+	require.NotNil(t, grant.StartsAt)
+	assert.True(t, extended.Equal(*grant.ExpiresAt))
+
+	// clear 플래그로 만료 해제
+	require.NoError(t, svc.UpdateGroupWorkspaceRoleWithPeriod(org.ID, ws.ID, &model.UpdateGroupWorkspaceRoleRequest{RoleID: operator.ID, ClearExpiresAt: true}))
+	grant = load()
+	assert.NotNil(t, grant.StartsAt)
+	assert.Nil(t, grant.ExpiresAt)
+}
+
+func TestEffectiveWorkspaceRoles_IgnoreInactiveGrants(t *testing.T) {
+	db := newRoleGrantTestDB(t)
+	user := createGRTestUser(t, db, "contractor", "kc-contractor")
+	active := createGRTestWorkspace(t, db, "ws-active")
+	pending := createGRTestWorkspace(t, db, "ws-pending")
+	expired := createGRTestWorkspace(t, db, "ws-expired")
+	groupWs := createGRTestWorkspace(t, db, "ws-group")
+	role := createGRTestRole(t, db, "operator")
+	org := createGRTestOrg(t, db, "oncall", "ONCALL")
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: user.ID, OrganizationID: org.ID}).Error)
+
+	now := time.Now().UTC()
+	roleRepo := repository.NewRoleRepository(db)
+	require.NoError(t, roleRepo.AssignWorkspaceRoleWithPeriod(user.ID, active.ID, role.ID,
+		model.RoleGrantPeriod{ExpiresAt: timePtr(now.Add(time.Hour))}))
+	require.NoError(t, roleRepo.AssignWorkspaceRoleWithPeriod(user.ID, pending.ID, role.ID,
+		model.RoleGrantPeriod{StartsAt: timePtr(now.Add(time.Hour))}))
+	require.NoError(t, roleRepo.AssignWorkspaceRoleWithPeriod(user.ID, expired.ID, role.ID,
+		model.RoleGrantPeriod{ExpiresAt: timePtr(now.Add(-time.Minute))}))
+	require.NoError(t, repository.NewGroupRoleRepository(db).CreateGroupWorkspaceRoleWithPeriod(org.ID, groupWs.ID, role.ID,
+		model.RoleGrantPeriod{ExpiresAt: timePtr(now.Add(-time.Minute))}))
+
+	roles, err := roleRepo.FindEffectiveWorkspaceRoles(user.ID)
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+	assert.Equal(t, active.ID, roles[0].WorkspaceID)
+
+	grants, err := repository.NewAuthzRepository(db).FindWorkspaceRoleGrants(user.ID, []uint{active.ID, pending.ID, expired.ID, groupWs.ID})
+	require.NoError(t, err)
+	require.Len(t, grants, 1)
+	assert.Equal(t, active.ID, grants[0].WorkspaceID)
+}
+
+func TestRoleGrantReaper_RemovesExpiredGrants(t *testing.T) {
+	db := newRoleGrantTestDB(t)
+	user := createGRTestUser(t, db, "oncall", "kc-oncall")
+	ws := createGRTestWorkspace(t, db, "ws")
+	platformRole := createGRTestRole(t, db, "operator")
+	keptRole := createGRTestRole(t, db, "viewer")
+	org := createGRTestOrg(t, db, "contractors", "CTR")
+
+	past := timePtr(time.Now().UTC().Add(-time.Minute))
+	roleRepo := repository.NewRoleRepository(db)
+	require.NoError(t, roleRepo.AssignPlatformRoleWithPeriod(user.ID, platformRole.ID, model.RoleGrantPeriod{ExpiresAt: past}))
+	require.NoError(t, roleRepo.AssignPlatformRole(user.ID, keptRole.ID))
+	require.NoError(t, roleRepo.AssignWorkspaceRoleWithPeriod(user.ID, ws.ID, platformRole.ID, model.RoleGrantPeriod{ExpiresAt: past}))
+	require.NoError(t, repository.NewGroupRoleRepository(db).CreateGroupWorkspaceRoleWithPeriod(org.ID, ws.ID, platformRole.ID,
+		model.RoleGrantPeriod{ExpiresAt: past}))
+
+	kc := &recordingKeycloakService{}
+	reaper := NewRoleGrantReaper(db)
+	reaper.kcService = kc
+
+	reaped, err := reaper.ReapExpired(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, reaped, 3)
+	assert.Equal(t, []string{"kc-oncall/operator"}, kc.removed)
+
+	var platformCount, workspaceCount, groupCount, auditCount int64
+	db.Model(&model.UserPlatformRole{}).Where("user_id = ?", user.ID).Count(&platformCount)
+	db.Model(&model.UserWorkspaceRole{}).Count(&workspaceCount)
+	db.Model(&model.GroupWorkspaceRole{}).Count(&groupCount)
+	db.Model(&model.AuditEvent{}).Where("actor_kc_user_id = ?", roleGrantReaperActor).Count(&auditCount)
+	assert.Equal(t, int64(1), platformCount, "만료 시각이 없는 역할은 유지")
+	assert.Equal(t, int64(0), workspaceCount)
+	assert.Equal(t, int64(0), groupCount)
+	assert.Equal(t, int64(3), auditCount)
+
+	// 회수할 항목이 없으면 아무 것도 하지 않는다
+	reaped, err = reaper.ReapExpired(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, reaped)
+}
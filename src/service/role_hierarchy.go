@@ -0,0 +1,155 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+)
+
+var (
+	ErrRoleParentNotFound = errors.New("parent role not found")
+	ErrRoleHierarchyCycle = errors.New("parent role would create a cycle in the role hierarchy")
+)
+
+// ValidateRoleParent 상위 역할 존재 여부, 소유 회사, 순환 여부 확인 (roleID가 0이면 companyID 회사의 신규 역할)
+// 상위 역할은 공용(company_id 없음)이거나 같은 회사 소유여야 한다. 기존 역할은 저장된 company_id 기준으로 확인하며,
+// 다른 회사의 전용 역할은 존재를 드러내지 않도록 ErrRoleParentNotFound로 거부한다.
+func (s *RoleService) ValidateRoleParent(roleID uint, parentID *uint, companyID *uint) error {
+	if parentID == nil || *parentID == 0 {
+		return nil
+	}
+	if roleID != 0 && *parentID == roleID {
+		return ErrRoleHierarchyCycle
+	}
+	parent, err := s.roleRepository.FindRoleByRoleID(*parentID, "")
+	if err != nil {
+		return err
+	}
+	if parent == nil {
+		return ErrRoleParentNotFound
+	}
+	if roleID != 0 {
+		role, err := s.roleRepository.FindRoleByRoleID(roleID, "")
+		if err != nil {
+			return err
+		}
+		if role != nil {
+			companyID = role.CompanyID
+		}
+	}
+	if !roleParentCompanyAllowed(companyID, parent.CompanyID) {
+		return ErrRoleParentNotFound
+	}
+	if roleID == 0 {
+		return nil
+	}
+	ancestors, err := s.roleRepository.FindRoleAncestors([]uint{*parentID})
+	if err != nil {
+		return err
+	}
+	for _, id := range ancestors[*parentID] {
+		if id == roleID {
+			return ErrRoleHierarchyCycle
+		}
+	}
+	return nil
+}
+
+// roleParentCompanyAllowed 상위 역할이 공용(company_id 없음)이거나 하위 역할과 같은 회사 소유인지 확인
+func roleParentCompanyAllowed(companyID, parentCompanyID *uint) bool {
+	return parentCompanyID == nil || (companyID != nil && *parentCompanyID == *companyID)
+}
+
+// GetRoleEffectiveGrants 역할의 유효 메뉴/권한/CSP 역할 (자신 + 상위 역할 상속)
+func (s *RoleService) GetRoleEffectiveGrants(roleID uint) (*model.RoleEffectiveGrantsResponse, error) {
+	role, err := s.roleRepository.FindRoleByRoleID(roleID, "")
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, nil
+	}
+
+	ancestors, err := s.roleRepository.FindRoleAncestors([]uint{roleID})
+	if err != nil {
+		return nil, err
+	}
+	chain := append([]uint{roleID}, ancestors[roleID]...) // 가까운 순
+	names, err := s.roleRepository.FindRoleNames(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &model.RoleEffectiveGrantsResponse{
+		RoleID:               role.ID,
+		RoleName:             role.Name,
+		Ancestors:            []model.RoleRef{},
+		Menus:                []model.RoleEffectiveGrant{},
+		PlatformPermissions:  []model.RoleEffectiveGrant{},
+		WorkspacePermissions: []model.RoleEffectiveGrant{},
+		CspRoles:             []model.RoleEffectiveCspRole{},
+	}
+	for _, id := range chain[1:] {
+		res.Ancestors = append(res.Ancestors, model.RoleRef{ID: id, Name: names[id]})
+	}
+
+	menus, err := s.roleRepository.FindRoleMenuIDs(chain)
+	if err != nil {
+		return nil, err
+	}
+	res.Menus = inheritGrants(chain, names, menus)
+
+	platformPermissions, err := s.roleRepository.FindRolePermissionIDs(constants.RoleTypePlatform, chain)
+	if err != nil {
+		return nil, err
+	}
+	res.PlatformPermissions = inheritGrants(chain, names, platformPermissions)
+
+	workspacePermissions, err := s.roleRepository.FindRolePermissionIDs(constants.RoleTypeWorkspace, chain)
+	if err != nil {
+		return nil, err
+	}
+	res.WorkspacePermissions = inheritGrants(chain, names, workspacePermissions)
+
+	cspRoles, err := s.roleRepository.FindRoleCspRoles(chain)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, id := range chain {
+		for _, cspRole := range cspRoles[id] {
+			key := cspRole.AuthMethod + "/" + cspRole.CspType + "/" + cspRole.Name
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			cspRole.SourceRoleID = id
+			cspRole.SourceRoleName = names[id]
+			cspRole.Inherited = id != roleID
+			res.CspRoles = append(res.CspRoles, cspRole)
+		}
+	}
+	return res, nil
+}
+
+// inheritGrants 역할 체인(자신 → 상위 순)의 부여 항목을 가까운 역할 기준으로 병합
+func inheritGrants(chain []uint, names map[uint]string, grants map[uint][]string) []model.RoleEffectiveGrant {
+	result := []model.RoleEffectiveGrant{}
+	seen := make(map[string]bool)
+	for _, id := range chain {
+		for _, value := range grants[id] {
+			if seen[value] {
+				continue
+			}
+			seen[value] = true
+			result = append(result, model.RoleEffectiveGrant{
+				ID:             value,
+				SourceRoleID:   id,
+				SourceRoleName: names[id],
+				Inherited:      id != chain[0],
+			})
+		}
+	}
+	return result
+}
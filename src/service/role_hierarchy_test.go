@@ -0,0 +1,172 @@
+package service
+
+// role_hierarchy_test.go
+// 역할 상속(parent_id) 단위 테스트 (SQLite in-memory DB)
+//
+// 테스트 범위:
+//   - 상위 역할 지정 시 존재 여부 / 소유 회사 / 순환 검증
+//   - 유효 메뉴, MciamPermission, CSP 역할 매핑 (자신 + 상위 역할)
+//   - 상위 역할 메뉴를 포함한 사용자 메뉴 트리
+
+import (
+	"context"
+	"testing"
+
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupRoleHierarchyTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&model.RoleMaster{},
+		&model.RoleSub{},
+		&model.Menu{},
+		&model.RoleMenuMapping{},
+		&model.MciamRoleMciamPermission{},
+		&model.CspRole{},
+		&model.RoleMasterCspRoleMapping{},
+	))
+	return db
+}
+
+func seedChildRole(t *testing.T, db *gorm.DB, name string, parentID uint) *model.RoleMaster {
+	t.Helper()
+	r := &model.RoleMaster{Name: name, ParentID: &parentID}
+	require.NoError(t, db.Create(r).Error)
+	return r
+}
+
+func TestValidateRoleParent_RejectsMissingParentAndCycle(t *testing.T) {
+	db := setupRoleHierarchyTestDB(t)
+	svc := NewRoleService(db)
+	root := seedRoleMaster(t, db, "root")
+	mid := seedChildRole(t, db, "mid", root.ID)
+	leaf := seedChildRole(t, db, "leaf", mid.ID)
+
+	missing := uint(999)
+	assert.ErrorIs(t, svc.ValidateRoleParent(0, &missing, nil), ErrRoleParentNotFound)
+	assert.NoError(t, svc.ValidateRoleParent(0, &leaf.ID, nil))
+	assert.NoError(t, svc.ValidateRoleParent(root.ID, nil, nil))
+
+	// 자기 자신, 하위 역할을 상위로 지정하면 순환
+	assert.ErrorIs(t, svc.ValidateRoleParent(root.ID, &root.ID, nil), ErrRoleHierarchyCycle)
+	assert.ErrorIs(t, svc.ValidateRoleParent(root.ID, &leaf.ID, nil), ErrRoleHierarchyCycle)
+
+	_, err := svc.UpdateRoleWithSubs(model.RoleMaster{ID: mid.ID, Name: "mid", ParentID: &leaf.ID}, []constants.IAMRoleType{constants.RoleTypePlatform})
+	assert.ErrorIs(t, err, ErrRoleHierarchyCycle)
+
+	// 형제 역할로 옮기는 것은 허용
+	other := seedRoleMaster(t, db, "other")
+	_, err = svc.UpdateRoleWithSubs(model.RoleMaster{ID: mid.ID, Name: "mid", ParentID: &other.ID}, []constants.IAMRoleType{constants.RoleTypePlatform})
+	assert.NoError(t, err)
+}
+
+func TestValidateRoleParent_RejectsOtherCompanyRole(t *testing.T) {
+	db := setupRoleHierarchyTestDB(t)
+	svc := NewRoleService(db)
+	companyA, companyB := uint(1), uint(2)
+	shared := seedRoleMaster(t, db, "shared")
+	privateA := &model.RoleMaster{Name: "private-a", CompanyID: &companyA}
+	require.NoError(t, db.Create(privateA).Error)
+	privateB := &model.RoleMaster{Name: "private-b", CompanyID: &companyB}
+	require.NoError(t, db.Create(privateB).Error)
+
+	// 공용 역할과 같은 회사 역할은 상위로 지정 가능
+	assert.NoError(t, svc.ValidateRoleParent(0, &shared.ID, &companyB))
+	assert.NoError(t, svc.ValidateRoleParent(0, &privateB.ID, &companyB))
+
+	// 다른 회사 전용 역할은 존재하지 않는 역할로 취급
+	assert.ErrorIs(t, svc.ValidateRoleParent(0, &privateA.ID, &companyB), ErrRoleParentNotFound)
+	assert.ErrorIs(t, svc.ValidateRoleParent(0, &privateA.ID, nil), ErrRoleParentNotFound)
+
+	// 기존 역할은 저장된 company_id 기준
+	_, err := svc.UpdateRoleWithSubs(model.RoleMaster{ID: privateB.ID, Name: "private-b", ParentID: &privateA.ID}, []constants.IAMRoleType{constants.RoleTypePlatform})
+	assert.ErrorIs(t, err, ErrRoleParentNotFound)
+	_, err = svc.UpdateRoleWithSubs(model.RoleMaster{ID: shared.ID, Name: "shared", ParentID: &privateB.ID}, []constants.IAMRoleType{constants.RoleTypePlatform})
+	assert.ErrorIs(t, err, ErrRoleParentNotFound)
+}
+
+func TestGetRoleEffectiveGrants_InheritsFromAncestors(t *testing.T) {
+	db := setupRoleHierarchyTestDB(t)
+	svc := NewRoleService(db)
+	root := seedRoleMaster(t, db, "root")
+	child := seedChildRole(t, db, "child", root.ID)
+
+	require.NoError(t, db.Create(&model.RoleMenuMapping{RoleID: root.ID, MenuID: "dashboard"}).Error)
+	require.NoError(t, db.Create(&model.RoleMenuMapping{RoleID: child.ID, MenuID: "dashboard"}).Error)
+	require.NoError(t, db.Create(&model.RoleMenuMapping{RoleID: child.ID, MenuID: "vm"}).Error)
+	require.NoError(t, db.Create(&model.MciamRoleMciamPermission{
+		RoleType: constants.RoleTypeWorkspace, RoleID: root.ID, PermissionID: "mc-iam-manager:workspace:read",
+	}).Error)
+	cspRole := &model.CspRole{Name: "mciam-root", CspType: "aws"}
+	require.NoError(t, db.Create(cspRole).Error)
+	require.NoError(t, db.Create(&model.RoleMasterCspRoleMapping{
+		RoleID: root.ID, CspRoleID: cspRole.ID, AuthMethod: constants.AuthMethodOIDC,
+	}).Error)
+
+	res, err := svc.GetRoleEffectiveGrants(child.ID)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.Equal(t, []model.RoleRef{{ID: root.ID, Name: "root"}}, res.Ancestors)
+
+	// 같은 메뉴는 가까운 역할(자신) 기준
+	require.Len(t, res.Menus, 2)
+	assert.Equal(t, "dashboard", res.Menus[0].ID)
+	assert.False(t, res.Menus[0].Inherited)
+	assert.Equal(t, "vm", res.Menus[1].ID)
+
+	require.Len(t, res.WorkspacePermissions, 1)
+	assert.True(t, res.WorkspacePermissions[0].Inherited)
+	assert.Equal(t, root.ID, res.WorkspacePermissions[0].SourceRoleID)
+	assert.Empty(t, res.PlatformPermissions)
+
+	require.Len(t, res.CspRoles, 1)
+	assert.Equal(t, cspRole.ID, res.CspRoles[0].CspRoleID)
+	assert.True(t, res.CspRoles[0].Inherited)
+
+	res, err = svc.GetRoleEffectiveGrants(999)
+	require.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestBuildUserMenuTree_OnlyMappedAndInheritedMenus(t *testing.T) {
+	db := setupRoleHierarchyTestDB(t)
+	svc := NewMenuService(db)
+	root := seedRoleMaster(t, db, "root")
+	child := seedChildRole(t, db, "child", root.ID)
+	other := seedRoleMaster(t, db, "other")
+
+	for _, menu := range []*model.Menu{
+		{ID: "settings", DisplayName: "Settings", ResType: "menu", MenuNumber: 1},
+		{ID: "users", ParentID: "settings", DisplayName: "Users", ResType: "menu", MenuNumber: 2},
+		{ID: "roles", ParentID: "settings", DisplayName: "Roles", ResType: "menu", MenuNumber: 3},
+		{ID: "billing", DisplayName: "Billing", ResType: "menu", MenuNumber: 4},
+	} {
+		require.NoError(t, db.Create(menu).Error)
+	}
+	require.NoError(t, db.Create(&model.RoleMenuMapping{RoleID: root.ID, MenuID: "users"}).Error)
+	require.NoError(t, db.Create(&model.RoleMenuMapping{RoleID: child.ID, MenuID: "roles"}).Error)
+	require.NoError(t, db.Create(&model.RoleMenuMapping{RoleID: other.ID, MenuID: "billing"}).Error)
+
+	tree, err := svc.BuildUserMenuTree(context.Background(), []uint{child.ID})
+	require.NoError(t, err)
+	require.Len(t, tree, 1)
+	assert.Equal(t, "settings", tree[0].ID)
+	require.Len(t, tree[0].Children, 2)
+	assert.Equal(t, "users", tree[0].Children[0].ID)
+	assert.Equal(t, "roles", tree[0].Children[1].ID)
+
+	tree, err = svc.BuildUserMenuTree(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, tree)
+}
@@ -1,7 +1,10 @@
 package service
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"time"
 
 	"github.com/m-cmp/mc-iam-manager/constants"
 	"github.com/m-cmp/mc-iam-manager/model"
@@ -14,6 +17,7 @@ import (
 type RoleService struct {
 	db             *gorm.DB
 	roleRepository *repository.RoleRepository
+	ticketRepo     *repository.WorkspaceTicketRepository
 }
 
 // NewRoleService 새 RoleService 인스턴스 생성
@@ -21,6 +25,7 @@ func NewRoleService(db *gorm.DB) *RoleService {
 	return &RoleService{
 		db:             db,
 		roleRepository: repository.NewRoleRepository(db),
+		ticketRepo:     repository.NewWorkspaceTicketRepository(db),
 	}
 }
 
@@ -46,6 +51,9 @@ func (s *RoleService) ExistRoleByName(roleName string, roleType constants.IAMRol
 
 // CreateRoleWithSubs 역할과 서브 타입을 함께 생성합니다.
 func (s *RoleService) CreateRoleWithSubs(role *model.RoleMaster, roleSubs []model.RoleSub) (*model.RoleMaster, error) {
+	if err := s.ValidateRoleParent(0, role.ParentID, role.CompanyID); err != nil {
+		return nil, err
+	}
 	return s.roleRepository.CreateRoleWithSubs(role, roleSubs)
 }
 
@@ -58,6 +66,9 @@ func (s *RoleService) CreateRoleWithAllDependencies(
 	cspRoles []model.CreateCspRoleRequest,
 	description string,
 ) (*model.RoleMaster, error) {
+	if err := s.ValidateRoleParent(0, role.ParentID, role.CompanyID); err != nil {
+		return nil, err
+	}
 	var createdRole *model.RoleMaster
 
 	err := s.db.Transaction(func(tx *gorm.DB) error {
@@ -136,11 +147,17 @@ func (s *RoleService) CreateRoleWithAllDependencies(
 
 // UpdateRoleWithSubs 역할과 역할 서브 타입들을 함께 수정
 func (s *RoleService) UpdateRoleWithSubs(role model.RoleMaster, roleTypes []constants.IAMRoleType) (*model.RoleMaster, error) {
+	if err := s.ValidateRoleParent(role.ID, role.ParentID, nil); err != nil {
+		return nil, err
+	}
 	return s.roleRepository.UpdateRoleWithSubs(role, roleTypes)
 }
 
 // UpdateRoleWithSubsWithTx 트랜잭션 내에서 역할과 역할 서브 타입들을 함께 수정
 func (s *RoleService) UpdateRoleWithSubsWithTx(tx *gorm.DB, role model.RoleMaster, roleTypes []constants.IAMRoleType) (*model.RoleMaster, error) {
+	if err := s.ValidateRoleParent(role.ID, role.ParentID, nil); err != nil {
+		return nil, err
+	}
 	return s.roleRepository.UpdateRoleWithSubsWithTx(tx, role, roleTypes)
 }
 
@@ -187,6 +204,16 @@ func (s *RoleService) DeleteRoleWithSubsAndMappings(roleID uint) error {
 
 // AssignPlatformRole 플랫폼 역할 할당
 func (s *RoleService) AssignPlatformRole(userID, roleID uint) error {
+	return s.AssignPlatformRoleWithPeriod(userID, roleID, model.RoleGrantPeriod{})
+}
+
+// AssignPlatformRoleWithPeriod 만료 시각을 지정한 플랫폼 역할 할당 (만료 시 RoleGrantReaper가 회수)
+func (s *RoleService) AssignPlatformRoleWithPeriod(userID, roleID uint, period model.RoleGrantPeriod) error {
+	period = period.UTC()
+	if err := period.Validate(time.Now()); err != nil {
+		return err
+	}
+
 	// 1. 역할이 존재하는지 확인
 	role, err := s.roleRepository.FindRoleByRoleID(roleID, constants.RoleTypePlatform)
 	if err != nil {
@@ -209,11 +236,30 @@ func (s *RoleService) AssignPlatformRole(userID, roleID uint) error {
 	}
 
 	// 3. 역할 할당
-	return s.roleRepository.AssignPlatformRole(userID, roleID)
+	return s.roleRepository.AssignPlatformRoleWithPeriod(userID, roleID, period)
+}
+
+// UpdatePlatformRolePeriod 이미 할당된 플랫폼 역할의 유효 기간 변경 (만료 시각만 지원, nil이면 만료 없음)
+func (s *RoleService) UpdatePlatformRolePeriod(userID, roleID uint, period model.RoleGrantPeriod) error {
+	period = period.UTC()
+	if err := period.Validate(time.Now()); err != nil {
+		return err
+	}
+	return s.roleRepository.UpdatePlatformRoleExpiry(userID, roleID, period.ExpiresAt)
 }
 
 // AssignWorkspaceRole 워크스페이스 역할 할당
 func (s *RoleService) AssignWorkspaceRole(userID, workspaceID, roleID uint) error {
+	return s.AssignWorkspaceRoleWithPeriod(userID, workspaceID, roleID, model.RoleGrantPeriod{})
+}
+
+// AssignWorkspaceRoleWithPeriod 유효 기간을 지정한 워크스페이스 역할 할당 (시작 전/만료 후에는 유효 역할에서 제외)
+func (s *RoleService) AssignWorkspaceRoleWithPeriod(userID, workspaceID, roleID uint, period model.RoleGrantPeriod) error {
+	period = period.UTC()
+	if err := period.Validate(time.Now()); err != nil {
+		return err
+	}
+
 	// 1. 역할이 존재하는지 확인
 	role, err := s.roleRepository.FindRoleByRoleID(roleID, constants.RoleTypeWorkspace)
 	if err != nil {
@@ -236,7 +282,7 @@ func (s *RoleService) AssignWorkspaceRole(userID, workspaceID, roleID uint) erro
 	}
 
 	// 3. 역할 할당
-	return s.roleRepository.AssignWorkspaceRole(userID, workspaceID, roleID)
+	return s.roleRepository.AssignWorkspaceRoleWithPeriod(userID, workspaceID, roleID, period)
 }
 
 // AssignRole 역할 할당 (플랫폼/워크스페이스)
@@ -282,7 +328,14 @@ func (s *RoleService) RemovePlatformRole(userID, roleID uint) error {
 
 // RemoveWorkspaceRole 워크스페이스 역할 제거
 func (s *RoleService) RemoveWorkspaceRole(userID, workspaceID, roleID uint) error {
-	return s.roleRepository.RemoveWorkspaceRole(userID, workspaceID, roleID)
+	if err := s.roleRepository.RemoveWorkspaceRole(userID, workspaceID, roleID); err != nil {
+		return err
+	}
+	// 제거된 역할이 담긴 워크스페이스 티켓은 더 이상 사용할 수 없도록 폐기
+	if _, err := s.ticketRepo.RevokeByUserAndWorkspace(userID, workspaceID, workspaceTicketRevokedByRole); err != nil {
+		log.Printf("[WARN] failed to revoke workspace tickets (userID=%d, workspaceID=%d): %v", userID, workspaceID, err)
+	}
+	return nil
 }
 
 // GetUserWorkspaceRoles 사용자의 워크스페이스 역할 목록 조회
@@ -361,6 +414,11 @@ func (s *RoleService) DeleteRoleCspRoleMappingsByRoleId(roleID uint) error {
 	return s.roleRepository.DeleteRoleCspRoleMappings(roleID)
 }
 
+// GetCspRoleMappingsByRoleID 역할에 연결된 모든 CSP 역할 매핑 조회
+func (s *RoleService) GetCspRoleMappingsByRoleID(roleID uint) ([]model.RoleMasterCspRoleMapping, error) {
+	return s.roleRepository.FindCspRoleMappingsByRoleID(roleID)
+}
+
 // ListWorkspaceRoleCspRoleMappings 워크스페이스 역할-CSP 역할 매핑 목록 조회
 func (s *RoleService) ListWorkspaceRoleCspRoleMappings(req *model.RoleMasterCspRoleMappingRequest) ([]*model.RoleMasterCspRoleMapping, error) {
 	return s.roleRepository.FindWorkspaceRoleCspRoleMappings(req)
@@ -426,6 +484,42 @@ func (s *RoleService) ListWorkspaceRoles(req *model.RoleFilterRequest) ([]*model
 	return roles, nil
 }
 
+// grantPlatformRole 플랫폼 역할을 DB와 Keycloak realm role에 할당. DB에 이미 있으면 Keycloak만 맞춘다 (idempotent).
+// DB에 새로 할당한 경우 true를 반환하며, Keycloak 반영에 실패하면 새로 만든 DB 할당을 되돌린다.
+func grantPlatformRole(ctx context.Context, roleService *RoleService, kc KeycloakService, user *model.User, role *model.RoleMaster) (bool, error) {
+	assigned, err := roleService.IsAssignedPlatformRole(user.ID, role.ID)
+	if err != nil {
+		return false, err
+	}
+	if !assigned {
+		if err := roleService.AssignPlatformRoleWithPeriod(user.ID, role.ID, model.RoleGrantPeriod{}); err != nil {
+			return false, fmt.Errorf("failed to assign platform role: %w", err)
+		}
+	}
+	rollback := func(cause error) (bool, error) {
+		if !assigned {
+			if err := roleService.RemovePlatformRole(user.ID, role.ID); err != nil {
+				log.Printf("[WARN] failed to rollback platform role assignment: %v", err)
+			}
+		}
+		return false, cause
+	}
+
+	exists, err := kc.CheckRealmRoleExists(ctx, role.Name)
+	if err != nil {
+		return rollback(fmt.Errorf("failed to check realm role: %w", err))
+	}
+	if !exists {
+		if err := kc.CreateRealmRoleAndWait(ctx, role.Name); err != nil {
+			return rollback(fmt.Errorf("failed to create realm role: %w", err))
+		}
+	}
+	if err := kc.AssignRealmRoleToUser(ctx, user.KcId, role.Name); err != nil {
+		return rollback(fmt.Errorf("failed to assign realm role: %w", err))
+	}
+	return !assigned, nil
+}
+
 // IsAssignedPlatformRole 사용자에게 특정 플랫폼 역할이 할당되어 있는지 확인
 func (s *RoleService) IsAssignedPlatformRole(userID uint, roleID uint) (bool, error) {
 	return s.roleRepository.IsAssignedPlatformRole(userID, roleID)
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// scimAttributes 필터 평가용 리소스 속성 (소문자 속성 경로 → 값 목록, 다중 값 속성은 여러 값)
+type scimAttributes map[string][]string
+
+// scimFilter SCIM 필터 식 (RFC 7644 3.4.2.2)
+type scimFilter interface {
+	match(attrs scimAttributes) bool
+}
+
+type scimLogicalFilter struct {
+	and         bool
+	left, right scimFilter
+}
+
+func (f *scimLogicalFilter) match(attrs scimAttributes) bool {
+	if f.and {
+		return f.left.match(attrs) && f.right.match(attrs)
+	}
+	return f.left.match(attrs) || f.right.match(attrs)
+}
+
+type scimNotFilter struct {
+	inner scimFilter
+}
+
+func (f *scimNotFilter) match(attrs scimAttributes) bool {
+	return !f.inner.match(attrs)
+}
+
+// scimCompareFilter attrPath op value. id를 제외한 문자열 비교는 대소문자를 구분하지 않는다.
+type scimCompareFilter struct {
+	attr  string
+	op    string
+	value string
+}
+
+func (f *scimCompareFilter) match(attrs scimAttributes) bool {
+	values := attrs[f.attr]
+	if f.op == "pr" {
+		for _, v := range values {
+			if v != "" {
+				return true
+			}
+		}
+		return false
+	}
+	if f.op == "ne" {
+		for _, v := range values {
+			if f.equal(v) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, v := range values {
+		if f.compare(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *scimCompareFilter) equal(v string) bool {
+	if f.attr == "id" {
+		return v == f.value
+	}
+	return strings.EqualFold(v, f.value)
+}
+
+func (f *scimCompareFilter) compare(v string) bool {
+	if f.attr != "id" {
+		v = strings.ToLower(v)
+	}
+	want := f.value
+	if f.attr != "id" {
+		want = strings.ToLower(want)
+	}
+	switch f.op {
+	case "eq":
+		return v == want
+	case "co":
+		return strings.Contains(v, want)
+	case "sw":
+		return strings.HasPrefix(v, want)
+	case "ew":
+		return strings.HasSuffix(v, want)
+	case "gt":
+		return v > want
+	case "ge":
+		return v >= want
+	case "lt":
+		return v < want
+	case "le":
+		return v <= want
+	}
+	return false
+}
+
+var scimCompareOps = map[string]bool{
+	"eq": true, "ne": true, "co": true, "sw": true, "ew": true,
+	"gt": true, "ge": true, "lt": true, "le": true,
+}
+
+// parseScimFilter filter 쿼리 파라미터 파싱. 비어 있으면 nil (전체)
+// 지원: and/or/not, 괄호, eq ne co sw ew gt ge lt le pr. 값 경로 필터(emails[type eq "work"])는 지원하지 않는다.
+func parseScimFilter(filter string) (scimFilter, error) {
+	if strings.TrimSpace(filter) == "" {
+		return nil, nil
+	}
+	tokens, err := tokenizeScimFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	p := &scimFilterParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("%w: unexpected %q", ErrScimInvalidFilter, p.tokens[p.pos].text)
+	}
+	return expr, nil
+}
+
+type scimFilterToken struct {
+	text   string
+	quoted bool
+}
+
+func tokenizeScimFilter(filter string) ([]scimFilterToken, error) {
+	var tokens []scimFilterToken
+	for i := 0; i < len(filter); {
+		ch := filter[i]
+		switch {
+		case ch == ' ' || ch == '\t':
+			i++
+		case ch == '(' || ch == ')':
+			tokens = append(tokens, scimFilterToken{text: string(ch)})
+			i++
+		case ch == '[' || ch == ']':
+			return nil, fmt.Errorf("%w: value path filters are not supported", ErrScimInvalidFilter)
+		case ch == '"':
+			end := i + 1
+			for end < len(filter) && filter[end] != '"' {
+				if filter[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(filter) {
+				return nil, fmt.Errorf("%w: unterminated string", ErrScimInvalidFilter)
+			}
+			var value string
+			if err := json.Unmarshal([]byte(filter[i:end+1]), &value); err != nil {
+				return nil, fmt.Errorf("%w: invalid string %s", ErrScimInvalidFilter, filter[i:end+1])
+			}
+			tokens = append(tokens, scimFilterToken{text: value, quoted: true})
+			i = end + 1
+		default:
+			end := i
+			for end < len(filter) && !strings.ContainsRune(" \t()[]\"", rune(filter[end])) {
+				end++
+			}
+			tokens = append(tokens, scimFilterToken{text: filter[i:end]})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+type scimFilterParser struct {
+	tokens []scimFilterToken
+	pos    int
+}
+
+func (p *scimFilterParser) peekKeyword(keyword string) bool {
+	return p.pos < len(p.tokens) && !p.tokens[p.pos].quoted && strings.EqualFold(p.tokens[p.pos].text, keyword)
+}
+
+func (p *scimFilterParser) parseOr() (scimFilter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &scimLogicalFilter{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *scimFilterParser) parseAnd() (scimFilter, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("and") {
+		p.pos++
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = &scimLogicalFilter{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *scimFilterParser) parseFactor() (scimFilter, error) {
+	if p.peekKeyword("not") {
+		p.pos++
+		inner, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return &scimNotFilter{inner: inner}, nil
+	}
+	if p.peekKeyword("(") {
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.peekKeyword(")") {
+			return nil, fmt.Errorf("%w: missing )", ErrScimInvalidFilter)
+		}
+		p.pos++
+		return inner, nil
+	}
+
+	if p.pos+1 >= len(p.tokens) || p.tokens[p.pos].quoted {
+		return nil, fmt.Errorf("%w: expected attribute and operator", ErrScimInvalidFilter)
+	}
+	attr := normalizeScimAttrPath(p.tokens[p.pos].text)
+	op := strings.ToLower(p.tokens[p.pos+1].text)
+	p.pos += 2
+	if op == "pr" {
+		return &scimCompareFilter{attr: attr, op: op}, nil
+	}
+	if !scimCompareOps[op] {
+		return nil, fmt.Errorf("%w: unsupported operator %q", ErrScimInvalidFilter, op)
+	}
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("%w: missing value for %s", ErrScimInvalidFilter, attr)
+	}
+	value := p.tokens[p.pos]
+	p.pos++
+	if !value.quoted {
+		// true/false/null/숫자 리터럴
+		value.text = strings.ToLower(value.text)
+		if value.text == "null" {
+			value.text = ""
+		}
+	}
+	return &scimCompareFilter{attr: attr, op: op, value: value.text}, nil
+}
+
+// normalizeScimAttrPath 스키마 URN 접두어 제거 후 소문자 속성 경로 (예: emails → emails.value)
+func normalizeScimAttrPath(path string) string {
+	for _, schema := range []string{scimSchemaPrefixUser, scimSchemaPrefixGroup} {
+		if len(path) > len(schema) && strings.EqualFold(path[:len(schema)], schema) {
+			path = path[len(schema):]
+			break
+		}
+	}
+	path = strings.ToLower(path)
+	switch path {
+	case "emails", "groups", "members":
+		return path + ".value"
+	}
+	return path
+}
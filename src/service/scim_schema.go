@@ -0,0 +1,134 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+)
+
+// ScimServiceProviderConfig 지원 기능 안내 (RFC 7643 5장)
+func ScimServiceProviderConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"schemas":          []string{model.ScimSchemaServiceProviderConfig},
+		"documentationUri": "https://datatracker.ietf.org/doc/html/rfc7644",
+		"patch":            map[string]interface{}{"supported": true},
+		"bulk":             map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":           map[string]interface{}{"supported": true, "maxResults": scimMaxCount},
+		"changePassword":   map[string]interface{}{"supported": false},
+		"sort":             map[string]interface{}{"supported": false},
+		"etag":             map[string]interface{}{"supported": false},
+		"authenticationSchemes": []map[string]interface{}{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "Authentication with the SCIM provisioning token (MC_IAM_MANAGER_SCIM_TOKEN) in the Authorization: Bearer header",
+			"primary":     true,
+		}},
+		"meta": map[string]interface{}{
+			"resourceType": "ServiceProviderConfig",
+			"location":     model.ScimBasePath + "/ServiceProviderConfig",
+		},
+	}
+}
+
+// ScimResourceTypes 지원 리소스 유형 (User, Group)
+func ScimResourceTypes() []map[string]interface{} {
+	resourceType := func(name, endpoint, schema string) map[string]interface{} {
+		return map[string]interface{}{
+			"schemas":     []string{model.ScimSchemaResourceType},
+			"id":          name,
+			"name":        name,
+			"endpoint":    endpoint,
+			"description": name,
+			"schema":      schema,
+			"meta": map[string]interface{}{
+				"resourceType": "ResourceType",
+				"location":     model.ScimBasePath + "/ResourceTypes/" + name,
+			},
+		}
+	}
+	return []map[string]interface{}{
+		resourceType("User", "/Users", model.ScimSchemaUser),
+		resourceType("Group", "/Groups", model.ScimSchemaGroup),
+	}
+}
+
+// ScimSchemas 지원 속성 스키마 (User, Group). 실제로 매핑하는 속성만 나열한다.
+func ScimSchemas() []map[string]interface{} {
+	return []map[string]interface{}{scimUserSchema(), scimGroupSchema()}
+}
+
+// ScimSchema 스키마 URN으로 조회
+func ScimSchema(id string) (map[string]interface{}, error) {
+	for _, schema := range ScimSchemas() {
+		if schema["id"] == id {
+			return schema, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: schema %s", ErrScimNotFound, id)
+}
+
+// scimAttribute 스키마 속성 정의 (mutability: readWrite/readOnly, uniqueness: none/server)
+func scimAttribute(name, attrType string, multiValued, required bool, mutability, uniqueness string, subAttributes ...map[string]interface{}) map[string]interface{} {
+	attr := map[string]interface{}{
+		"name":        name,
+		"type":        attrType,
+		"multiValued": multiValued,
+		"required":    required,
+		"caseExact":   false,
+		"mutability":  mutability,
+		"returned":    "default",
+		"uniqueness":  uniqueness,
+	}
+	if len(subAttributes) > 0 {
+		attr["subAttributes"] = subAttributes
+	}
+	return attr
+}
+
+func scimSchemaDocument(id, name, description string, attributes ...map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"schemas":     []string{model.ScimSchemaSchema},
+		"id":          id,
+		"name":        name,
+		"description": description,
+		"attributes":  attributes,
+		"meta": map[string]interface{}{
+			"resourceType": "Schema",
+			"location":     model.ScimBasePath + "/Schemas/" + id,
+		},
+	}
+}
+
+func scimUserSchema() map[string]interface{} {
+	return scimSchemaDocument(model.ScimSchemaUser, "User", "User Account",
+		scimAttribute("userName", "string", false, true, "readWrite", "server"),
+		scimAttribute("name", "complex", false, false, "readWrite", "none",
+			scimAttribute("formatted", "string", false, false, "readOnly", "none"),
+			scimAttribute("givenName", "string", false, false, "readWrite", "none"),
+			scimAttribute("familyName", "string", false, false, "readWrite", "none"),
+		),
+		scimAttribute("displayName", "string", false, false, "readOnly", "none"),
+		scimAttribute("emails", "complex", true, false, "readWrite", "none",
+			scimAttribute("value", "string", false, false, "readWrite", "none"),
+			scimAttribute("type", "string", false, false, "readWrite", "none"),
+			scimAttribute("primary", "boolean", false, false, "readWrite", "none"),
+		),
+		scimAttribute("active", "boolean", false, false, "readWrite", "none"),
+		scimAttribute("groups", "complex", true, false, "readOnly", "none",
+			scimAttribute("value", "string", false, false, "readOnly", "none"),
+			scimAttribute("$ref", "reference", false, false, "readOnly", "none"),
+			scimAttribute("display", "string", false, false, "readOnly", "none"),
+		),
+	)
+}
+
+func scimGroupSchema() map[string]interface{} {
+	return scimSchemaDocument(model.ScimSchemaGroup, "Group", "Group (organization)",
+		scimAttribute("displayName", "string", false, true, "readWrite", "server"),
+		scimAttribute("members", "complex", true, false, "readWrite", "none",
+			scimAttribute("value", "string", false, false, "immutable", "none"),
+			scimAttribute("$ref", "reference", false, false, "immutable", "none"),
+			scimAttribute("display", "string", false, false, "readOnly", "none"),
+		),
+	)
+}
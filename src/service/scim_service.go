@@ -0,0 +1,984 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	// scimDefaultCount count 파라미터가 없을 때 한 페이지 결과 수
+	scimDefaultCount = 100
+	// scimMaxCount 한 페이지 최대 결과 수 (ServiceProviderConfig filter.maxResults)
+	scimMaxCount = 200
+
+	tokenRevokedByScim = "user deprovisioned via SCIM"
+
+	scimSchemaPrefixUser  = model.ScimSchemaUser + ":"
+	scimSchemaPrefixGroup = model.ScimSchemaGroup + ":"
+	// scimExtensionPrefix 확장 스키마 속성 (예: enterprise User) - 저장하지 않고 무시한다
+	scimExtensionPrefix = "urn:ietf:params:scim:schemas:extension:"
+)
+
+var (
+	// ErrScimNotFound 리소스 없음 (탈퇴한 사용자 포함)
+	ErrScimNotFound = errors.New("scim resource not found")
+	// ErrScimConflict 사용자명/그룹 이름 중복 또는 하위 그룹이 있는 그룹 삭제
+	ErrScimConflict = errors.New("scim resource conflict")
+	// ErrScimInvalidValue 필수 속성 누락, 잘못된 값, 존재하지 않는 구성원
+	ErrScimInvalidValue = errors.New("invalid scim value")
+	// ErrScimInvalidFilter 지원하지 않거나 잘못된 filter
+	ErrScimInvalidFilter = errors.New("invalid scim filter")
+	// ErrScimInvalidPath 지원하지 않거나 읽기 전용인 PATCH 경로
+	ErrScimInvalidPath = errors.New("invalid scim path")
+)
+
+// ScimService SCIM 2.0 프로비저닝 서비스
+// User는 Keycloak 사용자와 mcmp_users(id = Keycloak ID), Group은 조직(Organization)과 소속(UserOrganization)에 매핑한다.
+// 비활성화(active=false)는 Keycloak 비활성화 + INACTIVE, 삭제는 탈퇴(WITHDRAWN) 처리한다.
+type ScimService struct {
+	db          *gorm.DB
+	userRepo    *repository.UserRepository
+	orgRepo     *repository.OrganizationRepository
+	orgService  *OrganizationService
+	kcService   KeycloakService
+	revocations *TokenRevocationService
+}
+
+// NewScimService 새 ScimService 인스턴스 생성
+func NewScimService(db *gorm.DB) *ScimService {
+	return &ScimService{
+		db:          db,
+		userRepo:    repository.NewUserRepository(db),
+		orgRepo:     repository.NewOrganizationRepository(db),
+		orgService:  NewOrganizationService(db),
+		kcService:   NewKeycloakService(),
+		revocations: NewTokenRevocationService(db),
+	}
+}
+
+// --- Users ---
+
+// ListUsers 사용자 목록 조회 (filter, startIndex는 1부터, count는 최대 scimMaxCount이며 음수이면 기본값)
+func (s *ScimService) ListUsers(ctx context.Context, filter string, startIndex, count int) (*model.ScimListResponse, error) {
+	expr, err := parseScimFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	kcUsers, err := s.kcService.GetUsers(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	kcIDs := make([]string, 0, len(kcUsers))
+	kcUserMap := make(map[string]*gocloak.User, len(kcUsers))
+	for _, u := range kcUsers {
+		if u != nil && u.ID != nil {
+			kcIDs = append(kcIDs, *u.ID)
+			kcUserMap[*u.ID] = u
+		}
+	}
+	users, err := s.userRepo.GetUsersByKcIDs(kcIDs)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := s.userGroups()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Username < users[j].Username })
+
+	var matched []interface{}
+	for i := range users {
+		user := &users[i]
+		if !isScimVisibleUser(user) {
+			continue
+		}
+		resource := scimUserResource(user, kcUserMap[user.KcId], groups[user.ID])
+		if expr != nil && !expr.match(scimUserAttributes(resource)) {
+			continue
+		}
+		matched = append(matched, resource)
+	}
+	return scimListPage(matched, startIndex, count), nil
+}
+
+// GetUser 사용자 조회 (id = Keycloak 사용자 ID)
+func (s *ScimService) GetUser(ctx context.Context, id string) (*model.ScimUser, error) {
+	user, kcUser, err := s.loadUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	orgs, err := s.orgRepo.FindDirectUserOrganizations(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]model.ScimMember, 0, len(orgs))
+	for _, org := range orgs {
+		groups = append(groups, scimGroupRef(&org))
+	}
+	resource := scimUserResource(user, kcUser, groups)
+	return &resource, nil
+}
+
+// CreateUser 사용자 생성. Keycloak에만 있는 같은 사용자명의 사용자는 새로 만들지 않고 연결한다.
+func (s *ScimService) CreateUser(ctx context.Context, req *model.ScimUser) (*model.ScimUser, error) {
+	userName := strings.ToLower(strings.TrimSpace(req.UserName))
+	if userName == "" {
+		return nil, fmt.Errorf("%w: userName is required", ErrScimInvalidValue)
+	}
+	if _, err := s.userRepo.FindByUsername(userName); err == nil {
+		return nil, fmt.Errorf("%w: userName %q already exists", ErrScimConflict, userName)
+	} else if !errors.Is(err, repository.ErrUserNotFound) {
+		return nil, err
+	}
+	kcUser, err := s.kcService.GetUserByUsername(ctx, userName)
+	if err != nil && !errors.Is(err, repository.ErrUserNotFound) {
+		return nil, err
+	}
+
+	profile := scimUserProfile(req)
+	profile.Username = userName
+	enabled := true
+	var user *model.User
+	if kcUser != nil && kcUser.ID != nil {
+		enabled = ptrBool(kcUser.Enabled)
+		profile.KcId = *kcUser.ID
+		profile.Enabled = enabled
+		if err := s.kcService.UpdateUser(ctx, profile); err != nil {
+			return nil, err
+		}
+		if user, err = s.userRepo.Create(&model.User{KcId: profile.KcId, Username: userName}); err != nil {
+			return nil, err
+		}
+	} else {
+		kcID, err := s.kcService.CreateUser(ctx, profile)
+		if err != nil {
+			return nil, err
+		}
+		if user, err = s.userRepo.Create(&model.User{KcId: kcID, Username: userName}); err != nil {
+			if rollbackErr := s.kcService.DeleteUser(ctx, kcID); rollbackErr != nil {
+				log.Printf("CRITICAL: KC rollback failed after SCIM user DB error (kcId: %s): %v", kcID, rollbackErr)
+			}
+			return nil, fmt.Errorf("failed to create user in DB after Keycloak: %w", err)
+		}
+	}
+	if req.Active != nil && *req.Active != enabled {
+		if err := s.setActive(ctx, user, *req.Active); err != nil {
+			return nil, err
+		}
+	}
+	return s.GetUser(ctx, user.KcId)
+}
+
+// ReplaceUser 사용자 전체 교체 (PUT). 요청에 없는 이름/이메일은 비운다.
+func (s *ScimService) ReplaceUser(ctx context.Context, id string, req *model.ScimUser) (*model.ScimUser, error) {
+	user, kcUser, err := s.loadUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyUser(ctx, user, kcUser, req); err != nil {
+		return nil, err
+	}
+	return s.GetUser(ctx, id)
+}
+
+// PatchUser 사용자 부분 수정 (PATCH). 현재 리소스에 연산을 적용한 뒤 전체 교체와 같은 방식으로 반영한다.
+func (s *ScimService) PatchUser(ctx context.Context, id string, req *model.ScimPatchRequest) (*model.ScimUser, error) {
+	if len(req.Operations) == 0 {
+		return nil, fmt.Errorf("%w: Operations is required", ErrScimInvalidValue)
+	}
+	user, kcUser, err := s.loadUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	resource := scimUserResource(user, kcUser, nil)
+	for _, op := range req.Operations {
+		if err := applyScimUserPatch(&resource, op); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.applyUser(ctx, user, kcUser, &resource); err != nil {
+		return nil, err
+	}
+	return s.GetUser(ctx, id)
+}
+
+// DeleteUser 사용자 삭제 (탈퇴 처리: 역할/조직 매핑 삭제, Keycloak 비활성화, 세션 폐기)
+func (s *ScimService) DeleteUser(ctx context.Context, id string) error {
+	user, err := s.findUser(id)
+	if err != nil {
+		return err
+	}
+	return withdrawUser(ctx, s.userRepo, s.kcService, s.revocations, user, tokenRevokedByScim, model.ScimActor)
+}
+
+// findUser SCIM id(Keycloak ID)로 DB 사용자 조회 (탈퇴한 사용자는 없는 것으로 본다)
+func (s *ScimService) findUser(id string) (*model.User, error) {
+	user, err := s.userRepo.FindByKcID(id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !isScimVisibleUser(user) {
+		return nil, fmt.Errorf("%w: user %s", ErrScimNotFound, id)
+	}
+	return user, nil
+}
+
+func (s *ScimService) loadUser(ctx context.Context, id string) (*model.User, *gocloak.User, error) {
+	user, err := s.findUser(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	kcUser, err := s.kcService.GetUser(ctx, user.KcId)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, kcUser, nil
+}
+
+// applyUser 요청 리소스를 Keycloak 프로필, DB 사용자명, 활성 상태에 반영
+func (s *ScimService) applyUser(ctx context.Context, user *model.User, kcUser *gocloak.User, desired *model.ScimUser) error {
+	userName := strings.ToLower(strings.TrimSpace(desired.UserName))
+	if userName == "" {
+		return fmt.Errorf("%w: userName is required", ErrScimInvalidValue)
+	}
+	if userName != user.Username {
+		other, err := s.userRepo.FindByUsername(userName)
+		if err == nil && other.ID != user.ID {
+			return fmt.Errorf("%w: userName %q already exists", ErrScimConflict, userName)
+		}
+		if err != nil && !errors.Is(err, repository.ErrUserNotFound) {
+			return err
+		}
+	}
+
+	current := scimUserProfile(&model.ScimUser{})
+	enabled := false
+	if kcUser != nil {
+		current = &model.User{
+			Username:  ptrStr(kcUser.Username),
+			Email:     ptrStr(kcUser.Email),
+			FirstName: ptrStr(kcUser.FirstName),
+			LastName:  ptrStr(kcUser.LastName),
+		}
+		enabled = ptrBool(kcUser.Enabled)
+	}
+	profile := scimUserProfile(desired)
+	profile.Username = userName
+	profile.KcId = user.KcId
+	profile.Enabled = enabled
+	if profile.Username != current.Username || profile.Email != current.Email ||
+		profile.FirstName != current.FirstName || profile.LastName != current.LastName {
+		if err := s.kcService.UpdateUser(ctx, profile); err != nil {
+			return err
+		}
+	}
+	if userName != user.Username {
+		user.Username = userName
+		if err := s.userRepo.Update(user); err != nil {
+			return err
+		}
+	}
+	if desired.Active != nil && *desired.Active != scimUserActive(user, kcUser) {
+		return s.setActive(ctx, user, *desired.Active)
+	}
+	return nil
+}
+
+// setActive 활성화(Keycloak 활성 + ACTIVE) 또는 비활성화(Keycloak 비활성 + INACTIVE + 세션 폐기)
+func (s *ScimService) setActive(ctx context.Context, user *model.User, active bool) error {
+	if active {
+		if err := s.kcService.EnableUser(ctx, user.KcId); err != nil {
+			return fmt.Errorf("failed to enable user in keycloak: %w", err)
+		}
+		return s.userRepo.UpdateStatus(user.ID, model.UserStatusActive)
+	}
+	if err := s.kcService.DisableUser(ctx, user.KcId); err != nil {
+		return fmt.Errorf("failed to disable user in keycloak: %w", err)
+	}
+	if err := s.userRepo.UpdateStatus(user.ID, model.UserStatusInactive); err != nil {
+		return err
+	}
+	if _, err := s.revocations.RevokeAllSessions(ctx, user.KcId, TokenRevokedByDeactivation, model.ScimActor); err != nil {
+		log.Printf("[WARN] failed to revoke sessions of user %s: %v", user.KcId, err)
+	}
+	return nil
+}
+
+// userGroups 사용자 DB ID → 소속 조직 참조
+func (s *ScimService) userGroups() (map[uint][]model.ScimMember, error) {
+	orgs, err := s.orgRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	orgMap := make(map[uint]*model.Organization, len(orgs))
+	for i := range orgs {
+		orgMap[orgs[i].ID] = &orgs[i]
+	}
+	mappings, err := s.orgRepo.FindUserOrganizationMappings()
+	if err != nil {
+		return nil, err
+	}
+	groups := make(map[uint][]model.ScimMember)
+	for _, m := range mappings {
+		if org, ok := orgMap[m.OrganizationID]; ok {
+			groups[m.UserID] = append(groups[m.UserID], scimGroupRef(org))
+		}
+	}
+	return groups, nil
+}
+
+func isScimVisibleUser(user *model.User) bool {
+	return user.Status != model.UserStatusWithdrawn && !strings.HasPrefix(user.Username, "service-account-")
+}
+
+func scimUserActive(user *model.User, kcUser *gocloak.User) bool {
+	return kcUser != nil && ptrBool(kcUser.Enabled) && user.Status != model.UserStatusInactive
+}
+
+// scimUserProfile SCIM User의 이름/이메일을 Keycloak 프로필로 변환 (primary 이메일, 없으면 첫 번째 이메일)
+func scimUserProfile(req *model.ScimUser) *model.User {
+	profile := &model.User{}
+	if req.Name != nil {
+		profile.FirstName = strings.TrimSpace(req.Name.GivenName)
+		profile.LastName = strings.TrimSpace(req.Name.FamilyName)
+	}
+	for i, email := range req.Emails {
+		if email.Primary || i == 0 {
+			profile.Email = strings.TrimSpace(email.Value)
+		}
+		if email.Primary {
+			break
+		}
+	}
+	return profile
+}
+
+func scimUserResource(user *model.User, kcUser *gocloak.User, groups []model.ScimMember) model.ScimUser {
+	resource := model.ScimUser{
+		Schemas:  []string{model.ScimSchemaUser},
+		ID:       user.KcId,
+		UserName: user.Username,
+		Groups:   groups,
+		Meta: &model.ScimMeta{
+			ResourceType: "User",
+			Location:     model.ScimBasePath + "/Users/" + user.KcId,
+		},
+	}
+	if !user.CreatedAt.IsZero() {
+		created, modified := user.CreatedAt, user.UpdatedAt
+		resource.Meta.Created = &created
+		resource.Meta.LastModified = &modified
+	}
+	active := scimUserActive(user, kcUser)
+	resource.Active = &active
+	if kcUser == nil {
+		resource.DisplayName = user.Username
+		return resource
+	}
+
+	given, family := ptrStr(kcUser.FirstName), ptrStr(kcUser.LastName)
+	if given != "" || family != "" {
+		formatted := strings.TrimSpace(given + " " + family)
+		resource.Name = &model.ScimName{Formatted: formatted, GivenName: given, FamilyName: family}
+		resource.DisplayName = formatted
+	} else {
+		resource.DisplayName = user.Username
+	}
+	if email := ptrStr(kcUser.Email); email != "" {
+		resource.Emails = []model.ScimEmail{{Value: email, Type: "work", Primary: true}}
+	}
+	return resource
+}
+
+func scimUserAttributes(u model.ScimUser) scimAttributes {
+	attrs := scimAttributes{
+		"id":          {u.ID},
+		"externalid":  {u.ExternalID},
+		"username":    {u.UserName},
+		"displayname": {u.DisplayName},
+		"active":      {strconv.FormatBool(u.Active != nil && *u.Active)},
+	}
+	if u.Name != nil {
+		attrs["name.givenname"] = []string{u.Name.GivenName}
+		attrs["name.familyname"] = []string{u.Name.FamilyName}
+		attrs["name.formatted"] = []string{u.Name.Formatted}
+	}
+	for _, e := range u.Emails {
+		attrs["emails.value"] = append(attrs["emails.value"], e.Value)
+		attrs["emails.type"] = append(attrs["emails.type"], e.Type)
+	}
+	for _, g := range u.Groups {
+		attrs["groups.value"] = append(attrs["groups.value"], g.Value)
+		attrs["groups.display"] = append(attrs["groups.display"], g.Display)
+	}
+	addScimMetaAttributes(attrs, u.Meta)
+	return attrs
+}
+
+// applyScimUserPatch PATCH 연산 하나를 User 리소스에 적용
+func applyScimUserPatch(u *model.ScimUser, op model.ScimPatchOperation) error {
+	opName, err := scimPatchOp(op)
+	if err != nil {
+		return err
+	}
+	if op.Path == "" {
+		if opName == "remove" {
+			return fmt.Errorf("%w: path is required for remove", ErrScimInvalidPath)
+		}
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &values); err != nil {
+			return fmt.Errorf("%w: value must be an object when path is omitted", ErrScimInvalidValue)
+		}
+		for attr, value := range values {
+			if err := setScimUserAttribute(u, opName, attr, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return setScimUserAttribute(u, opName, op.Path, op.Value)
+}
+
+func setScimUserAttribute(u *model.ScimUser, op, path string, value json.RawMessage) error {
+	if len(path) > len(scimSchemaPrefixUser) && strings.EqualFold(path[:len(scimSchemaPrefixUser)], scimSchemaPrefixUser) {
+		path = path[len(scimSchemaPrefixUser):]
+	}
+	attr := strings.ToLower(path)
+	remove := op == "remove"
+	switch {
+	case strings.HasPrefix(attr, scimExtensionPrefix), attr == "schemas", attr == "id", attr == "meta", attr == "externalid", attr == "displayname":
+		// 확장 스키마 속성, 읽기 전용 속성, 저장하지 않는 속성(externalId, 이름에서 계산하는 displayName)은 무시
+		return nil
+	case attr == "username":
+		if remove {
+			return fmt.Errorf("%w: userName is required", ErrScimInvalidValue)
+		}
+		return decodeScimValue(value, &u.UserName)
+	case attr == "active":
+		if remove {
+			return fmt.Errorf("%w: active cannot be removed", ErrScimInvalidValue)
+		}
+		active, err := decodeScimBool(value)
+		if err != nil {
+			return err
+		}
+		u.Active = &active
+		return nil
+	case attr == "name":
+		if remove {
+			u.Name = nil
+			return nil
+		}
+		if u.Name == nil {
+			u.Name = &model.ScimName{}
+		}
+		return decodeScimValue(value, u.Name)
+	case strings.HasPrefix(attr, "name."):
+		if u.Name == nil {
+			u.Name = &model.ScimName{}
+		}
+		var target *string
+		switch attr {
+		case "name.givenname":
+			target = &u.Name.GivenName
+		case "name.familyname":
+			target = &u.Name.FamilyName
+		case "name.formatted":
+			target = &u.Name.Formatted
+		default:
+			return fmt.Errorf("%w: unsupported attribute %s", ErrScimInvalidPath, path)
+		}
+		if remove {
+			*target = ""
+			return nil
+		}
+		return decodeScimValue(value, target)
+	case attr == "emails":
+		if remove {
+			u.Emails = nil
+			return nil
+		}
+		var emails []model.ScimEmail
+		if err := decodeScimList(value, &emails); err != nil {
+			return err
+		}
+		if op == "add" {
+			emails = append(u.Emails, emails...)
+		}
+		u.Emails = emails
+		return nil
+	case attr == "emails.value", strings.HasPrefix(attr, "emails["):
+		// 예: emails[type eq "work"].value - 주 이메일 하나만 관리하므로 주 이메일 값을 교체한다
+		if remove {
+			u.Emails = nil
+			return nil
+		}
+		var email string
+		if err := decodeScimValue(value, &email); err != nil {
+			return err
+		}
+		u.Emails = []model.ScimEmail{{Value: email, Type: "work", Primary: true}}
+		return nil
+	case attr == "groups":
+		return fmt.Errorf("%w: groups is read-only, update Group members instead", ErrScimInvalidPath)
+	}
+	return fmt.Errorf("%w: unsupported attribute %s", ErrScimInvalidPath, path)
+}
+
+// --- Groups ---
+
+// ListGroups 그룹(조직) 목록 조회. excludeMembers이면 members를 생략한다.
+func (s *ScimService) ListGroups(ctx context.Context, filter string, startIndex, count int, excludeMembers bool) (*model.ScimListResponse, error) {
+	expr, err := parseScimFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	orgs, err := s.orgRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	mappings, err := s.orgRepo.FindUserOrganizationMappings()
+	if err != nil {
+		return nil, err
+	}
+	members := make(map[uint][]model.ScimMember)
+	for _, m := range mappings {
+		if m.User != nil && isScimVisibleUser(m.User) {
+			members[m.OrganizationID] = append(members[m.OrganizationID], scimUserRef(m.User))
+		}
+	}
+
+	var matched []interface{}
+	for i := range orgs {
+		resource := scimGroupResource(&orgs[i], members[orgs[i].ID])
+		if expr != nil && !expr.match(scimGroupAttributes(resource)) {
+			continue
+		}
+		if excludeMembers {
+			resource.Members = nil
+		}
+		matched = append(matched, resource)
+	}
+	return scimListPage(matched, startIndex, count), nil
+}
+
+// GetGroup 그룹(조직) 조회 (id = 조직 ID)
+func (s *ScimService) GetGroup(ctx context.Context, id string, excludeMembers bool) (*model.ScimGroup, error) {
+	org, err := s.findGroup(id)
+	if err != nil {
+		return nil, err
+	}
+	var members []model.ScimMember
+	if !excludeMembers {
+		users, err := s.orgRepo.FindOrganizationUsers(org.ID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range users {
+			if isScimVisibleUser(&users[i]) {
+				members = append(members, scimUserRef(&users[i]))
+			}
+		}
+	}
+	resource := scimGroupResource(org, members)
+	return &resource, nil
+}
+
+// CreateGroup 최상위 조직으로 그룹 생성 (조직 코드 자동 생성)
+func (s *ScimService) CreateGroup(ctx context.Context, req *model.ScimGroup) (*model.ScimGroup, error) {
+	name := strings.TrimSpace(req.DisplayName)
+	if name == "" {
+		return nil, fmt.Errorf("%w: displayName is required", ErrScimInvalidValue)
+	}
+	users, err := s.resolveMembers(req.Members)
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.orgService.CreateOrganization(&model.CreateOrganizationRequest{Name: name})
+	if err != nil {
+		if errors.Is(err, repository.ErrOrganizationNameDuplicate) {
+			return nil, fmt.Errorf("%w: group %q already exists", ErrScimConflict, name)
+		}
+		return nil, err
+	}
+	for _, user := range users {
+		if err := s.orgRepo.AssignUserToOrganizations(user.ID, []uint{org.ID}); err != nil {
+			return nil, err
+		}
+	}
+	return s.GetGroup(ctx, strconv.FormatUint(uint64(org.ID), 10), false)
+}
+
+// ReplaceGroup 그룹 이름과 구성원 전체 교체 (PUT)
+func (s *ScimService) ReplaceGroup(ctx context.Context, id string, req *model.ScimGroup) (*model.ScimGroup, error) {
+	org, err := s.findGroup(id)
+	if err != nil {
+		return nil, err
+	}
+	users, err := s.resolveMembers(req.Members)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.renameGroup(org, req.DisplayName); err != nil {
+		return nil, err
+	}
+	if err := s.setGroupMembers(org.ID, users); err != nil {
+		return nil, err
+	}
+	return s.GetGroup(ctx, id, false)
+}
+
+// PatchGroup 그룹 부분 수정 (displayName 변경, members add/remove/replace, members[value eq "..."] 제거)
+func (s *ScimService) PatchGroup(ctx context.Context, id string, req *model.ScimPatchRequest) (*model.ScimGroup, error) {
+	if len(req.Operations) == 0 {
+		return nil, fmt.Errorf("%w: Operations is required", ErrScimInvalidValue)
+	}
+	org, err := s.findGroup(id)
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range req.Operations {
+		opName, err := scimPatchOp(op)
+		if err != nil {
+			return nil, err
+		}
+		if op.Path != "" {
+			if err := s.patchGroupAttribute(org, opName, op.Path, op.Value); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if opName == "remove" {
+			return nil, fmt.Errorf("%w: path is required for remove", ErrScimInvalidPath)
+		}
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &values); err != nil {
+			return nil, fmt.Errorf("%w: value must be an object when path is omitted", ErrScimInvalidValue)
+		}
+		for attr, value := range values {
+			if err := s.patchGroupAttribute(org, opName, attr, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return s.GetGroup(ctx, id, false)
+}
+
+// DeleteGroup 그룹(조직) 삭제. 소속은 함께 제거하며, 하위 조직이 있으면 삭제하지 않는다.
+func (s *ScimService) DeleteGroup(ctx context.Context, id string) error {
+	org, err := s.findGroup(id)
+	if err != nil {
+		return err
+	}
+	hasChildren, err := s.orgRepo.HasChildren(org.ID)
+	if err != nil {
+		return err
+	}
+	if hasChildren {
+		return fmt.Errorf("%w: group %q has child organizations", ErrScimConflict, org.Name)
+	}
+	if err := s.orgRepo.RemoveAllOrganizationUsers(org.ID); err != nil {
+		return err
+	}
+	if err := s.orgRepo.Delete(org.ID); err != nil {
+		return err
+	}
+	// Keycloak 그룹 정리 (DB는 이미 삭제됨, best-effort)
+	if err := s.kcService.DeleteGroup(ctx, org.Name); err != nil {
+		log.Printf("[WARN] keycloak group cleanup failed for SCIM group %s: %v", org.Name, err)
+	}
+	return nil
+}
+
+func (s *ScimService) patchGroupAttribute(org *model.Organization, op, path string, value json.RawMessage) error {
+	if len(path) > len(scimSchemaPrefixGroup) && strings.EqualFold(path[:len(scimSchemaPrefixGroup)], scimSchemaPrefixGroup) {
+		path = path[len(scimSchemaPrefixGroup):]
+	}
+	attr := strings.ToLower(path)
+	switch {
+	case strings.HasPrefix(attr, scimExtensionPrefix), attr == "schemas", attr == "id", attr == "meta", attr == "externalid":
+		return nil
+	case attr == "displayname":
+		if op == "remove" {
+			return fmt.Errorf("%w: displayName is required", ErrScimInvalidValue)
+		}
+		var name string
+		if err := decodeScimValue(value, &name); err != nil {
+			return err
+		}
+		return s.renameGroup(org, name)
+	case attr == "members":
+		var members []model.ScimMember
+		if len(bytes.TrimSpace(value)) > 0 && string(bytes.TrimSpace(value)) != "null" {
+			if err := decodeScimList(value, &members); err != nil {
+				return err
+			}
+		}
+		users, err := s.resolveMembers(members)
+		if err != nil {
+			return err
+		}
+		switch op {
+		case "add":
+			for _, user := range users {
+				if err := s.orgRepo.AssignUserToOrganizations(user.ID, []uint{org.ID}); err != nil {
+					return err
+				}
+			}
+			return nil
+		case "replace":
+			return s.setGroupMembers(org.ID, users)
+		}
+		if len(members) == 0 {
+			return s.orgRepo.RemoveAllOrganizationUsers(org.ID)
+		}
+		for _, user := range users {
+			if err := s.removeGroupMember(user.ID, org.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	case strings.HasPrefix(attr, "members[") && strings.HasSuffix(attr, "]"):
+		if op != "remove" {
+			return fmt.Errorf("%w: members value filter is only supported for remove", ErrScimInvalidPath)
+		}
+		expr, err := parseScimFilter(path[len("members[") : len(path)-1])
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrScimInvalidPath, err)
+		}
+		users, err := s.orgRepo.FindOrganizationUsers(org.ID)
+		if err != nil {
+			return err
+		}
+		for _, user := range users {
+			if expr.match(scimAttributes{"value": {user.KcId}, "display": {user.Username}}) {
+				if err := s.removeGroupMember(user.ID, org.ID); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: unsupported attribute %s", ErrScimInvalidPath, path)
+}
+
+// findGroup SCIM id(조직 ID)로 조직 조회
+func (s *ScimService) findGroup(id string) (*model.Organization, error) {
+	orgID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: group %s", ErrScimNotFound, id)
+	}
+	org, err := s.orgRepo.FindByID(uint(orgID))
+	if err != nil {
+		if errors.Is(err, repository.ErrOrganizationNotFound) {
+			return nil, fmt.Errorf("%w: group %s", ErrScimNotFound, id)
+		}
+		return nil, err
+	}
+	return org, nil
+}
+
+// resolveMembers 구성원 value(Keycloak 사용자 ID)를 DB 사용자로 변환
+func (s *ScimService) resolveMembers(members []model.ScimMember) ([]*model.User, error) {
+	users := make([]*model.User, 0, len(members))
+	for _, member := range members {
+		user, err := s.userRepo.FindByKcID(member.Value)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil || !isScimVisibleUser(user) {
+			return nil, fmt.Errorf("%w: member %q not found", ErrScimInvalidValue, member.Value)
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+func (s *ScimService) renameGroup(org *model.Organization, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("%w: displayName is required", ErrScimInvalidValue)
+	}
+	if name == org.Name {
+		return nil
+	}
+	exists, err := s.orgRepo.ExistsNameUnderParent(name, org.ParentID, &org.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: group %q already exists", ErrScimConflict, name)
+	}
+	if err := s.orgRepo.Update(org.ID, map[string]interface{}{"name": name}); err != nil {
+		return err
+	}
+	org.Name = name
+	return nil
+}
+
+// setGroupMembers 구성원을 users로 교체 (추가/제거분만 반영)
+func (s *ScimService) setGroupMembers(orgID uint, users []*model.User) error {
+	current, err := s.orgRepo.FindOrganizationUsers(orgID)
+	if err != nil {
+		return err
+	}
+	desired := make(map[uint]bool, len(users))
+	for _, user := range users {
+		desired[user.ID] = true
+	}
+	existing := make(map[uint]bool, len(current))
+	for _, user := range current {
+		existing[user.ID] = true
+		if !desired[user.ID] {
+			if err := s.removeGroupMember(user.ID, orgID); err != nil {
+				return err
+			}
+		}
+	}
+	for _, user := range users {
+		if !existing[user.ID] {
+			if err := s.orgRepo.AssignUserToOrganizations(user.ID, []uint{orgID}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *ScimService) removeGroupMember(userID, orgID uint) error {
+	if err := s.orgRepo.RemoveUserFromOrganization(userID, orgID); err != nil && !errors.Is(err, repository.ErrUserOrganizationNotFound) {
+		return err
+	}
+	return nil
+}
+
+func scimGroupResource(org *model.Organization, members []model.ScimMember) model.ScimGroup {
+	id := strconv.FormatUint(uint64(org.ID), 10)
+	created, modified := org.CreatedAt, org.UpdatedAt
+	return model.ScimGroup{
+		Schemas:     []string{model.ScimSchemaGroup},
+		ID:          id,
+		DisplayName: org.Name,
+		Members:     members,
+		Meta: &model.ScimMeta{
+			ResourceType: "Group",
+			Created:      &created,
+			LastModified: &modified,
+			Location:     model.ScimBasePath + "/Groups/" + id,
+		},
+	}
+}
+
+func scimGroupAttributes(g model.ScimGroup) scimAttributes {
+	attrs := scimAttributes{
+		"id":          {g.ID},
+		"externalid":  {g.ExternalID},
+		"displayname": {g.DisplayName},
+	}
+	for _, m := range g.Members {
+		attrs["members.value"] = append(attrs["members.value"], m.Value)
+		attrs["members.display"] = append(attrs["members.display"], m.Display)
+	}
+	addScimMetaAttributes(attrs, g.Meta)
+	return attrs
+}
+
+func scimGroupRef(org *model.Organization) model.ScimMember {
+	id := strconv.FormatUint(uint64(org.ID), 10)
+	return model.ScimMember{Value: id, Ref: model.ScimBasePath + "/Groups/" + id, Display: org.Name}
+}
+
+func scimUserRef(user *model.User) model.ScimMember {
+	return model.ScimMember{Value: user.KcId, Ref: model.ScimBasePath + "/Users/" + user.KcId, Display: user.Username}
+}
+
+// --- 공통 ---
+
+func addScimMetaAttributes(attrs scimAttributes, meta *model.ScimMeta) {
+	if meta == nil {
+		return
+	}
+	attrs["meta.resourcetype"] = []string{meta.ResourceType}
+	if meta.Created != nil {
+		attrs["meta.created"] = []string{meta.Created.UTC().Format("2006-01-02T15:04:05Z")}
+	}
+	if meta.LastModified != nil {
+		attrs["meta.lastmodified"] = []string{meta.LastModified.UTC().Format("2006-01-02T15:04:05Z")}
+	}
+}
+
+// scimListPage startIndex(1부터)/count로 목록 응답 구성 (count가 음수이면 scimDefaultCount)
+func scimListPage(resources []interface{}, startIndex, count int) *model.ScimListResponse {
+	if startIndex < 1 {
+		startIndex = 1
+	}
+	if count < 0 {
+		count = scimDefaultCount
+	}
+	if count > scimMaxCount {
+		count = scimMaxCount
+	}
+	page := make([]interface{}, 0, count)
+	for i := startIndex - 1; i >= 0 && i < len(resources) && len(page) < count; i++ {
+		page = append(page, resources[i])
+	}
+	return &model.ScimListResponse{
+		Schemas:      []string{model.ScimSchemaListResponse},
+		TotalResults: len(resources),
+		StartIndex:   startIndex,
+		ItemsPerPage: len(page),
+		Resources:    page,
+	}
+}
+
+func scimPatchOp(op model.ScimPatchOperation) (string, error) {
+	name := strings.ToLower(op.Op)
+	switch name {
+	case "add", "remove", "replace":
+		return name, nil
+	}
+	return "", fmt.Errorf("%w: unsupported op %q", ErrScimInvalidValue, op.Op)
+}
+
+func decodeScimValue(value json.RawMessage, target interface{}) error {
+	if err := json.Unmarshal(value, target); err != nil {
+		return fmt.Errorf("%w: %v", ErrScimInvalidValue, err)
+	}
+	return nil
+}
+
+// decodeScimList 배열 또는 단일 객체 값을 목록으로 디코딩
+func decodeScimList(value json.RawMessage, target interface{}) error {
+	trimmed := bytes.TrimSpace(value)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		trimmed = append(append([]byte{'['}, trimmed...), ']')
+	}
+	return decodeScimValue(trimmed, target)
+}
+
+// decodeScimBool true/false 또는 "True"/"False" 문자열 값 디코딩 (일부 IdP는 문자열로 보낸다)
+func decodeScimBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var str string
+	if err := json.Unmarshal(value, &str); err == nil {
+		if b, err := strconv.ParseBool(strings.TrimSpace(str)); err == nil {
+			return b, nil
+		}
+	}
+	return false, fmt.Errorf("%w: expected boolean, got %s", ErrScimInvalidValue, string(value))
+}
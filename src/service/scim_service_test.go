@@ -0,0 +1,317 @@
+package service
+
+// scim_service_test.go
+// SCIM 2.0 프로비저닝(필터, 사용자 생성/수정/비활성화/삭제, 그룹 구성원) 단위 테스트 (SQLite in-memory DB)
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// scimKeycloakService Keycloak 사용자를 ID별로 보관하는 KeycloakService 스텁
+type scimKeycloakService struct {
+	mockKeycloakService
+	users    map[string]*gocloak.User
+	disabled []string
+}
+
+func (m *scimKeycloakService) GetUser(ctx context.Context, kcId string) (*gocloak.User, error) {
+	return m.users[kcId], nil
+}
+
+func (m *scimKeycloakService) GetUserByUsername(ctx context.Context, username string) (*gocloak.User, error) {
+	for _, u := range m.users {
+		if *u.Username == username {
+			return u, nil
+		}
+	}
+	return nil, repository.ErrUserNotFound
+}
+
+func (m *scimKeycloakService) GetUsers(ctx context.Context, enabled *bool) ([]*gocloak.User, error) {
+	users := make([]*gocloak.User, 0, len(m.users))
+	for _, u := range m.users {
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+func (m *scimKeycloakService) CreateUser(ctx context.Context, user *model.User) (string, error) {
+	id := "kc-" + user.Username
+	m.users[id] = &gocloak.User{
+		ID:        gocloak.StringP(id),
+		Username:  gocloak.StringP(user.Username),
+		Email:     gocloak.StringP(user.Email),
+		FirstName: gocloak.StringP(user.FirstName),
+		LastName:  gocloak.StringP(user.LastName),
+		Enabled:   gocloak.BoolP(true),
+	}
+	return id, nil
+}
+
+func (m *scimKeycloakService) UpdateUser(ctx context.Context, user *model.User) error {
+	u := m.users[user.KcId]
+	u.Username = gocloak.StringP(user.Username)
+	u.Email = gocloak.StringP(user.Email)
+	u.FirstName = gocloak.StringP(user.FirstName)
+	u.LastName = gocloak.StringP(user.LastName)
+	u.Enabled = gocloak.BoolP(user.Enabled)
+	return nil
+}
+
+func (m *scimKeycloakService) EnableUser(ctx context.Context, kcUserID string) error {
+	m.users[kcUserID].Enabled = gocloak.BoolP(true)
+	return nil
+}
+
+func (m *scimKeycloakService) DisableUser(ctx context.Context, kcUserID string) error {
+	m.users[kcUserID].Enabled = gocloak.BoolP(false)
+	m.disabled = append(m.disabled, kcUserID)
+	return nil
+}
+
+func newTestScimService(t *testing.T) (*ScimService, *scimKeycloakService, *gorm.DB) {
+	t.Helper()
+	db := setupGroupRoleTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.TokenRevocation{}))
+	kc := &scimKeycloakService{users: map[string]*gocloak.User{}}
+	svc := NewScimService(db)
+	svc.kcService = kc
+	svc.revocations.keycloakService = kc
+	return svc, kc, db
+}
+
+func scimPatch(t *testing.T, ops ...interface{}) *model.ScimPatchRequest {
+	t.Helper()
+	body, err := json.Marshal(map[string]interface{}{
+		"schemas":    []string{model.ScimSchemaPatchOp},
+		"Operations": ops,
+	})
+	require.NoError(t, err)
+	var req model.ScimPatchRequest
+	require.NoError(t, json.Unmarshal(body, &req))
+	return &req
+}
+
+func TestParseScimFilter(t *testing.T) {
+	attrs := scimAttributes{
+		"username":     {"alice"},
+		"emails.value": {"alice@example.com", "a.kim@example.com"},
+		"active":       {"true"},
+		"id":           {"kc-Alice"},
+	}
+	cases := []struct {
+		filter string
+		want   bool
+	}{
+		{`userName eq "ALICE"`, true},
+		{`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "alice"`, true},
+		{`emails co "a.kim"`, true},
+		{`userName sw "al" and active eq true`, true},
+		{`userName eq "bob" or (emails.value ew "example.com" and not (active eq false))`, true},
+		{`id eq "kc-alice"`, false},
+		{`name.givenName pr`, false},
+		{`userName ne "alice"`, false},
+	}
+	for _, tc := range cases {
+		expr, err := parseScimFilter(tc.filter)
+		require.NoError(t, err, tc.filter)
+		assert.Equal(t, tc.want, expr.match(attrs), tc.filter)
+	}
+
+	for _, filter := range []string{`userName`, `userName xx "a"`, `(userName eq "a"`, `emails[type eq "work"]`} {
+		_, err := parseScimFilter(filter)
+		assert.ErrorIs(t, err, ErrScimInvalidFilter, filter)
+	}
+	expr, err := parseScimFilter("  ")
+	require.NoError(t, err)
+	assert.Nil(t, expr)
+}
+
+func TestScimUser_CreateListAndFilter(t *testing.T) {
+	svc, kc, _ := newTestScimService(t)
+	ctx := context.Background()
+
+	created, err := svc.CreateUser(ctx, &model.ScimUser{
+		UserName: "Alice",
+		Name:     &model.ScimName{GivenName: "Alice", FamilyName: "Kim"},
+		Emails:   []model.ScimEmail{{Value: "alice@example.com", Primary: true}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "kc-alice", created.ID)
+	assert.Equal(t, "alice", created.UserName, "사용자명은 소문자로 정규화")
+	assert.Equal(t, "Alice Kim", created.DisplayName)
+	assert.True(t, *created.Active)
+	assert.Equal(t, model.ScimBasePath+"/Users/kc-alice", created.Meta.Location)
+
+	_, err = svc.CreateUser(ctx, &model.ScimUser{UserName: "bob", Active: gocloak.BoolP(false)})
+	require.NoError(t, err)
+	assert.False(t, *kc.users["kc-bob"].Enabled, "active=false이면 비활성 상태로 생성")
+
+	_, err = svc.CreateUser(ctx, &model.ScimUser{UserName: "alice"})
+	assert.ErrorIs(t, err, ErrScimConflict)
+
+	list, err := svc.ListUsers(ctx, "", 0, -1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, list.TotalResults)
+
+	list, err = svc.ListUsers(ctx, `userName eq "alice"`, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, list.TotalResults)
+	assert.Equal(t, "kc-alice", list.Resources[0].(model.ScimUser).ID)
+
+	list, err = svc.ListUsers(ctx, `active eq false`, 1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, list.TotalResults)
+	assert.Empty(t, list.Resources, "count=0이면 건수만 반환")
+
+	_, err = svc.ListUsers(ctx, `userName eq`, 1, 10)
+	assert.ErrorIs(t, err, ErrScimInvalidFilter)
+}
+
+func TestScimUser_LinksKeycloakOnlyUser(t *testing.T) {
+	svc, kc, db := newTestScimService(t)
+	kc.users["kc-existing"] = &gocloak.User{
+		ID:       gocloak.StringP("kc-existing"),
+		Username: gocloak.StringP("carol"),
+		Enabled:  gocloak.BoolP(true),
+	}
+
+	created, err := svc.CreateUser(context.Background(), &model.ScimUser{
+		UserName: "carol",
+		Emails:   []model.ScimEmail{{Value: "carol@example.com"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "kc-existing", created.ID)
+	assert.Len(t, kc.users, 1, "Keycloak 사용자를 새로 만들지 않는다")
+	assert.Equal(t, "carol@example.com", *kc.users["kc-existing"].Email)
+
+	user, err := repository.NewUserRepository(db).FindByKcID("kc-existing")
+	require.NoError(t, err)
+	require.NotNil(t, user)
+	assert.Equal(t, "carol", user.Username)
+}
+
+func TestScimUser_PatchDeactivateAndRename(t *testing.T) {
+	svc, kc, db := newTestScimService(t)
+	ctx := context.Background()
+	_, err := svc.CreateUser(ctx, &model.ScimUser{UserName: "alice", Name: &model.ScimName{GivenName: "Alice"}})
+	require.NoError(t, err)
+
+	patched, err := svc.PatchUser(ctx, "kc-alice", scimPatch(t,
+		map[string]interface{}{"op": "Replace", "path": "name.familyName", "value": "Kim"},
+		map[string]interface{}{"op": "replace", "path": `emails[type eq "work"].value`, "value": "alice@example.com"},
+		map[string]interface{}{"op": "replace", "value": map[string]interface{}{"active": "False"}},
+	))
+	require.NoError(t, err)
+	assert.Equal(t, "Kim", patched.Name.FamilyName)
+	assert.Equal(t, "alice@example.com", patched.Emails[0].Value)
+	assert.False(t, *patched.Active)
+	assert.Equal(t, []string{"kc-alice"}, kc.disabled)
+
+	user, err := repository.NewUserRepository(db).FindByKcID("kc-alice")
+	require.NoError(t, err)
+	assert.Equal(t, model.UserStatusInactive, user.Status)
+
+	patched, err = svc.PatchUser(ctx, "kc-alice", scimPatch(t,
+		map[string]interface{}{"op": "replace", "path": "active", "value": true},
+	))
+	require.NoError(t, err)
+	assert.True(t, *patched.Active)
+
+	_, err = svc.PatchUser(ctx, "kc-alice", scimPatch(t,
+		map[string]interface{}{"op": "remove", "path": "userName"},
+	))
+	assert.ErrorIs(t, err, ErrScimInvalidValue)
+
+	_, err = svc.PatchUser(ctx, "kc-missing", scimPatch(t,
+		map[string]interface{}{"op": "replace", "path": "active", "value": false},
+	))
+	assert.ErrorIs(t, err, ErrScimNotFound)
+}
+
+func TestScimUser_DeleteWithdraws(t *testing.T) {
+	svc, kc, db := newTestScimService(t)
+	ctx := context.Background()
+	_, err := svc.CreateUser(ctx, &model.ScimUser{UserName: "alice"})
+	require.NoError(t, err)
+	org := createGRTestOrg(t, db, "dev-team", "DT01")
+	user, err := repository.NewUserRepository(db).FindByKcID("kc-alice")
+	require.NoError(t, err)
+	require.NoError(t, repository.NewOrganizationRepository(db).AssignUserToOrganizations(user.ID, []uint{org.ID}))
+
+	require.NoError(t, svc.DeleteUser(ctx, "kc-alice"))
+	assert.Equal(t, []string{"kc-alice"}, kc.disabled)
+
+	user, err = repository.NewUserRepository(db).FindByKcID("kc-alice")
+	require.NoError(t, err)
+	assert.Equal(t, model.UserStatusWithdrawn, user.Status)
+
+	_, err = svc.GetUser(ctx, "kc-alice")
+	assert.ErrorIs(t, err, ErrScimNotFound, "탈퇴한 사용자는 조회되지 않는다")
+	group, err := svc.GetGroup(ctx, strconv.FormatUint(uint64(org.ID), 10), false)
+	require.NoError(t, err)
+	assert.Empty(t, group.Members)
+	assert.ErrorIs(t, svc.DeleteUser(ctx, "kc-alice"), ErrScimNotFound)
+}
+
+func TestScimGroup_MembersLifecycle(t *testing.T) {
+	svc, _, db := newTestScimService(t)
+	ctx := context.Background()
+	alice := createGRTestUser(t, db, "alice", "kc-alice")
+	createGRTestUser(t, db, "bob", "kc-bob")
+
+	group, err := svc.CreateGroup(ctx, &model.ScimGroup{
+		DisplayName: "Engineering",
+		Members:     []model.ScimMember{{Value: "kc-alice"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, group.Members, 1)
+	assert.Equal(t, "kc-alice", group.Members[0].Value)
+
+	_, err = svc.CreateGroup(ctx, &model.ScimGroup{DisplayName: "Engineering"})
+	assert.ErrorIs(t, err, ErrScimConflict)
+	_, err = svc.CreateGroup(ctx, &model.ScimGroup{DisplayName: "QA", Members: []model.ScimMember{{Value: "kc-nobody"}}})
+	assert.ErrorIs(t, err, ErrScimInvalidValue)
+
+	group, err = svc.PatchGroup(ctx, group.ID, scimPatch(t,
+		map[string]interface{}{"op": "add", "path": "members", "value": []map[string]string{{"value": "kc-bob"}}},
+		map[string]interface{}{"op": "replace", "path": "displayName", "value": "Platform Engineering"},
+	))
+	require.NoError(t, err)
+	assert.Equal(t, "Platform Engineering", group.DisplayName)
+	assert.Len(t, group.Members, 2)
+
+	group, err = svc.PatchGroup(ctx, group.ID, scimPatch(t,
+		map[string]interface{}{"op": "remove", "path": `members[value eq "kc-alice"]`},
+	))
+	require.NoError(t, err)
+	require.Len(t, group.Members, 1)
+	assert.Equal(t, "kc-bob", group.Members[0].Value)
+
+	user, err := svc.GetUser(ctx, "kc-bob")
+	require.NoError(t, err)
+	require.Len(t, user.Groups, 1)
+	assert.Equal(t, group.ID, user.Groups[0].Value)
+
+	list, err := svc.ListGroups(ctx, `displayName eq "platform engineering"`, 1, -1, true)
+	require.NoError(t, err)
+	require.Equal(t, 1, list.TotalResults)
+	assert.Empty(t, list.Resources[0].(model.ScimGroup).Members, "excludedAttributes=members")
+
+	require.NoError(t, svc.DeleteGroup(ctx, group.ID))
+	_, err = svc.GetGroup(ctx, group.ID, false)
+	assert.ErrorIs(t, err, ErrScimNotFound)
+	orgs, err := repository.NewOrganizationRepository(db).FindDirectUserOrganizations(alice.ID)
+	require.NoError(t, err)
+	assert.Empty(t, orgs)
+}
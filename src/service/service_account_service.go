@@ -0,0 +1,467 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/m-cmp/mc-iam-manager/config"
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	// serviceAccountUsernamePrefix API_KEY 서비스 계정 사용자 행의 username 접두어 (Keycloak 서비스 계정 규칙과 동일)
+	serviceAccountUsernamePrefix = "service-account-"
+	// serviceAccountKcIDPrefix API_KEY 서비스 계정의 kcUserId 접두어 (Keycloak 사용자 ID와 충돌하지 않음)
+	serviceAccountKcIDPrefix = "service-account:"
+	// serviceAccountClientPrefix CLIENT_CREDENTIALS 서비스 계정의 Keycloak clientId 접두어
+	serviceAccountClientPrefix = "mciam-sa-"
+	// apiKeyTouchInterval 마지막 사용 시각 기록 최소 간격 (요청마다 UPDATE 방지)
+	apiKeyTouchInterval = time.Minute
+)
+
+var (
+	// ErrServiceAccountNotFound 서비스 계정 없음
+	ErrServiceAccountNotFound = errors.New("service account not found")
+	// ErrServiceAccountExists 같은 이름의 서비스 계정 존재
+	ErrServiceAccountExists = errors.New("service account already exists")
+	// ErrInvalidServiceAccount 잘못된 서비스 계정/API 키 요청
+	ErrInvalidServiceAccount = errors.New("invalid service account request")
+	// ErrApiKeyNotFound API 키 없음 또는 이미 폐기됨
+	ErrApiKeyNotFound = errors.New("api key not found")
+	// ErrInvalidApiKey API 키 인증 실패 (형식 오류, 불일치, 만료, 폐기, 비활성 서비스 계정)
+	ErrInvalidApiKey = errors.New("invalid api key")
+
+	serviceAccountNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,62}$`)
+)
+
+// ServiceAccountService 기계 클라이언트용 서비스 계정, API 키, Keycloak client credentials 관리
+type ServiceAccountService struct {
+	repo            *repository.ServiceAccountRepository
+	roleService     *RoleService
+	keycloakService KeycloakService
+	revocations     *TokenRevocationService
+	now             func() time.Time
+}
+
+// NewServiceAccountService 새 ServiceAccountService 인스턴스 생성
+func NewServiceAccountService(db *gorm.DB) *ServiceAccountService {
+	return &ServiceAccountService{
+		repo:            repository.NewServiceAccountRepository(db),
+		roleService:     NewRoleService(db),
+		keycloakService: NewKeycloakService(),
+		revocations:     NewTokenRevocationService(db),
+		now:             time.Now,
+	}
+}
+
+// Create 서비스 계정 생성. CLIENT_CREDENTIALS는 Keycloak 클라이언트를 만들고 secret을 한 번만 반환한다.
+func (s *ServiceAccountService) Create(ctx context.Context, req *model.CreateServiceAccountRequest, createdBy string) (*model.ServiceAccountResponse, error) {
+	if !serviceAccountNamePattern.MatchString(req.Name) {
+		return nil, fmt.Errorf("%w: name must be 2-63 lowercase letters, digits or '-'", ErrInvalidServiceAccount)
+	}
+	authType := req.AuthType
+	if authType == "" {
+		authType = model.ServiceAccountAuthApiKey
+	}
+	if authType != model.ServiceAccountAuthApiKey && authType != model.ServiceAccountAuthClientCredentials {
+		return nil, fmt.Errorf("%w: unsupported authType %q", ErrInvalidServiceAccount, authType)
+	}
+	exists, err := s.repo.ExistsByName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrServiceAccountExists
+	}
+
+	account := &model.ServiceAccount{
+		Name:        req.Name,
+		Description: req.Description,
+		AuthType:    authType,
+		Enabled:     true,
+		CreatedBy:   createdBy,
+	}
+	user := &model.User{
+		Username:    serviceAccountUsernamePrefix + req.Name,
+		KcId:        serviceAccountKcIDPrefix + uuid.NewString(),
+		Status:      model.UserStatusActive,
+		Description: "service account: " + req.Name,
+	}
+	resp := &model.ServiceAccountResponse{}
+
+	if authType == model.ServiceAccountAuthClientCredentials {
+		client, err := s.keycloakService.CreateServiceAccountClient(ctx, serviceAccountClientPrefix+req.Name, req.Description)
+		if err != nil {
+			return nil, err
+		}
+		account.KcClientID = client.ClientID
+		account.KcClientUUID = client.ID
+		user.KcId = client.UserID
+		if client.Username != "" {
+			user.Username = client.Username
+		}
+		resp.Credentials = &model.ServiceAccountClientCredentials{
+			ClientID:      client.ClientID,
+			ClientSecret:  client.Secret,
+			TokenEndpoint: serviceAccountTokenEndpoint(ctx),
+		}
+	}
+
+	if err := s.repo.CreateWithUser(account, user); err != nil {
+		if account.KcClientUUID != "" {
+			if delErr := s.keycloakService.DeleteServiceAccountClient(ctx, account.KcClientUUID); delErr != nil {
+				log.Printf("[WARN] failed to clean up client %s: %v", account.KcClientID, delErr)
+			}
+		}
+		return nil, err
+	}
+	resp.ServiceAccount = *account
+	return resp, nil
+}
+
+// List 서비스 계정 목록
+func (s *ServiceAccountService) List() ([]model.ServiceAccount, error) {
+	return s.repo.List()
+}
+
+// Get 서비스 계정 조회
+func (s *ServiceAccountService) Get(id uint) (*model.ServiceAccount, error) {
+	account, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrServiceAccountNotFound
+	}
+	return account, nil
+}
+
+// Update 설명/활성 상태 변경. 비활성화 시 Keycloak 클라이언트도 비활성화하고 발급된 access token을 폐기한다.
+func (s *ServiceAccountService) Update(ctx context.Context, id uint, req *model.UpdateServiceAccountRequest, actor string) (*model.ServiceAccount, error) {
+	account, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Description != nil {
+		account.Description = *req.Description
+	}
+	disabling := false
+	if req.Enabled != nil && *req.Enabled != account.Enabled {
+		if account.KcClientUUID != "" {
+			if err := s.keycloakService.SetClientEnabled(ctx, account.KcClientUUID, *req.Enabled); err != nil {
+				return nil, err
+			}
+		}
+		account.Enabled = *req.Enabled
+		disabling = !account.Enabled
+	}
+	if err := s.repo.Update(account); err != nil {
+		return nil, err
+	}
+	if disabling && account.AuthType == model.ServiceAccountAuthClientCredentials {
+		s.revokeTokens(account, TokenRevokedByDeactivation, actor)
+	}
+	return account, nil
+}
+
+// Delete 서비스 계정 삭제 (API 키, 역할 할당, Keycloak 클라이언트 포함)
+func (s *ServiceAccountService) Delete(ctx context.Context, id uint, actor string) error {
+	account, err := s.Get(id)
+	if err != nil {
+		return err
+	}
+	if account.KcClientUUID != "" {
+		if err := s.keycloakService.DeleteServiceAccountClient(ctx, account.KcClientUUID); err != nil && !isKeycloakNotFound(err) {
+			return err
+		}
+		s.revokeTokens(account, TokenRevokedByDeactivation, actor)
+	}
+	return s.repo.DeleteWithUser(account)
+}
+
+// RegenerateClientSecret CLIENT_CREDENTIALS 서비스 계정의 Keycloak client secret 재발급
+func (s *ServiceAccountService) RegenerateClientSecret(ctx context.Context, id uint) (*model.ServiceAccountClientCredentials, error) {
+	account, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if account.KcClientUUID == "" {
+		return nil, fmt.Errorf("%w: service account has no keycloak client", ErrInvalidServiceAccount)
+	}
+	secret, err := s.keycloakService.RegenerateServiceAccountClientSecret(ctx, account.KcClientUUID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ServiceAccountClientCredentials{
+		ClientID:      account.KcClientID,
+		ClientSecret:  secret,
+		TokenEndpoint: serviceAccountTokenEndpoint(ctx),
+	}, nil
+}
+
+// GetRoles 서비스 계정의 유효 플랫폼/워크스페이스 역할
+func (s *ServiceAccountService) GetRoles(id uint) (*model.ServiceAccountRolesResponse, error) {
+	account, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	platformRoles, err := s.roleService.GetEffectivePlatformRoles(account.UserID)
+	if err != nil {
+		return nil, err
+	}
+	workspaceRoles, err := s.roleService.GetEffectiveWorkspaceRoles(account.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ServiceAccountRolesResponse{PlatformRoles: platformRoles, WorkspaceRoles: workspaceRoles}, nil
+}
+
+// AssignPlatformRole 플랫폼 역할 할당. CLIENT_CREDENTIALS는 access token에 담기도록 Keycloak realm role도 할당한다.
+func (s *ServiceAccountService) AssignPlatformRole(ctx context.Context, id, roleID uint) error {
+	account, role, err := s.accountAndRole(id, roleID, constants.RoleTypePlatform)
+	if err != nil {
+		return err
+	}
+	if err := s.roleService.AssignPlatformRole(account.UserID, role.ID); err != nil {
+		return err
+	}
+	if account.KcClientUUID != "" {
+		if err := s.keycloakService.AssignRealmRoleToUser(ctx, account.KcUserID, role.Name); err != nil {
+			if rollbackErr := s.roleService.RemovePlatformRole(account.UserID, role.ID); rollbackErr != nil {
+				log.Printf("[WARN] failed to rollback platform role assignment: %v", rollbackErr)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// RemovePlatformRole 플랫폼 역할 제거 (CLIENT_CREDENTIALS는 기존 access token도 폐기)
+func (s *ServiceAccountService) RemovePlatformRole(ctx context.Context, id, roleID uint, actor string) error {
+	account, role, err := s.accountAndRole(id, roleID, constants.RoleTypePlatform)
+	if err != nil {
+		return err
+	}
+	if account.KcClientUUID != "" {
+		if err := s.keycloakService.RemoveRealmRoleFromUser(ctx, account.KcUserID, role.Name); err != nil && !isKeycloakNotFound(err) {
+			return err
+		}
+	}
+	if err := s.roleService.RemovePlatformRole(account.UserID, role.ID); err != nil {
+		return err
+	}
+	if account.KcClientUUID != "" {
+		s.revokeTokens(account, TokenRevokedByRoleRemoval, actor)
+	}
+	return nil
+}
+
+// AssignWorkspaceRole 워크스페이스 역할 할당
+func (s *ServiceAccountService) AssignWorkspaceRole(id, workspaceID, roleID uint) error {
+	if workspaceID == 0 {
+		return fmt.Errorf("%w: workspaceId is required", ErrInvalidServiceAccount)
+	}
+	account, role, err := s.accountAndRole(id, roleID, constants.RoleTypeWorkspace)
+	if err != nil {
+		return err
+	}
+	return s.roleService.AssignWorkspaceRole(account.UserID, workspaceID, role.ID)
+}
+
+// RemoveWorkspaceRole 워크스페이스 역할 제거
+func (s *ServiceAccountService) RemoveWorkspaceRole(id, workspaceID, roleID uint) error {
+	account, err := s.Get(id)
+	if err != nil {
+		return err
+	}
+	return s.roleService.RemoveWorkspaceRole(account.UserID, workspaceID, roleID)
+}
+
+// CreateApiKey API 키 발급. 키 원문은 응답에서만 확인할 수 있다.
+func (s *ServiceAccountService) CreateApiKey(id uint, req *model.CreateApiKeyRequest, createdBy string) (*model.CreateApiKeyResponse, error) {
+	account, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().UTC()
+	expiresAt := req.ExpiresAt
+	if expiresAt == nil {
+		if ttl := config.ApiKeyDefaultTTL(); ttl > 0 {
+			t := now.Add(ttl)
+			expiresAt = &t
+		}
+	} else if !expiresAt.After(now) {
+		return nil, fmt.Errorf("%w: expiresAt must be in the future", ErrInvalidServiceAccount)
+	}
+	for _, scope := range req.Scopes {
+		role, err := s.roleService.GetRoleByName(scope, constants.RoleTypePlatform)
+		if err != nil || role == nil {
+			return nil, fmt.Errorf("%w: unknown platform role scope %q", ErrInvalidServiceAccount, scope)
+		}
+	}
+
+	prefix, secret, err := generateApiKeyParts()
+	if err != nil {
+		return nil, err
+	}
+	key := model.ServiceAccountApiKeyPrefix + prefix + "_" + secret
+	apiKey := &model.ServiceAccountApiKey{
+		ServiceAccountID: account.ID,
+		Name:             req.Name,
+		Prefix:           prefix,
+		KeyHash:          hashApiKey(key),
+		Scopes:           req.Scopes,
+		ExpiresAt:        expiresAt,
+		CreatedBy:        createdBy,
+		CreatedAt:        now,
+	}
+	if err := s.repo.CreateApiKey(apiKey); err != nil {
+		return nil, err
+	}
+	return &model.CreateApiKeyResponse{ServiceAccountApiKey: *apiKey, Key: key}, nil
+}
+
+// ListApiKeys 서비스 계정의 API 키 목록 (해시 제외)
+func (s *ServiceAccountService) ListApiKeys(id uint) ([]model.ServiceAccountApiKey, error) {
+	if _, err := s.Get(id); err != nil {
+		return nil, err
+	}
+	return s.repo.ListApiKeys(id)
+}
+
+// RevokeApiKey API 키 폐기
+func (s *ServiceAccountService) RevokeApiKey(id, keyID uint) error {
+	revoked, err := s.repo.RevokeApiKey(id, keyID, s.now().UTC())
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrApiKeyNotFound
+	}
+	return nil
+}
+
+// AuthenticateApiKey API 키를 검증하고 서비스 계정의 kcUserId와 플랫폼 역할(키 범위 적용) 반환
+func (s *ServiceAccountService) AuthenticateApiKey(ctx context.Context, key, clientIP string) (*model.ServiceAccountPrincipal, error) {
+	prefix, ok := parseApiKeyPrefix(key)
+	if !ok {
+		return nil, ErrInvalidApiKey
+	}
+	apiKey, err := s.repo.FindApiKeyByPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey == nil || subtle.ConstantTimeCompare([]byte(apiKey.KeyHash), []byte(hashApiKey(key))) != 1 {
+		return nil, ErrInvalidApiKey
+	}
+	now := s.now().UTC()
+	if apiKey.RevokedAt != nil || (apiKey.ExpiresAt != nil && !apiKey.ExpiresAt.After(now)) {
+		return nil, ErrInvalidApiKey
+	}
+	account, err := s.repo.FindByID(apiKey.ServiceAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil || !account.Enabled {
+		return nil, ErrInvalidApiKey
+	}
+
+	roles, err := s.roleService.GetEffectivePlatformRoles(account.UserID)
+	if err != nil {
+		return nil, err
+	}
+	platformRoles := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if len(apiKey.Scopes) == 0 || slices.Contains(apiKey.Scopes, role.Name) {
+			platformRoles = append(platformRoles, role.Name)
+		}
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyTouchInterval || apiKey.LastUsedIP != clientIP {
+		if err := s.repo.TouchApiKey(apiKey.ID, now, clientIP); err != nil {
+			log.Printf("[WARN] failed to record api key usage (id=%d): %v", apiKey.ID, err)
+		}
+	}
+	return &model.ServiceAccountPrincipal{
+		ServiceAccountID: account.ID,
+		Name:             account.Name,
+		KcUserID:         account.KcUserID,
+		ApiKeyID:         apiKey.ID,
+		PlatformRoles:    platformRoles,
+	}, nil
+}
+
+func (s *ServiceAccountService) accountAndRole(id, roleID uint, roleType constants.IAMRoleType) (*model.ServiceAccount, *model.RoleMaster, error) {
+	account, err := s.Get(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	role, err := s.roleService.GetRoleByID(roleID, roleType)
+	if err != nil || role == nil {
+		return nil, nil, fmt.Errorf("%w: %s role %d not found", ErrInvalidServiceAccount, roleType, roleID)
+	}
+	return account, role, nil
+}
+
+// revokeTokens Keycloak이 발급한 서비스 계정 access token 폐기 (실패 시 경고만 기록)
+func (s *ServiceAccountService) revokeTokens(account *model.ServiceAccount, reason, actor string) {
+	if _, err := s.revocations.RevokeUserTokens(account.KcUserID, reason, actor); err != nil {
+		log.Printf("[WARN] failed to revoke tokens of service account %s: %v", account.Name, err)
+	}
+}
+
+// serviceAccountTokenEndpoint 요청 회사 realm의 client credentials 토큰 발급 주소 (외부 접근 주소 우선)
+func serviceAccountTokenEndpoint(ctx context.Context) string {
+	kc := config.KeycloakFor(ctx)
+	if kc == nil {
+		return ""
+	}
+	base := kc.ExternalURL
+	if base == "" {
+		base = kc.Host
+	}
+	return strings.TrimRight(base, "/") + "/realms/" + kc.Realm + "/protocol/openid-connect/token"
+}
+
+// generateApiKeyParts 조회용 접두어(8자)와 비밀 값(256bit) 생성
+func generateApiKeyParts() (string, string, error) {
+	buf := make([]byte, 4+32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	return hex.EncodeToString(buf[:4]), base64.RawURLEncoding.EncodeToString(buf[4:]), nil
+}
+
+// parseApiKeyPrefix mciam_<prefix>_<secret> 형식에서 접두어 추출
+func parseApiKeyPrefix(key string) (string, bool) {
+	rest, ok := strings.CutPrefix(key, model.ServiceAccountApiKeyPrefix)
+	if !ok {
+		return "", false
+	}
+	prefix, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(prefix) != 8 || secret == "" {
+		return "", false
+	}
+	return prefix, true
+}
+
+// hashApiKey API 키 저장용 해시 (키 자체가 256bit 난수이므로 SHA-256으로 충분)
+func hashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,127 @@
+package service
+
+// service_account_service_test.go
+// 서비스 계정 및 API 키 인증(ServiceAccountService) 단위 테스트 (SQLite in-memory DB)
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newTestServiceAccountService(t *testing.T, now *time.Time) (*ServiceAccountService, *gorm.DB) {
+	t.Helper()
+	db := setupGroupRoleTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.ServiceAccount{}, &model.ServiceAccountApiKey{}, &model.TokenRevocation{}))
+	clock := func() time.Time { return *now }
+	svc := &ServiceAccountService{
+		repo:            repository.NewServiceAccountRepository(db),
+		roleService:     NewRoleService(db),
+		keycloakService: &mockKeycloakService{},
+		revocations: &TokenRevocationService{
+			repo:  repository.NewTokenRevocationRepository(db),
+			cache: newTokenRevocationCache(),
+			now:   clock,
+		},
+		now: clock,
+	}
+	return svc, db
+}
+
+func TestServiceAccount_ApiKeyAuthenticatesWithScopedRoles(t *testing.T) {
+	now := time.Now()
+	svc, db := newTestServiceAccountService(t, &now)
+	operator := createGRTestRole(t, db, "operator")
+	viewer := createGRTestRole(t, db, "viewer")
+
+	created, err := svc.Create(context.Background(), &model.CreateServiceAccountRequest{Name: "ci-pipeline"}, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, model.ServiceAccountAuthApiKey, created.AuthType)
+	assert.Nil(t, created.Credentials)
+	require.NoError(t, svc.AssignPlatformRole(context.Background(), created.ID, operator.ID))
+	require.NoError(t, svc.AssignPlatformRole(context.Background(), created.ID, viewer.ID))
+
+	full, err := svc.CreateApiKey(created.ID, &model.CreateApiKeyRequest{Name: "full"}, "admin")
+	require.NoError(t, err)
+	require.NotNil(t, full.ExpiresAt, "기본 유효 기간 적용")
+	scoped, err := svc.CreateApiKey(created.ID, &model.CreateApiKeyRequest{Name: "read", Scopes: []string{"viewer"}}, "admin")
+	require.NoError(t, err)
+
+	var stored model.ServiceAccountApiKey
+	require.NoError(t, db.First(&stored, full.ID).Error)
+	assert.Equal(t, hashApiKey(full.Key), stored.KeyHash, "원문 대신 해시만 저장")
+
+	principal, err := svc.AuthenticateApiKey(context.Background(), full.Key, "10.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, created.KcUserID, principal.KcUserID)
+	assert.ElementsMatch(t, []string{"operator", "viewer"}, principal.PlatformRoles)
+
+	principal, err = svc.AuthenticateApiKey(context.Background(), scoped.Key, "10.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"viewer"}, principal.PlatformRoles)
+
+	keys, err := svc.ListApiKeys(created.ID)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	for _, key := range keys {
+		require.NotNil(t, key.LastUsedAt)
+		assert.Equal(t, "10.0.0.1", key.LastUsedIP)
+	}
+
+	_, err = svc.CreateApiKey(created.ID, &model.CreateApiKeyRequest{Scopes: []string{"unknown"}}, "admin")
+	assert.ErrorIs(t, err, ErrInvalidServiceAccount)
+}
+
+func TestServiceAccount_RejectsInvalidRevokedExpiredAndDisabledKeys(t *testing.T) {
+	now := time.Now()
+	svc, _ := newTestServiceAccountService(t, &now)
+	ctx := context.Background()
+
+	created, err := svc.Create(ctx, &model.CreateServiceAccountRequest{Name: "mc-workflow"}, "admin")
+	require.NoError(t, err)
+	_, err = svc.Create(ctx, &model.CreateServiceAccountRequest{Name: "mc-workflow"}, "admin")
+	assert.ErrorIs(t, err, ErrServiceAccountExists)
+	_, err = svc.Create(ctx, &model.CreateServiceAccountRequest{Name: "Bad Name"}, "admin")
+	assert.ErrorIs(t, err, ErrInvalidServiceAccount)
+
+	expiresAt := now.Add(time.Hour)
+	key, err := svc.CreateApiKey(created.ID, &model.CreateApiKeyRequest{ExpiresAt: &expiresAt}, "admin")
+	require.NoError(t, err)
+	_, err = svc.AuthenticateApiKey(ctx, key.Key, "")
+	require.NoError(t, err)
+
+	_, err = svc.AuthenticateApiKey(ctx, key.Key[:len(key.Key)-1]+"x", "")
+	assert.ErrorIs(t, err, ErrInvalidApiKey, "비밀 값 불일치")
+	_, err = svc.AuthenticateApiKey(ctx, "mciam_short", "")
+	assert.ErrorIs(t, err, ErrInvalidApiKey)
+
+	disabled := false
+	_, err = svc.Update(ctx, created.ID, &model.UpdateServiceAccountRequest{Enabled: &disabled}, "admin")
+	require.NoError(t, err)
+	_, err = svc.AuthenticateApiKey(ctx, key.Key, "")
+	assert.ErrorIs(t, err, ErrInvalidApiKey, "비활성 서비스 계정")
+	enabled := true
+	_, err = svc.Update(ctx, created.ID, &model.UpdateServiceAccountRequest{Enabled: &enabled}, "admin")
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Hour)
+	_, err = svc.AuthenticateApiKey(ctx, key.Key, "")
+	assert.ErrorIs(t, err, ErrInvalidApiKey, "만료된 키")
+
+	other, err := svc.CreateApiKey(created.ID, &model.CreateApiKeyRequest{}, "admin")
+	require.NoError(t, err)
+	require.NoError(t, svc.RevokeApiKey(created.ID, other.ID))
+	assert.ErrorIs(t, svc.RevokeApiKey(created.ID, other.ID), ErrApiKeyNotFound)
+	_, err = svc.AuthenticateApiKey(ctx, other.Key, "")
+	assert.ErrorIs(t, err, ErrInvalidApiKey, "폐기된 키")
+
+	require.NoError(t, svc.Delete(ctx, created.ID, "admin"))
+	_, err = svc.Get(created.ID)
+	assert.ErrorIs(t, err, ErrServiceAccountNotFound)
+}
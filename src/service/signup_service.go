@@ -0,0 +1,353 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/config"
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrSignupNotFound 가입 신청 없음
+	ErrSignupNotFound = errors.New("signup not found")
+	// ErrSignupInvalid 승인/거절 요청 내용 오류
+	ErrSignupInvalid = errors.New("invalid signup request")
+	// ErrSignupNotPending 승인/거절할 수 없는 상태
+	ErrSignupNotPending = errors.New("signup is not pending")
+	// ErrSignupNotVerified 이메일 인증 전에는 승인 불가
+	ErrSignupNotVerified = errors.New("signup email is not verified yet")
+)
+
+// SignupService 셀프 가입 신청 및 승인 대기열 서비스
+// 가입 시 Keycloak 사용자를 비활성(승인 대기)으로 만들고, 승인 시 활성화하면서 초기 플랫폼 역할과 조직을 할당한다.
+type SignupService struct {
+	db              *gorm.DB
+	signupRepo      *repository.SignupRepository
+	userRepo        *repository.UserRepository
+	orgRepo         *repository.OrganizationRepository
+	userService     *UserService
+	roleService     *RoleService
+	orgService      *OrganizationService
+	keycloakService KeycloakService
+}
+
+// NewSignupService 새 SignupService 인스턴스 생성
+func NewSignupService(db *gorm.DB) *SignupService {
+	return &SignupService{
+		db:              db,
+		signupRepo:      repository.NewSignupRepository(db),
+		userRepo:        repository.NewUserRepository(db),
+		orgRepo:         repository.NewOrganizationRepository(db),
+		userService:     NewUserService(db),
+		roleService:     NewRoleService(db),
+		orgService:      NewOrganizationService(db),
+		keycloakService: NewKeycloakService(),
+	}
+}
+
+// Signup 가입 신청. 이메일 인증이 필요하면 인증 메일을 발송하고, 필요 없으면 승인 대기로 둔다.
+// 자동 승인은 메일함 소유가 확인된(이메일 인증 완료) 신청에만 적용한다.
+func (s *SignupService) Signup(ctx context.Context, req *model.SignupRequest, companyID *uint) (*model.Signup, error) {
+	requireVerification := config.SignupEmailVerificationRequired()
+
+	kcUserID, err := s.keycloakService.CreatePendingUser(ctx, req, requireVerification)
+	if err != nil {
+		return nil, err
+	}
+
+	// 로컬 DB에도 사용자 동기화 (승인 전에도 DB 레코드 생성)
+	if _, err := s.userService.SyncUser(ctx, kcUserID); err != nil {
+		log.Printf("Warning: User created in Keycloak but not synced to DB: %v", err)
+	}
+
+	signup := &model.Signup{
+		KcUserID:     kcUserID,
+		Username:     strings.Split(req.Email, "@")[0],
+		Email:        req.Email,
+		EmailDomain:  emailDomain(req.Email),
+		FirstName:    req.FirstName,
+		LastName:     req.LastName,
+		Organization: req.Organization,
+		Status:       model.SignupStatusPendingApproval,
+		CompanyID:    companyID,
+	}
+	if requireVerification {
+		signup.Status = model.SignupStatusPendingVerification
+	}
+	if err := s.signupRepo.Create(signup); err != nil {
+		return nil, fmt.Errorf("failed to record signup: %w", err)
+	}
+
+	if requireVerification {
+		if err := s.keycloakService.SendVerifyEmail(ctx, kcUserID); err != nil {
+			// 가입은 유지하고 관리자가 인증 메일을 재발송할 수 있다
+			log.Printf("[WARN] failed to send verify email for signup %d: %v", signup.ID, err)
+		}
+		return signup, nil
+	}
+	return signup, nil
+}
+
+// ListSignups 가입 신청 목록 조회. 이메일 인증 대기 신청은 Keycloak 인증 상태를 먼저 반영한 뒤 필터를 적용한다.
+func (s *SignupService) ListSignups(ctx context.Context, filter *model.SignupFilterRequest) ([]model.Signup, error) {
+	verifying := model.SignupFilterRequest{Status: string(model.SignupStatusPendingVerification)}
+	if filter != nil {
+		verifying.Email, verifying.Domain, verifying.CompanyID = filter.Email, filter.Domain, filter.CompanyID
+	}
+	pending, err := s.signupRepo.List(&verifying)
+	if err != nil {
+		return nil, err
+	}
+	for i := range pending {
+		if _, err := s.refreshVerification(ctx, &pending[i]); err != nil {
+			log.Printf("[WARN] failed to refresh email verification for signup %d: %v", pending[i].ID, err)
+		}
+	}
+	return s.signupRepo.List(filter)
+}
+
+// GetSignup 가입 신청 상세 조회
+func (s *SignupService) GetSignup(ctx context.Context, id uint) (*model.Signup, error) {
+	signup, err := s.findSignup(id)
+	if err != nil {
+		return nil, err
+	}
+	if signup.Status == model.SignupStatusPendingVerification {
+		if signup, err = s.refreshVerification(ctx, signup); err != nil {
+			return nil, err
+		}
+	}
+	return signup, nil
+}
+
+// CheckLoginAllowed 로그인 시 가입 신청 상태 확인. 가입 신청으로 만든 계정은 승인 후에만 로그인할 수 있다.
+// 이메일 인증을 방금 마친 경우 여기서 비활성화(승인 대기)로 전환된다.
+func (s *SignupService) CheckLoginAllowed(ctx context.Context, kcUserID string) (bool, error) {
+	signup, err := s.signupRepo.FindByKcUserID(kcUserID)
+	if err != nil {
+		return false, err
+	}
+	if signup == nil {
+		return true, nil
+	}
+	if signup.Status == model.SignupStatusPendingVerification {
+		if signup, err = s.refreshVerification(ctx, signup); err != nil {
+			return false, err
+		}
+	}
+	return signup.Status == model.SignupStatusApproved, nil
+}
+
+// ApproveSignup 가입 승인. Keycloak 사용자를 활성화하고 초기 플랫폼 역할과 조직을 할당한다.
+func (s *SignupService) ApproveSignup(ctx context.Context, id, reviewerUserID uint, req *model.SignupApproveRequest) (*model.Signup, error) {
+	signup, err := s.GetSignup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	switch signup.Status {
+	case model.SignupStatusPendingApproval:
+	case model.SignupStatusPendingVerification:
+		return nil, ErrSignupNotVerified
+	default:
+		return nil, ErrSignupNotPending
+	}
+
+	roleName := req.PlatformRole
+	if roleName == "" {
+		roleName = config.SignupDefaultPlatformRole()
+	}
+	return s.approve(ctx, signup, roleName, req.OrganizationIDs, &reviewerUserID, req.Comment, false)
+}
+
+// RejectSignup 가입 거절. Keycloak 사용자는 비활성 상태로 남는다.
+func (s *SignupService) RejectSignup(ctx context.Context, id, reviewerUserID uint, reason string) (*model.Signup, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, fmt.Errorf("%w: reason is required", ErrSignupInvalid)
+	}
+	signup, err := s.findSignup(id)
+	if err != nil {
+		return nil, err
+	}
+	from := signup.Status
+	if from != model.SignupStatusPendingApproval && from != model.SignupStatusPendingVerification {
+		return nil, ErrSignupNotPending
+	}
+	if from == model.SignupStatusPendingVerification {
+		// 인증 메일 발송을 위해 임시 활성화된 계정
+		if err := s.keycloakService.DisableUser(ctx, signup.KcUserID); err != nil {
+			return nil, fmt.Errorf("failed to disable user in keycloak: %w", err)
+		}
+	}
+
+	now := time.Now().UTC()
+	signup.Status = model.SignupStatusRejected
+	signup.Reason = reason
+	signup.ReviewerUserID = &reviewerUserID
+	signup.DecidedAt = &now
+	if err := s.updateFromStatus(signup, from); err != nil {
+		return nil, err
+	}
+	return signup, nil
+}
+
+// ResendVerification 이메일 인증 대기 신청의 인증 메일 재발송
+func (s *SignupService) ResendVerification(ctx context.Context, id uint) (*model.Signup, error) {
+	signup, err := s.GetSignup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if signup.Status != model.SignupStatusPendingVerification {
+		return nil, fmt.Errorf("%w: signup is not waiting for email verification", ErrSignupNotPending)
+	}
+	if err := s.keycloakService.SendVerifyEmail(ctx, signup.KcUserID); err != nil {
+		return nil, err
+	}
+	return signup, nil
+}
+
+// refreshVerification Keycloak에서 이메일 인증이 완료되었으면 계정을 다시 비활성화하고 승인 대기로 전환
+// (자동 승인 도메인이면 바로 승인)
+func (s *SignupService) refreshVerification(ctx context.Context, signup *model.Signup) (*model.Signup, error) {
+	kcUser, err := s.keycloakService.GetUser(ctx, signup.KcUserID)
+	if err != nil {
+		return nil, err
+	}
+	if kcUser == nil || kcUser.EmailVerified == nil || !*kcUser.EmailVerified {
+		return signup, nil
+	}
+
+	if err := s.keycloakService.DisableUser(ctx, signup.KcUserID); err != nil {
+		return nil, fmt.Errorf("failed to disable verified user until approval: %w", err)
+	}
+	now := time.Now().UTC()
+	signup.Status = model.SignupStatusPendingApproval
+	signup.EmailVerifiedAt = &now
+	if err := s.updateFromStatus(signup, model.SignupStatusPendingVerification); err != nil {
+		if errors.Is(err, ErrSignupNotPending) {
+			// 동시에 다른 요청이 전환함
+			return s.findSignup(signup.ID)
+		}
+		return nil, err
+	}
+	return s.autoApproveIfAllowed(ctx, signup)
+}
+
+// autoApproveIfAllowed 이메일 인증을 마친 승인 대기 신청의 도메인이 자동 승인 목록에 있으면 기본 플랫폼 역할로 승인
+// 인증 전에는 주소만으로 도메인 소속을 믿을 수 없으므로 관리자 승인 대기로 남긴다.
+func (s *SignupService) autoApproveIfAllowed(ctx context.Context, signup *model.Signup) (*model.Signup, error) {
+	if signup.Status != model.SignupStatusPendingApproval || signup.EmailVerifiedAt == nil || !s.isAutoApproveDomain(signup.EmailDomain) {
+		return signup, nil
+	}
+	approved, err := s.approve(ctx, signup, config.SignupDefaultPlatformRole(), nil, nil, "auto-approved: email domain allowlist", true)
+	if err != nil {
+		// 자동 승인 실패 시 승인 대기열에 남겨 관리자가 처리
+		log.Printf("[WARN] auto-approval failed for signup %d: %v", signup.ID, err)
+		return signup, nil
+	}
+	return approved, nil
+}
+
+// approve 승인 처리 공통 로직
+func (s *SignupService) approve(ctx context.Context, signup *model.Signup, roleName string, orgIDs []uint,
+	reviewerUserID *uint, comment string, auto bool) (*model.Signup, error) {
+	var role *model.RoleMaster
+	if roleName != "" {
+		found, err := s.roleService.GetRoleByName(roleName, constants.RoleTypePlatform)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, fmt.Errorf("%w: platform role %q not found", ErrSignupInvalid, roleName)
+		}
+		role = found
+	}
+	for _, orgID := range orgIDs {
+		if _, err := s.orgRepo.FindByID(orgID); err != nil {
+			if errors.Is(err, repository.ErrOrganizationNotFound) {
+				return nil, fmt.Errorf("%w: organization %d not found", ErrSignupInvalid, orgID)
+			}
+			return nil, err
+		}
+	}
+
+	if err := s.keycloakService.EnableUser(ctx, signup.KcUserID); err != nil {
+		return nil, fmt.Errorf("failed to enable user in keycloak: %w", err)
+	}
+	user, err := s.userRepo.FindByKcID(signup.KcUserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		if user, err = s.userService.SyncUser(ctx, signup.KcUserID); err != nil {
+			return nil, fmt.Errorf("failed to sync approved user to local DB: %w", err)
+		}
+	}
+
+	if role != nil {
+		if _, err := grantPlatformRole(ctx, s.roleService, s.keycloakService, user, role); err != nil {
+			return nil, err
+		}
+	}
+	if len(orgIDs) > 0 {
+		if err := s.orgService.AssignUserToOrganizations(user.ID, orgIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().UTC()
+	signup.Status = model.SignupStatusApproved
+	signup.AutoApproved = auto
+	signup.AssignedPlatformRole = roleName
+	signup.AssignedOrgIDs = orgIDs
+	signup.Reason = comment
+	signup.ReviewerUserID = reviewerUserID
+	signup.DecidedAt = &now
+	if err := s.updateFromStatus(signup, model.SignupStatusPendingApproval); err != nil {
+		return nil, err
+	}
+	return signup, nil
+}
+
+func (s *SignupService) updateFromStatus(signup *model.Signup, from model.SignupStatus) error {
+	updated, err := s.signupRepo.UpdateFromStatus(signup, from)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrSignupNotPending
+	}
+	return nil
+}
+
+func (s *SignupService) findSignup(id uint) (*model.Signup, error) {
+	signup, err := s.signupRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSignupNotFound
+		}
+		return nil, err
+	}
+	return signup, nil
+}
+
+func (s *SignupService) isAutoApproveDomain(domain string) bool {
+	return domain != "" && slices.Contains(config.SignupAutoApproveDomains(), domain)
+}
+
+// emailDomain 이메일 주소의 도메인 (소문자)
+func emailDomain(email string) string {
+	_, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(domain))
+}
@@ -0,0 +1,270 @@
+package service
+
+// signup_service_test.go
+// 셀프 가입 승인 대기열 서비스 단위 테스트 (SQLite in-memory DB)
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// signupKeycloakService 이메일 인증 여부와 활성/비활성/realm role 할당 호출을 기록하는 KeycloakService 스텁
+type signupKeycloakService struct {
+	mockKeycloakService
+	verified    map[string]bool
+	enabled     []string
+	disabled    []string
+	realmRoles  []string
+	verifyMails []string
+}
+
+func (m *signupKeycloakService) GetUser(ctx context.Context, kcId string) (*gocloak.User, error) {
+	return &gocloak.User{ID: gocloak.StringP(kcId), EmailVerified: gocloak.BoolP(m.verified[kcId])}, nil
+}
+
+func (m *signupKeycloakService) EnableUser(ctx context.Context, kcUserID string) error {
+	m.enabled = append(m.enabled, kcUserID)
+	return nil
+}
+
+func (m *signupKeycloakService) DisableUser(ctx context.Context, kcUserID string) error {
+	m.disabled = append(m.disabled, kcUserID)
+	return nil
+}
+
+func (m *signupKeycloakService) AssignRealmRoleToUser(ctx context.Context, kcUserId, roleName string) error {
+	m.realmRoles = append(m.realmRoles, kcUserId+"/"+roleName)
+	return nil
+}
+
+func (m *signupKeycloakService) CheckRealmRoleExists(ctx context.Context, roleName string) (bool, error) {
+	return true, nil
+}
+
+func (m *signupKeycloakService) SendVerifyEmail(ctx context.Context, kcUserID string) error {
+	m.verifyMails = append(m.verifyMails, kcUserID)
+	return nil
+}
+
+func newSignupTestService(t *testing.T) (*SignupService, *signupKeycloakService, *gorm.DB) {
+	t.Helper()
+	t.Setenv("MC_IAM_MANAGER_SIGNUP_AUTO_APPROVE_DOMAINS", "")
+	t.Setenv("MC_IAM_MANAGER_SIGNUP_DEFAULT_PLATFORM_ROLE", "")
+	db := setupGroupRoleTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.Signup{}))
+
+	kc := &signupKeycloakService{verified: map[string]bool{}}
+	svc := NewSignupService(db)
+	svc.keycloakService = kc
+	return svc, kc, db
+}
+
+func createSignupTestRecord(t *testing.T, db *gorm.DB, kcID, email string, status model.SignupStatus) *model.Signup {
+	t.Helper()
+	createGRTestUser(t, db, kcID, kcID)
+	signup := &model.Signup{
+		KcUserID:    kcID,
+		Username:    kcID,
+		Email:       email,
+		EmailDomain: emailDomain(email),
+		Status:      status,
+	}
+	require.NoError(t, repository.NewSignupRepository(db).Create(signup))
+	return signup
+}
+
+func TestSignupService_ApproveAssignsRoleAndOrganizations(t *testing.T) {
+	svc, kc, db := newSignupTestService(t)
+	signup := createSignupTestRecord(t, db, "kc-new", "new@example.com", model.SignupStatusPendingApproval)
+	viewer := createGRTestRole(t, db, "viewer")
+	org := createGRTestOrg(t, db, "dev", "DEV")
+	reviewer := createGRTestUser(t, db, "admin", "kc-admin")
+
+	approved, err := svc.ApproveSignup(context.Background(), signup.ID, reviewer.ID, &model.SignupApproveRequest{
+		PlatformRole:    "viewer",
+		OrganizationIDs: []uint{org.ID},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, model.SignupStatusApproved, approved.Status)
+	assert.Equal(t, []string{"kc-new"}, kc.enabled)
+	assert.Equal(t, []string{"kc-new/viewer"}, kc.realmRoles)
+
+	var user model.User
+	require.NoError(t, db.Where("kc_id = ?", "kc-new").First(&user).Error)
+	assigned, err := svc.roleService.IsAssignedPlatformRole(user.ID, viewer.ID)
+	require.NoError(t, err)
+	assert.True(t, assigned)
+	var orgCount int64
+	require.NoError(t, db.Model(&model.UserOrganization{}).Where("user_id = ? AND organization_id = ?", user.ID, org.ID).Count(&orgCount).Error)
+	assert.Equal(t, int64(1), orgCount)
+
+	stored, err := svc.GetSignup(context.Background(), signup.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{org.ID}, stored.AssignedOrgIDs)
+	require.NotNil(t, stored.ReviewerUserID)
+	assert.Equal(t, reviewer.ID, *stored.ReviewerUserID)
+
+	// 이미 처리된 신청은 다시 승인/거절할 수 없음
+	_, err = svc.ApproveSignup(context.Background(), signup.ID, reviewer.ID, &model.SignupApproveRequest{})
+	assert.ErrorIs(t, err, ErrSignupNotPending)
+	_, err = svc.RejectSignup(context.Background(), signup.ID, reviewer.ID, "duplicate")
+	assert.ErrorIs(t, err, ErrSignupNotPending)
+}
+
+func TestSignupService_ApproveValidatesRequest(t *testing.T) {
+	svc, kc, db := newSignupTestService(t)
+	signup := createSignupTestRecord(t, db, "kc-new", "new@example.com", model.SignupStatusPendingApproval)
+
+	_, err := svc.ApproveSignup(context.Background(), signup.ID, 1, &model.SignupApproveRequest{PlatformRole: "missing"})
+	assert.ErrorIs(t, err, ErrSignupInvalid)
+	_, err = svc.ApproveSignup(context.Background(), signup.ID, 1, &model.SignupApproveRequest{OrganizationIDs: []uint{999}})
+	assert.ErrorIs(t, err, ErrSignupInvalid)
+	assert.Empty(t, kc.enabled, "검증 실패 시 Keycloak 사용자를 활성화하지 않아야 함")
+
+	_, err = svc.ApproveSignup(context.Background(), 999, 1, &model.SignupApproveRequest{})
+	assert.ErrorIs(t, err, ErrSignupNotFound)
+}
+
+func TestSignupService_ApproveRequiresEmailVerification(t *testing.T) {
+	svc, kc, db := newSignupTestService(t)
+	signup := createSignupTestRecord(t, db, "kc-new", "new@example.com", model.SignupStatusPendingVerification)
+
+	_, err := svc.ApproveSignup(context.Background(), signup.ID, 1, &model.SignupApproveRequest{})
+	assert.ErrorIs(t, err, ErrSignupNotVerified)
+
+	// 인증 완료 후에는 비활성화(승인 대기)로 전환되어 승인 가능
+	kc.verified["kc-new"] = true
+	approved, err := svc.ApproveSignup(context.Background(), signup.ID, 1, &model.SignupApproveRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, model.SignupStatusApproved, approved.Status)
+	assert.NotNil(t, approved.EmailVerifiedAt)
+	assert.Equal(t, []string{"kc-new"}, kc.disabled)
+	assert.Equal(t, []string{"kc-new"}, kc.enabled)
+}
+
+func TestSignupService_VerificationAutoApprovesAllowlistedDomain(t *testing.T) {
+	svc, kc, db := newSignupTestService(t)
+	t.Setenv("MC_IAM_MANAGER_SIGNUP_AUTO_APPROVE_DOMAINS", "Example.com, @m-cmp.org")
+	t.Setenv("MC_IAM_MANAGER_SIGNUP_DEFAULT_PLATFORM_ROLE", "viewer")
+	createGRTestRole(t, db, "viewer")
+	trusted := createSignupTestRecord(t, db, "kc-trusted", "dev@m-cmp.org", model.SignupStatusPendingVerification)
+	other := createSignupTestRecord(t, db, "kc-other", "dev@other.io", model.SignupStatusPendingVerification)
+	waiting := createSignupTestRecord(t, db, "kc-waiting", "dev@example.com", model.SignupStatusPendingVerification)
+	kc.verified["kc-trusted"] = true
+	kc.verified["kc-other"] = true
+
+	signups, err := svc.ListSignups(context.Background(), &model.SignupFilterRequest{
+		Status: string(model.SignupStatusPendingApproval),
+	})
+	require.NoError(t, err)
+	require.Len(t, signups, 1)
+	assert.Equal(t, other.ID, signups[0].ID)
+
+	approved, err := svc.GetSignup(context.Background(), trusted.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.SignupStatusApproved, approved.Status)
+	assert.True(t, approved.AutoApproved)
+	assert.Equal(t, "viewer", approved.AssignedPlatformRole)
+	assert.Equal(t, []string{"kc-trusted/viewer"}, kc.realmRoles)
+
+	stillWaiting, err := svc.GetSignup(context.Background(), waiting.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.SignupStatusPendingVerification, stillWaiting.Status)
+}
+
+func TestSignupService_AutoApproveRequiresVerifiedEmail(t *testing.T) {
+	svc, kc, db := newSignupTestService(t)
+	t.Setenv("MC_IAM_MANAGER_SIGNUP_AUTO_APPROVE_DOMAINS", "m-cmp.org")
+	createGRTestRole(t, db, "viewer")
+	signup := createSignupTestRecord(t, db, "kc-unverified", "dev@m-cmp.org", model.SignupStatusPendingApproval)
+
+	// 이메일 인증 없이 가입한 신청은 도메인이 허용 목록에 있어도 승인 대기
+	result, err := svc.autoApproveIfAllowed(context.Background(), signup)
+	require.NoError(t, err)
+	assert.Equal(t, model.SignupStatusPendingApproval, result.Status)
+	assert.Empty(t, kc.enabled)
+
+	now := time.Now().UTC()
+	signup.EmailVerifiedAt = &now
+	result, err = svc.autoApproveIfAllowed(context.Background(), signup)
+	require.NoError(t, err)
+	assert.Equal(t, model.SignupStatusApproved, result.Status)
+	assert.True(t, result.AutoApproved)
+}
+
+func TestSignupService_RejectRequiresReason(t *testing.T) {
+	svc, kc, db := newSignupTestService(t)
+	signup := createSignupTestRecord(t, db, "kc-new", "new@example.com", model.SignupStatusPendingVerification)
+
+	_, err := svc.RejectSignup(context.Background(), signup.ID, 1, " ")
+	assert.ErrorIs(t, err, ErrSignupInvalid)
+
+	rejected, err := svc.RejectSignup(context.Background(), signup.ID, 1, "unknown organization")
+	require.NoError(t, err)
+	assert.Equal(t, model.SignupStatusRejected, rejected.Status)
+	assert.Equal(t, "unknown organization", rejected.Reason)
+	assert.Equal(t, []string{"kc-new"}, kc.disabled, "인증 대기 중 활성화된 계정은 거절 시 비활성화")
+	assert.Empty(t, kc.enabled)
+}
+
+func TestSignupService_CheckLoginAllowed(t *testing.T) {
+	svc, _, db := newSignupTestService(t)
+	createSignupTestRecord(t, db, "kc-pending", "a@example.com", model.SignupStatusPendingApproval)
+	createSignupTestRecord(t, db, "kc-approved", "b@example.com", model.SignupStatusApproved)
+	createSignupTestRecord(t, db, "kc-rejected", "c@example.com", model.SignupStatusRejected)
+
+	cases := map[string]bool{
+		"kc-pending":  false,
+		"kc-approved": true,
+		"kc-rejected": false,
+		"kc-legacy":   true, // 가입 신청 없이 생성된 계정
+	}
+	for kcID, want := range cases {
+		allowed, err := svc.CheckLoginAllowed(context.Background(), kcID)
+		require.NoError(t, err)
+		assert.Equal(t, want, allowed, kcID)
+	}
+}
+
+func TestSignupService_ResendVerification(t *testing.T) {
+	svc, kc, db := newSignupTestService(t)
+	waiting := createSignupTestRecord(t, db, "kc-waiting", "a@example.com", model.SignupStatusPendingVerification)
+	pending := createSignupTestRecord(t, db, "kc-pending", "b@example.com", model.SignupStatusPendingApproval)
+
+	_, err := svc.ResendVerification(context.Background(), waiting.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"kc-waiting"}, kc.verifyMails)
+
+	_, err = svc.ResendVerification(context.Background(), pending.ID)
+	assert.ErrorIs(t, err, ErrSignupNotPending)
+}
+
+func TestSignupRepository_ListFilters(t *testing.T) {
+	_, _, db := newSignupTestService(t)
+	repo := repository.NewSignupRepository(db)
+	createSignupTestRecord(t, db, "kc-a", "Alice@Example.com", model.SignupStatusPendingApproval)
+	createSignupTestRecord(t, db, "kc-b", "bob@other.io", model.SignupStatusPendingApproval)
+	createSignupTestRecord(t, db, "kc-c", "carol@example.com", model.SignupStatusRejected)
+
+	byDomain, err := repo.List(&model.SignupFilterRequest{Domain: "@EXAMPLE.com"})
+	require.NoError(t, err)
+	assert.Len(t, byDomain, 2)
+
+	byEmail, err := repo.List(&model.SignupFilterRequest{Email: "alice", Status: string(model.SignupStatusPendingApproval)})
+	require.NoError(t, err)
+	require.Len(t, byEmail, 1)
+	assert.Equal(t, "kc-a", byEmail[0].KcUserID)
+}
+
+func TestEmailDomain(t *testing.T) {
+	assert.Equal(t, "example.com", emailDomain("dev@Example.COM"))
+	assert.Equal(t, "", emailDomain("invalid"))
+}
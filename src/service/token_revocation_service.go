@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/config"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+// tokenRevocationReloadInterval 다른 인스턴스가 기록한 폐기 항목을 DB에서 다시 읽는 간격
+const tokenRevocationReloadInterval = 15 * time.Second
+
+// access token 폐기 사유
+const (
+	TokenRevokedByAdmin        = "revoked by admin"
+	TokenRevokedByDeactivation = "user deactivated"
+	TokenRevokedByWithdrawal   = "user withdrawn"
+	TokenRevokedByRoleRemoval  = "platform role removed"
+	tokenRevokedByRoleExpiry   = "platform role expired"
+)
+
+// ErrUserSessionNotFound 사용자에게 해당 세션이 없음
+var ErrUserSessionNotFound = errors.New("session not found for user")
+
+// tokenRevocationCache 요청마다 DB를 조회하지 않도록 프로세스 내에서 공유하는 폐기 목록
+type tokenRevocationCache struct {
+	mu           sync.RWMutex
+	tokenIDs     map[string]bool      // jti
+	sessionIDs   map[string]time.Time // sid → 이 시각 이전 발급 토큰 무효
+	issuedBefore map[string]time.Time // kcUserID → 이 시각 이전 발급 토큰 무효
+	loadedAt     time.Time
+	loadMu       sync.Mutex
+}
+
+func newTokenRevocationCache() *tokenRevocationCache {
+	return &tokenRevocationCache{
+		tokenIDs:     map[string]bool{},
+		sessionIDs:   map[string]time.Time{},
+		issuedBefore: map[string]time.Time{},
+	}
+}
+
+var sharedTokenRevocationCache = newTokenRevocationCache()
+
+func (c *tokenRevocationCache) add(r *model.TokenRevocation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.addLocked(r)
+}
+
+func (c *tokenRevocationCache) addLocked(r *model.TokenRevocation) {
+	switch {
+	case r.TokenID != "":
+		c.tokenIDs[r.TokenID] = true
+	case r.SessionID != "" && r.IssuedBefore != nil:
+		if r.IssuedBefore.After(c.sessionIDs[r.SessionID]) {
+			c.sessionIDs[r.SessionID] = *r.IssuedBefore
+		}
+	case r.IssuedBefore != nil:
+		if r.IssuedBefore.After(c.issuedBefore[r.KcUserID]) {
+			c.issuedBefore[r.KcUserID] = *r.IssuedBefore
+		}
+	}
+}
+
+// TokenRevocationService access token 로컬 폐기 목록과 Keycloak 세션 관리
+// Keycloak 세션을 종료해도 이미 발급된 access token은 만료 전까지 서명 검증을 통과하므로,
+// AuthMiddleware가 IsRevoked로 폐기 목록을 함께 확인한다.
+type TokenRevocationService struct {
+	repo            *repository.TokenRevocationRepository
+	keycloakService KeycloakService
+	cache           *tokenRevocationCache
+	now             func() time.Time
+}
+
+// NewTokenRevocationService 새 TokenRevocationService 인스턴스 생성
+func NewTokenRevocationService(db *gorm.DB) *TokenRevocationService {
+	return &TokenRevocationService{
+		repo:            repository.NewTokenRevocationRepository(db),
+		keycloakService: NewKeycloakService(),
+		cache:           sharedTokenRevocationCache,
+		now:             time.Now,
+	}
+}
+
+// IsRevoked 토큰(jti, sid, 발급 사용자/시각)이 폐기되었는지 확인
+// 발급 시각을 알 수 없는 토큰은 사용자/세션 단위 폐기 대상이면 폐기된 것으로 본다.
+func (s *TokenRevocationService) IsRevoked(kcUserID, tokenID, sessionID string, issuedAt *time.Time) bool {
+	s.reloadIfStale()
+
+	s.cache.mu.RLock()
+	defer s.cache.mu.RUnlock()
+	if tokenID != "" && s.cache.tokenIDs[tokenID] {
+		return true
+	}
+	revokedBefore := func(before time.Time, ok bool) bool {
+		return ok && (issuedAt == nil || !issuedAt.After(before))
+	}
+	if sessionID != "" {
+		before, ok := s.cache.sessionIDs[sessionID]
+		if revokedBefore(before, ok) {
+			return true
+		}
+	}
+	before, ok := s.cache.issuedBefore[kcUserID]
+	return revokedBefore(before, ok)
+}
+
+// RevokeUserTokens 사용자가 지금까지 발급받은 access token 전체 폐기 (Keycloak 세션은 유지)
+func (s *TokenRevocationService) RevokeUserTokens(kcUserID, reason, revokedBy string) (time.Time, error) {
+	return s.revokeIssuedBefore(kcUserID, "", reason, revokedBy)
+}
+
+// RevokeToken access token 하나를 jti로 폐기 (expiresAt: 토큰 만료 시각)
+func (s *TokenRevocationService) RevokeToken(kcUserID, tokenID string, expiresAt time.Time, reason, revokedBy string) error {
+	if tokenID == "" {
+		return fmt.Errorf("token id (jti) is required")
+	}
+	revocation := &model.TokenRevocation{
+		TokenID:   tokenID,
+		KcUserID:  kcUserID,
+		Reason:    reason,
+		RevokedBy: revokedBy,
+		ExpiresAt: expiresAt.Add(config.TokenClockSkew()),
+	}
+	if err := s.repo.Create(revocation); err != nil {
+		return err
+	}
+	s.cache.add(revocation)
+	return nil
+}
+
+// ListUserSessions 사용자의 활성 Keycloak 세션 목록
+func (s *TokenRevocationService) ListUserSessions(ctx context.Context, kcUserID string) ([]model.UserSession, error) {
+	sessions, err := s.keycloakService.GetUserSessions(ctx, kcUserID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]model.UserSession, 0, len(sessions))
+	for _, session := range sessions {
+		if session == nil || session.ID == nil {
+			continue
+		}
+		item := model.UserSession{ID: *session.ID}
+		if session.IPAddress != nil {
+			item.IPAddress = *session.IPAddress
+		}
+		if session.Start != nil {
+			start := time.UnixMilli(*session.Start).UTC()
+			item.Start = &start
+		}
+		if session.LastAccess != nil {
+			lastAccess := time.UnixMilli(*session.LastAccess).UTC()
+			item.LastAccess = &lastAccess
+		}
+		if session.Clients != nil {
+			item.Clients = *session.Clients
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// RevokeSession 사용자 세션 하나를 종료하고 그 세션(sid)으로 발급된 access token 폐기
+func (s *TokenRevocationService) RevokeSession(ctx context.Context, kcUserID, sessionID, revokedBy string) (*model.RevokeUserSessionsResponse, error) {
+	sessions, err := s.ListUserSessions(ctx, kcUserID)
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for _, session := range sessions {
+		if session.ID == sessionID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, ErrUserSessionNotFound
+	}
+
+	issuedBefore, err := s.revokeIssuedBefore(kcUserID, sessionID, TokenRevokedByAdmin, revokedBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.keycloakService.LogoutUserSession(ctx, sessionID); err != nil {
+		return nil, err
+	}
+	return &model.RevokeUserSessionsResponse{KcUserID: kcUserID, SessionID: sessionID, IssuedBefore: issuedBefore}, nil
+}
+
+// RevokeAllSessions 사용자의 모든 Keycloak 세션을 종료하고 발급된 access token 전체 폐기
+// 로컬 폐기는 Keycloak 호출 실패와 관계없이 먼저 기록한다.
+func (s *TokenRevocationService) RevokeAllSessions(ctx context.Context, kcUserID, reason, revokedBy string) (*model.RevokeUserSessionsResponse, error) {
+	issuedBefore, err := s.RevokeUserTokens(kcUserID, reason, revokedBy)
+	if err != nil {
+		return nil, err
+	}
+	resp := &model.RevokeUserSessionsResponse{KcUserID: kcUserID, IssuedBefore: issuedBefore}
+	if err := s.keycloakService.LogoutAllUserSessions(ctx, kcUserID); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+func (s *TokenRevocationService) revokeIssuedBefore(kcUserID, sessionID, reason, revokedBy string) (time.Time, error) {
+	if kcUserID == "" {
+		return time.Time{}, fmt.Errorf("kc user id is required")
+	}
+	now := s.now().UTC()
+	revocation := &model.TokenRevocation{
+		KcUserID:     kcUserID,
+		SessionID:    sessionID,
+		IssuedBefore: &now,
+		Reason:       reason,
+		RevokedBy:    revokedBy,
+		// 이 시각 이전에 발급된 access token이 모두 만료될 때까지 유지
+		ExpiresAt: now.Add(time.Duration(config.AccessTokenLifespanSec())*time.Second + config.TokenClockSkew()),
+	}
+	if err := s.repo.Create(revocation); err != nil {
+		return time.Time{}, err
+	}
+	s.cache.add(revocation)
+	return now, nil
+}
+
+// reloadIfStale 다른 인스턴스의 폐기 항목 반영 (동시에 한 요청만 DB 조회, 실패 시 기존 목록 유지)
+func (s *TokenRevocationService) reloadIfStale() {
+	now := s.now()
+	s.cache.mu.RLock()
+	stale := now.Sub(s.cache.loadedAt) >= tokenRevocationReloadInterval
+	s.cache.mu.RUnlock()
+	if !stale || !s.cache.loadMu.TryLock() {
+		return
+	}
+	defer s.cache.loadMu.Unlock()
+
+	revocations, err := s.repo.FindActive(now.UTC())
+	if err != nil {
+		log.Printf("[WARN] failed to reload token revocations: %v", err)
+		s.cache.mu.Lock()
+		s.cache.loadedAt = now // 매 요청 재시도 방지
+		s.cache.mu.Unlock()
+		return
+	}
+	if _, err := s.repo.DeleteExpired(now.UTC()); err != nil {
+		log.Printf("[WARN] %v", err)
+	}
+
+	s.cache.mu.Lock()
+	defer s.cache.mu.Unlock()
+	s.cache.tokenIDs = map[string]bool{}
+	s.cache.sessionIDs = map[string]time.Time{}
+	s.cache.issuedBefore = map[string]time.Time{}
+	for i := range revocations {
+		s.cache.addLocked(&revocations[i])
+	}
+	s.cache.loadedAt = now
+}
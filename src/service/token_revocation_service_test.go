@@ -0,0 +1,118 @@
+package service
+
+// token_revocation_service_test.go
+// access token 로컬 폐기 목록(TokenRevocationService) 단위 테스트 (SQLite in-memory DB)
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sessionKeycloakService 세션 목록을 돌려주고 로그아웃 호출을 기록하는 KeycloakService 스텁
+type sessionKeycloakService struct {
+	mockKeycloakService
+	sessions   []*gocloak.UserSessionRepresentation
+	loggedOut  []string
+	logoutUser []string
+}
+
+func (m *sessionKeycloakService) GetUserSessions(ctx context.Context, kcUserID string) ([]*gocloak.UserSessionRepresentation, error) {
+	return m.sessions, nil
+}
+
+func (m *sessionKeycloakService) LogoutUserSession(ctx context.Context, sessionID string) error {
+	m.loggedOut = append(m.loggedOut, sessionID)
+	return nil
+}
+
+func (m *sessionKeycloakService) LogoutAllUserSessions(ctx context.Context, kcUserID string) error {
+	m.logoutUser = append(m.logoutUser, kcUserID)
+	return nil
+}
+
+func newTestTokenRevocationService(t *testing.T, kc KeycloakService, now *time.Time) *TokenRevocationService {
+	t.Helper()
+	db := setupGroupRoleTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.TokenRevocation{}))
+	return &TokenRevocationService{
+		repo:            repository.NewTokenRevocationRepository(db),
+		keycloakService: kc,
+		cache:           newTokenRevocationCache(),
+		now:             func() time.Time { return *now },
+	}
+}
+
+func TestTokenRevocation_RevokeAllSessionsRejectsEarlierTokens(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	kc := &sessionKeycloakService{}
+	svc := newTestTokenRevocationService(t, kc, &now)
+
+	before := now.Add(-time.Minute)
+	assert.False(t, svc.IsRevoked("kc-1", "jti-1", "", &before))
+
+	resp, err := svc.RevokeAllSessions(context.Background(), "kc-1", TokenRevokedByDeactivation, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"kc-1"}, kc.logoutUser)
+	assert.Equal(t, now.UTC(), resp.IssuedBefore)
+
+	assert.True(t, svc.IsRevoked("kc-1", "jti-1", "", &before))
+	assert.True(t, svc.IsRevoked("kc-1", "", "", nil), "발급 시각이 없는 토큰은 거부")
+	after := now.Add(time.Second)
+	assert.False(t, svc.IsRevoked("kc-1", "jti-2", "", &after), "폐기 이후 재발급된 토큰은 허용")
+	assert.False(t, svc.IsRevoked("kc-2", "jti-3", "", &before), "다른 사용자는 영향 없음")
+}
+
+func TestTokenRevocation_RevokeSessionAndToken(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	kc := &sessionKeycloakService{sessions: []*gocloak.UserSessionRepresentation{
+		{ID: gocloak.StringP("sid-1"), IPAddress: gocloak.StringP("10.0.0.1"), Start: gocloak.Int64P(now.UnixMilli())},
+		{ID: gocloak.StringP("sid-2")},
+	}}
+	svc := newTestTokenRevocationService(t, kc, &now)
+
+	sessions, err := svc.ListUserSessions(context.Background(), "kc-1")
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, "10.0.0.1", sessions[0].IPAddress)
+	assert.Equal(t, now.UTC(), *sessions[0].Start)
+
+	_, err = svc.RevokeSession(context.Background(), "kc-1", "sid-unknown", "admin")
+	assert.ErrorIs(t, err, ErrUserSessionNotFound)
+
+	_, err = svc.RevokeSession(context.Background(), "kc-1", "sid-1", "admin")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sid-1"}, kc.loggedOut)
+
+	issuedAt := now.Add(-time.Minute)
+	assert.True(t, svc.IsRevoked("kc-1", "", "sid-1", &issuedAt))
+	assert.False(t, svc.IsRevoked("kc-1", "", "sid-2", &issuedAt), "다른 세션의 토큰은 허용")
+
+	require.NoError(t, svc.RevokeToken("kc-1", "jti-9", now.Add(time.Minute), TokenRevokedByAdmin, "admin"))
+	assert.True(t, svc.IsRevoked("kc-1", "jti-9", "sid-2", &issuedAt))
+}
+
+func TestTokenRevocation_ReloadSharesAndPrunesEntries(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	writer := newTestTokenRevocationService(t, &sessionKeycloakService{}, &now)
+	_, err := writer.RevokeUserTokens("kc-1", TokenRevokedByRoleRemoval, "admin")
+	require.NoError(t, err)
+
+	// 같은 DB를 보는 다른 인스턴스는 다음 재조회 때 폐기 항목을 반영
+	reader := &TokenRevocationService{repo: writer.repo, cache: newTokenRevocationCache(), now: writer.now}
+	issuedAt := now.Add(-time.Second)
+	assert.True(t, reader.IsRevoked("kc-1", "", "", &issuedAt))
+
+	// 대상 토큰이 모두 만료된 뒤에는 항목이 정리된다
+	now = now.Add(24 * time.Hour)
+	assert.False(t, reader.IsRevoked("kc-1", "", "", &issuedAt))
+	active, err := writer.repo.FindActive(time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, active)
+}
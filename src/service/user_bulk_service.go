@@ -0,0 +1,541 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+// maxUserImportRows 한 번에 가져올 수 있는 최대 행 수
+const maxUserImportRows = 1000
+
+// userImportCSVHeader 일괄 가져오기/내보내기 CSV 컬럼
+var userImportCSVHeader = []string{"username", "email", "first_name", "last_name", "organization_codes", "platform_roles", "workspace_roles"}
+
+// ErrInvalidUserImport 가져오기 문서 형식 오류
+var ErrInvalidUserImport = errors.New("invalid user import")
+
+// UserBulkService 사용자 일괄 가져오기/내보내기 서비스
+// 사용자명 기준으로 멱등하게 동작한다: 없는 사용자만 생성하고, 기존 사용자에는 누락된 조직/역할 할당만 추가한다.
+type UserBulkService struct {
+	db            *gorm.DB
+	userRepo      *repository.UserRepository
+	roleRepo      *repository.RoleRepository
+	orgRepo       *repository.OrganizationRepository
+	workspaceRepo *repository.WorkspaceRepository
+	roleService   *RoleService
+	kcService     KeycloakService
+}
+
+// NewUserBulkService 새 UserBulkService 인스턴스 생성
+func NewUserBulkService(db *gorm.DB) *UserBulkService {
+	return &UserBulkService{
+		db:            db,
+		userRepo:      repository.NewUserRepository(db),
+		roleRepo:      repository.NewRoleRepository(db),
+		orgRepo:       repository.NewOrganizationRepository(db),
+		workspaceRepo: repository.NewWorkspaceRepository(db),
+		roleService:   NewRoleService(db),
+		kcService:     NewKeycloakService(),
+	}
+}
+
+// ParseUserImport 가져오기 문서 파싱. CSV는 헤더 행이 필요하며, JSON은 {"users": [...]} 또는 행 배열을 받는다.
+func ParseUserImport(body []byte, isCSV bool) ([]model.UserImportRow, error) {
+	if isCSV {
+		return parseUserImportCSV(body)
+	}
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var rows []model.UserImportRow
+		if err := json.Unmarshal(trimmed, &rows); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidUserImport, err)
+		}
+		return rows, nil
+	}
+	var req model.UserImportRequest
+	if err := json.Unmarshal(trimmed, &req); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidUserImport, err)
+	}
+	return req.Users, nil
+}
+
+func parseUserImportCSV(body []byte) ([]model.UserImportRow, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(body, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read csv header: %v", ErrInvalidUserImport, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["username"]; !ok {
+		return nil, fmt.Errorf("%w: csv header must contain username", ErrInvalidUserImport)
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var rows []model.UserImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidUserImport, err)
+		}
+		row := model.UserImportRow{
+			Username:          field(record, "username"),
+			Email:             field(record, "email"),
+			FirstName:         field(record, "first_name"),
+			LastName:          field(record, "last_name"),
+			OrganizationCodes: splitImportList(field(record, "organization_codes")),
+			PlatformRoles:     splitImportList(field(record, "platform_roles")),
+		}
+		for _, pair := range splitImportList(field(record, "workspace_roles")) {
+			i := strings.LastIndex(pair, ":")
+			if i < 0 {
+				// 형식 오류는 행 검증에서 보고
+				row.WorkspaceRoles = append(row.WorkspaceRoles, model.UserImportWorkspaceRole{Workspace: pair})
+				continue
+			}
+			row.WorkspaceRoles = append(row.WorkspaceRoles, model.UserImportWorkspaceRole{
+				Workspace: strings.TrimSpace(pair[:i]),
+				Role:      strings.TrimSpace(pair[i+1:]),
+			})
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// splitImportList ';'로 구분된 값 목록 (빈 값 제외)
+func splitImportList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ";") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// WriteUserExportCSV 내보내기 행을 가져오기와 같은 형식의 CSV로 작성
+func WriteUserExportCSV(rows []model.UserImportRow) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(userImportCSVHeader); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		pairs := make([]string, 0, len(row.WorkspaceRoles))
+		for _, wr := range row.WorkspaceRoles {
+			pairs = append(pairs, wr.Workspace+":"+wr.Role)
+		}
+		if err := writer.Write([]string{
+			row.Username,
+			row.Email,
+			row.FirstName,
+			row.LastName,
+			strings.Join(row.OrganizationCodes, ";"),
+			strings.Join(row.PlatformRoles, ";"),
+			strings.Join(pairs, ";"),
+		}); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// Import 사용자 일괄 가져오기. dryRun이면 검증과 변경 계획만 반환한다.
+// 행 단위로 처리하므로 일부 행이 실패해도 나머지 행은 반영되고, 같은 문서를 다시 가져오면 UNCHANGED가 된다.
+func (s *UserBulkService) Import(ctx context.Context, rows []model.UserImportRow, dryRun bool, companyID *uint) (*model.UserImportResult, error) {
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no rows to import", ErrInvalidUserImport)
+	}
+	if len(rows) > maxUserImportRows {
+		return nil, fmt.Errorf("%w: at most %d rows can be imported at once", ErrInvalidUserImport, maxUserImportRows)
+	}
+
+	result := &model.UserImportResult{DryRun: dryRun, Total: len(rows), Rows: make([]model.UserImportRowResult, 0, len(rows))}
+	seen := make(map[string]int, len(rows))
+	for i := range rows {
+		rowResult := s.importRow(ctx, i+1, &rows[i], dryRun, companyID, seen)
+		switch rowResult.Status {
+		case model.UserImportRowCreated:
+			result.Created++
+		case model.UserImportRowUpdated:
+			result.Updated++
+		case model.UserImportRowUnchanged:
+			result.Unchanged++
+		case model.UserImportRowValid:
+			result.Valid++
+		default:
+			result.Failed++
+		}
+		result.Rows = append(result.Rows, rowResult)
+	}
+	return result, nil
+}
+
+// userImportPlan 행 검증 결과 (참조 해석 및 누락된 할당)
+type userImportPlan struct {
+	user           *model.User
+	kcUser         *gocloak.User // Keycloak에는 있지만 DB에 없는 사용자
+	orgs           []model.Organization
+	platformRoles  []*model.RoleMaster
+	workspaceRoles []userImportWorkspaceGrant
+}
+
+type userImportWorkspaceGrant struct {
+	workspace *model.Workspace
+	role      *model.RoleMaster
+}
+
+func (s *UserBulkService) importRow(ctx context.Context, rowNum int, row *model.UserImportRow, dryRun bool,
+	companyID *uint, seen map[string]int) model.UserImportRowResult {
+	row.Username = strings.ToLower(strings.TrimSpace(row.Username))
+	row.Email = strings.TrimSpace(row.Email)
+	result := model.UserImportRowResult{Row: rowNum, Username: row.Username}
+
+	plan, errs := s.planRow(ctx, row, companyID)
+	if row.Username != "" {
+		if first, dup := seen[row.Username]; dup {
+			errs = append(errs, fmt.Sprintf("duplicate username (first seen in row %d)", first))
+		} else {
+			seen[row.Username] = rowNum
+		}
+	}
+	if len(errs) > 0 {
+		result.Status = model.UserImportRowFailed
+		result.Errors = errs
+		return result
+	}
+
+	creating := plan.user == nil
+	if dryRun {
+		result.Status = model.UserImportRowValid
+		if plan.user != nil {
+			result.UserID = plan.user.ID
+		}
+		result.Changes = plan.changes(row)
+		return result
+	}
+
+	user := plan.user
+	if creating {
+		created, change, err := s.createUser(ctx, row, plan.kcUser)
+		if err != nil {
+			result.Status = model.UserImportRowFailed
+			result.Errors = []string{err.Error()}
+			return result
+		}
+		user = created
+		result.Changes = append(result.Changes, change)
+	}
+	result.UserID = user.ID
+
+	if len(plan.orgs) > 0 {
+		orgIDs := make([]uint, 0, len(plan.orgs))
+		for _, org := range plan.orgs {
+			orgIDs = append(orgIDs, org.ID)
+		}
+		if err := s.orgRepo.AssignUserToOrganizations(user.ID, orgIDs); err != nil {
+			result.Errors = append(result.Errors, err.Error())
+		} else {
+			for _, org := range plan.orgs {
+				result.Changes = append(result.Changes, "add to organization "+org.OrganizationCode)
+			}
+		}
+	}
+	for _, role := range plan.platformRoles {
+		if _, err := grantPlatformRole(ctx, s.roleService, s.kcService, user, role); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("platform role %s: %v", role.Name, err))
+			continue
+		}
+		result.Changes = append(result.Changes, "grant platform role "+role.Name)
+	}
+	for _, grant := range plan.workspaceRoles {
+		if err := s.roleRepo.AssignWorkspaceRole(user.ID, grant.workspace.ID, grant.role.ID); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("workspace role %s:%s: %v", grant.workspace.Name, grant.role.Name, err))
+			continue
+		}
+		result.Changes = append(result.Changes, fmt.Sprintf("grant workspace role %s:%s", grant.workspace.Name, grant.role.Name))
+	}
+
+	switch {
+	case len(result.Errors) > 0:
+		result.Status = model.UserImportRowFailed
+	case creating:
+		result.Status = model.UserImportRowCreated
+	case len(result.Changes) > 0:
+		result.Status = model.UserImportRowUpdated
+	default:
+		result.Status = model.UserImportRowUnchanged
+	}
+	return result
+}
+
+// planRow 행의 조직/역할/워크스페이스 참조를 해석하고 기존 사용자에게 누락된 할당만 남긴다
+func (s *UserBulkService) planRow(ctx context.Context, row *model.UserImportRow, companyID *uint) (*userImportPlan, []string) {
+	plan := &userImportPlan{}
+	var errs []string
+	if row.Username == "" {
+		return plan, []string{"username is required"}
+	}
+
+	user, err := s.userRepo.FindByUsername(row.Username)
+	switch {
+	case err == nil:
+		plan.user = user
+	case errors.Is(err, repository.ErrUserNotFound):
+		kcUser, err := s.kcService.GetUserByUsername(ctx, row.Username)
+		if err != nil && !errors.Is(err, repository.ErrUserNotFound) {
+			errs = append(errs, fmt.Sprintf("failed to look up keycloak user: %v", err))
+		}
+		if kcUser != nil && kcUser.ID != nil {
+			plan.kcUser = kcUser
+		} else if row.Email == "" || !strings.Contains(row.Email, "@") {
+			errs = append(errs, "a valid email is required to create a user")
+		}
+	default:
+		errs = append(errs, err.Error())
+	}
+
+	var existingOrgs map[uint]bool
+	if plan.user != nil {
+		orgs, err := s.orgRepo.FindDirectUserOrganizations(plan.user.ID)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+		existingOrgs = make(map[uint]bool, len(orgs))
+		for _, org := range orgs {
+			existingOrgs[org.ID] = true
+		}
+	}
+	for _, code := range row.OrganizationCodes {
+		org, err := s.orgRepo.FindByCode(code)
+		if err != nil {
+			if errors.Is(err, repository.ErrOrganizationNotFound) {
+				errs = append(errs, fmt.Sprintf("organization code %q not found", code))
+			} else {
+				errs = append(errs, err.Error())
+			}
+			continue
+		}
+		if !existingOrgs[org.ID] {
+			plan.orgs = append(plan.orgs, *org)
+		}
+	}
+
+	for _, name := range row.PlatformRoles {
+		role, err := s.roleRepo.FindRoleByRoleName(name, constants.RoleTypePlatform)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		if role == nil {
+			errs = append(errs, fmt.Sprintf("platform role %q not found", name))
+			continue
+		}
+		if plan.user != nil {
+			assigned, err := s.roleRepo.IsAssignedPlatformRole(plan.user.ID, role.ID)
+			if err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+			if assigned {
+				continue
+			}
+		}
+		plan.platformRoles = append(plan.platformRoles, role)
+	}
+
+	for _, pair := range row.WorkspaceRoles {
+		if pair.Workspace == "" || pair.Role == "" {
+			errs = append(errs, fmt.Sprintf("invalid workspace role %q (expected workspace:role)", pair.Workspace+":"+pair.Role))
+			continue
+		}
+		ws, err := s.workspaceRepo.FindWorkspaceByName(pair.Workspace)
+		if err != nil || (companyID != nil && ws.CompanyID != nil && *ws.CompanyID != *companyID) {
+			if err != nil && !errors.Is(err, repository.ErrWorkspaceNotFound) {
+				errs = append(errs, err.Error())
+			} else {
+				errs = append(errs, fmt.Sprintf("workspace %q not found", pair.Workspace))
+			}
+			continue
+		}
+		role, err := s.roleRepo.FindRoleByRoleName(pair.Role, constants.RoleTypeWorkspace)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		if role == nil {
+			errs = append(errs, fmt.Sprintf("workspace role %q not found", pair.Role))
+			continue
+		}
+		if plan.user != nil {
+			current, err := s.roleRepo.FindUserWorkspaceRoles(plan.user.ID, ws.ID)
+			if err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+			if hasWorkspaceRole(current, role.ID) {
+				continue
+			}
+		}
+		plan.workspaceRoles = append(plan.workspaceRoles, userImportWorkspaceGrant{workspace: ws, role: role})
+	}
+	return plan, errs
+}
+
+// changes 검증 전용 모드에서 반영 예정인 변경 목록
+func (p *userImportPlan) changes(row *model.UserImportRow) []string {
+	var changes []string
+	switch {
+	case p.kcUser != nil:
+		changes = append(changes, "link existing keycloak user "+row.Username)
+	case p.user == nil:
+		changes = append(changes, "create user "+row.Username)
+	}
+	for _, org := range p.orgs {
+		changes = append(changes, "add to organization "+org.OrganizationCode)
+	}
+	for _, role := range p.platformRoles {
+		changes = append(changes, "grant platform role "+role.Name)
+	}
+	for _, grant := range p.workspaceRoles {
+		changes = append(changes, fmt.Sprintf("grant workspace role %s:%s", grant.workspace.Name, grant.role.Name))
+	}
+	return changes
+}
+
+// createUser Keycloak 사용자 생성(또는 기존 Keycloak 사용자 연결) 후 DB 등록. DB 등록 실패 시 새로 만든 Keycloak 사용자는 삭제한다.
+func (s *UserBulkService) createUser(ctx context.Context, row *model.UserImportRow, kcUser *gocloak.User) (*model.User, string, error) {
+	if kcUser != nil {
+		user, err := s.userRepo.Create(&model.User{KcId: *kcUser.ID, Username: row.Username})
+		if err != nil {
+			return nil, "", err
+		}
+		return user, "link existing keycloak user " + row.Username, nil
+	}
+
+	newUser := &model.User{
+		Username:  row.Username,
+		Email:     row.Email,
+		FirstName: row.FirstName,
+		LastName:  row.LastName,
+	}
+	kcID, err := s.kcService.CreateUser(ctx, newUser)
+	if err != nil {
+		return nil, "", err
+	}
+	newUser.KcId = kcID
+	user, err := s.userRepo.Create(newUser)
+	if err != nil {
+		if rollbackErr := s.kcService.DeleteUser(ctx, kcID); rollbackErr != nil {
+			log.Printf("CRITICAL: KC rollback failed after bulk import DB error (kcId: %s): %v", kcID, rollbackErr)
+		}
+		return nil, "", fmt.Errorf("failed to create user in DB after Keycloak: %w", err)
+	}
+	return user, "create user " + row.Username, nil
+}
+
+func hasWorkspaceRole(roles []model.UserWorkspaceRole, roleID uint) bool {
+	for _, r := range roles {
+		if r.RoleID == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+// Export 현재 realm 사용자와 직접 할당된 조직/플랫폼 역할/워크스페이스 역할을 가져오기와 같은 행 형식으로 내보내기
+// 탈퇴한 사용자와 서비스 계정 사용자는 제외한다.
+func (s *UserBulkService) Export(ctx context.Context) ([]model.UserImportRow, error) {
+	kcUsers, err := s.kcService.GetUsers(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	kcIDs := make([]string, 0, len(kcUsers))
+	kcUserMap := make(map[string]*gocloak.User, len(kcUsers))
+	for _, u := range kcUsers {
+		if u != nil && u.ID != nil {
+			kcIDs = append(kcIDs, *u.ID)
+			kcUserMap[*u.ID] = u
+		}
+	}
+	users, err := s.userRepo.GetUsersByKcIDs(kcIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]model.UserImportRow, 0, len(users))
+	for _, user := range users {
+		if user.Status == model.UserStatusWithdrawn || strings.HasPrefix(user.Username, "service-account-") {
+			continue
+		}
+		kcUser := kcUserMap[user.KcId]
+		row := model.UserImportRow{
+			Username:  user.Username,
+			Email:     ptrStr(kcUser.Email),
+			FirstName: ptrStr(kcUser.FirstName),
+			LastName:  ptrStr(kcUser.LastName),
+		}
+
+		orgs, err := s.orgRepo.FindDirectUserOrganizations(user.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, org := range orgs {
+			row.OrganizationCodes = append(row.OrganizationCodes, org.OrganizationCode)
+		}
+		platformRoles, err := s.roleRepo.FindUserPlatformRoles(user.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, role := range platformRoles {
+			row.PlatformRoles = append(row.PlatformRoles, role.Name)
+		}
+		workspaceRoles, err := s.roleRepo.FindUserWorkspaceRoles(user.ID, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, wr := range workspaceRoles {
+			row.WorkspaceRoles = append(row.WorkspaceRoles, model.UserImportWorkspaceRole{Workspace: wr.WorkspaceName, Role: wr.RoleName})
+		}
+		sort.Strings(row.PlatformRoles)
+		sort.Slice(row.WorkspaceRoles, func(i, j int) bool {
+			a, b := row.WorkspaceRoles[i], row.WorkspaceRoles[j]
+			if a.Workspace != b.Workspace {
+				return a.Workspace < b.Workspace
+			}
+			return a.Role < b.Role
+		})
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Username < rows[j].Username })
+	return rows, nil
+}
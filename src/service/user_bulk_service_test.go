@@ -0,0 +1,208 @@
+package service
+
+// user_bulk_service_test.go
+// 사용자 일괄 가져오기/내보내기(검증 전용, 부분 실패, 멱등성) 단위 테스트 (SQLite in-memory DB)
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// userBulkKeycloakService 생성된 사용자와 realm 역할 할당을 기록하는 KeycloakService 스텁
+type userBulkKeycloakService struct {
+	mockKeycloakService
+	users         map[string]*gocloak.User
+	assignedRoles []string
+}
+
+func (m *userBulkKeycloakService) GetUserByUsername(ctx context.Context, username string) (*gocloak.User, error) {
+	if u, ok := m.users[username]; ok {
+		return u, nil
+	}
+	return nil, repository.ErrUserNotFound
+}
+
+func (m *userBulkKeycloakService) CreateUser(ctx context.Context, user *model.User) (string, error) {
+	id := "kc-" + user.Username
+	m.users[user.Username] = &gocloak.User{
+		ID:        gocloak.StringP(id),
+		Username:  gocloak.StringP(user.Username),
+		Email:     gocloak.StringP(user.Email),
+		FirstName: gocloak.StringP(user.FirstName),
+		LastName:  gocloak.StringP(user.LastName),
+	}
+	return id, nil
+}
+
+func (m *userBulkKeycloakService) GetUsers(ctx context.Context, enabled *bool) ([]*gocloak.User, error) {
+	users := make([]*gocloak.User, 0, len(m.users))
+	for _, u := range m.users {
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+func (m *userBulkKeycloakService) CheckRealmRoleExists(ctx context.Context, roleName string) (bool, error) {
+	return true, nil
+}
+
+func (m *userBulkKeycloakService) AssignRealmRoleToUser(ctx context.Context, kcUserID, roleName string) error {
+	m.assignedRoles = append(m.assignedRoles, kcUserID+"/"+roleName)
+	return nil
+}
+
+func newTestUserBulkService(t *testing.T) (*UserBulkService, *userBulkKeycloakService, *gorm.DB) {
+	t.Helper()
+	db := setupGroupRoleTestDB(t)
+	kc := &userBulkKeycloakService{users: map[string]*gocloak.User{}}
+	svc := NewUserBulkService(db)
+	svc.kcService = kc
+
+	createGRTestOrg(t, db, "platform-team", "PT01")
+	createGRTestRole(t, db, "operator")
+	createGRTestWorkspace(t, db, "ws-dev")
+	return svc, kc, db
+}
+
+const userBulkTestCSV = `username,email,first_name,last_name,organization_codes,platform_roles,workspace_roles
+alice,alice@example.com,Alice,Kim,PT01,operator,ws-dev:operator
+Bob,bob@example.com,Bob,Lee,,,
+`
+
+func TestParseUserImport_CSVAndJSON(t *testing.T) {
+	rows, err := ParseUserImport([]byte(userBulkTestCSV), true)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "alice", rows[0].Username)
+	assert.Equal(t, []string{"PT01"}, rows[0].OrganizationCodes)
+	assert.Equal(t, []model.UserImportWorkspaceRole{{Workspace: "ws-dev", Role: "operator"}}, rows[0].WorkspaceRoles)
+	assert.Empty(t, rows[1].PlatformRoles)
+
+	rows, err = ParseUserImport([]byte(`[{"username":"carol","email":"carol@example.com"}]`), false)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	rows, err = ParseUserImport([]byte(`{"users":[{"username":"carol"},{"username":"dave"}]}`), false)
+	require.NoError(t, err)
+	assert.Len(t, rows, 2)
+
+	_, err = ParseUserImport([]byte("email\nx@example.com\n"), true)
+	assert.ErrorIs(t, err, ErrInvalidUserImport, "username 컬럼 필수")
+}
+
+func TestUserBulkImport_DryRunDoesNotApply(t *testing.T) {
+	svc, kc, db := newTestUserBulkService(t)
+	rows, err := ParseUserImport([]byte(userBulkTestCSV), true)
+	require.NoError(t, err)
+
+	result, err := svc.Import(context.Background(), rows, true, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Valid)
+	assert.Equal(t, 0, result.Failed)
+	assert.Contains(t, result.Rows[0].Changes, "grant workspace role ws-dev:operator")
+
+	var count int64
+	require.NoError(t, db.Model(&model.User{}).Count(&count).Error)
+	assert.Zero(t, count)
+	assert.Empty(t, kc.users)
+}
+
+func TestUserBulkImport_CreatesAndIsIdempotent(t *testing.T) {
+	svc, kc, db := newTestUserBulkService(t)
+	rows, err := ParseUserImport([]byte(userBulkTestCSV), true)
+	require.NoError(t, err)
+
+	result, err := svc.Import(context.Background(), rows, false, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Created, "%+v", result.Rows)
+	assert.Equal(t, "bob", result.Rows[1].Username, "사용자명은 소문자로 정규화")
+
+	alice, err := repository.NewUserRepository(db).FindByUsername("alice")
+	require.NoError(t, err)
+	roleRepo := repository.NewRoleRepository(db)
+	platformRoles, err := roleRepo.FindUserPlatformRoles(alice.ID)
+	require.NoError(t, err)
+	require.Len(t, platformRoles, 1)
+	wsRoles, err := roleRepo.FindUserWorkspaceRoles(alice.ID, 0)
+	require.NoError(t, err)
+	require.Len(t, wsRoles, 1)
+	assert.Equal(t, []string{"kc-alice/operator"}, kc.assignedRoles)
+
+	rows, err = ParseUserImport([]byte(userBulkTestCSV), true)
+	require.NoError(t, err)
+	result, err = svc.Import(context.Background(), rows, false, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Unchanged, "재실행 시 변경 없음")
+	assert.Len(t, kc.assignedRoles, 1)
+}
+
+func TestUserBulkImport_PartialFailure(t *testing.T) {
+	svc, _, db := newTestUserBulkService(t)
+	rows := []model.UserImportRow{
+		{Username: "alice", Email: "alice@example.com", PlatformRoles: []string{"operator"}},
+		{Username: "bob", Email: "bob@example.com", PlatformRoles: []string{"no-such-role"}, OrganizationCodes: []string{"XX99"}},
+		{Username: "ALICE", Email: "alice2@example.com"},
+		{Username: "carol"},
+		{Username: "dave", Email: "dave@example.com", WorkspaceRoles: []model.UserImportWorkspaceRole{{Workspace: "ws-dev"}}},
+	}
+
+	result, err := svc.Import(context.Background(), rows, false, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 4, result.Failed)
+	assert.Len(t, result.Rows[1].Errors, 2)
+	assert.Contains(t, result.Rows[2].Errors[0], "duplicate username")
+	assert.Contains(t, result.Rows[3].Errors[0], "email")
+	assert.Contains(t, result.Rows[4].Errors[0], "workspace:role")
+
+	_, err = repository.NewUserRepository(db).FindByUsername("bob")
+	assert.ErrorIs(t, err, repository.ErrUserNotFound, "실패한 행은 반영하지 않음")
+}
+
+func TestUserBulkImport_WorkspaceOfOtherCompanyNotFound(t *testing.T) {
+	svc, _, db := newTestUserBulkService(t)
+	other := uint(2)
+	require.NoError(t, db.Model(&model.Workspace{}).Where("name = ?", "ws-dev").Update("company_id", other).Error)
+
+	own := uint(1)
+	rows := []model.UserImportRow{{Username: "alice", Email: "alice@example.com",
+		WorkspaceRoles: []model.UserImportWorkspaceRole{{Workspace: "ws-dev", Role: "operator"}}}}
+	result, err := svc.Import(context.Background(), rows, true, &own)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Failed)
+	assert.Contains(t, result.Rows[0].Errors[0], `workspace "ws-dev" not found`)
+}
+
+func TestUserBulkExport_RoundTrip(t *testing.T) {
+	svc, _, _ := newTestUserBulkService(t)
+	rows, err := ParseUserImport([]byte(userBulkTestCSV), true)
+	require.NoError(t, err)
+	_, err = svc.Import(context.Background(), rows, false, nil)
+	require.NoError(t, err)
+
+	exported, err := svc.Export(context.Background())
+	require.NoError(t, err)
+	require.Len(t, exported, 2)
+	assert.Equal(t, "alice", exported[0].Username)
+	assert.Equal(t, "alice@example.com", exported[0].Email)
+	assert.Equal(t, []string{"PT01"}, exported[0].OrganizationCodes)
+	assert.Equal(t, []string{"operator"}, exported[0].PlatformRoles)
+
+	body, err := WriteUserExportCSV(exported)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "alice,alice@example.com,Alice,Kim,PT01,operator,ws-dev:operator")
+
+	reparsed, err := ParseUserImport(body, true)
+	require.NoError(t, err)
+	result, err := svc.Import(context.Background(), reparsed, false, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Unchanged)
+	assert.True(t, strings.HasPrefix(string(body), "username,email,"))
+}
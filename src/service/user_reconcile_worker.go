@@ -0,0 +1,386 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/m-cmp/mc-iam-manager/config"
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	userReconcileActor    = "system:user-reconcile"
+	userReconcilePageSize = 100
+
+	tokenRevokedByReconcile = "user removed from keycloak"
+)
+
+// ErrUserReconcileRunning 이전 정합성 점검이 아직 실행 중
+var ErrUserReconcileRunning = errors.New("user reconciliation is already running")
+
+// UserReconciler Keycloak 사용자와 mcmp_users를 주기적으로 비교하는 백그라운드 작업
+// 모든 realm의 사용자를 페이지 단위로 순회하여 양방향 누락, 상태/사용자명 불일치, 플랫폼 역할(realm role ↔ UserPlatformRole) 불일치를 찾는다.
+// 수정 시에는 더 제한적인 쪽으로 맞춘다: Keycloak에서 삭제/비활성화된 사용자는 DB에서 탈퇴/비활성 처리하고,
+// DB에서 비활성/탈퇴한 사용자는 Keycloak에서 비활성화하며, 한쪽에만 있는 플랫폼 역할은 제거한다.
+// Keycloak에만 있는 비활성 사용자는 가입 승인 대기일 수 있으므로 불일치로 보지 않는다.
+type UserReconciler struct {
+	userRepo     *repository.UserRepository
+	roleRepo     *repository.RoleRepository
+	auditService *AuditService
+	kcService    KeycloakService
+	revocations  *TokenRevocationService
+
+	interval time.Duration
+	fix      bool
+
+	runMu   sync.Mutex // 동시 실행 방지
+	mu      sync.RWMutex
+	running bool
+	lastRun *model.UserReconcileRun
+}
+
+// NewUserReconciler 새 UserReconciler 인스턴스 생성 (설정은 환경 변수에서 읽음)
+func NewUserReconciler(db *gorm.DB) *UserReconciler {
+	return &UserReconciler{
+		userRepo:     repository.NewUserRepository(db),
+		roleRepo:     repository.NewRoleRepository(db),
+		auditService: NewAuditService(db),
+		kcService:    NewKeycloakService(),
+		revocations:  NewTokenRevocationService(db),
+		interval:     config.UserReconcileInterval(),
+		fix:          config.UserReconcileFix(),
+	}
+}
+
+// Run interval마다 설정된 수정 여부로 RunOnce 실행. ctx가 취소되면 종료
+func (r *UserReconciler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if run, err := r.RunOnce(ctx, r.fix); err != nil {
+			log.Printf("[WARN] user reconcile: %v", err)
+		} else if run.Error != "" {
+			log.Printf("[WARN] user reconcile failed: %s", run.Error)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Status 설정 및 마지막 실행 결과
+func (r *UserReconciler) Status() *model.UserReconcileStatusResponse {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return &model.UserReconcileStatusResponse{
+		Enabled:         r.interval > 0,
+		IntervalSeconds: int(r.interval / time.Second),
+		Fix:             r.fix,
+		Running:         r.running,
+		LastRun:         r.lastRun,
+	}
+}
+
+// RunOnce 정합성 점검 1회 실행. fix가 false이면 불일치만 보고한다. 실행 결과는 Status의 LastRun으로 조회 가능
+func (r *UserReconciler) RunOnce(ctx context.Context, fix bool) (*model.UserReconcileRun, error) {
+	if !r.runMu.TryLock() {
+		return nil, ErrUserReconcileRunning
+	}
+	defer r.runMu.Unlock()
+	r.setRunning(true)
+	defer r.setRunning(false)
+
+	run := &model.UserReconcileRun{
+		StartedAt: time.Now().UTC(),
+		Fix:       fix,
+		Drifts:    []model.UserReconcileDrift{},
+	}
+	if err := r.reconcile(ctx, run); err != nil {
+		run.Error = err.Error()
+	}
+	for _, d := range run.Drifts {
+		switch d.Action {
+		case model.UserDriftActionFixed:
+			run.Fixed++
+		case model.UserDriftActionFailed:
+			run.Failed++
+		}
+	}
+	run.FinishedAt = time.Now().UTC()
+	run.Success = run.Error == "" && run.Failed == 0
+
+	if run.Fixed > 0 {
+		r.recordAudit(run)
+	}
+
+	r.mu.Lock()
+	r.lastRun = run
+	r.mu.Unlock()
+	return run, nil
+}
+
+func (r *UserReconciler) setRunning(running bool) {
+	r.mu.Lock()
+	r.running = running
+	r.mu.Unlock()
+}
+
+// reconcileKeycloakUser realm별로 조회한 Keycloak 사용자
+type reconcileKeycloakUser struct {
+	user   *gocloak.User
+	tenant *config.KeycloakConfig
+}
+
+func (r *UserReconciler) reconcile(ctx context.Context, run *model.UserReconcileRun) error {
+	// 일부 realm만 조회된 상태로 비교하면 나머지 사용자를 모두 누락으로 판단하므로, 조회 실패 시 전체 실행을 중단한다
+	kcUsers := make(map[string]reconcileKeycloakUser)
+	var emptyRealms []string
+	for _, tenant := range config.Tenants() {
+		users, err := r.listKeycloakUsers(config.WithKeycloak(ctx, tenant))
+		if err != nil {
+			return fmt.Errorf("realm %s: %w", tenant.Realm, err)
+		}
+		if len(users) == 0 {
+			emptyRealms = append(emptyRealms, tenant.Realm)
+		}
+		for _, u := range users {
+			if u != nil && u.ID != nil && !strings.HasPrefix(ptrStr(u.Username), "service-account-") {
+				kcUsers[*u.ID] = reconcileKeycloakUser{user: u, tenant: tenant}
+			}
+		}
+	}
+	dbUsers, err := r.userRepo.FindAll()
+	if err != nil {
+		return err
+	}
+	platformRoles, err := r.roleRepo.FindRoles(&model.RoleFilterRequest{RoleTypes: []constants.IAMRoleType{constants.RoleTypePlatform}})
+	if err != nil {
+		return fmt.Errorf("failed to list platform roles: %w", err)
+	}
+	rolesByName := make(map[string]*model.RoleMaster, len(platformRoles))
+	for _, role := range platformRoles {
+		rolesByName[role.Name] = role
+	}
+	run.KeycloakUsers = len(kcUsers)
+	run.DbUsers = len(dbUsers)
+
+	// 어느 realm이든 빈 목록을 반환하면 초기화/장애일 수 있으므로 DB 사용자를 일괄 탈퇴 처리하지 않는다
+	// (mcmp_users에는 realm 정보가 없어 해당 realm 사용자만 골라낼 수 없으므로 Keycloak 누락 사용자는 모두 보고만 한다)
+	keepOrphans := len(kcUsers) == 0 || len(emptyRealms) > 0
+	if keepOrphans && run.Fix {
+		log.Printf("[WARN] user reconcile: keycloak returned no users (empty realms: %q), keeping users missing in keycloak", emptyRealms)
+	}
+
+	inDB := make(map[string]bool, len(dbUsers))
+	for i := range dbUsers {
+		user := &dbUsers[i]
+		if user.KcId == "" || strings.HasPrefix(user.Username, "service-account-") {
+			continue
+		}
+		inDB[user.KcId] = true
+		kcUser, ok := kcUsers[user.KcId]
+		if !ok {
+			if user.Status != model.UserStatusWithdrawn {
+				r.apply(run, driftFor(model.UserDriftMissingInKeycloak, user, "", "user not found in any keycloak realm"), !keepOrphans, func() error {
+					return withdrawUser(ctx, r.userRepo, r.kcService, r.revocations, user, tokenRevokedByReconcile, userReconcileActor)
+				})
+			}
+			continue
+		}
+		r.reconcileUser(config.WithKeycloak(ctx, kcUser.tenant), run, user, kcUser, rolesByName)
+	}
+
+	kcIDs := make([]string, 0, len(kcUsers))
+	for id := range kcUsers {
+		kcIDs = append(kcIDs, id)
+	}
+	sort.Strings(kcIDs)
+	for _, id := range kcIDs {
+		kcUser := kcUsers[id]
+		if inDB[id] || !ptrBool(kcUser.user.Enabled) {
+			continue
+		}
+		drift := model.UserReconcileDrift{
+			Type:     model.UserDriftMissingInDB,
+			Realm:    kcUser.tenant.Realm,
+			KcUserID: id,
+			Username: ptrStr(kcUser.user.Username),
+			Detail:   "enabled keycloak user has no mcmp_users record",
+		}
+		r.apply(run, drift, true, func() error {
+			_, err := r.userRepo.Create(&model.User{KcId: id, Username: drift.Username})
+			return err
+		})
+	}
+	return nil
+}
+
+// listKeycloakUsers realm의 전체 사용자를 페이지 단위로 조회
+func (r *UserReconciler) listKeycloakUsers(ctx context.Context) ([]*gocloak.User, error) {
+	var users []*gocloak.User
+	for first := 0; ; first += userReconcilePageSize {
+		page, err := r.kcService.GetUsersPage(ctx, first, userReconcilePageSize)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, page...)
+		if len(page) < userReconcilePageSize {
+			return users, nil
+		}
+	}
+}
+
+// reconcileUser 양쪽에 모두 있는 사용자의 상태, 사용자명, 플랫폼 역할 비교 (ctx는 사용자의 realm)
+func (r *UserReconciler) reconcileUser(ctx context.Context, run *model.UserReconcileRun, user *model.User, kcUser reconcileKeycloakUser, rolesByName map[string]*model.RoleMaster) {
+	realm := kcUser.tenant.Realm
+	enabled := ptrBool(kcUser.user.Enabled)
+	switch {
+	case !enabled && user.Status == model.UserStatusActive:
+		drift := driftFor(model.UserDriftDisabledInKeycloak, user, realm, "user is disabled in keycloak but ACTIVE in mcmp_users")
+		r.apply(run, drift, true, func() error {
+			if err := r.userRepo.UpdateStatus(user.ID, model.UserStatusInactive); err != nil {
+				return err
+			}
+			if _, err := r.revocations.RevokeAllSessions(ctx, user.KcId, TokenRevokedByDeactivation, userReconcileActor); err != nil {
+				log.Printf("[WARN] failed to revoke sessions of user %s: %v", user.KcId, err)
+			}
+			return nil
+		})
+	case enabled && (user.Status == model.UserStatusInactive || user.Status == model.UserStatusWithdrawn):
+		drift := driftFor(model.UserDriftEnabledInKeycloak, user, realm, fmt.Sprintf("user is %s in mcmp_users but enabled in keycloak", user.Status))
+		r.apply(run, drift, true, func() error {
+			return r.kcService.DisableUser(ctx, user.KcId)
+		})
+	}
+	if user.Status == model.UserStatusWithdrawn {
+		return
+	}
+
+	if kcName := ptrStr(kcUser.user.Username); kcName != "" && kcName != user.Username {
+		drift := driftFor(model.UserDriftUsernameMismatch, user, realm, fmt.Sprintf("username is %q in keycloak", kcName))
+		r.apply(run, drift, true, func() error {
+			updated := *user
+			updated.Username = kcName
+			return r.userRepo.Update(&updated)
+		})
+	}
+	r.reconcilePlatformRoles(ctx, run, user, realm, rolesByName)
+}
+
+// reconcilePlatformRoles 유효한 UserPlatformRole과 사용자에게 직접 할당된 플랫폼 역할 realm role 비교
+// 그룹으로 상속된 realm role과 플랫폼 역할이 아닌 realm role(default-roles-*, offline_access 등)은 비교하지 않는다.
+func (r *UserReconciler) reconcilePlatformRoles(ctx context.Context, run *model.UserReconcileRun, user *model.User, realm string, rolesByName map[string]*model.RoleMaster) {
+	dbRoles, err := r.roleRepo.FindUserPlatformRoles(user.ID)
+	if err != nil {
+		run.Drifts = append(run.Drifts, failedDrift(model.UserDriftRoleMissingInKC, user, realm, err))
+		return
+	}
+	kcRoles, err := r.kcService.GetUserRealmRoles(ctx, user.KcId)
+	if err != nil {
+		run.Drifts = append(run.Drifts, failedDrift(model.UserDriftRoleMissingInDB, user, realm, err))
+		return
+	}
+	inKeycloak := make(map[string]bool, len(kcRoles))
+	for _, name := range kcRoles {
+		inKeycloak[name] = true
+	}
+	inDB := make(map[string]bool, len(dbRoles))
+	for _, role := range dbRoles {
+		inDB[role.Name] = true
+		if inKeycloak[role.Name] {
+			continue
+		}
+		roleID := role.ID
+		drift := driftFor(model.UserDriftRoleMissingInKC, user, realm, "platform role is not assigned as a keycloak realm role")
+		drift.Role = role.Name
+		r.apply(run, drift, true, func() error {
+			return r.roleRepo.RemovePlatformRole(user.ID, roleID)
+		})
+	}
+	sort.Strings(kcRoles)
+	for _, name := range kcRoles {
+		if inDB[name] || rolesByName[name] == nil {
+			continue
+		}
+		roleName := name
+		drift := driftFor(model.UserDriftRoleMissingInDB, user, realm, "keycloak realm role has no platform role assignment in mcmp_users")
+		drift.Role = roleName
+		r.apply(run, drift, true, func() error {
+			if err := r.kcService.RemoveRealmRoleFromUser(ctx, user.KcId, roleName); err != nil && !isKeycloakNotFound(err) {
+				return err
+			}
+			if _, err := r.revocations.RevokeUserTokens(user.KcId, TokenRevokedByRoleRemoval, userReconcileActor); err != nil {
+				log.Printf("[WARN] failed to revoke tokens of user %s: %v", user.KcId, err)
+			}
+			return nil
+		})
+	}
+}
+
+// apply 불일치 기록. 수정 실행이고 fixable이면 fixFn으로 바로잡는다
+func (r *UserReconciler) apply(run *model.UserReconcileRun, drift model.UserReconcileDrift, fixable bool, fixFn func() error) {
+	drift.Action = model.UserDriftActionReported
+	if run.Fix && fixable {
+		if err := fixFn(); err != nil {
+			log.Printf("[WARN] user reconcile: failed to fix %s for user %s: %v", drift.Type, drift.KcUserID, err)
+			drift.Action = model.UserDriftActionFailed
+			drift.Error = err.Error()
+		} else {
+			drift.Action = model.UserDriftActionFixed
+		}
+	}
+	run.Drifts = append(run.Drifts, drift)
+}
+
+func driftFor(driftType string, user *model.User, realm, detail string) model.UserReconcileDrift {
+	return model.UserReconcileDrift{
+		Type:     driftType,
+		Realm:    realm,
+		KcUserID: user.KcId,
+		UserID:   user.ID,
+		Username: user.Username,
+		Detail:   detail,
+	}
+}
+
+func failedDrift(driftType string, user *model.User, realm string, err error) model.UserReconcileDrift {
+	drift := driftFor(driftType, user, realm, "failed to compare platform roles")
+	drift.Action = model.UserDriftActionFailed
+	drift.Error = err.Error()
+	return drift
+}
+
+// recordAudit 수정한 불일치마다 감사 이벤트 기록 (실패해도 점검 결과는 유지)
+func (r *UserReconciler) recordAudit(run *model.UserReconcileRun) {
+	for _, d := range run.Drifts {
+		if d.Action != model.UserDriftActionFixed {
+			continue
+		}
+		targetID := d.KcUserID
+		if d.UserID != 0 {
+			targetID = fmt.Sprint(d.UserID)
+		}
+		event := &model.AuditEvent{
+			ActorKcUserID: userReconcileActor,
+			Action:        "user.reconcile." + d.Type,
+			TargetType:    "user",
+			TargetID:      targetID,
+			After:         ToAuditSnapshot(d),
+		}
+		if err := r.auditService.Record(event); err != nil {
+			log.Printf("[WARN] %v", err)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package service
+
+// user_reconcile_worker_test.go
+// Keycloak ↔ DB 사용자 정합성 점검(UserReconciler) 단위 테스트 (SQLite in-memory DB)
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/m-cmp/mc-iam-manager/config"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// reconcileKeycloakService 사용자 목록(페이지)과 realm role을 흉내내고 변경 호출을 기록하는 KeycloakService 스텁
+type reconcileKeycloakService struct {
+	mockKeycloakService
+	users        []*gocloak.User
+	realmRoles   map[string][]string
+	pageCalls    int
+	disabled     []string
+	removedRoles []string
+}
+
+func (m *reconcileKeycloakService) GetUsersPage(ctx context.Context, first, max int) ([]*gocloak.User, error) {
+	m.pageCalls++
+	if first >= len(m.users) {
+		return nil, nil
+	}
+	end := first + max
+	if end > len(m.users) {
+		end = len(m.users)
+	}
+	return m.users[first:end], nil
+}
+
+func (m *reconcileKeycloakService) GetUserRealmRoles(ctx context.Context, kcUserId string) ([]string, error) {
+	return m.realmRoles[kcUserId], nil
+}
+
+func (m *reconcileKeycloakService) DisableUser(ctx context.Context, kcUserID string) error {
+	m.disabled = append(m.disabled, kcUserID)
+	return nil
+}
+
+func (m *reconcileKeycloakService) RemoveRealmRoleFromUser(ctx context.Context, kcUserId, roleName string) error {
+	m.removedRoles = append(m.removedRoles, kcUserId+"/"+roleName)
+	return nil
+}
+
+func (m *reconcileKeycloakService) addUser(id, username string, enabled bool) {
+	m.users = append(m.users, &gocloak.User{
+		ID:       gocloak.StringP(id),
+		Username: gocloak.StringP(username),
+		Enabled:  gocloak.BoolP(enabled),
+	})
+}
+
+func newTestUserReconciler(t *testing.T) (*UserReconciler, *reconcileKeycloakService, *gorm.DB) {
+	t.Helper()
+	t.Setenv("MC_IAM_MANAGER_MULTI_TENANT", "")
+	prevKC := config.KC
+	config.KC = &config.KeycloakConfig{Realm: "mciam"}
+	t.Cleanup(func() { config.KC = prevKC })
+
+	db := setupGroupRoleTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.TokenRevocation{}, &model.AuditEvent{}))
+	kc := &reconcileKeycloakService{realmRoles: map[string][]string{}}
+	r := NewUserReconciler(db)
+	r.kcService = kc
+	r.revocations.keycloakService = kc
+	return r, kc, db
+}
+
+// userReconcileFixture 불일치 유형별 사용자 구성
+//   - alice: 일치 (operator 역할 양쪽에 있음)
+//   - bob: Keycloak에서 삭제됨
+//   - carol: Keycloak에서 비활성화됨 (DB ACTIVE)
+//   - dave: DB INACTIVE이나 Keycloak 활성
+//   - erin: Keycloak 사용자명 변경
+//   - frank: DB에만 operator 역할
+//   - grace: Keycloak에만 operator realm role (+ 플랫폼 역할이 아닌 realm role)
+//   - heidi: Keycloak에만 있음 (활성), ivan: Keycloak에만 있음 (비활성, 가입 승인 대기)
+func userReconcileFixture(t *testing.T, db *gorm.DB, kc *reconcileKeycloakService) *model.RoleMaster {
+	t.Helper()
+	operator := createGRTestRole(t, db, "operator")
+	roleRepo := repository.NewRoleRepository(db)
+	userRepo := repository.NewUserRepository(db)
+
+	for _, name := range []string{"alice", "bob", "carol", "dave", "erin", "frank", "grace"} {
+		user := createGRTestUser(t, db, name, "kc-"+name)
+		switch name {
+		case "alice", "frank":
+			require.NoError(t, roleRepo.AssignPlatformRole(user.ID, operator.ID))
+		case "dave":
+			require.NoError(t, userRepo.UpdateStatus(user.ID, model.UserStatusInactive))
+		}
+	}
+	kc.addUser("kc-alice", "alice", true)
+	kc.addUser("kc-carol", "carol", false)
+	kc.addUser("kc-dave", "dave", true)
+	kc.addUser("kc-erin", "erin.new", true)
+	kc.addUser("kc-frank", "frank", true)
+	kc.addUser("kc-grace", "grace", true)
+	kc.addUser("kc-heidi", "heidi", true)
+	kc.addUser("kc-ivan", "ivan", false)
+	kc.addUser("kc-sa", "service-account-mciam", true)
+	kc.realmRoles["kc-alice"] = []string{"operator", "default-roles-mciam"}
+	kc.realmRoles["kc-grace"] = []string{"operator", "offline_access"}
+	return operator
+}
+
+func driftTypesByUser(run *model.UserReconcileRun) map[string][]string {
+	types := map[string][]string{}
+	for _, d := range run.Drifts {
+		types[d.Username] = append(types[d.Username], d.Type)
+	}
+	return types
+}
+
+func TestUserReconciler_ReportOnlyDetectsDrift(t *testing.T) {
+	r, kc, db := newTestUserReconciler(t)
+	userReconcileFixture(t, db, kc)
+
+	run, err := r.RunOnce(context.Background(), false)
+	require.NoError(t, err)
+	assert.True(t, run.Success, run.Error)
+	assert.Equal(t, 8, run.KeycloakUsers, "service-account- 사용자 제외")
+	assert.Equal(t, 7, run.DbUsers)
+	assert.Zero(t, run.Fixed)
+	assert.Equal(t, map[string][]string{
+		"bob":   {model.UserDriftMissingInKeycloak},
+		"carol": {model.UserDriftDisabledInKeycloak},
+		"dave":  {model.UserDriftEnabledInKeycloak},
+		"erin":  {model.UserDriftUsernameMismatch},
+		"frank": {model.UserDriftRoleMissingInKC},
+		"grace": {model.UserDriftRoleMissingInDB},
+		"heidi": {model.UserDriftMissingInDB},
+	}, driftTypesByUser(run))
+	for _, d := range run.Drifts {
+		assert.Equal(t, model.UserDriftActionReported, d.Action)
+	}
+
+	assert.Empty(t, kc.disabled)
+	assert.Empty(t, kc.removedRoles)
+	bob, err := repository.NewUserRepository(db).FindByKcID("kc-bob")
+	require.NoError(t, err)
+	assert.Equal(t, model.UserStatusActive, bob.Status)
+	assert.Equal(t, run, r.Status().LastRun)
+}
+
+func TestUserReconciler_FixAppliesRestrictiveSide(t *testing.T) {
+	r, kc, db := newTestUserReconciler(t)
+	userReconcileFixture(t, db, kc)
+	userRepo := repository.NewUserRepository(db)
+	roleRepo := repository.NewRoleRepository(db)
+
+	run, err := r.RunOnce(context.Background(), true)
+	require.NoError(t, err)
+	assert.True(t, run.Success, "%+v", run.Drifts)
+	assert.Equal(t, 7, run.Fixed)
+
+	bob, err := userRepo.FindByKcID("kc-bob")
+	require.NoError(t, err)
+	assert.Equal(t, model.UserStatusWithdrawn, bob.Status)
+	carol, err := userRepo.FindByKcID("kc-carol")
+	require.NoError(t, err)
+	assert.Equal(t, model.UserStatusInactive, carol.Status)
+	assert.ElementsMatch(t, []string{"kc-bob", "kc-dave"}, kc.disabled)
+	erin, err := userRepo.FindByKcID("kc-erin")
+	require.NoError(t, err)
+	assert.Equal(t, "erin.new", erin.Username)
+	heidi, err := userRepo.FindByKcID("kc-heidi")
+	require.NoError(t, err)
+	require.NotNil(t, heidi)
+	ivan, err := userRepo.FindByKcID("kc-ivan")
+	require.NoError(t, err)
+	assert.Nil(t, ivan, "비활성 Keycloak 전용 사용자(가입 승인 대기)는 가져오지 않는다")
+
+	frank, err := userRepo.FindByKcID("kc-frank")
+	require.NoError(t, err)
+	roles, err := roleRepo.FindUserPlatformRoles(frank.ID)
+	require.NoError(t, err)
+	assert.Empty(t, roles)
+	assert.Equal(t, []string{"kc-grace/operator"}, kc.removedRoles)
+
+	var audits int64
+	require.NoError(t, db.Model(&model.AuditEvent{}).Where("actor_kc_user_id = ?", userReconcileActor).Count(&audits).Error)
+	assert.EqualValues(t, 7, audits)
+
+	// 수정 후 재실행하면 불일치가 없어야 한다 (스텁은 Keycloak 측 변경을 반영하지 않으므로 DB 측 수정 대상만 확인)
+	run, err = r.RunOnce(context.Background(), false)
+	require.NoError(t, err)
+	types := driftTypesByUser(run)
+	for _, name := range []string{"bob", "carol", "erin", "erin.new", "frank", "heidi"} {
+		assert.Empty(t, types[name], name)
+	}
+}
+
+func TestUserReconciler_PagesThroughKeycloakUsers(t *testing.T) {
+	r, kc, db := newTestUserReconciler(t)
+	for i := 0; i < 2*userReconcilePageSize+10; i++ {
+		kc.addUser(fmt.Sprintf("kc-%03d", i), fmt.Sprintf("user%03d", i), true)
+	}
+	createGRTestUser(t, db, "user209", "kc-209")
+
+	run, err := r.RunOnce(context.Background(), false)
+	require.NoError(t, err)
+	assert.Equal(t, 3, kc.pageCalls)
+	assert.Equal(t, 2*userReconcilePageSize+10, run.KeycloakUsers)
+	assert.Len(t, run.Drifts, 2*userReconcilePageSize+9, "마지막 페이지 사용자까지 비교")
+}
+
+func TestUserReconciler_EmptyKeycloakKeepsUsers(t *testing.T) {
+	r, _, db := newTestUserReconciler(t)
+	createGRTestUser(t, db, "alice", "kc-alice")
+
+	run, err := r.RunOnce(context.Background(), true)
+	require.NoError(t, err)
+	require.Len(t, run.Drifts, 1)
+	assert.Equal(t, model.UserDriftMissingInKeycloak, run.Drifts[0].Type)
+	assert.Equal(t, model.UserDriftActionReported, run.Drifts[0].Action)
+
+	alice, err := repository.NewUserRepository(db).FindByKcID("kc-alice")
+	require.NoError(t, err)
+	assert.Equal(t, model.UserStatusActive, alice.Status)
+}
+
+// realmUsersReconcileService 기본 realm에만 사용자가 있는 멀티테넌트 Keycloak 스텁
+type realmUsersReconcileService struct {
+	*reconcileKeycloakService
+	realm string
+}
+
+func (m *realmUsersReconcileService) GetUsersPage(ctx context.Context, first, max int) ([]*gocloak.User, error) {
+	if config.KeycloakFor(ctx).Realm != m.realm {
+		return nil, nil
+	}
+	return m.reconcileKeycloakService.GetUsersPage(ctx, first, max)
+}
+
+// 멀티테넌트에서 한 realm만 빈 목록을 반환해도 Keycloak 누락 사용자를 탈퇴 처리하지 않는다
+func TestUserReconciler_EmptyRealmKeepsUsers(t *testing.T) {
+	r, kc, db := newTestUserReconciler(t)
+	t.Setenv("MC_IAM_MANAGER_MULTI_TENANT", "true")
+	config.RegisterTenant(2, "acme", "acme-client", "secret")
+	t.Cleanup(func() { config.UnregisterTenant("acme") })
+	r.kcService = &realmUsersReconcileService{reconcileKeycloakService: kc, realm: "mciam"}
+
+	kc.addUser("kc-alice", "alice", true)
+	createGRTestUser(t, db, "alice", "kc-alice")
+	createGRTestUser(t, db, "bob", "kc-bob") // acme realm 사용자
+
+	run, err := r.RunOnce(context.Background(), true)
+	require.NoError(t, err)
+	require.Len(t, run.Drifts, 1)
+	assert.Equal(t, model.UserDriftMissingInKeycloak, run.Drifts[0].Type)
+	assert.Equal(t, "bob", run.Drifts[0].Username)
+	assert.Equal(t, model.UserDriftActionReported, run.Drifts[0].Action)
+
+	bob, err := repository.NewUserRepository(db).FindByKcID("kc-bob")
+	require.NoError(t, err)
+	assert.Equal(t, model.UserStatusActive, bob.Status)
+}
@@ -169,26 +169,6 @@ func (s *UserService) CreateUser(ctx context.Context, user *model.User) error {
 	return nil
 }
 
-// SignupUser creates a user in pending state (enabled=false)
-func (s *UserService) SignupUser(ctx context.Context, req *model.SignupRequest) (string, error) {
-	ks := NewKeycloakService()
-
-	// Keycloak에 pending 상태로 사용자 생성
-	kcId, err := ks.CreatePendingUser(ctx, req)
-	if err != nil {
-		return "", err
-	}
-
-	// 로컬 DB에도 사용자 동기화 (승인 전에도 DB 레코드 생성)
-	_, err = s.SyncUser(ctx, kcId)
-	if err != nil {
-		log.Printf("Warning: User created in Keycloak but not synced to DB: %v", err)
-		// DB 동기화 실패는 경고만 하고 계속 진행 (Keycloak에는 생성됨)
-	}
-
-	return kcId, nil
-}
-
 // ResetUserPassword resets a user's password
 func (s *UserService) ResetUserPassword(ctx context.Context, kcUserID, newPassword string) error {
 	ks := NewKeycloakService()
@@ -517,6 +497,15 @@ func (s *UserService) GetUserIDByKcID(ctx context.Context, kcUserID string) (uin
 	return syncedUser.ID, nil
 }
 
+// GetUserStatus DB에 저장된 사용자 계정 상태 조회
+func (s *UserService) GetUserStatus(userID uint) (model.UserStatus, error) {
+	user, err := s.userRepo.FindUserByID(userID)
+	if err != nil {
+		return "", err
+	}
+	return user.Status, nil
+}
+
 // DeactivateUser 사용자 계정 비활성화 (ACTIVE → INACTIVE)
 func (s *UserService) DeactivateUser(ctx context.Context, userID uint, requestorKcID string) error {
 	user, err := s.userRepo.FindUserByID(userID)
@@ -536,6 +525,7 @@ func (s *UserService) DeactivateUser(ctx context.Context, userID uint, requestor
 	if err := s.userRepo.UpdateStatus(userID, model.UserStatusInactive); err != nil {
 		return fmt.Errorf("failed to update user status in db: %w", err)
 	}
+	s.revokeUserSessions(ctx, user.KcId, TokenRevokedByDeactivation, requestorKcID)
 	return nil
 }
 
@@ -592,9 +582,46 @@ func (s *UserService) ProcessWithdrawal(ctx context.Context, userID uint) error
 	if err := s.userRepo.UpdateStatus(userID, model.UserStatusWithdrawn); err != nil {
 		return fmt.Errorf("failed to update user status in db: %w", err)
 	}
+	s.revokeUserSessions(ctx, user.KcId, TokenRevokedByWithdrawal, "")
 	return nil
 }
 
+// withdrawUser 탈퇴 최종 단계: 역할/조직 매핑 삭제, Keycloak 비활성화, WITHDRAWN 처리 후 세션 폐기
+// (오프보딩 실행, SCIM 사용자 삭제에서 사용. Keycloak에 이미 없는 사용자는 비활성화를 건너뛴다)
+func withdrawUser(ctx context.Context, userRepo *repository.UserRepository, kc KeycloakService, revocations *TokenRevocationService,
+	user *model.User, reason, revokedBy string) error {
+	if err := userRepo.DeleteAllRoleMappings(user.ID); err != nil {
+		return fmt.Errorf("failed to remove role mappings: %w", err)
+	}
+	if _, err := repository.NewWorkspaceTicketRepository(userRepo.DB()).RevokeByUserAndWorkspace(user.ID, 0, workspaceTicketRevokedByWithdraw); err != nil {
+		log.Printf("[WARN] failed to revoke workspace tickets of user %d: %v", user.ID, err)
+	}
+	if user.KcId != "" {
+		if err := kc.DisableUser(ctx, user.KcId); err != nil && !isKeycloakNotFound(err) {
+			return fmt.Errorf("failed to disable user in keycloak: %w", err)
+		}
+	}
+	if err := userRepo.UpdateStatus(user.ID, model.UserStatusWithdrawn); err != nil {
+		return fmt.Errorf("failed to update user status in db: %w", err)
+	}
+	if user.KcId != "" {
+		if _, err := revocations.RevokeAllSessions(ctx, user.KcId, reason, revokedBy); err != nil {
+			log.Printf("[WARN] failed to revoke sessions of user %s: %v", user.KcId, err)
+		}
+	}
+	return nil
+}
+
+// revokeUserSessions 사용자의 Keycloak 세션 종료 및 발급된 access token 폐기 (실패 시 경고만 기록)
+func (s *UserService) revokeUserSessions(ctx context.Context, kcUserID, reason, revokedBy string) {
+	if kcUserID == "" {
+		return
+	}
+	if _, err := NewTokenRevocationService(s.db).RevokeAllSessions(ctx, kcUserID, reason, revokedBy); err != nil {
+		log.Printf("[WARN] failed to revoke sessions of user %s: %v", kcUserID, err)
+	}
+}
+
 // getValidToken (Keep as is, assuming tokenRepo is initialized if needed)
 // func (s *UserService) getValidToken(ctx context.Context) (string, error) {
 // 	// ... (Implementation) ...
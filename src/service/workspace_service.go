@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"strings"
 
 	"github.com/m-cmp/mc-iam-manager/constants"
@@ -20,6 +21,7 @@ type WorkspaceService struct {
 	userRepo          *repository.UserRepository
 	workspaceRoleRepo *repository.WorkspaceRoleRepository
 	projectRepo       *repository.ProjectRepository
+	ticketRepo        *repository.WorkspaceTicketRepository
 }
 
 // NewWorkspaceService 새 WorkspaceService 인스턴스 생성
@@ -37,6 +39,7 @@ func NewWorkspaceService(db *gorm.DB) *WorkspaceService {
 		roleRepo:          roleRepo,
 		userRepo:          userRepo,
 		workspaceRoleRepo: workspaceRoleRepo,
+		ticketRepo:        repository.NewWorkspaceTicketRepository(db),
 	}
 }
 
@@ -296,6 +299,10 @@ func (s *WorkspaceService) RemoveUserFromWorkspace(workspaceID, userID uint) err
 	if err := s.userRepo.DeleteUserWorkspaceRole(workspaceID, userID); err != nil {
 		return err
 	}
+	// 제거된 역할이 담긴 워크스페이스 티켓 폐기
+	if _, err := s.ticketRepo.RevokeByUserAndWorkspace(userID, workspaceID, workspaceTicketRevokedByRole); err != nil {
+		log.Printf("[WARN] failed to revoke workspace tickets (userID=%d, workspaceID=%d): %v", userID, workspaceID, err)
+	}
 
 	return nil
 }
@@ -0,0 +1,348 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/m-cmp/mc-iam-manager/config"
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	// WorkspaceTicketAudience 워크스페이스 티켓 aud 클레임
+	WorkspaceTicketAudience = "mcmp-workspace"
+	// WorkspaceTicketType 워크스페이스 티켓 typ 헤더
+	WorkspaceTicketType = "workspace-ticket+jwt"
+
+	workspaceTicketRevokedByUser     = "revoked by user"
+	workspaceTicketRevokedByAdmin    = "revoked by admin"
+	workspaceTicketRevokedByRefresh  = "refreshed"
+	workspaceTicketRevokedByRole     = "workspace role removed"
+	workspaceTicketRevokedByGroup    = "group workspace role removed"
+	workspaceTicketRevokedByWithdraw = "user withdrawn"
+)
+
+var (
+	ErrWorkspaceTicketNoRole   = errors.New("user has no role in the workspace")
+	ErrWorkspaceTicketInvalid  = errors.New("invalid workspace ticket")
+	ErrWorkspaceTicketRevoked  = errors.New("workspace ticket has been revoked")
+	ErrWorkspaceTicketNotOwner = errors.New("workspace ticket does not belong to the user")
+)
+
+// WorkspaceTicketPermission RPT 호환 authorization.permissions 항목
+type WorkspaceTicketPermission struct {
+	Rsid   string   `json:"rsid"`
+	Rsname string   `json:"rsname"`
+	Scopes []string `json:"scopes"`
+}
+
+// WorkspaceTicketAuthorization RPT 호환 authorization 클레임
+type WorkspaceTicketAuthorization struct {
+	Permissions []WorkspaceTicketPermission `json:"permissions"`
+}
+
+// WorkspaceTicketClaims 워크스페이스 티켓 JWT 클레임
+// authorization 클레임은 Keycloak RPT와 같은 구조로, 기존 RPT 소비자도 그대로 해석할 수 있다.
+type WorkspaceTicketClaims struct {
+	WorkspaceID   string                       `json:"workspace_id"`
+	Roles         []string                     `json:"roles"`
+	Permissions   []string                     `json:"permissions"`
+	Authorization WorkspaceTicketAuthorization `json:"authorization"`
+	jwt.RegisteredClaims
+}
+
+// WorkspaceTicketService 워크스페이스 티켓 발급/갱신/폐기 서비스
+type WorkspaceTicketService struct {
+	db             *gorm.DB
+	ticketRepo     *repository.WorkspaceTicketRepository
+	userRepo       *repository.UserRepository
+	roleRepo       *repository.RoleRepository
+	permissionRepo *repository.MciamPermissionRepository
+}
+
+// NewWorkspaceTicketService 새 WorkspaceTicketService 인스턴스 생성
+func NewWorkspaceTicketService(db *gorm.DB) *WorkspaceTicketService {
+	return &WorkspaceTicketService{
+		db:             db,
+		ticketRepo:     repository.NewWorkspaceTicketRepository(db),
+		userRepo:       repository.NewUserRepository(db),
+		roleRepo:       repository.NewRoleRepository(db),
+		permissionRepo: repository.NewMciamPermissionRepository(db),
+	}
+}
+
+// IssueTicket 사용자의 실제 워크스페이스 역할과 매핑된 MciamPermission을 담은 티켓 발급
+func (s *WorkspaceTicketService) IssueTicket(kcUserID string, workspaceID uint) (*model.WorkspaceTicketResponse, error) {
+	user, err := s.userRepo.FindByKcID(kcUserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, repository.ErrUserNotFound
+	}
+
+	roles, permissions, err := s.collectWorkspaceAccess(user.ID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if len(roles) == 0 {
+		return nil, ErrWorkspaceTicketNoRole
+	}
+
+	signer, err := config.GetWorkspaceTicketSigner()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	lifespan := config.WorkspaceTicketLifespanSec()
+	expiresAt := now.Add(time.Duration(lifespan) * time.Second)
+	workspaceIDStr := strconv.FormatUint(uint64(workspaceID), 10)
+
+	scopes := make([]string, 0, len(roles))
+	for _, role := range roles {
+		scopes = append(scopes, fmt.Sprintf("workspace:%s", role))
+	}
+
+	claims := WorkspaceTicketClaims{
+		WorkspaceID: workspaceIDStr,
+		Roles:       roles,
+		Permissions: permissions,
+		Authorization: WorkspaceTicketAuthorization{
+			Permissions: []WorkspaceTicketPermission{{Rsid: workspaceIDStr, Rsname: "workspace", Scopes: scopes}},
+		},
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    config.WorkspaceTicketIssuer(),
+			Subject:   kcUserID,
+			Audience:  jwt.ClaimStrings{WorkspaceTicketAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = signer.KeyID
+	token.Header["typ"] = WorkspaceTicketType
+	signed, err := token.SignedString(signer.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign workspace ticket: %w", err)
+	}
+
+	snapshot, err := json.Marshal(map[string]interface{}{
+		"workspace_id": workspaceID,
+		"kc_user_id":   kcUserID,
+		"roles":        roles,
+		"permissions":  permissions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal workspace ticket permissions: %w", err)
+	}
+
+	record := &model.WorkspaceTicket{
+		TicketID:    claims.ID,
+		KcUserID:    kcUserID,
+		UserID:      user.ID,
+		WorkspaceID: workspaceID,
+		KeyID:       signer.KeyID,
+		Ticket:      signed,
+		Permissions: datatypes.JSON(snapshot),
+		ExpiresAt:   expiresAt,
+		LastUsedAt:  now,
+	}
+	if err := s.ticketRepo.Create(record); err != nil {
+		return nil, fmt.Errorf("failed to save workspace ticket: %w", err)
+	}
+
+	return &model.WorkspaceTicketResponse{
+		Ticket:      signed,
+		TokenType:   "Bearer",
+		ExpiresIn:   lifespan,
+		ExpiresAt:   expiresAt,
+		WorkspaceID: workspaceIDStr,
+		Roles:       roles,
+		Permissions: permissions,
+	}, nil
+}
+
+// GetActiveTicket 현재 서명 키로 발급되어 minValidity 이상 남은 기존 티켓 조회. 없으면 ErrWorkspaceTicketNotFound
+// 서명 키가 바뀌기 전에 발급한 티켓은 검증되지 않으므로 재사용하지 않는다.
+func (s *WorkspaceTicketService) GetActiveTicket(kcUserID string, workspaceID uint, minValidity time.Duration) (*model.WorkspaceTicket, error) {
+	signer, err := config.GetWorkspaceTicketSigner()
+	if err != nil {
+		return nil, err
+	}
+	ticket, err := s.ticketRepo.FindActiveByUserAndWorkspace(kcUserID, workspaceID, signer.KeyID, time.Now().Add(minValidity))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ticketRepo.TouchLastUsed(ticket.TicketID); err != nil {
+		log.Printf("[WARN] failed to update workspace ticket last_used_at (jti=%s): %v", ticket.TicketID, err)
+	}
+	return ticket, nil
+}
+
+// ValidateTicket 서명/만료/발급자 검증 후 폐기 여부까지 확인
+func (s *WorkspaceTicketService) ValidateTicket(ticket string) (*WorkspaceTicketClaims, error) {
+	claims, err := ParseWorkspaceTicket(ticket)
+	if err != nil {
+		return nil, err
+	}
+	record, err := s.ticketRepo.FindByTicketID(claims.ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrWorkspaceTicketNotFound) {
+			return nil, ErrWorkspaceTicketInvalid
+		}
+		return nil, err
+	}
+	if record.RevokedAt != nil {
+		return nil, ErrWorkspaceTicketRevoked
+	}
+	return claims, nil
+}
+
+// RefreshTicket 유효한 티켓을 현재 역할 기준으로 재발급하고 기존 티켓은 폐기
+func (s *WorkspaceTicketService) RefreshTicket(ticket, kcUserID string) (*model.WorkspaceTicketResponse, error) {
+	claims, err := s.ValidateTicket(ticket)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Subject != kcUserID {
+		return nil, ErrWorkspaceTicketNotOwner
+	}
+	workspaceID, err := strconv.ParseUint(claims.WorkspaceID, 10, 32)
+	if err != nil {
+		return nil, ErrWorkspaceTicketInvalid
+	}
+
+	issued, err := s.IssueTicket(kcUserID, uint(workspaceID))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ticketRepo.RevokeByTicketID(claims.ID, workspaceTicketRevokedByRefresh); err != nil && !errors.Is(err, repository.ErrWorkspaceTicketNotFound) {
+		log.Printf("[WARN] failed to revoke refreshed workspace ticket (jti=%s): %v", claims.ID, err)
+	}
+	return issued, nil
+}
+
+// RevokeTicket 사용자 본인의 티켓 폐기 (만료된 티켓도 허용)
+func (s *WorkspaceTicketService) RevokeTicket(ticket, kcUserID string) error {
+	claims, err := parseWorkspaceTicket(ticket, jwt.WithoutClaimsValidation())
+	if err != nil {
+		return err
+	}
+	if claims.Subject != kcUserID {
+		return ErrWorkspaceTicketNotOwner
+	}
+	return s.ticketRepo.RevokeByTicketID(claims.ID, workspaceTicketRevokedByUser)
+}
+
+// RevokeUserWorkspaceTickets 관리자에 의한 사용자 워크스페이스 티켓 일괄 폐기 (workspaceID 0이면 전체)
+func (s *WorkspaceTicketService) RevokeUserWorkspaceTickets(userID, workspaceID uint) (int64, error) {
+	return s.ticketRepo.RevokeByUserAndWorkspace(userID, workspaceID, workspaceTicketRevokedByAdmin)
+}
+
+// GetJWKS downstream 프레임워크가 티켓 서명을 검증할 수 있도록 공개키를 JWKS 형식으로 반환
+func (s *WorkspaceTicketService) GetJWKS() (map[string]interface{}, error) {
+	signer, err := config.GetWorkspaceTicketSigner()
+	if err != nil {
+		return nil, err
+	}
+	pub := signer.PrivateKey.PublicKey
+	return map[string]interface{}{
+		"keys": []map[string]interface{}{
+			{
+				"kty": "RSA",
+				"use": "sig",
+				"alg": jwt.SigningMethodRS256.Alg(),
+				"kid": signer.KeyID,
+				"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			},
+		},
+	}, nil
+}
+
+// collectWorkspaceAccess 워크스페이스의 유효 역할(직접 할당 + 그룹 상속)과 매핑된 MciamPermission ID 목록 조회
+func (s *WorkspaceTicketService) collectWorkspaceAccess(userID, workspaceID uint) ([]string, []string, error) {
+	effectiveRoles, err := s.roleRepo.FindEffectiveWorkspaceRoles(userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get workspace roles: %w", err)
+	}
+
+	roleSet := make(map[string]struct{})
+	permissionSet := make(map[string]struct{})
+	for _, role := range effectiveRoles {
+		if role.WorkspaceID != workspaceID {
+			continue
+		}
+		roleSet[role.RoleName] = struct{}{}
+		permissionIDs, err := s.permissionRepo.GetRoleMciamPermissions(constants.RoleTypeWorkspace, role.RoleID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get permissions of role %d: %w", role.RoleID, err)
+		}
+		for _, id := range permissionIDs {
+			permissionSet[id] = struct{}{}
+		}
+	}
+	return sortedKeys(roleSet), sortedKeys(permissionSet), nil
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ParseWorkspaceTicket 서명, 만료, 발급자, 대상만 검증 (폐기 여부는 ValidateTicket 사용)
+func ParseWorkspaceTicket(ticket string) (*WorkspaceTicketClaims, error) {
+	claims, err := parseWorkspaceTicket(ticket,
+		jwt.WithIssuer(config.WorkspaceTicketIssuer()),
+		jwt.WithAudience(WorkspaceTicketAudience),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if claims.ExpiresAt == nil || claims.ID == "" {
+		return nil, ErrWorkspaceTicketInvalid
+	}
+	return claims, nil
+}
+
+func parseWorkspaceTicket(ticket string, opts ...jwt.ParserOption) (*WorkspaceTicketClaims, error) {
+	signer, err := config.GetWorkspaceTicketSigner()
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}))
+
+	claims := &WorkspaceTicketClaims{}
+	token, err := jwt.ParseWithClaims(ticket, claims, func(token *jwt.Token) (interface{}, error) {
+		if kid, _ := token.Header["kid"].(string); kid != signer.KeyID {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		return &signer.PrivateKey.PublicKey, nil
+	}, opts...)
+	if err != nil || !token.Valid {
+		log.Printf("[DEBUG] workspace ticket validation failed: %v", err)
+		return nil, ErrWorkspaceTicketInvalid
+	}
+	return claims, nil
+}
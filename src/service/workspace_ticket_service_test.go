@@ -0,0 +1,206 @@
+package service
+
+// workspace_ticket_service_test.go
+// 워크스페이스 티켓 서비스 단위 테스트 (SQLite in-memory DB)
+//
+// 테스트 범위:
+//   - IssueTicket: 역할 없음 거부, 실제 역할/권한 클레임 포함, 서명 검증
+//   - RefreshTicket: 재발급 후 기존 티켓 폐기, 타인 티켓 거부
+//   - RevokeTicket / RevokeUserWorkspaceTickets: 폐기 후 ValidateTicket 실패
+//   - GetJWKS: kid 일치
+//   - GetActiveTicket: 서명 키가 바뀐 티켓 재사용 안 함, 티켓 암호화 저장
+//   - 그룹 워크스페이스 역할 제거 / 그룹 탈퇴 시 티켓 폐기
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/m-cmp/mc-iam-manager/config"
+	"github.com/m-cmp/mc-iam-manager/constants"
+	"github.com/m-cmp/mc-iam-manager/model"
+	"github.com/m-cmp/mc-iam-manager/pkg/envelope"
+	"github.com/m-cmp/mc-iam-manager/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type workspaceTicketFixture struct {
+	user      *model.User
+	workspace *model.Workspace
+	role      *model.RoleMaster
+}
+
+func newTestWorkspaceTicketService(t *testing.T) (*WorkspaceTicketService, *gorm.DB, workspaceTicketFixture) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&model.User{},
+		&model.Workspace{},
+		&model.RoleMaster{},
+		&model.RoleSub{},
+		&model.UserWorkspaceRole{},
+		&model.Organization{},
+		&model.UserOrganization{},
+		&model.GroupWorkspaceRole{},
+		&model.MciamRoleMciamPermission{},
+		&model.WorkspaceTicket{},
+	))
+
+	user := &model.User{Username: "ticket-user", KcId: "kc-ticket-user"}
+	require.NoError(t, db.Create(user).Error)
+	ws := &model.Workspace{Name: "ticket-ws"}
+	require.NoError(t, db.Create(ws).Error)
+	role := &model.RoleMaster{Name: "operator"}
+	require.NoError(t, db.Create(role).Error)
+	require.NoError(t, db.Create(&model.RoleSub{RoleID: role.ID, RoleType: constants.RoleTypeWorkspace}).Error)
+	require.NoError(t, db.Create(&model.MciamRoleMciamPermission{
+		RoleType: constants.RoleTypeWorkspace, RoleID: role.ID, PermissionID: "mc-infra-manager:vm:read",
+	}).Error)
+
+	return NewWorkspaceTicketService(db), db, workspaceTicketFixture{user: user, workspace: ws, role: role}
+}
+
+func assignTicketTestRole(t *testing.T, db *gorm.DB, f workspaceTicketFixture) {
+	t.Helper()
+	require.NoError(t, db.Create(&model.UserWorkspaceRole{
+		UserID: f.user.ID, WorkspaceID: f.workspace.ID, RoleID: f.role.ID,
+	}).Error)
+}
+
+func TestWorkspaceTicketIssue_NoRole(t *testing.T) {
+	svc, _, f := newTestWorkspaceTicketService(t)
+
+	_, err := svc.IssueTicket(f.user.KcId, f.workspace.ID)
+
+	assert.ErrorIs(t, err, ErrWorkspaceTicketNoRole)
+}
+
+func TestWorkspaceTicketIssue_ContainsRolesAndPermissions(t *testing.T) {
+	svc, db, f := newTestWorkspaceTicketService(t)
+	assignTicketTestRole(t, db, f)
+
+	issued, err := svc.IssueTicket(f.user.KcId, f.workspace.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"operator"}, issued.Roles)
+	assert.Equal(t, []string{"mc-infra-manager:vm:read"}, issued.Permissions)
+	assert.Equal(t, config.WorkspaceTicketLifespanSec(), issued.ExpiresIn)
+
+	claims, err := svc.ValidateTicket(issued.Ticket)
+	require.NoError(t, err)
+	assert.Equal(t, f.user.KcId, claims.Subject)
+	assert.Equal(t, issued.WorkspaceID, claims.WorkspaceID)
+	require.Len(t, claims.Authorization.Permissions, 1)
+	assert.Equal(t, []string{"workspace:operator"}, claims.Authorization.Permissions[0].Scopes)
+
+	_, err = svc.ValidateTicket(issued.Ticket + "x")
+	assert.ErrorIs(t, err, ErrWorkspaceTicketInvalid)
+}
+
+func TestWorkspaceTicketRefresh_RevokesPrevious(t *testing.T) {
+	svc, db, f := newTestWorkspaceTicketService(t)
+	assignTicketTestRole(t, db, f)
+
+	issued, err := svc.IssueTicket(f.user.KcId, f.workspace.ID)
+	require.NoError(t, err)
+
+	_, err = svc.RefreshTicket(issued.Ticket, "someone-else")
+	assert.ErrorIs(t, err, ErrWorkspaceTicketNotOwner)
+
+	refreshed, err := svc.RefreshTicket(issued.Ticket, f.user.KcId)
+	require.NoError(t, err)
+	assert.NotEqual(t, issued.Ticket, refreshed.Ticket)
+
+	_, err = svc.ValidateTicket(issued.Ticket)
+	assert.ErrorIs(t, err, ErrWorkspaceTicketRevoked)
+	_, err = svc.ValidateTicket(refreshed.Ticket)
+	assert.NoError(t, err)
+}
+
+func TestWorkspaceTicketRevoke(t *testing.T) {
+	svc, db, f := newTestWorkspaceTicketService(t)
+	assignTicketTestRole(t, db, f)
+
+	first, err := svc.IssueTicket(f.user.KcId, f.workspace.ID)
+	require.NoError(t, err)
+	require.NoError(t, svc.RevokeTicket(first.Ticket, f.user.KcId))
+	_, err = svc.ValidateTicket(first.Ticket)
+	assert.ErrorIs(t, err, ErrWorkspaceTicketRevoked)
+
+	second, err := svc.IssueTicket(f.user.KcId, f.workspace.ID)
+	require.NoError(t, err)
+	revoked, err := svc.RevokeUserWorkspaceTickets(f.user.ID, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), revoked)
+	_, err = svc.ValidateTicket(second.Ticket)
+	assert.ErrorIs(t, err, ErrWorkspaceTicketRevoked)
+}
+
+func TestWorkspaceTicketJWKS(t *testing.T) {
+	svc, _, _ := newTestWorkspaceTicketService(t)
+	signer, err := config.GetWorkspaceTicketSigner()
+	require.NoError(t, err)
+
+	jwks, err := svc.GetJWKS()
+	require.NoError(t, err)
+	keys := jwks["keys"].([]map[string]interface{})
+	require.Len(t, keys, 1)
+	assert.Equal(t, signer.KeyID, keys[0]["kid"])
+	assert.Equal(t, "AQAB", keys[0]["e"])
+}
+
+func TestWorkspaceTicketGetActive_ReissuesOnKeyChangeAndEncryptsTicket(t *testing.T) {
+	provider, err := envelope.NewStaticKeyProvider("test", bytes.Repeat([]byte{7}, 32))
+	require.NoError(t, err)
+	envelope.SetDefault(provider)
+	t.Cleanup(func() { envelope.SetDefault(nil) })
+
+	svc, db, f := newTestWorkspaceTicketService(t)
+	assignTicketTestRole(t, db, f)
+
+	issued, err := svc.IssueTicket(f.user.KcId, f.workspace.ID)
+	require.NoError(t, err)
+
+	// 저장된 티켓은 암호화되고, 조회 시 복호화됨
+	var stored string
+	require.NoError(t, db.Model(&model.WorkspaceTicket{}).Where("kc_user_id = ?", f.user.KcId).Pluck("ticket", &stored).Error)
+	assert.True(t, envelope.IsEncrypted(stored))
+	active, err := svc.GetActiveTicket(f.user.KcId, f.workspace.ID, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, issued.Ticket, active.Ticket)
+
+	// 다른 서명 키로 발급된 티켓은 재사용하지 않음
+	require.NoError(t, db.Model(&model.WorkspaceTicket{}).Where("kc_user_id = ?", f.user.KcId).Update("key_id", "previous-kid").Error)
+	_, err = svc.GetActiveTicket(f.user.KcId, f.workspace.ID, time.Minute)
+	assert.ErrorIs(t, err, repository.ErrWorkspaceTicketNotFound)
+}
+
+func TestWorkspaceTicket_RevokedWhenGroupWorkspaceRoleRemoved(t *testing.T) {
+	svc, db, f := newTestWorkspaceTicketService(t)
+	org := &model.Organization{Name: "oncall", OrganizationCode: "ONCALL"}
+	require.NoError(t, db.Create(org).Error)
+	require.NoError(t, db.Create(&model.UserOrganization{UserID: f.user.ID, OrganizationID: org.ID}).Error)
+	require.NoError(t, db.Create(&model.GroupWorkspaceRole{GroupID: org.ID, WorkspaceID: f.workspace.ID, RoleID: f.role.ID}).Error)
+	groupSvc := NewGroupRoleService(db)
+
+	issued, err := svc.IssueTicket(f.user.KcId, f.workspace.ID)
+	require.NoError(t, err)
+	require.NoError(t, groupSvc.RemoveGroupWorkspaceRole(org.ID, f.workspace.ID))
+	_, err = svc.ValidateTicket(issued.Ticket)
+	assert.ErrorIs(t, err, ErrWorkspaceTicketRevoked)
+
+	// 그룹에서 제외된 사용자의 티켓도 폐기
+	require.NoError(t, db.Create(&model.GroupWorkspaceRole{GroupID: org.ID, WorkspaceID: f.workspace.ID, RoleID: f.role.ID}).Error)
+	issued, err = svc.IssueTicket(f.user.KcId, f.workspace.ID)
+	require.NoError(t, err)
+	require.NoError(t, groupSvc.RemoveUserFromGroup(context.Background(), f.user.ID, org.ID, ""))
+	_, err = svc.ValidateTicket(issued.Ticket)
+	assert.ErrorIs(t, err, ErrWorkspaceTicketRevoked)
+}
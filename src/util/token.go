@@ -1,66 +1,136 @@
 package util
 
 import (
+	"context"
+	"crypto/rsa"
 	"fmt"
 	"log"
-
-	"crypto/rsa"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/m-cmp/mc-iam-manager/config"
 )
 
+// TokenValidator Keycloak access token 검증기 (서명 kid, iss, aud/azp, 시계 오차)
+type TokenValidator struct {
+	// GetKey kid에 해당하는 서명 공개키 조회
+	GetKey func(ctx context.Context, kid string) (*rsa.PublicKey, error)
+	// Issuers 허용 발급자 목록 (비어 있거나 "*" 포함 시 검증 생략)
+	Issuers []string
+	// Audiences 허용 aud/azp 목록 (비어 있거나 "*" 포함 시 검증 생략)
+	Audiences []string
+	// Leeway exp/nbf/iat 허용 시계 오차
+	Leeway time.Duration
+
+	realm *config.KeycloakConfig // ValidateTokenFor 캐시 무효화용 (회사 재등록 시 새 설정)
+}
+
+var (
+	defaultValidatorOnce sync.Once
+	defaultValidator     *TokenValidator
+)
+
 // ValidateToken은 JWT 토큰을 검증하고 claims를 반환합니다.
+// 서명 키는 토큰의 kid로 Keycloak JWKS 캐시에서 조회합니다.
 func ValidateToken(tokenString string) (*jwt.MapClaims, error) {
-	// 토큰 파싱
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		// 서명 알고리즘 확인
-		if token.Method.Alg() != jwt.SigningMethodRS256.Alg() {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	defaultValidatorOnce.Do(func() {
+		defaultValidator = &TokenValidator{
+			GetKey:    config.KC.GetSigningKey,
+			Issuers:   config.TokenIssuers(),
+			Audiences: config.TokenAudiences(),
+			Leeway:    config.TokenClockSkew(),
 		}
+		log.Printf("[INFO] token validation: issuers=%v audiences=%v leeway=%s", defaultValidator.Issuers, defaultValidator.Audiences, defaultValidator.Leeway)
+	})
+	return defaultValidator.Validate(context.Background(), tokenString)
+}
 
-		// 토큰의 kid 확인
-		kid, ok := token.Header["kid"].(string)
-		if !ok {
-			return nil, fmt.Errorf("kid header not found")
-		}
-		log.Printf("[DEBUG] Token KID: %s", kid)
+// realmValidators 멀티테넌트 모드의 회사 realm별 검증기 (realm 이름 → *TokenValidator)
+var realmValidators sync.Map
 
-		// Keycloak 공개키 가져오기
-		publicKey, err := config.KC.GetPublicKey()
-		if err != nil {
-			log.Printf("[DEBUG] Failed to get public key: %v", err)
-			return nil, fmt.Errorf("failed to get public key: %v", err)
+// ValidateTokenFor 회사 realm의 JWKS, iss, aud/azp로 토큰 검증. 기본 realm(config.KC)이면 ValidateToken과 같다.
+func ValidateTokenFor(kc *config.KeycloakConfig, tokenString string) (*jwt.MapClaims, error) {
+	if kc == nil || kc == config.KC {
+		return ValidateToken(tokenString)
+	}
+	validator, ok := realmValidators.Load(kc.Realm)
+	if !ok || validator.(*TokenValidator).realm != kc {
+		validator = &TokenValidator{
+			GetKey:    kc.GetSigningKey,
+			Issuers:   config.TokenIssuersFor(kc),
+			Audiences: config.TokenAudiencesFor(kc),
+			Leeway:    config.TokenClockSkew(),
+			realm:     kc,
 		}
+		realmValidators.Store(kc.Realm, validator)
+	}
+	return validator.(*TokenValidator).Validate(context.Background(), tokenString)
+}
 
-		// 공개키 정보 로깅
-		log.Printf("[DEBUG] Public Key Type: %T", publicKey)
-		if rsaKey, ok := publicKey.(*rsa.PublicKey); ok {
-			log.Printf("[DEBUG] RSA Key Size: %d bits", rsaKey.Size()*8)
+// Validate 서명, 유효 기간, iss, aud/azp를 검증하고 claims 반환
+func (v *TokenValidator) Validate(ctx context.Context, tokenString string) (*jwt.MapClaims, error) {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodRS384.Alg(), jwt.SigningMethodRS512.Alg()}),
+		jwt.WithLeeway(v.Leeway),
+		jwt.WithIssuedAt(),
+	)
+	token, err := parser.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		kid, ok := token.Header["kid"].(string)
+		if !ok || kid == "" {
+			return nil, fmt.Errorf("kid header not found")
 		}
-
-		return publicKey, nil
+		return v.GetKey(ctx, kid)
 	})
-
 	if err != nil {
 		log.Printf("[DEBUG] Token validation error: %v", err)
-		return nil, fmt.Errorf("failed to parse token: %v", err)
+		return nil, fmt.Errorf("failed to parse token: %w", err)
 	}
-
 	if !token.Valid {
 		return nil, fmt.Errorf("invalid token")
 	}
 
-	// claims 추출
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok {
 		return nil, fmt.Errorf("invalid token claims")
 	}
+	if _, ok := claims["exp"]; !ok {
+		return nil, fmt.Errorf("%w: exp", jwt.ErrTokenRequiredClaimMissing)
+	}
+	if err := v.verifyIssuer(claims); err != nil {
+		return nil, err
+	}
+	if err := v.verifyAudience(claims); err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
 
-	// 토큰 정보 로깅
-	log.Printf("[DEBUG] Token Claims - Issuer: %v", claims["iss"])
-	log.Printf("[DEBUG] Token Claims - Audience: %v", claims["aud"])
-	log.Printf("[DEBUG] Token Claims - Subject: %v", claims["sub"])
+func (v *TokenValidator) verifyIssuer(claims jwt.MapClaims) error {
+	if len(v.Issuers) == 0 || slices.Contains(v.Issuers, config.TokenValidationAny) {
+		return nil
+	}
+	iss, _ := claims.GetIssuer()
+	if !slices.Contains(v.Issuers, iss) {
+		return fmt.Errorf("%w: %q", jwt.ErrTokenInvalidIssuer, iss)
+	}
+	return nil
+}
 
-	return &claims, nil
+// verifyAudience aud에 허용 값이 있거나 azp(토큰을 요청한 클라이언트)가 허용 값이면 통과
+func (v *TokenValidator) verifyAudience(claims jwt.MapClaims) error {
+	if len(v.Audiences) == 0 || slices.Contains(v.Audiences, config.TokenValidationAny) {
+		return nil
+	}
+	if azp, ok := claims["azp"].(string); ok && slices.Contains(v.Audiences, azp) {
+		return nil
+	}
+	aud, _ := claims.GetAudience()
+	for _, a := range aud {
+		if slices.Contains(v.Audiences, a) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: aud=%v azp=%v", jwt.ErrTokenInvalidAudience, aud, claims["azp"])
 }
@@ -0,0 +1,118 @@
+package util
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/m-cmp/mc-iam-manager/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testIssuer = "http://keycloak:8080/realms/mciam"
+
+func newTestRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func testJWK(kid string, key *rsa.PrivateKey) gocloak.CertResponseKey {
+	n := base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+	e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+	return gocloak.CertResponseKey{Kid: &kid, Kty: gocloak.StringP("RSA"), Use: gocloak.StringP("sig"), Alg: gocloak.StringP("RS256"), N: &n, E: &e}
+}
+
+func signTestToken(t *testing.T, kid string, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func validTestClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss": testIssuer,
+		"sub": "user-1",
+		"aud": "account",
+		"azp": "mciammanager",
+		"exp": time.Now().Add(time.Minute).Unix(),
+		"iat": time.Now().Unix(),
+	}
+}
+
+func TestTokenValidator_FollowsKeyRotation(t *testing.T) {
+	oldKey, newKey := newTestRSAKey(t), newTestRSAKey(t)
+	jwks := []gocloak.CertResponseKey{testJWK("old", oldKey)}
+	fetches := 0
+	cache := config.NewJWKSCache(func(ctx context.Context) ([]gocloak.CertResponseKey, error) {
+		fetches++
+		return jwks, nil
+	})
+	v := &TokenValidator{GetKey: cache.GetKey, Issuers: []string{testIssuer}, Audiences: []string{"mciammanager"}}
+
+	_, err := v.Validate(context.Background(), signTestToken(t, "old", oldKey, validTestClaims()))
+	require.NoError(t, err)
+	_, err = v.Validate(context.Background(), signTestToken(t, "old", oldKey, validTestClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, 1, fetches, "알려진 kid는 캐시에서 조회")
+
+	// 모르는 kid는 재조회하지만 최소 간격 이내 재시도는 Keycloak을 호출하지 않는다
+	_, err = v.Validate(context.Background(), signTestToken(t, "new", newKey, validTestClaims()))
+	assert.ErrorIs(t, err, config.ErrUnknownSigningKey)
+	assert.Equal(t, 1, fetches)
+
+	// 새 키 추가 후 새 캐시에서는 재조회로 검증 성공
+	jwks = append(jwks, testJWK("new", newKey))
+	cache = config.NewJWKSCache(func(ctx context.Context) ([]gocloak.CertResponseKey, error) {
+		fetches++
+		return jwks, nil
+	})
+	v.GetKey = cache.GetKey
+	claims, err := v.Validate(context.Background(), signTestToken(t, "new", newKey, validTestClaims()))
+	require.NoError(t, err)
+	sub, _ := claims.GetSubject()
+	assert.Equal(t, "user-1", sub)
+}
+
+func TestTokenValidator_RejectsIssuerAudienceAndExpiry(t *testing.T) {
+	key := newTestRSAKey(t)
+	v := &TokenValidator{
+		GetKey: func(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+			if kid != "k1" {
+				return nil, errors.New("unexpected kid")
+			}
+			return &key.PublicKey, nil
+		},
+		Issuers:   []string{testIssuer},
+		Audiences: []string{"mciammanager"},
+		Leeway:    30 * time.Second,
+	}
+	validate := func(mutate func(jwt.MapClaims)) error {
+		claims := validTestClaims()
+		mutate(claims)
+		_, err := v.Validate(context.Background(), signTestToken(t, "k1", key, claims))
+		return err
+	}
+
+	assert.ErrorIs(t, validate(func(c jwt.MapClaims) { c["iss"] = "http://evil/realms/mciam" }), jwt.ErrTokenInvalidIssuer)
+	assert.ErrorIs(t, validate(func(c jwt.MapClaims) { c["azp"] = "other-client" }), jwt.ErrTokenInvalidAudience)
+	assert.NoError(t, validate(func(c jwt.MapClaims) { c["azp"] = "other-client"; c["aud"] = []string{"account", "mciammanager"} }))
+	assert.NoError(t, validate(func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-10 * time.Second).Unix() }), "시계 오차 이내 만료는 허용")
+	assert.ErrorIs(t, validate(func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() }), jwt.ErrTokenExpired)
+	assert.ErrorIs(t, validate(func(c jwt.MapClaims) { delete(c, "exp") }), jwt.ErrTokenRequiredClaimMissing)
+
+	v.Issuers, v.Audiences = []string{config.TokenValidationAny}, nil
+	assert.NoError(t, validate(func(c jwt.MapClaims) { c["iss"] = "http://other"; c["azp"] = "x" }))
+}